@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,8 +16,11 @@ import (
 	"github.com/pressly/goose/v3"
 	"go.uber.org/zap"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
 	"github.com/ctf01d/ctf01d-training-platform/internal/config"
+	"github.com/ctf01d/ctf01d-training-platform/internal/mail"
+	"github.com/ctf01d/ctf01d-training-platform/internal/ratelimit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
 	"github.com/ctf01d/ctf01d-training-platform/internal/server"
 	"github.com/ctf01d/ctf01d-training-platform/internal/server/handler"
@@ -93,31 +97,92 @@ func run() error {
 	}
 	defer store.Close()
 
-	fileStorage, err := storage.NewLocalStorage(cfg.Storage.Dir)
+	fileStorage, err := openStorage(ctx, cfg.Storage)
 	if err != nil {
 		return fmt.Errorf("creating file storage: %w", err)
 	}
 
 	jwtMgr := auth.NewManager(cfg.JWT.Secret, cfg.JWT.TTLHours)
+	jwtMgr.SetAccessTTL(time.Duration(cfg.JWT.AccessTTLMinutes) * time.Minute)
 	userService := usersvc.NewService(store.Queries)
+	userService.SetTxRunner(store)
 	authService := authsvc.NewService(store.Queries, store.Queries, jwtMgr, &auth.PasswordCheckerImpl{})
+	authService.SetTxRunner(store)
+	oidcService := authsvc.NewOIDCService(authService, store.Queries, identityProviders(cfg.OIDC))
+	oidcService.SetAutoProvision(cfg.OIDC.AutoProvision)
+	oidcService.SetTxRunner(store)
+	var totpBox authsvc.SecretSealer
+	if cfg.TwoFactor.SecretKey != "" {
+		box, err := auth.NewSecretBox(cfg.TwoFactor.SecretKey)
+		if err != nil {
+			return fmt.Errorf("creating two-factor cipher: %w", err)
+		}
+		totpBox = box
+	}
+	twoFactorService := authsvc.NewTwoFactorService(store.Queries, totpBox, cfg.TwoFactor.Issuer)
+	twoFactorService.SetTxRunner(store)
+	authService.SetTwoFactor(twoFactorService)
+	var limits ratelimit.Store
+	if cfg.RateLimit.Enabled {
+		limits = openRateLimitStore(cfg.RateLimit, store.Queries)
+		authService.SetLoginThrottle(ratelimit.NewLoginGuard(limits, ratelimit.LoginPolicy{
+			MaxUserFailures: cfg.RateLimit.LoginMaxUserFailures,
+			MaxIPFailures:   cfg.RateLimit.LoginMaxIPFailures,
+			Lockout:         time.Duration(cfg.RateLimit.LoginLockoutMinutes) * time.Minute,
+		}))
+	}
+	apiTokenService := authsvc.NewAPITokenService(store.Queries)
+	mailer, err := openMailer(cfg.Mail)
+	if err != nil {
+		return fmt.Errorf("creating mailer: %w", err)
+	}
+	emailService := authsvc.NewEmailService(store.Queries, mailer, cfg.Mail.LinkBaseURL)
+	emailService.SetTxRunner(store)
 	universityService := unisvc.NewService(store.Queries)
 	teamService := teamsvc.NewService(store, store, store, store)
 	membershipService := membersvc.NewService(store, store, store, store)
-	gameService := gamesvc.NewService(store, store, store, store, store)
+	gameService := gamesvc.NewService(store, store, store, store, store, store)
+	gameService.SetBlockPublishOnPortConflicts(cfg.Games.BlockPublishOnPortConflicts)
 	gameTeamService := gameteamsvc.NewService(store, store)
 	resultService := resultsvc.NewService(store.Queries, store.Queries)
+	resultService.SetTxRunner(store)
 	writeupService := writeupsvc.NewService(store.Queries, teamService)
 	scoreboardService := scoreboardsvc.NewService(store.Queries, store.Queries, store.Queries, store.Queries)
 	svcService := svcsvc.NewService(store.Queries)
 	svcArchives := svcsvc.NewArchiveService(store.Queries, fileStorage, cfg.Storage.MaxUploadBytes)
+	svcArchives.SetTxRunner(store)
 	svcChecker := svcsvc.NewCheckerService(store.Queries, fileStorage)
 	svcImport := svcsvc.NewImportService(store.Queries, fileStorage, cfg.Storage.MaxUploadBytes)
+	svcArchives.SetArchiveRetention(cfg.Storage.ArchiveRetention)
+	svcImport.SetArchiveRetention(cfg.Storage.ArchiveRetention)
+	svcImport.SetTxRunner(store)
+	var credentialBox svcsvc.SecretSealer
+	if cfg.Git.CredentialsKey != "" {
+		box, err := auth.NewSecretBox(cfg.Git.CredentialsKey)
+		if err != nil {
+			return fmt.Errorf("creating git credentials cipher: %w", err)
+		}
+		credentialBox = box
+	}
+	gitCredentials := svcsvc.NewGitCredentialService(store.Queries, credentialBox)
+	gitCredentials.SetTxRunner(store)
+	svcImport.SetGitCredentials(gitCredentials)
+	submissions := svcsvc.NewSubmissionService(store.Queries, svcImport)
+	submissions.SetTxRunner(store)
+	downloadLinks := svcsvc.NewDownloadLinkService(store.Queries, svcArchives, auth.NewURLSigner(cfg.Downloads.LinkSecret))
+	downloadLinks.SetTxRunner(store)
 	ctf01dBuilder := ctf01dsvc.NewBuilder(store.Queries)
-	ctf01dBuilder.SetStorageDir(cfg.Storage.Dir)
-	h := handler.New(userService, authService, jwtMgr, universityService, teamService, membershipService, gameService, gameTeamService, resultService, writeupService, scoreboardService, store.Queries, svcService, svcArchives, svcChecker, svcImport, ctf01dBuilder, cfg.Storage.MaxUploadBytes, cfg.Storage.Dir, fileStorage)
+	if cfg.Storage.Backend == config.StorageBackendLocal {
+		ctf01dBuilder.SetStorageDir(cfg.Storage.Dir)
+	} else {
+		ctf01dBuilder.SetStorage(fileStorage)
+	}
+	exports := svcsvc.NewExportStore(store.Queries, fileStorage)
+	exports.SetTxRunner(store)
+	auditLog := audit.NewService(store.Queries)
+	h := handler.New(userService, authService, oidcService, twoFactorService, apiTokenService, emailService, jwtMgr, universityService, teamService, membershipService, gameService, gameTeamService, resultService, writeupService, scoreboardService, store.Queries, svcService, svcArchives, svcChecker, svcImport, gitCredentials, submissions, downloadLinks, ctf01dBuilder, exports, auditLog, cfg.Storage.MaxUploadBytes, cfg.Storage.Dir, fileStorage)
 
-	engine := server.New(cfg, log, store, h)
+	engine := server.New(cfg, log, store, h, limits)
 
 	// Periodically purge expired sessions so user_sessions does not grow without
 	// bound. Tied to the process lifetime via context.
@@ -125,6 +190,15 @@ func run() error {
 	defer stopSessionCleanup()
 	go runSessionCleanup(sessionCleanupCtx, store.Queries, log)
 
+	// Collect unreferenced archive blobs and re-hash a batch of stored ones
+	// on the same schedule.
+	blobs := svcsvc.NewBlobService(store.Queries, fileStorage)
+	go runBlobMaintenance(sessionCleanupCtx, blobs, log)
+
+	if limits != nil {
+		go runRateLimitPrune(sessionCleanupCtx, limits, log)
+	}
+
 	srv := &http.Server{
 		Addr:              cfg.HTTP.Addr,
 		Handler:           engine,
@@ -188,3 +262,115 @@ func runSessionCleanup(ctx context.Context, store expiredSessionDeleter, log *za
 		}
 	}
 }
+
+const rateLimitPruneInterval = 10 * time.Minute
+
+// runRateLimitPrune drops rate limit state that no longer limits anyone on an
+// interval until ctx is canceled.
+func runRateLimitPrune(ctx context.Context, limits ratelimit.Store, log *zap.Logger) {
+	ticker := time.NewTicker(rateLimitPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := limits.Prune(ctx); err != nil && ctx.Err() == nil {
+				log.Warn("rate limit pruning failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+const (
+	blobMaintenanceInterval = time.Hour
+	blobScrubBatch          = 100
+)
+
+// runBlobMaintenance garbage collects unreferenced blobs and scrubs a batch of
+// stored ones on startup and then on an interval until ctx is canceled.
+func runBlobMaintenance(ctx context.Context, blobs *svcsvc.BlobService, log *zap.Logger) {
+	run := func() {
+		removed, err := blobs.CollectGarbage(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Warn("blob garbage collection failed", zap.Error(err))
+		}
+		result, err := blobs.Scrub(ctx, blobScrubBatch)
+		if err != nil && ctx.Err() == nil {
+			log.Warn("blob scrub failed", zap.Error(err))
+		}
+		if result.Corrupt > 0 {
+			log.Error("corrupt storage blobs found", zap.Int("corrupt", result.Corrupt))
+		}
+		if removed > 0 {
+			log.Info("removed unreferenced blobs", zap.Int("removed", removed))
+		}
+	}
+	run()
+
+	ticker := time.NewTicker(blobMaintenanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+// identityProviders builds the configured external sign-in providers. The
+// provider sends the browser back to the frontend callback page, which
+// completes the sign-in through the API.
+func identityProviders(cfg config.OIDCConfig) []auth.IdentityProvider {
+	base := strings.TrimRight(cfg.RedirectBaseURL, "/")
+	providers := make([]auth.IdentityProvider, 0, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		opts := auth.OAuthClientOptions{
+			Name:         p.Name,
+			DisplayName:  p.DisplayName,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  base + "/auth/oidc/" + p.Name + "/callback",
+			Scopes:       p.Scopes,
+		}
+		if p.Type == config.OIDCProviderTypeGitHub {
+			providers = append(providers, auth.NewGitHubProvider(opts))
+		} else {
+			providers = append(providers, auth.NewOIDCProvider(p.Issuer, opts))
+		}
+	}
+	return providers
+}
+
+func openStorage(ctx context.Context, cfg config.StorageConfig) (storage.Storage, error) {
+	if cfg.Backend == config.StorageBackendS3 {
+		return storage.NewS3Storage(ctx, storage.S3Options(cfg.S3))
+	}
+	return storage.NewLocalStorage(cfg.Dir)
+}
+
+func openRateLimitStore(cfg config.RateLimitConfig, queries ratelimit.PostgresQueries) ratelimit.Store {
+	if cfg.Backend == config.RateLimitBackendPostgres {
+		return ratelimit.NewPostgresStore(queries)
+	}
+	return ratelimit.NewMemoryStore()
+}
+
+func openMailer(cfg config.MailConfig) (mail.Mailer, error) {
+	switch cfg.Driver {
+	case config.MailDriverSMTP:
+		return mail.NewSMTPMailer(mail.SMTPOptions{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.From,
+		}), nil
+	case config.MailDriverFile:
+		return mail.NewFileMailer(cfg.Dir, cfg.From)
+	default:
+		return mail.LogMailer{}, nil
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/config"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
+	svcsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/services"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
+	"github.com/ctf01d/ctf01d-training-platform/pkg/logger"
+)
+
+const usage = `usage: storage-blobs [flags] <command>
+
+commands:
+  migrate-keys  move archives stored under per-version keys into blobs
+  gc            delete blobs unreferenced for longer than -grace
+  scrub         re-hash up to -limit blobs and flag corrupt ones
+  corrupt       list blobs flagged as corrupt`
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "storage-blobs error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run maintains the content-addressed archive blobs in the configured storage
+// backend. The server runs gc and scrub hourly; the command is for one-off
+// runs and for the migration of archives saved before blobs existed.
+func run() error {
+	grace := flag.Duration("grace", svcsvc.DefaultBlobGCGrace, "how long an unreferenced blob is kept")
+	limit := flag.Int("limit", 1000, "how many blobs scrub checks")
+	flag.Usage = func() { fmt.Fprintln(flag.CommandLine.Output(), usage); flag.PrintDefaults() }
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		return errors.New("expected one command")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	log, err := logger.New(cfg.Env, cfg.Log.Level)
+	if err != nil {
+		return fmt.Errorf("creating logger: %w", err)
+	}
+	defer logger.Sync(log)
+
+	ctx := context.Background()
+	store, err := repository.NewStore(ctx, cfg.DB.URL)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer store.Close()
+
+	fileStorage, err := openStorage(ctx, cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("opening storage: %w", err)
+	}
+
+	blobs := svcsvc.NewBlobService(store.Queries, fileStorage)
+	blobs.SetTxRunner(store)
+	blobs.SetGCGrace(*grace)
+
+	switch cmd := flag.Arg(0); cmd {
+	case "migrate-keys":
+		moved, err := blobs.MigrateLegacyKeys(ctx)
+		if err != nil {
+			return err
+		}
+		log.Info("legacy archive keys migrated", zap.Int("moved", moved))
+	case "gc":
+		total := 0
+		for {
+			removed, err := blobs.CollectGarbage(ctx)
+			if err != nil {
+				return err
+			}
+			total += removed
+			if removed == 0 {
+				break
+			}
+		}
+		log.Info("unreferenced blobs removed", zap.Int("removed", total))
+	case "scrub":
+		result, err := blobs.Scrub(ctx, *limit)
+		if err != nil {
+			return err
+		}
+		log.Info("blobs scrubbed", zap.Int("checked", result.Checked), zap.Int("corrupt", result.Corrupt))
+	case "corrupt":
+		corrupt, err := blobs.ListCorrupt(ctx)
+		if err != nil {
+			return err
+		}
+		for _, b := range corrupt {
+			fmt.Printf("%s\t%s\t%d bytes\t%d refs\tflagged %s\n",
+				b.SHA256, b.StorageKey, b.Size, b.RefCount, b.CorruptAt.Format(time.RFC3339))
+		}
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+	return nil
+}
+
+func openStorage(ctx context.Context, cfg config.StorageConfig) (storage.Storage, error) {
+	if cfg.Backend == config.StorageBackendS3 {
+		return storage.NewS3Storage(ctx, storage.S3Options(cfg.S3))
+	}
+	return storage.NewLocalStorage(cfg.Dir)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/config"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
+	"github.com/ctf01d/ctf01d-training-platform/pkg/logger"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "storage-migrate error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run copies every object of the local storage directory (STORAGE_DIR) to the
+// S3 bucket configured by the S3_* variables. Keys stay the same, so the
+// database needs no changes; objects already in the bucket with the same
+// SHA256 are skipped and the command can be re-run after a failure.
+func run() error {
+	dryRun := flag.Bool("dry-run", false, "list the objects that would be copied without uploading")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if cfg.Storage.S3.Endpoint == "" || cfg.Storage.S3.Bucket == "" {
+		return errors.New("S3_ENDPOINT and S3_BUCKET are required")
+	}
+
+	log, err := logger.New(cfg.Env, cfg.Log.Level)
+	if err != nil {
+		return fmt.Errorf("creating logger: %w", err)
+	}
+	defer logger.Sync(log)
+
+	ctx := context.Background()
+	src, err := storage.NewLocalStorage(cfg.Storage.Dir)
+	if err != nil {
+		return fmt.Errorf("opening local storage: %w", err)
+	}
+	dst, err := storage.NewS3Storage(ctx, storage.S3Options(cfg.Storage.S3))
+	if err != nil {
+		return fmt.Errorf("opening S3 storage: %w", err)
+	}
+
+	stats, err := migrate(ctx, cfg.Storage.Dir, src, dst, *dryRun, log)
+	if err != nil {
+		return err
+	}
+	log.Info("storage migration completed",
+		zap.Int("copied", stats.copied),
+		zap.Int("skipped", stats.skipped),
+		zap.Bool("dry_run", *dryRun))
+	return nil
+}
+
+type migrateStats struct {
+	copied  int
+	skipped int
+}
+
+// migrate copies the files under dir from src to dst.
+func migrate(ctx context.Context, dir string, src, dst storage.Storage, dryRun bool, log *zap.Logger) (migrateStats, error) {
+	var stats migrateStats
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Temp files are uploads LocalStorage has not finished writing.
+		if d.IsDir() || strings.HasSuffix(d.Name(), ".tmp") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		want, err := src.Stat(ctx, key)
+		if err != nil {
+			return fmt.Errorf("stating %s: %w", key, err)
+		}
+		have, err := dst.Stat(ctx, key)
+		switch {
+		case err == nil && have.SHA256 == want.SHA256:
+			stats.skipped++
+			return nil
+		case err != nil && !errors.Is(err, storage.ErrFileNotFound):
+			return fmt.Errorf("stating %s in destination: %w", key, err)
+		}
+
+		if dryRun {
+			log.Info("would copy", zap.String("key", key), zap.Int64("size", want.Size))
+			stats.copied++
+			return nil
+		}
+		if err := copyObject(ctx, key, want, src, dst); err != nil {
+			return err
+		}
+		log.Info("copied", zap.String("key", key), zap.Int64("size", want.Size))
+		stats.copied++
+		return nil
+	})
+	if err != nil {
+		return stats, fmt.Errorf("migrating storage: %w", err)
+	}
+	return stats, nil
+}
+
+func copyObject(ctx context.Context, key string, want storage.FileInfo, src, dst storage.Storage) error {
+	rc, err := src.Open(ctx, key)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", key, err)
+	}
+	defer rc.Close()
+	got, err := dst.Save(ctx, key, rc)
+	if err != nil {
+		return fmt.Errorf("copying %s: %w", key, err)
+	}
+	if got.SHA256 != want.SHA256 {
+		return fmt.Errorf("copying %s: checksum mismatch", key)
+	}
+	return nil
+}
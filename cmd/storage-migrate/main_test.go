@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
+)
+
+func TestMigrate_CopiesAndSkips(t *testing.T) {
+	ctx := context.Background()
+	srcDir := t.TempDir()
+	src, err := storage.NewLocalStorage(srcDir)
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	dst, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+
+	for key, content := range map[string]string{
+		"services/1/service.zip": "service",
+		"services/1/checker.zip": "checker",
+		"avatars/2.png":          "avatar",
+	} {
+		if _, err := src.Save(ctx, key, bytes.NewReader([]byte(content))); err != nil {
+			t.Fatalf("Save(%s): %v", key, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "services", "1", "upload.tmp"), []byte("partial"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := dst.Save(ctx, "avatars/2.png", bytes.NewReader([]byte("avatar"))); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	stats, err := migrate(ctx, srcDir, src, dst, true, zap.NewNop())
+	if err != nil {
+		t.Fatalf("migrate(dry run): %v", err)
+	}
+	if stats.copied != 2 || stats.skipped != 1 {
+		t.Errorf("dry run stats = %+v, want 2 copied, 1 skipped", stats)
+	}
+	if _, err := dst.Stat(ctx, "services/1/service.zip"); err == nil {
+		t.Error("dry run must not copy objects")
+	}
+
+	stats, err = migrate(ctx, srcDir, src, dst, false, zap.NewNop())
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if stats.copied != 2 || stats.skipped != 1 {
+		t.Errorf("stats = %+v, want 2 copied, 1 skipped", stats)
+	}
+	if _, err := dst.Stat(ctx, "services/1/upload.tmp"); err == nil {
+		t.Error("temp files must not be copied")
+	}
+
+	stats, err = migrate(ctx, srcDir, src, dst, false, zap.NewNop())
+	if err != nil {
+		t.Fatalf("migrate again: %v", err)
+	}
+	if stats.copied != 0 || stats.skipped != 3 {
+		t.Errorf("re-run stats = %+v, want everything skipped", stats)
+	}
+}
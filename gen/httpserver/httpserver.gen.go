@@ -17,6 +17,57 @@ const (
 	BearerAuthScopes bearerAuthContextKey = "BearerAuth.Scopes"
 )
 
+// Defines values for APITokenScope.
+const (
+	GamesExport  APITokenScope = "games:export"
+	ResultsRead  APITokenScope = "results:read"
+	ResultsWrite APITokenScope = "results:write"
+	ServicesSync APITokenScope = "services:sync"
+)
+
+// Valid indicates whether the value is a known member of the APITokenScope enum.
+func (e APITokenScope) Valid() bool {
+	switch e {
+	case GamesExport:
+		return true
+	case ResultsRead:
+		return true
+	case ResultsWrite:
+		return true
+	case ServicesSync:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for AuditEventEntityType.
+const (
+	AuditEventEntityTypeGame          AuditEventEntityType = "game"
+	AuditEventEntityTypeGitCredential AuditEventEntityType = "git_credential"
+	AuditEventEntityTypeResult        AuditEventEntityType = "result"
+	AuditEventEntityTypeService       AuditEventEntityType = "service"
+	AuditEventEntityTypeUser          AuditEventEntityType = "user"
+)
+
+// Valid indicates whether the value is a known member of the AuditEventEntityType enum.
+func (e AuditEventEntityType) Valid() bool {
+	switch e {
+	case AuditEventEntityTypeGame:
+		return true
+	case AuditEventEntityTypeGitCredential:
+		return true
+	case AuditEventEntityTypeResult:
+		return true
+	case AuditEventEntityTypeService:
+		return true
+	case AuditEventEntityTypeUser:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for GameRegistrationStatus.
 const (
 	GameRegistrationStatusClosed      GameRegistrationStatus = "closed"
@@ -89,6 +140,105 @@ func (e GameStatus) Valid() bool {
 	}
 }
 
+// Defines values for GameViewerGameRole.
+const (
+	GameViewerGameRoleJury      GameViewerGameRole = "jury"
+	GameViewerGameRoleObserver  GameViewerGameRole = "observer"
+	GameViewerGameRoleOrganizer GameViewerGameRole = "organizer"
+)
+
+// Valid indicates whether the value is a known member of the GameViewerGameRole enum.
+func (e GameViewerGameRole) Valid() bool {
+	switch e {
+	case GameViewerGameRoleJury:
+		return true
+	case GameViewerGameRoleObserver:
+		return true
+	case GameViewerGameRoleOrganizer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GameRoleRole.
+const (
+	GameRoleRoleJury      GameRoleRole = "jury"
+	GameRoleRoleObserver  GameRoleRole = "observer"
+	GameRoleRoleOrganizer GameRoleRole = "organizer"
+)
+
+// Valid indicates whether the value is a known member of the GameRoleRole enum.
+func (e GameRoleRole) Valid() bool {
+	switch e {
+	case GameRoleRoleJury:
+		return true
+	case GameRoleRoleObserver:
+		return true
+	case GameRoleRoleOrganizer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GameRoleSetRequestRole.
+const (
+	Jury      GameRoleSetRequestRole = "jury"
+	Observer  GameRoleSetRequestRole = "observer"
+	Organizer GameRoleSetRequestRole = "organizer"
+)
+
+// Valid indicates whether the value is a known member of the GameRoleSetRequestRole enum.
+func (e GameRoleSetRequestRole) Valid() bool {
+	switch e {
+	case Jury:
+		return true
+	case Observer:
+		return true
+	case Organizer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GitCredentialKind.
+const (
+	GitCredentialKindHttpsToken GitCredentialKind = "https_token"
+	GitCredentialKindSshKey     GitCredentialKind = "ssh_key"
+)
+
+// Valid indicates whether the value is a known member of the GitCredentialKind enum.
+func (e GitCredentialKind) Valid() bool {
+	switch e {
+	case GitCredentialKindHttpsToken:
+		return true
+	case GitCredentialKindSshKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GitCredentialCreateKind.
+const (
+	GitCredentialCreateKindHttpsToken GitCredentialCreateKind = "https_token"
+	GitCredentialCreateKindSshKey     GitCredentialCreateKind = "ssh_key"
+)
+
+// Valid indicates whether the value is a known member of the GitCredentialCreateKind enum.
+func (e GitCredentialCreateKind) Valid() bool {
+	switch e {
+	case GitCredentialCreateKindHttpsToken:
+		return true
+	case GitCredentialCreateKindSshKey:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for ScoreboardStatus.
 const (
 	ScoreboardStatusAlways   ScoreboardStatus = "always"
@@ -134,6 +284,63 @@ func (e ServiceCheckStatus) Valid() bool {
 	}
 }
 
+// Defines values for ServiceArchiveVersionKind.
+const (
+	ServiceArchiveVersionKindChecker ServiceArchiveVersionKind = "checker"
+	ServiceArchiveVersionKindService ServiceArchiveVersionKind = "service"
+)
+
+// Valid indicates whether the value is a known member of the ServiceArchiveVersionKind enum.
+func (e ServiceArchiveVersionKind) Valid() bool {
+	switch e {
+	case ServiceArchiveVersionKindChecker:
+		return true
+	case ServiceArchiveVersionKindService:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ServiceArchiveVersionSourceKind.
+const (
+	ServiceArchiveVersionSourceKindGit    ServiceArchiveVersionSourceKind = "git"
+	ServiceArchiveVersionSourceKindUpload ServiceArchiveVersionSourceKind = "upload"
+	ServiceArchiveVersionSourceKindUrl    ServiceArchiveVersionSourceKind = "url"
+)
+
+// Valid indicates whether the value is a known member of the ServiceArchiveVersionSourceKind enum.
+func (e ServiceArchiveVersionSourceKind) Valid() bool {
+	switch e {
+	case ServiceArchiveVersionSourceKindGit:
+		return true
+	case ServiceArchiveVersionSourceKindUpload:
+		return true
+	case ServiceArchiveVersionSourceKindUrl:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ServiceDownloadLinkCreateKind.
+const (
+	ServiceDownloadLinkCreateKindChecker ServiceDownloadLinkCreateKind = "checker"
+	ServiceDownloadLinkCreateKindService ServiceDownloadLinkCreateKind = "service"
+)
+
+// Valid indicates whether the value is a known member of the ServiceDownloadLinkCreateKind enum.
+func (e ServiceDownloadLinkCreateKind) Valid() bool {
+	switch e {
+	case ServiceDownloadLinkCreateKindChecker:
+		return true
+	case ServiceDownloadLinkCreateKindService:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for ServiceImportPreviewSource.
 const (
 	ServiceImportPreviewSourceGit ServiceImportPreviewSource = "git"
@@ -215,6 +422,111 @@ func (e ServiceSourceSyncStatus) Valid() bool {
 	}
 }
 
+// Defines values for ServiceSubmissionSourceKind.
+const (
+	ServiceSubmissionSourceKindGit ServiceSubmissionSourceKind = "git"
+	ServiceSubmissionSourceKindZip ServiceSubmissionSourceKind = "zip"
+)
+
+// Valid indicates whether the value is a known member of the ServiceSubmissionSourceKind enum.
+func (e ServiceSubmissionSourceKind) Valid() bool {
+	switch e {
+	case ServiceSubmissionSourceKindGit:
+		return true
+	case ServiceSubmissionSourceKindZip:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ServiceSubmissionStatus.
+const (
+	ServiceSubmissionStatusAccepted         ServiceSubmissionStatus = "accepted"
+	ServiceSubmissionStatusChangesRequested ServiceSubmissionStatus = "changes_requested"
+	ServiceSubmissionStatusRejected         ServiceSubmissionStatus = "rejected"
+	ServiceSubmissionStatusSubmitted        ServiceSubmissionStatus = "submitted"
+)
+
+// Valid indicates whether the value is a known member of the ServiceSubmissionStatus enum.
+func (e ServiceSubmissionStatus) Valid() bool {
+	switch e {
+	case ServiceSubmissionStatusAccepted:
+		return true
+	case ServiceSubmissionStatusChangesRequested:
+		return true
+	case ServiceSubmissionStatusRejected:
+		return true
+	case ServiceSubmissionStatusSubmitted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ServiceSubmissionReviewDecision.
+const (
+	ServiceSubmissionReviewDecisionAccepted         ServiceSubmissionReviewDecision = "accepted"
+	ServiceSubmissionReviewDecisionChangesRequested ServiceSubmissionReviewDecision = "changes_requested"
+	ServiceSubmissionReviewDecisionRejected         ServiceSubmissionReviewDecision = "rejected"
+)
+
+// Valid indicates whether the value is a known member of the ServiceSubmissionReviewDecision enum.
+func (e ServiceSubmissionReviewDecision) Valid() bool {
+	switch e {
+	case ServiceSubmissionReviewDecisionAccepted:
+		return true
+	case ServiceSubmissionReviewDecisionChangesRequested:
+		return true
+	case ServiceSubmissionReviewDecisionRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ServiceVulnDifficulty.
+const (
+	ServiceVulnDifficultyEasy   ServiceVulnDifficulty = "easy"
+	ServiceVulnDifficultyHard   ServiceVulnDifficulty = "hard"
+	ServiceVulnDifficultyMedium ServiceVulnDifficulty = "medium"
+)
+
+// Valid indicates whether the value is a known member of the ServiceVulnDifficulty enum.
+func (e ServiceVulnDifficulty) Valid() bool {
+	switch e {
+	case ServiceVulnDifficultyEasy:
+		return true
+	case ServiceVulnDifficultyHard:
+		return true
+	case ServiceVulnDifficultyMedium:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ServiceVulnInputDifficulty.
+const (
+	ServiceVulnInputDifficultyEasy   ServiceVulnInputDifficulty = "easy"
+	ServiceVulnInputDifficultyHard   ServiceVulnInputDifficulty = "hard"
+	ServiceVulnInputDifficultyMedium ServiceVulnInputDifficulty = "medium"
+)
+
+// Valid indicates whether the value is a known member of the ServiceVulnInputDifficulty enum.
+func (e ServiceVulnInputDifficulty) Valid() bool {
+	switch e {
+	case ServiceVulnInputDifficultyEasy:
+		return true
+	case ServiceVulnInputDifficultyHard:
+		return true
+	case ServiceVulnInputDifficultyMedium:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for SetRoleRequestRole.
 const (
 	SetRoleRequestRoleCaptain     SetRoleRequestRole = "captain"
@@ -367,19 +679,40 @@ func (e TeamMembershipUpdateRole) Valid() bool {
 
 // Defines values for TeamMembershipUpdateStatus.
 const (
-	Approved TeamMembershipUpdateStatus = "approved"
-	Pending  TeamMembershipUpdateStatus = "pending"
-	Rejected TeamMembershipUpdateStatus = "rejected"
+	TeamMembershipUpdateStatusApproved TeamMembershipUpdateStatus = "approved"
+	TeamMembershipUpdateStatusPending  TeamMembershipUpdateStatus = "pending"
+	TeamMembershipUpdateStatusRejected TeamMembershipUpdateStatus = "rejected"
 )
 
 // Valid indicates whether the value is a known member of the TeamMembershipUpdateStatus enum.
 func (e TeamMembershipUpdateStatus) Valid() bool {
 	switch e {
-	case Approved:
+	case TeamMembershipUpdateStatusApproved:
+		return true
+	case TeamMembershipUpdateStatusPending:
+		return true
+	case TeamMembershipUpdateStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for TwoFactorPolicyRequiredRoles.
+const (
+	TwoFactorPolicyRequiredRolesAdmin  TwoFactorPolicyRequiredRoles = "admin"
+	TwoFactorPolicyRequiredRolesGuest  TwoFactorPolicyRequiredRoles = "guest"
+	TwoFactorPolicyRequiredRolesPlayer TwoFactorPolicyRequiredRoles = "player"
+)
+
+// Valid indicates whether the value is a known member of the TwoFactorPolicyRequiredRoles enum.
+func (e TwoFactorPolicyRequiredRoles) Valid() bool {
+	switch e {
+	case TwoFactorPolicyRequiredRolesAdmin:
 		return true
-	case Pending:
+	case TwoFactorPolicyRequiredRolesGuest:
 		return true
-	case Rejected:
+	case TwoFactorPolicyRequiredRolesPlayer:
 		return true
 	default:
 		return false
@@ -514,19 +847,112 @@ func (e UserProfileUpdateTheme) Valid() bool {
 
 // Defines values for UserRoleUpdateRole.
 const (
-	Admin  UserRoleUpdateRole = "admin"
-	Guest  UserRoleUpdateRole = "guest"
-	Player UserRoleUpdateRole = "player"
+	UserRoleUpdateRoleAdmin  UserRoleUpdateRole = "admin"
+	UserRoleUpdateRoleGuest  UserRoleUpdateRole = "guest"
+	UserRoleUpdateRolePlayer UserRoleUpdateRole = "player"
 )
 
 // Valid indicates whether the value is a known member of the UserRoleUpdateRole enum.
 func (e UserRoleUpdateRole) Valid() bool {
 	switch e {
-	case Admin:
+	case UserRoleUpdateRoleAdmin:
+		return true
+	case UserRoleUpdateRoleGuest:
+		return true
+	case UserRoleUpdateRolePlayer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ListAuditEventsParamsEntityType.
+const (
+	ListAuditEventsParamsEntityTypeGame          ListAuditEventsParamsEntityType = "game"
+	ListAuditEventsParamsEntityTypeGitCredential ListAuditEventsParamsEntityType = "git_credential"
+	ListAuditEventsParamsEntityTypeResult        ListAuditEventsParamsEntityType = "result"
+	ListAuditEventsParamsEntityTypeService       ListAuditEventsParamsEntityType = "service"
+	ListAuditEventsParamsEntityTypeUser          ListAuditEventsParamsEntityType = "user"
+)
+
+// Valid indicates whether the value is a known member of the ListAuditEventsParamsEntityType enum.
+func (e ListAuditEventsParamsEntityType) Valid() bool {
+	switch e {
+	case ListAuditEventsParamsEntityTypeGame:
+		return true
+	case ListAuditEventsParamsEntityTypeGitCredential:
+		return true
+	case ListAuditEventsParamsEntityTypeResult:
+		return true
+	case ListAuditEventsParamsEntityTypeService:
+		return true
+	case ListAuditEventsParamsEntityTypeUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ListServiceSubmissionsParamsStatus.
+const (
+	ListServiceSubmissionsParamsStatusAccepted         ListServiceSubmissionsParamsStatus = "accepted"
+	ListServiceSubmissionsParamsStatusChangesRequested ListServiceSubmissionsParamsStatus = "changes_requested"
+	ListServiceSubmissionsParamsStatusRejected         ListServiceSubmissionsParamsStatus = "rejected"
+	ListServiceSubmissionsParamsStatusSubmitted        ListServiceSubmissionsParamsStatus = "submitted"
+)
+
+// Valid indicates whether the value is a known member of the ListServiceSubmissionsParamsStatus enum.
+func (e ListServiceSubmissionsParamsStatus) Valid() bool {
+	switch e {
+	case ListServiceSubmissionsParamsStatusAccepted:
+		return true
+	case ListServiceSubmissionsParamsStatusChangesRequested:
+		return true
+	case ListServiceSubmissionsParamsStatusRejected:
+		return true
+	case ListServiceSubmissionsParamsStatusSubmitted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SearchServicesParamsGamesUsage.
+const (
+	N0  SearchServicesParamsGamesUsage = "0"
+	N1  SearchServicesParamsGamesUsage = "1"
+	N24 SearchServicesParamsGamesUsage = "2-4"
+	N5  SearchServicesParamsGamesUsage = "5+"
+)
+
+// Valid indicates whether the value is a known member of the SearchServicesParamsGamesUsage enum.
+func (e SearchServicesParamsGamesUsage) Valid() bool {
+	switch e {
+	case N0:
+		return true
+	case N1:
+		return true
+	case N24:
 		return true
-	case Guest:
+	case N5:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ListServiceArchiveVersionsParamsKind.
+const (
+	ListServiceArchiveVersionsParamsKindChecker ListServiceArchiveVersionsParamsKind = "checker"
+	ListServiceArchiveVersionsParamsKindService ListServiceArchiveVersionsParamsKind = "service"
+)
+
+// Valid indicates whether the value is a known member of the ListServiceArchiveVersionsParamsKind enum.
+func (e ListServiceArchiveVersionsParamsKind) Valid() bool {
+	switch e {
+	case ListServiceArchiveVersionsParamsKindChecker:
 		return true
-	case Player:
+	case ListServiceArchiveVersionsParamsKindService:
 		return true
 	default:
 		return false
@@ -551,11 +977,100 @@ func (e DownloadServiceArchiveParamsKind) Valid() bool {
 	}
 }
 
-// Ctf01dExportError defines model for Ctf01dExportError.
-type Ctf01dExportError struct {
-	Code    string   `json:"code"`
-	Errors  []string `json:"errors"`
-	Message *string  `json:"message,omitempty"`
+// Defines values for DownloadServiceArchiveSignedParamsKind.
+const (
+	DownloadServiceArchiveSignedParamsKindChecker DownloadServiceArchiveSignedParamsKind = "checker"
+	DownloadServiceArchiveSignedParamsKindService DownloadServiceArchiveSignedParamsKind = "service"
+)
+
+// Valid indicates whether the value is a known member of the DownloadServiceArchiveSignedParamsKind enum.
+func (e DownloadServiceArchiveSignedParamsKind) Valid() bool {
+	switch e {
+	case DownloadServiceArchiveSignedParamsKindChecker:
+		return true
+	case DownloadServiceArchiveSignedParamsKindService:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIToken defines model for APIToken.
+type APIToken struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Hint Leading characters of the token, to recognize it
+	Hint       string          `json:"hint"`
+	Id         int64           `json:"id"`
+	LastUsedAt *time.Time      `json:"last_used_at,omitempty"`
+	LastUsedIp *string         `json:"last_used_ip,omitempty"`
+	Name       string          `json:"name"`
+	Scopes     []APITokenScope `json:"scopes"`
+}
+
+// APITokenCreate defines model for APITokenCreate.
+type APITokenCreate struct {
+	// ExpiresAt The token lasts until revoked when omitted
+	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
+	Name      string          `json:"name"`
+	Scopes    []APITokenScope `json:"scopes"`
+}
+
+// APITokenCreated defines model for APITokenCreated.
+type APITokenCreated struct {
+	ApiToken APIToken `json:"api_token"`
+
+	// Token The token itself; it is shown only once
+	Token string `json:"token"`
+}
+
+// APITokenList defines model for APITokenList.
+type APITokenList struct {
+	Items []APIToken `json:"items"`
+}
+
+// APITokenScope defines model for APITokenScope.
+type APITokenScope string
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	// Action For example finalize, unfinalize, export, create, update, delete, change_role, block, unblock, revoke_session, revoke_sessions, reset_two_factor, set_game_role, remove_game_role, upload_archives, redownload, git_sync, git_sync_failed, create_download_link
+	Action string `json:"action"`
+
+	// ActorId Empty for actions taken outside a request or by a deleted user
+	ActorId   *int64  `json:"actor_id,omitempty"`
+	ActorName *string `json:"actor_name,omitempty"`
+
+	// After Fields the action changed, as they became; empty when the entity is gone
+	After *map[string]interface{} `json:"after,omitempty"`
+
+	// Before Fields the action changed, as they were; empty when the entity did not exist
+	Before     *map[string]interface{} `json:"before,omitempty"`
+	CreatedAt  time.Time               `json:"created_at"`
+	EntityId   string                  `json:"entity_id"`
+	EntityType AuditEventEntityType    `json:"entity_type"`
+	Id         int64                   `json:"id"`
+	IpAddress  *string                 `json:"ip_address,omitempty"`
+
+	// RequestId X-Request-ID of the request that took the action
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// AuditEventEntityType defines model for AuditEvent.EntityType.
+type AuditEventEntityType string
+
+// AuditEventList defines model for AuditEventList.
+type AuditEventList struct {
+	Items      []AuditEvent `json:"items"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// Ctf01dExportError defines model for Ctf01dExportError.
+type Ctf01dExportError struct {
+	Code    string   `json:"code"`
+	Errors  []string `json:"errors"`
+	Message *string  `json:"message,omitempty"`
 }
 
 // Ctf01dExportOptions defines model for Ctf01dExportOptions.
@@ -589,6 +1104,11 @@ type Ctf01dExportRequest struct {
 	Random           *bool      `json:"random,omitempty"`
 }
 
+// EmailVerificationConfirm defines model for EmailVerificationConfirm.
+type EmailVerificationConfirm struct {
+	Token string `json:"token"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    string                  `json:"code"`
@@ -622,8 +1142,11 @@ type Game struct {
 	Status               *GameStatus             `json:"status,omitempty"`
 	Theme                *string                 `json:"theme,omitempty"`
 	UpdatedAt            *time.Time              `json:"updated_at,omitempty"`
-	VpnConfigUrl         *string                 `json:"vpn_config_url,omitempty"`
-	VpnUrl               *string                 `json:"vpn_url,omitempty"`
+
+	// ViewerGameRole Role of the signed-in viewer in this game; returned by the single-game endpoints
+	ViewerGameRole *GameViewerGameRole `json:"viewer_game_role,omitempty"`
+	VpnConfigUrl   *string             `json:"vpn_config_url,omitempty"`
+	VpnUrl         *string             `json:"vpn_url,omitempty"`
 }
 
 // GameRegistrationStatus defines model for Game.RegistrationStatus.
@@ -635,6 +1158,9 @@ type GameScoreboardStatus string
 // GameStatus defines model for Game.Status.
 type GameStatus string
 
+// GameViewerGameRole Role of the signed-in viewer in this game; returned by the single-game endpoints
+type GameViewerGameRole string
+
 // GameCreate defines model for GameCreate.
 type GameCreate struct {
 	AccessInstructions   *string    `json:"access_instructions,omitempty"`
@@ -663,10 +1189,63 @@ type GameList struct {
 	Pagination Pagination `json:"pagination"`
 }
 
+// GamePortConflict defines model for GamePortConflict.
+type GamePortConflict struct {
+	Port        int32                `json:"port"`
+	Services    []GamePortService    `json:"services"`
+	Suggestions []GamePortSuggestion `json:"suggestions"`
+}
+
+// GamePortService defines model for GamePortService.
+type GamePortService struct {
+	Name      string  `json:"name"`
+	Ports     []int32 `json:"ports"`
+	ServiceId int64   `json:"service_id"`
+}
+
+// GamePortSuggestion defines model for GamePortSuggestion.
+type GamePortSuggestion struct {
+	Port      int32 `json:"port"`
+	ServiceId int64 `json:"service_id"`
+}
+
+// GameRole defines model for GameRole.
+type GameRole struct {
+	AvatarUrl   *string      `json:"avatar_url,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	DisplayName string       `json:"display_name"`
+	Role        GameRoleRole `json:"role"`
+	UserId      int64        `json:"user_id"`
+	UserName    string       `json:"user_name"`
+}
+
+// GameRoleRole defines model for GameRole.Role.
+type GameRoleRole string
+
+// GameRoleList defines model for GameRoleList.
+type GameRoleList struct {
+	Items []GameRole `json:"items"`
+}
+
+// GameRoleSetRequest defines model for GameRoleSetRequest.
+type GameRoleSetRequest struct {
+	Role GameRoleSetRequestRole `json:"role"`
+}
+
+// GameRoleSetRequestRole defines model for GameRoleSetRequest.Role.
+type GameRoleSetRequestRole string
+
 // GameServiceLink defines model for GameServiceLink.
 type GameServiceLink struct {
-	ServiceId int64  `json:"service_id"`
-	Status    string `json:"status"`
+	PinnedCheckerVersionId *int64 `json:"pinned_checker_version_id,omitempty"`
+
+	// PinnedGitCommit Git commit the pinned archive was built from
+	PinnedGitCommit *string `json:"pinned_git_commit,omitempty"`
+
+	// PinnedServiceVersionId Service archive version the game is pinned to; null follows the current archive
+	PinnedServiceVersionId *int64 `json:"pinned_service_version_id,omitempty"`
+	ServiceId              int64  `json:"service_id"`
+	Status                 string `json:"status"`
 }
 
 // GameTeam defines model for GameTeam.
@@ -729,18 +1308,87 @@ type GameUpdate struct {
 	VpnUrl               *string    `json:"vpn_url,omitempty"`
 }
 
+// GitBatchImportRequest defines model for GitBatchImportRequest.
+type GitBatchImportRequest struct {
+	CredentialId *int64  `json:"credential_id,omitempty"`
+	Ref          *string `json:"ref,omitempty"`
+	RepoUrl      string  `json:"repo_url"`
+
+	// Subdirs Service directories returned by discovery
+	Subdirs []string `json:"subdirs"`
+}
+
+// GitBatchImportResult defines model for GitBatchImportResult.
+type GitBatchImportResult struct {
+	Items []ImportResult `json:"items"`
+}
+
+// GitCredential defines model for GitCredential.
+type GitCredential struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// HostPattern Glob matched against the repository host, or host/owner/repo when it contains a slash
+	HostPattern *string           `json:"host_pattern,omitempty"`
+	Id          int64             `json:"id"`
+	Kind        GitCredentialKind `json:"kind"`
+	Name        string            `json:"name"`
+	UpdatedAt   *time.Time        `json:"updated_at,omitempty"`
+	Username    *string           `json:"username,omitempty"`
+}
+
+// GitCredentialKind defines model for GitCredential.Kind.
+type GitCredentialKind string
+
+// GitCredentialCreate defines model for GitCredentialCreate.
+type GitCredentialCreate struct {
+	HostPattern *string                 `json:"host_pattern,omitempty"`
+	Kind        GitCredentialCreateKind `json:"kind"`
+	Name        string                  `json:"name"`
+
+	// Secret HTTPS access token or SSH private key; stored encrypted and never returned
+	Secret   string  `json:"secret"`
+	Username *string `json:"username,omitempty"`
+}
+
+// GitCredentialCreateKind defines model for GitCredentialCreate.Kind.
+type GitCredentialCreateKind string
+
+// GitCredentialList defines model for GitCredentialList.
+type GitCredentialList struct {
+	Items []GitCredential `json:"items"`
+}
+
+// GitCredentialUpdate defines model for GitCredentialUpdate.
+type GitCredentialUpdate struct {
+	// HostPattern Empty string detaches the credential from host matching
+	HostPattern *string `json:"host_pattern,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Secret      *string `json:"secret,omitempty"`
+	Username    *string `json:"username,omitempty"`
+}
+
+// GitImportDiscovery defines model for GitImportDiscovery.
+type GitImportDiscovery struct {
+	Commit   string                 `json:"commit"`
+	Services []ServiceImportPreview `json:"services"`
+}
+
 // GitImportRequest defines model for GitImportRequest.
 type GitImportRequest struct {
-	Ref     *string `json:"ref,omitempty"`
-	RepoUrl string  `json:"repo_url"`
-	Subdir  *string `json:"subdir,omitempty"`
+	// CredentialId Git credential used for private repositories; host pattern matching applies when omitted
+	CredentialId *int64  `json:"credential_id,omitempty"`
+	Ref          *string `json:"ref,omitempty"`
+	RepoUrl      string  `json:"repo_url"`
+	Subdir       *string `json:"subdir,omitempty"`
 }
 
 // GitSourceInput defines model for GitSourceInput.
 type GitSourceInput struct {
-	Ref     *string `json:"ref,omitempty"`
-	RepoUrl *string `json:"repo_url,omitempty"`
-	Subdir  *string `json:"subdir,omitempty"`
+	// CredentialId Git credential used for private repositories; host pattern matching applies when none is set. On update an omitted value keeps the current credential and 0 clears it
+	CredentialId *int64  `json:"credential_id,omitempty"`
+	Ref          *string `json:"ref,omitempty"`
+	RepoUrl      *string `json:"repo_url,omitempty"`
+	Subdir       *string `json:"subdir,omitempty"`
 }
 
 // GlobalScoreboard defines model for GlobalScoreboard.
@@ -771,10 +1419,60 @@ type LoginRequest struct {
 
 // LoginResponse defines model for LoginResponse.
 type LoginResponse struct {
+	// ExpiresAt When the access token expires
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// RecoveryCodes New recovery codes; only present when two-factor enrollment was completed during sign-in
+	RecoveryCodes *[]string `json:"recovery_codes,omitempty"`
+
+	// RefreshToken Single-use token for /session/refresh, valid while the session lasts
+	RefreshToken string `json:"refresh_token"`
+
+	// Token Short-lived access token
 	Token string `json:"token"`
 	User  User   `json:"user"`
 }
 
+// OIDCAuthorization defines model for OIDCAuthorization.
+type OIDCAuthorization struct {
+	// AuthorizationUrl Provider URL to send the browser to
+	AuthorizationUrl string `json:"authorization_url"`
+
+	// State Keep it and check it against the state of the callback
+	State string `json:"state"`
+}
+
+// OIDCCallbackRequest defines model for OIDCCallbackRequest.
+type OIDCCallbackRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// OIDCCallbackResponse defines model for OIDCCallbackResponse.
+type OIDCCallbackResponse struct {
+	// ExpiresAt When the access token expires; absent when the callback linked an identity
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Linked    bool       `json:"linked"`
+
+	// RefreshToken Absent when the callback linked an identity
+	RefreshToken *string `json:"refresh_token,omitempty"`
+
+	// Token Session token; absent when the callback linked an identity
+	Token *string `json:"token,omitempty"`
+	User  User    `json:"user"`
+}
+
+// OIDCProvider defines model for OIDCProvider.
+type OIDCProvider struct {
+	DisplayName string `json:"display_name"`
+	Name        string `json:"name"`
+}
+
+// OIDCProviderList defines model for OIDCProviderList.
+type OIDCProviderList struct {
+	Items []OIDCProvider `json:"items"`
+}
+
 // Pagination defines model for Pagination.
 type Pagination struct {
 	Page    int `json:"page"`
@@ -782,11 +1480,40 @@ type Pagination struct {
 	Total   int `json:"total"`
 }
 
+// PasswordResetConfirm defines model for PasswordResetConfirm.
+type PasswordResetConfirm struct {
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	// Login User name or email address
+	Login string `json:"login"`
+}
+
 // PasswordUpdate defines model for PasswordUpdate.
 type PasswordUpdate struct {
 	Password string `json:"password"`
 }
 
+// RefreshRequest defines model for RefreshRequest.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshResponse defines model for RefreshResponse.
+type RefreshResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// RefreshToken Replaces the refresh token that was sent, which stops working
+	RefreshToken string `json:"refresh_token"`
+
+	// SessionExpiresAt Refreshing is possible until then; sign in again afterwards
+	SessionExpiresAt time.Time `json:"session_expires_at"`
+	Token            string    `json:"token"`
+}
+
 // ReorderRequest defines model for ReorderRequest.
 type ReorderRequest struct {
 	Items []struct {
@@ -842,28 +1569,31 @@ type ScoreboardEntry struct {
 
 // Service defines model for Service.
 type Service struct {
-	Author             *string                 `json:"author,omitempty"`
-	AvatarUrl          *string                 `json:"avatar_url,omitempty"`
-	CheckStatus        ServiceCheckStatus      `json:"check_status"`
-	CheckedAt          *time.Time              `json:"checked_at,omitempty"`
-	CheckerArchive     *ServiceArchiveMeta     `json:"checker_archive,omitempty"`
-	CheckerArchiveUrl  *string                 `json:"checker_archive_url,omitempty"`
-	Copyright          *string                 `json:"copyright,omitempty"`
-	CreatedAt          *time.Time              `json:"created_at,omitempty"`
-	Ctf01dTraining     *map[string]interface{} `json:"ctf01d_training"`
-	ExploitsUrl        *string                 `json:"exploits_url,omitempty"`
-	Id                 int64                   `json:"id"`
-	Name               string                  `json:"name"`
-	Ports              []int32                 `json:"ports"`
-	PrivateDescription *string                 `json:"private_description,omitempty"`
-	Public             bool                    `json:"public"`
-	PublicDescription  *string                 `json:"public_description,omitempty"`
-	ServiceArchive     *ServiceArchiveMeta     `json:"service_archive,omitempty"`
-	ServiceArchiveUrl  *string                 `json:"service_archive_url,omitempty"`
-	Source             *ServiceSource          `json:"source,omitempty"`
-	TechStack          []string                `json:"tech_stack"`
-	UpdatedAt          *time.Time              `json:"updated_at,omitempty"`
-	WriteupUrl         *string                 `json:"writeup_url,omitempty"`
+	Author            *string                 `json:"author,omitempty"`
+	AvatarUrl         *string                 `json:"avatar_url,omitempty"`
+	CheckStatus       ServiceCheckStatus      `json:"check_status"`
+	CheckedAt         *time.Time              `json:"checked_at,omitempty"`
+	CheckerArchive    *ServiceArchiveMeta     `json:"checker_archive,omitempty"`
+	CheckerArchiveUrl *string                 `json:"checker_archive_url,omitempty"`
+	Copyright         *string                 `json:"copyright,omitempty"`
+	CreatedAt         *time.Time              `json:"created_at,omitempty"`
+	Ctf01dTraining    *map[string]interface{} `json:"ctf01d_training"`
+	ExploitsUrl       *string                 `json:"exploits_url,omitempty"`
+	Id                int64                   `json:"id"`
+
+	// License SPDX identifier detected from the LICENSE file at import
+	License            *string             `json:"license,omitempty"`
+	Name               string              `json:"name"`
+	Ports              []int32             `json:"ports"`
+	PrivateDescription *string             `json:"private_description,omitempty"`
+	Public             bool                `json:"public"`
+	PublicDescription  *string             `json:"public_description,omitempty"`
+	ServiceArchive     *ServiceArchiveMeta `json:"service_archive,omitempty"`
+	ServiceArchiveUrl  *string             `json:"service_archive_url,omitempty"`
+	Source             *ServiceSource      `json:"source,omitempty"`
+	TechStack          []string            `json:"tech_stack"`
+	UpdatedAt          *time.Time          `json:"updated_at,omitempty"`
+	WriteupUrl         *string             `json:"writeup_url,omitempty"`
 }
 
 // ServiceCheckStatus defines model for Service.CheckStatus.
@@ -876,6 +1606,66 @@ type ServiceArchiveMeta struct {
 	Size         *int64     `json:"size,omitempty"`
 }
 
+// ServiceArchiveVersion defines model for ServiceArchiveVersion.
+type ServiceArchiveVersion struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Current Whether this version is the active archive of the service
+	Current bool                      `json:"current"`
+	Id      int64                     `json:"id"`
+	Kind    ServiceArchiveVersionKind `json:"kind"`
+
+	// Pinned Whether a game is pinned to this version; pinned versions are never pruned
+	Pinned     bool                            `json:"pinned"`
+	ServiceId  int64                           `json:"service_id"`
+	Sha256     string                          `json:"sha256"`
+	Size       int64                           `json:"size"`
+	SourceKind ServiceArchiveVersionSourceKind `json:"source_kind"`
+
+	// SourceRef Download URL or git commit the archive was built from
+	SourceRef *string `json:"source_ref,omitempty"`
+}
+
+// ServiceArchiveVersionKind defines model for ServiceArchiveVersion.Kind.
+type ServiceArchiveVersionKind string
+
+// ServiceArchiveVersionSourceKind defines model for ServiceArchiveVersion.SourceKind.
+type ServiceArchiveVersionSourceKind string
+
+// ServiceArchiveVersionDiff defines model for ServiceArchiveVersionDiff.
+type ServiceArchiveVersionDiff struct {
+	Added     []string              `json:"added"`
+	From      ServiceArchiveVersion `json:"from"`
+	Modified  []string              `json:"modified"`
+	Removed   []string              `json:"removed"`
+	To        ServiceArchiveVersion `json:"to"`
+	Unchanged int                   `json:"unchanged"`
+}
+
+// ServiceArchiveVersionList defines model for ServiceArchiveVersionList.
+type ServiceArchiveVersionList struct {
+	Items []ServiceArchiveVersion `json:"items"`
+}
+
+// ServiceAuthor defines model for ServiceAuthor.
+type ServiceAuthor struct {
+	AvatarUrl   *string   `json:"avatar_url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	DisplayName string    `json:"display_name"`
+	UserId      int64     `json:"user_id"`
+	UserName    string    `json:"user_name"`
+}
+
+// ServiceAuthorAddRequest defines model for ServiceAuthorAddRequest.
+type ServiceAuthorAddRequest struct {
+	UserId int64 `json:"user_id"`
+}
+
+// ServiceAuthorList defines model for ServiceAuthorList.
+type ServiceAuthorList struct {
+	Items []ServiceAuthor `json:"items"`
+}
+
 // ServiceCreate defines model for ServiceCreate.
 type ServiceCreate struct {
 	Author             *string                 `json:"author,omitempty"`
@@ -895,6 +1685,51 @@ type ServiceCreate struct {
 	WriteupUrl         *string                 `json:"writeup_url,omitempty"`
 }
 
+// ServiceDownloadLink defines model for ServiceDownloadLink.
+type ServiceDownloadLink struct {
+	CreatedAt     time.Time `json:"created_at"`
+	DownloadCount int       `json:"download_count"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	Id            int64     `json:"id"`
+	Kind          string    `json:"kind"`
+	MaxDownloads  *int      `json:"max_downloads,omitempty"`
+	ServiceId     int64     `json:"service_id"`
+
+	// Url Path of the signed download, relative to the API host
+	Url string `json:"url"`
+}
+
+// ServiceDownloadLinkCreate defines model for ServiceDownloadLinkCreate.
+type ServiceDownloadLinkCreate struct {
+	// ExpiresInSeconds Link lifetime, 24 hours by default and at most 7 days
+	ExpiresInSeconds *int                          `json:"expires_in_seconds,omitempty"`
+	Kind             ServiceDownloadLinkCreateKind `json:"kind"`
+
+	// MaxDownloads How many times the link can be used; unlimited when omitted
+	MaxDownloads *int `json:"max_downloads,omitempty"`
+}
+
+// ServiceDownloadLinkCreateKind defines model for ServiceDownloadLinkCreate.Kind.
+type ServiceDownloadLinkCreateKind string
+
+// ServiceFacetValue defines model for ServiceFacetValue.
+type ServiceFacetValue struct {
+	Count int    `json:"count"`
+	Value string `json:"value"`
+}
+
+// ServiceGamePin defines model for ServiceGamePin.
+type ServiceGamePin struct {
+	// FollowsCurrent The game is not pinned and uses the current archive of the service
+	FollowsCurrent         bool    `json:"follows_current"`
+	GameFinalized          bool    `json:"game_finalized"`
+	GameId                 int64   `json:"game_id"`
+	GameName               *string `json:"game_name,omitempty"`
+	PinnedCheckerVersionId *int64  `json:"pinned_checker_version_id,omitempty"`
+	PinnedServiceVersionId *int64  `json:"pinned_service_version_id,omitempty"`
+	Status                 string  `json:"status"`
+}
+
 // ServiceImportPreview defines model for ServiceImportPreview.
 type ServiceImportPreview struct {
 	CheckerDirectory       *string                       `json:"checker_directory,omitempty"`
@@ -908,8 +1743,11 @@ type ServiceImportPreview struct {
 	ServiceDirectory       *string                       `json:"service_directory,omitempty"`
 	ServiceName            string                        `json:"service_name"`
 	Source                 ServiceImportPreviewSource    `json:"source"`
-	Valid                  bool                          `json:"valid"`
-	Warnings               []string                      `json:"warnings"`
+
+	// Subdir Repository directory the service was imported from
+	Subdir   *string  `json:"subdir,omitempty"`
+	Valid    bool     `json:"valid"`
+	Warnings []string `json:"warnings"`
 }
 
 // ServiceImportPreviewSource defines model for ServiceImportPreview.Source.
@@ -932,16 +1770,91 @@ type ServiceList struct {
 	Pagination Pagination `json:"pagination"`
 }
 
+// ServiceManifestDiagnostic defines model for ServiceManifestDiagnostic.
+type ServiceManifestDiagnostic struct {
+	// Column 1-based YAML column, 0 when unknown
+	Column int `json:"column"`
+
+	// Line 1-based YAML line, 0 when unknown
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+
+	// Path JSON pointer to the offending value; empty for document-level problems
+	Path string `json:"path"`
+}
+
+// ServiceManifestValidateRequest defines model for ServiceManifestValidateRequest.
+type ServiceManifestValidateRequest struct {
+	// Content Contents of .ctf01d-service.yml
+	Content string `json:"content"`
+}
+
+// ServiceManifestValidation defines model for ServiceManifestValidation.
+type ServiceManifestValidation struct {
+	Diagnostics   []ServiceManifestDiagnostic `json:"diagnostics"`
+	SchemaVersion int                         `json:"schema_version"`
+	Valid         bool                        `json:"valid"`
+}
+
+// ServiceReadme defines model for ServiceReadme.
+type ServiceReadme struct {
+	// Html Sanitized HTML rendering; relative images point to the readme asset endpoint
+	Html      string `json:"html"`
+	Markdown  string `json:"markdown"`
+	ServiceId int64  `json:"service_id"`
+
+	// VersionId Archive version the README was read from; null for a README recorded only at import
+	VersionId *int64 `json:"version_id,omitempty"`
+}
+
+// ServiceSearchFacets Each facet counts the services matching the query and the other facets' filters
+type ServiceSearchFacets struct {
+	CheckStatus []ServiceFacetValue `json:"check_status"`
+
+	// GamesUsage How many games use the service, bucketed as 0, 1, 2-4 and 5+
+	GamesUsage []ServiceFacetValue `json:"games_usage"`
+
+	// License Detected license; "none" when it was missing or not recognized
+	License   []ServiceFacetValue `json:"license"`
+	TechStack []ServiceFacetValue `json:"tech_stack"`
+}
+
+// ServiceSearchHit defines model for ServiceSearchHit.
+type ServiceSearchHit struct {
+	GamesCount int     `json:"games_count"`
+	Rank       float32 `json:"rank"`
+	Service    Service `json:"service"`
+
+	// Snippet Highlighted fragments of the descriptions and README; empty without a text query
+	Snippet []ServiceSnippetPart `json:"snippet"`
+}
+
+// ServiceSearchResult defines model for ServiceSearchResult.
+type ServiceSearchResult struct {
+	// Facets Each facet counts the services matching the query and the other facets' filters
+	Facets     ServiceSearchFacets `json:"facets"`
+	Items      []ServiceSearchHit  `json:"items"`
+	Pagination Pagination          `json:"pagination"`
+}
+
+// ServiceSnippetPart defines model for ServiceSnippetPart.
+type ServiceSnippetPart struct {
+	// Match The text is a term of the query
+	Match bool   `json:"match"`
+	Text  string `json:"text"`
+}
+
 // ServiceSource defines model for ServiceSource.
 type ServiceSource struct {
-	Kind       ServiceSourceKind       `json:"kind"`
-	LastCommit *string                 `json:"last_commit,omitempty"`
-	Ref        *string                 `json:"ref,omitempty"`
-	RepoUrl    *string                 `json:"repo_url,omitempty"`
-	Subdir     *string                 `json:"subdir,omitempty"`
-	SyncError  *string                 `json:"sync_error,omitempty"`
-	SyncStatus ServiceSourceSyncStatus `json:"sync_status"`
-	SyncedAt   *time.Time              `json:"synced_at,omitempty"`
+	CredentialId *int64                  `json:"credential_id,omitempty"`
+	Kind         ServiceSourceKind       `json:"kind"`
+	LastCommit   *string                 `json:"last_commit,omitempty"`
+	Ref          *string                 `json:"ref,omitempty"`
+	RepoUrl      *string                 `json:"repo_url,omitempty"`
+	Subdir       *string                 `json:"subdir,omitempty"`
+	SyncError    *string                 `json:"sync_error,omitempty"`
+	SyncStatus   ServiceSourceSyncStatus `json:"sync_status"`
+	SyncedAt     *time.Time              `json:"synced_at,omitempty"`
 }
 
 // ServiceSourceKind defines model for ServiceSource.Kind.
@@ -950,6 +1863,83 @@ type ServiceSourceKind string
 // ServiceSourceSyncStatus defines model for ServiceSource.SyncStatus.
 type ServiceSourceSyncStatus string
 
+// ServiceSubmission defines model for ServiceSubmission.
+type ServiceSubmission struct {
+	// Commit Commit a git submission was previewed at; accepting imports this commit
+	Commit     *string               `json:"commit,omitempty"`
+	CreatedAt  *time.Time            `json:"created_at,omitempty"`
+	Id         int64                 `json:"id"`
+	Preview    *ServiceImportPreview `json:"preview,omitempty"`
+	Ref        *string               `json:"ref,omitempty"`
+	RepoUrl    *string               `json:"repo_url,omitempty"`
+	ReviewedAt *time.Time            `json:"reviewed_at,omitempty"`
+	ReviewerId *int64                `json:"reviewer_id,omitempty"`
+
+	// ServiceId Service created when the submission was accepted
+	ServiceId   *int64                      `json:"service_id,omitempty"`
+	ServiceName string                      `json:"service_name"`
+	SourceKind  ServiceSubmissionSourceKind `json:"source_kind"`
+	Status      ServiceSubmissionStatus     `json:"status"`
+	Subdir      *string                     `json:"subdir,omitempty"`
+	SubmitterId int64                       `json:"submitter_id"`
+	UpdatedAt   *time.Time                  `json:"updated_at,omitempty"`
+}
+
+// ServiceSubmissionSourceKind defines model for ServiceSubmission.SourceKind.
+type ServiceSubmissionSourceKind string
+
+// ServiceSubmissionStatus defines model for ServiceSubmission.Status.
+type ServiceSubmissionStatus string
+
+// ServiceSubmissionComment defines model for ServiceSubmissionComment.
+type ServiceSubmissionComment struct {
+	AuthorId       *int64    `json:"author_id,omitempty"`
+	AuthorUserName *string   `json:"author_user_name,omitempty"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+	Id             int64     `json:"id"`
+	ParentId       *int64    `json:"parent_id,omitempty"`
+	SubmissionId   int64     `json:"submission_id"`
+}
+
+// ServiceSubmissionCommentCreate defines model for ServiceSubmissionCommentCreate.
+type ServiceSubmissionCommentCreate struct {
+	Body string `json:"body"`
+
+	// ParentId Comment of the same submission this one replies to
+	ParentId *int64 `json:"parent_id,omitempty"`
+}
+
+// ServiceSubmissionCommentList defines model for ServiceSubmissionCommentList.
+type ServiceSubmissionCommentList struct {
+	Items []ServiceSubmissionComment `json:"items"`
+}
+
+// ServiceSubmissionGitRequest defines model for ServiceSubmissionGitRequest.
+type ServiceSubmissionGitRequest struct {
+	Ref *string `json:"ref,omitempty"`
+
+	// RepoUrl Public https repository URL
+	RepoUrl string  `json:"repo_url"`
+	Subdir  *string `json:"subdir,omitempty"`
+}
+
+// ServiceSubmissionList defines model for ServiceSubmissionList.
+type ServiceSubmissionList struct {
+	Items      []ServiceSubmission `json:"items"`
+	Pagination Pagination          `json:"pagination"`
+}
+
+// ServiceSubmissionReview defines model for ServiceSubmissionReview.
+type ServiceSubmissionReview struct {
+	// Comment Posted to the review discussion; required when requesting changes
+	Comment  *string                         `json:"comment,omitempty"`
+	Decision ServiceSubmissionReviewDecision `json:"decision"`
+}
+
+// ServiceSubmissionReviewDecision defines model for ServiceSubmissionReview.Decision.
+type ServiceSubmissionReviewDecision string
+
 // ServiceUpdate defines model for ServiceUpdate.
 type ServiceUpdate struct {
 	Author             *string                 `json:"author,omitempty"`
@@ -969,6 +1959,45 @@ type ServiceUpdate struct {
 	WriteupUrl         *string                 `json:"writeup_url,omitempty"`
 }
 
+// ServiceVuln defines model for ServiceVuln.
+type ServiceVuln struct {
+	Cwe        *string                `json:"cwe,omitempty"`
+	Difficulty *ServiceVulnDifficulty `json:"difficulty,omitempty"`
+
+	// Exploit Path of the demonstrating exploit inside exploits/
+	Exploit   *string `json:"exploit,omitempty"`
+	Fix       *string `json:"fix,omitempty"`
+	Id        int64   `json:"id"`
+	ServiceId int64   `json:"service_id"`
+	Title     string  `json:"title"`
+}
+
+// ServiceVulnDifficulty defines model for ServiceVuln.Difficulty.
+type ServiceVulnDifficulty string
+
+// ServiceVulnInput defines model for ServiceVulnInput.
+type ServiceVulnInput struct {
+	// Cwe CWE id; a bare number is accepted
+	Cwe        *string                     `json:"cwe,omitempty"`
+	Difficulty *ServiceVulnInputDifficulty `json:"difficulty,omitempty"`
+	Exploit    *string                     `json:"exploit,omitempty"`
+	Fix        *string                     `json:"fix,omitempty"`
+	Title      string                      `json:"title"`
+}
+
+// ServiceVulnInputDifficulty defines model for ServiceVulnInput.Difficulty.
+type ServiceVulnInputDifficulty string
+
+// ServiceVulnList defines model for ServiceVulnList.
+type ServiceVulnList struct {
+	Items []ServiceVuln `json:"items"`
+}
+
+// ServiceVulnsReplaceRequest defines model for ServiceVulnsReplaceRequest.
+type ServiceVulnsReplaceRequest struct {
+	Items []ServiceVulnInput `json:"items"`
+}
+
 // SetRoleRequest defines model for SetRoleRequest.
 type SetRoleRequest struct {
 	Role SetRoleRequestRole `json:"role"`
@@ -1090,6 +2119,78 @@ type Timestamped struct {
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// TwoFactorChallenge defines model for TwoFactorChallenge.
+type TwoFactorChallenge struct {
+	// ChallengeToken Pass it to /session/two-factor with a code to finish signing in
+	ChallengeToken string `json:"challenge_token"`
+
+	// EnrollmentRequired The user's role requires two-factor authentication and the user has not set it up yet
+	EnrollmentRequired bool      `json:"enrollment_required"`
+	ExpiresAt          time.Time `json:"expires_at"`
+}
+
+// TwoFactorChallengeToken defines model for TwoFactorChallengeToken.
+type TwoFactorChallengeToken struct {
+	ChallengeToken string `json:"challenge_token"`
+}
+
+// TwoFactorCode defines model for TwoFactorCode.
+type TwoFactorCode struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorDisable defines model for TwoFactorDisable.
+type TwoFactorDisable struct {
+	Code         *string `json:"code,omitempty"`
+	RecoveryCode *string `json:"recovery_code,omitempty"`
+}
+
+// TwoFactorEnrollment defines model for TwoFactorEnrollment.
+type TwoFactorEnrollment struct {
+	OtpauthUri string `json:"otpauth_uri"`
+
+	// QrCodePng Base64-encoded PNG with the otpauth URI
+	QrCodePng []byte `json:"qr_code_png"`
+
+	// Secret Base32 secret for manual entry
+	Secret string `json:"secret"`
+}
+
+// TwoFactorLoginRequest defines model for TwoFactorLoginRequest.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+
+	// Code Code from the authenticator app
+	Code *string `json:"code,omitempty"`
+
+	// RecoveryCode One-time recovery code, instead of code
+	RecoveryCode *string `json:"recovery_code,omitempty"`
+}
+
+// TwoFactorPolicy defines model for TwoFactorPolicy.
+type TwoFactorPolicy struct {
+	RequiredRoles []TwoFactorPolicyRequiredRoles `json:"required_roles"`
+}
+
+// TwoFactorPolicyRequiredRoles defines model for TwoFactorPolicy.RequiredRoles.
+type TwoFactorPolicyRequiredRoles string
+
+// TwoFactorRecoveryCodes defines model for TwoFactorRecoveryCodes.
+type TwoFactorRecoveryCodes struct {
+	// RecoveryCodes Shown once; each code signs in one time
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorStatus defines model for TwoFactorStatus.
+type TwoFactorStatus struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+
+	// Required The user's role requires two-factor authentication
+	Required bool `json:"required"`
+}
+
 // University defines model for University.
 type University struct {
 	AvatarUrl *string    `json:"avatar_url,omitempty"`
@@ -1122,23 +2223,26 @@ type UniversityUpdate struct {
 
 // User defines model for User.
 type User struct {
-	AvatarUrl   *string      `json:"avatar_url,omitempty"`
-	Bio         *string      `json:"bio,omitempty"`
-	CreatedAt   *time.Time   `json:"created_at,omitempty"`
-	DisplayName string       `json:"display_name"`
-	Email       *string      `json:"email,omitempty"`
-	Github      *string      `json:"github,omitempty"`
-	Id          int64        `json:"id"`
-	IsBlocked   bool         `json:"is_blocked"`
-	Language    UserLanguage `json:"language"`
-	LastLoginAt *time.Time   `json:"last_login_at,omitempty"`
-	LastLoginIp *string      `json:"last_login_ip,omitempty"`
-	Rating      int          `json:"rating"`
-	Role        UserRole     `json:"role"`
-	Telegram    *string      `json:"telegram,omitempty"`
-	Theme       UserTheme    `json:"theme"`
-	UpdatedAt   *time.Time   `json:"updated_at,omitempty"`
-	UserName    string       `json:"user_name"`
+	AvatarUrl   *string    `json:"avatar_url,omitempty"`
+	Bio         *string    `json:"bio,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	DisplayName string     `json:"display_name"`
+	Email       *string    `json:"email,omitempty"`
+
+	// EmailVerifiedAt When a link sent to the current email was followed; only shown to the user and admins
+	EmailVerifiedAt *time.Time   `json:"email_verified_at,omitempty"`
+	Github          *string      `json:"github,omitempty"`
+	Id              int64        `json:"id"`
+	IsBlocked       bool         `json:"is_blocked"`
+	Language        UserLanguage `json:"language"`
+	LastLoginAt     *time.Time   `json:"last_login_at,omitempty"`
+	LastLoginIp     *string      `json:"last_login_ip,omitempty"`
+	Rating          int          `json:"rating"`
+	Role            UserRole     `json:"role"`
+	Telegram        *string      `json:"telegram,omitempty"`
+	Theme           UserTheme    `json:"theme"`
+	UpdatedAt       *time.Time   `json:"updated_at,omitempty"`
+	UserName        string       `json:"user_name"`
 }
 
 // UserLanguage defines model for User.Language.
@@ -1173,6 +2277,25 @@ type UserDeleteRequest struct {
 	Password string `json:"password"`
 }
 
+// UserIdentity defines model for UserIdentity.
+type UserIdentity struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	Email       *string    `json:"email,omitempty"`
+	Id          int64      `json:"id"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	Provider    string     `json:"provider"`
+}
+
+// UserIdentityLink defines model for UserIdentityLink.
+type UserIdentityLink struct {
+	Provider string `json:"provider"`
+}
+
+// UserIdentityList defines model for UserIdentityList.
+type UserIdentityList struct {
+	Items []UserIdentity `json:"items"`
+}
+
 // UserList defines model for UserList.
 type UserList struct {
 	Items      []User     `json:"items"`
@@ -1267,6 +2390,9 @@ type Forbidden = Error
 // NotFound defines model for NotFound.
 type NotFound = Error
 
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = Error
+
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = Error
 
@@ -1276,6 +2402,25 @@ type ValidationError = Error
 // bearerAuthContextKey is the context key for BearerAuth security scheme
 type bearerAuthContextKey string
 
+// ListAuditEventsParams defines parameters for ListAuditEvents.
+type ListAuditEventsParams struct {
+	Page       *PageParam                       `form:"page,omitempty" json:"page,omitempty"`
+	PerPage    *PerPageParam                    `form:"per_page,omitempty" json:"per_page,omitempty"`
+	EntityType *ListAuditEventsParamsEntityType `form:"entity_type,omitempty" json:"entity_type,omitempty"`
+	EntityId   *string                          `form:"entity_id,omitempty" json:"entity_id,omitempty"`
+	ActorId    *int64                           `form:"actor_id,omitempty" json:"actor_id,omitempty"`
+	Action     *string                          `form:"action,omitempty" json:"action,omitempty"`
+
+	// Since Only events at or after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only events before this time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+}
+
+// ListAuditEventsParamsEntityType defines parameters for ListAuditEvents.
+type ListAuditEventsParamsEntityType string
+
 // ListGamesParams defines parameters for ListGames.
 type ListGamesParams struct {
 	Page      *PageParam    `form:"page,omitempty" json:"page,omitempty"`
@@ -1295,6 +2440,12 @@ type SetGameServiceStatusJSONBody struct {
 	Status string `json:"status"`
 }
 
+// PinGameServiceJSONBody defines parameters for PinGameService.
+type PinGameServiceJSONBody struct {
+	ArchiveVersionId *int64  `json:"archive_version_id,omitempty"`
+	GitCommit        *string `json:"git_commit,omitempty"`
+}
+
 // UploadProfileAvatarMultipartBody defines parameters for UploadProfileAvatar.
 type UploadProfileAvatarMultipartBody struct {
 	Avatar openapi_types.File `json:"avatar"`
@@ -1306,6 +2457,26 @@ type ListResultsParams struct {
 	TeamId *int64 `form:"team_id,omitempty" json:"team_id,omitempty"`
 }
 
+// ListServiceSubmissionsParams defines parameters for ListServiceSubmissions.
+type ListServiceSubmissionsParams struct {
+	Page    *int                                `form:"page,omitempty" json:"page,omitempty"`
+	PerPage *int                                `form:"per_page,omitempty" json:"per_page,omitempty"`
+	Status  *ListServiceSubmissionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListServiceSubmissionsParamsStatus defines parameters for ListServiceSubmissions.
+type ListServiceSubmissionsParamsStatus string
+
+// SubmitServiceFromZipMultipartBody defines parameters for SubmitServiceFromZip.
+type SubmitServiceFromZipMultipartBody struct {
+	Archive openapi_types.File `json:"archive"`
+}
+
+// ResubmitServiceFromZipMultipartBody defines parameters for ResubmitServiceFromZip.
+type ResubmitServiceFromZipMultipartBody struct {
+	Archive openapi_types.File `json:"archive"`
+}
+
 // ListServicesParams defines parameters for ListServices.
 type ListServicesParams struct {
 	Page    *PageParam    `form:"page,omitempty" json:"page,omitempty"`
@@ -1324,12 +2495,75 @@ type PreviewServiceZipImportMultipartBody struct {
 	Archive openapi_types.File `json:"archive"`
 }
 
-// DownloadServiceArchiveParamsKind defines parameters for DownloadServiceArchive.
-type DownloadServiceArchiveParamsKind string
+// SearchServicesParams defines parameters for SearchServices.
+type SearchServicesParams struct {
+	Page    *PageParam    `form:"page,omitempty" json:"page,omitempty"`
+	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Q Words matched as prefixes against name, tech stack, author, descriptions and README
+	Q           *string                           `form:"q,omitempty" json:"q,omitempty"`
+	Tech        *[]string                         `form:"tech,omitempty" json:"tech,omitempty"`
+	License     *[]string                         `form:"license,omitempty" json:"license,omitempty"`
+	CheckStatus *[]string                         `form:"check_status,omitempty" json:"check_status,omitempty"`
+	GamesUsage  *[]SearchServicesParamsGamesUsage `form:"games_usage,omitempty" json:"games_usage,omitempty"`
+}
+
+// SearchServicesParamsGamesUsage defines parameters for SearchServices.
+type SearchServicesParamsGamesUsage string
+
+// ListServiceArchiveVersionsParams defines parameters for ListServiceArchiveVersions.
+type ListServiceArchiveVersionsParams struct {
+	Kind *ListServiceArchiveVersionsParamsKind `form:"kind,omitempty" json:"kind,omitempty"`
+}
+
+// ListServiceArchiveVersionsParamsKind defines parameters for ListServiceArchiveVersions.
+type ListServiceArchiveVersionsParamsKind string
+
+// DiffServiceArchiveVersionsParams defines parameters for DiffServiceArchiveVersions.
+type DiffServiceArchiveVersionsParams struct {
+	From int64 `form:"from" json:"from"`
+	To   int64 `form:"to" json:"to"`
+}
+
+// DownloadServiceArchiveParamsKind defines parameters for DownloadServiceArchive.
+type DownloadServiceArchiveParamsKind string
+
+// DownloadServiceArchiveSignedParams defines parameters for DownloadServiceArchiveSigned.
+type DownloadServiceArchiveSignedParams struct {
+	Link      int64  `form:"link" json:"link"`
+	Expires   int64  `form:"expires" json:"expires"`
+	Signature string `form:"signature" json:"signature"`
+}
+
+// DownloadServiceArchiveSignedParamsKind defines parameters for DownloadServiceArchiveSigned.
+type DownloadServiceArchiveSignedParamsKind string
+
+// ListServiceGamePinsParams defines parameters for ListServiceGamePins.
+type ListServiceGamePinsParams struct {
+	// VersionId Only games pinned to this archive version
+	VersionId *int64 `form:"version_id,omitempty" json:"version_id,omitempty"`
+}
+
+// GetServiceReadmeParams defines parameters for GetServiceReadme.
+type GetServiceReadmeParams struct {
+	// Version Service archive version; defaults to the active one
+	Version *int64 `form:"version,omitempty" json:"version,omitempty"`
+}
+
+// GetServiceReadmeAssetParams defines parameters for GetServiceReadmeAsset.
+type GetServiceReadmeAssetParams struct {
+	Version int64 `form:"version" json:"version"`
+
+	// Path Path of the image inside the archive, next to or below the README
+	Path string `form:"path" json:"path"`
+}
 
 // UploadServiceArchivesMultipartBody defines parameters for UploadServiceArchives.
 type UploadServiceArchivesMultipartBody struct {
+	// CheckerArchive ZIP, tar or tar.gz archive
 	CheckerArchive *openapi_types.File `json:"checker_archive,omitempty"`
+
+	// ServiceArchive ZIP, tar or tar.gz archive
 	ServiceArchive *openapi_types.File `json:"service_archive,omitempty"`
 }
 
@@ -1377,6 +2611,21 @@ type ListWriteupsParams struct {
 	TeamId *int64 `form:"team_id,omitempty" json:"team_id,omitempty"`
 }
 
+// ConfirmEmailVerificationJSONRequestBody defines body for ConfirmEmailVerification for application/json ContentType.
+type ConfirmEmailVerificationJSONRequestBody = EmailVerificationConfirm
+
+// CompleteOIDCLoginJSONRequestBody defines body for CompleteOIDCLogin for application/json ContentType.
+type CompleteOIDCLoginJSONRequestBody = OIDCCallbackRequest
+
+// RequestPasswordResetJSONRequestBody defines body for RequestPasswordReset for application/json ContentType.
+type RequestPasswordResetJSONRequestBody = PasswordResetRequest
+
+// ConfirmPasswordResetJSONRequestBody defines body for ConfirmPasswordReset for application/json ContentType.
+type ConfirmPasswordResetJSONRequestBody = PasswordResetConfirm
+
+// UpdateTwoFactorPolicyJSONRequestBody defines body for UpdateTwoFactorPolicy for application/json ContentType.
+type UpdateTwoFactorPolicyJSONRequestBody = TwoFactorPolicy
+
 // CreateGameTeamJSONRequestBody defines body for CreateGameTeam for application/json ContentType.
 type CreateGameTeamJSONRequestBody = GameTeamCreate
 
@@ -1392,36 +2641,87 @@ type UpdateGameJSONRequestBody = GameUpdate
 // ExportCtf01dJSONRequestBody defines body for ExportCtf01d for application/json ContentType.
 type ExportCtf01dJSONRequestBody = Ctf01dExportRequest
 
+// SetGameRoleJSONRequestBody defines body for SetGameRole for application/json ContentType.
+type SetGameRoleJSONRequestBody = GameRoleSetRequest
+
 // AddGameServiceJSONRequestBody defines body for AddGameService for application/json ContentType.
 type AddGameServiceJSONRequestBody AddGameServiceJSONBody
 
 // SetGameServiceStatusJSONRequestBody defines body for SetGameServiceStatus for application/json ContentType.
 type SetGameServiceStatusJSONRequestBody SetGameServiceStatusJSONBody
 
+// PinGameServiceJSONRequestBody defines body for PinGameService for application/json ContentType.
+type PinGameServiceJSONRequestBody PinGameServiceJSONBody
+
 // ReorderGameTeamsJSONRequestBody defines body for ReorderGameTeams for application/json ContentType.
 type ReorderGameTeamsJSONRequestBody = ReorderRequest
 
+// CreateGitCredentialJSONRequestBody defines body for CreateGitCredential for application/json ContentType.
+type CreateGitCredentialJSONRequestBody = GitCredentialCreate
+
+// UpdateGitCredentialJSONRequestBody defines body for UpdateGitCredential for application/json ContentType.
+type UpdateGitCredentialJSONRequestBody = GitCredentialUpdate
+
 // UpdateProfileJSONRequestBody defines body for UpdateProfile for application/json ContentType.
 type UpdateProfileJSONRequestBody = UserProfileUpdate
 
+// CreateProfileAPITokenJSONRequestBody defines body for CreateProfileAPIToken for application/json ContentType.
+type CreateProfileAPITokenJSONRequestBody = APITokenCreate
+
 // UploadProfileAvatarMultipartRequestBody defines body for UploadProfileAvatar for multipart/form-data ContentType.
 type UploadProfileAvatarMultipartRequestBody UploadProfileAvatarMultipartBody
 
+// LinkProfileIdentityJSONRequestBody defines body for LinkProfileIdentity for application/json ContentType.
+type LinkProfileIdentityJSONRequestBody = UserIdentityLink
+
 // ChangeProfilePasswordJSONRequestBody defines body for ChangeProfilePassword for application/json ContentType.
 type ChangeProfilePasswordJSONRequestBody = PasswordUpdate
 
+// ConfirmProfileTwoFactorJSONRequestBody defines body for ConfirmProfileTwoFactor for application/json ContentType.
+type ConfirmProfileTwoFactorJSONRequestBody = TwoFactorCode
+
+// DisableProfileTwoFactorJSONRequestBody defines body for DisableProfileTwoFactor for application/json ContentType.
+type DisableProfileTwoFactorJSONRequestBody = TwoFactorDisable
+
+// RegenerateProfileRecoveryCodesJSONRequestBody defines body for RegenerateProfileRecoveryCodes for application/json ContentType.
+type RegenerateProfileRecoveryCodesJSONRequestBody = TwoFactorCode
+
 // CreateResultJSONRequestBody defines body for CreateResult for application/json ContentType.
 type CreateResultJSONRequestBody = ResultCreate
 
 // UpdateResultJSONRequestBody defines body for UpdateResult for application/json ContentType.
 type UpdateResultJSONRequestBody = ResultUpdate
 
+// SubmitServiceFromGitJSONRequestBody defines body for SubmitServiceFromGit for application/json ContentType.
+type SubmitServiceFromGitJSONRequestBody = ServiceSubmissionGitRequest
+
+// SubmitServiceFromZipMultipartRequestBody defines body for SubmitServiceFromZip for multipart/form-data ContentType.
+type SubmitServiceFromZipMultipartRequestBody SubmitServiceFromZipMultipartBody
+
+// AddServiceSubmissionCommentJSONRequestBody defines body for AddServiceSubmissionComment for application/json ContentType.
+type AddServiceSubmissionCommentJSONRequestBody = ServiceSubmissionCommentCreate
+
+// ResubmitServiceFromGitJSONRequestBody defines body for ResubmitServiceFromGit for application/json ContentType.
+type ResubmitServiceFromGitJSONRequestBody = ServiceSubmissionGitRequest
+
+// ReviewServiceSubmissionJSONRequestBody defines body for ReviewServiceSubmission for application/json ContentType.
+type ReviewServiceSubmissionJSONRequestBody = ServiceSubmissionReview
+
+// ResubmitServiceFromZipMultipartRequestBody defines body for ResubmitServiceFromZip for multipart/form-data ContentType.
+type ResubmitServiceFromZipMultipartRequestBody ResubmitServiceFromZipMultipartBody
+
 // CreateServiceJSONRequestBody defines body for CreateService for application/json ContentType.
 type CreateServiceJSONRequestBody = ServiceCreate
 
 // ImportServiceFromGitJSONRequestBody defines body for ImportServiceFromGit for application/json ContentType.
 type ImportServiceFromGitJSONRequestBody = GitImportRequest
 
+// ImportServicesFromGitJSONRequestBody defines body for ImportServicesFromGit for application/json ContentType.
+type ImportServicesFromGitJSONRequestBody = GitBatchImportRequest
+
+// DiscoverServicesInGitJSONRequestBody defines body for DiscoverServicesInGit for application/json ContentType.
+type DiscoverServicesInGitJSONRequestBody = GitImportRequest
+
 // PreviewServiceGitImportJSONRequestBody defines body for PreviewServiceGitImport for application/json ContentType.
 type PreviewServiceGitImportJSONRequestBody = GitImportRequest
 
@@ -1431,15 +2731,36 @@ type ImportServiceFromZipMultipartRequestBody ImportServiceFromZipMultipartBody
 // PreviewServiceZipImportMultipartRequestBody defines body for PreviewServiceZipImport for multipart/form-data ContentType.
 type PreviewServiceZipImportMultipartRequestBody PreviewServiceZipImportMultipartBody
 
+// ValidateServiceManifestJSONRequestBody defines body for ValidateServiceManifest for application/json ContentType.
+type ValidateServiceManifestJSONRequestBody = ServiceManifestValidateRequest
+
 // UpdateServiceJSONRequestBody defines body for UpdateService for application/json ContentType.
 type UpdateServiceJSONRequestBody = ServiceUpdate
 
+// AddServiceAuthorJSONRequestBody defines body for AddServiceAuthor for application/json ContentType.
+type AddServiceAuthorJSONRequestBody = ServiceAuthorAddRequest
+
+// CreateServiceDownloadLinkJSONRequestBody defines body for CreateServiceDownloadLink for application/json ContentType.
+type CreateServiceDownloadLinkJSONRequestBody = ServiceDownloadLinkCreate
+
 // UploadServiceArchivesMultipartRequestBody defines body for UploadServiceArchives for multipart/form-data ContentType.
 type UploadServiceArchivesMultipartRequestBody UploadServiceArchivesMultipartBody
 
+// ReplaceServiceVulnsJSONRequestBody defines body for ReplaceServiceVulns for application/json ContentType.
+type ReplaceServiceVulnsJSONRequestBody = ServiceVulnsReplaceRequest
+
 // LoginJSONRequestBody defines body for Login for application/json ContentType.
 type LoginJSONRequestBody = LoginRequest
 
+// RefreshSessionJSONRequestBody defines body for RefreshSession for application/json ContentType.
+type RefreshSessionJSONRequestBody = RefreshRequest
+
+// CompleteTwoFactorLoginJSONRequestBody defines body for CompleteTwoFactorLogin for application/json ContentType.
+type CompleteTwoFactorLoginJSONRequestBody = TwoFactorLoginRequest
+
+// StartTwoFactorLoginEnrollmentJSONRequestBody defines body for StartTwoFactorLoginEnrollment for application/json ContentType.
+type StartTwoFactorLoginEnrollmentJSONRequestBody = TwoFactorChallengeToken
+
 // CreateTeamMembershipJSONRequestBody defines body for CreateTeamMembership for application/json ContentType.
 type CreateTeamMembershipJSONRequestBody = TeamMembershipCreate
 
@@ -1493,6 +2814,33 @@ type CreateWriteupJSONRequestBody = WriteupCreate
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List audit events
+	// (GET /audit-events)
+	ListAuditEvents(c *gin.Context, params ListAuditEventsParams)
+	// Verify an email address
+	// (POST /auth/email-verification/confirm)
+	ConfirmEmailVerification(c *gin.Context)
+	// List external identity providers
+	// (GET /auth/oidc/providers)
+	ListOIDCProviders(c *gin.Context)
+	// Start signing in with an external provider
+	// (POST /auth/oidc/{provider}/authorize)
+	StartOIDCLogin(c *gin.Context, provider string)
+	// Finish signing in with an external provider
+	// (POST /auth/oidc/{provider}/callback)
+	CompleteOIDCLogin(c *gin.Context, provider string)
+	// Ask for a password reset link
+	// (POST /auth/password-reset)
+	RequestPasswordReset(c *gin.Context)
+	// Set a new password with a reset link
+	// (POST /auth/password-reset/confirm)
+	ConfirmPasswordReset(c *gin.Context)
+	// Get the two-factor policy
+	// (GET /auth/two-factor/policy)
+	GetTwoFactorPolicy(c *gin.Context)
+	// Update the two-factor policy
+	// (PUT /auth/two-factor/policy)
+	UpdateTwoFactorPolicy(c *gin.Context)
 	// Add a team to a game
 	// (POST /game-teams)
 	CreateGameTeam(c *gin.Context)
@@ -1526,9 +2874,21 @@ type ServerInterface interface {
 	// Finalize game results
 	// (POST /games/{id}/finalize)
 	FinalizeGame(c *gin.Context, id int64)
+	// List vulnbox ports shared by services of a game
+	// (GET /games/{id}/port-conflicts)
+	ListGamePortConflicts(c *gin.Context, id int64)
 	// Publish a planning game into the games section
 	// (POST /games/{id}/publish)
 	PublishGame(c *gin.Context, id int64)
+	// List the roles users hold in a game
+	// (GET /games/{id}/roles)
+	ListGameRoles(c *gin.Context, id int64)
+	// Take a user's role in a game away
+	// (DELETE /games/{id}/roles/{user_id})
+	RemoveGameRole(c *gin.Context, id int64, userId int64)
+	// Give a user a role in a game
+	// (PUT /games/{id}/roles/{user_id})
+	SetGameRole(c *gin.Context, id int64, userId int64)
 	// Get scoreboard for a game
 	// (GET /games/{id}/scoreboard)
 	GetGameScoreboard(c *gin.Context, id int64)
@@ -1544,6 +2904,12 @@ type ServerInterface interface {
 	// Set the planning status of a linked service
 	// (PATCH /games/{id}/services/{service_id})
 	SetGameServiceStatus(c *gin.Context, id int64, serviceId int64)
+	// Make a linked service follow its current archive again
+	// (DELETE /games/{id}/services/{service_id}/pin)
+	UnpinGameService(c *gin.Context, id int64, serviceId int64)
+	// Pin a linked service to an archive version
+	// (PUT /games/{id}/services/{service_id}/pin)
+	PinGameService(c *gin.Context, id int64, serviceId int64)
 	// List teams in a game
 	// (GET /games/{id}/teams)
 	ListGameTeams(c *gin.Context, id int64)
@@ -1553,21 +2919,72 @@ type ServerInterface interface {
 	// Unfinalize game results
 	// (POST /games/{id}/unfinalize)
 	UnfinalizeGame(c *gin.Context, id int64)
+	// List git credentials
+	// (GET /git-credentials)
+	ListGitCredentials(c *gin.Context)
+	// Create a git credential
+	// (POST /git-credentials)
+	CreateGitCredential(c *gin.Context)
+	// Delete a git credential
+	// (DELETE /git-credentials/{id})
+	DeleteGitCredential(c *gin.Context, id int64)
+	// Get a git credential by ID
+	// (GET /git-credentials/{id})
+	GetGitCredential(c *gin.Context, id int64)
+	// Update a git credential
+	// (PATCH /git-credentials/{id})
+	UpdateGitCredential(c *gin.Context, id int64)
 	// Get current user profile
 	// (GET /profile)
 	GetProfile(c *gin.Context)
 	// Update current user profile
 	// (PATCH /profile)
 	UpdateProfile(c *gin.Context)
+	// List current user's API tokens
+	// (GET /profile/api-tokens)
+	ListProfileAPITokens(c *gin.Context)
+	// Create an API token
+	// (POST /profile/api-tokens)
+	CreateProfileAPIToken(c *gin.Context)
+	// Revoke an API token
+	// (DELETE /profile/api-tokens/{id})
+	RevokeProfileAPIToken(c *gin.Context, id int64)
 	// Upload current user's avatar
 	// (POST /profile/avatar)
 	UploadProfileAvatar(c *gin.Context)
+	// Send an email verification link
+	// (POST /profile/email/verification)
+	SendProfileEmailVerification(c *gin.Context)
+	// List current user's external identities
+	// (GET /profile/identities)
+	ListProfileIdentities(c *gin.Context)
+	// Start linking an external identity
+	// (POST /profile/identities)
+	LinkProfileIdentity(c *gin.Context)
+	// Unlink an external identity
+	// (DELETE /profile/identities/{id})
+	UnlinkProfileIdentity(c *gin.Context, id int64)
 	// Change current user's password
 	// (PUT /profile/password)
 	ChangeProfilePassword(c *gin.Context)
 	// List current user's active sessions
 	// (GET /profile/sessions)
 	ListProfileSessions(c *gin.Context)
+	// Get current user's two-factor status
+	// (GET /profile/two-factor)
+	GetProfileTwoFactor(c *gin.Context)
+	// Confirm the second factor
+	// (POST /profile/two-factor/confirm)
+	ConfirmProfileTwoFactor(c *gin.Context)
+	// Turn off the second factor
+	// (POST /profile/two-factor/disable)
+	DisableProfileTwoFactor(c *gin.Context)
+	// Start setting up a second factor
+	// (POST /profile/two-factor/enrollment)
+	StartProfileTwoFactorEnrollment(c *gin.Context)
+	// Replace recovery codes
+	// (POST /profile/two-factor/recovery-codes)
+	RegenerateProfileRecoveryCodes(c *gin.Context)
 	// List results
 	// (GET /results)
 	ListResults(c *gin.Context, params ListResultsParams)
@@ -1586,6 +3003,33 @@ type ServerInterface interface {
 	// Get global scoreboard
 	// (GET /scoreboard)
 	GetGlobalScoreboard(c *gin.Context)
+	// List service submissions
+	// (GET /service-submissions)
+	ListServiceSubmissions(c *gin.Context, params ListServiceSubmissionsParams)
+	// Submit a service from a git repository
+	// (POST /service-submissions/git)
+	SubmitServiceFromGit(c *gin.Context)
+	// Submit a service archive
+	// (POST /service-submissions/zip)
+	SubmitServiceFromZip(c *gin.Context)
+	// Get a service submission
+	// (GET /service-submissions/{id})
+	GetServiceSubmission(c *gin.Context, id int64)
+	// List review comments of a submission
+	// (GET /service-submissions/{id}/comments)
+	ListServiceSubmissionComments(c *gin.Context, id int64)
+	// Comment on a submission
+	// (POST /service-submissions/{id}/comments)
+	AddServiceSubmissionComment(c *gin.Context, id int64)
+	// Resubmit a service from a git repository
+	// (POST /service-submissions/{id}/git)
+	ResubmitServiceFromGit(c *gin.Context, id int64)
+	// Decide on a service submission
+	// (POST /service-submissions/{id}/review)
+	ReviewServiceSubmission(c *gin.Context, id int64)
+	// Resubmit a service archive
+	// (POST /service-submissions/{id}/zip)
+	ResubmitServiceFromZip(c *gin.Context, id int64)
 	// List services
 	// (GET /services)
 	ListServices(c *gin.Context, params ListServicesParams)
@@ -1595,6 +3039,12 @@ type ServerInterface interface {
 	// Import a service from a git repository
 	// (POST /services/import/git)
 	ImportServiceFromGit(c *gin.Context)
+	// Import several services of a repository at once
+	// (POST /services/import/git/batch)
+	ImportServicesFromGit(c *gin.Context)
+	// Find and preview every service in a repository
+	// (POST /services/import/git/discover)
+	DiscoverServicesInGit(c *gin.Context)
 	// Preview and validate a git service import
 	// (POST /services/import/git/preview)
 	PreviewServiceGitImport(c *gin.Context)
@@ -1604,6 +3054,12 @@ type ServerInterface interface {
 	// Preview and validate a ZIP service import
 	// (POST /services/import/zip/preview)
 	PreviewServiceZipImport(c *gin.Context)
+	// Validate a .ctf01d-service.yml manifest
+	// (POST /services/manifest/validate)
+	ValidateServiceManifest(c *gin.Context)
+	// Full-text search over the service catalog
+	// (GET /services/search)
+	SearchServices(c *gin.Context, params SearchServicesParams)
 	// Delete a service
 	// (DELETE /services/{id})
 	DeleteService(c *gin.Context, id int64)
@@ -1613,12 +3069,45 @@ type ServerInterface interface {
 	// Update a service
 	// (PATCH /services/{id})
 	UpdateService(c *gin.Context, id int64)
+	// List stored archive versions of a service
+	// (GET /services/{id}/archive-versions)
+	ListServiceArchiveVersions(c *gin.Context, id int64, params ListServiceArchiveVersionsParams)
+	// Compare the file lists of two archive versions
+	// (GET /services/{id}/archive-versions/diff)
+	DiffServiceArchiveVersions(c *gin.Context, id int64, params DiffServiceArchiveVersionsParams)
+	// Make a stored archive version active again
+	// (POST /services/{id}/archive-versions/{version_id}/rollback)
+	RollbackServiceArchiveVersion(c *gin.Context, id int64, versionId int64)
+	// List the authors of a service
+	// (GET /services/{id}/authors)
+	ListServiceAuthors(c *gin.Context, id int64)
+	// Add an author to a service
+	// (POST /services/{id}/authors)
+	AddServiceAuthor(c *gin.Context, id int64)
+	// Remove an author from a service
+	// (DELETE /services/{id}/authors/{user_id})
+	RemoveServiceAuthor(c *gin.Context, id int64, userId int64)
 	// Run checker inspection
 	// (POST /services/{id}/check-checker)
 	CheckServiceChecker(c *gin.Context, id int64)
+	// Create a signed, expiring download link for an archive
+	// (POST /services/{id}/download-links)
+	CreateServiceDownloadLink(c *gin.Context, id int64)
 	// Download service or checker archive
 	// (GET /services/{id}/download/{kind})
 	DownloadServiceArchive(c *gin.Context, id int64, kind DownloadServiceArchiveParamsKind)
+	// Download an archive through a signed link
+	// (GET /services/{id}/download/{kind}/signed)
+	DownloadServiceArchiveSigned(c *gin.Context, id int64, kind DownloadServiceArchiveSignedParamsKind, params DownloadServiceArchiveSignedParams)
+	// List games using a service and the archive versions they are pinned to
+	// (GET /services/{id}/game-pins)
+	ListServiceGamePins(c *gin.Context, id int64, params ListServiceGamePinsParams)
+	// Get the rendered README of a service
+	// (GET /services/{id}/readme)
+	GetServiceReadme(c *gin.Context, id int64, params GetServiceReadmeParams)
+	// Get an image referenced by the service README
+	// (GET /services/{id}/readme/assets)
+	GetServiceReadmeAsset(c *gin.Context, id int64, params GetServiceReadmeAssetParams)
 	// Re-download service and checker archives from URLs
 	// (POST /services/{id}/redownload)
 	RedownloadServiceArchives(c *gin.Context, id int64)
@@ -1631,12 +3120,27 @@ type ServerInterface interface {
 	// Upload service and/or checker archives
 	// (POST /services/{id}/upload-archives)
 	UploadServiceArchives(c *gin.Context, id int64)
+	// List the intended vulnerabilities of a service
+	// (GET /services/{id}/vulns)
+	ListServiceVulns(c *gin.Context, id int64)
+	// Replace the vulnerability catalog of a service
+	// (PUT /services/{id}/vulns)
+	ReplaceServiceVulns(c *gin.Context, id int64)
 	// Logout
 	// (DELETE /session)
 	Logout(c *gin.Context)
 	// Login
 	// (POST /session)
 	Login(c *gin.Context)
+	// Renew the access token
+	// (POST /session/refresh)
+	RefreshSession(c *gin.Context)
+	// Finish signing in with a second factor
+	// (POST /session/two-factor)
+	CompleteTwoFactorLogin(c *gin.Context)
+	// Set up a second factor while signing in
+	// (POST /session/two-factor/enrollment)
+	StartTwoFactorLoginEnrollment(c *gin.Context)
 	// List team memberships
 	// (GET /team-memberships)
 	ListTeamMemberships(c *gin.Context, params ListTeamMembershipsParams)
@@ -1748,6 +3252,9 @@ type ServerInterface interface {
 	// Revoke a user's session
 	// (DELETE /users/{id}/sessions/{sessionId})
 	RevokeUserSession(c *gin.Context, id int64, sessionId int64)
+	// Reset a user's second factor
+	// (DELETE /users/{id}/two-factor)
+	ResetUserTwoFactor(c *gin.Context, id int64)
 	// List writeups
 	// (GET /writeups)
 	ListWriteups(c *gin.Context, params ListWriteupsParams)
@@ -1771,6 +3278,223 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// ListAuditEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditEvents(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEventsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", c.Request.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "per_page", c.Request.URL.Query(), &params.PerPage, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter per_page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "entity_type" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "entity_type", c.Request.URL.Query(), &params.EntityType, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter entity_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "entity_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "entity_id", c.Request.URL.Query(), &params.EntityId, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter entity_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "actor_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "actor_id", c.Request.URL.Query(), &params.ActorId, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "action", c.Request.URL.Query(), &params.Action, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter action: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "since", c.Request.URL.Query(), &params.Since, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter since: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "until", c.Request.URL.Query(), &params.Until, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter until: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListAuditEvents(c, params)
+}
+
+// ConfirmEmailVerification operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmEmailVerification(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ConfirmEmailVerification(c)
+}
+
+// ListOIDCProviders operation middleware
+func (siw *ServerInterfaceWrapper) ListOIDCProviders(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListOIDCProviders(c)
+}
+
+// StartOIDCLogin operation middleware
+func (siw *ServerInterfaceWrapper) StartOIDCLogin(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StartOIDCLogin(c, provider)
+}
+
+// CompleteOIDCLogin operation middleware
+func (siw *ServerInterfaceWrapper) CompleteOIDCLogin(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CompleteOIDCLogin(c, provider)
+}
+
+// RequestPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) RequestPasswordReset(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RequestPasswordReset(c)
+}
+
+// ConfirmPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmPasswordReset(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ConfirmPasswordReset(c)
+}
+
+// GetTwoFactorPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetTwoFactorPolicy(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTwoFactorPolicy(c)
+}
+
+// UpdateTwoFactorPolicy operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTwoFactorPolicy(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateTwoFactorPolicy(c)
+}
+
 // CreateGameTeam operation middleware
 func (siw *ServerInterfaceWrapper) CreateGameTeam(c *gin.Context) {
 
@@ -2066,8 +3790,8 @@ func (siw *ServerInterfaceWrapper) FinalizeGame(c *gin.Context) {
 	siw.Handler.FinalizeGame(c, id)
 }
 
-// PublishGame operation middleware
-func (siw *ServerInterfaceWrapper) PublishGame(c *gin.Context) {
+// ListGamePortConflicts operation middleware
+func (siw *ServerInterfaceWrapper) ListGamePortConflicts(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2090,11 +3814,11 @@ func (siw *ServerInterfaceWrapper) PublishGame(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PublishGame(c, id)
+	siw.Handler.ListGamePortConflicts(c, id)
 }
 
-// GetGameScoreboard operation middleware
-func (siw *ServerInterfaceWrapper) GetGameScoreboard(c *gin.Context) {
+// PublishGame operation middleware
+func (siw *ServerInterfaceWrapper) PublishGame(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2108,6 +3832,8 @@ func (siw *ServerInterfaceWrapper) GetGameScoreboard(c *gin.Context) {
 		return
 	}
 
+	c.Set(string(BearerAuthScopes), []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -2115,11 +3841,11 @@ func (siw *ServerInterfaceWrapper) GetGameScoreboard(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetGameScoreboard(c, id)
+	siw.Handler.PublishGame(c, id)
 }
 
-// ListGameServices operation middleware
-func (siw *ServerInterfaceWrapper) ListGameServices(c *gin.Context) {
+// ListGameRoles operation middleware
+func (siw *ServerInterfaceWrapper) ListGameRoles(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2133,6 +3859,8 @@ func (siw *ServerInterfaceWrapper) ListGameServices(c *gin.Context) {
 		return
 	}
 
+	c.Set(string(BearerAuthScopes), []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -2140,11 +3868,11 @@ func (siw *ServerInterfaceWrapper) ListGameServices(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListGameServices(c, id)
+	siw.Handler.ListGameRoles(c, id)
 }
 
-// AddGameService operation middleware
-func (siw *ServerInterfaceWrapper) AddGameService(c *gin.Context) {
+// RemoveGameRole operation middleware
+func (siw *ServerInterfaceWrapper) RemoveGameRole(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2158,6 +3886,15 @@ func (siw *ServerInterfaceWrapper) AddGameService(c *gin.Context) {
 		return
 	}
 
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(string(BearerAuthScopes), []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2167,11 +3904,11 @@ func (siw *ServerInterfaceWrapper) AddGameService(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.AddGameService(c, id)
+	siw.Handler.RemoveGameRole(c, id, userId)
 }
 
-// RemoveGameService operation middleware
-func (siw *ServerInterfaceWrapper) RemoveGameService(c *gin.Context) {
+// SetGameRole operation middleware
+func (siw *ServerInterfaceWrapper) SetGameRole(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2185,12 +3922,12 @@ func (siw *ServerInterfaceWrapper) RemoveGameService(c *gin.Context) {
 		return
 	}
 
-	// ------------- Path parameter "service_id" -------------
-	var serviceId int64
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
 
-	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Param("service_id"), &serviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter service_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -2203,11 +3940,11 @@ func (siw *ServerInterfaceWrapper) RemoveGameService(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.RemoveGameService(c, id, serviceId)
+	siw.Handler.SetGameRole(c, id, userId)
 }
 
-// SetGameServiceStatus operation middleware
-func (siw *ServerInterfaceWrapper) SetGameServiceStatus(c *gin.Context) {
+// GetGameScoreboard operation middleware
+func (siw *ServerInterfaceWrapper) GetGameScoreboard(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2221,12 +3958,53 @@ func (siw *ServerInterfaceWrapper) SetGameServiceStatus(c *gin.Context) {
 		return
 	}
 
-	// ------------- Path parameter "service_id" -------------
-	var serviceId int64
-
-	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Param("service_id"), &serviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter service_id: %w", err), http.StatusBadRequest)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGameScoreboard(c, id)
+}
+
+// ListGameServices operation middleware
+func (siw *ServerInterfaceWrapper) ListGameServices(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListGameServices(c, id)
+}
+
+// AddGameService operation middleware
+func (siw *ServerInterfaceWrapper) AddGameService(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -2239,11 +4017,11 @@ func (siw *ServerInterfaceWrapper) SetGameServiceStatus(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.SetGameServiceStatus(c, id, serviceId)
+	siw.Handler.AddGameService(c, id)
 }
 
-// ListGameTeams operation middleware
-func (siw *ServerInterfaceWrapper) ListGameTeams(c *gin.Context) {
+// RemoveGameService operation middleware
+func (siw *ServerInterfaceWrapper) RemoveGameService(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2257,6 +4035,17 @@ func (siw *ServerInterfaceWrapper) ListGameTeams(c *gin.Context) {
 		return
 	}
 
+	// ------------- Path parameter "service_id" -------------
+	var serviceId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Param("service_id"), &serviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter service_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -2264,11 +4053,11 @@ func (siw *ServerInterfaceWrapper) ListGameTeams(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListGameTeams(c, id)
+	siw.Handler.RemoveGameService(c, id, serviceId)
 }
 
-// ReorderGameTeams operation middleware
-func (siw *ServerInterfaceWrapper) ReorderGameTeams(c *gin.Context) {
+// SetGameServiceStatus operation middleware
+func (siw *ServerInterfaceWrapper) SetGameServiceStatus(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2282,6 +4071,15 @@ func (siw *ServerInterfaceWrapper) ReorderGameTeams(c *gin.Context) {
 		return
 	}
 
+	// ------------- Path parameter "service_id" -------------
+	var serviceId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Param("service_id"), &serviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter service_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(string(BearerAuthScopes), []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2291,11 +4089,11 @@ func (siw *ServerInterfaceWrapper) ReorderGameTeams(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ReorderGameTeams(c, id)
+	siw.Handler.SetGameServiceStatus(c, id, serviceId)
 }
 
-// UnfinalizeGame operation middleware
-func (siw *ServerInterfaceWrapper) UnfinalizeGame(c *gin.Context) {
+// UnpinGameService operation middleware
+func (siw *ServerInterfaceWrapper) UnpinGameService(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2309,6 +4107,15 @@ func (siw *ServerInterfaceWrapper) UnfinalizeGame(c *gin.Context) {
 		return
 	}
 
+	// ------------- Path parameter "service_id" -------------
+	var serviceId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Param("service_id"), &serviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter service_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(string(BearerAuthScopes), []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2318,11 +4125,32 @@ func (siw *ServerInterfaceWrapper) UnfinalizeGame(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UnfinalizeGame(c, id)
+	siw.Handler.UnpinGameService(c, id, serviceId)
 }
 
-// GetProfile operation middleware
-func (siw *ServerInterfaceWrapper) GetProfile(c *gin.Context) {
+// PinGameService operation middleware
+func (siw *ServerInterfaceWrapper) PinGameService(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "service_id" -------------
+	var serviceId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Param("service_id"), &serviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter service_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(string(BearerAuthScopes), []string{})
 
@@ -2333,11 +4161,48 @@ func (siw *ServerInterfaceWrapper) GetProfile(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetProfile(c)
+	siw.Handler.PinGameService(c, id, serviceId)
 }
 
-// UpdateProfile operation middleware
-func (siw *ServerInterfaceWrapper) UpdateProfile(c *gin.Context) {
+// ListGameTeams operation middleware
+func (siw *ServerInterfaceWrapper) ListGameTeams(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListGameTeams(c, id)
+}
+
+// ReorderGameTeams operation middleware
+func (siw *ServerInterfaceWrapper) ReorderGameTeams(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(string(BearerAuthScopes), []string{})
 
@@ -2348,11 +4213,23 @@ func (siw *ServerInterfaceWrapper) UpdateProfile(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateProfile(c)
+	siw.Handler.ReorderGameTeams(c, id)
 }
 
-// UploadProfileAvatar operation middleware
-func (siw *ServerInterfaceWrapper) UploadProfileAvatar(c *gin.Context) {
+// UnfinalizeGame operation middleware
+func (siw *ServerInterfaceWrapper) UnfinalizeGame(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(string(BearerAuthScopes), []string{})
 
@@ -2363,11 +4240,11 @@ func (siw *ServerInterfaceWrapper) UploadProfileAvatar(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UploadProfileAvatar(c)
+	siw.Handler.UnfinalizeGame(c, id)
 }
 
-// ChangeProfilePassword operation middleware
-func (siw *ServerInterfaceWrapper) ChangeProfilePassword(c *gin.Context) {
+// ListGitCredentials operation middleware
+func (siw *ServerInterfaceWrapper) ListGitCredentials(c *gin.Context) {
 
 	c.Set(string(BearerAuthScopes), []string{})
 
@@ -2378,11 +4255,11 @@ func (siw *ServerInterfaceWrapper) ChangeProfilePassword(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ChangeProfilePassword(c)
+	siw.Handler.ListGitCredentials(c)
 }
 
-// ListProfileSessions operation middleware
-func (siw *ServerInterfaceWrapper) ListProfileSessions(c *gin.Context) {
+// CreateGitCredential operation middleware
+func (siw *ServerInterfaceWrapper) CreateGitCredential(c *gin.Context) {
 
 	c.Set(string(BearerAuthScopes), []string{})
 
@@ -2393,36 +4270,80 @@ func (siw *ServerInterfaceWrapper) ListProfileSessions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListProfileSessions(c)
+	siw.Handler.CreateGitCredential(c)
 }
 
-// ListResults operation middleware
-func (siw *ServerInterfaceWrapper) ListResults(c *gin.Context) {
+// DeleteGitCredential operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGitCredential(c *gin.Context) {
 
 	var err error
 	_ = err
 
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(string(BearerAuthScopes), []string{})
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListResultsParams
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	// ------------- Optional query parameter "game_id" -------------
+	siw.Handler.DeleteGitCredential(c, id)
+}
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "game_id", c.Request.URL.Query(), &params.GameId, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+// GetGitCredential operation middleware
+func (siw *ServerInterfaceWrapper) GetGitCredential(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter game_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "team_id" -------------
+	c.Set(string(BearerAuthScopes), []string{})
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "team_id", c.Request.URL.Query(), &params.TeamId, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGitCredential(c, id)
+}
+
+// UpdateGitCredential operation middleware
+func (siw *ServerInterfaceWrapper) UpdateGitCredential(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter team_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	c.Set(string(BearerAuthScopes), []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -2430,11 +4351,11 @@ func (siw *ServerInterfaceWrapper) ListResults(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListResults(c, params)
+	siw.Handler.UpdateGitCredential(c, id)
 }
 
-// CreateResult operation middleware
-func (siw *ServerInterfaceWrapper) CreateResult(c *gin.Context) {
+// GetProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetProfile(c *gin.Context) {
 
 	c.Set(string(BearerAuthScopes), []string{})
 
@@ -2445,11 +4366,1189 @@ func (siw *ServerInterfaceWrapper) CreateResult(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateResult(c)
+	siw.Handler.GetProfile(c)
 }
 
-// DeleteResult operation middleware
-func (siw *ServerInterfaceWrapper) DeleteResult(c *gin.Context) {
+// UpdateProfile operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProfile(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateProfile(c)
+}
+
+// ListProfileAPITokens operation middleware
+func (siw *ServerInterfaceWrapper) ListProfileAPITokens(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListProfileAPITokens(c)
+}
+
+// CreateProfileAPIToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateProfileAPIToken(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateProfileAPIToken(c)
+}
+
+// RevokeProfileAPIToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokeProfileAPIToken(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RevokeProfileAPIToken(c, id)
+}
+
+// UploadProfileAvatar operation middleware
+func (siw *ServerInterfaceWrapper) UploadProfileAvatar(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UploadProfileAvatar(c)
+}
+
+// SendProfileEmailVerification operation middleware
+func (siw *ServerInterfaceWrapper) SendProfileEmailVerification(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SendProfileEmailVerification(c)
+}
+
+// ListProfileIdentities operation middleware
+func (siw *ServerInterfaceWrapper) ListProfileIdentities(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListProfileIdentities(c)
+}
+
+// LinkProfileIdentity operation middleware
+func (siw *ServerInterfaceWrapper) LinkProfileIdentity(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.LinkProfileIdentity(c)
+}
+
+// UnlinkProfileIdentity operation middleware
+func (siw *ServerInterfaceWrapper) UnlinkProfileIdentity(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UnlinkProfileIdentity(c, id)
+}
+
+// ChangeProfilePassword operation middleware
+func (siw *ServerInterfaceWrapper) ChangeProfilePassword(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ChangeProfilePassword(c)
+}
+
+// ListProfileSessions operation middleware
+func (siw *ServerInterfaceWrapper) ListProfileSessions(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListProfileSessions(c)
+}
+
+// GetProfileTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) GetProfileTwoFactor(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProfileTwoFactor(c)
+}
+
+// ConfirmProfileTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmProfileTwoFactor(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ConfirmProfileTwoFactor(c)
+}
+
+// DisableProfileTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) DisableProfileTwoFactor(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DisableProfileTwoFactor(c)
+}
+
+// StartProfileTwoFactorEnrollment operation middleware
+func (siw *ServerInterfaceWrapper) StartProfileTwoFactorEnrollment(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StartProfileTwoFactorEnrollment(c)
+}
+
+// RegenerateProfileRecoveryCodes operation middleware
+func (siw *ServerInterfaceWrapper) RegenerateProfileRecoveryCodes(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RegenerateProfileRecoveryCodes(c)
+}
+
+// ListResults operation middleware
+func (siw *ServerInterfaceWrapper) ListResults(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListResultsParams
+
+	// ------------- Optional query parameter "game_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "game_id", c.Request.URL.Query(), &params.GameId, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter game_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "team_id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "team_id", c.Request.URL.Query(), &params.TeamId, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter team_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListResults(c, params)
+}
+
+// CreateResult operation middleware
+func (siw *ServerInterfaceWrapper) CreateResult(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateResult(c)
+}
+
+// DeleteResult operation middleware
+func (siw *ServerInterfaceWrapper) DeleteResult(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteResult(c, id)
+}
+
+// GetResult operation middleware
+func (siw *ServerInterfaceWrapper) GetResult(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetResult(c, id)
+}
+
+// UpdateResult operation middleware
+func (siw *ServerInterfaceWrapper) UpdateResult(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateResult(c, id)
+}
+
+// GetGlobalScoreboard operation middleware
+func (siw *ServerInterfaceWrapper) GetGlobalScoreboard(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGlobalScoreboard(c)
+}
+
+// ListServiceSubmissions operation middleware
+func (siw *ServerInterfaceWrapper) ListServiceSubmissions(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListServiceSubmissionsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", c.Request.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "per_page", c.Request.URL.Query(), &params.PerPage, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter per_page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "status", c.Request.URL.Query(), &params.Status, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListServiceSubmissions(c, params)
+}
+
+// SubmitServiceFromGit operation middleware
+func (siw *ServerInterfaceWrapper) SubmitServiceFromGit(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SubmitServiceFromGit(c)
+}
+
+// SubmitServiceFromZip operation middleware
+func (siw *ServerInterfaceWrapper) SubmitServiceFromZip(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SubmitServiceFromZip(c)
+}
+
+// GetServiceSubmission operation middleware
+func (siw *ServerInterfaceWrapper) GetServiceSubmission(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetServiceSubmission(c, id)
+}
+
+// ListServiceSubmissionComments operation middleware
+func (siw *ServerInterfaceWrapper) ListServiceSubmissionComments(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListServiceSubmissionComments(c, id)
+}
+
+// AddServiceSubmissionComment operation middleware
+func (siw *ServerInterfaceWrapper) AddServiceSubmissionComment(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddServiceSubmissionComment(c, id)
+}
+
+// ResubmitServiceFromGit operation middleware
+func (siw *ServerInterfaceWrapper) ResubmitServiceFromGit(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ResubmitServiceFromGit(c, id)
+}
+
+// ReviewServiceSubmission operation middleware
+func (siw *ServerInterfaceWrapper) ReviewServiceSubmission(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReviewServiceSubmission(c, id)
+}
+
+// ResubmitServiceFromZip operation middleware
+func (siw *ServerInterfaceWrapper) ResubmitServiceFromZip(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ResubmitServiceFromZip(c, id)
+}
+
+// ListServices operation middleware
+func (siw *ServerInterfaceWrapper) ListServices(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListServicesParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", c.Request.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "per_page", c.Request.URL.Query(), &params.PerPage, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter per_page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "public" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "public", c.Request.URL.Query(), &params.Public, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter public: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "q", c.Request.URL.Query(), &params.Q, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListServices(c, params)
+}
+
+// CreateService operation middleware
+func (siw *ServerInterfaceWrapper) CreateService(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateService(c)
+}
+
+// ImportServiceFromGit operation middleware
+func (siw *ServerInterfaceWrapper) ImportServiceFromGit(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportServiceFromGit(c)
+}
+
+// ImportServicesFromGit operation middleware
+func (siw *ServerInterfaceWrapper) ImportServicesFromGit(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportServicesFromGit(c)
+}
+
+// DiscoverServicesInGit operation middleware
+func (siw *ServerInterfaceWrapper) DiscoverServicesInGit(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DiscoverServicesInGit(c)
+}
+
+// PreviewServiceGitImport operation middleware
+func (siw *ServerInterfaceWrapper) PreviewServiceGitImport(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PreviewServiceGitImport(c)
+}
+
+// ImportServiceFromZip operation middleware
+func (siw *ServerInterfaceWrapper) ImportServiceFromZip(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportServiceFromZip(c)
+}
+
+// PreviewServiceZipImport operation middleware
+func (siw *ServerInterfaceWrapper) PreviewServiceZipImport(c *gin.Context) {
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PreviewServiceZipImport(c)
+}
+
+// ValidateServiceManifest operation middleware
+func (siw *ServerInterfaceWrapper) ValidateServiceManifest(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ValidateServiceManifest(c)
+}
+
+// SearchServices operation middleware
+func (siw *ServerInterfaceWrapper) SearchServices(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchServicesParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", c.Request.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "per_page", c.Request.URL.Query(), &params.PerPage, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter per_page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "q", c.Request.URL.Query(), &params.Q, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tech" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "tech", c.Request.URL.Query(), &params.Tech, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tech: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "license" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "license", c.Request.URL.Query(), &params.License, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter license: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "check_status" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "check_status", c.Request.URL.Query(), &params.CheckStatus, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter check_status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "games_usage" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "games_usage", c.Request.URL.Query(), &params.GamesUsage, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter games_usage: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SearchServices(c, params)
+}
+
+// DeleteService operation middleware
+func (siw *ServerInterfaceWrapper) DeleteService(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteService(c, id)
+}
+
+// GetService operation middleware
+func (siw *ServerInterfaceWrapper) GetService(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetService(c, id)
+}
+
+// UpdateService operation middleware
+func (siw *ServerInterfaceWrapper) UpdateService(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateService(c, id)
+}
+
+// ListServiceArchiveVersions operation middleware
+func (siw *ServerInterfaceWrapper) ListServiceArchiveVersions(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListServiceArchiveVersionsParams
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "kind", c.Request.URL.Query(), &params.Kind, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter kind: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListServiceArchiveVersions(c, id, params)
+}
+
+// DiffServiceArchiveVersions operation middleware
+func (siw *ServerInterfaceWrapper) DiffServiceArchiveVersions(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DiffServiceArchiveVersionsParams
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "from", c.Request.URL.Query(), &params.From, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "to", c.Request.URL.Query(), &params.To, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DiffServiceArchiveVersions(c, id, params)
+}
+
+// RollbackServiceArchiveVersion operation middleware
+func (siw *ServerInterfaceWrapper) RollbackServiceArchiveVersion(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "version_id" -------------
+	var versionId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version_id", c.Param("version_id"), &versionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RollbackServiceArchiveVersion(c, id, versionId)
+}
+
+// ListServiceAuthors operation middleware
+func (siw *ServerInterfaceWrapper) ListServiceAuthors(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListServiceAuthors(c, id)
+}
+
+// AddServiceAuthor operation middleware
+func (siw *ServerInterfaceWrapper) AddServiceAuthor(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddServiceAuthor(c, id)
+}
+
+// RemoveServiceAuthor operation middleware
+func (siw *ServerInterfaceWrapper) RemoveServiceAuthor(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveServiceAuthor(c, id, userId)
+}
+
+// CheckServiceChecker operation middleware
+func (siw *ServerInterfaceWrapper) CheckServiceChecker(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2472,11 +5571,11 @@ func (siw *ServerInterfaceWrapper) DeleteResult(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteResult(c, id)
+	siw.Handler.CheckServiceChecker(c, id)
 }
 
-// GetResult operation middleware
-func (siw *ServerInterfaceWrapper) GetResult(c *gin.Context) {
+// CreateServiceDownloadLink operation middleware
+func (siw *ServerInterfaceWrapper) CreateServiceDownloadLink(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2499,11 +5598,11 @@ func (siw *ServerInterfaceWrapper) GetResult(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetResult(c, id)
+	siw.Handler.CreateServiceDownloadLink(c, id)
 }
 
-// UpdateResult operation middleware
-func (siw *ServerInterfaceWrapper) UpdateResult(c *gin.Context) {
+// DownloadServiceArchive operation middleware
+func (siw *ServerInterfaceWrapper) DownloadServiceArchive(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2517,20 +5616,16 @@ func (siw *ServerInterfaceWrapper) UpdateResult(c *gin.Context) {
 		return
 	}
 
-	c.Set(string(BearerAuthScopes), []string{})
+	// ------------- Path parameter "kind" -------------
+	var kind DownloadServiceArchiveParamsKind
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	err = runtime.BindStyledParameterWithOptions("simple", "kind", c.Param("kind"), &kind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter kind: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.UpdateResult(c, id)
-}
-
-// GetGlobalScoreboard operation middleware
-func (siw *ServerInterfaceWrapper) GetGlobalScoreboard(c *gin.Context) {
+	c.Set(string(BearerAuthScopes), []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -2539,80 +5634,60 @@ func (siw *ServerInterfaceWrapper) GetGlobalScoreboard(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetGlobalScoreboard(c)
+	siw.Handler.DownloadServiceArchive(c, id, kind)
 }
 
-// ListServices operation middleware
-func (siw *ServerInterfaceWrapper) ListServices(c *gin.Context) {
+// DownloadServiceArchiveSigned operation middleware
+func (siw *ServerInterfaceWrapper) DownloadServiceArchiveSigned(c *gin.Context) {
 
 	var err error
 	_ = err
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListServicesParams
-
-	// ------------- Optional query parameter "page" -------------
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", c.Request.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "per_page" -------------
+	// ------------- Path parameter "kind" -------------
+	var kind DownloadServiceArchiveSignedParamsKind
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "per_page", c.Request.URL.Query(), &params.PerPage, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	err = runtime.BindStyledParameterWithOptions("simple", "kind", c.Param("kind"), &kind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter per_page: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter kind: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "public" -------------
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DownloadServiceArchiveSignedParams
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "public", c.Request.URL.Query(), &params.Public, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	// ------------- Required query parameter "link" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "link", c.Request.URL.Query(), &params.Link, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter public: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter link: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "q" -------------
+	// ------------- Required query parameter "expires" -------------
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "q", c.Request.URL.Query(), &params.Q, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "expires", c.Request.URL.Query(), &params.Expires, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter expires: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
-
-	siw.Handler.ListServices(c, params)
-}
-
-// CreateService operation middleware
-func (siw *ServerInterfaceWrapper) CreateService(c *gin.Context) {
-
-	c.Set(string(BearerAuthScopes), []string{})
+	// ------------- Required query parameter "signature" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "signature", c.Request.URL.Query(), &params.Signature, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter signature: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.CreateService(c)
-}
-
-// ImportServiceFromGit operation middleware
-func (siw *ServerInterfaceWrapper) ImportServiceFromGit(c *gin.Context) {
-
-	c.Set(string(BearerAuthScopes), []string{})
-
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -2620,43 +5695,36 @@ func (siw *ServerInterfaceWrapper) ImportServiceFromGit(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ImportServiceFromGit(c)
+	siw.Handler.DownloadServiceArchiveSigned(c, id, kind, params)
 }
 
-// PreviewServiceGitImport operation middleware
-func (siw *ServerInterfaceWrapper) PreviewServiceGitImport(c *gin.Context) {
-
-	c.Set(string(BearerAuthScopes), []string{})
+// ListServiceGamePins operation middleware
+func (siw *ServerInterfaceWrapper) ListServiceGamePins(c *gin.Context) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	var err error
+	_ = err
 
-	siw.Handler.PreviewServiceGitImport(c)
-}
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-// ImportServiceFromZip operation middleware
-func (siw *ServerInterfaceWrapper) ImportServiceFromZip(c *gin.Context) {
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(string(BearerAuthScopes), []string{})
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
-
-	siw.Handler.ImportServiceFromZip(c)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListServiceGamePinsParams
 
-// PreviewServiceZipImport operation middleware
-func (siw *ServerInterfaceWrapper) PreviewServiceZipImport(c *gin.Context) {
+	// ------------- Optional query parameter "version_id" -------------
 
-	c.Set(string(BearerAuthScopes), []string{})
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "version_id", c.Request.URL.Query(), &params.VersionId, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -2665,11 +5733,11 @@ func (siw *ServerInterfaceWrapper) PreviewServiceZipImport(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PreviewServiceZipImport(c)
+	siw.Handler.ListServiceGamePins(c, id, params)
 }
 
-// DeleteService operation middleware
-func (siw *ServerInterfaceWrapper) DeleteService(c *gin.Context) {
+// GetServiceReadme operation middleware
+func (siw *ServerInterfaceWrapper) GetServiceReadme(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2683,7 +5751,16 @@ func (siw *ServerInterfaceWrapper) DeleteService(c *gin.Context) {
 		return
 	}
 
-	c.Set(string(BearerAuthScopes), []string{})
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetServiceReadmeParams
+
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "version", c.Request.URL.Query(), &params.Version, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -2692,11 +5769,11 @@ func (siw *ServerInterfaceWrapper) DeleteService(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteService(c, id)
+	siw.Handler.GetServiceReadme(c, id, params)
 }
 
-// GetService operation middleware
-func (siw *ServerInterfaceWrapper) GetService(c *gin.Context) {
+// GetServiceReadmeAsset operation middleware
+func (siw *ServerInterfaceWrapper) GetServiceReadmeAsset(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2710,33 +5787,25 @@ func (siw *ServerInterfaceWrapper) GetService(c *gin.Context) {
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
-
-	siw.Handler.GetService(c, id)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetServiceReadmeAssetParams
 
-// UpdateService operation middleware
-func (siw *ServerInterfaceWrapper) UpdateService(c *gin.Context) {
+	// ------------- Required query parameter "version" -------------
 
-	var err error
-	_ = err
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "version", c.Request.URL.Query(), &params.Version, runtime.BindQueryParameterOptions{Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter version: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	// ------------- Path parameter "id" -------------
-	var id int64
+	// ------------- Required query parameter "path" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "path", c.Request.URL.Query(), &params.Path, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	c.Set(string(BearerAuthScopes), []string{})
-
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -2744,11 +5813,11 @@ func (siw *ServerInterfaceWrapper) UpdateService(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateService(c, id)
+	siw.Handler.GetServiceReadmeAsset(c, id, params)
 }
 
-// CheckServiceChecker operation middleware
-func (siw *ServerInterfaceWrapper) CheckServiceChecker(c *gin.Context) {
+// RedownloadServiceArchives operation middleware
+func (siw *ServerInterfaceWrapper) RedownloadServiceArchives(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2771,11 +5840,11 @@ func (siw *ServerInterfaceWrapper) CheckServiceChecker(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CheckServiceChecker(c, id)
+	siw.Handler.RedownloadServiceArchives(c, id)
 }
 
-// DownloadServiceArchive operation middleware
-func (siw *ServerInterfaceWrapper) DownloadServiceArchive(c *gin.Context) {
+// SyncServiceFromGit operation middleware
+func (siw *ServerInterfaceWrapper) SyncServiceFromGit(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2789,15 +5858,6 @@ func (siw *ServerInterfaceWrapper) DownloadServiceArchive(c *gin.Context) {
 		return
 	}
 
-	// ------------- Path parameter "kind" -------------
-	var kind DownloadServiceArchiveParamsKind
-
-	err = runtime.BindStyledParameterWithOptions("simple", "kind", c.Param("kind"), &kind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter kind: %w", err), http.StatusBadRequest)
-		return
-	}
-
 	c.Set(string(BearerAuthScopes), []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2807,11 +5867,11 @@ func (siw *ServerInterfaceWrapper) DownloadServiceArchive(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DownloadServiceArchive(c, id, kind)
+	siw.Handler.SyncServiceFromGit(c, id)
 }
 
-// RedownloadServiceArchives operation middleware
-func (siw *ServerInterfaceWrapper) RedownloadServiceArchives(c *gin.Context) {
+// ToggleServicePublic operation middleware
+func (siw *ServerInterfaceWrapper) ToggleServicePublic(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2834,11 +5894,11 @@ func (siw *ServerInterfaceWrapper) RedownloadServiceArchives(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.RedownloadServiceArchives(c, id)
+	siw.Handler.ToggleServicePublic(c, id)
 }
 
-// SyncServiceFromGit operation middleware
-func (siw *ServerInterfaceWrapper) SyncServiceFromGit(c *gin.Context) {
+// UploadServiceArchives operation middleware
+func (siw *ServerInterfaceWrapper) UploadServiceArchives(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2861,11 +5921,11 @@ func (siw *ServerInterfaceWrapper) SyncServiceFromGit(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.SyncServiceFromGit(c, id)
+	siw.Handler.UploadServiceArchives(c, id)
 }
 
-// ToggleServicePublic operation middleware
-func (siw *ServerInterfaceWrapper) ToggleServicePublic(c *gin.Context) {
+// ListServiceVulns operation middleware
+func (siw *ServerInterfaceWrapper) ListServiceVulns(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2888,11 +5948,11 @@ func (siw *ServerInterfaceWrapper) ToggleServicePublic(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ToggleServicePublic(c, id)
+	siw.Handler.ListServiceVulns(c, id)
 }
 
-// UploadServiceArchives operation middleware
-func (siw *ServerInterfaceWrapper) UploadServiceArchives(c *gin.Context) {
+// ReplaceServiceVulns operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceServiceVulns(c *gin.Context) {
 
 	var err error
 	_ = err
@@ -2915,7 +5975,7 @@ func (siw *ServerInterfaceWrapper) UploadServiceArchives(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UploadServiceArchives(c, id)
+	siw.Handler.ReplaceServiceVulns(c, id)
 }
 
 // Logout operation middleware
@@ -2946,6 +6006,45 @@ func (siw *ServerInterfaceWrapper) Login(c *gin.Context) {
 	siw.Handler.Login(c)
 }
 
+// RefreshSession operation middleware
+func (siw *ServerInterfaceWrapper) RefreshSession(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RefreshSession(c)
+}
+
+// CompleteTwoFactorLogin operation middleware
+func (siw *ServerInterfaceWrapper) CompleteTwoFactorLogin(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CompleteTwoFactorLogin(c)
+}
+
+// StartTwoFactorLoginEnrollment operation middleware
+func (siw *ServerInterfaceWrapper) StartTwoFactorLoginEnrollment(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StartTwoFactorLoginEnrollment(c)
+}
+
 // ListTeamMemberships operation middleware
 func (siw *ServerInterfaceWrapper) ListTeamMemberships(c *gin.Context) {
 
@@ -3981,6 +7080,33 @@ func (siw *ServerInterfaceWrapper) RevokeUserSession(c *gin.Context) {
 	siw.Handler.RevokeUserSession(c, id, sessionId)
 }
 
+// ResetUserTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) ResetUserTwoFactor(c *gin.Context) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int64"})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(string(BearerAuthScopes), []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ResetUserTwoFactor(c, id)
+}
+
 // ListWriteups operation middleware
 func (siw *ServerInterfaceWrapper) ListWriteups(c *gin.Context) {
 
@@ -4114,6 +7240,15 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/audit-events", wrapper.ListAuditEvents)
+	router.POST(options.BaseURL+"/auth/email-verification/confirm", wrapper.ConfirmEmailVerification)
+	router.GET(options.BaseURL+"/auth/oidc/providers", wrapper.ListOIDCProviders)
+	router.POST(options.BaseURL+"/auth/oidc/:provider/authorize", wrapper.StartOIDCLogin)
+	router.POST(options.BaseURL+"/auth/oidc/:provider/callback", wrapper.CompleteOIDCLogin)
+	router.POST(options.BaseURL+"/auth/password-reset", wrapper.RequestPasswordReset)
+	router.POST(options.BaseURL+"/auth/password-reset/confirm", wrapper.ConfirmPasswordReset)
+	router.GET(options.BaseURL+"/auth/two-factor/policy", wrapper.GetTwoFactorPolicy)
+	router.PUT(options.BaseURL+"/auth/two-factor/policy", wrapper.UpdateTwoFactorPolicy)
 	router.POST(options.BaseURL+"/game-teams", wrapper.CreateGameTeam)
 	router.DELETE(options.BaseURL+"/game-teams/:id", wrapper.DeleteGameTeam)
 	router.PATCH(options.BaseURL+"/game-teams/:id", wrapper.UpdateGameTeam)
@@ -4125,43 +7260,95 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.POST(options.BaseURL+"/games/:id/export/ctf01d", wrapper.ExportCtf01d)
 	router.GET(options.BaseURL+"/games/:id/export/ctf01d/options", wrapper.GetCtf01dExportOptions)
 	router.POST(options.BaseURL+"/games/:id/finalize", wrapper.FinalizeGame)
+	router.GET(options.BaseURL+"/games/:id/port-conflicts", wrapper.ListGamePortConflicts)
 	router.POST(options.BaseURL+"/games/:id/publish", wrapper.PublishGame)
+	router.GET(options.BaseURL+"/games/:id/roles", wrapper.ListGameRoles)
+	router.DELETE(options.BaseURL+"/games/:id/roles/:user_id", wrapper.RemoveGameRole)
+	router.PUT(options.BaseURL+"/games/:id/roles/:user_id", wrapper.SetGameRole)
 	router.GET(options.BaseURL+"/games/:id/scoreboard", wrapper.GetGameScoreboard)
 	router.GET(options.BaseURL+"/games/:id/services", wrapper.ListGameServices)
 	router.POST(options.BaseURL+"/games/:id/services", wrapper.AddGameService)
 	router.DELETE(options.BaseURL+"/games/:id/services/:service_id", wrapper.RemoveGameService)
 	router.PATCH(options.BaseURL+"/games/:id/services/:service_id", wrapper.SetGameServiceStatus)
+	router.DELETE(options.BaseURL+"/games/:id/services/:service_id/pin", wrapper.UnpinGameService)
+	router.PUT(options.BaseURL+"/games/:id/services/:service_id/pin", wrapper.PinGameService)
 	router.GET(options.BaseURL+"/games/:id/teams", wrapper.ListGameTeams)
 	router.POST(options.BaseURL+"/games/:id/teams/reorder", wrapper.ReorderGameTeams)
 	router.POST(options.BaseURL+"/games/:id/unfinalize", wrapper.UnfinalizeGame)
+	router.GET(options.BaseURL+"/git-credentials", wrapper.ListGitCredentials)
+	router.POST(options.BaseURL+"/git-credentials", wrapper.CreateGitCredential)
+	router.DELETE(options.BaseURL+"/git-credentials/:id", wrapper.DeleteGitCredential)
+	router.GET(options.BaseURL+"/git-credentials/:id", wrapper.GetGitCredential)
+	router.PATCH(options.BaseURL+"/git-credentials/:id", wrapper.UpdateGitCredential)
 	router.GET(options.BaseURL+"/profile", wrapper.GetProfile)
 	router.PATCH(options.BaseURL+"/profile", wrapper.UpdateProfile)
+	router.GET(options.BaseURL+"/profile/api-tokens", wrapper.ListProfileAPITokens)
+	router.POST(options.BaseURL+"/profile/api-tokens", wrapper.CreateProfileAPIToken)
+	router.DELETE(options.BaseURL+"/profile/api-tokens/:id", wrapper.RevokeProfileAPIToken)
 	router.POST(options.BaseURL+"/profile/avatar", wrapper.UploadProfileAvatar)
+	router.POST(options.BaseURL+"/profile/email/verification", wrapper.SendProfileEmailVerification)
+	router.GET(options.BaseURL+"/profile/identities", wrapper.ListProfileIdentities)
+	router.POST(options.BaseURL+"/profile/identities", wrapper.LinkProfileIdentity)
+	router.DELETE(options.BaseURL+"/profile/identities/:id", wrapper.UnlinkProfileIdentity)
 	router.PUT(options.BaseURL+"/profile/password", wrapper.ChangeProfilePassword)
 	router.GET(options.BaseURL+"/profile/sessions", wrapper.ListProfileSessions)
+	router.GET(options.BaseURL+"/profile/two-factor", wrapper.GetProfileTwoFactor)
+	router.POST(options.BaseURL+"/profile/two-factor/confirm", wrapper.ConfirmProfileTwoFactor)
+	router.POST(options.BaseURL+"/profile/two-factor/disable", wrapper.DisableProfileTwoFactor)
+	router.POST(options.BaseURL+"/profile/two-factor/enrollment", wrapper.StartProfileTwoFactorEnrollment)
+	router.POST(options.BaseURL+"/profile/two-factor/recovery-codes", wrapper.RegenerateProfileRecoveryCodes)
 	router.GET(options.BaseURL+"/results", wrapper.ListResults)
 	router.POST(options.BaseURL+"/results", wrapper.CreateResult)
 	router.DELETE(options.BaseURL+"/results/:id", wrapper.DeleteResult)
 	router.GET(options.BaseURL+"/results/:id", wrapper.GetResult)
 	router.PATCH(options.BaseURL+"/results/:id", wrapper.UpdateResult)
 	router.GET(options.BaseURL+"/scoreboard", wrapper.GetGlobalScoreboard)
+	router.GET(options.BaseURL+"/service-submissions", wrapper.ListServiceSubmissions)
+	router.POST(options.BaseURL+"/service-submissions/git", wrapper.SubmitServiceFromGit)
+	router.POST(options.BaseURL+"/service-submissions/zip", wrapper.SubmitServiceFromZip)
+	router.GET(options.BaseURL+"/service-submissions/:id", wrapper.GetServiceSubmission)
+	router.GET(options.BaseURL+"/service-submissions/:id/comments", wrapper.ListServiceSubmissionComments)
+	router.POST(options.BaseURL+"/service-submissions/:id/comments", wrapper.AddServiceSubmissionComment)
+	router.POST(options.BaseURL+"/service-submissions/:id/git", wrapper.ResubmitServiceFromGit)
+	router.POST(options.BaseURL+"/service-submissions/:id/review", wrapper.ReviewServiceSubmission)
+	router.POST(options.BaseURL+"/service-submissions/:id/zip", wrapper.ResubmitServiceFromZip)
 	router.GET(options.BaseURL+"/services", wrapper.ListServices)
 	router.POST(options.BaseURL+"/services", wrapper.CreateService)
 	router.POST(options.BaseURL+"/services/import/git", wrapper.ImportServiceFromGit)
+	router.POST(options.BaseURL+"/services/import/git/batch", wrapper.ImportServicesFromGit)
+	router.POST(options.BaseURL+"/services/import/git/discover", wrapper.DiscoverServicesInGit)
 	router.POST(options.BaseURL+"/services/import/git/preview", wrapper.PreviewServiceGitImport)
 	router.POST(options.BaseURL+"/services/import/zip", wrapper.ImportServiceFromZip)
 	router.POST(options.BaseURL+"/services/import/zip/preview", wrapper.PreviewServiceZipImport)
+	router.POST(options.BaseURL+"/services/manifest/validate", wrapper.ValidateServiceManifest)
+	router.GET(options.BaseURL+"/services/search", wrapper.SearchServices)
 	router.DELETE(options.BaseURL+"/services/:id", wrapper.DeleteService)
 	router.GET(options.BaseURL+"/services/:id", wrapper.GetService)
 	router.PATCH(options.BaseURL+"/services/:id", wrapper.UpdateService)
+	router.GET(options.BaseURL+"/services/:id/archive-versions", wrapper.ListServiceArchiveVersions)
+	router.GET(options.BaseURL+"/services/:id/archive-versions/diff", wrapper.DiffServiceArchiveVersions)
+	router.POST(options.BaseURL+"/services/:id/archive-versions/:version_id/rollback", wrapper.RollbackServiceArchiveVersion)
+	router.GET(options.BaseURL+"/services/:id/authors", wrapper.ListServiceAuthors)
+	router.POST(options.BaseURL+"/services/:id/authors", wrapper.AddServiceAuthor)
+	router.DELETE(options.BaseURL+"/services/:id/authors/:user_id", wrapper.RemoveServiceAuthor)
 	router.POST(options.BaseURL+"/services/:id/check-checker", wrapper.CheckServiceChecker)
+	router.POST(options.BaseURL+"/services/:id/download-links", wrapper.CreateServiceDownloadLink)
 	router.GET(options.BaseURL+"/services/:id/download/:kind", wrapper.DownloadServiceArchive)
+	router.GET(options.BaseURL+"/services/:id/download/:kind/signed", wrapper.DownloadServiceArchiveSigned)
+	router.GET(options.BaseURL+"/services/:id/game-pins", wrapper.ListServiceGamePins)
+	router.GET(options.BaseURL+"/services/:id/readme", wrapper.GetServiceReadme)
+	router.GET(options.BaseURL+"/services/:id/readme/assets", wrapper.GetServiceReadmeAsset)
 	router.POST(options.BaseURL+"/services/:id/redownload", wrapper.RedownloadServiceArchives)
 	router.POST(options.BaseURL+"/services/:id/sync-from-git", wrapper.SyncServiceFromGit)
 	router.POST(options.BaseURL+"/services/:id/toggle-public", wrapper.ToggleServicePublic)
 	router.POST(options.BaseURL+"/services/:id/upload-archives", wrapper.UploadServiceArchives)
+	router.GET(options.BaseURL+"/services/:id/vulns", wrapper.ListServiceVulns)
+	router.PUT(options.BaseURL+"/services/:id/vulns", wrapper.ReplaceServiceVulns)
 	router.DELETE(options.BaseURL+"/session", wrapper.Logout)
 	router.POST(options.BaseURL+"/session", wrapper.Login)
+	router.POST(options.BaseURL+"/session/refresh", wrapper.RefreshSession)
+	router.POST(options.BaseURL+"/session/two-factor", wrapper.CompleteTwoFactorLogin)
+	router.POST(options.BaseURL+"/session/two-factor/enrollment", wrapper.StartTwoFactorLoginEnrollment)
 	router.GET(options.BaseURL+"/team-memberships", wrapper.ListTeamMemberships)
 	router.POST(options.BaseURL+"/team-memberships", wrapper.CreateTeamMembership)
 	router.DELETE(options.BaseURL+"/team-memberships/:id", wrapper.DeleteTeamMembership)
@@ -4199,6 +7386,7 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.PATCH(options.BaseURL+"/users/:id/role", wrapper.UpdateUserRole)
 	router.GET(options.BaseURL+"/users/:id/sessions", wrapper.ListUserSessions)
 	router.DELETE(options.BaseURL+"/users/:id/sessions/:sessionId", wrapper.RevokeUserSession)
+	router.DELETE(options.BaseURL+"/users/:id/two-factor", wrapper.ResetUserTwoFactor)
 	router.GET(options.BaseURL+"/writeups", wrapper.ListWriteups)
 	router.POST(options.BaseURL+"/writeups", wrapper.CreateWriteup)
 	router.DELETE(options.BaseURL+"/writeups/:id", wrapper.DeleteWriteup)
@@ -4,48 +4,143 @@ package httpserver
 
 // OperationRequiredRoles maps OpenAPI operation keys to the minimum hierarchy role declared via x-required-role.
 var OperationRequiredRoles = map[string]string{
-	"DELETE /game-teams/{id}":                  "player",
-	"DELETE /games/{id}":                       "player",
-	"DELETE /games/{id}/services/{service_id}": "player",
-	"DELETE /results/{id}":                     "player",
-	"DELETE /services/{id}":                    "player",
-	"DELETE /universities/{id}":                "admin",
-	"DELETE /users/{id}":                       "admin",
-	"DELETE /users/{id}/sessions/{sessionId}":  "admin",
-	"GET /games/{id}/export/ctf01d/options":    "player",
-	"GET /users/{id}/sessions":                 "admin",
-	"PATCH /game-teams/{id}":                   "player",
-	"PATCH /games/{id}":                        "player",
-	"PATCH /games/{id}/services/{service_id}":  "player",
-	"PATCH /results/{id}":                      "player",
-	"PATCH /services/{id}":                     "player",
-	"PATCH /team-memberships/{id}":             "admin",
-	"PATCH /universities/{id}":                 "admin",
-	"PATCH /users/{id}/profile":                "admin",
-	"PATCH /users/{id}/role":                   "admin",
-	"POST /game-teams":                         "player",
-	"POST /games":                              "player",
-	"POST /games/{id}/export/ctf01d":           "player",
-	"POST /games/{id}/finalize":                "player",
-	"POST /games/{id}/publish":                 "player",
-	"POST /games/{id}/services":                "player",
-	"POST /games/{id}/teams/reorder":           "player",
-	"POST /games/{id}/unfinalize":              "player",
-	"POST /results":                            "player",
-	"POST /services":                           "player",
-	"POST /services/import/git":                "admin",
-	"POST /services/import/git/preview":        "admin",
-	"POST /services/import/zip":                "player",
-	"POST /services/import/zip/preview":        "player",
-	"POST /services/{id}/check-checker":        "player",
-	"POST /services/{id}/redownload":           "player",
-	"POST /services/{id}/sync-from-git":        "admin",
-	"POST /services/{id}/toggle-public":        "player",
-	"POST /services/{id}/upload-archives":      "player",
-	"POST /team-memberships":                   "admin",
-	"POST /universities":                       "admin",
-	"POST /users":                              "admin",
-	"POST /users/{id}/avatar":                  "admin",
-	"POST /users/{id}/block":                   "admin",
-	"PUT /users/{id}/password":                 "admin",
+	"DELETE /game-teams/{id}":                                    "admin",
+	"DELETE /games/{id}":                                         "admin",
+	"DELETE /games/{id}/roles/{user_id}":                         "admin",
+	"DELETE /games/{id}/services/{service_id}":                   "admin",
+	"DELETE /games/{id}/services/{service_id}/pin":               "admin",
+	"DELETE /git-credentials/{id}":                               "admin",
+	"DELETE /results/{id}":                                       "admin",
+	"DELETE /services/{id}":                                      "player",
+	"DELETE /services/{id}/authors/{user_id}":                    "admin",
+	"DELETE /universities/{id}":                                  "admin",
+	"DELETE /users/{id}":                                         "admin",
+	"DELETE /users/{id}/sessions/{sessionId}":                    "admin",
+	"DELETE /users/{id}/two-factor":                              "admin",
+	"GET /audit-events":                                          "admin",
+	"GET /auth/two-factor/policy":                                "admin",
+	"GET /games/{id}/export/ctf01d/options":                      "admin",
+	"GET /games/{id}/port-conflicts":                             "admin",
+	"GET /games/{id}/roles":                                      "admin",
+	"GET /git-credentials":                                       "admin",
+	"GET /git-credentials/{id}":                                  "admin",
+	"GET /service-submissions":                                   "player",
+	"GET /service-submissions/{id}":                              "player",
+	"GET /service-submissions/{id}/comments":                     "player",
+	"GET /services/{id}/archive-versions":                        "player",
+	"GET /services/{id}/archive-versions/diff":                   "player",
+	"GET /services/{id}/game-pins":                               "player",
+	"GET /services/{id}/vulns":                                   "player",
+	"GET /users/{id}/sessions":                                   "admin",
+	"PATCH /game-teams/{id}":                                     "admin",
+	"PATCH /games/{id}":                                          "admin",
+	"PATCH /games/{id}/services/{service_id}":                    "admin",
+	"PATCH /git-credentials/{id}":                                "admin",
+	"PATCH /results/{id}":                                        "admin",
+	"PATCH /services/{id}":                                       "admin",
+	"PATCH /team-memberships/{id}":                               "admin",
+	"PATCH /universities/{id}":                                   "admin",
+	"PATCH /users/{id}/profile":                                  "admin",
+	"PATCH /users/{id}/role":                                     "admin",
+	"POST /games":                                                "player",
+	"POST /games/{id}/export/ctf01d":                             "admin",
+	"POST /games/{id}/finalize":                                  "admin",
+	"POST /games/{id}/publish":                                   "admin",
+	"POST /games/{id}/services":                                  "admin",
+	"POST /games/{id}/teams/reorder":                             "admin",
+	"POST /games/{id}/unfinalize":                                "admin",
+	"POST /git-credentials":                                      "admin",
+	"POST /service-submissions/git":                              "player",
+	"POST /service-submissions/zip":                              "player",
+	"POST /service-submissions/{id}/comments":                    "player",
+	"POST /service-submissions/{id}/git":                         "player",
+	"POST /service-submissions/{id}/review":                      "admin",
+	"POST /service-submissions/{id}/zip":                         "player",
+	"POST /services":                                             "player",
+	"POST /services/import/git":                                  "admin",
+	"POST /services/import/git/batch":                            "admin",
+	"POST /services/import/git/discover":                         "admin",
+	"POST /services/import/git/preview":                          "admin",
+	"POST /services/import/zip":                                  "player",
+	"POST /services/import/zip/preview":                          "player",
+	"POST /services/{id}/archive-versions/{version_id}/rollback": "admin",
+	"POST /services/{id}/authors":                                "admin",
+	"POST /services/{id}/check-checker":                          "admin",
+	"POST /services/{id}/download-links":                         "admin",
+	"POST /services/{id}/redownload":                             "admin",
+	"POST /services/{id}/sync-from-git":                          "admin",
+	"POST /services/{id}/toggle-public":                          "player",
+	"POST /services/{id}/upload-archives":                        "admin",
+	"POST /team-memberships":                                     "admin",
+	"POST /universities":                                         "admin",
+	"POST /users":                                                "admin",
+	"POST /users/{id}/avatar":                                    "admin",
+	"POST /users/{id}/block":                                     "admin",
+	"PUT /auth/two-factor/policy":                                "admin",
+	"PUT /games/{id}/roles/{user_id}":                            "admin",
+	"PUT /games/{id}/services/{service_id}/pin":                  "admin",
+	"PUT /services/{id}/vulns":                                   "admin",
+	"PUT /users/{id}/password":                                   "admin",
+}
+
+// OperationResourcePermissions maps OpenAPI operation keys to the resource-level permission declared via x-resource-permission.
+var OperationResourcePermissions = map[string]string{
+	"DELETE /game-teams/{id}":                                    "game_team_organizer",
+	"DELETE /games/{id}/roles/{user_id}":                         "game_organizer",
+	"DELETE /games/{id}/services/{service_id}":                   "game_organizer",
+	"DELETE /games/{id}/services/{service_id}/pin":               "game_organizer",
+	"DELETE /results/{id}":                                       "result_jury",
+	"GET /games/{id}/export/ctf01d/options":                      "game_organizer",
+	"GET /games/{id}/port-conflicts":                             "game_organizer",
+	"GET /games/{id}/roles":                                      "game_organizer",
+	"GET /services/{id}":                                         "service_author",
+	"GET /services/{id}/archive-versions":                        "service_author",
+	"GET /services/{id}/archive-versions/diff":                   "service_author",
+	"GET /services/{id}/vulns":                                   "service_author",
+	"PATCH /game-teams/{id}":                                     "game_team_organizer",
+	"PATCH /games/{id}":                                          "game_organizer",
+	"PATCH /games/{id}/services/{service_id}":                    "game_organizer",
+	"PATCH /results/{id}":                                        "result_jury",
+	"PATCH /services/{id}":                                       "service_author",
+	"POST /games/{id}/export/ctf01d":                             "game_organizer",
+	"POST /games/{id}/finalize":                                  "game_organizer",
+	"POST /games/{id}/publish":                                   "game_organizer",
+	"POST /games/{id}/services":                                  "game_organizer",
+	"POST /games/{id}/teams/reorder":                             "game_organizer",
+	"POST /games/{id}/unfinalize":                                "game_organizer",
+	"POST /services/{id}/archive-versions/{version_id}/rollback": "service_author",
+	"POST /services/{id}/check-checker":                          "service_author",
+	"POST /services/{id}/redownload":                             "service_author",
+	"POST /services/{id}/sync-from-git":                          "service_author",
+	"POST /services/{id}/upload-archives":                        "service_author",
+	"PUT /games/{id}/roles/{user_id}":                            "game_organizer",
+	"PUT /games/{id}/services/{service_id}/pin":                  "game_organizer",
+	"PUT /services/{id}/vulns":                                   "service_author",
+}
+
+// OperationTokenScopes maps OpenAPI operation keys to the API token scope declared via x-token-scope.
+var OperationTokenScopes = map[string]string{
+	"DELETE /results/{id}":                  "results:write",
+	"GET /games/{id}/export/ctf01d/options": "games:export",
+	"GET /results":                          "results:read",
+	"GET /results/{id}":                     "results:read",
+	"PATCH /results/{id}":                   "results:write",
+	"POST /games/{id}/export/ctf01d":        "games:export",
+	"POST /results":                         "results:write",
+	"POST /services/{id}/sync-from-git":     "services:sync",
+}
+
+// OperationRateLimits maps OpenAPI operation keys to the per-caller rate declared via x-rate-limit.
+var OperationRateLimits = map[string]string{
+	"POST /auth/email-verification/confirm": "10/15m",
+	"POST /auth/oidc/{provider}/authorize":  "30/1m",
+	"POST /auth/oidc/{provider}/callback":   "30/1m",
+	"POST /auth/password-reset":             "5/15m",
+	"POST /auth/password-reset/confirm":     "10/15m",
+	"POST /profile/email/verification":      "3/15m",
+	"POST /services/manifest/validate":      "30/1m",
+	"POST /session":                         "60/1m",
+	"POST /session/refresh":                 "60/1m",
+	"POST /session/two-factor":              "10/1m",
+	"POST /session/two-factor/enrollment":   "10/1m",
 }
@@ -0,0 +1,175 @@
+// Package audit records administrative and sensitive actions: who changed
+// which entity, from which address and request, and the fields the change
+// touched. Services write an event through the queries of the transaction
+// making the change, so the log and the data never disagree. The actor comes
+// from the request context, where the HTTP layer puts it with WithActor.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+// Entities the log records actions on.
+const (
+	EntityGame          = "game"
+	EntityResult        = "result"
+	EntityUser          = "user"
+	EntityService       = "service"
+	EntityGitCredential = "git_credential"
+)
+
+// Actions recorded in the log.
+const (
+	ActionFinalize           = "finalize"
+	ActionUnfinalize         = "unfinalize"
+	ActionExport             = "export"
+	ActionCreate             = "create"
+	ActionUpdate             = "update"
+	ActionDelete             = "delete"
+	ActionChangeRole         = "change_role"
+	ActionBlock              = "block"
+	ActionUnblock            = "unblock"
+	ActionRevokeSession      = "revoke_session"
+	ActionRevokeSessions     = "revoke_sessions"
+	ActionResetTwoFactor     = "reset_two_factor"
+	ActionUploadArchives     = "upload_archives"
+	ActionRedownload         = "redownload"
+	ActionGitSync            = "git_sync"
+	ActionGitSyncFailed      = "git_sync_failed"
+	ActionSetGameRole        = "set_game_role"
+	ActionRemoveGameRole     = "remove_game_role"
+	ActionCreateDownloadLink = "create_download_link"
+)
+
+// Actor is who performs the actions of a request. Actions taken outside a
+// request, such as background jobs, have no actor.
+type Actor struct {
+	UserID    int64
+	UserName  string
+	IP        string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor of the request.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor stored in ctx by WithActor.
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+// Entry is an action on an entity. Before and After are the entity, or the
+// fields the action is about, as they were and as they became; either is nil
+// when the entity did not exist. Only the fields that differ are stored.
+type Entry struct {
+	EntityType string
+	EntityID   int64
+	Action     string
+	Before     any
+	After      any
+}
+
+// Inserter stores events; *db.Queries of a transaction implements it.
+type Inserter interface {
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+}
+
+// Record writes the entry through q, together with the actor of ctx.
+func Record(ctx context.Context, q Inserter, e Entry) error {
+	before, after, err := diff(e.Before, e.After)
+	if err != nil {
+		return fmt.Errorf("audit %s %s: %w", e.EntityType, e.Action, err)
+	}
+	arg := db.InsertAuditEventParams{
+		EntityType: e.EntityType,
+		EntityID:   strconv.FormatInt(e.EntityID, 10),
+		Action:     e.Action,
+		Before:     before,
+		After:      after,
+	}
+	if actor, ok := ActorFrom(ctx); ok {
+		if actor.UserID != 0 {
+			arg.ActorID = &actor.UserID
+		}
+		arg.ActorName = optional(actor.UserName)
+		arg.IpAddress = optional(actor.IP)
+		arg.RequestID = optional(actor.RequestID)
+	}
+	if err := q.InsertAuditEvent(ctx, arg); err != nil {
+		return fmt.Errorf("audit %s %s: %w", e.EntityType, e.Action, err)
+	}
+	return nil
+}
+
+// diff encodes before and after as JSON objects holding only the fields whose
+// values differ. A nil side is stored as NULL and keeps the other one whole.
+func diff(before, after any) ([]byte, []byte, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := fields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b != nil && a != nil {
+		for key, value := range b {
+			if other, ok := a[key]; ok && bytes.Equal(value, other) {
+				delete(b, key)
+				delete(a, key)
+			}
+		}
+	}
+	bj, err := encode(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	aj, err := encode(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bj, aj, nil
+}
+
+func fields(v any) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("state must encode as a JSON object: %w", err)
+	}
+	if m == nil {
+		return nil, nil
+	}
+	return m, nil
+}
+
+func encode(m map[string]json.RawMessage) ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return json.Marshal(m)
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
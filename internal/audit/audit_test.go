@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type mockInserter struct {
+	events []db.InsertAuditEventParams
+}
+
+func (m *mockInserter) InsertAuditEvent(_ context.Context, arg db.InsertAuditEventParams) error {
+	m.events = append(m.events, arg)
+	return nil
+}
+
+func TestRecord_StoresOnlyChangedFields(t *testing.T) {
+	q := &mockInserter{}
+	before := map[string]any{"role": "player", "is_blocked": false}
+	after := map[string]any{"role": "admin", "is_blocked": false}
+
+	if err := Record(context.Background(), q, Entry{EntityType: EntityUser, EntityID: 7, Action: ActionChangeRole, Before: before, After: after}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	event := q.events[0]
+	if event.EntityID != "7" || event.EntityType != EntityUser || event.Action != ActionChangeRole {
+		t.Errorf("event = %s %s/%s", event.Action, event.EntityType, event.EntityID)
+	}
+	if string(event.Before) != `{"role":"player"}` || string(event.After) != `{"role":"admin"}` {
+		t.Errorf("diff = %s -> %s", event.Before, event.After)
+	}
+	if event.ActorID != nil || event.IpAddress != nil || event.RequestID != nil {
+		t.Errorf("event outside a request has an actor: %+v", event)
+	}
+}
+
+func TestRecord_MissingSideKeepsTheOther(t *testing.T) {
+	q := &mockInserter{}
+	type state struct {
+		Score *int32 `json:"score"`
+	}
+	var gone *state
+
+	if err := Record(context.Background(), q, Entry{EntityType: EntityResult, EntityID: 1, Action: ActionDelete, Before: state{}, After: gone}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if string(q.events[0].Before) != `{"score":null}` || q.events[0].After != nil {
+		t.Errorf("diff = %s -> %s, want the whole entity before and NULL after", q.events[0].Before, q.events[0].After)
+	}
+}
+
+func TestRecord_RejectsNonObjectState(t *testing.T) {
+	err := Record(context.Background(), &mockInserter{}, Entry{EntityType: EntityGame, EntityID: 1, Action: ActionUpdate, After: 5})
+	if err == nil {
+		t.Fatal("expected an error for a state that is not an object")
+	}
+}
+
+func TestRecord_TakesActorFromContext(t *testing.T) {
+	q := &mockInserter{}
+	ctx := WithActor(context.Background(), Actor{UserID: 1, UserName: "root", IP: "192.0.2.1", RequestID: "abc"})
+
+	if err := Record(ctx, q, Entry{EntityType: EntityGame, EntityID: 3, Action: ActionExport}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	event := q.events[0]
+	if event.ActorID == nil || *event.ActorID != 1 || *event.ActorName != "root" {
+		t.Errorf("actor = %v %v", event.ActorID, event.ActorName)
+	}
+	if *event.IpAddress != "192.0.2.1" || *event.RequestID != "abc" {
+		t.Errorf("origin = %v %v", *event.IpAddress, *event.RequestID)
+	}
+	if event.Before != nil || event.After != nil {
+		t.Errorf("diff = %s -> %s, want none", event.Before, event.After)
+	}
+}
@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type Event struct {
+	ID         int64           `json:"id"`
+	ActorID    *int64          `json:"actor_id"`
+	ActorName  *string         `json:"actor_name"`
+	IPAddress  *string         `json:"ip_address"`
+	RequestID  *string         `json:"request_id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Filter narrows the listed events; empty fields match every event. Until is
+// exclusive.
+type Filter struct {
+	EntityType *string
+	EntityID   *string
+	ActorID    *int64
+	Action     *string
+	Since      *time.Time
+	Until      *time.Time
+}
+
+type EventListResult struct {
+	Items   []Event `json:"items"`
+	Page    int     `json:"page"`
+	PerPage int     `json:"per_page"`
+	Total   int64   `json:"total"`
+}
+
+type Querier interface {
+	Inserter
+	ListAuditEvents(ctx context.Context, arg db.ListAuditEventsParams) ([]db.AuditEvent, error)
+	CountAuditEvents(ctx context.Context, arg db.CountAuditEventsParams) (int64, error)
+}
+
+type Service struct {
+	q Querier
+}
+
+func NewService(q Querier) *Service {
+	return &Service{q: q}
+}
+
+// Record writes an entry for an action that changes nothing in the database,
+// such as an export, so there is no transaction to join.
+func (s *Service) Record(ctx context.Context, e Entry) error {
+	return Record(ctx, s.q, e)
+}
+
+// List returns the events matching the filter, newest first.
+func (s *Service) List(ctx context.Context, filter Filter, page, perPage int) (*EventListResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := int64(page-1) * int64(perPage)
+	if offset > math.MaxInt32 {
+		return nil, fmt.Errorf("page %d is out of range", page)
+	}
+
+	since, until := timestamptz(filter.Since), timestamptz(filter.Until)
+	rows, err := s.q.ListAuditEvents(ctx, db.ListAuditEventsParams{
+		EntityType: filter.EntityType,
+		EntityID:   filter.EntityID,
+		ActorID:    filter.ActorID,
+		Action:     filter.Action,
+		Since:      since,
+		Until:      until,
+		Limit:      int32(perPage),
+		Offset:     int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.q.CountAuditEvents(ctx, db.CountAuditEventsParams{
+		EntityType: filter.EntityType,
+		EntityID:   filter.EntityID,
+		ActorID:    filter.ActorID,
+		Action:     filter.Action,
+		Since:      since,
+		Until:      until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EventListResult{
+		Items:   make([]Event, len(rows)),
+		Page:    page,
+		PerPage: perPage,
+		Total:   total,
+	}
+	for i, row := range rows {
+		result.Items[i] = Event{
+			ID:         row.ID,
+			ActorID:    row.ActorID,
+			ActorName:  row.ActorName,
+			IPAddress:  row.IpAddress,
+			RequestID:  row.RequestID,
+			EntityType: row.EntityType,
+			EntityID:   row.EntityID,
+			Action:     row.Action,
+			Before:     row.Before,
+			After:      row.After,
+			CreatedAt:  row.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+func timestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
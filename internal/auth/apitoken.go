@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"slices"
+	"strings"
+)
+
+// APITokenPrefix marks personal API tokens, so a bearer token is told apart
+// from a session JWT without parsing it.
+const APITokenPrefix = "ctf01d_pat_"
+
+// apiTokenBytes is the entropy of an API token.
+const apiTokenBytes = 32
+
+// apiTokenHintLength is how much of a token is kept in clear to let its owner
+// recognize it in a list.
+const apiTokenHintLength = len(APITokenPrefix) + 4
+
+// API token scopes. An operation reachable with an API token declares the
+// scope it needs via x-token-scope in OpenAPI; every other operation is closed
+// to API tokens.
+const (
+	ScopeResultsRead  = "results:read"
+	ScopeResultsWrite = "results:write"
+	ScopeServicesSync = "services:sync"
+	ScopeGamesExport  = "games:export"
+)
+
+// APITokenScopes lists the known scopes.
+var APITokenScopes = []string{ScopeResultsRead, ScopeResultsWrite, ScopeServicesSync, ScopeGamesExport}
+
+// ValidAPITokenScope reports whether scope is one of APITokenScopes.
+func ValidAPITokenScope(scope string) bool {
+	return slices.Contains(APITokenScopes, scope)
+}
+
+// NewAPIToken returns a random personal API token. Only its HashToken and
+// APITokenHint are stored.
+func NewAPIToken() (string, error) {
+	b := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// IsAPIToken reports whether a bearer token is a personal API token.
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// APITokenHint returns the leading characters of a token shown in token lists.
+func APITokenHint(token string) string {
+	if len(token) <= apiTokenHintLength {
+		return token
+	}
+	return token[:apiTokenHintLength]
+}
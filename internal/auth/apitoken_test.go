@@ -0,0 +1,45 @@
+package auth
+
+import "testing"
+
+func TestNewAPIToken(t *testing.T) {
+	a, err := NewAPIToken()
+	if err != nil {
+		t.Fatalf("NewAPIToken: %v", err)
+	}
+	b, err := NewAPIToken()
+	if err != nil {
+		t.Fatalf("NewAPIToken: %v", err)
+	}
+	if a == b {
+		t.Fatal("tokens must be random")
+	}
+	if !IsAPIToken(a) {
+		t.Errorf("IsAPIToken(%q) = false", a)
+	}
+	if hint := APITokenHint(a); hint != a[:len(APITokenPrefix)+4] {
+		t.Errorf("APITokenHint = %q", hint)
+	}
+}
+
+func TestIsAPIToken_SessionJWT(t *testing.T) {
+	m := NewManager("test-secret", 24)
+	token, err := m.Generate(1, "player", "alice", "jti")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if IsAPIToken(token) {
+		t.Error("a session JWT must not look like an API token")
+	}
+}
+
+func TestValidAPITokenScope(t *testing.T) {
+	for _, scope := range APITokenScopes {
+		if !ValidAPITokenScope(scope) {
+			t.Errorf("ValidAPITokenScope(%q) = false", scope)
+		}
+	}
+	if ValidAPITokenScope("results:*") {
+		t.Error("unknown scope accepted")
+	}
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"strconv"
@@ -17,22 +18,38 @@ type Claims struct {
 }
 
 type Manager struct {
-	secret []byte
-	ttl    time.Duration
+	secret    []byte
+	ttl       time.Duration
+	accessTTL time.Duration
 }
 
+// NewManager signs tokens valid for the whole session lifetime ttlHours until
+// SetAccessTTL shortens them.
 func NewManager(secret string, ttlHours int) *Manager {
+	ttl := time.Duration(ttlHours) * time.Hour
 	return &Manager{
-		secret: []byte(secret),
-		ttl:    time.Duration(ttlHours) * time.Hour,
+		secret:    []byte(secret),
+		ttl:       ttl,
+		accessTTL: ttl,
 	}
 }
 
-// TTL returns the configured token lifetime, used to align session expiry.
+// SetAccessTTL limits the lifetime of access tokens; clients renew them with a
+// refresh token while the session lasts.
+func (m *Manager) SetAccessTTL(d time.Duration) {
+	m.accessTTL = d
+}
+
+// TTL returns the session lifetime, used to set session expiry.
 func (m *Manager) TTL() time.Duration {
 	return m.ttl
 }
 
+// AccessTTL returns the lifetime of a single access token.
+func (m *Manager) AccessTTL() time.Duration {
+	return m.accessTTL
+}
+
 // Generate signs a token for the user. jti uniquely identifies the session so
 // it can be looked up and revoked server-side.
 func (m *Manager) Generate(userID int64, role, userName, jti string) (string, error) {
@@ -41,7 +58,7 @@ func (m *Manager) Generate(userID int64, role, userName, jti string) (string, er
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        jti,
 			Subject:   int64ToString(userID),
-			ExpiresAt: jwt.NewNumericDate(now.Add(m.ttl)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.accessTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 		Role:     role,
@@ -51,8 +68,12 @@ func (m *Manager) Generate(userID int64, role, userName, jti string) (string, er
 	return token.SignedString(m.secret)
 }
 
-// sessionIDBytes is the number of random bytes in a session identifier (jti).
-const sessionIDBytes = 16
+const (
+	// sessionIDBytes is the number of random bytes in a session identifier (jti).
+	sessionIDBytes = 16
+	// refreshTokenBytes is the number of random bytes in a refresh token.
+	refreshTokenBytes = 32
+)
 
 // NewSessionID returns a random opaque identifier used as a JWT ID (jti).
 func NewSessionID() (string, error) {
@@ -63,6 +84,16 @@ func NewSessionID() (string, error) {
 	return hex.EncodeToString(b), nil
 }
 
+// NewRefreshToken returns a random opaque refresh token. Only its HashToken
+// is stored.
+func NewRefreshToken() (string, error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 func (m *Manager) Parse(tokenStr string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
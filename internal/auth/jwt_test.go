@@ -29,7 +29,7 @@ func TestJWT_RoundTrip(t *testing.T) {
 
 func TestJWT_ExpiredToken(t *testing.T) {
 	m := NewManager("test-secret", 24)
-	m.ttl = -1 * time.Hour
+	m.SetAccessTTL(-1 * time.Hour)
 
 	token, err := m.Generate(1, "guest", "expired", "jti2")
 	if err != nil {
@@ -42,6 +42,37 @@ func TestJWT_ExpiredToken(t *testing.T) {
 	}
 }
 
+func TestJWT_AccessTTL(t *testing.T) {
+	m := NewManager("test-secret", 24)
+	m.SetAccessTTL(15 * time.Minute)
+
+	token, err := m.Generate(1, "guest", "user", "jti3")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	claims, err := m.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if left := time.Until(claims.ExpiresAt.Time); left > 15*time.Minute || left < 14*time.Minute {
+		t.Errorf("token expires in %v, want the access TTL", left)
+	}
+	if m.TTL() != 24*time.Hour {
+		t.Errorf("TTL() = %v, want the session lifetime", m.TTL())
+	}
+}
+
+func TestNewRefreshToken(t *testing.T) {
+	a, err := NewRefreshToken()
+	if err != nil {
+		t.Fatalf("NewRefreshToken: %v", err)
+	}
+	b, _ := NewRefreshToken()
+	if len(a) != 43 || a == b {
+		t.Errorf("tokens %q and %q should be distinct 256-bit values", a, b)
+	}
+}
+
 func TestJWT_InvalidToken(t *testing.T) {
 	m := NewManager("test-secret", 24)
 
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+// oauthHTTPClient bounds every request to an identity provider, so a slow
+// provider cannot hold a login request forever.
+var oauthHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+const gitHubAPIURL = "https://api.github.com"
+
+// ExternalIdentity is what a provider asserts about the person who signed in.
+type ExternalIdentity struct {
+	// Subject is the provider's stable id of the account.
+	Subject string
+	// Email is set only when the provider vouches for it.
+	Email string
+	// UserName is the preferred login, used to name auto-provisioned users.
+	UserName    string
+	DisplayName string
+}
+
+// IdentityProvider runs the OAuth2 authorization code flow with PKCE against
+// one external provider.
+type IdentityProvider interface {
+	Name() string
+	DisplayName() string
+	// AuthCodeURL returns the provider URL the browser is sent to.
+	AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	// Exchange redeems the code from the callback and returns the identity.
+	Exchange(ctx context.Context, code, nonce, verifier string) (*ExternalIdentity, error)
+}
+
+// OAuthClientOptions describes this application as a client of a provider.
+type OAuthClientOptions struct {
+	Name         string
+	DisplayName  string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// NewOAuthState returns a random value for the state and nonce parameters.
+func NewOAuthState() (string, error) {
+	return NewSessionID()
+}
+
+// NewPKCEVerifier returns a random PKCE code verifier.
+func NewPKCEVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// OIDCProvider is an OpenID Connect provider configured by discovery from
+// its issuer (Keycloak, Google, ...). Discovery runs on first use and is
+// retried after a failure, so an unreachable provider does not stop the
+// server from starting.
+type OIDCProvider struct {
+	opts   OAuthClientOptions
+	issuer string
+
+	mu       sync.Mutex
+	config   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func NewOIDCProvider(issuer string, opts OAuthClientOptions) *OIDCProvider {
+	if len(opts.Scopes) == 0 {
+		opts.Scopes = []string{"profile", "email"}
+	}
+	if !slices.Contains(opts.Scopes, oidc.ScopeOpenID) {
+		opts.Scopes = append([]string{oidc.ScopeOpenID}, opts.Scopes...)
+	}
+	return &OIDCProvider{opts: opts, issuer: issuer}
+}
+
+func (p *OIDCProvider) Name() string        { return p.opts.Name }
+func (p *OIDCProvider) DisplayName() string { return p.opts.DisplayName }
+
+func (p *OIDCProvider) discover(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.config != nil {
+		return p.config, p.verifier, nil
+	}
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, oauthHTTPClient), p.issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("discovering %s: %w", p.opts.Name, err)
+	}
+	p.config = &oauth2.Config{
+		ClientID:     p.opts.ClientID,
+		ClientSecret: p.opts.ClientSecret,
+		RedirectURL:  p.opts.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       p.opts.Scopes,
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.opts.ClientID})
+	return p.config, p.verifier, nil
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	config, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code, nonce, verifier string) (*ExternalIdentity, error) {
+	config, idVerifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, oauthHTTPClient)
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchanging code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response has no id_token")
+	}
+	idToken, err := idVerifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verifying id_token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id_token nonce does not match")
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		EmailVerified     bool   `json:"email_verified"`
+		PreferredUserName string `json:"preferred_username"`
+		Name              string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("decoding id_token claims: %w", err)
+	}
+	identity := &ExternalIdentity{
+		Subject:     idToken.Subject,
+		UserName:    claims.PreferredUserName,
+		DisplayName: claims.Name,
+	}
+	if claims.EmailVerified {
+		identity.Email = claims.Email
+	}
+	return identity, nil
+}
+
+// GitHubProvider signs in with a GitHub OAuth app. GitHub is not an OpenID
+// provider, so the identity comes from the /user API.
+type GitHubProvider struct {
+	opts   OAuthClientOptions
+	config *oauth2.Config
+	apiURL string
+}
+
+func NewGitHubProvider(opts OAuthClientOptions) *GitHubProvider {
+	if len(opts.Scopes) == 0 {
+		opts.Scopes = []string{"read:user"}
+	}
+	return &GitHubProvider{
+		opts: opts,
+		config: &oauth2.Config{
+			ClientID:     opts.ClientID,
+			ClientSecret: opts.ClientSecret,
+			RedirectURL:  opts.RedirectURL,
+			Endpoint:     github.Endpoint,
+			Scopes:       opts.Scopes,
+		},
+		apiURL: gitHubAPIURL,
+	}
+}
+
+func (p *GitHubProvider) Name() string        { return p.opts.Name }
+func (p *GitHubProvider) DisplayName() string { return p.opts.DisplayName }
+
+func (p *GitHubProvider) AuthCodeURL(_ context.Context, state, _, verifier string) (string, error) {
+	return p.config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *GitHubProvider) Exchange(ctx context.Context, code, _, verifier string) (*ExternalIdentity, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, oauthHTTPClient)
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchanging code: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+"/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := p.config.Client(ctx, token).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching github user: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("fetching github user: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("decoding github user: %w", err)
+	}
+	if user.ID == 0 {
+		return nil, errors.New("github user has no id")
+	}
+	return &ExternalIdentity{
+		Subject:     strconv.FormatInt(user.ID, 10),
+		Email:       user.Email,
+		UserName:    user.Login,
+		DisplayName: user.Name,
+	}, nil
+}
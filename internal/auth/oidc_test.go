@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// mockOIDCServer is a minimal OpenID provider: discovery, JWKS and a token
+// endpoint that checks the PKCE verifier of codes handed out by authorize.
+type mockOIDCServer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]mockGrant
+}
+
+type mockGrant struct {
+	challenge string
+	nonce     string
+	subject   string
+}
+
+func newMockOIDCServer(t *testing.T) *mockOIDCServer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &mockOIDCServer{key: key, codes: map[string]mockGrant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                m.URL,
+			"authorization_endpoint":                m.URL + "/authorize",
+			"token_endpoint":                        m.URL + "/token",
+			"jwks_uri":                              m.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", m.token)
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+// authorize plays the user approving the login at the provider: it records
+// the request from the authorization URL and returns the code.
+func (m *mockOIDCServer) authorize(t *testing.T, authURL, subject string) (state, code string) {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		t.Fatalf("authorization URL has no S256 challenge: %s", authURL)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code = "code-" + subject
+	m.codes[code] = mockGrant{challenge: q.Get("code_challenge"), nonce: q.Get("nonce"), subject: subject}
+	return q.Get("state"), code
+}
+
+func (m *mockOIDCServer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m.mu.Lock()
+	grant, ok := m.codes[r.PostForm.Get("code")]
+	m.mu.Unlock()
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                m.URL,
+		"sub":                grant.subject,
+		"aud":                "ctf01d",
+		"exp":                time.Now().Add(time.Minute).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              grant.nonce,
+		"email":              grant.subject + "@example.edu",
+		"email_verified":     true,
+		"preferred_username": grant.subject,
+		"name":               "Student " + grant.subject,
+	})
+	idToken.Header["kid"] = "test"
+	signed, err := idToken.SignedString(m.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{"access_token": "access", "token_type": "Bearer", "id_token": signed})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestOIDCProvider_CodeFlowWithPKCE(t *testing.T) {
+	server := newMockOIDCServer(t)
+	p := NewOIDCProvider(server.URL, OAuthClientOptions{Name: "keycloak", ClientID: "ctf01d", RedirectURL: "http://localhost/cb"})
+	ctx := context.Background()
+	verifier := NewPKCEVerifier()
+
+	authURL, err := p.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	state, code := server.authorize(t, authURL, "alice")
+	if state != "state-1" {
+		t.Errorf("state = %q, want state-1", state)
+	}
+
+	identity, err := p.Exchange(ctx, code, "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if identity.Subject != "alice" || identity.Email != "alice@example.edu" || identity.UserName != "alice" {
+		t.Errorf("identity = %+v", identity)
+	}
+
+	if _, err := p.Exchange(ctx, code, "nonce-1", NewPKCEVerifier()); err == nil {
+		t.Error("Exchange must fail with another PKCE verifier")
+	}
+	if _, err := p.Exchange(ctx, code, "other-nonce", verifier); err == nil {
+		t.Error("Exchange must fail when the nonce does not match")
+	}
+}
+
+func TestOIDCProvider_DiscoveryFailureIsRetried(t *testing.T) {
+	server := newMockOIDCServer(t)
+	p := NewOIDCProvider(server.URL+"/missing", OAuthClientOptions{Name: "keycloak", ClientID: "ctf01d"})
+	if _, err := p.AuthCodeURL(context.Background(), "s", "n", NewPKCEVerifier()); err == nil {
+		t.Fatal("AuthCodeURL must fail when discovery fails")
+	}
+	p.issuer = server.URL
+	if _, err := p.AuthCodeURL(context.Background(), "s", "n", NewPKCEVerifier()); err != nil {
+		t.Fatalf("AuthCodeURL after recovery: %v", err)
+	}
+}
+
+func TestGitHubProvider_Exchange(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("code_verifier") == "" {
+			http.Error(w, "missing verifier", http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, map[string]string{"access_token": "gh-token", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gh-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, map[string]any{"id": 583231, "login": "octocat", "name": "The Octocat"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := NewGitHubProvider(OAuthClientOptions{Name: "github", ClientID: "id", ClientSecret: "secret"})
+	p.config.Endpoint = oauth2.Endpoint{AuthURL: server.URL + "/login/oauth/authorize", TokenURL: server.URL + "/login/oauth/access_token"}
+	p.apiURL = server.URL
+
+	identity, err := p.Exchange(context.Background(), "code", "", NewPKCEVerifier())
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if identity.Subject != "583231" || identity.UserName != "octocat" || identity.Email != "" {
+		t.Errorf("identity = %+v", identity)
+	}
+}
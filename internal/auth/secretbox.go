@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrSecretBoxKeyRequired is returned when encryption at rest is requested
+// without a configured key.
+var ErrSecretBoxKeyRequired = errors.New("secret box key is required")
+
+var errSecretBoxCiphertext = errors.New("ciphertext is too short")
+
+// SecretBox encrypts small secrets (git tokens, deploy keys) before they are
+// stored in the database. Output is nonce||ciphertext sealed with AES-256-GCM.
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox builds a SecretBox from the configured key. A base64-encoded
+// 32-byte value is used as-is; any other string is treated as a passphrase and
+// stretched with SHA-256 so operators can supply either form.
+func NewSecretBox(key string) (*SecretBox, error) {
+	if key == "" {
+		return nil, ErrSecretBoxKeyRequired
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(raw) != 32 {
+		sum := sha256.Sum256([]byte(key))
+		raw = sum[:]
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM: %w", err)
+	}
+	return &SecretBox{aead: aead}, nil
+}
+
+func (b *SecretBox) Seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	return b.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (b *SecretBox) Open(ciphertext []byte) ([]byte, error) {
+	nonceSize := b.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errSecretBoxCiphertext
+	}
+	plaintext, err := b.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting secret: %w", err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestSecretBox_RoundTrip(t *testing.T) {
+	b, err := NewSecretBox("passphrase")
+	if err != nil {
+		t.Fatalf("NewSecretBox: %v", err)
+	}
+
+	sealed, err := b.Seal([]byte("ghp_token"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if bytes.Contains(sealed, []byte("ghp_token")) {
+		t.Fatal("ciphertext must not contain the plaintext")
+	}
+
+	opened, err := b.Open(sealed)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if string(opened) != "ghp_token" {
+		t.Errorf("Open = %q, want %q", opened, "ghp_token")
+	}
+}
+
+func TestSecretBox_WrongKey(t *testing.T) {
+	a, _ := NewSecretBox(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)))
+	b, _ := NewSecretBox(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32)))
+
+	sealed, err := a.Seal([]byte("secret"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if _, err := b.Open(sealed); err == nil {
+		t.Fatal("expected error when opening with a different key")
+	}
+	if _, err := a.Open(sealed[:4]); err == nil {
+		t.Fatal("expected error for truncated ciphertext")
+	}
+}
+
+func TestSecretBox_EmptyKey(t *testing.T) {
+	if _, err := NewSecretBox(""); !errors.Is(err, ErrSecretBoxKeyRequired) {
+		t.Fatalf("expected ErrSecretBoxKeyRequired, got %v", err)
+	}
+}
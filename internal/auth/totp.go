@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	totpPeriod = 30
+	// totpSkew accepts codes from the neighbouring steps, which covers clock
+	// drift between the server and the phone.
+	totpSkew = 1
+
+	totpQRCodeSize = 256
+
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeHalf     = 5
+)
+
+// TOTPKey is a new authenticator secret with the ways to hand it to an app.
+type TOTPKey struct {
+	Secret string
+	// URL is the otpauth:// URI encoded in the QR code.
+	URL       string
+	QRCodePNG []byte
+}
+
+// NewTOTPKey generates a secret for account (the user name) under issuer.
+func NewTOTPKey(issuer, account string) (*TOTPKey, error) {
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: issuer, AccountName: account, Period: totpPeriod})
+	if err != nil {
+		return nil, fmt.Errorf("generating totp key: %w", err)
+	}
+	img, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		return nil, fmt.Errorf("rendering qr code: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encoding qr code: %w", err)
+	}
+	return &TOTPKey{Secret: key.Secret(), URL: key.URL(), QRCodePNG: buf.Bytes()}, nil
+}
+
+// VerifyTOTP checks code against secret and returns the time step it belongs
+// to. Callers store the step and reject codes of earlier or equal steps, so a
+// code cannot be replayed.
+func VerifyTOTP(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	opts := totp.ValidateOpts{Period: totpPeriod, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+	current := now.Unix() / totpPeriod
+	for delta := int64(-totpSkew); delta <= totpSkew; delta++ {
+		step := current + delta
+		want, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), opts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// NewRecoveryCodes returns n random one-time codes formatted as xxxxx-xxxxx.
+func NewRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	buf := make([]byte, 2*recoveryCodeHalf)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		var sb strings.Builder
+		for j, b := range buf {
+			if j == recoveryCodeHalf {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+		codes[i] = sb.String()
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the stored form of a recovery code. Case, spaces
+// and dashes do not matter when the user types it in.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return HashToken(code)
+}
+
+// HashToken returns the hex SHA-256 of a random token. Tokens carry enough
+// entropy that a plain hash is a safe way to store them.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+)
+
+func TestVerifyTOTP(t *testing.T) {
+	key, err := NewTOTPKey("ctf01d", "alice")
+	if err != nil {
+		t.Fatalf("NewTOTPKey: %v", err)
+	}
+	if !strings.HasPrefix(key.URL, "otpauth://totp/ctf01d:alice?") {
+		t.Errorf("URL = %q", key.URL)
+	}
+	if !bytes.HasPrefix(key.QRCodePNG, []byte("\x89PNG")) {
+		t.Error("QR code is not a PNG")
+	}
+
+	now := time.Unix(1_800_000_000, 0)
+	code, err := totp.GenerateCode(key.Secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	step, ok := VerifyTOTP(key.Secret, code, now.Add(totpPeriod*time.Second))
+	if !ok || step != now.Unix()/totpPeriod {
+		t.Fatalf("VerifyTOTP = %d, %v; want the step of the code", step, ok)
+	}
+	if _, ok := VerifyTOTP(key.Secret, code, now.Add(3*totpPeriod*time.Second)); ok {
+		t.Error("a code three steps old must be rejected")
+	}
+	if _, ok := VerifyTOTP(key.Secret, "000000x", now); ok {
+		t.Error("garbage must be rejected")
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := NewRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, c := range codes {
+		if len(c) != 2*recoveryCodeHalf+1 || c[recoveryCodeHalf] != '-' || seen[c] {
+			t.Fatalf("bad or repeated code %q", c)
+		}
+		seen[c] = true
+	}
+	typed := strings.ToUpper(strings.Replace(codes[0], "-", " ", 1))
+	if HashRecoveryCode(typed) != HashRecoveryCode(codes[0]) {
+		t.Error("recovery codes must match regardless of case and separators")
+	}
+}
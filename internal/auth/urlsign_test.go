@@ -0,0 +1,21 @@
+package auth
+
+import "testing"
+
+func TestURLSigner_SignVerify(t *testing.T) {
+	s := NewURLSigner("secret")
+	sig := s.Sign("archive:1:service:7:1700000000")
+
+	if !s.Verify("archive:1:service:7:1700000000", sig) {
+		t.Fatal("signature should verify")
+	}
+	if s.Verify("archive:1:checker:7:1700000000", sig) {
+		t.Error("signature must not verify another payload")
+	}
+	if NewURLSigner("other").Verify("archive:1:service:7:1700000000", sig) {
+		t.Error("signature must not verify with another secret")
+	}
+	if s.Verify("archive:1:service:7:1700000000", "not-hex") {
+		t.Error("malformed signature must not verify")
+	}
+}
@@ -15,9 +15,16 @@ func setenvs(t *testing.T, envs map[string]string) {
 func clearEnvForConfig(t *testing.T) {
 	t.Helper()
 	for _, key := range []string{
-		"APP_ENV", "HTTP_ADDR", "DATABASE_URL", "JWT_SECRET",
-		"JWT_TTL_HOURS", "LOG_LEVEL", "CORS_ALLOWED_ORIGINS",
-		"STORAGE_DIR", "STORAGE_MAX_UPLOAD_BYTES",
+		"APP_ENV", "HTTP_ADDR", "HTTP_TRUSTED_PROXIES", "DATABASE_URL", "JWT_SECRET",
+		"JWT_TTL_HOURS", "JWT_ACCESS_TTL_MINUTES", "LOG_LEVEL", "CORS_ALLOWED_ORIGINS",
+		"STORAGE_DIR", "STORAGE_MAX_UPLOAD_BYTES", "STORAGE_BACKEND",
+		"S3_ENDPOINT", "S3_BUCKET", "DOWNLOAD_LINK_SECRET",
+		"OIDC_PROVIDERS", "OIDC_REDIRECT_BASE_URL", "OIDC_AUTO_PROVISION",
+		"TWO_FACTOR_SECRET_KEY", "TWO_FACTOR_ISSUER",
+		"MAIL_DRIVER", "MAIL_FROM", "MAIL_DIR", "MAIL_LINK_BASE_URL",
+		"SMTP_HOST", "SMTP_PORT", "SMTP_USERNAME", "SMTP_PASSWORD",
+		"RATE_LIMIT_ENABLED", "RATE_LIMIT_BACKEND",
+		"LOGIN_MAX_FAILURES_PER_USER", "LOGIN_MAX_FAILURES_PER_IP", "LOGIN_LOCKOUT_MINUTES",
 	} {
 		t.Setenv(key, "")
 		os.Unsetenv(key)
@@ -42,6 +49,9 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.JWT.TTLHours != 24 {
 		t.Errorf("JWT.TTLHours = %d, want 24", cfg.JWT.TTLHours)
 	}
+	if cfg.JWT.AccessTTLMinutes != 15 {
+		t.Errorf("JWT.AccessTTLMinutes = %d, want 15", cfg.JWT.AccessTTLMinutes)
+	}
 	if cfg.Log.Level != "info" {
 		t.Errorf("Log.Level = %q, want info", cfg.Log.Level)
 	}
@@ -144,3 +154,181 @@ func TestLoad_ProductionValid(t *testing.T) {
 		t.Errorf("DB.URL = %q, want custom", cfg.DB.URL)
 	}
 }
+
+func TestLoad_S3StorageRequiresBucket(t *testing.T) {
+	clearEnvForConfig(t)
+	t.Setenv("STORAGE_BACKEND", "s3")
+	t.Setenv("S3_ENDPOINT", "minio:9000")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail when S3_BUCKET is empty for the s3 backend")
+	}
+
+	t.Setenv("S3_BUCKET", "ctf01d")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Storage.Backend != StorageBackendS3 || !cfg.Storage.S3.UseSSL {
+		t.Errorf("Storage = %+v, want s3 backend with SSL by default", cfg.Storage)
+	}
+}
+
+func TestLoad_UnknownStorageBackend(t *testing.T) {
+	clearEnvForConfig(t)
+	t.Setenv("STORAGE_BACKEND", "ftp")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail for an unknown STORAGE_BACKEND")
+	}
+}
+
+func TestLoad_Mail(t *testing.T) {
+	clearEnvForConfig(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Mail.Driver != MailDriverLog || cfg.Mail.LinkBaseURL != "http://localhost:5173" {
+		t.Errorf("Mail = %+v, want the log driver linking to the dev frontend", cfg.Mail)
+	}
+
+	t.Setenv("MAIL_DRIVER", "smtp")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail when SMTP_HOST is empty for the smtp driver")
+	}
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Mail.SMTP.Port != 587 {
+		t.Errorf("SMTP.Port = %d, want 587", cfg.Mail.SMTP.Port)
+	}
+
+	t.Setenv("MAIL_DRIVER", "pigeon")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail for an unknown MAIL_DRIVER")
+	}
+}
+
+func TestLoad_RateLimit(t *testing.T) {
+	clearEnvForConfig(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	want := RateLimitConfig{Enabled: true, Backend: RateLimitBackendMemory, LoginMaxUserFailures: 10, LoginMaxIPFailures: 50, LoginLockoutMinutes: 15}
+	if cfg.RateLimit != want {
+		t.Errorf("RateLimit = %+v, want %+v", cfg.RateLimit, want)
+	}
+
+	t.Setenv("HTTP_TRUSTED_PROXIES", "10.0.0.0/8, 192.0.2.1")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.HTTP.TrustedProxies) != 2 || cfg.HTTP.TrustedProxies[1] != "192.0.2.1" {
+		t.Errorf("TrustedProxies = %q", cfg.HTTP.TrustedProxies)
+	}
+	t.Setenv("HTTP_TRUSTED_PROXIES", "proxy.local")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail for a trusted proxy that is not an address")
+	}
+	t.Setenv("HTTP_TRUSTED_PROXIES", "")
+
+	t.Setenv("RATE_LIMIT_BACKEND", "redis")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail for an unknown RATE_LIMIT_BACKEND")
+	}
+	t.Setenv("RATE_LIMIT_BACKEND", "postgres")
+	t.Setenv("LOGIN_LOCKOUT_MINUTES", "0")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail for a zero LOGIN_LOCKOUT_MINUTES")
+	}
+}
+
+func TestLoad_DownloadLinkSecretDefaultsToJWTSecret(t *testing.T) {
+	clearEnvForConfig(t)
+	t.Setenv("JWT_SECRET", "jwt")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Downloads.LinkSecret != "jwt" {
+		t.Errorf("LinkSecret = %q, want JWT_SECRET", cfg.Downloads.LinkSecret)
+	}
+
+	t.Setenv("DOWNLOAD_LINK_SECRET", "links")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Downloads.LinkSecret != "links" {
+		t.Errorf("LinkSecret = %q, want DOWNLOAD_LINK_SECRET", cfg.Downloads.LinkSecret)
+	}
+}
+
+func TestLoad_TwoFactorKeyDefaultsToJWTSecret(t *testing.T) {
+	clearEnvForConfig(t)
+	t.Setenv("JWT_SECRET", "jwt")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.TwoFactor.SecretKey != "jwt" || cfg.TwoFactor.Issuer != "ctf01d" {
+		t.Errorf("TwoFactor = %+v, want JWT_SECRET and the default issuer", cfg.TwoFactor)
+	}
+
+	t.Setenv("TWO_FACTOR_SECRET_KEY", "totp")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.TwoFactor.SecretKey != "totp" {
+		t.Errorf("SecretKey = %q, want TWO_FACTOR_SECRET_KEY", cfg.TwoFactor.SecretKey)
+	}
+}
+
+func TestLoad_OIDCProviders(t *testing.T) {
+	clearEnvForConfig(t)
+	setenvs(t, map[string]string{
+		"OIDC_PROVIDERS":             "keycloak, github",
+		"OIDC_KEYCLOAK_ISSUER":       "https://sso.example.edu/realms/students",
+		"OIDC_KEYCLOAK_CLIENT_ID":    "ctf01d",
+		"OIDC_KEYCLOAK_DISPLAY_NAME": "University SSO",
+		"OIDC_GITHUB_TYPE":           "github",
+		"OIDC_GITHUB_CLIENT_ID":      "gh-client",
+		"OIDC_GITHUB_CLIENT_SECRET":  "gh-secret",
+		"OIDC_GITHUB_SCOPES":         "read:user, user:email",
+	})
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.OIDC.Providers) != 2 {
+		t.Fatalf("Providers = %+v, want 2", cfg.OIDC.Providers)
+	}
+	kc, gh := cfg.OIDC.Providers[0], cfg.OIDC.Providers[1]
+	if kc.Type != OIDCProviderTypeOIDC || kc.DisplayName != "University SSO" || kc.ClientID != "ctf01d" {
+		t.Errorf("keycloak = %+v", kc)
+	}
+	if gh.Type != OIDCProviderTypeGitHub || gh.DisplayName != "github" || len(gh.Scopes) != 2 || gh.Scopes[1] != "user:email" {
+		t.Errorf("github = %+v", gh)
+	}
+	if !cfg.OIDC.AutoProvision {
+		t.Error("AutoProvision should default to true")
+	}
+}
+
+func TestLoad_OIDCProviderValidation(t *testing.T) {
+	clearEnvForConfig(t)
+	t.Setenv("OIDC_PROVIDERS", "keycloak")
+	t.Setenv("OIDC_KEYCLOAK_CLIENT_ID", "ctf01d")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail when an oidc provider has no issuer")
+	}
+
+	t.Setenv("OIDC_PROVIDERS", "Bad-Name")
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail for an invalid provider name")
+	}
+}
@@ -3,6 +3,7 @@ package errs
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -10,6 +11,8 @@ var (
 	ErrConflict     = errors.New("conflict")
 	ErrForbidden    = errors.New("forbidden")
 	ErrUnauthorized = errors.New("unauthorized")
+	// ErrTooManyRequests is matched by every RateLimitError.
+	ErrTooManyRequests = errors.New("too many requests")
 )
 
 type ValidationError struct {
@@ -23,3 +26,21 @@ func (e *ValidationError) Error() string {
 func NewValidationError(fields map[string]string) *ValidationError {
 	return &ValidationError{Fields: fields}
 }
+
+// RateLimitError refuses a request that exceeded a rate limit; it may be
+// retried once RetryAfter has passed.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("too many requests, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrTooManyRequests
+}
+
+func NewRateLimitError(retryAfter time.Duration) *RateLimitError {
+	return &RateLimitError{RetryAfter: retryAfter}
+}
@@ -4,10 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestSentinelErrors(t *testing.T) {
-	sentinels := []error{ErrNotFound, ErrConflict, ErrForbidden, ErrUnauthorized}
+	sentinels := []error{ErrNotFound, ErrConflict, ErrForbidden, ErrUnauthorized, ErrTooManyRequests}
 	for _, err := range sentinels {
 		if err == nil {
 			t.Errorf("expected non-nil sentinel error, got nil")
@@ -16,7 +17,7 @@ func TestSentinelErrors(t *testing.T) {
 }
 
 func TestSentinelErrorsAreDistinct(t *testing.T) {
-	errsList := []error{ErrNotFound, ErrConflict, ErrForbidden, ErrUnauthorized}
+	errsList := []error{ErrNotFound, ErrConflict, ErrForbidden, ErrUnauthorized, ErrTooManyRequests}
 	for i := 0; i < len(errsList); i++ {
 		for j := i + 1; j < len(errsList); j++ {
 			if errors.Is(errsList[i], errsList[j]) {
@@ -74,3 +75,14 @@ func TestWrappedValidationErrorAs(t *testing.T) {
 		t.Error("fields not preserved through wrapping")
 	}
 }
+
+func TestRateLimitError(t *testing.T) {
+	err := fmt.Errorf("login: %w", NewRateLimitError(90*time.Second))
+	if !errors.Is(err, ErrTooManyRequests) {
+		t.Error("RateLimitError should match ErrTooManyRequests")
+	}
+	var target *RateLimitError
+	if !errors.As(err, &target) || target.RetryAfter != 90*time.Second {
+		t.Errorf("RetryAfter not preserved: %+v", target)
+	}
+}
@@ -0,0 +1,116 @@
+// Package ports detects vulnbox port conflicts between the services of a
+// game. It is shared by the game service and the ctf01d export builder.
+package ports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const maxPort = 65535
+
+// ServicePorts is a service of a game together with the vulnbox ports it
+// listens on.
+type ServicePorts struct {
+	ServiceID int64   `json:"service_id"`
+	Name      string  `json:"name"`
+	Ports     []int32 `json:"ports"`
+}
+
+// Suggestion proposes a free port for a service that has to move.
+type Suggestion struct {
+	ServiceID int64 `json:"service_id"`
+	Port      int32 `json:"port"`
+}
+
+// Conflict is a vulnbox port claimed by more than one service of a game.
+// The first service keeps the port; the others get a suggested free port.
+type Conflict struct {
+	Port        int32          `json:"port"`
+	Services    []ServicePorts `json:"services"`
+	Suggestions []Suggestion   `json:"suggestions"`
+}
+
+func (c Conflict) String() string {
+	names := make([]string, len(c.Services))
+	for i, svc := range c.Services {
+		names[i] = svc.Name
+	}
+	msg := fmt.Sprintf("port %d is used by %s", c.Port, strings.Join(names, ", "))
+	if len(c.Suggestions) > 0 {
+		free := make([]string, len(c.Suggestions))
+		for i, s := range c.Suggestions {
+			free[i] = fmt.Sprint(s.Port)
+		}
+		msg += " (free: " + strings.Join(free, ", ") + ")"
+	}
+	return msg
+}
+
+// Involves reports whether the service is one of those sharing the port.
+func (c Conflict) Involves(serviceID int64) bool {
+	for _, svc := range c.Services {
+		if svc.ServiceID == serviceID {
+			return true
+		}
+	}
+	return false
+}
+
+// FindConflicts reports every port used by more than one service and
+// suggests the closest higher port that no service of the game uses.
+func FindConflicts(services []ServicePorts) []Conflict {
+	used := make(map[int32]bool)
+	owners := make(map[int32][]ServicePorts)
+	for _, svc := range services {
+		seen := make(map[int32]bool, len(svc.Ports))
+		for _, port := range svc.Ports {
+			used[port] = true
+			if seen[port] {
+				continue
+			}
+			seen[port] = true
+			owners[port] = append(owners[port], svc)
+		}
+	}
+
+	var conflicts []Conflict
+	for port, svcs := range owners {
+		if len(svcs) < 2 {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{Port: port, Services: svcs})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Port < conflicts[j].Port })
+
+	for i := range conflicts {
+		for _, svc := range conflicts[i].Services[1:] {
+			free, ok := nextFreePort(conflicts[i].Port, used)
+			if !ok {
+				break
+			}
+			used[free] = true
+			conflicts[i].Suggestions = append(conflicts[i].Suggestions, Suggestion{ServiceID: svc.ServiceID, Port: free})
+		}
+	}
+	return conflicts
+}
+
+// Message joins conflicts into a single validation message.
+func Message(conflicts []Conflict) string {
+	parts := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+func nextFreePort(from int32, used map[int32]bool) (int32, bool) {
+	for port := from + 1; port <= maxPort; port++ {
+		if !used[port] {
+			return port, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindConflicts(t *testing.T) {
+	conflicts := FindConflicts([]ServicePorts{
+		{ServiceID: 1, Name: "bank", Ports: []int32{8080, 9000}},
+		{ServiceID: 2, Name: "notes", Ports: []int32{8080, 8081}},
+		{ServiceID: 3, Name: "shop", Ports: []int32{8080, 5000, 5000}},
+	})
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %#v, want exactly one", conflicts)
+	}
+	c := conflicts[0]
+	if c.Port != 8080 || len(c.Services) != 3 {
+		t.Fatalf("conflict = %#v", c)
+	}
+	want := []Suggestion{{ServiceID: 2, Port: 8082}, {ServiceID: 3, Port: 8083}}
+	if !reflect.DeepEqual(c.Suggestions, want) {
+		t.Errorf("Suggestions = %#v, want %#v", c.Suggestions, want)
+	}
+	if got := c.String(); got != "port 8080 is used by bank, notes, shop (free: 8082, 8083)" {
+		t.Errorf("String() = %q", got)
+	}
+	if !c.Involves(3) || c.Involves(4) {
+		t.Errorf("Involves reports wrong services for %#v", c)
+	}
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// FileMailer writes every message as an .eml file into a directory instead of
+// sending it; any mail client opens them.
+type FileMailer struct {
+	dir  string
+	from string
+	now  func() time.Time
+}
+
+const dirMode = 0o755
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, fmt.Errorf("creating mail directory: %w", err)
+	}
+	return &FileMailer{dir: dir, from: from, now: time.Now}, nil
+}
+
+func (m *FileMailer) Send(_ context.Context, msg Message) error {
+	now := m.now()
+	data, err := encode(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(m.dir, now.UTC().Format("20060102T150405")+"-*.eml")
+	if err != nil {
+		return fmt.Errorf("creating mail file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("writing mail file: %w", err)
+	}
+	return f.Close()
+}
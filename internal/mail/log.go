@@ -0,0 +1,15 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogMailer only logs messages, links included. It is the development
+// default and must not be used where the log is shared.
+type LogMailer struct{}
+
+func (LogMailer) Send(_ context.Context, msg Message) error {
+	slog.Info("mail not sent (log mailer)", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
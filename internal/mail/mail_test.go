@@ -0,0 +1,63 @@
+package mail
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	mailer, err := NewFileMailer(dir, "ctf01d <noreply@ctf.example>")
+	if err != nil {
+		t.Fatalf("NewFileMailer: %v", err)
+	}
+	msg := Message{To: "alice@example.com", Subject: "Сброс пароля", Body: "Ссылка:\nhttps://ctf.example/reset-password?token=abc\n"}
+	if err := mailer.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v (%v)", files, err)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	parsed, err := mail.ReadMessage(f)
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("subject = %q (%v), want %q", subject, err, msg.Subject)
+	}
+	if !strings.HasSuffix(parsed.Header.Get("Message-ID"), "@ctf.example>") {
+		t.Errorf("unexpected Message-ID %q", parsed.Header.Get("Message-ID"))
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.ReplaceAll(string(body), "\r\n", "\n"); got != msg.Body {
+		t.Errorf("body = %q, want %q", got, msg.Body)
+	}
+}
+
+func TestSendRejectsBadRecipient(t *testing.T) {
+	mailer, err := NewFileMailer(t.TempDir(), "noreply@ctf.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mailer.Send(context.Background(), Message{To: "not an address", Subject: "x", Body: "x"}); err == nil {
+		t.Error("expected an error for an invalid recipient")
+	}
+}
@@ -0,0 +1,87 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Template names, one file per language under templates/<language>/.
+const (
+	TemplateVerifyEmail   = "verify_email"
+	TemplateResetPassword = "reset_password"
+)
+
+// defaultLanguage is used for users whose language has no templates.
+const defaultLanguage = "en"
+
+//go:embed templates
+var templateFS embed.FS
+
+// TemplateData is what the account templates can refer to.
+type TemplateData struct {
+	UserName    string
+	DisplayName string
+	Email       string
+	Link        string
+	ValidHours  int
+}
+
+// hours spells a duration in hours with the plural form of the language.
+var hours = map[string]func(n int) string{
+	"en": func(n int) string {
+		if n == 1 {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", n)
+	},
+	"ru": func(n int) string {
+		word := "часов"
+		switch {
+		case n%100 >= 11 && n%100 <= 14:
+		case n%10 == 1:
+			word = "час"
+		case n%10 >= 2 && n%10 <= 4:
+			word = "часа"
+		}
+		return fmt.Sprintf("%d %s", n, word)
+	},
+}
+
+var templates = parseTemplates()
+
+func parseTemplates() map[string]map[string]*template.Template {
+	out := map[string]map[string]*template.Template{}
+	for language, hoursFunc := range hours {
+		out[language] = map[string]*template.Template{}
+		for _, name := range []string{TemplateVerifyEmail, TemplateResetPassword} {
+			out[language][name] = template.Must(template.New(name).
+				Funcs(template.FuncMap{"hours": hoursFunc}).
+				ParseFS(templateFS, "templates/"+language+"/"+name+".txt"))
+		}
+	}
+	return out
+}
+
+// Compose renders the named template in the user's language, falling back to
+// English, into a message for to.
+func Compose(to, language, name string, data TemplateData) (Message, error) {
+	set, ok := templates[strings.ToLower(language)]
+	if !ok {
+		set = templates[defaultLanguage]
+	}
+	tmpl, ok := set[name]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown mail template %q", name)
+	}
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, fmt.Errorf("rendering %s subject: %w", name, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, fmt.Errorf("rendering %s body: %w", name, err)
+	}
+	return Message{To: to, Subject: strings.TrimSpace(subject.String()), Body: body.String()}, nil
+}
@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompose(t *testing.T) {
+	data := TemplateData{UserName: "alice", DisplayName: "Alice", Email: "alice@example.com", Link: "https://ctf.example/verify-email?token=abc", ValidHours: 24}
+
+	tests := []struct {
+		language string
+		name     string
+		subject  string
+		hours    string
+	}{
+		{"en", TemplateVerifyEmail, "Confirm your email address", "24 hours"},
+		{"ru", TemplateVerifyEmail, "Подтвердите адрес электронной почты", "24 часа"},
+		{"RU", TemplateResetPassword, "Сброс пароля", "24 часа"},
+		{"de", TemplateResetPassword, "Reset your password", "24 hours"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.language+"/"+tt.name, func(t *testing.T) {
+			msg, err := Compose("alice@example.com", tt.language, tt.name, data)
+			if err != nil {
+				t.Fatalf("Compose: %v", err)
+			}
+			if msg.To != "alice@example.com" || msg.Subject != tt.subject {
+				t.Errorf("to %q, subject %q", msg.To, msg.Subject)
+			}
+			for _, want := range []string{data.Link, data.DisplayName, tt.hours} {
+				if !strings.Contains(msg.Body, want) {
+					t.Errorf("body does not mention %q:\n%s", want, msg.Body)
+				}
+			}
+		})
+	}
+
+	if _, err := Compose("alice@example.com", "en", "welcome", data); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+}
+
+func TestRussianHours(t *testing.T) {
+	for n, want := range map[int]string{1: "1 час", 2: "2 часа", 5: "5 часов", 11: "11 часов", 21: "21 час", 24: "24 часа"} {
+		if got := hours["ru"](n); got != want {
+			t.Errorf("hours(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
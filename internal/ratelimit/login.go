@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// LoginPolicy sets how failed sign-ins are throttled, per user name and per
+// client address. A counter lets a third of its maximum failures through
+// without delay; past that, each failure doubles the wait before the next
+// attempt, from a second up to a minute. At the maximum the user name or
+// address is locked out for Lockout. An attempt made too early starts the
+// wait over. A counter is forgotten Lockout after its last failure. A maximum
+// of zero turns the counter off.
+type LoginPolicy struct {
+	MaxUserFailures int
+	MaxIPFailures   int
+	Lockout         time.Duration
+}
+
+const (
+	loginBaseDelay = time.Second
+	loginMaxDelay  = time.Minute
+
+	loginUserPrefix = "login:user:"
+	loginIPPrefix   = "login:ip:"
+)
+
+// LoginGuard applies a LoginPolicy to the sign-ins it is told about.
+type LoginGuard struct {
+	store  Store
+	policy LoginPolicy
+}
+
+func NewLoginGuard(store Store, policy LoginPolicy) *LoginGuard {
+	return &LoginGuard{store: store, policy: policy}
+}
+
+type loginCounter struct {
+	key         string
+	maxFailures int
+}
+
+func (g *LoginGuard) counters(userName, ip string) []loginCounter {
+	counters := make([]loginCounter, 0, 2)
+	if g.policy.MaxUserFailures > 0 {
+		counters = append(counters, loginCounter{loginUserPrefix + strings.ToLower(userName), g.policy.MaxUserFailures})
+	}
+	if g.policy.MaxIPFailures > 0 && ip != "" {
+		counters = append(counters, loginCounter{loginIPPrefix + ip, g.policy.MaxIPFailures})
+	}
+	return counters
+}
+
+// Attempt reserves a sign-in attempt of userName from ip before its password
+// is checked: the attempt is counted as a failure up front, and whether it may
+// go ahead follows from the count it got, so concurrent attempts cannot slip
+// past one check together. It returns how long to wait when the attempt
+// comes too soon; such an attempt is taken back, and as its wait started over
+// the full delay is returned. Zero lets the attempt through, and it stays
+// counted unless Succeeded is called.
+func (g *LoginGuard) Attempt(ctx context.Context, userName, ip string) (time.Duration, error) {
+	counters := g.counters(userName, ip)
+	var early bool
+	var wait time.Duration
+	for i, c := range counters {
+		before, err := g.store.AddFailure(ctx, c.key, g.policy.Lockout)
+		if err != nil {
+			g.release(ctx, counters[:i])
+			return 0, err
+		}
+		delay := g.delay(before.Count, c.maxFailures)
+		early = early || delay > before.SinceLast
+		wait = max(wait, delay)
+	}
+	if !early {
+		return 0, nil
+	}
+	g.release(ctx, counters)
+	return wait, nil
+}
+
+// Succeeded forgets the failures of the user name and takes back the attempt
+// counted against the address. The address keeps its earlier failures:
+// otherwise signing in to an account of one's own would reset them between
+// guesses at others.
+func (g *LoginGuard) Succeeded(ctx context.Context, userName, ip string) error {
+	for _, c := range g.counters(userName, ip) {
+		var err error
+		if strings.HasPrefix(c.key, loginUserPrefix) {
+			err = g.store.ResetFailures(ctx, c.key)
+		} else {
+			err = g.store.RemoveFailure(ctx, c.key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// release takes back the attempt counted on each counter. A counter left
+// behind only makes the next attempt wait longer, so errors are ignored.
+func (g *LoginGuard) release(ctx context.Context, counters []loginCounter) {
+	for _, c := range counters {
+		_ = g.store.RemoveFailure(ctx, c.key)
+	}
+}
+
+// delay is the wait after the last of count failures on a counter.
+func (g *LoginGuard) delay(count, maxFailures int) time.Duration {
+	if count >= maxFailures {
+		return g.policy.Lockout
+	}
+	excess := count - maxFailures/3
+	if excess <= 0 {
+		return 0
+	}
+	if excess > 7 {
+		return loginMaxDelay
+	}
+	return min(loginBaseDelay<<(excess-1), loginMaxDelay)
+}
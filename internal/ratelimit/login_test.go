@@ -0,0 +1,147 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoginGuard_ProgressiveDelayAndLockout(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newClockedStore()
+	g := NewLoginGuard(s, LoginPolicy{MaxUserFailures: 6, MaxIPFailures: 100, Lockout: 15 * time.Minute})
+
+	// The first third of the maximum is free, then the wait doubles.
+	wantWaits := []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second, 15 * time.Minute}
+	for i, want := range wantWaits {
+		if wait, err := g.Attempt(ctx, "Alice", "192.0.2.1"); err != nil || wait != 0 {
+			t.Fatalf("attempt %d: wait %s, err %v", i+1, wait, err)
+		}
+		if want > 0 {
+			wait, err := g.Attempt(ctx, "alice", "198.51.100.7")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if wait != want {
+				t.Fatalf("after %d failures: wait %s, want %s", i+1, wait, want)
+			}
+		}
+		clock.advance(want)
+	}
+
+	if wait, _ := g.Attempt(ctx, "alice", "198.51.100.7"); wait != 0 {
+		t.Fatalf("lockout over: wait %s", wait)
+	}
+	if wait, _ := g.Attempt(ctx, "bob", "192.0.2.1"); wait != 0 {
+		t.Fatalf("another user from the same address: wait %s", wait)
+	}
+}
+
+func TestLoginGuard_EarlyAttemptRestartsWait(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newClockedStore()
+	g := NewLoginGuard(s, LoginPolicy{MaxUserFailures: 3, Lockout: time.Minute})
+
+	for range 2 {
+		_, _ = g.Attempt(ctx, "alice", "")
+	}
+	clock.advance(500 * time.Millisecond)
+	if wait, _ := g.Attempt(ctx, "alice", ""); wait != time.Second {
+		t.Fatalf("early attempt: wait %s, want 1s", wait)
+	}
+	clock.advance(time.Second)
+	if wait, _ := g.Attempt(ctx, "alice", ""); wait != 0 {
+		t.Fatalf("refused attempt must not be counted: wait %s", wait)
+	}
+}
+
+func TestLoginGuard_PerAddress(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newClockedStore()
+	g := NewLoginGuard(s, LoginPolicy{MaxUserFailures: 100, MaxIPFailures: 3, Lockout: time.Minute})
+
+	for _, user := range []string{"a", "b", "c"} {
+		if wait, _ := g.Attempt(ctx, user, "192.0.2.1"); wait != 0 {
+			t.Fatalf("user %s: wait %s", user, wait)
+		}
+		clock.advance(time.Second)
+	}
+	if wait, _ := g.Attempt(ctx, "d", "192.0.2.1"); wait <= 0 {
+		t.Fatal("sprayed address must be locked out")
+	}
+	if wait, _ := g.Attempt(ctx, "d", "192.0.2.2"); wait != 0 {
+		t.Fatalf("other address: wait %s", wait)
+	}
+
+}
+
+func TestLoginGuard_SucceededKeepsAddressFailures(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newClockedStore()
+	g := NewLoginGuard(s, LoginPolicy{MaxUserFailures: 100, MaxIPFailures: 6, Lockout: time.Minute})
+
+	for _, user := range []string{"a", "b", "c"} {
+		_, _ = g.Attempt(ctx, user, "192.0.2.1")
+		clock.advance(time.Second)
+	}
+	if wait, _ := g.Attempt(ctx, "d", "192.0.2.1"); wait != 0 {
+		t.Fatalf("fourth attempt: wait %s", wait)
+	}
+	// The sign-in takes back its own attempt only: three failures stay.
+	_ = g.Succeeded(ctx, "d", "192.0.2.1")
+	if wait, _ := g.Attempt(ctx, "e", "192.0.2.1"); wait != time.Second {
+		t.Fatalf("after a sign-in: wait %s, want 1s", wait)
+	}
+}
+
+func TestLoginGuard_SucceededResetsUser(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newClockedStore()
+	g := NewLoginGuard(s, LoginPolicy{MaxUserFailures: 3, Lockout: time.Minute})
+
+	for range 3 {
+		_, _ = g.Attempt(ctx, "alice", "")
+		clock.advance(time.Second)
+	}
+	if wait, _ := g.Attempt(ctx, "alice", ""); wait == 0 {
+		t.Fatal("expected a lockout")
+	}
+	_ = g.Succeeded(ctx, "alice", "")
+	if wait, _ := g.Attempt(ctx, "alice", ""); wait != 0 {
+		t.Fatalf("after success: wait %s", wait)
+	}
+}
+
+func TestLoginGuard_ConcurrentAttempts(t *testing.T) {
+	ctx := context.Background()
+	g := NewLoginGuard(NewMemoryStore(), LoginPolicy{MaxUserFailures: 6, Lockout: time.Minute})
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait, err := g.Attempt(ctx, "alice", "")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if wait == 0 {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Only the free attempts get through a burst; the rest have to wait.
+	if allowed > 6 || allowed < 1 {
+		t.Fatalf("allowed %d concurrent attempts, want at most 6", allowed)
+	}
+}
@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+	expires time.Time
+}
+
+type memoryCounter struct {
+	count   int
+	last    time.Time
+	expires time.Time
+}
+
+// MemoryStore keeps the state in the process. Each replica counts on its own,
+// so it only suits a single instance and tests.
+type MemoryStore struct {
+	mu       sync.Mutex
+	buckets  map[string]*memoryBucket
+	counters map[string]*memoryCounter
+	now      func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  make(map[string]*memoryBucket),
+		counters: make(map[string]*memoryCounter),
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, rate Rate) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(rate.Limit), updated: now}
+		s.buckets[key] = b
+	}
+	elapsed := max(now.Sub(b.updated).Seconds(), 0)
+	available := math.Min(float64(rate.Limit), b.tokens+elapsed*rate.perSecond())
+	d := decide(available, rate)
+	if d.Allowed {
+		available--
+	}
+	b.tokens, b.updated, b.expires = available, now, now.Add(rate.Period)
+	return d, nil
+}
+
+func (s *MemoryStore) AddFailure(_ context.Context, key string, window time.Duration) (Failures, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var before Failures
+	c, ok := s.counters[key]
+	if !ok || !c.expires.After(now) {
+		c = &memoryCounter{}
+		s.counters[key] = c
+	} else {
+		before = Failures{Count: c.count, SinceLast: now.Sub(c.last)}
+	}
+	c.count++
+	c.last, c.expires = now, now.Add(window)
+	return before, nil
+}
+
+func (s *MemoryStore) RemoveFailure(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.counters[key]; ok && c.count > 0 {
+		c.count--
+	}
+	return nil
+}
+
+func (s *MemoryStore) ResetFailures(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, key)
+	return nil
+}
+
+func (s *MemoryStore) Prune(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, b := range s.buckets {
+		if !b.expires.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, c := range s.counters {
+		if !c.expires.After(now) {
+			delete(s.counters, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock drives a MemoryStore through time.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newClockedStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 7, 16, 12, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = func() time.Time { return clock.now }
+	return s, clock
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newClockedStore()
+	rate := Rate{Limit: 3, Period: time.Minute}
+
+	for i := range 3 {
+		if d, _ := s.Take(ctx, "k", rate); !d.Allowed {
+			t.Fatalf("request %d within the burst refused", i+1)
+		}
+	}
+	d, _ := s.Take(ctx, "k", rate)
+	if d.Allowed || d.RetryAfter != 20*time.Second {
+		t.Fatalf("over the burst: got %+v, want a 20s wait", d)
+	}
+	if d, _ := s.Take(ctx, "other", rate); !d.Allowed {
+		t.Fatal("keys must not share a bucket")
+	}
+
+	clock.advance(20 * time.Second)
+	if d, _ := s.Take(ctx, "k", rate); !d.Allowed {
+		t.Fatal("a token must be back after 20s")
+	}
+	if d, _ := s.Take(ctx, "k", rate); d.Allowed {
+		t.Fatal("only one token refilled")
+	}
+
+	clock.advance(time.Hour)
+	for i := range 3 {
+		if d, _ := s.Take(ctx, "k", rate); !d.Allowed {
+			t.Fatalf("refilled bucket: request %d refused", i+1)
+		}
+	}
+}
+
+func TestMemoryStore_Failures(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newClockedStore()
+
+	for range 2 {
+		if _, err := s.AddFailure(ctx, "k", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	clock.advance(10 * time.Second)
+	f, _ := s.AddFailure(ctx, "k", time.Minute)
+	if f.Count != 2 || f.SinceLast != 10*time.Second {
+		t.Fatalf("got %+v", f)
+	}
+
+	if err := s.RemoveFailure(ctx, "k"); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := s.AddFailure(ctx, "k", time.Minute); f.Count != 2 || f.SinceLast != 0 {
+		t.Fatalf("after taking one back: %+v", f)
+	}
+
+	clock.advance(2 * time.Minute)
+	if f, _ := s.AddFailure(ctx, "k", time.Minute); f.Count != 0 {
+		t.Fatalf("counter outlived its window: %+v", f)
+	}
+
+	if err := s.ResetFailures(ctx, "k"); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := s.AddFailure(ctx, "k", time.Minute); f.Count != 0 {
+		t.Fatalf("reset counter: %+v", f)
+	}
+}
+
+func TestMemoryStore_Prune(t *testing.T) {
+	ctx := context.Background()
+	s, clock := newClockedStore()
+
+	_, _ = s.Take(ctx, "bucket", Rate{Limit: 1, Period: time.Minute})
+	_, _ = s.AddFailure(ctx, "counter", time.Hour)
+	clock.advance(2 * time.Minute)
+	if err := s.Prune(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.buckets["bucket"]; ok {
+		t.Error("full bucket not pruned")
+	}
+	if _, ok := s.counters["counter"]; !ok {
+		t.Error("live counter pruned")
+	}
+}
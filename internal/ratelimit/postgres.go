@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type PostgresQueries interface {
+	EnsureRateLimitBucket(ctx context.Context, arg db.EnsureRateLimitBucketParams) error
+	TakeRateLimitToken(ctx context.Context, arg db.TakeRateLimitTokenParams) (float64, error)
+	AddRateLimitFailure(ctx context.Context, arg db.AddRateLimitFailureParams) (db.AddRateLimitFailureRow, error)
+	RemoveRateLimitFailure(ctx context.Context, key string) error
+	DeleteRateLimitFailures(ctx context.Context, key string) error
+	DeleteExpiredRateLimitBuckets(ctx context.Context) error
+	DeleteExpiredRateLimitFailures(ctx context.Context) error
+}
+
+// PostgresStore keeps the state in the database, shared by every replica.
+// Times come from the database clock.
+type PostgresStore struct {
+	q PostgresQueries
+}
+
+func NewPostgresStore(q PostgresQueries) *PostgresStore {
+	return &PostgresStore{q: q}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, rate Rate) (Decision, error) {
+	burst := float64(rate.Limit)
+	if err := s.q.EnsureRateLimitBucket(ctx, db.EnsureRateLimitBucketParams{Key: key, Burst: burst}); err != nil {
+		return Decision{}, fmt.Errorf("creating rate limit bucket: %w", err)
+	}
+	available, err := s.q.TakeRateLimitToken(ctx, db.TakeRateLimitTokenParams{
+		Key:           key,
+		Burst:         burst,
+		PerSecond:     rate.perSecond(),
+		PeriodSeconds: rate.Period.Seconds(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Pruned between the two statements: the bucket was full.
+		return Decision{Allowed: true}, nil
+	}
+	if err != nil {
+		return Decision{}, fmt.Errorf("taking rate limit token: %w", err)
+	}
+	return decide(available, rate), nil
+}
+
+func (s *PostgresStore) AddFailure(ctx context.Context, key string, window time.Duration) (Failures, error) {
+	row, err := s.q.AddRateLimitFailure(ctx, db.AddRateLimitFailureParams{Key: key, WindowSeconds: window.Seconds()})
+	if err != nil {
+		return Failures{}, fmt.Errorf("counting failure: %w", err)
+	}
+	return Failures{
+		Count:     int(row.Failures),
+		SinceLast: time.Duration(row.SecondsSinceLast * float64(time.Second)),
+	}, nil
+}
+
+func (s *PostgresStore) RemoveFailure(ctx context.Context, key string) error {
+	if err := s.q.RemoveRateLimitFailure(ctx, key); err != nil {
+		return fmt.Errorf("taking back failure: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) ResetFailures(ctx context.Context, key string) error {
+	return s.q.DeleteRateLimitFailures(ctx, key)
+}
+
+func (s *PostgresStore) Prune(ctx context.Context) error {
+	if err := s.q.DeleteExpiredRateLimitBuckets(ctx); err != nil {
+		return fmt.Errorf("pruning rate limit buckets: %w", err)
+	}
+	if err := s.q.DeleteExpiredRateLimitFailures(ctx); err != nil {
+		return fmt.Errorf("pruning failure counters: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Package ratelimit throttles requests: token buckets that cap how often a
+// caller may use an API operation, and failure counters that slow down and
+// then lock out password guessing. The state lives in a Store; the Postgres
+// one is shared by every replica, the in-memory one suits a single process.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate allows Limit requests per Period, all of which may come in one burst.
+type Rate struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParseRate reads a rate written as "<limit>/<period>", e.g. "5/15m".
+func ParseRate(s string) (Rate, error) {
+	limit, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Rate{}, fmt.Errorf("rate %q: want <limit>/<period>", s)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(limit))
+	if err != nil || n <= 0 {
+		return Rate{}, fmt.Errorf("rate %q: limit must be a positive integer", s)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return Rate{}, fmt.Errorf("rate %q: period must be a positive duration", s)
+	}
+	return Rate{Limit: n, Period: d}, nil
+}
+
+func (r Rate) String() string {
+	return strconv.Itoa(r.Limit) + "/" + r.Period.String()
+}
+
+// perSecond is the refill speed of the bucket.
+func (r Rate) perSecond() float64 {
+	return float64(r.Limit) / r.Period.Seconds()
+}
+
+// Decision is the outcome of taking a token.
+type Decision struct {
+	Allowed bool
+	// RetryAfter is how long until a token is available when not allowed.
+	RetryAfter time.Duration
+}
+
+// decide turns the tokens a bucket held before taking into a decision.
+func decide(available float64, rate Rate) Decision {
+	if available >= 1 {
+		return Decision{Allowed: true}
+	}
+	wait := (1 - available) / rate.perSecond()
+	return Decision{RetryAfter: time.Duration(math.Ceil(wait * float64(time.Second)))}
+}
+
+// Failures is the state of a failure counter.
+type Failures struct {
+	Count int
+	// SinceLast is the time since the most recent failure.
+	SinceLast time.Duration
+}
+
+// Store keeps buckets and failure counters. Times are measured by the store,
+// so replicas sharing one agree on them.
+type Store interface {
+	// Take takes a token from the bucket key, which refills at rate.
+	Take(ctx context.Context, key string, rate Rate) (Decision, error)
+	// AddFailure counts a failure under key and returns the counter as it
+	// was before, zero once window had passed since its last failure.
+	// Concurrent callers each see a different count. The counter is
+	// forgotten window after its last failure.
+	AddFailure(ctx context.Context, key string, window time.Duration) (Failures, error)
+	// RemoveFailure takes back one failure counted under key. The time of
+	// the last failure stays.
+	RemoveFailure(ctx context.Context, key string) error
+	// ResetFailures forgets the counter key.
+	ResetFailures(ctx context.Context, key string) error
+	// Prune drops buckets that are full again and forgotten counters.
+	Prune(ctx context.Context) error
+}
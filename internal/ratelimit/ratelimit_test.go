@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	rate, err := ParseRate("5/15m")
+	if err != nil {
+		t.Fatalf("ParseRate: %v", err)
+	}
+	if rate.Limit != 5 || rate.Period != 15*time.Minute {
+		t.Fatalf("got %+v", rate)
+	}
+	if rate.String() != "5/15m0s" {
+		t.Errorf("String() = %q", rate.String())
+	}
+
+	for _, bad := range []string{"", "5", "0/1m", "-1/1m", "x/1m", "5/", "5/0s", "5/soon"} {
+		if _, err := ParseRate(bad); err == nil {
+			t.Errorf("ParseRate(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestDecide(t *testing.T) {
+	rate := Rate{Limit: 6, Period: time.Minute}
+	if d := decide(1, rate); !d.Allowed {
+		t.Errorf("a whole token must be allowed: %+v", d)
+	}
+	d := decide(0.5, rate)
+	if d.Allowed || d.RetryAfter != 5*time.Second {
+		t.Errorf("half a token at 0.1/s: got %+v, want a 5s wait", d)
+	}
+}
@@ -221,6 +221,17 @@ func (q *Queries) ListGames(ctx context.Context, arg ListGamesParams) ([]Game, e
 	return items, nil
 }
 
+const lockGameForUpdate = `-- name: LockGameForUpdate :one
+SELECT id FROM games WHERE id = $1 FOR UPDATE
+`
+
+func (q *Queries) LockGameForUpdate(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRow(ctx, lockGameForUpdate, id)
+	var id_2 int64
+	err := row.Scan(&id_2)
+	return id_2, err
+}
+
 const setFinalized = `-- name: SetFinalized :one
 UPDATE games SET finalized = $2, finalized_at = $3, updated_at = now()
 WHERE id = $1
@@ -26,6 +26,122 @@ func (q *Queries) AddService(ctx context.Context, arg AddServiceParams) error {
 	return err
 }
 
+const listGamePinsByService = `-- name: ListGamePinsByService :many
+SELECT gs.game_id, g.name AS game_name, g.finalized AS game_finalized, gs.status,
+    gs.pinned_service_version_id, gs.pinned_checker_version_id
+FROM games_services gs
+JOIN games g ON g.id = gs.game_id
+WHERE gs.service_id = $1
+ORDER BY g.starts_at DESC NULLS LAST, gs.game_id DESC
+`
+
+type ListGamePinsByServiceRow struct {
+	GameID                 int64   `json:"game_id"`
+	GameName               *string `json:"game_name"`
+	GameFinalized          bool    `json:"game_finalized"`
+	Status                 string  `json:"status"`
+	PinnedServiceVersionID *int64  `json:"pinned_service_version_id"`
+	PinnedCheckerVersionID *int64  `json:"pinned_checker_version_id"`
+}
+
+func (q *Queries) ListGamePinsByService(ctx context.Context, serviceID int64) ([]ListGamePinsByServiceRow, error) {
+	rows, err := q.db.Query(ctx, listGamePinsByService, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGamePinsByServiceRow
+	for rows.Next() {
+		var i ListGamePinsByServiceRow
+		if err := rows.Scan(
+			&i.GameID,
+			&i.GameName,
+			&i.GameFinalized,
+			&i.Status,
+			&i.PinnedServiceVersionID,
+			&i.PinnedCheckerVersionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameServicePins = `-- name: ListGameServicePins :many
+SELECT gs.service_id, sv.storage_key AS service_storage_key, cv.storage_key AS checker_storage_key
+FROM games_services gs
+LEFT JOIN service_archive_versions sv ON sv.id = gs.pinned_service_version_id
+LEFT JOIN service_archive_versions cv ON cv.id = gs.pinned_checker_version_id
+WHERE gs.game_id = $1
+  AND (gs.pinned_service_version_id IS NOT NULL OR gs.pinned_checker_version_id IS NOT NULL)
+`
+
+type ListGameServicePinsRow struct {
+	ServiceID         int64   `json:"service_id"`
+	ServiceStorageKey *string `json:"service_storage_key"`
+	CheckerStorageKey *string `json:"checker_storage_key"`
+}
+
+// Storage keys the ctf01d export should use; NULL keys follow the service's
+// current archive.
+func (q *Queries) ListGameServicePins(ctx context.Context, gameID int64) ([]ListGameServicePinsRow, error) {
+	rows, err := q.db.Query(ctx, listGameServicePins, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGameServicePinsRow
+	for rows.Next() {
+		var i ListGameServicePinsRow
+		if err := rows.Scan(&i.ServiceID, &i.ServiceStorageKey, &i.CheckerStorageKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameServicePorts = `-- name: ListGameServicePorts :many
+SELECT s.id, s.name, s.ports
+FROM games_services gs
+JOIN services s ON s.id = gs.service_id
+WHERE gs.game_id = $1
+ORDER BY s.id
+`
+
+type ListGameServicePortsRow struct {
+	ID    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Ports []int32 `json:"ports"`
+}
+
+func (q *Queries) ListGameServicePorts(ctx context.Context, gameID int64) ([]ListGameServicePortsRow, error) {
+	rows, err := q.db.Query(ctx, listGameServicePorts, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGameServicePortsRow
+	for rows.Next() {
+		var i ListGameServicePortsRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Ports); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listServiceIDsByGame = `-- name: ListServiceIDsByGame :many
 SELECT service_id FROM games_services WHERE game_id = $1
 `
@@ -51,12 +167,24 @@ func (q *Queries) ListServiceIDsByGame(ctx context.Context, gameID int64) ([]int
 }
 
 const listServicesByGame = `-- name: ListServicesByGame :many
-SELECT service_id, status FROM games_services WHERE game_id = $1
+SELECT gs.service_id, gs.status, gs.pinned_service_version_id, gs.pinned_checker_version_id,
+    sv.source_kind AS service_source_kind, sv.source_ref AS service_source_ref,
+    cv.source_kind AS checker_source_kind, cv.source_ref AS checker_source_ref
+FROM games_services gs
+LEFT JOIN service_archive_versions sv ON sv.id = gs.pinned_service_version_id
+LEFT JOIN service_archive_versions cv ON cv.id = gs.pinned_checker_version_id
+WHERE gs.game_id = $1
 `
 
 type ListServicesByGameRow struct {
-	ServiceID int64  `json:"service_id"`
-	Status    string `json:"status"`
+	ServiceID              int64   `json:"service_id"`
+	Status                 string  `json:"status"`
+	PinnedServiceVersionID *int64  `json:"pinned_service_version_id"`
+	PinnedCheckerVersionID *int64  `json:"pinned_checker_version_id"`
+	ServiceSourceKind      *string `json:"service_source_kind"`
+	ServiceSourceRef       *string `json:"service_source_ref"`
+	CheckerSourceKind      *string `json:"checker_source_kind"`
+	CheckerSourceRef       *string `json:"checker_source_ref"`
 }
 
 func (q *Queries) ListServicesByGame(ctx context.Context, gameID int64) ([]ListServicesByGameRow, error) {
@@ -68,7 +196,16 @@ func (q *Queries) ListServicesByGame(ctx context.Context, gameID int64) ([]ListS
 	var items []ListServicesByGameRow
 	for rows.Next() {
 		var i ListServicesByGameRow
-		if err := rows.Scan(&i.ServiceID, &i.Status); err != nil {
+		if err := rows.Scan(
+			&i.ServiceID,
+			&i.Status,
+			&i.PinnedServiceVersionID,
+			&i.PinnedCheckerVersionID,
+			&i.ServiceSourceKind,
+			&i.ServiceSourceRef,
+			&i.CheckerSourceKind,
+			&i.CheckerSourceRef,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -79,6 +216,29 @@ func (q *Queries) ListServicesByGame(ctx context.Context, gameID int64) ([]ListS
 	return items, nil
 }
 
+const pinCurrentServiceVersions = `-- name: PinCurrentServiceVersions :exec
+UPDATE games_services gs SET
+    pinned_service_version_id = (
+        SELECT v.id FROM service_archive_versions v
+        WHERE v.service_id = s.id AND v.kind = 'service' AND v.storage_key = s.service_local_path
+    ),
+    pinned_checker_version_id = (
+        SELECT v.id FROM service_archive_versions v
+        WHERE v.service_id = s.id AND v.kind = 'checker' AND v.storage_key = s.checker_local_path
+    )
+FROM services s
+WHERE gs.game_id = $1
+  AND s.id = gs.service_id
+  AND gs.pinned_service_version_id IS NULL
+  AND gs.pinned_checker_version_id IS NULL
+`
+
+// Pins every unpinned service of the game to its active archives.
+func (q *Queries) PinCurrentServiceVersions(ctx context.Context, gameID int64) error {
+	_, err := q.db.Exec(ctx, pinCurrentServiceVersions, gameID)
+	return err
+}
+
 const removeService = `-- name: RemoveService :exec
 DELETE FROM games_services WHERE game_id = $1 AND service_id = $2
 `
@@ -93,6 +253,33 @@ func (q *Queries) RemoveService(ctx context.Context, arg RemoveServiceParams) er
 	return err
 }
 
+const setGameServicePin = `-- name: SetGameServicePin :execrows
+UPDATE games_services SET
+    pinned_service_version_id = $1,
+    pinned_checker_version_id = $2
+WHERE game_id = $3 AND service_id = $4
+`
+
+type SetGameServicePinParams struct {
+	PinnedServiceVersionID *int64 `json:"pinned_service_version_id"`
+	PinnedCheckerVersionID *int64 `json:"pinned_checker_version_id"`
+	GameID                 int64  `json:"game_id"`
+	ServiceID              int64  `json:"service_id"`
+}
+
+func (q *Queries) SetGameServicePin(ctx context.Context, arg SetGameServicePinParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setGameServicePin,
+		arg.PinnedServiceVersionID,
+		arg.PinnedCheckerVersionID,
+		arg.GameID,
+		arg.ServiceID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const setServiceStatus = `-- name: SetServiceStatus :exec
 UPDATE games_services SET status = $3
 WHERE game_id = $1 AND service_id = $2
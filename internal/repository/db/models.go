@@ -11,6 +11,20 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AuditEvent struct {
+	ID         int64     `json:"id"`
+	ActorID    *int64    `json:"actor_id"`
+	ActorName  *string   `json:"actor_name"`
+	IpAddress  *string   `json:"ip_address"`
+	RequestID  *string   `json:"request_id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Action     string    `json:"action"`
+	Before     []byte    `json:"before"`
+	After      []byte    `json:"after"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 type FinalResult struct {
 	ID        int64     `json:"id"`
 	GameID    int64     `json:"game_id"`
@@ -47,6 +61,24 @@ type Game struct {
 	Requirements         *string            `json:"requirements"`
 }
 
+type GameExport struct {
+	ID         int64     `json:"id"`
+	GameID     int64     `json:"game_id"`
+	StorageKey string    `json:"storage_key"`
+	Sha256     string    `json:"sha256"`
+	Size       int64     `json:"size"`
+	Filename   string    `json:"filename"`
+	ModifiedAt time.Time `json:"modified_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GameRole struct {
+	GameID    int64     `json:"game_id"`
+	UserID    int64     `json:"user_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type GameTeam struct {
 	ID              int64           `json:"id"`
 	GameID          int64           `json:"game_id"`
@@ -61,9 +93,56 @@ type GameTeam struct {
 }
 
 type GamesService struct {
-	GameID    int64  `json:"game_id"`
-	ServiceID int64  `json:"service_id"`
-	Status    string `json:"status"`
+	GameID                 int64  `json:"game_id"`
+	ServiceID              int64  `json:"service_id"`
+	Status                 string `json:"status"`
+	PinnedServiceVersionID *int64 `json:"pinned_service_version_id"`
+	PinnedCheckerVersionID *int64 `json:"pinned_checker_version_id"`
+}
+
+type GitCredential struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	Kind             string    `json:"kind"`
+	HostPattern      *string   `json:"host_pattern"`
+	Username         *string   `json:"username"`
+	SecretCiphertext []byte    `json:"secret_ciphertext"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type LoginChallenge struct {
+	TokenHash  string    `json:"token_hash"`
+	UserID     int64     `json:"user_id"`
+	Enrollment bool      `json:"enrollment"`
+	Attempts   int32     `json:"attempts"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type OidcLoginState struct {
+	State        string    `json:"state"`
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	LinkUserID   *int64    `json:"link_user_id"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type RateLimitBucket struct {
+	Key       string    `json:"key"`
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type RateLimitFailure struct {
+	Key               string             `json:"key"`
+	Failures          int32              `json:"failures"`
+	LastFailureAt     time.Time          `json:"last_failure_at"`
+	ExpiresAt         time.Time          `json:"expires_at"`
+	PreviousFailureAt pgtype.Timestamptz `json:"previous_failure_at"`
 }
 
 type Result struct {
@@ -111,6 +190,108 @@ type Service struct {
 	GitSyncedAt         pgtype.Timestamptz `json:"git_synced_at"`
 	GitSyncStatus       string             `json:"git_sync_status"`
 	GitSyncError        *string            `json:"git_sync_error"`
+	GitCredentialID     *int64             `json:"git_credential_id"`
+	Readme              *string            `json:"readme"`
+	License             *string            `json:"license"`
+}
+
+type ServiceArchiveVersion struct {
+	ID         int64     `json:"id"`
+	ServiceID  int64     `json:"service_id"`
+	Kind       string    `json:"kind"`
+	StorageKey string    `json:"storage_key"`
+	Sha256     string    `json:"sha256"`
+	Size       int64     `json:"size"`
+	SourceKind string    `json:"source_kind"`
+	SourceRef  *string   `json:"source_ref"`
+	CreatedAt  time.Time `json:"created_at"`
+	Readme     *string   `json:"readme"`
+	ReadmePath *string   `json:"readme_path"`
+}
+
+type ServiceAuthor struct {
+	ServiceID int64     `json:"service_id"`
+	UserID    int64     `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ServiceDownloadLink struct {
+	ID            int64              `json:"id"`
+	ServiceID     int64              `json:"service_id"`
+	Kind          string             `json:"kind"`
+	ExpiresAt     time.Time          `json:"expires_at"`
+	MaxDownloads  *int32             `json:"max_downloads"`
+	DownloadCount int32              `json:"download_count"`
+	CreatedBy     *int64             `json:"created_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+	LastUsedAt    pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp    *string            `json:"last_used_ip"`
+	StorageKey    *string            `json:"storage_key"`
+}
+
+type ServiceSearchDocument struct {
+	ServiceID       int64       `json:"service_id"`
+	PublicDocument  interface{} `json:"public_document"`
+	PrivateDocument interface{} `json:"private_document"`
+}
+
+type ServiceSubmission struct {
+	ID          int64              `json:"id"`
+	SubmitterID int64              `json:"submitter_id"`
+	Status      string             `json:"status"`
+	SourceKind  string             `json:"source_kind"`
+	RepoUrl     *string            `json:"repo_url"`
+	GitRef      *string            `json:"git_ref"`
+	GitSubdir   *string            `json:"git_subdir"`
+	ArchiveKey  *string            `json:"archive_key"`
+	ServiceName string             `json:"service_name"`
+	Preview     json.RawMessage    `json:"preview"`
+	ServiceID   *int64             `json:"service_id"`
+	ReviewerID  *int64             `json:"reviewer_id"`
+	ReviewedAt  pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	GitCommit   *string            `json:"git_commit"`
+}
+
+type ServiceSubmissionComment struct {
+	ID           int64     `json:"id"`
+	SubmissionID int64     `json:"submission_id"`
+	AuthorID     *int64    `json:"author_id"`
+	ParentID     *int64    `json:"parent_id"`
+	Body         string    `json:"body"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ServiceVuln struct {
+	ID          int64     `json:"id"`
+	ServiceID   int64     `json:"service_id"`
+	Position    int32     `json:"position"`
+	Title       string    `json:"title"`
+	Cwe         *string   `json:"cwe"`
+	Difficulty  *string   `json:"difficulty"`
+	ExploitPath *string   `json:"exploit_path"`
+	Fix         *string   `json:"fix"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type SessionRefreshToken struct {
+	ID        int64              `json:"id"`
+	SessionID int64              `json:"session_id"`
+	TokenHash string             `json:"token_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type StorageBlob struct {
+	Sha256         string             `json:"sha256"`
+	StorageKey     string             `json:"storage_key"`
+	Size           int64              `json:"size"`
+	RefCount       int32              `json:"ref_count"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UnreferencedAt pgtype.Timestamptz `json:"unreferenced_at"`
+	VerifiedAt     pgtype.Timestamptz `json:"verified_at"`
+	CorruptAt      pgtype.Timestamptz `json:"corrupt_at"`
 }
 
 type Team struct {
@@ -149,6 +330,12 @@ type TeamMembershipEvent struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+type TwoFactorRequiredRole struct {
+	Role      string    `json:"role"`
+	CreatedBy *int64    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type University struct {
 	ID        int64     `json:"id"`
 	Name      *string   `json:"name"`
@@ -159,25 +346,66 @@ type University struct {
 }
 
 type User struct {
-	ID             int64              `json:"id"`
-	UserName       string             `json:"user_name"`
-	DisplayName    string             `json:"display_name"`
-	Role           string             `json:"role"`
-	Rating         int32              `json:"rating"`
-	AvatarUrl      *string            `json:"avatar_url"`
-	PasswordDigest *string            `json:"password_digest"`
-	CreatedAt      time.Time          `json:"created_at"`
-	UpdatedAt      time.Time          `json:"updated_at"`
-	Bio            *string            `json:"bio"`
-	Telegram       *string            `json:"telegram"`
-	Github         *string            `json:"github"`
-	Email          *string            `json:"email"`
-	IsBlocked      bool               `json:"is_blocked"`
-	BlockedAt      pgtype.Timestamptz `json:"blocked_at"`
-	LastLoginIp    *string            `json:"last_login_ip"`
-	LastLoginAt    pgtype.Timestamptz `json:"last_login_at"`
-	Language       string             `json:"language"`
-	Theme          string             `json:"theme"`
+	ID              int64              `json:"id"`
+	UserName        string             `json:"user_name"`
+	DisplayName     string             `json:"display_name"`
+	Role            string             `json:"role"`
+	Rating          int32              `json:"rating"`
+	AvatarUrl       *string            `json:"avatar_url"`
+	PasswordDigest  *string            `json:"password_digest"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	Bio             *string            `json:"bio"`
+	Telegram        *string            `json:"telegram"`
+	Github          *string            `json:"github"`
+	Email           *string            `json:"email"`
+	IsBlocked       bool               `json:"is_blocked"`
+	BlockedAt       pgtype.Timestamptz `json:"blocked_at"`
+	LastLoginIp     *string            `json:"last_login_ip"`
+	LastLoginAt     pgtype.Timestamptz `json:"last_login_at"`
+	Language        string             `json:"language"`
+	Theme           string             `json:"theme"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+}
+
+type UserApiToken struct {
+	ID          int64              `json:"id"`
+	UserID      int64              `json:"user_id"`
+	Name        string             `json:"name"`
+	TokenHash   string             `json:"token_hash"`
+	TokenPrefix string             `json:"token_prefix"`
+	Scopes      []string           `json:"scopes"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp  *string            `json:"last_used_ip"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type UserEmailToken struct {
+	TokenHash string    `json:"token_hash"`
+	UserID    int64     `json:"user_id"`
+	Purpose   string    `json:"purpose"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserIdentity struct {
+	ID          int64              `json:"id"`
+	UserID      int64              `json:"user_id"`
+	Provider    string             `json:"provider"`
+	Subject     string             `json:"subject"`
+	Email       *string            `json:"email"`
+	CreatedAt   time.Time          `json:"created_at"`
+	LastLoginAt pgtype.Timestamptz `json:"last_login_at"`
+}
+
+type UserRecoveryCode struct {
+	ID        int64              `json:"id"`
+	UserID    int64              `json:"user_id"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt time.Time          `json:"created_at"`
 }
 
 type UserSession struct {
@@ -192,6 +420,15 @@ type UserSession struct {
 	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
 }
 
+type UserTwoFactor struct {
+	UserID           int64              `json:"user_id"`
+	SecretCiphertext []byte             `json:"secret_ciphertext"`
+	ConfirmedAt      pgtype.Timestamptz `json:"confirmed_at"`
+	LastUsedStep     *int64             `json:"last_used_step"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
 type Writeup struct {
 	ID        int64     `json:"id"`
 	GameID    int64     `json:"game_id"`
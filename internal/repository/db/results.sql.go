@@ -191,6 +191,49 @@ func (q *Queries) ListResultsByTeam(ctx context.Context, teamID int64) ([]Result
 	return items, nil
 }
 
+const lockResult = `-- name: LockResult :one
+SELECT id, game_id, team_id, score, created_at, updated_at FROM results WHERE id = $1 FOR UPDATE
+`
+
+// Locks a result for a change, so its audit event starts from the state the
+// change replaced.
+func (q *Queries) LockResult(ctx context.Context, id int64) (Result, error) {
+	row := q.db.QueryRow(ctx, lockResult, id)
+	var i Result
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.TeamID,
+		&i.Score,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const lockResultByGameAndTeam = `-- name: LockResultByGameAndTeam :one
+SELECT id, game_id, team_id, score, created_at, updated_at FROM results WHERE game_id = $1 AND team_id = $2 FOR UPDATE
+`
+
+type LockResultByGameAndTeamParams struct {
+	GameID int64 `json:"game_id"`
+	TeamID int64 `json:"team_id"`
+}
+
+func (q *Queries) LockResultByGameAndTeam(ctx context.Context, arg LockResultByGameAndTeamParams) (Result, error) {
+	row := q.db.QueryRow(ctx, lockResultByGameAndTeam, arg.GameID, arg.TeamID)
+	var i Result
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.TeamID,
+		&i.Score,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateResult = `-- name: UpdateResult :one
 UPDATE results SET score = COALESCE($2, score), updated_at = now()
 WHERE id = $1
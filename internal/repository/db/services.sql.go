@@ -19,9 +19,11 @@ UPDATE services SET
     author = $4,
     copyright = $5,
     ctf01d_training = $6,
+    readme = $7,
+    license = $8,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type ApplyServiceImportMetadataParams struct {
@@ -31,6 +33,8 @@ type ApplyServiceImportMetadataParams struct {
 	Author            *string         `json:"author"`
 	Copyright         *string         `json:"copyright"`
 	Ctf01dTraining    json.RawMessage `json:"ctf01d_training"`
+	Readme            *string         `json:"readme"`
+	License           *string         `json:"license"`
 }
 
 func (q *Queries) ApplyServiceImportMetadata(ctx context.Context, arg ApplyServiceImportMetadataParams) (Service, error) {
@@ -41,6 +45,8 @@ func (q *Queries) ApplyServiceImportMetadata(ctx context.Context, arg ApplyServi
 		arg.Author,
 		arg.Copyright,
 		arg.Ctf01dTraining,
+		arg.Readme,
+		arg.License,
 	)
 	var i Service
 	err := row.Scan(
@@ -79,6 +85,9 @@ func (q *Queries) ApplyServiceImportMetadata(ctx context.Context, arg ApplyServi
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -105,7 +114,7 @@ const createService = `-- name: CreateService :one
 INSERT INTO services (name, public_description, private_description, author, copyright,
     avatar_url, public, service_archive_url, checker_archive_url, writeup_url, exploits_url,
     check_status, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref,
-    git_subdir, git_sync_status)
+    git_subdir, git_sync_status, git_credential_id, readme, license)
 VALUES (
     $1,
     $2,
@@ -126,9 +135,12 @@ VALUES (
     $17,
     $18,
     $19,
-    $20
+    $20,
+    $21,
+    $22,
+    $23
 )
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type CreateServiceParams struct {
@@ -152,6 +164,9 @@ type CreateServiceParams struct {
 	GitRef             *string         `json:"git_ref"`
 	GitSubdir          *string         `json:"git_subdir"`
 	GitSyncStatus      string          `json:"git_sync_status"`
+	GitCredentialID    *int64          `json:"git_credential_id"`
+	Readme             *string         `json:"readme"`
+	License            *string         `json:"license"`
 }
 
 func (q *Queries) CreateService(ctx context.Context, arg CreateServiceParams) (Service, error) {
@@ -176,6 +191,9 @@ func (q *Queries) CreateService(ctx context.Context, arg CreateServiceParams) (S
 		arg.GitRef,
 		arg.GitSubdir,
 		arg.GitSyncStatus,
+		arg.GitCredentialID,
+		arg.Readme,
+		arg.License,
 	)
 	var i Service
 	err := row.Scan(
@@ -214,6 +232,9 @@ func (q *Queries) CreateService(ctx context.Context, arg CreateServiceParams) (S
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -228,7 +249,7 @@ func (q *Queries) DeleteService(ctx context.Context, id int64) error {
 }
 
 const getServiceByID = `-- name: GetServiceByID :one
-SELECT id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error FROM services WHERE id = $1
+SELECT id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license FROM services WHERE id = $1
 `
 
 func (q *Queries) GetServiceByID(ctx context.Context, id int64) (Service, error) {
@@ -270,12 +291,15 @@ func (q *Queries) GetServiceByID(ctx context.Context, id int64) (Service, error)
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
 
 const getServiceByName = `-- name: GetServiceByName :one
-SELECT id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error FROM services WHERE name = $1
+SELECT id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license FROM services WHERE name = $1
 `
 
 func (q *Queries) GetServiceByName(ctx context.Context, name string) (Service, error) {
@@ -317,12 +341,15 @@ func (q *Queries) GetServiceByName(ctx context.Context, name string) (Service, e
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
 
 const listServices = `-- name: ListServices :many
-SELECT id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error FROM services
+SELECT id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license FROM services
 WHERE (public = $3 OR $3 IS NULL)
   AND (name ILIKE '%' || $4 || '%' OR $4 IS NULL)
 ORDER BY created_at DESC, id DESC
@@ -386,6 +413,9 @@ func (q *Queries) ListServices(ctx context.Context, arg ListServicesParams) ([]S
 			&i.GitSyncedAt,
 			&i.GitSyncStatus,
 			&i.GitSyncError,
+			&i.GitCredentialID,
+			&i.Readme,
+			&i.License,
 		); err != nil {
 			return nil, err
 		}
@@ -397,13 +427,182 @@ func (q *Queries) ListServices(ctx context.Context, arg ListServicesParams) ([]S
 	return items, nil
 }
 
+const listServicesByIDs = `-- name: ListServicesByIDs :many
+SELECT id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license FROM services WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) ListServicesByIDs(ctx context.Context, ids []int64) ([]Service, error) {
+	rows, err := q.db.Query(ctx, listServicesByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Service
+	for rows.Next() {
+		var i Service
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.PublicDescription,
+			&i.PrivateDescription,
+			&i.Author,
+			&i.Copyright,
+			&i.AvatarUrl,
+			&i.Public,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ServiceArchiveUrl,
+			&i.CheckerArchiveUrl,
+			&i.WriteupUrl,
+			&i.ExploitsUrl,
+			&i.CheckStatus,
+			&i.CheckedAt,
+			&i.ServiceLocalPath,
+			&i.ServiceLocalSize,
+			&i.ServiceLocalSha256,
+			&i.ServiceDownloadedAt,
+			&i.CheckerLocalPath,
+			&i.CheckerLocalSize,
+			&i.CheckerLocalSha256,
+			&i.CheckerDownloadedAt,
+			&i.Ctf01dTraining,
+			&i.Ports,
+			&i.TechStack,
+			&i.SourceKind,
+			&i.GitRepoUrl,
+			&i.GitRef,
+			&i.GitSubdir,
+			&i.GitLastCommit,
+			&i.GitSyncedAt,
+			&i.GitSyncStatus,
+			&i.GitSyncError,
+			&i.GitCredentialID,
+			&i.Readme,
+			&i.License,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchServiceCandidates = `-- name: SearchServiceCandidates :many
+SELECT s.id, s.tech_stack, s.license, s.check_status,
+    (SELECT count(*) FROM games_services gs WHERE gs.service_id = s.id)::integer AS games_count,
+    CASE WHEN $1::text = '' THEN 0::real
+        WHEN $2::boolean
+            THEN ts_rank_cd(d.public_document || d.private_document, to_tsquery('simple', $1::text))
+        ELSE ts_rank_cd(d.public_document, to_tsquery('simple', $1::text))
+    END::real AS rank
+FROM services s
+JOIN service_search_documents d ON d.service_id = s.id
+WHERE (s.public OR $2::boolean)
+  AND ($1::text = ''
+    OR d.public_document @@ to_tsquery('simple', $1::text)
+    OR ($2::boolean AND d.private_document @@ to_tsquery('simple', $1::text)))
+ORDER BY rank DESC, s.created_at DESC, s.id DESC
+`
+
+type SearchServiceCandidatesParams struct {
+	Query          string `json:"query"`
+	IncludePrivate bool   `json:"include_private"`
+}
+
+type SearchServiceCandidatesRow struct {
+	ID          int64    `json:"id"`
+	TechStack   []string `json:"tech_stack"`
+	License     *string  `json:"license"`
+	CheckStatus string   `json:"check_status"`
+	GamesCount  int32    `json:"games_count"`
+	Rank        float32  `json:"rank"`
+}
+
+// Every service matching the text query, with the fields facets are built
+// from. An empty query matches all services the caller may see.
+func (q *Queries) SearchServiceCandidates(ctx context.Context, arg SearchServiceCandidatesParams) ([]SearchServiceCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, searchServiceCandidates, arg.Query, arg.IncludePrivate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchServiceCandidatesRow
+	for rows.Next() {
+		var i SearchServiceCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TechStack,
+			&i.License,
+			&i.CheckStatus,
+			&i.GamesCount,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchServiceSnippets = `-- name: SearchServiceSnippets :many
+SELECT s.id,
+    ts_headline('simple',
+        concat_ws(E'\n', s.public_description,
+            CASE WHEN $1::boolean THEN s.private_description END,
+            s.readme),
+        to_tsquery('simple', $2::text),
+        'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxFragments=2, MaxWords=24, MinWords=8, FragmentDelimiter=" … "'
+    )::text AS snippet
+FROM services s
+WHERE s.id = ANY($3::bigint[])
+`
+
+type SearchServiceSnippetsParams struct {
+	IncludePrivate bool    `json:"include_private"`
+	Query          string  `json:"query"`
+	Ids            []int64 `json:"ids"`
+}
+
+type SearchServiceSnippetsRow struct {
+	ID      int64  `json:"id"`
+	Snippet string `json:"snippet"`
+}
+
+// Highlighted fragments of the descriptions and README. Matches are wrapped
+// in STX/ETX control characters which never occur in the text itself.
+func (q *Queries) SearchServiceSnippets(ctx context.Context, arg SearchServiceSnippetsParams) ([]SearchServiceSnippetsRow, error) {
+	rows, err := q.db.Query(ctx, searchServiceSnippets, arg.IncludePrivate, arg.Query, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchServiceSnippetsRow
+	for rows.Next() {
+		var i SearchServiceSnippetsRow
+		if err := rows.Scan(&i.ID, &i.Snippet); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const setArchiveURLs = `-- name: SetArchiveURLs :one
 UPDATE services SET
     service_archive_url = COALESCE($2, service_archive_url),
     checker_archive_url = COALESCE($3, checker_archive_url),
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type SetArchiveURLsParams struct {
@@ -451,6 +650,9 @@ func (q *Queries) SetArchiveURLs(ctx context.Context, arg SetArchiveURLsParams)
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -458,7 +660,7 @@ func (q *Queries) SetArchiveURLs(ctx context.Context, arg SetArchiveURLsParams)
 const setCheckStatus = `-- name: SetCheckStatus :one
 UPDATE services SET check_status = $2, checked_at = $3, updated_at = now()
 WHERE id = $1
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type SetCheckStatusParams struct {
@@ -506,6 +708,9 @@ func (q *Queries) SetCheckStatus(ctx context.Context, arg SetCheckStatusParams)
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -518,7 +723,7 @@ UPDATE services SET
     checker_downloaded_at = $5,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type SetCheckerLocalParams struct {
@@ -574,42 +779,48 @@ func (q *Queries) SetCheckerLocal(ctx context.Context, arg SetCheckerLocalParams
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
 
 const setGitSource = `-- name: SetGitSource :one
 UPDATE services SET
-    source_kind = $2,
-    git_repo_url = $3,
-    git_ref = $4,
-    git_subdir = $5,
+    source_kind = $1,
+    git_repo_url = $2,
+    git_ref = $3,
+    git_subdir = $4,
+    git_credential_id = $5,
     git_last_commit = NULL,
     git_synced_at = NULL,
     git_sync_status = $6,
     git_sync_error = NULL,
     updated_at = now()
-WHERE id = $1
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+WHERE id = $7
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type SetGitSourceParams struct {
-	ID            int64   `json:"id"`
-	SourceKind    string  `json:"source_kind"`
-	GitRepoUrl    *string `json:"git_repo_url"`
-	GitRef        *string `json:"git_ref"`
-	GitSubdir     *string `json:"git_subdir"`
-	GitSyncStatus string  `json:"git_sync_status"`
+	SourceKind      string  `json:"source_kind"`
+	GitRepoUrl      *string `json:"git_repo_url"`
+	GitRef          *string `json:"git_ref"`
+	GitSubdir       *string `json:"git_subdir"`
+	GitCredentialID *int64  `json:"git_credential_id"`
+	GitSyncStatus   string  `json:"git_sync_status"`
+	ID              int64   `json:"id"`
 }
 
 func (q *Queries) SetGitSource(ctx context.Context, arg SetGitSourceParams) (Service, error) {
 	row := q.db.QueryRow(ctx, setGitSource,
-		arg.ID,
 		arg.SourceKind,
 		arg.GitRepoUrl,
 		arg.GitRef,
 		arg.GitSubdir,
+		arg.GitCredentialID,
 		arg.GitSyncStatus,
+		arg.ID,
 	)
 	var i Service
 	err := row.Scan(
@@ -648,6 +859,9 @@ func (q *Queries) SetGitSource(ctx context.Context, arg SetGitSourceParams) (Ser
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -660,7 +874,7 @@ UPDATE services SET
     git_sync_error = $5,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type SetGitSyncStateParams struct {
@@ -716,6 +930,9 @@ func (q *Queries) SetGitSyncState(ctx context.Context, arg SetGitSyncStateParams
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -723,7 +940,7 @@ func (q *Queries) SetGitSyncState(ctx context.Context, arg SetGitSyncStateParams
 const setPublic = `-- name: SetPublic :one
 UPDATE services SET public = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type SetPublicParams struct {
@@ -770,6 +987,9 @@ func (q *Queries) SetPublic(ctx context.Context, arg SetPublicParams) (Service,
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -782,7 +1002,7 @@ UPDATE services SET
     service_downloaded_at = $5,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type SetServiceLocalParams struct {
@@ -838,6 +1058,9 @@ func (q *Queries) SetServiceLocal(ctx context.Context, arg SetServiceLocalParams
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -860,7 +1083,7 @@ UPDATE services SET
     tech_stack = COALESCE($14::text[], tech_stack),
     updated_at = now()
 WHERE id = $15
-RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error
+RETURNING id, name, public_description, private_description, author, copyright, avatar_url, public, created_at, updated_at, service_archive_url, checker_archive_url, writeup_url, exploits_url, check_status, checked_at, service_local_path, service_local_size, service_local_sha256, service_downloaded_at, checker_local_path, checker_local_size, checker_local_sha256, checker_downloaded_at, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref, git_subdir, git_last_commit, git_synced_at, git_sync_status, git_sync_error, git_credential_id, readme, license
 `
 
 type UpdateServiceParams struct {
@@ -936,6 +1159,9 @@ func (q *Queries) UpdateService(ctx context.Context, arg UpdateServiceParams) (S
 		&i.GitSyncedAt,
 		&i.GitSyncStatus,
 		&i.GitSyncError,
+		&i.GitCredentialID,
+		&i.Readme,
+		&i.License,
 	)
 	return i, err
 }
@@ -12,6 +12,21 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createRefreshToken = `-- name: CreateRefreshToken :exec
+INSERT INTO session_refresh_tokens (session_id, token_hash)
+VALUES ($1, $2)
+`
+
+type CreateRefreshTokenParams struct {
+	SessionID int64  `json:"session_id"`
+	TokenHash string `json:"token_hash"`
+}
+
+func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
+	_, err := q.db.Exec(ctx, createRefreshToken, arg.SessionID, arg.TokenHash)
+	return err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO user_sessions (user_id, jti, ip_address, user_agent, expires_at)
 VALUES ($1, $2, $3, $4, $5)
@@ -59,6 +74,26 @@ func (q *Queries) DeleteExpiredSessions(ctx context.Context) error {
 	return err
 }
 
+const getRefreshToken = `-- name: GetRefreshToken :one
+SELECT r.used_at, s.id AS session_id, s.user_id
+FROM session_refresh_tokens r
+JOIN user_sessions s ON s.id = r.session_id
+WHERE r.token_hash = $1
+`
+
+type GetRefreshTokenRow struct {
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	SessionID int64              `json:"session_id"`
+	UserID    int64              `json:"user_id"`
+}
+
+func (q *Queries) GetRefreshToken(ctx context.Context, tokenHash string) (GetRefreshTokenRow, error) {
+	row := q.db.QueryRow(ctx, getRefreshToken, tokenHash)
+	var i GetRefreshTokenRow
+	err := row.Scan(&i.UsedAt, &i.SessionID, &i.UserID)
+	return i, err
+}
+
 const getSessionByJTI = `-- name: GetSessionByJTI :one
 SELECT id, user_id, jti, ip_address, user_agent, created_at, last_seen_at, expires_at, revoked_at FROM user_sessions
 WHERE jti = $1
@@ -109,6 +144,41 @@ func (q *Queries) GetSessionForAuth(ctx context.Context, jti string) (GetSession
 	return i, err
 }
 
+const getSessionForRefresh = `-- name: GetSessionForRefresh :one
+SELECT s.id, s.jti, s.user_id, s.revoked_at, s.expires_at,
+       u.user_name, u.role, u.is_blocked
+FROM user_sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.id = $1
+`
+
+type GetSessionForRefreshRow struct {
+	ID        int64              `json:"id"`
+	Jti       string             `json:"jti"`
+	UserID    int64              `json:"user_id"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	UserName  string             `json:"user_name"`
+	Role      string             `json:"role"`
+	IsBlocked bool               `json:"is_blocked"`
+}
+
+func (q *Queries) GetSessionForRefresh(ctx context.Context, id int64) (GetSessionForRefreshRow, error) {
+	row := q.db.QueryRow(ctx, getSessionForRefresh, id)
+	var i GetSessionForRefreshRow
+	err := row.Scan(
+		&i.ID,
+		&i.Jti,
+		&i.UserID,
+		&i.RevokedAt,
+		&i.ExpiresAt,
+		&i.UserName,
+		&i.Role,
+		&i.IsBlocked,
+	)
+	return i, err
+}
+
 const listActiveSessionsByUser = `-- name: ListActiveSessionsByUser :many
 SELECT id, user_id, jti, ip_address, user_agent, created_at, last_seen_at, expires_at, revoked_at FROM user_sessions
 WHERE user_id = $1
@@ -201,3 +271,19 @@ func (q *Queries) TouchSession(ctx context.Context, arg TouchSessionParams) erro
 	_, err := q.db.Exec(ctx, touchSession, arg.Jti, arg.IpAddress)
 	return err
 }
+
+const useRefreshToken = `-- name: UseRefreshToken :one
+UPDATE session_refresh_tokens
+SET used_at = now()
+WHERE token_hash = $1 AND used_at IS NULL
+RETURNING session_id
+`
+
+// Marks the token used and returns its session; no row means the token is
+// unknown or was used before.
+func (q *Queries) UseRefreshToken(ctx context.Context, tokenHash string) (int64, error) {
+	row := q.db.QueryRow(ctx, useRefreshToken, tokenHash)
+	var session_id int64
+	err := row.Scan(&session_id)
+	return session_id, err
+}
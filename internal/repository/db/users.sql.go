@@ -39,7 +39,7 @@ func (q *Queries) CountUsers(ctx context.Context, searchQuery *string) (int64, e
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (user_name, display_name, role, rating, avatar_url, password_digest)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type CreateUserParams struct {
@@ -81,6 +81,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -96,7 +97,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme FROM users
+SELECT id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at FROM users
 WHERE id = $1
 `
 
@@ -123,12 +124,13 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const getUserByUserName = `-- name: GetUserByUserName :one
-SELECT id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme FROM users
+SELECT id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at FROM users
 WHERE user_name = $1
 `
 
@@ -155,12 +157,13 @@ func (q *Queries) GetUserByUserName(ctx context.Context, userName string) (User,
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme FROM users
+SELECT id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at FROM users
 WHERE (
   user_name ILIKE '%' || $3 || '%'
   OR display_name ILIKE '%' || $3 || '%'
@@ -205,6 +208,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.LastLoginAt,
 			&i.Language,
 			&i.Theme,
+			&i.EmailVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -221,7 +225,7 @@ UPDATE users
 SET avatar_url = $2,
     updated_at = now()
 WHERE id = $1
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type SetUserAvatarParams struct {
@@ -252,6 +256,7 @@ func (q *Queries) SetUserAvatar(ctx context.Context, arg SetUserAvatarParams) (U
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -262,7 +267,7 @@ SET is_blocked = $2,
     blocked_at = CASE WHEN $2 THEN now() ELSE NULL END,
     updated_at = now()
 WHERE id = $1
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type SetUserBlockedParams struct {
@@ -293,6 +298,7 @@ func (q *Queries) SetUserBlocked(ctx context.Context, arg SetUserBlockedParams)
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -302,7 +308,7 @@ UPDATE users
 SET last_login_ip = $2,
     last_login_at = now()
 WHERE id = $1
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type SetUserLastLoginParams struct {
@@ -333,6 +339,7 @@ func (q *Queries) SetUserLastLogin(ctx context.Context, arg SetUserLastLoginPara
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -342,7 +349,7 @@ UPDATE users
 SET password_digest = $2,
     updated_at = now()
 WHERE id = $1
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type UpdateUserPasswordParams struct {
@@ -373,6 +380,7 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -384,7 +392,7 @@ SET display_name = $2,
     password_digest = COALESCE($4, password_digest),
     updated_at = now()
 WHERE id = $1
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type UpdateUserProfileParams struct {
@@ -422,6 +430,7 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -435,11 +444,12 @@ SET display_name = $2,
     telegram = $6,
     github = $7,
     email = $8,
+    email_verified_at = CASE WHEN email IS NOT DISTINCT FROM $8 THEN email_verified_at END,
     language = $9,
     theme = $10,
     updated_at = now()
 WHERE id = $1
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type UpdateUserProfileAdminParams struct {
@@ -489,6 +499,7 @@ func (q *Queries) UpdateUserProfileAdmin(ctx context.Context, arg UpdateUserProf
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -498,7 +509,7 @@ UPDATE users
 SET rating = $2,
     updated_at = now()
 WHERE id = $1
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type UpdateUserRatingParams struct {
@@ -529,6 +540,7 @@ func (q *Queries) UpdateUserRating(ctx context.Context, arg UpdateUserRatingPara
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -538,7 +550,7 @@ UPDATE users
 SET role = $2,
     updated_at = now()
 WHERE id = $1
-RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme
+RETURNING id, user_name, display_name, role, rating, avatar_url, password_digest, created_at, updated_at, bio, telegram, github, email, is_blocked, blocked_at, last_login_ip, last_login_at, language, theme, email_verified_at
 `
 
 type UpdateUserRoleParams struct {
@@ -569,6 +581,7 @@ func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams)
 		&i.LastLoginAt,
 		&i.Language,
 		&i.Theme,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
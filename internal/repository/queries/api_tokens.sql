@@ -0,0 +1,32 @@
+-- name: CreateAPIToken :one
+INSERT INTO user_api_tokens (user_id, name, token_hash, token_prefix, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListAPITokensByUser :many
+SELECT * FROM user_api_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: CountAPITokensByUser :one
+SELECT count(*) FROM user_api_tokens
+WHERE user_id = $1;
+
+-- name: GetAPITokenForAuth :one
+-- Single read used on every request made with an API token: the token plus
+-- its owner's current role and blocked flag.
+SELECT t.id, t.user_id, t.scopes, t.expires_at, t.last_used_at,
+       u.user_name, u.role, u.is_blocked
+FROM user_api_tokens t
+JOIN users u ON u.id = t.user_id
+WHERE t.token_hash = $1;
+
+-- name: TouchAPIToken :exec
+UPDATE user_api_tokens
+SET last_used_at = now(),
+    last_used_ip = COALESCE($2, last_used_ip)
+WHERE id = $1;
+
+-- name: DeleteAPIToken :execrows
+DELETE FROM user_api_tokens
+WHERE id = $1 AND user_id = $2;
@@ -0,0 +1,33 @@
+-- name: ListUsersByLogin :many
+-- A reset may be requested by user name or by email; addresses are not unique,
+-- so one email can match several accounts.
+SELECT * FROM users
+WHERE user_name = sqlc.arg('login')
+   OR lower(email) = lower(sqlc.arg('login'))
+ORDER BY id;
+
+-- name: CreateEmailToken :exec
+INSERT INTO user_email_tokens (token_hash, user_id, purpose, email, expires_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ConsumeEmailToken :one
+-- A token is single-use: it is deleted whether or not the caller succeeds.
+DELETE FROM user_email_tokens
+WHERE token_hash = $1 AND purpose = $2 AND expires_at > now()
+RETURNING *;
+
+-- name: DeleteUserEmailTokens :exec
+-- Issuing a new link voids the earlier ones of the same purpose.
+DELETE FROM user_email_tokens
+WHERE user_id = $1 AND purpose = $2;
+
+-- name: DeleteExpiredEmailTokens :exec
+DELETE FROM user_email_tokens
+WHERE expires_at <= now();
+
+-- name: MarkUserEmailVerified :execrows
+-- Only the address the link was sent to is marked: if the user has changed
+-- it since, nothing happens.
+UPDATE users
+SET email_verified_at = COALESCE(email_verified_at, now())
+WHERE id = $1 AND email = $2;
@@ -0,0 +1,25 @@
+-- name: CreateGameExport :one
+-- Exporting the same archive again only refreshes its row, so it keeps its
+-- single blob reference.
+INSERT INTO game_exports (game_id, storage_key, sha256, size, filename, modified_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (game_id, sha256) DO UPDATE SET
+    filename = EXCLUDED.filename,
+    modified_at = EXCLUDED.modified_at,
+    created_at = now()
+RETURNING *;
+
+-- name: GetGameExport :one
+SELECT * FROM game_exports
+WHERE game_id = sqlc.arg('game_id') AND sha256 = sqlc.arg('sha256');
+
+-- name: PruneGameExports :exec
+-- Keeps the newest exports of a game and deletes the rest.
+DELETE FROM game_exports
+WHERE game_exports.game_id = sqlc.arg('game_id')
+  AND id NOT IN (
+      SELECT e.id FROM game_exports e
+      WHERE e.game_id = sqlc.arg('game_id')
+      ORDER BY e.created_at DESC, e.id DESC
+      LIMIT sqlc.arg('keep')
+  );
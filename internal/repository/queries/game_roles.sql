@@ -0,0 +1,29 @@
+-- name: ListGameRoles :many
+SELECT gr.game_id, gr.user_id, gr.role, u.user_name, u.display_name, u.avatar_url, gr.created_at
+FROM game_roles gr
+JOIN users u ON u.id = gr.user_id
+WHERE gr.game_id = $1
+ORDER BY gr.created_at, gr.user_id;
+
+-- name: GetGameRole :one
+SELECT role FROM game_roles WHERE game_id = $1 AND user_id = $2;
+
+-- name: GetGameRoleByGameTeam :one
+SELECT gr.role
+FROM game_teams gt
+JOIN game_roles gr ON gr.game_id = gt.game_id
+WHERE gt.id = sqlc.arg('game_team_id') AND gr.user_id = sqlc.arg('user_id');
+
+-- name: GetGameRoleByResult :one
+SELECT gr.role
+FROM results r
+JOIN game_roles gr ON gr.game_id = r.game_id
+WHERE r.id = sqlc.arg('result_id') AND gr.user_id = sqlc.arg('user_id');
+
+-- name: SetGameRole :exec
+INSERT INTO game_roles (game_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id, user_id) DO UPDATE SET role = EXCLUDED.role;
+
+-- name: DeleteGameRole :execrows
+DELETE FROM game_roles WHERE game_id = $1 AND user_id = $2;
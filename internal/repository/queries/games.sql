@@ -9,6 +9,9 @@ RETURNING *;
 -- name: GetGameByID :one
 SELECT * FROM games WHERE id = $1;
 
+-- name: LockGameForUpdate :one
+SELECT id FROM games WHERE id = $1 FOR UPDATE;
+
 -- name: ListGames :many
 SELECT * FROM games
 WHERE (name ILIKE '%' || sqlc.narg('search_query') || '%' OR sqlc.narg('search_query') IS NULL)
@@ -7,7 +7,13 @@ ON CONFLICT (game_id, service_id) DO NOTHING;
 DELETE FROM games_services WHERE game_id = $1 AND service_id = $2;
 
 -- name: ListServicesByGame :many
-SELECT service_id, status FROM games_services WHERE game_id = $1;
+SELECT gs.service_id, gs.status, gs.pinned_service_version_id, gs.pinned_checker_version_id,
+    sv.source_kind AS service_source_kind, sv.source_ref AS service_source_ref,
+    cv.source_kind AS checker_source_kind, cv.source_ref AS checker_source_ref
+FROM games_services gs
+LEFT JOIN service_archive_versions sv ON sv.id = gs.pinned_service_version_id
+LEFT JOIN service_archive_versions cv ON cv.id = gs.pinned_checker_version_id
+WHERE gs.game_id = $1;
 
 -- name: ListServiceIDsByGame :many
 SELECT service_id FROM games_services WHERE game_id = $1;
@@ -15,3 +21,51 @@ SELECT service_id FROM games_services WHERE game_id = $1;
 -- name: SetServiceStatus :exec
 UPDATE games_services SET status = $3
 WHERE game_id = $1 AND service_id = $2;
+
+-- name: SetGameServicePin :execrows
+UPDATE games_services SET
+    pinned_service_version_id = sqlc.narg('pinned_service_version_id'),
+    pinned_checker_version_id = sqlc.narg('pinned_checker_version_id')
+WHERE game_id = sqlc.arg('game_id') AND service_id = sqlc.arg('service_id');
+
+-- name: PinCurrentServiceVersions :exec
+-- Pins every unpinned service of the game to its active archives.
+UPDATE games_services gs SET
+    pinned_service_version_id = (
+        SELECT v.id FROM service_archive_versions v
+        WHERE v.service_id = s.id AND v.kind = 'service' AND v.storage_key = s.service_local_path
+    ),
+    pinned_checker_version_id = (
+        SELECT v.id FROM service_archive_versions v
+        WHERE v.service_id = s.id AND v.kind = 'checker' AND v.storage_key = s.checker_local_path
+    )
+FROM services s
+WHERE gs.game_id = $1
+  AND s.id = gs.service_id
+  AND gs.pinned_service_version_id IS NULL
+  AND gs.pinned_checker_version_id IS NULL;
+
+-- name: ListGameServicePins :many
+-- Storage keys the ctf01d export should use; NULL keys follow the service's
+-- current archive.
+SELECT gs.service_id, sv.storage_key AS service_storage_key, cv.storage_key AS checker_storage_key
+FROM games_services gs
+LEFT JOIN service_archive_versions sv ON sv.id = gs.pinned_service_version_id
+LEFT JOIN service_archive_versions cv ON cv.id = gs.pinned_checker_version_id
+WHERE gs.game_id = $1
+  AND (gs.pinned_service_version_id IS NOT NULL OR gs.pinned_checker_version_id IS NOT NULL);
+
+-- name: ListGamePinsByService :many
+SELECT gs.game_id, g.name AS game_name, g.finalized AS game_finalized, gs.status,
+    gs.pinned_service_version_id, gs.pinned_checker_version_id
+FROM games_services gs
+JOIN games g ON g.id = gs.game_id
+WHERE gs.service_id = $1
+ORDER BY g.starts_at DESC NULLS LAST, gs.game_id DESC;
+
+-- name: ListGameServicePorts :many
+SELECT s.id, s.name, s.ports
+FROM games_services gs
+JOIN services s ON s.id = gs.service_id
+WHERE gs.game_id = $1
+ORDER BY s.id;
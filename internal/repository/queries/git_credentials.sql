@@ -0,0 +1,40 @@
+-- name: CreateGitCredential :one
+INSERT INTO git_credentials (name, kind, host_pattern, username, secret_ciphertext)
+VALUES (
+    sqlc.arg('name'),
+    sqlc.arg('kind'),
+    sqlc.narg('host_pattern'),
+    sqlc.narg('username'),
+    sqlc.arg('secret_ciphertext')
+)
+RETURNING *;
+
+-- name: GetGitCredentialByID :one
+SELECT * FROM git_credentials WHERE id = $1;
+
+-- name: LockGitCredential :one
+-- Locks a credential for a change, so its audit event starts from the state
+-- the change replaced.
+SELECT * FROM git_credentials WHERE id = $1 FOR UPDATE;
+
+-- name: ListGitCredentials :many
+SELECT * FROM git_credentials ORDER BY name ASC, id ASC;
+
+-- name: ListGitCredentialsWithHostPattern :many
+SELECT * FROM git_credentials
+WHERE host_pattern IS NOT NULL
+ORDER BY length(host_pattern) DESC, id ASC;
+
+-- name: UpdateGitCredential :one
+-- An empty host_pattern or username clears the stored value.
+UPDATE git_credentials SET
+    name = COALESCE(sqlc.narg('name'), name),
+    host_pattern = NULLIF(COALESCE(sqlc.narg('host_pattern'), host_pattern), ''),
+    username = NULLIF(COALESCE(sqlc.narg('username'), username), ''),
+    secret_ciphertext = COALESCE(sqlc.narg('secret_ciphertext'), secret_ciphertext),
+    updated_at = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteGitCredential :exec
+DELETE FROM git_credentials WHERE id = $1;
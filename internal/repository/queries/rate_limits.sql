@@ -0,0 +1,58 @@
+-- name: EnsureRateLimitBucket :exec
+-- A new bucket starts full.
+INSERT INTO rate_limit_buckets (key, tokens, updated_at, expires_at)
+VALUES (sqlc.arg('key'), sqlc.arg('burst')::float8, now(), now())
+ON CONFLICT (key) DO NOTHING;
+
+-- name: TakeRateLimitToken :one
+-- Refills the bucket for the time since its last update and takes a token if
+-- a whole one is there. Returns the tokens available before taking: the
+-- request is allowed when that is at least 1. The row lock serializes
+-- concurrent takes on one key.
+UPDATE rate_limit_buckets AS b
+SET tokens = CASE WHEN cur.available >= 1 THEN cur.available - 1 ELSE cur.available END,
+    updated_at = now(),
+    expires_at = now() + make_interval(secs => sqlc.arg('period_seconds')::float8)
+FROM (
+    SELECT r.key,
+           LEAST(
+               sqlc.arg('burst')::float8,
+               r.tokens + GREATEST(EXTRACT(EPOCH FROM now() - r.updated_at)::float8, 0) * sqlc.arg('per_second')::float8
+           ) AS available
+    FROM rate_limit_buckets AS r
+    WHERE r.key = sqlc.arg('key')
+    FOR UPDATE
+) AS cur
+WHERE b.key = cur.key
+RETURNING cur.available::float8 AS available;
+
+-- name: AddRateLimitFailure :one
+-- Counts a failure and returns the counter as it was before it. A counter
+-- whose window has passed starts over.
+INSERT INTO rate_limit_failures (key, failures, last_failure_at, expires_at)
+VALUES (sqlc.arg('key'), 1, now(), now() + make_interval(secs => sqlc.arg('window_seconds')::float8))
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE WHEN rate_limit_failures.expires_at > now() THEN rate_limit_failures.failures + 1 ELSE 1 END,
+    previous_failure_at = CASE WHEN rate_limit_failures.expires_at > now() THEN rate_limit_failures.last_failure_at END,
+    last_failure_at = now(),
+    expires_at = EXCLUDED.expires_at
+RETURNING (failures - 1)::integer AS failures,
+          COALESCE(EXTRACT(EPOCH FROM now() - previous_failure_at), 0)::float8 AS seconds_since_last;
+
+-- name: RemoveRateLimitFailure :exec
+-- Takes back one counted failure.
+UPDATE rate_limit_failures
+SET failures = failures - 1
+WHERE key = $1 AND failures > 0;
+
+-- name: DeleteRateLimitFailures :exec
+DELETE FROM rate_limit_failures
+WHERE key = $1;
+
+-- name: DeleteExpiredRateLimitBuckets :exec
+DELETE FROM rate_limit_buckets
+WHERE expires_at <= now();
+
+-- name: DeleteExpiredRateLimitFailures :exec
+DELETE FROM rate_limit_failures
+WHERE expires_at <= now();
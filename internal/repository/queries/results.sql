@@ -6,6 +6,14 @@ RETURNING *;
 -- name: GetResultByID :one
 SELECT * FROM results WHERE id = $1;
 
+-- name: LockResult :one
+-- Locks a result for a change, so its audit event starts from the state the
+-- change replaced.
+SELECT * FROM results WHERE id = $1 FOR UPDATE;
+
+-- name: LockResultByGameAndTeam :one
+SELECT * FROM results WHERE game_id = $1 AND team_id = $2 FOR UPDATE;
+
 -- name: ListResultsByGame :many
 SELECT * FROM results WHERE game_id = $1 ORDER BY score DESC, team_id ASC;
 
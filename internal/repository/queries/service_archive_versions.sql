@@ -0,0 +1,58 @@
+-- name: CreateServiceArchiveVersion :one
+INSERT INTO service_archive_versions (service_id, kind, storage_key, sha256, size, source_kind, source_ref, readme, readme_path, created_at)
+VALUES (
+    sqlc.arg('service_id'),
+    sqlc.arg('kind'),
+    sqlc.arg('storage_key'),
+    sqlc.arg('sha256'),
+    sqlc.arg('size'),
+    sqlc.arg('source_kind'),
+    sqlc.narg('source_ref'),
+    sqlc.narg('readme'),
+    sqlc.narg('readme_path'),
+    sqlc.arg('created_at')
+)
+RETURNING *;
+
+-- name: GetServiceArchiveVersion :one
+SELECT * FROM service_archive_versions
+WHERE id = sqlc.arg('id') AND service_id = sqlc.arg('service_id');
+
+-- name: GetServiceArchiveVersionByKey :one
+-- Deduplicated versions share a key; the newest one is the active version.
+SELECT * FROM service_archive_versions
+WHERE service_id = sqlc.arg('service_id') AND storage_key = sqlc.arg('storage_key')
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListServiceArchiveVersions :many
+SELECT v.id, v.service_id, v.kind, v.storage_key, v.sha256, v.size, v.source_kind, v.source_ref, v.created_at,
+    EXISTS (
+        SELECT 1 FROM games_services gs
+        WHERE gs.pinned_service_version_id = v.id OR gs.pinned_checker_version_id = v.id
+    ) AS pinned
+FROM service_archive_versions v
+WHERE v.service_id = sqlc.arg('service_id')
+  AND (v.kind = sqlc.narg('kind') OR sqlc.narg('kind') IS NULL)
+ORDER BY v.created_at DESC, v.id DESC;
+
+-- name: DeleteServiceArchiveVersion :exec
+DELETE FROM service_archive_versions WHERE id = $1;
+
+-- name: GetLatestServiceArchiveVersionByCommit :one
+-- commit may be an abbreviated hash.
+SELECT * FROM service_archive_versions
+WHERE service_id = sqlc.arg('service_id')
+  AND source_kind = 'git'
+  AND source_ref LIKE sqlc.arg('commit')::text || '%'
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: GetServiceArchiveVersionAsOf :one
+-- The version of the given kind that was active at created_at.
+SELECT * FROM service_archive_versions
+WHERE service_id = sqlc.arg('service_id')
+  AND kind = sqlc.arg('kind')
+  AND created_at <= sqlc.arg('created_at')
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
@@ -0,0 +1,19 @@
+-- name: ListServiceAuthors :many
+SELECT sa.service_id, sa.user_id, u.user_name, u.display_name, u.avatar_url, sa.created_at
+FROM service_authors sa
+JOIN users u ON u.id = sa.user_id
+WHERE sa.service_id = $1
+ORDER BY sa.created_at, sa.user_id;
+
+-- name: AddServiceAuthor :exec
+INSERT INTO service_authors (service_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveServiceAuthor :execrows
+DELETE FROM service_authors WHERE service_id = $1 AND user_id = $2;
+
+-- name: IsServiceAuthor :one
+SELECT EXISTS (
+    SELECT 1 FROM service_authors WHERE service_id = $1 AND user_id = $2
+) AS is_author;
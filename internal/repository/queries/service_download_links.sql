@@ -0,0 +1,21 @@
+-- name: CreateServiceDownloadLink :one
+INSERT INTO service_download_links (service_id, kind, storage_key, expires_at, max_downloads, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetServiceDownloadLink :one
+SELECT * FROM service_download_links WHERE id = $1;
+
+-- name: UseServiceDownloadLink :one
+-- Counts one download. No row is returned when the link does not match the
+-- service and kind, has expired or has used up its limit.
+UPDATE service_download_links
+SET download_count = download_count + 1,
+    last_used_at = now(),
+    last_used_ip = sqlc.arg('ip')::text
+WHERE id = sqlc.arg('id')
+  AND service_id = sqlc.arg('service_id')
+  AND kind = sqlc.arg('kind')
+  AND expires_at > now()
+  AND (max_downloads IS NULL OR download_count < max_downloads)
+RETURNING *;
@@ -0,0 +1,76 @@
+-- name: CreateServiceSubmission :one
+INSERT INTO service_submissions (
+    submitter_id, source_kind, repo_url, git_ref, git_subdir, git_commit, archive_key, service_name, preview
+) VALUES (
+    sqlc.arg('submitter_id'), sqlc.arg('source_kind'), sqlc.narg('repo_url'), sqlc.narg('git_ref'),
+    sqlc.narg('git_subdir'), sqlc.narg('git_commit'), sqlc.narg('archive_key'), sqlc.arg('service_name'),
+    sqlc.arg('preview')
+)
+RETURNING *;
+
+-- name: GetServiceSubmission :one
+SELECT * FROM service_submissions WHERE id = $1;
+
+-- name: LockServiceSubmission :one
+-- Locks a submission for a review decision, so concurrent reviews of the same
+-- submission run one after the other.
+SELECT * FROM service_submissions WHERE id = $1 FOR UPDATE;
+
+-- name: ListServiceSubmissions :many
+-- Lists submissions newest first. submitter_id narrows to one player's
+-- submissions, status to one state of the review queue.
+SELECT * FROM service_submissions
+WHERE (sqlc.narg('submitter_id')::bigint IS NULL OR submitter_id = sqlc.narg('submitter_id'))
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountServiceSubmissions :one
+SELECT count(*) FROM service_submissions
+WHERE (sqlc.narg('submitter_id')::bigint IS NULL OR submitter_id = sqlc.narg('submitter_id'))
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'));
+
+-- name: ResubmitServiceSubmission :one
+-- Replaces the source of a submission sent back for changes and puts it back
+-- into the review queue.
+UPDATE service_submissions
+SET status = 'submitted',
+    source_kind = sqlc.arg('source_kind'),
+    repo_url = sqlc.narg('repo_url'),
+    git_ref = sqlc.narg('git_ref'),
+    git_subdir = sqlc.narg('git_subdir'),
+    git_commit = sqlc.narg('git_commit'),
+    archive_key = sqlc.narg('archive_key'),
+    service_name = sqlc.arg('service_name'),
+    preview = sqlc.arg('preview'),
+    updated_at = now()
+WHERE id = sqlc.arg('id') AND status = 'changes_requested'
+RETURNING *;
+
+-- name: ReviewServiceSubmission :one
+-- Records a review decision. Only submissions waiting in the queue can be
+-- reviewed, so a decision never overwrites a concurrent one.
+UPDATE service_submissions
+SET status = sqlc.arg('status'),
+    reviewer_id = sqlc.arg('reviewer_id'),
+    reviewed_at = now(),
+    service_id = sqlc.narg('service_id'),
+    updated_at = now()
+WHERE id = sqlc.arg('id') AND status = 'submitted'
+RETURNING *;
+
+-- name: ListServiceSubmissionComments :many
+SELECT c.id, c.submission_id, c.author_id, c.parent_id, c.body, c.created_at,
+    u.user_name AS author_user_name
+FROM service_submission_comments c
+LEFT JOIN users u ON u.id = c.author_id
+WHERE c.submission_id = $1
+ORDER BY c.id;
+
+-- name: CreateServiceSubmissionComment :one
+INSERT INTO service_submission_comments (submission_id, author_id, parent_id, body)
+VALUES (sqlc.arg('submission_id'), sqlc.arg('author_id'), sqlc.narg('parent_id'), sqlc.arg('body'))
+RETURNING *;
+
+-- name: GetServiceSubmissionComment :one
+SELECT * FROM service_submission_comments WHERE id = $1;
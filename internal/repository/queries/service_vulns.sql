@@ -0,0 +1,26 @@
+-- name: ListServiceVulns :many
+SELECT * FROM service_vulns
+WHERE service_id = $1
+ORDER BY position, id;
+
+-- name: ReplaceServiceVulns :exec
+-- Replaces the whole list in one statement; the arrays are parallel and their
+-- order becomes the position. Empty strings are stored as NULL.
+WITH removed AS (
+    DELETE FROM service_vulns WHERE service_id = sqlc.arg('service_id')
+)
+INSERT INTO service_vulns (service_id, position, title, cwe, difficulty, exploit_path, fix)
+SELECT sqlc.arg('service_id'), i,
+    (sqlc.arg('titles')::text[])[i],
+    NULLIF((sqlc.arg('cwes')::text[])[i], ''),
+    NULLIF((sqlc.arg('difficulties')::text[])[i], ''),
+    NULLIF((sqlc.arg('exploit_paths')::text[])[i], ''),
+    NULLIF((sqlc.arg('fixes')::text[])[i], '')
+FROM generate_subscripts(sqlc.arg('titles')::text[], 1) AS i;
+
+-- name: IsServiceInFinalizedGame :one
+SELECT EXISTS (
+    SELECT 1 FROM games_services gs
+    JOIN games g ON g.id = gs.game_id
+    WHERE gs.service_id = $1 AND g.finalized
+) AS finalized;
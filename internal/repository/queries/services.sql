@@ -2,7 +2,7 @@
 INSERT INTO services (name, public_description, private_description, author, copyright,
     avatar_url, public, service_archive_url, checker_archive_url, writeup_url, exploits_url,
     check_status, ctf01d_training, ports, tech_stack, source_kind, git_repo_url, git_ref,
-    git_subdir, git_sync_status)
+    git_subdir, git_sync_status, git_credential_id, readme, license)
 VALUES (
     sqlc.arg('name'),
     sqlc.narg('public_description'),
@@ -23,7 +23,10 @@ VALUES (
     sqlc.narg('git_repo_url'),
     sqlc.narg('git_ref'),
     sqlc.narg('git_subdir'),
-    sqlc.arg('git_sync_status')
+    sqlc.arg('git_sync_status'),
+    sqlc.narg('git_credential_id'),
+    sqlc.narg('readme'),
+    sqlc.narg('license')
 )
 RETURNING *;
 
@@ -45,6 +48,41 @@ SELECT count(*) FROM services
 WHERE (public = sqlc.narg('public_filter') OR sqlc.narg('public_filter') IS NULL)
   AND (name ILIKE '%' || sqlc.narg('search_query') || '%' OR sqlc.narg('search_query') IS NULL);
 
+-- name: ListServicesByIDs :many
+SELECT * FROM services WHERE id = ANY(sqlc.arg('ids')::bigint[]);
+
+-- name: SearchServiceCandidates :many
+-- Every service matching the text query, with the fields facets are built
+-- from. An empty query matches all services the caller may see.
+SELECT s.id, s.tech_stack, s.license, s.check_status,
+    (SELECT count(*) FROM games_services gs WHERE gs.service_id = s.id)::integer AS games_count,
+    CASE WHEN sqlc.arg('query')::text = '' THEN 0::real
+        WHEN sqlc.arg('include_private')::boolean
+            THEN ts_rank_cd(d.public_document || d.private_document, to_tsquery('simple', sqlc.arg('query')::text))
+        ELSE ts_rank_cd(d.public_document, to_tsquery('simple', sqlc.arg('query')::text))
+    END::real AS rank
+FROM services s
+JOIN service_search_documents d ON d.service_id = s.id
+WHERE (s.public OR sqlc.arg('include_private')::boolean)
+  AND (sqlc.arg('query')::text = ''
+    OR d.public_document @@ to_tsquery('simple', sqlc.arg('query')::text)
+    OR (sqlc.arg('include_private')::boolean AND d.private_document @@ to_tsquery('simple', sqlc.arg('query')::text)))
+ORDER BY rank DESC, s.created_at DESC, s.id DESC;
+
+-- name: SearchServiceSnippets :many
+-- Highlighted fragments of the descriptions and README. Matches are wrapped
+-- in STX/ETX control characters which never occur in the text itself.
+SELECT s.id,
+    ts_headline('simple',
+        concat_ws(E'\n', s.public_description,
+            CASE WHEN sqlc.arg('include_private')::boolean THEN s.private_description END,
+            s.readme),
+        to_tsquery('simple', sqlc.arg('query')::text),
+        'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxFragments=2, MaxWords=24, MinWords=8, FragmentDelimiter=" … "'
+    )::text AS snippet
+FROM services s
+WHERE s.id = ANY(sqlc.arg('ids')::bigint[]);
+
 -- name: UpdateService :one
 UPDATE services SET
     name = COALESCE(sqlc.arg('name'), name),
@@ -67,16 +105,17 @@ RETURNING *;
 
 -- name: SetGitSource :one
 UPDATE services SET
-    source_kind = $2,
-    git_repo_url = $3,
-    git_ref = $4,
-    git_subdir = $5,
+    source_kind = sqlc.arg('source_kind'),
+    git_repo_url = sqlc.narg('git_repo_url'),
+    git_ref = sqlc.narg('git_ref'),
+    git_subdir = sqlc.narg('git_subdir'),
+    git_credential_id = sqlc.narg('git_credential_id'),
     git_last_commit = NULL,
     git_synced_at = NULL,
-    git_sync_status = $6,
+    git_sync_status = sqlc.arg('git_sync_status'),
     git_sync_error = NULL,
     updated_at = now()
-WHERE id = $1
+WHERE id = sqlc.arg('id')
 RETURNING *;
 
 -- name: ApplyServiceImportMetadata :one
@@ -86,6 +125,8 @@ UPDATE services SET
     author = $4,
     copyright = $5,
     ctf01d_training = $6,
+    readme = $7,
+    license = $8,
     updated_at = now()
 WHERE id = $1
 RETURNING *;
@@ -46,3 +46,28 @@ WHERE user_id = $1 AND revoked_at IS NULL;
 -- name: DeleteExpiredSessions :exec
 DELETE FROM user_sessions
 WHERE expires_at < now();
+
+-- name: CreateRefreshToken :exec
+INSERT INTO session_refresh_tokens (session_id, token_hash)
+VALUES ($1, $2);
+
+-- name: UseRefreshToken :one
+-- Marks the token used and returns its session; no row means the token is
+-- unknown or was used before.
+UPDATE session_refresh_tokens
+SET used_at = now()
+WHERE token_hash = $1 AND used_at IS NULL
+RETURNING session_id;
+
+-- name: GetRefreshToken :one
+SELECT r.used_at, s.id AS session_id, s.user_id
+FROM session_refresh_tokens r
+JOIN user_sessions s ON s.id = r.session_id
+WHERE r.token_hash = $1;
+
+-- name: GetSessionForRefresh :one
+SELECT s.id, s.jti, s.user_id, s.revoked_at, s.expires_at,
+       u.user_name, u.role, u.is_blocked
+FROM user_sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.id = $1;
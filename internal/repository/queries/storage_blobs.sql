@@ -0,0 +1,57 @@
+-- name: GetStorageBlob :one
+SELECT * FROM storage_blobs WHERE sha256 = $1;
+
+-- name: UpsertStorageBlob :one
+-- Records a stored blob. Saving an existing unreferenced blob restarts its GC
+-- grace period, so it survives until the caller references it; saving it again
+-- after a corruption marks it healthy.
+INSERT INTO storage_blobs (sha256, storage_key, size)
+VALUES (sqlc.arg('sha256'), sqlc.arg('storage_key'), sqlc.arg('size'))
+ON CONFLICT (sha256) DO UPDATE SET
+    unreferenced_at = CASE WHEN storage_blobs.ref_count = 0 THEN now() ELSE storage_blobs.unreferenced_at END,
+    corrupt_at = NULL,
+    verified_at = CASE WHEN storage_blobs.corrupt_at IS NULL THEN storage_blobs.verified_at ELSE now() END
+RETURNING *;
+
+-- name: ListUnreferencedStorageBlobs :many
+SELECT * FROM storage_blobs
+WHERE ref_count = 0 AND unreferenced_at < sqlc.arg('before')
+ORDER BY unreferenced_at
+LIMIT sqlc.arg('limit');
+
+-- name: DeleteUnreferencedStorageBlob :execrows
+-- The ref_count guard keeps a blob that was referenced again since it was listed.
+DELETE FROM storage_blobs
+WHERE sha256 = sqlc.arg('sha256') AND ref_count = 0 AND unreferenced_at < sqlc.arg('before');
+
+-- name: ListStorageBlobsToScrub :many
+-- Never verified blobs first, then the ones verified longest ago.
+SELECT * FROM storage_blobs
+ORDER BY verified_at NULLS FIRST, created_at
+LIMIT sqlc.arg('limit');
+
+-- name: MarkStorageBlobVerified :exec
+UPDATE storage_blobs SET verified_at = now(), corrupt_at = NULL WHERE sha256 = $1;
+
+-- name: MarkStorageBlobCorrupt :exec
+UPDATE storage_blobs
+SET verified_at = now(), corrupt_at = COALESCE(corrupt_at, now())
+WHERE sha256 = $1;
+
+-- name: ListCorruptStorageBlobs :many
+SELECT * FROM storage_blobs WHERE corrupt_at IS NOT NULL ORDER BY corrupt_at;
+
+-- name: ListLegacyArchiveStorageKeys :many
+-- Keys of archive versions stored before content addressing.
+SELECT DISTINCT storage_key FROM service_archive_versions
+WHERE storage_key NOT LIKE 'blobs/%'
+ORDER BY storage_key;
+
+-- name: ReplaceArchiveVersionStorageKey :exec
+UPDATE service_archive_versions SET storage_key = sqlc.arg('new_key') WHERE storage_key = sqlc.arg('old_key');
+
+-- name: ReplaceServiceLocalStorageKey :exec
+UPDATE services SET
+    service_local_path = CASE WHEN service_local_path = sqlc.arg('old_key')::text THEN sqlc.arg('new_key')::text ELSE service_local_path END,
+    checker_local_path = CASE WHEN checker_local_path = sqlc.arg('old_key')::text THEN sqlc.arg('new_key')::text ELSE checker_local_path END
+WHERE service_local_path = sqlc.arg('old_key')::text OR checker_local_path = sqlc.arg('old_key')::text;
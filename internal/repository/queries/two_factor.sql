@@ -0,0 +1,82 @@
+-- name: GetUserTwoFactor :one
+SELECT * FROM user_two_factor
+WHERE user_id = $1;
+
+-- name: StartUserTwoFactorEnrollment :one
+-- Stores a new unconfirmed secret. No row is returned when 2FA is already
+-- enabled, so a running enrollment never replaces an active secret.
+INSERT INTO user_two_factor (user_id, secret_ciphertext)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret_ciphertext = EXCLUDED.secret_ciphertext,
+    confirmed_at = NULL,
+    last_used_step = NULL
+WHERE user_two_factor.confirmed_at IS NULL
+RETURNING *;
+
+-- name: UseUserTwoFactorStep :execrows
+-- Accepts a verified code once: its step must be newer than the last one used.
+-- The first accepted code confirms the enrollment.
+UPDATE user_two_factor
+SET last_used_step = sqlc.arg('step')::bigint,
+    confirmed_at = COALESCE(confirmed_at, now())
+WHERE user_id = sqlc.arg('user_id')
+  AND (last_used_step IS NULL OR last_used_step < sqlc.arg('step')::bigint);
+
+-- name: DeleteUserTwoFactor :exec
+DELETE FROM user_two_factor
+WHERE user_id = $1;
+
+-- name: CreateUserRecoveryCode :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+VALUES ($1, $2);
+
+-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = now()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedUserRecoveryCodes :one
+SELECT count(*) FROM user_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1;
+
+-- name: CreateLoginChallenge :exec
+INSERT INTO login_challenges (token_hash, user_id, enrollment, expires_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetLoginChallenge :one
+SELECT * FROM login_challenges
+WHERE token_hash = $1 AND expires_at > now();
+
+-- name: RecordLoginChallengeFailure :one
+UPDATE login_challenges
+SET attempts = attempts + 1
+WHERE token_hash = $1
+RETURNING attempts;
+
+-- name: DeleteLoginChallenge :exec
+DELETE FROM login_challenges
+WHERE token_hash = $1;
+
+-- name: DeleteExpiredLoginChallenges :exec
+DELETE FROM login_challenges
+WHERE expires_at <= now();
+
+-- name: ListTwoFactorRequiredRoles :many
+SELECT role FROM two_factor_required_roles
+ORDER BY role;
+
+-- name: IsTwoFactorRequiredForRole :one
+SELECT EXISTS (SELECT 1 FROM two_factor_required_roles WHERE role = $1);
+
+-- name: DeleteTwoFactorRequiredRoles :exec
+DELETE FROM two_factor_required_roles;
+
+-- name: AddTwoFactorRequiredRole :exec
+INSERT INTO two_factor_required_roles (role, created_by)
+VALUES ($1, $2)
+ON CONFLICT (role) DO NOTHING;
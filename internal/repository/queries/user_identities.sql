@@ -0,0 +1,44 @@
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities
+WHERE provider = $1 AND subject = $2;
+
+-- name: ListUserIdentities :many
+SELECT * FROM user_identities
+WHERE user_id = $1
+ORDER BY provider;
+
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities (user_id, provider, subject, email, last_login_at)
+VALUES ($1, $2, $3, $4, now())
+RETURNING *;
+
+-- name: TouchUserIdentity :exec
+UPDATE user_identities
+SET last_login_at = now(),
+    email = COALESCE(sqlc.narg('email'), email)
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteUserIdentity :execrows
+DELETE FROM user_identities
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateExternalUser :one
+-- Auto-provisioned account of an external identity: guest role, no password.
+-- The provider only passes on an address it has verified.
+INSERT INTO users (user_name, display_name, email, role, email_verified_at)
+VALUES ($1, $2, $3, 'guest', CASE WHEN $3::text IS NULL THEN NULL ELSE now() END)
+RETURNING *;
+
+-- name: CreateOIDCLoginState :exec
+INSERT INTO oidc_login_states (state, provider, code_verifier, nonce, link_user_id, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ConsumeOIDCLoginState :one
+-- A state is single-use: it is deleted whether or not the callback succeeds.
+DELETE FROM oidc_login_states
+WHERE state = $1 AND expires_at > now()
+RETURNING *;
+
+-- name: DeleteExpiredOIDCLoginStates :exec
+DELETE FROM oidc_login_states
+WHERE expires_at <= now();
@@ -47,6 +47,7 @@ SET display_name = $2,
     telegram = $6,
     github = $7,
     email = $8,
+    email_verified_at = CASE WHEN email IS NOT DISTINCT FROM $8 THEN email_verified_at END,
     language = $9,
     theme = $10,
     updated_at = now()
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	authsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/auth"
+)
+
+// apiTokenAuthenticator adapts the API token service to the middleware.
+type apiTokenAuthenticator struct {
+	tokens *authsvc.APITokenService
+}
+
+func (a apiTokenAuthenticator) AuthenticateAPIToken(ctx context.Context, token, ipAddress string) (middleware.APIToken, bool) {
+	identity, ok := a.tokens.Authenticate(ctx, token, ipAddress)
+	if !ok {
+		return middleware.APIToken{}, false
+	}
+	return middleware.APIToken{
+		UserID:   identity.UserID,
+		UserName: identity.UserName,
+		Role:     identity.Role,
+		Scopes:   identity.Scopes,
+	}, true
+}
+
+func (h *Handler) ListProfileAPITokens(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	tokens, err := h.apiTokens.List(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]httpserver.APIToken, len(tokens))
+	for i, t := range tokens {
+		items[i] = apiTokenToHTTP(t)
+	}
+	c.JSON(http.StatusOK, httpserver.APITokenList{Items: items})
+}
+
+func (h *Handler) CreateProfileAPIToken(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	req, ok := bindJSON[httpserver.APITokenCreate](c)
+	if !ok {
+		return
+	}
+
+	scopes := make([]string, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = string(scope)
+	}
+	token, created, err := h.apiTokens.Create(c.Request.Context(), userID, req.Name, scopes, req.ExpiresAt)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, httpserver.APITokenCreated{Token: token, ApiToken: apiTokenToHTTP(*created)})
+}
+
+func (h *Handler) RevokeProfileAPIToken(c *gin.Context, id int64) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	if err := h.apiTokens.Revoke(c.Request.Context(), userID, id); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func apiTokenToHTTP(t authsvc.APIToken) httpserver.APIToken {
+	scopes := make([]httpserver.APITokenScope, len(t.Scopes))
+	for i, scope := range t.Scopes {
+		scopes[i] = httpserver.APITokenScope(scope)
+	}
+	return httpserver.APIToken{
+		Id:         t.ID,
+		Name:       t.Name,
+		Hint:       t.Hint,
+		Scopes:     scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		LastUsedIp: t.LastUsedIP,
+		CreatedAt:  t.CreatedAt,
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	svcsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/services"
+)
+
+func (h *Handler) HandleListServiceArchiveVersions(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	access := serviceAccess(c)
+	role, hasRole := middleware.CurrentRole(c)
+	includeSource := access.CanManage() || (hasRole && role == rolePlayer)
+	versions, err := h.svcArchives.ListVersions(c.Request.Context(), id, c.Query("kind"), access)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]httpserver.ServiceArchiveVersion, len(versions))
+	for i, v := range versions {
+		items[i] = archiveVersionToHTTP(v, includeSource)
+	}
+	c.JSON(http.StatusOK, httpserver.ServiceArchiveVersionList{Items: items})
+}
+
+func (h *Handler) HandleDiffServiceArchiveVersions(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	from, errFrom := strconv.ParseInt(c.Query("from"), 10, 64)
+	to, errTo := strconv.ParseInt(c.Query("to"), 10, 64)
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{Code: codeBadRequest, Message: "from and to must be version ids"})
+		return
+	}
+
+	access := serviceAccess(c)
+	role, hasRole := middleware.CurrentRole(c)
+	includeSource := access.CanManage() || (hasRole && role == rolePlayer)
+	diff, err := h.svcArchives.DiffVersions(c.Request.Context(), id, from, to, access)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, httpserver.ServiceArchiveVersionDiff{
+		From:      archiveVersionToHTTP(diff.From, includeSource),
+		To:        archiveVersionToHTTP(diff.To, includeSource),
+		Added:     diff.Added,
+		Removed:   diff.Removed,
+		Modified:  diff.Modified,
+		Unchanged: diff.Unchanged,
+	})
+}
+
+func (h *Handler) HandleRollbackServiceArchiveVersion(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	versionID, ok := parseIDParam(c, "version_id")
+	if !ok {
+		return
+	}
+
+	svc, err := h.svcArchives.Rollback(c.Request.Context(), id, versionID, serviceAccess(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, serviceToHTTP(*svc, true))
+}
+
+// archiveVersionToHTTP hides the source reference (download URL or git commit)
+// from callers that may not see the service source either.
+func archiveVersionToHTTP(v svcsvc.ArchiveVersion, includeSource bool) httpserver.ServiceArchiveVersion {
+	result := httpserver.ServiceArchiveVersion{
+		Id:         v.ID,
+		ServiceId:  v.ServiceID,
+		Kind:       httpserver.ServiceArchiveVersionKind(v.Kind),
+		Sha256:     v.SHA256,
+		Size:       v.Size,
+		SourceKind: httpserver.ServiceArchiveVersionSourceKind(v.SourceKind),
+		CreatedAt:  v.CreatedAt,
+		Current:    v.Current,
+		Pinned:     v.Pinned,
+	}
+	if includeSource {
+		result.SourceRef = v.SourceRef
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+)
+
+func (h *Handler) HandleListAuditEvents(c *gin.Context) {
+	page := 1
+	perPage := 20
+	if v := c.Query("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if v := c.Query("per_page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			perPage = p
+		}
+	}
+
+	filter, ok := auditFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.auditLog.List(c.Request.Context(), filter, page, perPage)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]httpserver.AuditEvent, len(result.Items))
+	for i, e := range result.Items {
+		items[i] = auditEventToHTTP(e)
+	}
+	c.JSON(http.StatusOK, httpserver.AuditEventList{
+		Items: items,
+		Pagination: httpserver.Pagination{
+			Page:    result.Page,
+			PerPage: result.PerPage,
+			Total:   int(result.Total),
+		},
+	})
+}
+
+func auditFilterFromQuery(c *gin.Context) (audit.Filter, bool) {
+	var filter audit.Filter
+	if v := c.Query("entity_type"); v != "" {
+		filter.EntityType = &v
+	}
+	if v := c.Query("entity_id"); v != "" {
+		filter.EntityID = &v
+	}
+	if v := c.Query("action"); v != "" {
+		filter.Action = &v
+	}
+	if v := c.Query("actor_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			respondError(c, errs.NewValidationError(map[string]string{"actor_id": "must be an integer"}))
+			return filter, false
+		}
+		filter.ActorID = &id
+	}
+	var ok bool
+	if filter.Since, ok = queryTime(c, "since"); !ok {
+		return filter, false
+	}
+	if filter.Until, ok = queryTime(c, "until"); !ok {
+		return filter, false
+	}
+	return filter, true
+}
+
+func queryTime(c *gin.Context, name string) (*time.Time, bool) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		respondError(c, errs.NewValidationError(map[string]string{name: "must be an RFC 3339 date-time"}))
+		return nil, false
+	}
+	return &t, true
+}
+
+func auditEventToHTTP(e audit.Event) httpserver.AuditEvent {
+	return httpserver.AuditEvent{
+		Id:         e.ID,
+		ActorId:    e.ActorID,
+		ActorName:  e.ActorName,
+		IpAddress:  e.IPAddress,
+		RequestId:  e.RequestID,
+		EntityType: httpserver.AuditEventEntityType(e.EntityType),
+		EntityId:   e.EntityID,
+		Action:     e.Action,
+		Before:     auditStateToHTTP(e.Before),
+		After:      auditStateToHTTP(e.After),
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func auditStateToHTTP(raw json.RawMessage) *map[string]interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	var state map[string]interface{}
+	if err := json.Unmarshal(raw, &state); err != nil || state == nil {
+		return nil
+	}
+	return &state
+}
+
+// recordExport logs an export of the game and what it produced. Exports
+// change nothing, so the event is written on its own.
+func (h *Handler) recordExport(c *gin.Context, gameID int64, export map[string]string) error {
+	return h.auditLog.Record(c.Request.Context(), audit.Entry{
+		EntityType: audit.EntityGame,
+		EntityID:   gameID,
+		Action:     audit.ActionExport,
+		After:      export,
+	})
+}
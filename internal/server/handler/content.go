@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serveContent streams a stored file with HTTP caching and resumption:
+// ETag comes from the file's SHA256, Last-Modified from modTime, and
+// http.ServeContent answers Range, If-Range, If-None-Match and
+// If-Modified-Since. An empty sha256 or zero modTime omits that validator.
+// A non-empty filename is sent as an attachment.
+func serveContent(c *gin.Context, content io.ReadSeeker, filename, contentType, sha256 string, modTime time.Time) {
+	if sha256 != "" {
+		c.Header("ETag", `"`+sha256+`"`)
+	}
+	c.Header("Content-Type", contentType)
+	if filename != "" {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, sanitizeFilename(filename)))
+	}
+	http.ServeContent(c.Writer, c.Request, filename, modTime, content)
+}
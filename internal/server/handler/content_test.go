@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestServeContent_RangeAndConditional(t *testing.T) {
+	modTime := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	r := setupGin()
+	r.GET("/file", func(c *gin.Context) {
+		serveContent(c, strings.NewReader("0123456789"), "a.zip", "application/zip", "abc", modTime)
+	})
+	get := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/file", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get(nil)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != `"abc"` || w.Header().Get("Accept-Ranges") != "bytes" {
+		t.Fatalf("full: code=%d headers=%v", w.Code, w.Header())
+	}
+	if w.Header().Get("Last-Modified") != modTime.Format(http.TimeFormat) {
+		t.Errorf("Last-Modified = %q", w.Header().Get("Last-Modified"))
+	}
+
+	if w := get(map[string]string{"If-None-Match": `"abc"`}); w.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match: code = %d, want 304", w.Code)
+	}
+
+	w = get(map[string]string{"Range": "bytes=4-"})
+	if w.Code != http.StatusPartialContent || w.Body.String() != "456789" {
+		t.Errorf("Range: code=%d body=%q", w.Code, w.Body.String())
+	}
+
+	w = get(map[string]string{"Range": "bytes=4-", "If-Range": `"stale"`})
+	if w.Code != http.StatusOK || w.Body.String() != "0123456789" {
+		t.Errorf("stale If-Range: code=%d body=%q, want the full file", w.Code, w.Body.String())
+	}
+}
@@ -1,16 +1,17 @@
 package handler
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	ctf01dsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/ctf01d"
 )
 
@@ -58,6 +59,9 @@ func (h *Handler) HandleExportCtf01d(c *gin.Context) {
 	if !ok {
 		return
 	}
+	if h.resumeExport(c, id) {
+		return
+	}
 
 	req, ok := bindJSON[httpserver.Ctf01dExportRequest](c)
 	if !ok {
@@ -107,6 +111,7 @@ func (h *Handler) HandleExportCtf01d(c *gin.Context) {
 		respondError(c, err)
 		return
 	}
+	defer result.Cleanup()
 
 	opts := result.Options
 	opts.Warnings = result.Warnings
@@ -125,11 +130,38 @@ func (h *Handler) HandleExportCtf01d(c *gin.Context) {
 		return
 	}
 
-	c.Header("Content-Type", "application/zip")
-	safeName := sanitizeFilename(exportResult.Filename)
-	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, safeName))
-	c.Header("Content-Length", strconv.Itoa(len(exportResult.Data)))
-	c.Data(http.StatusOK, "application/zip", exportResult.Data)
+	stored, err := h.exports.Save(c.Request.Context(), id, exportResult.Filename, exportResult.Data, result.ModifiedAt)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := h.recordExport(c, id, map[string]string{"format": "ctf01d", "filename": stored.Filename, "sha256": stored.SHA256}); err != nil {
+		respondError(c, err)
+		return
+	}
+	serveContent(c, bytes.NewReader(exportResult.Data), stored.Filename, "application/zip", stored.SHA256, stored.ModifiedAt)
+}
+
+// resumeExport serves a range of an export the client already started to
+// download, named by the ETag in If-Range, from storage instead of building
+// it again. It reports false when the request is not such a resumption or
+// the export is no longer stored; the export is then built as usual.
+func (h *Handler) resumeExport(c *gin.Context, gameID int64) bool {
+	ifRange := c.GetHeader("If-Range")
+	if c.GetHeader("Range") == "" || !strings.HasPrefix(ifRange, `"`) {
+		return false
+	}
+	file, err := h.exports.Open(c.Request.Context(), gameID, strings.Trim(ifRange, `"`))
+	if errors.Is(err, errs.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		respondError(c, err)
+		return true
+	}
+	defer file.Close()
+	serveContent(c, file, file.Filename, "application/zip", file.SHA256, file.ModifiedAt)
+	return true
 }
 
 func strPtr(s string) *string {
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+)
+
+func (h *Handler) RequestPasswordReset(c *gin.Context) {
+	req, ok := bindJSON[httpserver.PasswordResetRequest](c)
+	if !ok {
+		return
+	}
+	if err := h.emails.RequestPasswordReset(c.Request.Context(), req.Login); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ConfirmPasswordReset(c *gin.Context) {
+	req, ok := bindJSON[httpserver.PasswordResetConfirm](c)
+	if !ok {
+		return
+	}
+	if err := h.emails.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ConfirmEmailVerification(c *gin.Context) {
+	req, ok := bindJSON[httpserver.EmailVerificationConfirm](c)
+	if !ok {
+		return
+	}
+	if err := h.emails.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) SendProfileEmailVerification(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+	if err := h.emails.SendVerification(c.Request.Context(), userID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	gamesvc "github.com/ctf01d/ctf01d-training-platform/internal/service/games"
+)
+
+// viewerGameRole is the role the caller holds in the game, or "" for
+// anonymous callers and users without one.
+func (h *Handler) viewerGameRole(c *gin.Context, gameID int64) string {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return ""
+	}
+	role, err := h.games.RoleOf(c.Request.Context(), gameID, userID)
+	if err != nil {
+		return ""
+	}
+	return role
+}
+
+// canManageGame reports whether the caller may change the game: admins every
+// game, organizers theirs. Operations addressed by {id} get this from the
+// game_organizer permission; this serves those naming the game in the body.
+func (h *Handler) canManageGame(c *gin.Context, gameID int64) bool {
+	if role, _ := middleware.CurrentRole(c); role == roleAdmin {
+		return true
+	}
+	return h.viewerGameRole(c, gameID) == gamesvc.RoleOrganizer
+}
+
+// gameRoleAccess is what the caller may do with the roles of the game of the
+// current request.
+func gameRoleAccess(c *gin.Context) gamesvc.RoleAccess {
+	role, hasRole := middleware.CurrentRole(c)
+	return gamesvc.RoleAccess{
+		Admin:     hasRole && role == roleAdmin,
+		Organizer: middleware.HasResourceAccess(c),
+	}
+}
+
+func (h *Handler) HandleListGameRoles(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	roles, err := h.games.ListRoles(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gameRoleListToHTTP(roles))
+}
+
+func (h *Handler) HandleSetGameRole(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := parseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[httpserver.GameRoleSetRequest](c)
+	if !ok {
+		return
+	}
+	roles, err := h.games.SetRole(c.Request.Context(), id, userID, string(req.Role), gameRoleAccess(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gameRoleListToHTTP(roles))
+}
+
+func (h *Handler) HandleRemoveGameRole(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := parseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+	if err := h.games.RemoveRole(c.Request.Context(), id, userID, gameRoleAccess(c)); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func gameRoleListToHTTP(roles []gamesvc.GameRole) httpserver.GameRoleList {
+	items := make([]httpserver.GameRole, len(roles))
+	for i, r := range roles {
+		items[i] = httpserver.GameRole{
+			UserId:      r.UserID,
+			UserName:    r.UserName,
+			DisplayName: r.DisplayName,
+			AvatarUrl:   r.AvatarUrl,
+			Role:        httpserver.GameRoleRole(r.Role),
+			CreatedAt:   r.CreatedAt,
+		}
+	}
+	return httpserver.GameRoleList{Items: items}
+}
@@ -65,6 +65,10 @@ func (h *Handler) HandleCreateGameTeam(c *gin.Context) {
 	if !ok {
 		return
 	}
+	if !h.canManageGame(c, req.GameId) {
+		respondError(c, errs.ErrForbidden)
+		return
+	}
 
 	order := int32(0)
 	if req.Order != nil {
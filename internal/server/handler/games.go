@@ -87,6 +87,10 @@ func (h *Handler) HandleCreateGame(c *gin.Context) {
 		Theme:                req.Theme,
 		Requirements:         req.Requirements,
 	}
+	// Players who set up a game run it; admins run every game anyway.
+	if role, _ := middleware.CurrentRole(c); role != roleAdmin {
+		params.CreatorID, _ = middleware.CurrentUserID(c)
+	}
 
 	game, err := h.games.Create(c.Request.Context(), params)
 	if err != nil {
@@ -94,10 +98,7 @@ func (h *Handler) HandleCreateGame(c *gin.Context) {
 		return
 	}
 
-	viewerRole, _ := middleware.CurrentRole(c)
-	userID, hasUser := middleware.CurrentUserID(c)
-
-	c.JSON(http.StatusCreated, gameToHTTP(*game, h.canAccessGameSecrets(c, game.ID, viewerRole, hasUser, userID)))
+	c.JSON(http.StatusCreated, h.gameResponse(c, *game))
 }
 
 func (h *Handler) HandleGetGame(c *gin.Context) {
@@ -112,10 +113,7 @@ func (h *Handler) HandleGetGame(c *gin.Context) {
 		return
 	}
 
-	viewerRole, _ := middleware.CurrentRole(c)
-	userID, hasUser := middleware.CurrentUserID(c)
-
-	c.JSON(http.StatusOK, gameToHTTP(*game, h.canAccessGameSecrets(c, game.ID, viewerRole, hasUser, userID)))
+	c.JSON(http.StatusOK, h.gameResponse(c, *game))
 }
 
 func (h *Handler) HandleUpdateGame(c *gin.Context) {
@@ -155,10 +153,7 @@ func (h *Handler) HandleUpdateGame(c *gin.Context) {
 		return
 	}
 
-	viewerRole, _ := middleware.CurrentRole(c)
-	userID, hasUser := middleware.CurrentUserID(c)
-
-	c.JSON(http.StatusOK, gameToHTTP(*game, h.canAccessGameSecrets(c, game.ID, viewerRole, hasUser, userID)))
+	c.JSON(http.StatusOK, h.gameResponse(c, *game))
 }
 
 func (h *Handler) HandleDeleteGame(c *gin.Context) {
@@ -186,10 +181,7 @@ func (h *Handler) HandleFinalizeGame(c *gin.Context) {
 		return
 	}
 
-	viewerRole, _ := middleware.CurrentRole(c)
-	userID, hasUser := middleware.CurrentUserID(c)
-
-	c.JSON(http.StatusOK, gameToHTTP(*game, h.canAccessGameSecrets(c, game.ID, viewerRole, hasUser, userID)))
+	c.JSON(http.StatusOK, h.gameResponse(c, *game))
 }
 
 func (h *Handler) HandleUnfinalizeGame(c *gin.Context) {
@@ -204,10 +196,7 @@ func (h *Handler) HandleUnfinalizeGame(c *gin.Context) {
 		return
 	}
 
-	viewerRole, _ := middleware.CurrentRole(c)
-	userID, hasUser := middleware.CurrentUserID(c)
-
-	c.JSON(http.StatusOK, gameToHTTP(*game, h.canAccessGameSecrets(c, game.ID, viewerRole, hasUser, userID)))
+	c.JSON(http.StatusOK, h.gameResponse(c, *game))
 }
 
 func (h *Handler) HandleListGameServices(c *gin.Context) {
@@ -224,7 +213,7 @@ func (h *Handler) HandleListGameServices(c *gin.Context) {
 
 	out := make([]httpserver.GameServiceLink, len(links))
 	for i, l := range links {
-		out[i] = httpserver.GameServiceLink{ServiceId: l.ServiceID, Status: l.Status}
+		out[i] = gameServiceLinkToHTTP(l)
 	}
 	c.JSON(http.StatusOK, out)
 }
@@ -290,10 +279,34 @@ func (h *Handler) HandlePublishGame(c *gin.Context) {
 		return
 	}
 
-	viewerRole, _ := middleware.CurrentRole(c)
-	userID, hasUser := middleware.CurrentUserID(c)
+	c.JSON(http.StatusOK, h.gameResponse(c, *game))
+}
+
+func (h *Handler) HandleListGamePortConflicts(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	conflicts, err := h.games.PortConflicts(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
 
-	c.JSON(http.StatusOK, gameToHTTP(*game, h.canAccessGameSecrets(c, game.ID, viewerRole, hasUser, userID)))
+	out := make([]httpserver.GamePortConflict, len(conflicts))
+	for i, conflict := range conflicts {
+		services := make([]httpserver.GamePortService, len(conflict.Services))
+		for j, svc := range conflict.Services {
+			services[j] = httpserver.GamePortService{ServiceId: svc.ServiceID, Name: svc.Name, Ports: svc.Ports}
+		}
+		suggestions := make([]httpserver.GamePortSuggestion, len(conflict.Suggestions))
+		for j, suggestion := range conflict.Suggestions {
+			suggestions[j] = httpserver.GamePortSuggestion{ServiceId: suggestion.ServiceID, Port: suggestion.Port}
+		}
+		out[i] = httpserver.GamePortConflict{Port: conflict.Port, Services: services, Suggestions: suggestions}
+	}
+	c.JSON(http.StatusOK, out)
 }
 
 func (h *Handler) HandleRemoveGameService(c *gin.Context) {
@@ -314,6 +327,99 @@ func (h *Handler) HandleRemoveGameService(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func (h *Handler) HandlePinGameService(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	serviceID, ok := parseIDParam(c, "service_id")
+	if !ok {
+		return
+	}
+
+	req, ok := bindJSON[httpserver.PinGameServiceJSONRequestBody](c)
+	if !ok {
+		return
+	}
+
+	link, err := h.games.PinService(c.Request.Context(), id, serviceID, gamesvc.PinParams{
+		ArchiveVersionID: req.ArchiveVersionId,
+		GitCommit:        req.GitCommit,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gameServiceLinkToHTTP(*link))
+}
+
+func (h *Handler) HandleUnpinGameService(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	serviceID, ok := parseIDParam(c, "service_id")
+	if !ok {
+		return
+	}
+
+	if err := h.games.UnpinService(c.Request.Context(), id, serviceID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) HandleListServiceGamePins(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var versionID *int64
+	if raw := c.Query("version_id"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{Code: codeBadRequest, Message: "version_id must be a version id"})
+			return
+		}
+		versionID = &v
+	}
+
+	role, hasRole := middleware.CurrentRole(c)
+	pins, err := h.games.ListServicePins(c.Request.Context(), id, versionID, hasRole && role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	out := make([]httpserver.ServiceGamePin, len(pins))
+	for i, p := range pins {
+		out[i] = httpserver.ServiceGamePin{
+			GameId:                 p.GameID,
+			GameName:               p.GameName,
+			GameFinalized:          p.GameFinalized,
+			Status:                 p.Status,
+			PinnedServiceVersionId: p.PinnedServiceVersionID,
+			PinnedCheckerVersionId: p.PinnedCheckerVersionID,
+			FollowsCurrent:         p.FollowsCurrent,
+		}
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func gameServiceLinkToHTTP(l gamesvc.GameServiceLink) httpserver.GameServiceLink {
+	return httpserver.GameServiceLink{
+		ServiceId:              l.ServiceID,
+		Status:                 l.Status,
+		PinnedServiceVersionId: l.PinnedServiceVersionID,
+		PinnedCheckerVersionId: l.PinnedCheckerVersionID,
+		PinnedGitCommit:        l.PinnedGitCommit,
+	}
+}
+
 func gameToHTTP(g gamesvc.Game, canAccessSecrets bool) httpserver.Game {
 	result := httpserver.Game{
 		Id:                   g.ID,
@@ -348,6 +454,21 @@ func gameToHTTP(g gamesvc.Game, canAccessSecrets bool) httpserver.Game {
 	return result
 }
 
+// gameResponse renders a game for the caller: the access details if they may
+// see them and the caller's role in the game.
+func (h *Handler) gameResponse(c *gin.Context, game gamesvc.Game) httpserver.Game {
+	viewerRole, _ := middleware.CurrentRole(c)
+	userID, hasUser := middleware.CurrentUserID(c)
+
+	result := gameToHTTP(game, h.canAccessGameSecrets(c, game.ID, viewerRole, hasUser, userID))
+	if role := h.viewerGameRole(c, game.ID); role != "" {
+		result.ViewerGameRole = (*httpserver.GameViewerGameRole)(&role)
+	}
+	return result
+}
+
+// canAccessGameSecrets reports whether the caller may see the access details
+// of a game: admins, its organizers and members of approved teams may.
 func (h *Handler) canAccessGameSecrets(c *gin.Context, gameID int64, role string, hasUser bool, userID int64) bool {
 	if role == roleAdmin {
 		return true
@@ -355,6 +476,9 @@ func (h *Handler) canAccessGameSecrets(c *gin.Context, gameID int64, role string
 	if !hasUser {
 		return false
 	}
+	if h.viewerGameRole(c, gameID) == gamesvc.RoleOrganizer {
+		return true
+	}
 	approved, err := h.gameTeamsQ.IsUserApprovedInGameTeams(c.Request.Context(), db.IsUserApprovedInGameTeamsParams{
 		GameID: gameID,
 		UserID: userID,
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	svcsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/services"
+)
+
+func (h *Handler) HandleListGitCredentials(c *gin.Context) {
+	role, _ := middleware.CurrentRole(c)
+	creds, err := h.gitCredentials.List(c.Request.Context(), role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]httpserver.GitCredential, len(creds))
+	for i, cred := range creds {
+		items[i] = gitCredentialToHTTP(cred)
+	}
+	c.JSON(http.StatusOK, httpserver.GitCredentialList{Items: items})
+}
+
+func (h *Handler) HandleCreateGitCredential(c *gin.Context) {
+	req, ok := bindJSON[httpserver.GitCredentialCreate](c)
+	if !ok {
+		return
+	}
+
+	role, _ := middleware.CurrentRole(c)
+	cred, err := h.gitCredentials.Create(c.Request.Context(), svcsvc.GitCredentialCreateParams{
+		Name:        req.Name,
+		Kind:        string(req.Kind),
+		HostPattern: req.HostPattern,
+		Username:    req.Username,
+		Secret:      req.Secret,
+	}, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gitCredentialToHTTP(*cred))
+}
+
+func (h *Handler) HandleGetGitCredential(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	role, _ := middleware.CurrentRole(c)
+	cred, err := h.gitCredentials.GetByID(c.Request.Context(), id, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gitCredentialToHTTP(*cred))
+}
+
+func (h *Handler) HandleUpdateGitCredential(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	req, ok := bindJSON[httpserver.GitCredentialUpdate](c)
+	if !ok {
+		return
+	}
+
+	role, _ := middleware.CurrentRole(c)
+	cred, err := h.gitCredentials.Update(c.Request.Context(), id, svcsvc.GitCredentialUpdateParams{
+		Name:        req.Name,
+		HostPattern: req.HostPattern,
+		Username:    req.Username,
+		Secret:      req.Secret,
+	}, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gitCredentialToHTTP(*cred))
+}
+
+func (h *Handler) HandleDeleteGitCredential(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	role, _ := middleware.CurrentRole(c)
+	if err := h.gitCredentials.Delete(c.Request.Context(), id, role == roleAdmin); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func gitCredentialToHTTP(cred svcsvc.GitCredential) httpserver.GitCredential {
+	return httpserver.GitCredential{
+		Id:          cred.ID,
+		Name:        cred.Name,
+		Kind:        httpserver.GitCredentialKind(cred.Kind),
+		HostPattern: cred.HostPattern,
+		Username:    cred.Username,
+		CreatedAt:   &cred.CreatedAt,
+		UpdatedAt:   &cred.UpdatedAt,
+	}
+}
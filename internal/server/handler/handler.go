@@ -1,8 +1,8 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
@@ -32,6 +33,10 @@ import (
 type Handler struct {
 	users          *usersvc.Service
 	auth           *authsvc.Service
+	oidc           *authsvc.OIDCService
+	twoFactor      *authsvc.TwoFactorService
+	apiTokens      *authsvc.APITokenService
+	emails         *authsvc.EmailService
 	jwtMgr         *auth.Manager
 	universities   *unisvc.Service
 	teams          *teamsvc.Service
@@ -46,7 +51,12 @@ type Handler struct {
 	svcArchives    *svcsvc.ArchiveService
 	svcChecker     *svcsvc.CheckerService
 	svcImport      *svcsvc.ImportService
+	gitCredentials *svcsvc.GitCredentialService
+	submissions    *svcsvc.SubmissionService
+	downloadLinks  *svcsvc.DownloadLinkService
 	ctf01dBuilder  *ctf01dsvc.Builder
+	exports        *svcsvc.ExportStore
+	auditLog       *audit.Service
 	maxUploadBytes int64
 	storageDir     string
 	fileStorage    storage.Storage
@@ -58,6 +68,8 @@ const (
 	roleAdmin  = "admin"
 
 	maxBytesReaderOverhead = 1024
+	// maxManifestBytes caps the body of the public manifest validator.
+	maxManifestBytes = 1 << 20
 
 	minInt32 = -1 << 31
 	maxInt32 = 1<<31 - 1
@@ -66,6 +78,10 @@ const (
 func New(
 	users *usersvc.Service,
 	authSvc *authsvc.Service,
+	oidc *authsvc.OIDCService,
+	twoFactor *authsvc.TwoFactorService,
+	apiTokens *authsvc.APITokenService,
+	emails *authsvc.EmailService,
 	jwtMgr *auth.Manager,
 	universities *unisvc.Service,
 	teams *teamsvc.Service,
@@ -80,7 +96,12 @@ func New(
 	svcArchives *svcsvc.ArchiveService,
 	svcChecker *svcsvc.CheckerService,
 	svcImport *svcsvc.ImportService,
+	gitCredentials *svcsvc.GitCredentialService,
+	submissions *svcsvc.SubmissionService,
+	downloadLinks *svcsvc.DownloadLinkService,
 	ctf01dBuilder *ctf01dsvc.Builder,
+	exports *svcsvc.ExportStore,
+	auditLog *audit.Service,
 	maxUploadBytes int64,
 	storageDir string,
 	fileStorage storage.Storage,
@@ -88,6 +109,10 @@ func New(
 	return &Handler{
 		users:          users,
 		auth:           authSvc,
+		oidc:           oidc,
+		twoFactor:      twoFactor,
+		apiTokens:      apiTokens,
+		emails:         emails,
 		jwtMgr:         jwtMgr,
 		universities:   universities,
 		teams:          teams,
@@ -102,7 +127,12 @@ func New(
 		svcArchives:    svcArchives,
 		svcChecker:     svcChecker,
 		svcImport:      svcImport,
+		gitCredentials: gitCredentials,
+		submissions:    submissions,
+		downloadLinks:  downloadLinks,
 		ctf01dBuilder:  ctf01dBuilder,
+		exports:        exports,
+		auditLog:       auditLog,
 		maxUploadBytes: maxUploadBytes,
 		storageDir:     storageDir,
 		fileStorage:    fileStorage,
@@ -122,22 +152,62 @@ func (h *Handler) SessionChecker() middleware.SessionChecker {
 	return h.auth
 }
 
+// APITokens exposes personal API token authentication to middleware. It
+// returns nil when no token service is configured (tests).
+func (h *Handler) APITokens() middleware.APITokenAuthenticator {
+	if h.apiTokens == nil {
+		return nil
+	}
+	return apiTokenAuthenticator{tokens: h.apiTokens}
+}
+
+// ResourcePermissions exposes the resource-level permission checks declared in
+// OpenAPI to middleware. It returns nil when no service catalog is configured
+// (tests).
+func (h *Handler) ResourcePermissions() middleware.ResourcePermissionChecker {
+	if h.svcService == nil || h.games == nil {
+		return nil
+	}
+	return resourcePermissions{services: h.svcService, games: h.games}
+}
+
+// resourcePermissions resolves the x-resource-permission checks of OpenAPI
+// operations.
+type resourcePermissions struct {
+	services *svcsvc.Service
+	games    *gamesvc.Service
+}
+
+func (p resourcePermissions) HasResourcePermission(ctx context.Context, permission string, userID, resourceID int64) (bool, error) {
+	switch permission {
+	case middleware.PermissionServiceAuthor:
+		return p.services.IsAuthor(ctx, resourceID, userID)
+	case middleware.PermissionGameOrganizer:
+		role, err := p.games.RoleOf(ctx, resourceID, userID)
+		return role == gamesvc.RoleOrganizer, err
+	case middleware.PermissionGameTeamOrganizer:
+		role, err := p.games.RoleOfGameTeam(ctx, resourceID, userID)
+		return role == gamesvc.RoleOrganizer, err
+	case middleware.PermissionResultJury:
+		role, err := p.games.RoleOfResult(ctx, resourceID, userID)
+		return role == gamesvc.RoleOrganizer || role == gamesvc.RoleJury, err
+	default:
+		return false, nil
+	}
+}
+
 func (h *Handler) Login(c *gin.Context) {
 	req, ok := bindJSON[httpserver.LoginRequest](c)
 	if !ok {
 		return
 	}
 
-	token, user, err := h.auth.Login(c.Request.Context(), req.UserName, req.Password, c.ClientIP(), c.Request.UserAgent())
+	result, err := h.auth.Login(c.Request.Context(), req.UserName, req.Password, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-
-	c.JSON(http.StatusOK, httpserver.LoginResponse{
-		Token: token,
-		User:  userToHTTPPrivate(*user),
-	})
+	respondLogin(c, result)
 }
 
 func (h *Handler) Logout(c *gin.Context) {
@@ -150,6 +220,25 @@ func (h *Handler) Logout(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func (h *Handler) RefreshSession(c *gin.Context) {
+	req, ok := bindJSON[httpserver.RefreshRequest](c)
+	if !ok {
+		return
+	}
+
+	result, err := h.auth.Refresh(c.Request.Context(), req.RefreshToken, c.ClientIP())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, httpserver.RefreshResponse{
+		Token:            result.Token,
+		ExpiresAt:        result.TokenExpiresAt,
+		RefreshToken:     result.RefreshToken,
+		SessionExpiresAt: result.SessionExpiresAt,
+	})
+}
+
 func (h *Handler) GetProfile(c *gin.Context) {
 	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
@@ -397,6 +486,10 @@ func (h *Handler) HandleUpdateUserRole(c *gin.Context) {
 }
 
 // ServerInterface implementation (used for compile-time check)
+func (h *Handler) ListAuditEvents(c *gin.Context, _ httpserver.ListAuditEventsParams) {
+	h.HandleListAuditEvents(c)
+}
+
 func (h *Handler) ListUsers(c *gin.Context, _ httpserver.ListUsersParams) {
 	h.HandleListUsers(c)
 }
@@ -451,6 +544,7 @@ func userToHTTPWithPrivate(u usersvc.User, includePrivate bool) httpserver.User
 		UpdatedAt:   &u.UpdatedAt,
 	}
 	if includePrivate {
+		result.EmailVerifiedAt = u.EmailVerifiedAt
 		result.LastLoginIp = u.LastLoginIp
 		result.LastLoginAt = u.LastLoginAt
 	}
@@ -629,6 +723,23 @@ func (h *Handler) UnfinalizeGame(c *gin.Context, id int64) {
 	h.HandleUnfinalizeGame(c)
 }
 
+func (h *Handler) ListGameRoles(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleListGameRoles(c)
+}
+
+func (h *Handler) SetGameRole(c *gin.Context, id int64, _ int64) {
+	// "user_id" is the authenticated user's context key; the handler reads the
+	// path parameter instead.
+	c.Set("id", id)
+	h.HandleSetGameRole(c)
+}
+
+func (h *Handler) RemoveGameRole(c *gin.Context, id int64, _ int64) {
+	c.Set("id", id)
+	h.HandleRemoveGameRole(c)
+}
+
 func (h *Handler) ListGameServices(c *gin.Context, id int64) {
 	c.Set("id", id)
 	h.HandleListGameServices(c)
@@ -645,6 +756,18 @@ func (h *Handler) RemoveGameService(c *gin.Context, id int64, serviceId int64) {
 	h.HandleRemoveGameService(c)
 }
 
+func (h *Handler) PinGameService(c *gin.Context, id int64, serviceId int64) {
+	c.Set("id", id)
+	c.Set("service_id", serviceId)
+	h.HandlePinGameService(c)
+}
+
+func (h *Handler) UnpinGameService(c *gin.Context, id int64, serviceId int64) {
+	c.Set("id", id)
+	c.Set("service_id", serviceId)
+	h.HandleUnpinGameService(c)
+}
+
 func (h *Handler) SetGameServiceStatus(c *gin.Context, id int64, serviceId int64) {
 	c.Set("id", id)
 	c.Set("service_id", serviceId)
@@ -656,6 +779,11 @@ func (h *Handler) PublishGame(c *gin.Context, id int64) {
 	h.HandlePublishGame(c)
 }
 
+func (h *Handler) ListGamePortConflicts(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleListGamePortConflicts(c)
+}
+
 func (h *Handler) ListGameTeams(c *gin.Context, id int64) {
 	c.Set("id", id)
 	h.HandleListGameTeams(c)
@@ -806,6 +934,7 @@ func (h *Handler) HandleCreateService(c *gin.Context) {
 		if req.GitSource.Subdir != nil {
 			params.GitSource.Subdir = *req.GitSource.Subdir
 		}
+		params.GitSource.CredentialID = req.GitSource.CredentialId
 	}
 	if req.Ports != nil {
 		params.Ports = *req.Ports
@@ -827,14 +956,14 @@ func (h *Handler) HandleGetService(c *gin.Context) {
 		return
 	}
 	role, hasRole := middleware.CurrentRole(c)
-	isAdmin := hasRole && role == roleAdmin
-	includeSource := hasRole && (role == roleAdmin || role == rolePlayer)
-	svc, err := h.svcService.GetByID(c.Request.Context(), id, isAdmin)
+	access := serviceAccess(c)
+	includeSource := access.CanManage() || (hasRole && role == rolePlayer)
+	svc, err := h.svcService.GetByID(c.Request.Context(), id, access)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	if !svc.Public && !isAdmin {
+	if !svc.Public && !access.CanManage() {
 		respondError(c, errs.ErrNotFound)
 		return
 	}
@@ -850,8 +979,6 @@ func (h *Handler) HandleUpdateService(c *gin.Context) {
 	if !ok {
 		return
 	}
-	role, _ := middleware.CurrentRole(c)
-	isAdmin := role == roleAdmin
 	var training json.RawMessage
 	if req.Ctf01dTraining != nil {
 		b, _ := json.Marshal(*req.Ctf01dTraining)
@@ -882,6 +1009,7 @@ func (h *Handler) HandleUpdateService(c *gin.Context) {
 		if req.GitSource.Subdir != nil {
 			params.GitSource.Subdir = *req.GitSource.Subdir
 		}
+		params.GitSource.CredentialID = req.GitSource.CredentialId
 	}
 	if req.Ports != nil {
 		params.Ports = *req.Ports
@@ -889,7 +1017,7 @@ func (h *Handler) HandleUpdateService(c *gin.Context) {
 	if req.TechStack != nil {
 		params.TechStack = *req.TechStack
 	}
-	svc, err := h.svcService.Update(c.Request.Context(), id, params, isAdmin)
+	svc, err := h.svcService.Update(c.Request.Context(), id, params, serviceAccess(c))
 	if err != nil {
 		respondError(c, err)
 		return
@@ -929,9 +1057,7 @@ func (h *Handler) HandleCheckServiceChecker(c *gin.Context) {
 	if !ok {
 		return
 	}
-	role, _ := middleware.CurrentRole(c)
-	isAdmin := role == roleAdmin
-	svc, err := h.svcChecker.CheckChecker(c.Request.Context(), id, isAdmin)
+	svc, err := h.svcChecker.CheckChecker(c.Request.Context(), id, serviceAccess(c))
 	if err != nil {
 		respondError(c, err)
 		return
@@ -944,9 +1070,7 @@ func (h *Handler) HandleRedownloadServiceArchives(c *gin.Context) {
 	if !ok {
 		return
 	}
-	role, _ := middleware.CurrentRole(c)
-	isAdmin := role == roleAdmin
-	svc, err := h.svcArchives.Redownload(c.Request.Context(), id, isAdmin)
+	svc, err := h.svcArchives.Redownload(c.Request.Context(), id, serviceAccess(c))
 	if err != nil {
 		respondError(c, err)
 		return
@@ -959,9 +1083,6 @@ func (h *Handler) HandleUploadServiceArchives(c *gin.Context) {
 	if !ok {
 		return
 	}
-	role, _ := middleware.CurrentRole(c)
-	isAdmin := role == roleAdmin
-
 	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+maxBytesReaderOverhead)
 
 	form, err := c.MultipartForm()
@@ -992,7 +1113,7 @@ func (h *Handler) HandleUploadServiceArchives(c *gin.Context) {
 		checkerFile = f
 	}
 
-	svc, err := h.svcArchives.UploadArchives(c.Request.Context(), id, serviceFile, checkerFile, isAdmin)
+	svc, err := h.svcArchives.UploadArchives(c.Request.Context(), id, serviceFile, checkerFile, serviceAccess(c))
 	if err != nil {
 		respondError(c, err)
 		return
@@ -1011,7 +1132,7 @@ func (h *Handler) HandleDownloadServiceArchive(c *gin.Context) {
 	isAdmin := role == roleAdmin
 	isPlayer := role == rolePlayer
 
-	svc, err := h.svcService.GetByID(c.Request.Context(), id, isAdmin)
+	svc, err := h.svcService.GetByID(c.Request.Context(), id, svcsvc.Access{Admin: isAdmin})
 	if err != nil {
 		respondError(c, err)
 		return
@@ -1021,20 +1142,14 @@ func (h *Handler) HandleDownloadServiceArchive(c *gin.Context) {
 		return
 	}
 
-	rc, filename, err := h.svcArchives.OpenLocal(c.Request.Context(), id, kind)
+	file, err := h.svcArchives.OpenLocal(c.Request.Context(), id, kind)
 	if err != nil {
 		respondError(c, err)
 		return
 	}
-	defer rc.Close()
+	defer file.Close()
 
-	c.Header("Content-Type", "application/zip")
-	safeName := sanitizeFilename(filename)
-	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, safeName))
-	c.Status(http.StatusOK)
-	if _, err := io.Copy(c.Writer, rc); err != nil {
-		_ = c.Error(fmt.Errorf("copy service archive: %w", err))
-	}
+	serveContent(c, file, file.Filename, "application/zip", file.SHA256, file.ModifiedAt)
 }
 
 func (h *Handler) HandleSyncServiceFromGit(c *gin.Context) {
@@ -1042,9 +1157,7 @@ func (h *Handler) HandleSyncServiceFromGit(c *gin.Context) {
 	if !ok {
 		return
 	}
-	role, _ := middleware.CurrentRole(c)
-	isAdmin := role == roleAdmin
-	svc, err := h.svcImport.SyncFromGit(c.Request.Context(), id, isAdmin)
+	svc, err := h.svcImport.SyncFromGit(c.Request.Context(), id, serviceAccess(c))
 	if err != nil {
 		respondError(c, err)
 		return
@@ -1060,7 +1173,8 @@ func (h *Handler) HandleImportServiceFromGit(c *gin.Context) {
 	role, _ := middleware.CurrentRole(c)
 	isAdmin := role == roleAdmin
 	importReq := svcsvc.GitImportRequest{
-		RepoURL: req.RepoUrl,
+		RepoURL:      req.RepoUrl,
+		CredentialID: req.CredentialId,
 	}
 	if req.Ref != nil {
 		importReq.Ref = *req.Ref
@@ -1084,7 +1198,8 @@ func (h *Handler) HandlePreviewServiceGitImport(c *gin.Context) {
 	role, _ := middleware.CurrentRole(c)
 	isAdmin := role == roleAdmin
 	importReq := svcsvc.GitImportRequest{
-		RepoURL: req.RepoUrl,
+		RepoURL:      req.RepoUrl,
+		CredentialID: req.CredentialId,
 	}
 	if req.Ref != nil {
 		importReq.Ref = *req.Ref
@@ -1100,6 +1215,101 @@ func (h *Handler) HandlePreviewServiceGitImport(c *gin.Context) {
 	c.JSON(http.StatusOK, importPreviewToHTTP(result))
 }
 
+func (h *Handler) HandleDiscoverServicesInGit(c *gin.Context) {
+	req, ok := bindJSON[httpserver.GitImportRequest](c)
+	if !ok {
+		return
+	}
+	role, _ := middleware.CurrentRole(c)
+	isAdmin := role == roleAdmin
+	importReq := svcsvc.GitImportRequest{
+		RepoURL:      req.RepoUrl,
+		CredentialID: req.CredentialId,
+	}
+	if req.Ref != nil {
+		importReq.Ref = *req.Ref
+	}
+	if req.Subdir != nil {
+		importReq.Subdir = *req.Subdir
+	}
+	discovery, err := h.svcImport.DiscoverFromGit(c.Request.Context(), importReq, isAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	services := make([]httpserver.ServiceImportPreview, len(discovery.Services))
+	for i, p := range discovery.Services {
+		services[i] = importPreviewToHTTP(p)
+	}
+	c.JSON(http.StatusOK, httpserver.GitImportDiscovery{Commit: discovery.Commit, Services: services})
+}
+
+func (h *Handler) HandleImportServicesFromGit(c *gin.Context) {
+	req, ok := bindJSON[httpserver.GitBatchImportRequest](c)
+	if !ok {
+		return
+	}
+	role, _ := middleware.CurrentRole(c)
+	isAdmin := role == roleAdmin
+	importReq := svcsvc.GitImportRequest{
+		RepoURL:      req.RepoUrl,
+		CredentialID: req.CredentialId,
+	}
+	if req.Ref != nil {
+		importReq.Ref = *req.Ref
+	}
+	results, err := h.svcImport.ImportDiscoveredFromGit(c.Request.Context(), importReq, req.Subdirs, isAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	items := make([]httpserver.ImportResult, len(results))
+	for i, r := range results {
+		items[i] = importResultToHTTP(r, true)
+	}
+	c.JSON(http.StatusCreated, httpserver.GitBatchImportResult{Items: items})
+}
+
+// HandleValidateServiceManifest accepts the manifest either as the raw YAML
+// body or as {"content": "..."} and reports schema diagnostics.
+func (h *Handler) HandleValidateServiceManifest(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxManifestBytes)
+	var manifest []byte
+	if c.ContentType() == gin.MIMEJSON {
+		req, ok := bindJSON[httpserver.ServiceManifestValidateRequest](c)
+		if !ok {
+			return
+		}
+		manifest = []byte(req.Content)
+	} else {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse{Code: codeValidationError, Message: "manifest is too large"})
+			return
+		}
+		manifest = body
+	}
+	result, err := svcsvc.ValidateServiceManifest(manifest)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	diagnostics := make([]httpserver.ServiceManifestDiagnostic, len(result.Diagnostics))
+	for i, d := range result.Diagnostics {
+		diagnostics[i] = httpserver.ServiceManifestDiagnostic{
+			Path:    d.Path,
+			Line:    d.Line,
+			Column:  d.Column,
+			Message: d.Message,
+		}
+	}
+	c.JSON(http.StatusOK, httpserver.ServiceManifestValidation{
+		SchemaVersion: result.SchemaVersion,
+		Valid:         result.Valid,
+		Diagnostics:   diagnostics,
+	})
+}
+
 func (h *Handler) HandleImportServiceFromZip(c *gin.Context) {
 	file, err := c.FormFile("archive")
 	if err != nil {
@@ -1166,6 +1376,10 @@ func (h *Handler) ListServices(c *gin.Context, _ httpserver.ListServicesParams)
 	h.HandleListServices(c)
 }
 
+func (h *Handler) SearchServices(c *gin.Context, _ httpserver.SearchServicesParams) {
+	h.HandleSearchServices(c)
+}
+
 func (h *Handler) CreateService(c *gin.Context) {
 	h.HandleCreateService(c)
 }
@@ -1196,6 +1410,17 @@ func (h *Handler) DownloadServiceArchive(c *gin.Context, id int64, kind httpserv
 	h.HandleDownloadServiceArchive(c)
 }
 
+func (h *Handler) CreateServiceDownloadLink(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleCreateServiceDownloadLink(c)
+}
+
+func (h *Handler) DownloadServiceArchiveSigned(c *gin.Context, id int64, kind httpserver.DownloadServiceArchiveSignedParamsKind, _ httpserver.DownloadServiceArchiveSignedParams) {
+	c.Set("id", id)
+	c.Set("kind", string(kind))
+	h.HandleDownloadServiceArchiveSigned(c)
+}
+
 func (h *Handler) RedownloadServiceArchives(c *gin.Context, id int64) {
 	c.Set("id", id)
 	h.HandleRedownloadServiceArchives(c)
@@ -1211,6 +1436,64 @@ func (h *Handler) UploadServiceArchives(c *gin.Context, id int64) {
 	h.HandleUploadServiceArchives(c)
 }
 
+func (h *Handler) GetServiceReadme(c *gin.Context, id int64, _ httpserver.GetServiceReadmeParams) {
+	c.Set("id", id)
+	h.HandleGetServiceReadme(c)
+}
+
+func (h *Handler) GetServiceReadmeAsset(c *gin.Context, id int64, _ httpserver.GetServiceReadmeAssetParams) {
+	c.Set("id", id)
+	h.HandleGetServiceReadmeAsset(c)
+}
+
+func (h *Handler) ListServiceAuthors(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleListServiceAuthors(c)
+}
+
+func (h *Handler) AddServiceAuthor(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleAddServiceAuthor(c)
+}
+
+func (h *Handler) RemoveServiceAuthor(c *gin.Context, id int64, _ int64) {
+	// "user_id" is the authenticated user's context key; the handler reads the
+	// path parameter instead.
+	c.Set("id", id)
+	h.HandleRemoveServiceAuthor(c)
+}
+
+func (h *Handler) ListServiceVulns(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleListServiceVulns(c)
+}
+
+func (h *Handler) ReplaceServiceVulns(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleReplaceServiceVulns(c)
+}
+
+func (h *Handler) ListServiceArchiveVersions(c *gin.Context, id int64, _ httpserver.ListServiceArchiveVersionsParams) {
+	c.Set("id", id)
+	h.HandleListServiceArchiveVersions(c)
+}
+
+func (h *Handler) DiffServiceArchiveVersions(c *gin.Context, id int64, _ httpserver.DiffServiceArchiveVersionsParams) {
+	c.Set("id", id)
+	h.HandleDiffServiceArchiveVersions(c)
+}
+
+func (h *Handler) RollbackServiceArchiveVersion(c *gin.Context, id, versionID int64) {
+	c.Set("id", id)
+	c.Set("version_id", versionID)
+	h.HandleRollbackServiceArchiveVersion(c)
+}
+
+func (h *Handler) ListServiceGamePins(c *gin.Context, id int64, _ httpserver.ListServiceGamePinsParams) {
+	c.Set("id", id)
+	h.HandleListServiceGamePins(c)
+}
+
 func (h *Handler) SyncServiceFromGit(c *gin.Context, id int64) {
 	c.Set("id", id)
 	h.HandleSyncServiceFromGit(c)
@@ -1224,14 +1507,91 @@ func (h *Handler) PreviewServiceGitImport(c *gin.Context) {
 	h.HandlePreviewServiceGitImport(c)
 }
 
+func (h *Handler) DiscoverServicesInGit(c *gin.Context) {
+	h.HandleDiscoverServicesInGit(c)
+}
+
+func (h *Handler) ImportServicesFromGit(c *gin.Context) {
+	h.HandleImportServicesFromGit(c)
+}
+
 func (h *Handler) ImportServiceFromZip(c *gin.Context) {
 	h.HandleImportServiceFromZip(c)
 }
 
+func (h *Handler) ListServiceSubmissions(c *gin.Context, _ httpserver.ListServiceSubmissionsParams) {
+	h.HandleListServiceSubmissions(c)
+}
+
+func (h *Handler) SubmitServiceFromGit(c *gin.Context) {
+	h.HandleSubmitServiceFromGit(c)
+}
+
+func (h *Handler) SubmitServiceFromZip(c *gin.Context) {
+	h.HandleSubmitServiceFromZip(c)
+}
+
+func (h *Handler) GetServiceSubmission(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleGetServiceSubmission(c)
+}
+
+func (h *Handler) ResubmitServiceFromGit(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleResubmitServiceFromGit(c)
+}
+
+func (h *Handler) ResubmitServiceFromZip(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleResubmitServiceFromZip(c)
+}
+
+func (h *Handler) ReviewServiceSubmission(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleReviewServiceSubmission(c)
+}
+
+func (h *Handler) ListServiceSubmissionComments(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleListServiceSubmissionComments(c)
+}
+
+func (h *Handler) AddServiceSubmissionComment(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleAddServiceSubmissionComment(c)
+}
+
+func (h *Handler) ValidateServiceManifest(c *gin.Context) {
+	h.HandleValidateServiceManifest(c)
+}
+
 func (h *Handler) PreviewServiceZipImport(c *gin.Context) {
 	h.HandlePreviewServiceZipImport(c)
 }
 
+func (h *Handler) ListGitCredentials(c *gin.Context) {
+	h.HandleListGitCredentials(c)
+}
+
+func (h *Handler) CreateGitCredential(c *gin.Context) {
+	h.HandleCreateGitCredential(c)
+}
+
+func (h *Handler) GetGitCredential(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleGetGitCredential(c)
+}
+
+func (h *Handler) UpdateGitCredential(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleUpdateGitCredential(c)
+}
+
+func (h *Handler) DeleteGitCredential(c *gin.Context, id int64) {
+	c.Set("id", id)
+	h.HandleDeleteGitCredential(c)
+}
+
 func (h *Handler) GetCtf01dExportOptions(c *gin.Context, id int64) {
 	c.Set("id", id)
 	h.HandleGetCtf01dExportOptions(c)
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+)
+
+func (h *Handler) ListOIDCProviders(c *gin.Context) {
+	providers := h.oidc.Providers()
+	items := make([]httpserver.OIDCProvider, len(providers))
+	for i, p := range providers {
+		items[i] = httpserver.OIDCProvider{Name: p.Name, DisplayName: p.DisplayName}
+	}
+	c.JSON(http.StatusOK, httpserver.OIDCProviderList{Items: items})
+}
+
+func (h *Handler) StartOIDCLogin(c *gin.Context, provider string) {
+	authz, err := h.oidc.StartLogin(c.Request.Context(), provider)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, httpserver.OIDCAuthorization{AuthorizationUrl: authz.URL, State: authz.State})
+}
+
+func (h *Handler) CompleteOIDCLogin(c *gin.Context, provider string) {
+	req, ok := bindJSON[httpserver.OIDCCallbackRequest](c)
+	if !ok {
+		return
+	}
+
+	result, err := h.oidc.Callback(c.Request.Context(), provider, req.State, req.Code, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if result.Challenge != nil {
+		c.JSON(http.StatusAccepted, challengeToHTTP(result.Challenge))
+		return
+	}
+	resp := httpserver.OIDCCallbackResponse{User: userToHTTPPrivate(*result.User), Linked: result.Linked}
+	if result.Token != "" {
+		resp.Token = &result.Token
+		resp.ExpiresAt = &result.TokenExpiresAt
+		resp.RefreshToken = &result.RefreshToken
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) ListProfileIdentities(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	identities, err := h.oidc.ListIdentities(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]httpserver.UserIdentity, len(identities))
+	for i, id := range identities {
+		items[i] = httpserver.UserIdentity{
+			Id:          id.ID,
+			Provider:    id.Provider,
+			Email:       id.Email,
+			CreatedAt:   id.CreatedAt,
+			LastLoginAt: id.LastLoginAt,
+		}
+	}
+	c.JSON(http.StatusOK, httpserver.UserIdentityList{Items: items})
+}
+
+func (h *Handler) LinkProfileIdentity(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	req, ok := bindJSON[httpserver.UserIdentityLink](c)
+	if !ok {
+		return
+	}
+
+	authz, err := h.oidc.StartLink(c.Request.Context(), req.Provider, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, httpserver.OIDCAuthorization{AuthorizationUrl: authz.URL, State: authz.State})
+}
+
+func (h *Handler) UnlinkProfileIdentity(c *gin.Context, id int64) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	if err := h.oidc.Unlink(c.Request.Context(), userID, id); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -3,7 +3,10 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -21,6 +24,7 @@ const (
 	codeConflict        = "conflict"
 	codeForbidden       = "forbidden"
 	codeUnauthorized    = "unauthorized"
+	codeTooManyRequests = "too_many_requests"
 	codeValidationError = "validation_error"
 	codeBadRequest      = "bad_request"
 	codeInternalError   = "internal_error"
@@ -34,7 +38,11 @@ const (
 
 func respondError(c *gin.Context, err error) {
 	var ve *errs.ValidationError
+	var rl *errs.RateLimitError
 	switch {
+	case errors.As(err, &rl):
+		c.Header("Retry-After", retryAfterSeconds(rl.RetryAfter))
+		c.JSON(http.StatusTooManyRequests, errorResponse{Code: codeTooManyRequests, Message: err.Error()})
 	case errors.Is(err, errs.ErrNotFound):
 		c.JSON(http.StatusNotFound, errorResponse{Code: codeNotFound, Message: err.Error()})
 	case errors.Is(err, errs.ErrConflict):
@@ -58,6 +66,12 @@ func respondError(c *gin.Context, err error) {
 	}
 }
 
+// retryAfterSeconds renders a wait as a Retry-After value, rounded up to a
+// whole second.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(max(int(math.Ceil(d.Seconds())), 1))
+}
+
 func bindJSON[T any](c *gin.Context) (T, bool) {
 	var req T
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -74,6 +75,26 @@ func TestRespondError_Forbidden(t *testing.T) {
 	}
 }
 
+func TestRespondError_TooManyRequests(t *testing.T) {
+	r := setupGin()
+	r.GET("/test", func(c *gin.Context) {
+		respondError(c, fmt.Errorf("login: %w", errs.NewRateLimitError(1500*time.Millisecond)))
+	})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("expected Retry-After 2, got %q", got)
+	}
+	if resp := decodeBody(t, w); resp.Code != "too_many_requests" {
+		t.Errorf("expected code too_many_requests, got %s", resp.Code)
+	}
+}
+
 func TestRespondError_Unauthorized(t *testing.T) {
 	r := setupGin()
 	r.GET("/test", func(c *gin.Context) {
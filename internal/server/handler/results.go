@@ -9,6 +9,7 @@ import (
 	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	gamesvc "github.com/ctf01d/ctf01d-training-platform/internal/service/games"
 	resultsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/results"
 )
 
@@ -79,6 +80,14 @@ func (h *Handler) HandleCreateResult(c *gin.Context) {
 	}
 
 	role, _ := middleware.CurrentRole(c)
+	if role != roleAdmin {
+		// The jury of a game records its results along with the organizers.
+		gameRole := h.viewerGameRole(c, req.GameId)
+		if gameRole != gamesvc.RoleOrganizer && gameRole != gamesvc.RoleJury {
+			respondError(c, errs.ErrForbidden)
+			return
+		}
+	}
 
 	score, ok := int32PtrFromIntPtr(c, req.Score)
 	if !ok {
@@ -16,8 +16,9 @@ func (h *Handler) HandleGetGameScoreboard(c *gin.Context) {
 	}
 
 	viewerRole, _ := middleware.CurrentRole(c)
+	seesHidden := viewerRole == roleAdmin || h.viewerGameRole(c, gameID) != ""
 
-	sb, err := h.scoreboard.ForGame(c.Request.Context(), gameID, viewerRole)
+	sb, err := h.scoreboard.ForGame(c.Request.Context(), gameID, seesHidden)
 	if err != nil {
 		respondError(c, err)
 		return
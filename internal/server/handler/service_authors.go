@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	svcsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/services"
+)
+
+// serviceAccess is what the caller may do with the service of the current
+// request: admins manage every service, authors those the middleware granted.
+func serviceAccess(c *gin.Context) svcsvc.Access {
+	role, hasRole := middleware.CurrentRole(c)
+	return svcsvc.Access{
+		Admin:  hasRole && role == roleAdmin,
+		Author: middleware.HasResourceAccess(c),
+	}
+}
+
+func (h *Handler) HandleListServiceAuthors(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	authors, err := h.svcService.ListAuthors(c.Request.Context(), id, serviceAccess(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, authorListToHTTP(authors))
+}
+
+func (h *Handler) HandleAddServiceAuthor(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[httpserver.ServiceAuthorAddRequest](c)
+	if !ok {
+		return
+	}
+	role, _ := middleware.CurrentRole(c)
+	authors, err := h.svcService.AddAuthor(c.Request.Context(), id, req.UserId, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, authorListToHTTP(authors))
+}
+
+func (h *Handler) HandleRemoveServiceAuthor(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := parseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+	role, _ := middleware.CurrentRole(c)
+	if err := h.svcService.RemoveAuthor(c.Request.Context(), id, userID, role == roleAdmin); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func authorListToHTTP(authors []svcsvc.ServiceAuthor) httpserver.ServiceAuthorList {
+	items := make([]httpserver.ServiceAuthor, len(authors))
+	for i, a := range authors {
+		items[i] = httpserver.ServiceAuthor{
+			UserId:      a.UserID,
+			UserName:    a.UserName,
+			DisplayName: a.DisplayName,
+			AvatarUrl:   a.AvatarUrl,
+			CreatedAt:   a.CreatedAt,
+		}
+	}
+	return httpserver.ServiceAuthorList{Items: items}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	svcsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/services"
+)
+
+func (h *Handler) HandleCreateServiceDownloadLink(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[httpserver.ServiceDownloadLinkCreate](c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+
+	in := svcsvc.DownloadLinkCreate{Kind: string(req.Kind), MaxDownloads: req.MaxDownloads}
+	if req.ExpiresInSeconds != nil {
+		in.TTL = time.Duration(*req.ExpiresInSeconds) * time.Second
+	}
+	link, err := h.downloadLinks.Create(c.Request.Context(), id, in, userID, serviceAccess(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, downloadLinkToHTTP(link))
+}
+
+func (h *Handler) HandleDownloadServiceArchiveSigned(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	linkID, err1 := strconv.ParseInt(c.Query("link"), 10, 64)
+	expires, err2 := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err1 != nil || err2 != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{Code: codeValidationError, Message: "invalid link parameters"})
+		return
+	}
+
+	file, err := h.downloadLinks.Open(c.Request.Context(), id, c.Param("kind"), linkID, expires, c.Query("signature"), c.ClientIP())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer file.Close()
+
+	serveContent(c, file, file.Filename, "application/zip", file.SHA256, file.ModifiedAt)
+}
+
+func downloadLinkToHTTP(link *svcsvc.DownloadLink) httpserver.ServiceDownloadLink {
+	out := httpserver.ServiceDownloadLink{
+		Id:            link.ID,
+		ServiceId:     link.ServiceID,
+		Kind:          link.Kind,
+		Url:           signedDownloadURL(link),
+		ExpiresAt:     link.ExpiresAt,
+		DownloadCount: int(link.DownloadCount),
+		CreatedAt:     link.CreatedAt,
+	}
+	if link.MaxDownloads != nil {
+		n := int(*link.MaxDownloads)
+		out.MaxDownloads = &n
+	}
+	return out
+}
+
+func signedDownloadURL(link *svcsvc.DownloadLink) string {
+	return fmt.Sprintf("/api/v1/services/%d/download/%s/signed?link=%d&expires=%d&signature=%s",
+		link.ServiceID, link.Kind, link.ID, link.ExpiresAt.Unix(), link.Signature)
+}
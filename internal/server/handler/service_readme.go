@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+)
+
+// readmeAssetCacheSeconds is how long browsers may cache README images; a
+// version's archive never changes.
+const readmeAssetCacheSeconds = 86400
+
+func readmeAssetURL(serviceID, versionID int64, path string) string {
+	return fmt.Sprintf("/api/v1/services/%d/readme/assets?version=%d&path=%s", serviceID, versionID, url.QueryEscape(path))
+}
+
+func (h *Handler) HandleGetServiceReadme(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var versionID *int64
+	if raw := c.Query("version"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{Code: codeBadRequest, Message: "version must be a version id"})
+			return
+		}
+		versionID = &v
+	}
+
+	role, hasRole := middleware.CurrentRole(c)
+	isAdmin := hasRole && role == roleAdmin
+	readme, err := h.svcArchives.Readme(c.Request.Context(), id, versionID, isAdmin, readmeAssetURL)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, httpserver.ServiceReadme{
+		ServiceId: readme.ServiceID,
+		VersionId: readme.VersionID,
+		Markdown:  readme.Markdown,
+		Html:      readme.HTML,
+	})
+}
+
+func (h *Handler) HandleGetServiceReadmeAsset(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	versionID, err := strconv.ParseInt(c.Query("version"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{Code: codeBadRequest, Message: "version must be a version id"})
+		return
+	}
+
+	role, hasRole := middleware.CurrentRole(c)
+	isAdmin := hasRole && role == roleAdmin
+	asset, err := h.svcArchives.ReadmeAsset(c.Request.Context(), id, versionID, c.Query("path"), isAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// SVG images may carry scripts; the sandbox keeps them inert when the
+	// asset is opened directly.
+	c.Header("Content-Security-Policy", "sandbox; default-src 'none'; style-src 'unsafe-inline'")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, sanitizeFilename(asset.Name)))
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", readmeAssetCacheSeconds))
+	c.Data(http.StatusOK, asset.ContentType, asset.Data)
+}
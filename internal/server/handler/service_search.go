@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	svcsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/services"
+)
+
+func (h *Handler) HandleSearchServices(c *gin.Context) {
+	params := svcsvc.SearchParams{
+		Query:       c.Query("q"),
+		TechStack:   c.QueryArray("tech"),
+		License:     c.QueryArray("license"),
+		CheckStatus: c.QueryArray("check_status"),
+		GamesUsage:  c.QueryArray("games_usage"),
+	}
+	if v := c.Query("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			params.Page = p
+		}
+	}
+	if v := c.Query("per_page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			params.PerPage = p
+		}
+	}
+
+	role, hasRole := middleware.CurrentRole(c)
+	isAdmin := hasRole && role == roleAdmin
+	includeSource := hasRole && (role == roleAdmin || role == rolePlayer)
+
+	result, err := h.svcService.Search(c.Request.Context(), params, isAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]httpserver.ServiceSearchHit, len(result.Items))
+	for i, hit := range result.Items {
+		snippet := make([]httpserver.ServiceSnippetPart, len(hit.Snippet))
+		for j, part := range hit.Snippet {
+			snippet[j] = httpserver.ServiceSnippetPart{Text: part.Text, Match: part.Match}
+		}
+		items[i] = httpserver.ServiceSearchHit{
+			Service:    serviceToHTTP(hit.Service, includeSource),
+			Rank:       hit.Rank,
+			GamesCount: hit.GamesCount,
+			Snippet:    snippet,
+		}
+	}
+
+	c.JSON(http.StatusOK, httpserver.ServiceSearchResult{
+		Items: items,
+		Facets: httpserver.ServiceSearchFacets{
+			TechStack:   facetValuesToHTTP(result.Facets.TechStack),
+			License:     facetValuesToHTTP(result.Facets.License),
+			CheckStatus: facetValuesToHTTP(result.Facets.CheckStatus),
+			GamesUsage:  facetValuesToHTTP(result.Facets.GamesUsage),
+		},
+		Pagination: httpserver.Pagination{
+			Page:    result.Page,
+			PerPage: result.PerPage,
+			Total:   int(result.Total),
+		},
+	})
+}
+
+func facetValuesToHTTP(values []svcsvc.FacetValue) []httpserver.ServiceFacetValue {
+	out := make([]httpserver.ServiceFacetValue, len(values))
+	for i, v := range values {
+		out[i] = httpserver.ServiceFacetValue{Value: v.Value, Count: v.Count}
+	}
+	return out
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	svcsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/services"
+)
+
+func (h *Handler) HandleListServiceSubmissions(c *gin.Context) {
+	page := 1
+	perPage := 20
+	if v := c.Query("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if v := c.Query("per_page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			perPage = p
+		}
+	}
+	var status *string
+	if v := c.Query("status"); v != "" {
+		status = &v
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+	role, _ := middleware.CurrentRole(c)
+	result, err := h.submissions.List(c.Request.Context(), page, perPage, status, userID, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]httpserver.ServiceSubmission, len(result.Items))
+	for i, sub := range result.Items {
+		items[i] = submissionToHTTP(&sub)
+	}
+	c.JSON(http.StatusOK, httpserver.ServiceSubmissionList{
+		Items: items,
+		Pagination: httpserver.Pagination{
+			Page:    result.Page,
+			PerPage: result.PerPage,
+			Total:   int(result.Total),
+		},
+	})
+}
+
+func (h *Handler) HandleSubmitServiceFromGit(c *gin.Context) {
+	req, ok := bindJSON[httpserver.ServiceSubmissionGitRequest](c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+	sub, err := h.submissions.SubmitGit(c.Request.Context(), userID, submissionGitRequest(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, submissionToHTTP(sub))
+}
+
+func (h *Handler) HandleSubmitServiceFromZip(c *gin.Context) {
+	archive, ok := h.readArchiveForm(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+	sub, err := h.submissions.SubmitZip(c.Request.Context(), userID, archive)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, submissionToHTTP(sub))
+}
+
+func (h *Handler) HandleGetServiceSubmission(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+	role, _ := middleware.CurrentRole(c)
+	sub, err := h.submissions.Get(c.Request.Context(), id, userID, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, submissionToHTTP(sub))
+}
+
+func (h *Handler) HandleResubmitServiceFromGit(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[httpserver.ServiceSubmissionGitRequest](c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+	sub, err := h.submissions.ResubmitGit(c.Request.Context(), id, userID, submissionGitRequest(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, submissionToHTTP(sub))
+}
+
+func (h *Handler) HandleResubmitServiceFromZip(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	archive, ok := h.readArchiveForm(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+	sub, err := h.submissions.ResubmitZip(c.Request.Context(), id, userID, archive)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, submissionToHTTP(sub))
+}
+
+func (h *Handler) HandleReviewServiceSubmission(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[httpserver.ServiceSubmissionReview](c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+	role, _ := middleware.CurrentRole(c)
+	sub, err := h.submissions.Review(c.Request.Context(), id, userID, string(req.Decision), req.Comment, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, submissionToHTTP(sub))
+}
+
+func (h *Handler) HandleListServiceSubmissionComments(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+	role, _ := middleware.CurrentRole(c)
+	comments, err := h.submissions.ListComments(c.Request.Context(), id, userID, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	items := make([]httpserver.ServiceSubmissionComment, len(comments))
+	for i, comment := range comments {
+		items[i] = submissionCommentToHTTP(comment)
+	}
+	c.JSON(http.StatusOK, httpserver.ServiceSubmissionCommentList{Items: items})
+}
+
+func (h *Handler) HandleAddServiceSubmissionComment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[httpserver.ServiceSubmissionCommentCreate](c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.CurrentUserID(c)
+	role, _ := middleware.CurrentRole(c)
+	comment, err := h.submissions.AddComment(c.Request.Context(), id, userID, req.ParentId, req.Body, role == roleAdmin)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, submissionCommentToHTTP(*comment))
+}
+
+// readArchiveForm reads the "archive" file of a multipart upload within the
+// upload limit.
+func (h *Handler) readArchiveForm(c *gin.Context) ([]byte, bool) {
+	file, err := c.FormFile("archive")
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, errorResponse{Code: codeValidationError, Message: "archive file is required"})
+		return nil, false
+	}
+	f, err := file.Open()
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, h.maxUploadBytes+1))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if int64(len(data)) > h.maxUploadBytes {
+		c.JSON(http.StatusUnprocessableEntity, errorResponse{Code: codeValidationError, Message: "archive file too large"})
+		return nil, false
+	}
+	return data, true
+}
+
+func submissionGitRequest(req httpserver.ServiceSubmissionGitRequest) svcsvc.GitImportRequest {
+	result := svcsvc.GitImportRequest{RepoURL: req.RepoUrl}
+	if req.Ref != nil {
+		result.Ref = *req.Ref
+	}
+	if req.Subdir != nil {
+		result.Subdir = *req.Subdir
+	}
+	return result
+}
+
+func submissionToHTTP(sub *svcsvc.Submission) httpserver.ServiceSubmission {
+	result := httpserver.ServiceSubmission{
+		Id:          sub.ID,
+		SubmitterId: sub.SubmitterID,
+		Status:      httpserver.ServiceSubmissionStatus(sub.Status),
+		SourceKind:  httpserver.ServiceSubmissionSourceKind(sub.SourceKind),
+		RepoUrl:     sub.RepoURL,
+		Ref:         sub.Ref,
+		Subdir:      sub.Subdir,
+		Commit:      sub.Commit,
+		ServiceName: sub.ServiceName,
+		ServiceId:   sub.ServiceID,
+		ReviewerId:  sub.ReviewerID,
+		ReviewedAt:  sub.ReviewedAt,
+		CreatedAt:   &sub.CreatedAt,
+		UpdatedAt:   &sub.UpdatedAt,
+	}
+	if sub.Preview != nil {
+		preview := importPreviewToHTTP(sub.Preview)
+		result.Preview = &preview
+	}
+	return result
+}
+
+func submissionCommentToHTTP(comment svcsvc.SubmissionComment) httpserver.ServiceSubmissionComment {
+	return httpserver.ServiceSubmissionComment{
+		Id:             comment.ID,
+		SubmissionId:   comment.SubmissionID,
+		AuthorId:       comment.AuthorID,
+		AuthorUserName: comment.AuthorName,
+		ParentId:       comment.ParentID,
+		Body:           comment.Body,
+		CreatedAt:      comment.CreatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	svcsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/services"
+)
+
+func (h *Handler) HandleListServiceVulns(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	vulns, err := h.svcService.ListVulns(c.Request.Context(), id, serviceAccess(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, vulnListToHTTP(vulns))
+}
+
+func (h *Handler) HandleReplaceServiceVulns(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[httpserver.ServiceVulnsReplaceRequest](c)
+	if !ok {
+		return
+	}
+
+	inputs := make([]svcsvc.VulnInput, len(req.Items))
+	for i, item := range req.Items {
+		inputs[i] = svcsvc.VulnInput{
+			Title:      item.Title,
+			CWE:        item.Cwe,
+			Difficulty: (*string)(item.Difficulty),
+			Exploit:    item.Exploit,
+			Fix:        item.Fix,
+		}
+	}
+
+	vulns, err := h.svcService.ReplaceVulns(c.Request.Context(), id, inputs, serviceAccess(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, vulnListToHTTP(vulns))
+}
+
+func vulnListToHTTP(vulns []svcsvc.Vuln) httpserver.ServiceVulnList {
+	items := make([]httpserver.ServiceVuln, len(vulns))
+	for i, v := range vulns {
+		items[i] = httpserver.ServiceVuln{
+			Id:         v.ID,
+			ServiceId:  v.ServiceID,
+			Title:      v.Title,
+			Cwe:        v.CWE,
+			Difficulty: (*httpserver.ServiceVulnDifficulty)(v.Difficulty),
+			Exploit:    v.Exploit,
+			Fix:        v.Fix,
+		}
+	}
+	return httpserver.ServiceVulnList{Items: items}
+}
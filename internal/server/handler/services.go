@@ -25,6 +25,7 @@ func serviceToHTTP(s svcsvc.ServiceModel, includeSource bool) httpserver.Service
 		UpdatedAt:         &s.UpdatedAt,
 		Ports:             s.Ports,
 		TechStack:         s.TechStack,
+		License:           s.License,
 	}
 	if result.Ports == nil {
 		result.Ports = []int32{}
@@ -78,13 +79,14 @@ func serviceToHTTP(s svcsvc.ServiceModel, includeSource bool) httpserver.Service
 
 	if includeSource {
 		source := &httpserver.ServiceSource{
-			Kind:       httpserver.ServiceSourceKind(s.Source.Kind),
-			RepoUrl:    s.Source.RepoURL,
-			Ref:        s.Source.Ref,
-			Subdir:     s.Source.Subdir,
-			LastCommit: s.Source.LastCommit,
-			SyncStatus: httpserver.ServiceSourceSyncStatus(s.Source.SyncStatus),
-			SyncError:  s.Source.SyncError,
+			Kind:         httpserver.ServiceSourceKind(s.Source.Kind),
+			RepoUrl:      s.Source.RepoURL,
+			Ref:          s.Source.Ref,
+			Subdir:       s.Source.Subdir,
+			CredentialId: s.Source.CredentialID,
+			LastCommit:   s.Source.LastCommit,
+			SyncStatus:   httpserver.ServiceSourceSyncStatus(s.Source.SyncStatus),
+			SyncError:    s.Source.SyncError,
 		}
 		if s.Source.SyncedAt != nil {
 			source.SyncedAt = s.Source.SyncedAt
@@ -134,6 +136,7 @@ func importPreviewToHTTP(p *svcsvc.ImportPreview) httpserver.ServiceImportPrevie
 		CheckerDirectory:       optionalString(p.CheckerDirectory),
 		HasDevDirectory:        p.HasDevDirectory,
 		ExistingServiceId:      p.ExistingServiceID,
+		Subdir:                 optionalString(p.Subdir),
 		Requirements:           requirements,
 		Warnings:               warnings,
 	}
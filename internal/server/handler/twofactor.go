@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/server/middleware"
+	authsvc "github.com/ctf01d/ctf01d-training-platform/internal/service/auth"
+)
+
+// respondLogin answers a sign-in step: a session, or 202 with the challenge
+// of the second factor.
+func respondLogin(c *gin.Context, result *authsvc.LoginResult) {
+	if result.Challenge != nil {
+		c.JSON(http.StatusAccepted, challengeToHTTP(result.Challenge))
+		return
+	}
+	resp := httpserver.LoginResponse{
+		Token:        result.Token,
+		ExpiresAt:    result.TokenExpiresAt,
+		RefreshToken: result.RefreshToken,
+		User:         userToHTTPPrivate(*result.User),
+	}
+	if len(result.RecoveryCodes) > 0 {
+		resp.RecoveryCodes = &result.RecoveryCodes
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func challengeToHTTP(ch *authsvc.Challenge) httpserver.TwoFactorChallenge {
+	return httpserver.TwoFactorChallenge{
+		ChallengeToken:     ch.Token,
+		ExpiresAt:          ch.ExpiresAt,
+		EnrollmentRequired: ch.EnrollmentRequired,
+	}
+}
+
+func enrollmentToHTTP(e *authsvc.Enrollment) httpserver.TwoFactorEnrollment {
+	return httpserver.TwoFactorEnrollment{Secret: e.Secret, OtpauthUri: e.URL, QrCodePng: e.QRCodePNG}
+}
+
+func (h *Handler) CompleteTwoFactorLogin(c *gin.Context) {
+	req, ok := bindJSON[httpserver.TwoFactorLoginRequest](c)
+	if !ok {
+		return
+	}
+
+	result, err := h.auth.CompleteTwoFactor(c.Request.Context(), req.ChallengeToken, derefString(req.Code), derefString(req.RecoveryCode), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondLogin(c, result)
+}
+
+func (h *Handler) StartTwoFactorLoginEnrollment(c *gin.Context) {
+	req, ok := bindJSON[httpserver.TwoFactorChallengeToken](c)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.auth.EnrollWithChallenge(c.Request.Context(), req.ChallengeToken)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, enrollmentToHTTP(enrollment))
+}
+
+func (h *Handler) GetProfileTwoFactor(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+	role, _ := middleware.CurrentRole(c)
+
+	status, err := h.twoFactor.Status(c.Request.Context(), userID, role)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, httpserver.TwoFactorStatus{
+		Enabled:                status.Enabled,
+		EnabledAt:              status.EnabledAt,
+		Required:               status.Required,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+	})
+}
+
+func (h *Handler) StartProfileTwoFactorEnrollment(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+	userName, _ := middleware.CurrentUserName(c)
+
+	enrollment, err := h.twoFactor.BeginEnrollment(c.Request.Context(), userID, userName)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, enrollmentToHTTP(enrollment))
+}
+
+func (h *Handler) ConfirmProfileTwoFactor(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	req, ok := bindJSON[httpserver.TwoFactorCode](c)
+	if !ok {
+		return
+	}
+
+	codes, err := h.twoFactor.ConfirmEnrollment(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, httpserver.TwoFactorRecoveryCodes{RecoveryCodes: codes})
+}
+
+func (h *Handler) RegenerateProfileRecoveryCodes(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	req, ok := bindJSON[httpserver.TwoFactorCode](c)
+	if !ok {
+		return
+	}
+
+	codes, err := h.twoFactor.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, httpserver.TwoFactorRecoveryCodes{RecoveryCodes: codes})
+}
+
+func (h *Handler) DisableProfileTwoFactor(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+	role, _ := middleware.CurrentRole(c)
+
+	req, ok := bindJSON[httpserver.TwoFactorDisable](c)
+	if !ok {
+		return
+	}
+
+	if err := h.twoFactor.Disable(c.Request.Context(), userID, role, derefString(req.Code), derefString(req.RecoveryCode)); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ResetUserTwoFactor(c *gin.Context, id int64) {
+	actorID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	if _, err := h.users.GetByID(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := h.twoFactor.Reset(c.Request.Context(), id, actorID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) GetTwoFactorPolicy(c *gin.Context) {
+	roles, err := h.twoFactor.RequiredRoles(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, policyToHTTP(roles))
+}
+
+func (h *Handler) UpdateTwoFactorPolicy(c *gin.Context) {
+	actorID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, errorResponse{Code: codeUnauthorized, Message: msgNotAuthenticated})
+		return
+	}
+
+	req, ok := bindJSON[httpserver.TwoFactorPolicy](c)
+	if !ok {
+		return
+	}
+
+	roles := make([]string, len(req.RequiredRoles))
+	for i, role := range req.RequiredRoles {
+		roles[i] = string(role)
+	}
+	updated, err := h.twoFactor.SetRequiredRoles(c.Request.Context(), roles, actorID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, policyToHTTP(updated))
+}
+
+func policyToHTTP(roles []string) httpserver.TwoFactorPolicy {
+	out := make([]httpserver.TwoFactorPolicyRequiredRoles, len(roles))
+	for i, role := range roles {
+		out[i] = httpserver.TwoFactorPolicyRequiredRoles(role)
+	}
+	return httpserver.TwoFactorPolicy{RequiredRoles: out}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
@@ -181,7 +180,14 @@ func (h *Handler) HandleGetUserAvatar(c *gin.Context) {
 		return
 	}
 
-	rc, err := h.fileStorage.Open(c.Request.Context(), userAvatarKey(id))
+	user, err := h.users.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	key := userAvatarKey(id)
+	rc, err := h.fileStorage.Open(c.Request.Context(), key)
 	if err != nil {
 		if errors.Is(err, storage.ErrFileNotFound) {
 			c.JSON(http.StatusNotFound, errorResponse{Code: codeNotFound, Message: "avatar not found"})
@@ -191,13 +197,14 @@ func (h *Handler) HandleGetUserAvatar(c *gin.Context) {
 		return
 	}
 	defer rc.Close()
+	info, err := h.fileStorage.Stat(c.Request.Context(), key)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
 
-	c.Header("Content-Type", "image/png")
 	c.Header("Cache-Control", "private, max-age=60")
-	c.Status(http.StatusOK)
-	if _, err := io.Copy(c.Writer, rc); err != nil {
-		_ = c.Error(fmt.Errorf("copy avatar: %w", err))
-	}
+	serveContent(c, rc, "", "image/png", info.SHA256, user.UpdatedAt)
 }
 
 func (h *Handler) HandleListUserSessions(c *gin.Context) {
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/ratelimit"
 )
 
 type contextKey string
@@ -15,11 +17,60 @@ type SessionChecker interface {
 	ValidateAndTouch(ctx context.Context, jti, ipAddress string) bool
 }
 
+// APIToken is the caller behind a personal API token.
+type APIToken struct {
+	UserID   int64
+	UserName string
+	Role     string
+	Scopes   []string
+}
+
+// APITokenAuthenticator resolves personal API tokens. It is satisfied by an
+// adapter over the auth service; a nil authenticator rejects API tokens.
+type APITokenAuthenticator interface {
+	AuthenticateAPIToken(ctx context.Context, token, ipAddress string) (APIToken, bool)
+}
+
+// ResourcePermissionChecker answers whether a user holds a resource-level
+// permission declared in OpenAPI via x-resource-permission.
+type ResourcePermissionChecker interface {
+	HasResourcePermission(ctx context.Context, permission string, userID, resourceID int64) (bool, error)
+}
+
+// RateLimiter takes tokens from the buckets behind x-rate-limit. It is
+// satisfied by a ratelimit.Store; a nil limiter disables the limits.
+type RateLimiter interface {
+	Take(ctx context.Context, key string, rate ratelimit.Rate) (ratelimit.Decision, error)
+}
+
+// Resource-level permissions understood by x-resource-permission.
+const (
+	// PermissionServiceAuthor is held by the authors of the service named by {id}.
+	PermissionServiceAuthor = "service_author"
+	// PermissionGameOrganizer is held by the organizers of the game named by {id}.
+	PermissionGameOrganizer = "game_organizer"
+	// PermissionGameTeamOrganizer is held by the organizers of the game the
+	// game team named by {id} plays in.
+	PermissionGameTeamOrganizer = "game_team_organizer"
+	// PermissionResultJury is held by the organizers and the jury of the game
+	// the result named by {id} belongs to.
+	PermissionResultJury = "result_jury"
+)
+
+var resourcePermissions = map[string]bool{
+	PermissionServiceAuthor:     true,
+	PermissionGameOrganizer:     true,
+	PermissionGameTeamOrganizer: true,
+	PermissionResultJury:        true,
+}
+
 const (
-	userIDKey   contextKey = "user_id"
-	roleKey     contextKey = "role"
-	userNameKey contextKey = "user_name"
-	sessionKey  contextKey = "session_jti"
+	userIDKey         contextKey = "user_id"
+	roleKey           contextKey = "role"
+	userNameKey       contextKey = "user_name"
+	sessionKey        contextKey = "session_jti"
+	resourceAccessKey contextKey = "resource_access"
+	tokenScopesKey    contextKey = "token_scopes"
 
 	roleGuestLevel  = 0
 	rolePlayerLevel = 1
@@ -33,6 +84,7 @@ const (
 	jsonKeyMessage      = "message"
 	errCodeUnauthorized = "unauthorized"
 	errCodeForbidden    = "forbidden"
+	errCodeRateLimited  = "too_many_requests"
 )
 
 func abortWithJSON(c *gin.Context, status int, code, msg string) {
@@ -56,6 +108,12 @@ func CurrentRole(c *gin.Context) (string, bool) {
 	return role.(string), true
 }
 
+// HasResourceAccess reports whether OpenAPIRole granted the caller the
+// operation's resource-level permission.
+func HasResourceAccess(c *gin.Context) bool {
+	return c.GetBool(string(resourceAccessKey))
+}
+
 func CurrentUserName(c *gin.Context) (string, bool) {
 	name, exists := c.Get(string(userNameKey))
 	if !exists {
@@ -73,6 +131,16 @@ func CurrentSessionJTI(c *gin.Context) (string, bool) {
 	return jti.(string), true
 }
 
+// CurrentTokenScopes returns the scopes of the caller's API token. It reports
+// false for callers signed in with a session.
+func CurrentTokenScopes(c *gin.Context) ([]string, bool) {
+	scopes, exists := c.Get(string(tokenScopesKey))
+	if !exists {
+		return nil, false
+	}
+	return scopes.([]string), true
+}
+
 var roleLevel = map[string]int{
 	roleGuest:  roleGuestLevel,
 	rolePlayer: rolePlayerLevel,
@@ -1,15 +1,21 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 
 	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/ratelimit"
 )
 
 type authResponse struct {
@@ -66,7 +72,7 @@ func readAuthResponse(t *testing.T, w *httptest.ResponseRecorder) authResponse {
 
 func TestOpenAPIAuth_RequiredBearerMissingToken(t *testing.T) {
 	mgr := auth.NewManager("test-secret", 24)
-	r := setupOpenAPIRouter(mgr, http.MethodGet, "/test", true, OpenAPIAuth(mgr, nil))
+	r := setupOpenAPIRouter(mgr, http.MethodGet, "/test", true, OpenAPIAuth(mgr, nil, nil))
 
 	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
 	w := httptest.NewRecorder()
@@ -79,7 +85,7 @@ func TestOpenAPIAuth_RequiredBearerMissingToken(t *testing.T) {
 
 func TestOpenAPIAuth_PublicRouteWithoutToken(t *testing.T) {
 	mgr := auth.NewManager("test-secret", 24)
-	r := setupOpenAPIRouter(mgr, http.MethodGet, "/test", false, OpenAPIAuth(mgr, nil))
+	r := setupOpenAPIRouter(mgr, http.MethodGet, "/test", false, OpenAPIAuth(mgr, nil, nil))
 
 	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
 	w := httptest.NewRecorder()
@@ -96,7 +102,7 @@ func TestOpenAPIAuth_PublicRouteWithoutToken(t *testing.T) {
 
 func TestOpenAPIAuth_ValidToken(t *testing.T) {
 	mgr := auth.NewManager("test-secret", 24)
-	r := setupOpenAPIRouter(mgr, http.MethodGet, "/test", true, OpenAPIAuth(mgr, nil))
+	r := setupOpenAPIRouter(mgr, http.MethodGet, "/test", true, OpenAPIAuth(mgr, nil, nil))
 
 	token := makeToken(t, mgr, 42, "player", "alice")
 	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
@@ -113,12 +119,40 @@ func TestOpenAPIAuth_ValidToken(t *testing.T) {
 	}
 }
 
+func TestOpenAPIAudit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mgr := auth.NewManager("test-secret", 24)
+
+	var actor audit.Actor
+	var hasActor bool
+	r := gin.New()
+	r.Use(requestid.New())
+	r.GET("/test", func(c *gin.Context) {
+		c.Set(string(httpserver.BearerAuthScopes), []string{})
+		OpenAPIAuth(mgr, nil, nil)(c)
+		OpenAPIAudit()(c)
+		actor, hasActor = audit.ActorFrom(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+makeToken(t, mgr, 42, "admin", "root"))
+	req.Header.Set("X-Request-ID", "req-42")
+	req.RemoteAddr = "192.0.2.7:4000"
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	want := audit.Actor{UserID: 42, UserName: "root", IP: "192.0.2.7", RequestID: "req-42"}
+	if !hasActor || actor != want {
+		t.Fatalf("actor = %+v (%v), want %+v", actor, hasActor, want)
+	}
+}
+
 func TestOpenAPIAuth_ExpiredToken(t *testing.T) {
 	expiredMgr := auth.NewManager("test-secret", -1)
 	token := makeToken(t, expiredMgr, 1, "player", "alice")
 
 	validMgr := auth.NewManager("test-secret", 24)
-	r := setupOpenAPIRouter(validMgr, http.MethodGet, "/test", true, OpenAPIAuth(validMgr, nil))
+	r := setupOpenAPIRouter(validMgr, http.MethodGet, "/test", true, OpenAPIAuth(validMgr, nil, nil))
 
 	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -137,8 +171,8 @@ func TestOpenAPIRole_InsufficientRole(t *testing.T) {
 		http.MethodPatch,
 		"/users/:id/role",
 		true,
-		OpenAPIAuth(mgr, nil),
-		OpenAPIRole(),
+		OpenAPIAuth(mgr, nil, nil),
+		OpenAPIRole(nil),
 	)
 
 	token := makeToken(t, mgr, 1, "player", "bob")
@@ -159,8 +193,8 @@ func TestOpenAPIRole_SufficientRole(t *testing.T) {
 		http.MethodPatch,
 		"/users/:id/role",
 		true,
-		OpenAPIAuth(mgr, nil),
-		OpenAPIRole(),
+		OpenAPIAuth(mgr, nil, nil),
+		OpenAPIRole(nil),
 	)
 
 	token := makeToken(t, mgr, 1, "admin", "admin")
@@ -174,6 +208,210 @@ func TestOpenAPIRole_SufficientRole(t *testing.T) {
 	}
 }
 
+type authorChecker map[int64]int64
+
+func (a authorChecker) HasResourcePermission(_ context.Context, permission string, userID, resourceID int64) (bool, error) {
+	return permission == PermissionServiceAuthor && a[resourceID] == userID, nil
+}
+
+func TestOpenAPIRole_ResourcePermission(t *testing.T) {
+	mgr := auth.NewManager("test-secret", 24)
+	checker := authorChecker{5: 1}
+	r := setupOpenAPIRouter(
+		mgr,
+		http.MethodPatch,
+		"/services/:id",
+		true,
+		OpenAPIAuth(mgr, nil, nil),
+		OpenAPIRole(checker),
+		func(c *gin.Context) {
+			c.Header("X-Resource-Access", strconv.FormatBool(HasResourceAccess(c)))
+		},
+	)
+
+	tests := []struct {
+		name   string
+		userID int64
+		path   string
+		want   int
+		access string
+	}{
+		{"author", 1, "/services/5", http.StatusOK, "true"},
+		{"other service", 1, "/services/6", http.StatusForbidden, ""},
+		{"not an author", 2, "/services/5", http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := makeToken(t, mgr, tt.userID, "player", "bob")
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodPatch, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("X-Resource-Access"); got != tt.access {
+				t.Errorf("resource access = %q, want %q", got, tt.access)
+			}
+		})
+	}
+}
+
+type fakeAPITokens map[string]APIToken
+
+func (f fakeAPITokens) AuthenticateAPIToken(_ context.Context, token, _ string) (APIToken, bool) {
+	t, ok := f[token]
+	return t, ok
+}
+
+const (
+	testResultsToken = auth.APITokenPrefix + "results"
+	testSyncToken    = auth.APITokenPrefix + "sync"
+)
+
+var testAPITokens = fakeAPITokens{
+	testResultsToken: {UserID: 7, UserName: "ci", Role: "admin", Scopes: []string{auth.ScopeResultsWrite}},
+	testSyncToken:    {UserID: 7, UserName: "ci", Role: "admin", Scopes: []string{auth.ScopeServicesSync}},
+}
+
+func TestOpenAPIAuth_APIToken(t *testing.T) {
+	mgr := auth.NewManager("test-secret", 24)
+	r := setupOpenAPIRouter(mgr, http.MethodGet, "/test", true, OpenAPIAuth(mgr, nil, testAPITokens))
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"known token", testResultsToken, http.StatusOK},
+		{"unknown token", auth.APITokenPrefix + "revoked", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.want == http.StatusOK {
+				body := readAuthResponse(t, w)
+				if !body.HasUser || body.UserID != 7 || body.Role != "admin" {
+					t.Fatalf("unexpected user context: %+v", body)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPIAuth_APITokenWithoutAuthenticator(t *testing.T) {
+	mgr := auth.NewManager("test-secret", 24)
+	r := setupOpenAPIRouter(mgr, http.MethodGet, "/test", true, OpenAPIAuth(mgr, nil, nil))
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+testResultsToken)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestOpenAPIScope(t *testing.T) {
+	mgr := auth.NewManager("test-secret", 24)
+	middlewares := []httpserver.MiddlewareFunc{OpenAPIAuth(mgr, nil, testAPITokens), OpenAPIScope(), OpenAPIRole(nil)}
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		secured bool
+		token   string
+		want    int
+		hasUser bool
+	}{
+		{"token with the scope", http.MethodPost, "/results", true, testResultsToken, http.StatusOK, true},
+		{"token without the scope", http.MethodPost, "/results", true, testSyncToken, http.StatusForbidden, false},
+		{"operation without a scope", http.MethodPatch, "/users/:id/role", true, testResultsToken, http.StatusForbidden, false},
+		{"public operation is anonymous", http.MethodGet, "/games", false, testResultsToken, http.StatusOK, false},
+		{"session is not limited", http.MethodPatch, "/users/:id/role", true, makeToken(t, mgr, 1, "admin", "admin"), http.StatusOK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupOpenAPIRouter(mgr, tt.method, tt.path, tt.secured, middlewares...)
+			req := httptest.NewRequestWithContext(t.Context(), tt.method, strings.ReplaceAll(tt.path, ":id", "1"), nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.want == http.StatusOK {
+				if body := readAuthResponse(t, w); body.HasUser != tt.hasUser {
+					t.Fatalf("has user = %v, want %v", body.HasUser, tt.hasUser)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPIRateLimit(t *testing.T) {
+	mgr := auth.NewManager("test-secret", 24)
+	limiter := ratelimit.NewMemoryStore()
+	limited := setupOpenAPIRouter(mgr, http.MethodPost, "/auth/password-reset", false, OpenAPIAuth(mgr, nil, nil), OpenAPIRateLimit(limiter))
+	unlimited := setupOpenAPIRouter(mgr, http.MethodGet, "/games", false, OpenAPIAuth(mgr, nil, nil), OpenAPIRateLimit(limiter))
+
+	send := func(r *gin.Engine, method, path, addr, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(t.Context(), method, path, nil)
+		req.RemoteAddr = addr
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// POST /auth/password-reset allows 5 requests per 15 minutes.
+	for i := range 5 {
+		if w := send(limited, http.MethodPost, "/auth/password-reset", "192.0.2.1:1000", ""); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+	w := send(limited, http.MethodPost, "/auth/password-reset", "192.0.2.1:1000", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("over the limit: expected 429, got %d", w.Code)
+	}
+	if retry, err := strconv.Atoi(w.Header().Get("Retry-After")); err != nil || retry < 1 || retry > 180 {
+		t.Fatalf("Retry-After = %q, want the seconds until one request refills", w.Header().Get("Retry-After"))
+	}
+
+	if w := send(limited, http.MethodPost, "/auth/password-reset", "192.0.2.2:1000", ""); w.Code != http.StatusOK {
+		t.Fatalf("other address: expected 200, got %d", w.Code)
+	}
+	token := makeToken(t, mgr, 1, "player", "alice")
+	if w := send(limited, http.MethodPost, "/auth/password-reset", "192.0.2.1:1000", token); w.Code != http.StatusOK {
+		t.Fatalf("signed-in user has a bucket of their own: expected 200, got %d", w.Code)
+	}
+	for range 10 {
+		if w := send(unlimited, http.MethodGet, "/games", "192.0.2.1:1000", ""); w.Code != http.StatusOK {
+			t.Fatalf("operation without x-rate-limit: expected 200, got %d", w.Code)
+		}
+	}
+
+	disabled := setupOpenAPIRouter(mgr, http.MethodPost, "/auth/password-reset", false, OpenAPIRateLimit(nil))
+	for range 10 {
+		if w := send(disabled, http.MethodPost, "/auth/password-reset", "192.0.2.1:1000", ""); w.Code != http.StatusOK {
+			t.Fatalf("nil limiter: expected 200, got %d", w.Code)
+		}
+	}
+}
+
 func TestRoleHierarchy(t *testing.T) {
 	tests := []struct {
 		current  string
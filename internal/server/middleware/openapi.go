@@ -2,19 +2,28 @@ package middleware
 
 import (
 	"fmt"
+	"log/slog"
+	"math"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 
 	"github.com/ctf01d/ctf01d-training-platform/gen/httpserver"
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/ratelimit"
 )
 
 // OpenAPIAuth enforces BearerAuth only when the generated wrapper marks the
 // operation as secured. For public operations it still accepts a valid token so
-// handlers can render privileged fields for authenticated viewers.
-func OpenAPIAuth(jwtMgr *auth.Manager, sessions SessionChecker) httpserver.MiddlewareFunc {
+// handlers can render privileged fields for authenticated viewers. The bearer
+// token is either a session JWT or a personal API token; OpenAPIScope limits
+// what the latter can reach.
+func OpenAPIAuth(jwtMgr *auth.Manager, sessions SessionChecker, tokens APITokenAuthenticator) httpserver.MiddlewareFunc {
 	return func(c *gin.Context) {
 		requiresAuth := openAPIRequiresBearer(c)
 		header := c.GetHeader("Authorization")
@@ -25,6 +34,25 @@ func OpenAPIAuth(jwtMgr *auth.Manager, sessions SessionChecker) httpserver.Middl
 			return
 		}
 
+		if tokenStr := strings.TrimPrefix(header, "Bearer "); auth.IsAPIToken(tokenStr) {
+			var token APIToken
+			ok := false
+			if tokens != nil {
+				token, ok = tokens.AuthenticateAPIToken(c.Request.Context(), tokenStr, c.ClientIP())
+			}
+			if !ok {
+				if requiresAuth {
+					abortWithJSON(c, http.StatusUnauthorized, errCodeUnauthorized, "API token expired or revoked")
+				}
+				return
+			}
+			c.Set(string(userIDKey), token.UserID)
+			c.Set(string(roleKey), token.Role)
+			c.Set(string(userNameKey), token.UserName)
+			c.Set(string(tokenScopesKey), token.Scopes)
+			return
+		}
+
 		if jwtMgr == nil {
 			if requiresAuth {
 				abortWithJSON(c, http.StatusUnauthorized, errCodeUnauthorized, "invalid token")
@@ -69,13 +97,100 @@ func OpenAPIAuth(jwtMgr *auth.Manager, sessions SessionChecker) httpserver.Middl
 	}
 }
 
+// OpenAPIAudit puts the caller, their address and the request id into the
+// request context, so the audit events services write are attributed to them.
+func OpenAPIAudit() httpserver.MiddlewareFunc {
+	return func(c *gin.Context) {
+		actor := audit.Actor{IP: c.ClientIP(), RequestID: requestid.Get(c)}
+		actor.UserID, _ = CurrentUserID(c)
+		actor.UserName, _ = CurrentUserName(c)
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), actor))
+	}
+}
+
+// OpenAPIRateLimit enforces the per-caller limits declared in OpenAPI via
+// x-rate-limit. Every caller has a token bucket per operation: signed-in users
+// by id, anonymous ones by client address. A refused request gets 429 with
+// Retry-After. Should the limiter fail, the request goes through.
+func OpenAPIRateLimit(limiter RateLimiter) httpserver.MiddlewareFunc {
+	rates := parseRateLimits(httpserver.OperationRateLimits)
+
+	return func(c *gin.Context) {
+		if limiter == nil {
+			return
+		}
+		operation := openAPIOperationKey(c.Request.Method, normalizedOpenAPIPath(c.FullPath()))
+		rate, ok := rates[operation]
+		if !ok {
+			return
+		}
+
+		key := "op:" + operation + ":ip:" + c.ClientIP()
+		if userID, ok := CurrentUserID(c); ok {
+			key = "op:" + operation + ":user:" + strconv.FormatInt(userID, 10)
+		}
+		decision, err := limiter.Take(c.Request.Context(), key, rate)
+		if err != nil {
+			slog.Warn("rate limiter unavailable", "operation", operation, "error", err)
+			return
+		}
+		if !decision.Allowed {
+			c.Header("Retry-After", strconv.Itoa(max(int(math.Ceil(decision.RetryAfter.Seconds())), 1)))
+			abortWithJSON(c, http.StatusTooManyRequests, errCodeRateLimited, "rate limit exceeded, retry later")
+		}
+	}
+}
+
+// OpenAPIScope confines callers signed in with a personal API token to the
+// operations declared with one of the token's scopes via x-token-scope. A
+// secured operation outside the scopes is refused; a public one is served as
+// to an anonymous caller. Session callers are not affected.
+func OpenAPIScope() httpserver.MiddlewareFunc {
+	validateTokenScopes(httpserver.OperationTokenScopes)
+
+	return func(c *gin.Context) {
+		scopes, ok := CurrentTokenScopes(c)
+		if !ok {
+			return
+		}
+		operation := openAPIOperationKey(c.Request.Method, normalizedOpenAPIPath(c.FullPath()))
+		required := httpserver.OperationTokenScopes[operation]
+		if required != "" && slices.Contains(scopes, required) {
+			return
+		}
+		if openAPIRequiresBearer(c) {
+			abortWithJSON(c, http.StatusForbidden, errCodeForbidden, "API token scope does not allow this operation")
+			return
+		}
+		for _, key := range []contextKey{userIDKey, roleKey, userNameKey, tokenScopesKey} {
+			c.Delete(string(key))
+		}
+	}
+}
+
 // OpenAPIRole enforces the role gates declared in OpenAPI via x-required-role.
-func OpenAPIRole() httpserver.MiddlewareFunc {
+// Operations that also declare x-resource-permission are open to callers that
+// hold the permission on the {id} resource, whatever their role; handlers read
+// the outcome through HasResourceAccess. A nil checker grants nothing.
+func OpenAPIRole(permissions ResourcePermissionChecker) httpserver.MiddlewareFunc {
 	validateOpenAPIRoles(httpserver.OperationRequiredRoles)
+	validateResourcePermissions(httpserver.OperationResourcePermissions)
 
 	return func(c *gin.Context) {
-		requiredRole := requiredOpenAPIRole(c.Request.Method, normalizedOpenAPIPath(c.FullPath()))
-		if requiredRole == "" {
+		operation := openAPIOperationKey(c.Request.Method, normalizedOpenAPIPath(c.FullPath()))
+
+		granted, err := resolveResourcePermission(c, permissions, httpserver.OperationResourcePermissions[operation])
+		if err != nil {
+			_ = c.Error(err)
+			abortWithJSON(c, http.StatusInternalServerError, errCodeInternal, "internal server error")
+			return
+		}
+		if granted {
+			c.Set(string(resourceAccessKey), true)
+		}
+
+		requiredRole := httpserver.OperationRequiredRoles[operation]
+		if requiredRole == "" || granted {
 			return
 		}
 
@@ -97,6 +212,32 @@ func OpenAPIRole() httpserver.MiddlewareFunc {
 	}
 }
 
+// resolveResourcePermission checks the permission of the authenticated user on
+// the resource named by the id path parameter. Admins are not looked up: they
+// pass every role gate anyway.
+func resolveResourcePermission(c *gin.Context, checker ResourcePermissionChecker, permission string) (bool, error) {
+	if permission == "" || checker == nil {
+		return false, nil
+	}
+	userID, ok := CurrentUserID(c)
+	if !ok {
+		return false, nil
+	}
+	if role, _ := CurrentRole(c); role == roleAdmin {
+		return false, nil
+	}
+	resourceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		// The handler reports the malformed id.
+		return false, nil
+	}
+	granted, err := checker.HasResourcePermission(c.Request.Context(), permission, userID, resourceID)
+	if err != nil {
+		return false, fmt.Errorf("checking %s permission: %w", permission, err)
+	}
+	return granted, nil
+}
+
 func OpenAPIErrorHandler(c *gin.Context, err error, statusCode int) {
 	c.JSON(statusCode, gin.H{jsonKeyCode: errCodeBadRequest, jsonKeyMessage: err.Error()})
 }
@@ -113,8 +254,8 @@ func normalizedOpenAPIPath(path string) string {
 	return strings.TrimPrefix(path, "/api/v1")
 }
 
-func requiredOpenAPIRole(method, path string) string {
-	return httpserver.OperationRequiredRoles[method+" "+openAPIPathFromGin(path)]
+func openAPIOperationKey(method, path string) string {
+	return method + " " + openAPIPathFromGin(path)
 }
 
 func openAPIPathFromGin(path string) string {
@@ -135,4 +276,35 @@ func validateOpenAPIRoles(roles map[string]string) {
 	}
 }
 
-const errCodeBadRequest = "bad_request"
+func validateTokenScopes(scopes map[string]string) {
+	for operation, scope := range scopes {
+		if !auth.ValidAPITokenScope(scope) {
+			panic(fmt.Sprintf("invalid OpenAPI token scope %q for %s", scope, operation))
+		}
+	}
+}
+
+func parseRateLimits(limits map[string]string) map[string]ratelimit.Rate {
+	rates := make(map[string]ratelimit.Rate, len(limits))
+	for operation, limit := range limits {
+		rate, err := ratelimit.ParseRate(limit)
+		if err != nil {
+			panic(fmt.Sprintf("invalid OpenAPI rate limit for %s: %v", operation, err))
+		}
+		rates[operation] = rate
+	}
+	return rates
+}
+
+func validateResourcePermissions(permissions map[string]string) {
+	for operation, permission := range permissions {
+		if _, ok := resourcePermissions[permission]; !ok {
+			panic(fmt.Sprintf("invalid OpenAPI resource permission %q for %s", permission, operation))
+		}
+	}
+}
+
+const (
+	errCodeBadRequest = "bad_request"
+	errCodeInternal   = "internal_error"
+)
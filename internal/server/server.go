@@ -22,13 +22,21 @@ type Store interface {
 
 const corsMaxAge = 12 * time.Hour
 
-func New(cfg *config.Config, log *zap.Logger, store Store, h *handler.Handler) *gin.Engine {
+// New builds the HTTP engine. limiter backs the x-rate-limit limits; nil
+// turns them off.
+func New(cfg *config.Config, log *zap.Logger, store Store, h *handler.Handler, limiter middleware.RateLimiter) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	if cfg.Env == "development" {
 		gin.SetMode(gin.DebugMode)
 	}
 
 	engine := gin.New()
+	// Validated by config.Load. gin trusts every sender by default; with no
+	// proxies configured it trusts none and takes the client address from
+	// the connection.
+	if err := engine.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		log.Error("setting trusted proxies", zap.Error(err))
+	}
 
 	engine.Use(gin.Recovery())
 	engine.Use(requestid.New())
@@ -72,8 +80,11 @@ func New(cfg *config.Config, log *zap.Logger, store Store, h *handler.Handler) *
 	httpserver.RegisterHandlersWithOptions(engine, h, httpserver.GinServerOptions{
 		BaseURL: "/api/v1",
 		Middlewares: []httpserver.MiddlewareFunc{
-			middleware.OpenAPIAuth(h.JWTMgr(), h.SessionChecker()),
-			middleware.OpenAPIRole(),
+			middleware.OpenAPIAuth(h.JWTMgr(), h.SessionChecker(), h.APITokens()),
+			middleware.OpenAPIAudit(),
+			middleware.OpenAPIRateLimit(limiter),
+			middleware.OpenAPIScope(),
+			middleware.OpenAPIRole(h.ResourcePermissions()),
 		},
 		ErrorHandler: middleware.OpenAPIErrorHandler,
 	})
@@ -23,21 +23,24 @@ func (m *mockStore) Ping() error {
 }
 
 func newTestEngine(store Store) *gin.Engine {
-	cfg := &config.Config{
+	return newTestEngineWithConfig(store, &config.Config{
 		Env: "development",
 		CORS: config.CORSConfig{
 			AllowedOrigins: "http://localhost:5173",
 		},
-	}
+	})
+}
+
+func newTestEngineWithConfig(store Store, cfg *config.Config) *gin.Engine {
 	log, _ := zap.NewDevelopment()
 	jwtMgr := auth.NewManager("test-secret", 24)
 	h := handler.New(
-		nil, nil, jwtMgr,
+		nil, nil, nil, nil, nil, nil, jwtMgr,
 		nil, nil, nil, nil, nil, nil, nil, nil,
-		nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		209715200, "./storage", nil,
 	)
-	return New(cfg, log, store, h)
+	return New(cfg, log, store, h, nil)
 }
 
 func TestHealthz_OK(t *testing.T) {
@@ -75,3 +78,27 @@ func TestVersion(t *testing.T) {
 		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
 	}
 }
+
+func TestClientIP_TrustedProxies(t *testing.T) {
+	clientIP := func(proxies []string) string {
+		engine := newTestEngineWithConfig(&mockStore{}, &config.Config{
+			HTTP: config.HTTPConfig{TrustedProxies: proxies},
+			CORS: config.CORSConfig{AllowedOrigins: "http://localhost:5173"},
+		})
+		engine.GET("/test-client-ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/test-client-ip", nil)
+		req.RemoteAddr = "192.0.2.10:4321"
+		req.Header.Set("X-Forwarded-For", "198.51.100.7")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	if got := clientIP(nil); got != "192.0.2.10" {
+		t.Errorf("no trusted proxies: client IP = %q, want the connection address", got)
+	}
+	if got := clientIP([]string{"192.0.2.0/24"}); got != "198.51.100.7" {
+		t.Errorf("trusted proxy: client IP = %q, want the forwarded address", got)
+	}
+}
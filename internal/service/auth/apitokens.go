@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+// maxAPITokensPerUser bounds how many API tokens one user can hold.
+const maxAPITokensPerUser = 20
+
+// maxAPITokenNameLength bounds the label of a token.
+const maxAPITokenNameLength = 100
+
+const (
+	fieldName      = "name"
+	fieldScopes    = "scopes"
+	fieldExpiresAt = "expires_at"
+)
+
+var errTooManyAPITokens = fmt.Errorf("%w: at most %d API tokens per user, revoke one first", errs.ErrConflict, maxAPITokensPerUser)
+
+type APITokenStore interface {
+	CreateAPIToken(ctx context.Context, arg db.CreateAPITokenParams) (db.UserApiToken, error)
+	ListAPITokensByUser(ctx context.Context, userID int64) ([]db.UserApiToken, error)
+	CountAPITokensByUser(ctx context.Context, userID int64) (int64, error)
+	GetAPITokenForAuth(ctx context.Context, tokenHash string) (db.GetAPITokenForAuthRow, error)
+	TouchAPIToken(ctx context.Context, arg db.TouchAPITokenParams) error
+	DeleteAPIToken(ctx context.Context, arg db.DeleteAPITokenParams) (int64, error)
+}
+
+// APITokenService manages personal API tokens: bearer tokens that act as
+// their owner for automation, limited to the scopes they were created with.
+type APITokenService struct {
+	store APITokenStore
+	now   func() time.Time
+}
+
+func NewAPITokenService(store APITokenStore) *APITokenService {
+	return &APITokenService{store: store, now: time.Now}
+}
+
+// APIToken is the owner's view of a token; the token itself is shown only
+// once, when it is created.
+type APIToken struct {
+	ID         int64
+	Name       string
+	Hint       string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	LastUsedIP *string
+	CreatedAt  time.Time
+}
+
+// APITokenIdentity is the caller behind a valid API token. Role is the
+// owner's current role, so a demoted or blocked owner takes their tokens down
+// with them.
+type APITokenIdentity struct {
+	UserID   int64
+	UserName string
+	Role     string
+	Scopes   []string
+}
+
+// Create issues a token for the user and returns it in clear together with
+// its stored view. A nil expiresAt makes a token that lasts until revoked.
+func (s *APITokenService) Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (string, *APIToken, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", nil, errs.NewValidationError(map[string]string{fieldName: "is required"})
+	case len(name) > maxAPITokenNameLength:
+		return "", nil, errs.NewValidationError(map[string]string{fieldName: fmt.Sprintf("must be at most %d characters", maxAPITokenNameLength)})
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return "", nil, errs.NewValidationError(map[string]string{fieldExpiresAt: "must be in the future"})
+	}
+
+	count, err := s.store.CountAPITokensByUser(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	if count >= maxAPITokensPerUser {
+		return "", nil, errTooManyAPITokens
+	}
+
+	token, err := auth.NewAPIToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("generating API token: %w", err)
+	}
+	params := db.CreateAPITokenParams{
+		UserID:      userID,
+		Name:        name,
+		TokenHash:   auth.HashToken(token),
+		TokenPrefix: auth.APITokenHint(token),
+		Scopes:      scopes,
+	}
+	if expiresAt != nil {
+		params.ExpiresAt = pgtype.Timestamptz{Time: *expiresAt, Valid: true}
+	}
+	row, err := s.store.CreateAPIToken(ctx, params)
+	if err != nil {
+		return "", nil, fmt.Errorf("creating API token: %w", err)
+	}
+	slog.Info("API token created", "user_id", userID, "token_id", row.ID, "scopes", scopes)
+	view := apiTokenFromDB(row)
+	return token, &view, nil
+}
+
+// normalizeScopes checks the requested scopes and drops duplicates.
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, errs.NewValidationError(map[string]string{fieldScopes: "at least one scope is required"})
+	}
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !auth.ValidAPITokenScope(scope) {
+			return nil, errs.NewValidationError(map[string]string{fieldScopes: fmt.Sprintf("unknown scope %q", scope)})
+		}
+		if !slices.Contains(out, scope) {
+			out = append(out, scope)
+		}
+	}
+	return out, nil
+}
+
+func (s *APITokenService) List(ctx context.Context, userID int64) ([]APIToken, error) {
+	rows, err := s.store.ListAPITokensByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]APIToken, len(rows))
+	for i, r := range rows {
+		out[i] = apiTokenFromDB(r)
+	}
+	return out, nil
+}
+
+// Revoke deletes one of the user's tokens; it stops working at once.
+func (s *APITokenService) Revoke(ctx context.Context, userID, tokenID int64) error {
+	n, err := s.store.DeleteAPIToken(ctx, db.DeleteAPITokenParams{ID: tokenID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.ErrNotFound
+	}
+	slog.Info("API token revoked", "user_id", userID, "token_id", tokenID)
+	return nil
+}
+
+// Authenticate resolves a bearer token to its owner. It reports false for an
+// unknown or expired token and for a blocked owner. Like sessions, last-used
+// is refreshed at most once per sessionTouchInterval.
+func (s *APITokenService) Authenticate(ctx context.Context, token, ipAddress string) (*APITokenIdentity, bool) {
+	if !auth.IsAPIToken(token) {
+		return nil, false
+	}
+	row, err := s.store.GetAPITokenForAuth(ctx, auth.HashToken(token))
+	if err != nil {
+		return nil, false
+	}
+	now := s.now()
+	if row.IsBlocked || (row.ExpiresAt.Valid && !now.Before(row.ExpiresAt.Time)) {
+		return nil, false
+	}
+	if !row.LastUsedAt.Valid || now.Sub(row.LastUsedAt.Time) >= sessionTouchInterval {
+		_ = s.store.TouchAPIToken(ctx, db.TouchAPITokenParams{ID: row.ID, LastUsedIp: strToPtr(ipAddress)})
+	}
+	return &APITokenIdentity{UserID: row.UserID, UserName: row.UserName, Role: row.Role, Scopes: row.Scopes}, true
+}
+
+func apiTokenFromDB(r db.UserApiToken) APIToken {
+	t := APIToken{
+		ID:         r.ID,
+		Name:       r.Name,
+		Hint:       r.TokenPrefix,
+		Scopes:     r.Scopes,
+		LastUsedIP: r.LastUsedIp,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.ExpiresAt.Valid {
+		t.ExpiresAt = &r.ExpiresAt.Time
+	}
+	if r.LastUsedAt.Valid {
+		t.LastUsedAt = &r.LastUsedAt.Time
+	}
+	return t
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type mockAPITokenStore struct {
+	tokens  map[int64]*db.UserApiToken
+	owners  map[int64]db.User
+	touched int
+	nextID  int64
+}
+
+func newMockAPITokenStore(owners ...db.User) *mockAPITokenStore {
+	m := &mockAPITokenStore{tokens: map[int64]*db.UserApiToken{}, owners: map[int64]db.User{}}
+	for _, u := range owners {
+		m.owners[u.ID] = u
+	}
+	return m
+}
+
+func (m *mockAPITokenStore) CreateAPIToken(_ context.Context, arg db.CreateAPITokenParams) (db.UserApiToken, error) {
+	m.nextID++
+	row := &db.UserApiToken{
+		ID:          m.nextID,
+		UserID:      arg.UserID,
+		Name:        arg.Name,
+		TokenHash:   arg.TokenHash,
+		TokenPrefix: arg.TokenPrefix,
+		Scopes:      arg.Scopes,
+		ExpiresAt:   arg.ExpiresAt,
+		CreatedAt:   time.Now(),
+	}
+	m.tokens[row.ID] = row
+	return *row, nil
+}
+
+func (m *mockAPITokenStore) ListAPITokensByUser(_ context.Context, userID int64) ([]db.UserApiToken, error) {
+	var out []db.UserApiToken
+	for _, t := range m.tokens {
+		if t.UserID == userID {
+			out = append(out, *t)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockAPITokenStore) CountAPITokensByUser(ctx context.Context, userID int64) (int64, error) {
+	rows, _ := m.ListAPITokensByUser(ctx, userID)
+	return int64(len(rows)), nil
+}
+
+func (m *mockAPITokenStore) GetAPITokenForAuth(_ context.Context, tokenHash string) (db.GetAPITokenForAuthRow, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			owner := m.owners[t.UserID]
+			return db.GetAPITokenForAuthRow{
+				ID:         t.ID,
+				UserID:     t.UserID,
+				Scopes:     t.Scopes,
+				ExpiresAt:  t.ExpiresAt,
+				LastUsedAt: t.LastUsedAt,
+				UserName:   owner.UserName,
+				Role:       owner.Role,
+				IsBlocked:  owner.IsBlocked,
+			}, nil
+		}
+	}
+	return db.GetAPITokenForAuthRow{}, pgx.ErrNoRows
+}
+
+func (m *mockAPITokenStore) TouchAPIToken(_ context.Context, arg db.TouchAPITokenParams) error {
+	m.touched++
+	t := m.tokens[arg.ID]
+	t.LastUsedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	t.LastUsedIp = arg.LastUsedIp
+	return nil
+}
+
+func (m *mockAPITokenStore) DeleteAPIToken(_ context.Context, arg db.DeleteAPITokenParams) (int64, error) {
+	t, ok := m.tokens[arg.ID]
+	if !ok || t.UserID != arg.UserID {
+		return 0, nil
+	}
+	delete(m.tokens, arg.ID)
+	return 1, nil
+}
+
+func TestAPITokenService_CreateAndAuthenticate(t *testing.T) {
+	store := newMockAPITokenStore(db.User{ID: 7, UserName: "ci", Role: "admin"})
+	svc := NewAPITokenService(store)
+
+	token, view, err := svc.Create(context.Background(), 7, "  CI  ", []string{auth.ScopeResultsWrite, auth.ScopeResultsWrite, auth.ScopeServicesSync}, nil)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if view.Name != "CI" || len(view.Scopes) != 2 || view.Hint != auth.APITokenHint(token) {
+		t.Fatalf("unexpected token view: %+v", view)
+	}
+	if store.tokens[view.ID].TokenHash == token {
+		t.Fatal("token must be stored hashed")
+	}
+
+	identity, ok := svc.Authenticate(context.Background(), token, "10.0.0.1")
+	if !ok {
+		t.Fatal("Authenticate rejected a fresh token")
+	}
+	if identity.UserID != 7 || identity.Role != "admin" || identity.UserName != "ci" || len(identity.Scopes) != 2 {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+	if ip := store.tokens[view.ID].LastUsedIp; ip == nil || *ip != "10.0.0.1" {
+		t.Errorf("last used ip = %v, want 10.0.0.1", ip)
+	}
+
+	if _, ok := svc.Authenticate(context.Background(), token, "10.0.0.1"); !ok {
+		t.Fatal("Authenticate rejected a token used a moment ago")
+	}
+	if store.touched != 1 {
+		t.Errorf("touched %d times, want 1", store.touched)
+	}
+
+	if _, ok := svc.Authenticate(context.Background(), token+"x", ""); ok {
+		t.Error("Authenticate accepted an unknown token")
+	}
+}
+
+func TestAPITokenService_CreateValidation(t *testing.T) {
+	svc := NewAPITokenService(newMockAPITokenStore())
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		tokenName string
+		scopes    []string
+		expiresAt *time.Time
+		field     string
+	}{
+		{"empty name", " ", []string{auth.ScopeResultsRead}, nil, fieldName},
+		{"no scopes", "ci", nil, nil, fieldScopes},
+		{"unknown scope", "ci", []string{"results:*"}, nil, fieldScopes},
+		{"expired", "ci", []string{auth.ScopeResultsRead}, &past, fieldExpiresAt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := svc.Create(context.Background(), 1, tt.tokenName, tt.scopes, tt.expiresAt)
+			var ve *errs.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if _, ok := ve.Fields[tt.field]; !ok {
+				t.Errorf("fields = %v, want %q", ve.Fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestAPITokenService_CreateLimit(t *testing.T) {
+	svc := NewAPITokenService(newMockAPITokenStore())
+	for range maxAPITokensPerUser {
+		if _, _, err := svc.Create(context.Background(), 1, "ci", []string{auth.ScopeResultsRead}, nil); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if _, _, err := svc.Create(context.Background(), 1, "ci", []string{auth.ScopeResultsRead}, nil); !errors.Is(err, errs.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestAPITokenService_AuthenticateRejects(t *testing.T) {
+	store := newMockAPITokenStore(db.User{ID: 1, UserName: "alice", Role: "player"}, db.User{ID: 2, UserName: "bob", Role: "player", IsBlocked: true})
+	svc := NewAPITokenService(store)
+
+	soon := time.Now().Add(time.Minute)
+	expiring, _, err := svc.Create(context.Background(), 1, "expiring", []string{auth.ScopeResultsRead}, &soon)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	blocked, _, err := svc.Create(context.Background(), 2, "blocked", []string{auth.ScopeResultsRead}, nil)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, ok := svc.Authenticate(context.Background(), blocked, ""); ok {
+		t.Error("token of a blocked user accepted")
+	}
+	svc.now = func() time.Time { return soon }
+	if _, ok := svc.Authenticate(context.Background(), expiring, ""); ok {
+		t.Error("expired token accepted")
+	}
+	if _, ok := svc.Authenticate(context.Background(), "not-an-api-token", ""); ok {
+		t.Error("session JWT accepted as an API token")
+	}
+}
+
+func TestAPITokenService_Revoke(t *testing.T) {
+	store := newMockAPITokenStore(db.User{ID: 1, UserName: "alice", Role: "player"})
+	svc := NewAPITokenService(store)
+
+	token, view, err := svc.Create(context.Background(), 1, "ci", []string{auth.ScopeResultsRead}, nil)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := svc.Revoke(context.Background(), 2, view.ID); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("revoking another user's token: expected ErrNotFound, got %v", err)
+	}
+	if err := svc.Revoke(context.Background(), 1, view.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if _, ok := svc.Authenticate(context.Background(), token, ""); ok {
+		t.Error("revoked token accepted")
+	}
+}
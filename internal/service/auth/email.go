@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/mail"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	usersvc "github.com/ctf01d/ctf01d-training-platform/internal/service/users"
+)
+
+// Purposes of email tokens, kept in sync with the user_email_tokens check
+// constraint.
+const (
+	purposeVerifyEmail   = "verify_email"
+	purposeResetPassword = "reset_password"
+)
+
+const (
+	verifyEmailTTL   = 24 * time.Hour
+	resetPasswordTTL = time.Hour
+
+	// Frontend pages the emailed links open; they finish the flow through
+	// the API.
+	verifyEmailPath   = "/verify-email"
+	resetPasswordPath = "/reset-password"
+
+	fieldLogin = "login"
+	fieldToken = "token"
+)
+
+var (
+	errNoEmail              = fmt.Errorf("%w: add an email address to your profile first", errs.ErrConflict)
+	errEmailAlreadyVerified = fmt.Errorf("%w: email address is already verified", errs.ErrConflict)
+	errEmailTokenInvalid    = errs.NewValidationError(map[string]string{fieldToken: "is invalid or expired"})
+)
+
+type EmailTokenStore interface {
+	GetUserByID(ctx context.Context, id int64) (db.User, error)
+	ListUsersByLogin(ctx context.Context, login string) ([]db.User, error)
+	UpdateUserPassword(ctx context.Context, arg db.UpdateUserPasswordParams) (db.User, error)
+	MarkUserEmailVerified(ctx context.Context, arg db.MarkUserEmailVerifiedParams) (int64, error)
+	RevokeAllUserSessions(ctx context.Context, userID int64) error
+	CreateEmailToken(ctx context.Context, arg db.CreateEmailTokenParams) error
+	ConsumeEmailToken(ctx context.Context, arg db.ConsumeEmailTokenParams) (db.UserEmailToken, error)
+	DeleteUserEmailTokens(ctx context.Context, arg db.DeleteUserEmailTokensParams) error
+	DeleteExpiredEmailTokens(ctx context.Context) error
+}
+
+// EmailService runs the flows that prove control of an email address: email
+// verification and password reset. Both mail a single-use, expiring link to
+// the frontend; only the token's hash is stored.
+type EmailService struct {
+	store    EmailTokenStore
+	mailer   mail.Mailer
+	linkBase string
+	tx       TxRunner
+	now      func() time.Time
+}
+
+// NewEmailService builds the service; linkBaseURL is the frontend origin the
+// emailed links point to.
+func NewEmailService(store EmailTokenStore, mailer mail.Mailer, linkBaseURL string) *EmailService {
+	return &EmailService{store: store, mailer: mailer, linkBase: strings.TrimRight(linkBaseURL, "/"), now: time.Now}
+}
+
+// SetTxRunner makes a password reset consume its token, set the password and
+// end the sessions in one transaction.
+func (s *EmailService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+func (s *EmailService) inTx(ctx context.Context, fn func(q EmailTokenStore) error) error {
+	if s.tx != nil {
+		return s.tx.RunInTx(ctx, func(q *db.Queries) error { return fn(q) })
+	}
+	return fn(s.store)
+}
+
+// SendVerification mails a verification link to the user's current address.
+// A new link voids the earlier ones.
+func (s *EmailService) SendVerification(ctx context.Context, userID int64) error {
+	u, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return errs.ErrNotFound
+	}
+	if u.Email == nil {
+		return errNoEmail
+	}
+	if u.EmailVerifiedAt.Valid {
+		return errEmailAlreadyVerified
+	}
+	if err := s.send(ctx, u, purposeVerifyEmail); err != nil {
+		return fmt.Errorf("sending verification email: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail marks the address a verification link was sent to as verified.
+// A link to an address the user has replaced since is rejected.
+func (s *EmailService) VerifyEmail(ctx context.Context, token string) error {
+	row, err := s.consume(ctx, s.store, token, purposeVerifyEmail)
+	if err != nil {
+		return err
+	}
+	n, err := s.store.MarkUserEmailVerified(ctx, db.MarkUserEmailVerifiedParams{ID: row.UserID, Email: &row.Email})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errEmailTokenInvalid
+	}
+	slog.Info("email verified", "user_id", row.UserID)
+	return nil
+}
+
+// RequestPasswordReset mails a reset link to every account matching login (a
+// user name or an email address) that has a verified address. It reports
+// success either way, so the endpoint does not reveal which accounts exist.
+func (s *EmailService) RequestPasswordReset(ctx context.Context, login string) error {
+	login = strings.TrimSpace(login)
+	if login == "" {
+		return errs.NewValidationError(map[string]string{fieldLogin: "is required"})
+	}
+	users, err := s.store.ListUsersByLogin(ctx, login)
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		// An unverified address may be a typo: a reset link sent there would
+		// hand the account to a stranger.
+		if u.Email == nil || !u.EmailVerifiedAt.Valid || u.IsBlocked {
+			continue
+		}
+		if err := s.send(ctx, u, purposeResetPassword); err != nil {
+			slog.Warn("password reset email failed", "user_id", u.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with a reset link and signs the user out
+// everywhere. The link only works while the account keeps the address it was
+// sent to.
+func (s *EmailService) ResetPassword(ctx context.Context, token, password string) error {
+	// Checked first so a rejected password does not use up the link.
+	if err := usersvc.ValidatePassword(password); err != nil {
+		return err
+	}
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	var userID int64
+	err = s.inTx(ctx, func(q EmailTokenStore) error {
+		row, err := s.consume(ctx, q, token, purposeResetPassword)
+		if err != nil {
+			return err
+		}
+		u, err := q.GetUserByID(ctx, row.UserID)
+		if err != nil {
+			return err
+		}
+		if u.Email == nil || *u.Email != row.Email {
+			return errEmailTokenInvalid
+		}
+		if _, err := q.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{ID: u.ID, PasswordDigest: &hash}); err != nil {
+			return err
+		}
+		if err := q.DeleteUserEmailTokens(ctx, db.DeleteUserEmailTokensParams{UserID: u.ID, Purpose: purposeResetPassword}); err != nil {
+			return err
+		}
+		userID = u.ID
+		return q.RevokeAllUserSessions(ctx, u.ID)
+	})
+	if err != nil {
+		return err
+	}
+	slog.Info("password reset", "user_id", userID)
+	return nil
+}
+
+// consume redeems a token of the given purpose; it is gone afterwards.
+func (s *EmailService) consume(ctx context.Context, q EmailTokenStore, token, purpose string) (db.UserEmailToken, error) {
+	if token == "" {
+		return db.UserEmailToken{}, errEmailTokenInvalid
+	}
+	row, err := q.ConsumeEmailToken(ctx, db.ConsumeEmailTokenParams{TokenHash: auth.HashToken(token), Purpose: purpose})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.UserEmailToken{}, errEmailTokenInvalid
+	}
+	return row, err
+}
+
+// send issues a token for purpose, replacing earlier ones, and mails the link
+// in the user's language.
+func (s *EmailService) send(ctx context.Context, u db.User, purpose string) error {
+	ttl, path, tmpl := verifyEmailTTL, verifyEmailPath, mail.TemplateVerifyEmail
+	if purpose == purposeResetPassword {
+		ttl, path, tmpl = resetPasswordTTL, resetPasswordPath, mail.TemplateResetPassword
+	}
+
+	token, err := auth.NewSessionID()
+	if err != nil {
+		return fmt.Errorf("generating email token: %w", err)
+	}
+	_ = s.store.DeleteExpiredEmailTokens(ctx)
+	if err := s.store.DeleteUserEmailTokens(ctx, db.DeleteUserEmailTokensParams{UserID: u.ID, Purpose: purpose}); err != nil {
+		return err
+	}
+	if err := s.store.CreateEmailToken(ctx, db.CreateEmailTokenParams{
+		TokenHash: auth.HashToken(token),
+		UserID:    u.ID,
+		Purpose:   purpose,
+		Email:     *u.Email,
+		ExpiresAt: s.now().Add(ttl),
+	}); err != nil {
+		return err
+	}
+
+	msg, err := mail.Compose(*u.Email, u.Language, tmpl, mail.TemplateData{
+		UserName:    u.UserName,
+		DisplayName: u.DisplayName,
+		Email:       *u.Email,
+		Link:        s.linkBase + path + "?token=" + url.QueryEscape(token),
+		ValidHours:  int(ttl / time.Hour),
+	})
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(ctx, msg)
+}
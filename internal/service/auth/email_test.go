@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/mail"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type mockEmailTokenStore struct {
+	users    map[int64]*db.User
+	tokens   map[string]db.UserEmailToken
+	revoked  []int64
+	verified int
+}
+
+func newMockEmailTokenStore(users ...db.User) *mockEmailTokenStore {
+	m := &mockEmailTokenStore{users: map[int64]*db.User{}, tokens: map[string]db.UserEmailToken{}}
+	for _, u := range users {
+		m.users[u.ID] = &u
+	}
+	return m
+}
+
+func (m *mockEmailTokenStore) GetUserByID(_ context.Context, id int64) (db.User, error) {
+	u, ok := m.users[id]
+	if !ok {
+		return db.User{}, pgx.ErrNoRows
+	}
+	return *u, nil
+}
+
+func (m *mockEmailTokenStore) ListUsersByLogin(_ context.Context, login string) ([]db.User, error) {
+	var out []db.User
+	for _, u := range m.users {
+		if u.UserName == login || (u.Email != nil && strings.EqualFold(*u.Email, login)) {
+			out = append(out, *u)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockEmailTokenStore) UpdateUserPassword(_ context.Context, arg db.UpdateUserPasswordParams) (db.User, error) {
+	u := m.users[arg.ID]
+	u.PasswordDigest = arg.PasswordDigest
+	return *u, nil
+}
+
+func (m *mockEmailTokenStore) MarkUserEmailVerified(_ context.Context, arg db.MarkUserEmailVerifiedParams) (int64, error) {
+	u, ok := m.users[arg.ID]
+	if !ok || u.Email == nil || *u.Email != *arg.Email {
+		return 0, nil
+	}
+	m.verified++
+	u.EmailVerifiedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	return 1, nil
+}
+
+func (m *mockEmailTokenStore) RevokeAllUserSessions(_ context.Context, userID int64) error {
+	m.revoked = append(m.revoked, userID)
+	return nil
+}
+
+func (m *mockEmailTokenStore) CreateEmailToken(_ context.Context, arg db.CreateEmailTokenParams) error {
+	m.tokens[arg.TokenHash] = db.UserEmailToken{TokenHash: arg.TokenHash, UserID: arg.UserID, Purpose: arg.Purpose, Email: arg.Email, ExpiresAt: arg.ExpiresAt}
+	return nil
+}
+
+func (m *mockEmailTokenStore) ConsumeEmailToken(_ context.Context, arg db.ConsumeEmailTokenParams) (db.UserEmailToken, error) {
+	t, ok := m.tokens[arg.TokenHash]
+	if !ok || t.Purpose != arg.Purpose || !t.ExpiresAt.After(time.Now()) {
+		return db.UserEmailToken{}, pgx.ErrNoRows
+	}
+	delete(m.tokens, arg.TokenHash)
+	return t, nil
+}
+
+func (m *mockEmailTokenStore) DeleteUserEmailTokens(_ context.Context, arg db.DeleteUserEmailTokensParams) error {
+	for hash, t := range m.tokens {
+		if t.UserID == arg.UserID && t.Purpose == arg.Purpose {
+			delete(m.tokens, hash)
+		}
+	}
+	return nil
+}
+
+func (m *mockEmailTokenStore) DeleteExpiredEmailTokens(context.Context) error { return nil }
+
+type captureMailer struct {
+	sent []mail.Message
+}
+
+func (c *captureMailer) Send(_ context.Context, msg mail.Message) error {
+	c.sent = append(c.sent, msg)
+	return nil
+}
+
+// linkToken pulls the token out of the link in the last message sent.
+func (c *captureMailer) linkToken(t *testing.T) string {
+	t.Helper()
+	if len(c.sent) == 0 {
+		t.Fatal("no mail sent")
+	}
+	body := c.sent[len(c.sent)-1].Body
+	start := strings.Index(body, "http")
+	if start < 0 {
+		t.Fatalf("no link in %q", body)
+	}
+	link, err := url.Parse(strings.Fields(body[start:])[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return link.Query().Get("token")
+}
+
+func strPtr(s string) *string { return &s }
+
+var verifiedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+func TestEmailService_Verification(t *testing.T) {
+	store := newMockEmailTokenStore(
+		db.User{ID: 1, UserName: "alice", DisplayName: "Alice", Email: strPtr("alice@example.com"), Language: "ru"},
+		db.User{ID: 2, UserName: "bob"},
+	)
+	mailer := &captureMailer{}
+	svc := NewEmailService(store, mailer, "https://ctf.example/")
+
+	if err := svc.SendVerification(context.Background(), 2); !errors.Is(err, errs.ErrConflict) {
+		t.Fatalf("user without email: expected ErrConflict, got %v", err)
+	}
+	if err := svc.SendVerification(context.Background(), 1); err != nil {
+		t.Fatalf("SendVerification: %v", err)
+	}
+	msg := mailer.sent[0]
+	if msg.To != "alice@example.com" || !strings.Contains(msg.Body, "https://ctf.example/verify-email?token=") {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+	if !strings.Contains(msg.Subject, "Подтвердите") {
+		t.Errorf("subject %q is not in the user's language", msg.Subject)
+	}
+	token := mailer.linkToken(t)
+	if _, ok := store.tokens[token]; ok {
+		t.Fatal("token must be stored hashed")
+	}
+
+	if err := svc.VerifyEmail(context.Background(), token); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if !store.users[1].EmailVerifiedAt.Valid {
+		t.Fatal("email not marked verified")
+	}
+	var ve *errs.ValidationError
+	if err := svc.VerifyEmail(context.Background(), token); !errors.As(err, &ve) {
+		t.Fatalf("reused token: expected ValidationError, got %v", err)
+	}
+	if err := svc.SendVerification(context.Background(), 1); !errors.Is(err, errs.ErrConflict) {
+		t.Fatalf("verified address: expected ErrConflict, got %v", err)
+	}
+}
+
+func TestEmailService_VerificationOfReplacedAddress(t *testing.T) {
+	store := newMockEmailTokenStore(db.User{ID: 1, UserName: "alice", Email: strPtr("old@example.com")})
+	mailer := &captureMailer{}
+	svc := NewEmailService(store, mailer, "https://ctf.example")
+
+	if err := svc.SendVerification(context.Background(), 1); err != nil {
+		t.Fatalf("SendVerification: %v", err)
+	}
+	store.users[1].Email = strPtr("new@example.com")
+
+	var ve *errs.ValidationError
+	if err := svc.VerifyEmail(context.Background(), mailer.linkToken(t)); !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if store.verified != 0 {
+		t.Error("replaced address marked verified")
+	}
+}
+
+func TestEmailService_PasswordReset(t *testing.T) {
+	store := newMockEmailTokenStore(
+		db.User{ID: 1, UserName: "alice", Email: strPtr("alice@example.com"), EmailVerifiedAt: verifiedAt},
+		db.User{ID: 2, UserName: "bob", Email: strPtr("bob@example.com")},
+	)
+	mailer := &captureMailer{}
+	svc := NewEmailService(store, mailer, "https://ctf.example")
+
+	for _, login := range []string{"nobody", "bob", "ALICE@example.com"} {
+		if err := svc.RequestPasswordReset(context.Background(), login); err != nil {
+			t.Fatalf("RequestPasswordReset(%q): %v", login, err)
+		}
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != "alice@example.com" {
+		t.Fatalf("expected one mail to the verified address, got %+v", mailer.sent)
+	}
+	token := mailer.linkToken(t)
+
+	var ve *errs.ValidationError
+	if err := svc.ResetPassword(context.Background(), token, "short"); !errors.As(err, &ve) {
+		t.Fatalf("short password: expected ValidationError, got %v", err)
+	}
+	if err := svc.ResetPassword(context.Background(), token, "n3w-password"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if digest := store.users[1].PasswordDigest; digest == nil || !auth.CheckPassword(*digest, "n3w-password") {
+		t.Error("password not updated")
+	}
+	if len(store.revoked) != 1 || store.revoked[0] != 1 {
+		t.Errorf("revoked sessions of %v, want [1]", store.revoked)
+	}
+	if err := svc.ResetPassword(context.Background(), token, "another-password"); !errors.As(err, &ve) {
+		t.Fatalf("reused token: expected ValidationError, got %v", err)
+	}
+}
+
+func TestEmailService_NewResetLinkVoidsOlder(t *testing.T) {
+	store := newMockEmailTokenStore(db.User{ID: 1, UserName: "alice", Email: strPtr("alice@example.com"), EmailVerifiedAt: verifiedAt})
+	mailer := &captureMailer{}
+	svc := NewEmailService(store, mailer, "https://ctf.example")
+
+	if err := svc.RequestPasswordReset(context.Background(), "alice"); err != nil {
+		t.Fatal(err)
+	}
+	first := mailer.linkToken(t)
+	if err := svc.RequestPasswordReset(context.Background(), "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	var ve *errs.ValidationError
+	if err := svc.ResetPassword(context.Background(), first, "n3w-password"); !errors.As(err, &ve) {
+		t.Fatalf("older link: expected ValidationError, got %v", err)
+	}
+	if err := svc.ResetPassword(context.Background(), mailer.linkToken(t), "n3w-password"); err != nil {
+		t.Fatalf("newest link: %v", err)
+	}
+}
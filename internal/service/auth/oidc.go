@@ -0,0 +1,374 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	usersvc "github.com/ctf01d/ctf01d-training-platform/internal/service/users"
+)
+
+// oidcStateTTL is how long the user has to finish signing in at the provider.
+const oidcStateTTL = 10 * time.Minute
+
+// maxProvisionAttempts bounds the search for a free user name.
+const maxProvisionAttempts = 20
+
+var (
+	ErrUnknownProvider = fmt.Errorf("%w: unknown identity provider", errs.ErrNotFound)
+
+	errLoginStateInvalid = fmt.Errorf("%w: sign-in attempt is invalid or expired", errs.ErrUnauthorized)
+	errIdentityRejected  = fmt.Errorf("%w: identity provider rejected the sign-in", errs.ErrUnauthorized)
+	errIdentityNotLinked = fmt.Errorf("%w: no account is linked to this identity", errs.ErrUnauthorized)
+	errIdentityTaken     = fmt.Errorf("%w: identity is linked to another user", errs.ErrConflict)
+	errProviderLinked    = fmt.Errorf("%w: another account of this provider is already linked", errs.ErrConflict)
+	errLastLoginMethod   = fmt.Errorf("%w: set a password or link another identity before unlinking the last one", errs.ErrConflict)
+
+	userNameUnsafeChars = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+type IdentityStore interface {
+	GetUserByID(ctx context.Context, id int64) (db.User, error)
+	GetUserByUserName(ctx context.Context, userName string) (db.User, error)
+	CreateExternalUser(ctx context.Context, arg db.CreateExternalUserParams) (db.User, error)
+	GetUserIdentity(ctx context.Context, arg db.GetUserIdentityParams) (db.UserIdentity, error)
+	ListUserIdentities(ctx context.Context, userID int64) ([]db.UserIdentity, error)
+	CreateUserIdentity(ctx context.Context, arg db.CreateUserIdentityParams) (db.UserIdentity, error)
+	TouchUserIdentity(ctx context.Context, arg db.TouchUserIdentityParams) error
+	DeleteUserIdentity(ctx context.Context, arg db.DeleteUserIdentityParams) (int64, error)
+	CreateOIDCLoginState(ctx context.Context, arg db.CreateOIDCLoginStateParams) error
+	ConsumeOIDCLoginState(ctx context.Context, state string) (db.OidcLoginState, error)
+	DeleteExpiredOIDCLoginStates(ctx context.Context) error
+}
+
+type TxRunner interface {
+	RunInTx(ctx context.Context, fn func(queries *db.Queries) error) error
+}
+
+// OIDCService signs users in through external identity providers and links
+// identities to existing accounts. A successful sign-in ends in the same
+// session-backed JWT as a password login.
+type OIDCService struct {
+	auth          *Service
+	store         IdentityStore
+	tx            TxRunner
+	providers     []auth.IdentityProvider
+	autoProvision bool
+}
+
+func NewOIDCService(authSvc *Service, store IdentityStore, providers []auth.IdentityProvider) *OIDCService {
+	return &OIDCService{auth: authSvc, store: store, providers: providers, autoProvision: true}
+}
+
+// SetAutoProvision controls whether an unknown identity gets a new guest
+// account; without it only linked identities can sign in.
+func (s *OIDCService) SetAutoProvision(enabled bool) {
+	s.autoProvision = enabled
+}
+
+// SetTxRunner makes auto-provisioning create the user and its identity in one
+// transaction.
+func (s *OIDCService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+// Provider is the public view of a configured identity provider.
+type Provider struct {
+	Name        string
+	DisplayName string
+}
+
+// Authorization is where to send the browser to sign in at a provider. The
+// frontend keeps State to check it against the callback.
+type Authorization struct {
+	URL   string
+	State string
+}
+
+// CallbackResult is the outcome of a provider callback: a sign-in, or, with
+// Linked set, only the User the identity was linked to.
+type CallbackResult struct {
+	LoginResult
+	Linked bool
+}
+
+// Identity is an external identity linked to a user.
+type Identity struct {
+	ID          int64
+	Provider    string
+	Email       *string
+	CreatedAt   time.Time
+	LastLoginAt *time.Time
+}
+
+func (s *OIDCService) Providers() []Provider {
+	out := make([]Provider, len(s.providers))
+	for i, p := range s.providers {
+		out[i] = Provider{Name: p.Name(), DisplayName: p.DisplayName()}
+	}
+	return out
+}
+
+func (s *OIDCService) provider(name string) (auth.IdentityProvider, error) {
+	for _, p := range s.providers {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, ErrUnknownProvider
+}
+
+// StartLogin begins signing in with the named provider.
+func (s *OIDCService) StartLogin(ctx context.Context, providerName string) (*Authorization, error) {
+	return s.start(ctx, providerName, nil)
+}
+
+// StartLink begins linking an identity of the named provider to userID.
+func (s *OIDCService) StartLink(ctx context.Context, providerName string, userID int64) (*Authorization, error) {
+	return s.start(ctx, providerName, &userID)
+}
+
+func (s *OIDCService) start(ctx context.Context, providerName string, linkUserID *int64) (*Authorization, error) {
+	p, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	state, err := auth.NewOAuthState()
+	if err != nil {
+		return nil, fmt.Errorf("generating state: %w", err)
+	}
+	nonce, err := auth.NewOAuthState()
+	if err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	verifier := auth.NewPKCEVerifier()
+
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("building authorization url: %w", err)
+	}
+	// Abandoned attempts are swept on the way; a failure only delays that.
+	if err := s.store.DeleteExpiredOIDCLoginStates(ctx); err != nil {
+		slog.Warn("failed to delete expired oidc login states", "error", err)
+	}
+	if err := s.store.CreateOIDCLoginState(ctx, db.CreateOIDCLoginStateParams{
+		State:        state,
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		LinkUserID:   linkUserID,
+		ExpiresAt:    time.Now().Add(oidcStateTTL),
+	}); err != nil {
+		return nil, fmt.Errorf("saving login state: %w", err)
+	}
+	return &Authorization{URL: authURL, State: state}, nil
+}
+
+// Callback finishes a sign-in or a link started by StartLogin or StartLink.
+// The state is single-use and must belong to the same provider.
+func (s *OIDCService) Callback(ctx context.Context, providerName, state, code, ipAddress, userAgent string) (*CallbackResult, error) {
+	p, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.store.ConsumeOIDCLoginState(ctx, state)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && row.Provider != providerName) {
+		return nil, errLoginStateInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := p.Exchange(ctx, code, row.Nonce, row.CodeVerifier)
+	if err != nil {
+		slog.Warn("oidc sign-in rejected", "provider", providerName, "ip", ipAddress, "error", err)
+		return nil, errIdentityRejected
+	}
+
+	if row.LinkUserID != nil {
+		user, err := s.link(ctx, providerName, *row.LinkUserID, identity)
+		if err != nil {
+			return nil, err
+		}
+		return &CallbackResult{LoginResult: LoginResult{User: user}, Linked: true}, nil
+	}
+
+	dbUser, err := s.findOrProvision(ctx, providerName, identity)
+	if err != nil {
+		return nil, err
+	}
+	if dbUser.IsBlocked {
+		return nil, fmt.Errorf("%w: account is blocked", errs.ErrForbidden)
+	}
+	login, err := s.auth.signIn(ctx, dbUser, ipAddress, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("oidc sign-in", "provider", providerName, "user_id", dbUser.ID, "ip", ipAddress, "two_factor", login.Challenge != nil)
+	return &CallbackResult{LoginResult: *login}, nil
+}
+
+func (s *OIDCService) link(ctx context.Context, providerName string, userID int64, identity *auth.ExternalIdentity) (*usersvc.User, error) {
+	existing, err := s.store.GetUserIdentity(ctx, db.GetUserIdentityParams{Provider: providerName, Subject: identity.Subject})
+	switch {
+	case err == nil && existing.UserID != userID:
+		return nil, errIdentityTaken
+	case err == nil:
+	case errors.Is(err, pgx.ErrNoRows):
+		if _, err := s.store.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+			UserID:   userID,
+			Provider: providerName,
+			Subject:  identity.Subject,
+			Email:    strToPtr(identity.Email),
+		}); err != nil {
+			if repository.IsDuplicateKey(err) {
+				return nil, errProviderLinked
+			}
+			return nil, err
+		}
+		slog.Info("external identity linked", "provider", providerName, "user_id", userID)
+	default:
+		return nil, err
+	}
+
+	dbUser, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errs.ErrNotFound
+	}
+	u := userFromDB(dbUser)
+	return &u, nil
+}
+
+// findOrProvision returns the user linked to identity. Unknown identities get
+// a new guest account when auto-provisioning is on; they are never matched to
+// existing users by e-mail, which would let a provider take over accounts.
+func (s *OIDCService) findOrProvision(ctx context.Context, providerName string, identity *auth.ExternalIdentity) (db.User, error) {
+	existing, err := s.store.GetUserIdentity(ctx, db.GetUserIdentityParams{Provider: providerName, Subject: identity.Subject})
+	if err == nil {
+		if err := s.store.TouchUserIdentity(ctx, db.TouchUserIdentityParams{ID: existing.ID, Email: strToPtr(identity.Email)}); err != nil {
+			slog.Warn("failed to touch identity", "identity_id", existing.ID, "error", err)
+		}
+		return s.store.GetUserByID(ctx, existing.UserID)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return db.User{}, err
+	}
+	if !s.autoProvision {
+		return db.User{}, errIdentityNotLinked
+	}
+
+	var created db.User
+	provision := func(q IdentityStore) error {
+		user, err := s.createExternalUser(ctx, q, providerName, identity)
+		if err != nil {
+			return err
+		}
+		if _, err := q.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  identity.Subject,
+			Email:    strToPtr(identity.Email),
+		}); err != nil {
+			return fmt.Errorf("linking identity: %w", err)
+		}
+		created = user
+		return nil
+	}
+	if s.tx != nil {
+		err = s.tx.RunInTx(ctx, func(q *db.Queries) error { return provision(q) })
+	} else {
+		err = provision(s.store)
+	}
+	if err != nil {
+		return db.User{}, err
+	}
+	slog.Info("user provisioned from external identity", "provider", providerName, "user_id", created.ID, "user_name", created.UserName)
+	return created, nil
+}
+
+// createExternalUser picks the first free name among the identity's preferred
+// login, then the same with a numeric suffix.
+func (s *OIDCService) createExternalUser(ctx context.Context, q IdentityStore, providerName string, identity *auth.ExternalIdentity) (db.User, error) {
+	base := userNameUnsafeChars.ReplaceAllString(strings.ToLower(identity.UserName), "_")
+	base = strings.Trim(base, "_")
+	if base == "" {
+		base = providerName + "_user"
+	}
+	displayName := strings.TrimSpace(identity.DisplayName)
+	if displayName == "" {
+		displayName = base
+	}
+
+	for i := 0; i < maxProvisionAttempts; i++ {
+		name := base
+		if i > 0 {
+			name = base + "_" + strconv.Itoa(i+1)
+		}
+		if _, err := q.GetUserByUserName(ctx, name); err == nil {
+			continue
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return db.User{}, err
+		}
+		return q.CreateExternalUser(ctx, db.CreateExternalUserParams{
+			UserName:    name,
+			DisplayName: displayName,
+			Email:       strToPtr(identity.Email),
+		})
+	}
+	return db.User{}, fmt.Errorf("%w: no free user name for %q", errs.ErrConflict, base)
+}
+
+func (s *OIDCService) ListIdentities(ctx context.Context, userID int64) ([]Identity, error) {
+	rows, err := s.store.ListUserIdentities(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Identity, len(rows))
+	for i, r := range rows {
+		out[i] = Identity{ID: r.ID, Provider: r.Provider, Email: r.Email, CreatedAt: r.CreatedAt}
+		if r.LastLoginAt.Valid {
+			out[i].LastLoginAt = &r.LastLoginAt.Time
+		}
+	}
+	return out, nil
+}
+
+// Unlink removes one of the user's identities. The last identity of a user
+// without a password stays, or the account could no longer sign in.
+func (s *OIDCService) Unlink(ctx context.Context, userID, identityID int64) error {
+	dbUser, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return errs.ErrNotFound
+	}
+	rows, err := s.store.ListUserIdentities(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if dbUser.PasswordDigest == nil && len(rows) <= 1 {
+		for _, r := range rows {
+			if r.ID == identityID {
+				return errLastLoginMethod
+			}
+		}
+	}
+	n, err := s.store.DeleteUserIdentity(ctx, db.DeleteUserIdentityParams{ID: identityID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.ErrNotFound
+	}
+	slog.Info("external identity unlinked", "identity_id", identityID, "user_id", userID)
+	return nil
+}
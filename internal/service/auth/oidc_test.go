@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+// mockIdentityStore shares users with mockUserStore so a provisioned user can
+// sign in through the same Service.
+type mockIdentityStore struct {
+	*mockUserStore
+	identities []db.UserIdentity
+	states     map[string]db.OidcLoginState
+}
+
+func newMockIdentityStore(users *mockUserStore) *mockIdentityStore {
+	return &mockIdentityStore{mockUserStore: users, states: map[string]db.OidcLoginState{}}
+}
+
+func (m *mockIdentityStore) GetUserByUserName(_ context.Context, userName string) (db.User, error) {
+	u, ok := m.users[userName]
+	if !ok {
+		return db.User{}, pgx.ErrNoRows
+	}
+	return u, nil
+}
+
+func (m *mockIdentityStore) CreateExternalUser(_ context.Context, arg db.CreateExternalUserParams) (db.User, error) {
+	u := db.User{ID: int64(len(m.byID) + 100), UserName: arg.UserName, DisplayName: arg.DisplayName, Email: arg.Email, Role: "guest"}
+	m.users[u.UserName] = u
+	m.byID[u.ID] = u
+	return u, nil
+}
+
+func (m *mockIdentityStore) GetUserIdentity(_ context.Context, arg db.GetUserIdentityParams) (db.UserIdentity, error) {
+	for _, i := range m.identities {
+		if i.Provider == arg.Provider && i.Subject == arg.Subject {
+			return i, nil
+		}
+	}
+	return db.UserIdentity{}, pgx.ErrNoRows
+}
+
+func (m *mockIdentityStore) ListUserIdentities(_ context.Context, userID int64) ([]db.UserIdentity, error) {
+	var out []db.UserIdentity
+	for _, i := range m.identities {
+		if i.UserID == userID {
+			out = append(out, i)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockIdentityStore) CreateUserIdentity(_ context.Context, arg db.CreateUserIdentityParams) (db.UserIdentity, error) {
+	for _, i := range m.identities {
+		if i.UserID == arg.UserID && i.Provider == arg.Provider {
+			return db.UserIdentity{}, &pgconn.PgError{Code: "23505"}
+		}
+	}
+	row := db.UserIdentity{ID: int64(len(m.identities) + 1), UserID: arg.UserID, Provider: arg.Provider, Subject: arg.Subject, Email: arg.Email}
+	m.identities = append(m.identities, row)
+	return row, nil
+}
+
+func (m *mockIdentityStore) TouchUserIdentity(_ context.Context, _ db.TouchUserIdentityParams) error {
+	return nil
+}
+
+func (m *mockIdentityStore) DeleteUserIdentity(_ context.Context, arg db.DeleteUserIdentityParams) (int64, error) {
+	for i, row := range m.identities {
+		if row.ID == arg.ID && row.UserID == arg.UserID {
+			m.identities = append(m.identities[:i], m.identities[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (m *mockIdentityStore) CreateOIDCLoginState(_ context.Context, arg db.CreateOIDCLoginStateParams) error {
+	m.states[arg.State] = db.OidcLoginState{
+		State: arg.State, Provider: arg.Provider, CodeVerifier: arg.CodeVerifier,
+		Nonce: arg.Nonce, LinkUserID: arg.LinkUserID, ExpiresAt: arg.ExpiresAt,
+	}
+	return nil
+}
+
+func (m *mockIdentityStore) ConsumeOIDCLoginState(_ context.Context, state string) (db.OidcLoginState, error) {
+	row, ok := m.states[state]
+	delete(m.states, state)
+	if !ok || !row.ExpiresAt.After(time.Now()) {
+		return db.OidcLoginState{}, pgx.ErrNoRows
+	}
+	return row, nil
+}
+
+func (m *mockIdentityStore) DeleteExpiredOIDCLoginStates(_ context.Context) error { return nil }
+
+// fakeProvider asserts a fixed identity for any code, but only with the nonce
+// and verifier it saw in AuthCodeURL.
+type fakeProvider struct {
+	identity auth.ExternalIdentity
+	nonce    string
+	verifier string
+}
+
+func (p *fakeProvider) Name() string        { return "keycloak" }
+func (p *fakeProvider) DisplayName() string { return "University SSO" }
+
+func (p *fakeProvider) AuthCodeURL(_ context.Context, state, nonce, verifier string) (string, error) {
+	p.nonce, p.verifier = nonce, verifier
+	return "https://sso.example.edu/auth?state=" + state, nil
+}
+
+func (p *fakeProvider) Exchange(_ context.Context, _, nonce, verifier string) (*auth.ExternalIdentity, error) {
+	if nonce != p.nonce || verifier != p.verifier {
+		return nil, errors.New("nonce or verifier mismatch")
+	}
+	identity := p.identity
+	return &identity, nil
+}
+
+func newOIDCFixture(identity auth.ExternalIdentity) (*OIDCService, *mockIdentityStore, *mockSessionStore) {
+	users := newMockUserStore()
+	sessions := &mockSessionStore{}
+	store := newMockIdentityStore(users)
+	svc := NewService(users, sessions, &mockJWT{}, &mockChecker{})
+	provider := &fakeProvider{identity: identity}
+	return NewOIDCService(svc, store, []auth.IdentityProvider{provider}), store, sessions
+}
+
+func TestOIDCCallback_ProvisionsGuestAndIssuesSession(t *testing.T) {
+	oidc, store, sessions := newOIDCFixture(auth.ExternalIdentity{Subject: "sub-1", UserName: "Alice.Smith", Email: "alice@example.edu"})
+	addTestUser(store.mockUserStore, 1, "alice_smith", "pw")
+	ctx := context.Background()
+
+	start, err := oidc.StartLogin(ctx, "keycloak")
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+	result, err := oidc.Callback(ctx, "keycloak", start.State, "code", "10.0.0.1", "ua")
+	if err != nil {
+		t.Fatalf("Callback: %v", err)
+	}
+	if result.User.Role != "guest" || result.User.UserName != "alice_smith_2" {
+		t.Errorf("user = %s (%s), want guest alice_smith_2", result.User.UserName, result.User.Role)
+	}
+	if result.Token != "token_alice_smith_2" || len(sessions.created) != 1 {
+		t.Errorf("token = %q with %d sessions, want a session-backed token", result.Token, len(sessions.created))
+	}
+
+	if _, err := oidc.Callback(ctx, "keycloak", start.State, "code", "", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("replayed state: err = %v, want unauthorized", err)
+	}
+
+	again, err := oidc.StartLogin(ctx, "keycloak")
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+	second, err := oidc.Callback(ctx, "keycloak", again.State, "code", "", "")
+	if err != nil {
+		t.Fatalf("second Callback: %v", err)
+	}
+	if second.User.ID != result.User.ID {
+		t.Errorf("second sign-in got user %d, want %d", second.User.ID, result.User.ID)
+	}
+}
+
+func TestOIDCCallback_WithoutAutoProvision(t *testing.T) {
+	oidc, _, _ := newOIDCFixture(auth.ExternalIdentity{Subject: "sub-1"})
+	oidc.SetAutoProvision(false)
+	ctx := context.Background()
+
+	start, err := oidc.StartLogin(ctx, "keycloak")
+	if err != nil {
+		t.Fatalf("StartLogin: %v", err)
+	}
+	if _, err := oidc.Callback(ctx, "keycloak", start.State, "code", "", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("err = %v, want unauthorized", err)
+	}
+	if _, err := oidc.StartLogin(ctx, "github"); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("unknown provider: err = %v, want not found", err)
+	}
+}
+
+func TestOIDCLinkAndUnlink(t *testing.T) {
+	oidc, store, sessions := newOIDCFixture(auth.ExternalIdentity{Subject: "sub-1"})
+	addTestUser(store.mockUserStore, 1, "bob", "pw")
+	ctx := context.Background()
+
+	start, err := oidc.StartLink(ctx, "keycloak", 1)
+	if err != nil {
+		t.Fatalf("StartLink: %v", err)
+	}
+	result, err := oidc.Callback(ctx, "keycloak", start.State, "code", "", "")
+	if err != nil {
+		t.Fatalf("Callback: %v", err)
+	}
+	if !result.Linked || result.Token != "" || result.User.ID != 1 || len(sessions.created) != 0 {
+		t.Fatalf("result = %+v, want a link without a new session", result)
+	}
+
+	login, _ := oidc.StartLogin(ctx, "keycloak")
+	signedIn, err := oidc.Callback(ctx, "keycloak", login.State, "code", "", "")
+	if err != nil || signedIn.User.ID != 1 {
+		t.Fatalf("sign-in through linked identity: %+v, %v", signedIn, err)
+	}
+
+	addTestUser(store.mockUserStore, 2, "carol", "pw")
+	other, _ := oidc.StartLink(ctx, "keycloak", 2)
+	if _, err := oidc.Callback(ctx, "keycloak", other.State, "code", "", ""); !errors.Is(err, errs.ErrConflict) {
+		t.Errorf("identity of another user: err = %v, want conflict", err)
+	}
+
+	identities, err := oidc.ListIdentities(ctx, 1)
+	if err != nil || len(identities) != 1 {
+		t.Fatalf("identities = %+v, %v", identities, err)
+	}
+	if err := oidc.Unlink(ctx, 1, identities[0].ID); err != nil {
+		t.Fatalf("Unlink: %v", err)
+	}
+}
+
+func TestOIDCUnlink_KeepsLastLoginMethod(t *testing.T) {
+	oidc, store, _ := newOIDCFixture(auth.ExternalIdentity{Subject: "sub-1", UserName: "dave"})
+	ctx := context.Background()
+
+	start, _ := oidc.StartLogin(ctx, "keycloak")
+	result, err := oidc.Callback(ctx, "keycloak", start.State, "code", "", "")
+	if err != nil {
+		t.Fatalf("Callback: %v", err)
+	}
+	if err := oidc.Unlink(ctx, result.User.ID, store.identities[0].ID); !errors.Is(err, errs.ErrConflict) {
+		t.Errorf("err = %v, want conflict for a passwordless user's last identity", err)
+	}
+}
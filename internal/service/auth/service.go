@@ -2,10 +2,15 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
@@ -26,6 +31,11 @@ type SessionStore interface {
 	RevokeSession(ctx context.Context, jti string) error
 	RevokeSessionByID(ctx context.Context, arg db.RevokeSessionByIDParams) error
 	RevokeAllUserSessions(ctx context.Context, userID int64) error
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+	CreateRefreshToken(ctx context.Context, arg db.CreateRefreshTokenParams) error
+	UseRefreshToken(ctx context.Context, tokenHash string) (int64, error)
+	GetRefreshToken(ctx context.Context, tokenHash string) (db.GetRefreshTokenRow, error)
+	GetSessionForRefresh(ctx context.Context, id int64) (db.GetSessionForRefreshRow, error)
 }
 
 // sessionTouchInterval throttles last-seen updates so a read endpoint does not
@@ -35,23 +45,77 @@ const sessionTouchInterval = 5 * time.Minute
 type JWTManager interface {
 	Generate(userID int64, role, userName, jti string) (string, error)
 	TTL() time.Duration
+	AccessTTL() time.Duration
 }
 
+var errRefreshTokenInvalid = fmt.Errorf("%w: refresh token is invalid or expired", errs.ErrUnauthorized)
+
 type PasswordChecker interface {
 	CheckPassword(hash, plain string) bool
 }
 
+// LoginThrottle slows down password guessing; ratelimit.LoginGuard
+// implements it.
+type LoginThrottle interface {
+	Attempt(ctx context.Context, userName, ipAddress string) (time.Duration, error)
+	Succeeded(ctx context.Context, userName, ipAddress string) error
+}
+
 type Service struct {
-	store    UserStore
-	sessions SessionStore
-	jwt      JWTManager
-	checker  PasswordChecker
+	store     UserStore
+	sessions  SessionStore
+	jwt       JWTManager
+	checker   PasswordChecker
+	twoFactor *TwoFactorService
+	throttle  LoginThrottle
+	tx        TxRunner
 }
 
 func NewService(store UserStore, sessions SessionStore, jwt JWTManager, checker PasswordChecker) *Service {
 	return &Service{store: store, sessions: sessions, jwt: jwt, checker: checker}
 }
 
+// SetTwoFactor makes sign-ins ask for a second factor where one is enabled or
+// required by the role.
+func (s *Service) SetTwoFactor(tf *TwoFactorService) {
+	s.twoFactor = tf
+}
+
+// SetLoginThrottle makes Login refuse attempts while the user name or the
+// client address is waiting out earlier failures.
+func (s *Service) SetLoginThrottle(t LoginThrottle) {
+	s.throttle = t
+}
+
+// SetTxRunner creates a session together with its first refresh token and
+// makes refresh token rotation atomic.
+func (s *Service) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+func (s *Service) inTx(ctx context.Context, fn func(q SessionStore) error) error {
+	if s.tx != nil {
+		return s.tx.RunInTx(ctx, func(q *db.Queries) error { return fn(q) })
+	}
+	return fn(s.sessions)
+}
+
+// LoginResult is either a session (Token and User) or, when a second factor
+// is needed, a Challenge to pass to CompleteTwoFactor.
+type LoginResult struct {
+	// Token is a short-lived access token; RefreshToken renews it until the
+	// session expires at SessionExpiresAt.
+	Token            string
+	TokenExpiresAt   time.Time
+	RefreshToken     string
+	SessionExpiresAt time.Time
+	User             *usersvc.User
+	Challenge        *Challenge
+	// RecoveryCodes is set once, when the login also completed a required
+	// enrollment.
+	RecoveryCodes []string
+}
+
 // Session is the public view of an active login.
 type Session struct {
 	ID         int64     `json:"id"`
@@ -63,51 +127,248 @@ type Session struct {
 	Current    bool      `json:"current"`
 }
 
-func (s *Service) Login(ctx context.Context, userName, password, ipAddress, userAgent string) (string, *usersvc.User, error) {
+func (s *Service) Login(ctx context.Context, userName, password, ipAddress, userAgent string) (*LoginResult, error) {
+	if err := s.checkThrottle(ctx, userName, ipAddress); err != nil {
+		return nil, err
+	}
+
 	dbUser, err := s.store.GetUserByUserName(ctx, userName)
 	if err != nil {
-		return "", nil, errs.ErrUnauthorized
+		return nil, errs.ErrUnauthorized
 	}
 
 	if dbUser.PasswordDigest == nil || !s.checker.CheckPassword(*dbUser.PasswordDigest, password) {
-		return "", nil, errs.ErrUnauthorized
+		return nil, errs.ErrUnauthorized
+	}
+	if s.throttle != nil {
+		if err := s.throttle.Succeeded(ctx, userName, ipAddress); err != nil {
+			slog.Warn("resetting login failures failed", "user_id", dbUser.ID, "error", err)
+		}
+	}
+
+	if dbUser.IsBlocked {
+		return nil, fmt.Errorf("%w: account is blocked", errs.ErrForbidden)
 	}
 
+	return s.signIn(ctx, dbUser, ipAddress, userAgent)
+}
+
+// checkThrottle counts a login attempt as failed until the password proves
+// right and refuses it when it comes too soon after failed ones. The throttle
+// failing open keeps sign-in working when its store is down.
+func (s *Service) checkThrottle(ctx context.Context, userName, ipAddress string) error {
+	if s.throttle == nil {
+		return nil
+	}
+	wait, err := s.throttle.Attempt(ctx, userName, ipAddress)
+	if err != nil {
+		slog.Warn("login throttle unavailable", "error", err)
+		return nil
+	}
+	if wait > 0 {
+		slog.Warn("login throttled", "user_name", userName, "ip", ipAddress, "retry_after", wait)
+		return errs.NewRateLimitError(wait)
+	}
+	return nil
+}
+
+// signIn finishes a successful first factor: it returns a challenge when a
+// second factor is needed and a session otherwise.
+func (s *Service) signIn(ctx context.Context, dbUser db.User, ipAddress, userAgent string) (*LoginResult, error) {
+	if s.twoFactor != nil {
+		challenge, err := s.twoFactor.challengeFor(ctx, dbUser)
+		if err != nil {
+			return nil, err
+		}
+		if challenge != nil {
+			return &LoginResult{Challenge: challenge}, nil
+		}
+	}
+	return s.issueSession(ctx, dbUser, ipAddress, userAgent)
+}
+
+// CompleteTwoFactor passes a login challenge with an authenticator code or a
+// recovery code and creates the session. For a challenge that requires
+// enrollment, the code confirms the secret from EnrollWithChallenge and the
+// result carries the new recovery codes.
+func (s *Service) CompleteTwoFactor(ctx context.Context, challengeToken, code, recoveryCode, ipAddress, userAgent string) (*LoginResult, error) {
+	if s.twoFactor == nil {
+		return nil, errChallengeInvalid
+	}
+	challenge, err := s.twoFactor.challenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	dbUser, err := s.store.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, errChallengeInvalid
+	}
 	if dbUser.IsBlocked {
-		return "", nil, fmt.Errorf("%w: account is blocked", errs.ErrForbidden)
+		return nil, fmt.Errorf("%w: account is blocked", errs.ErrForbidden)
+	}
+
+	var recoveryCodes []string
+	if challenge.Enrollment {
+		recoveryCodes, err = s.twoFactor.ConfirmEnrollment(ctx, dbUser.ID, code)
+		var ve *errs.ValidationError
+		if errors.As(err, &ve) {
+			err = errSecondFactorInvalid
+		}
+	} else {
+		err = s.twoFactor.verify(ctx, dbUser.ID, code, recoveryCode)
+	}
+	if errors.Is(err, errSecondFactorInvalid) {
+		s.twoFactor.failChallenge(ctx, challengeToken)
+		slog.Warn("second factor rejected", "user_id", dbUser.ID, "ip", ipAddress)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactor.store.DeleteLoginChallenge(ctx, challenge.TokenHash); err != nil {
+		return nil, fmt.Errorf("closing login challenge: %w", err)
+	}
+	result, err := s.issueSession(ctx, dbUser, ipAddress, userAgent)
+	if err != nil {
+		return nil, err
 	}
+	result.RecoveryCodes = recoveryCodes
+	return result, nil
+}
 
+// EnrollWithChallenge starts the enrollment a role requires, authorized by the
+// login challenge instead of a session.
+func (s *Service) EnrollWithChallenge(ctx context.Context, challengeToken string) (*Enrollment, error) {
+	if s.twoFactor == nil {
+		return nil, errChallengeInvalid
+	}
+	challenge, err := s.twoFactor.challenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.Enrollment {
+		return nil, errTwoFactorEnabled
+	}
+	dbUser, err := s.store.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, errChallengeInvalid
+	}
+	return s.twoFactor.BeginEnrollment(ctx, dbUser.ID, dbUser.UserName)
+}
+
+// issueSession records the login and returns an access token and the first
+// refresh token of a new session. Every way of signing in ends here.
+func (s *Service) issueSession(ctx context.Context, dbUser db.User, ipAddress, userAgent string) (*LoginResult, error) {
 	jti, err := auth.NewSessionID()
 	if err != nil {
-		return "", nil, fmt.Errorf("generating session id: %w", err)
+		return nil, fmt.Errorf("generating session id: %w", err)
+	}
+	refreshToken, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating refresh token: %w", err)
 	}
 
 	ip := strToPtr(ipAddress)
 	// Order matters: record login and sign the token before the session row.
-	// CreateSession runs last so a failure leaves no orphan session, and a
+	// The session is created last so a failure leaves no orphan session, and a
 	// returned token always has a backing session.
 	dbUser, err = s.store.SetUserLastLogin(ctx, db.SetUserLastLoginParams{ID: dbUser.ID, LastLoginIp: ip})
 	if err != nil {
-		return "", nil, fmt.Errorf("recording login: %w", err)
+		return nil, fmt.Errorf("recording login: %w", err)
 	}
 
 	token, err := s.jwt.Generate(dbUser.ID, dbUser.Role, dbUser.UserName, jti)
 	if err != nil {
-		return "", nil, fmt.Errorf("generating token: %w", err)
+		return nil, fmt.Errorf("generating token: %w", err)
 	}
 
-	if _, err := s.sessions.CreateSession(ctx, db.CreateSessionParams{
-		UserID:    dbUser.ID,
-		Jti:       jti,
-		IpAddress: ip,
-		UserAgent: strToPtr(userAgent),
-		ExpiresAt: time.Now().Add(s.jwt.TTL()),
-	}); err != nil {
-		return "", nil, fmt.Errorf("creating session: %w", err)
+	now := time.Now()
+	expiresAt := now.Add(s.jwt.TTL())
+	err = s.inTx(ctx, func(q SessionStore) error {
+		session, err := q.CreateSession(ctx, db.CreateSessionParams{
+			UserID:    dbUser.ID,
+			Jti:       jti,
+			IpAddress: ip,
+			UserAgent: strToPtr(userAgent),
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+		return q.CreateRefreshToken(ctx, db.CreateRefreshTokenParams{SessionID: session.ID, TokenHash: auth.HashToken(refreshToken)})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating session: %w", err)
 	}
 
 	u := userFromDB(dbUser)
-	return token, &u, nil
+	return &LoginResult{
+		Token:            token,
+		TokenExpiresAt:   now.Add(s.jwt.AccessTTL()),
+		RefreshToken:     refreshToken,
+		SessionExpiresAt: expiresAt,
+		User:             &u,
+	}, nil
+}
+
+// Refresh trades a refresh token for a new access token and a new refresh
+// token of the same session; the result has no User. Each refresh token works
+// once: presenting a used one means it was copied, so the whole session is
+// revoked and both the thief and the user have to sign in again.
+func (s *Service) Refresh(ctx context.Context, refreshToken, ipAddress string) (*LoginResult, error) {
+	hash := auth.HashToken(refreshToken)
+	next, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating refresh token: %w", err)
+	}
+
+	var session db.GetSessionForRefreshRow
+	err = s.inTx(ctx, func(q SessionStore) error {
+		sessionID, err := q.UseRefreshToken(ctx, hash)
+		if err != nil {
+			return err
+		}
+		session, err = q.GetSessionForRefresh(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if session.RevokedAt.Valid || time.Now().After(session.ExpiresAt) || session.IsBlocked {
+			return errRefreshTokenInvalid
+		}
+		return q.CreateRefreshToken(ctx, db.CreateRefreshTokenParams{SessionID: session.ID, TokenHash: auth.HashToken(next)})
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		s.detectRefreshReuse(ctx, hash, ipAddress)
+		return nil, errRefreshTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.jwt.Generate(session.UserID, session.Role, session.UserName, session.Jti)
+	if err != nil {
+		return nil, fmt.Errorf("generating token: %w", err)
+	}
+	_ = s.sessions.TouchSession(ctx, db.TouchSessionParams{Jti: session.Jti, IpAddress: strToPtr(ipAddress)})
+	return &LoginResult{
+		Token:            token,
+		TokenExpiresAt:   time.Now().Add(s.jwt.AccessTTL()),
+		RefreshToken:     next,
+		SessionExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// detectRefreshReuse revokes the session of a refresh token that was already
+// rotated away.
+func (s *Service) detectRefreshReuse(ctx context.Context, hash, ipAddress string) {
+	row, err := s.sessions.GetRefreshToken(ctx, hash)
+	if err != nil || !row.UsedAt.Valid {
+		return
+	}
+	slog.Warn("refresh token reused, revoking session", "user_id", row.UserID, "session_id", row.SessionID, "ip", ipAddress)
+	if err := s.sessions.RevokeSessionByID(ctx, db.RevokeSessionByIDParams{ID: row.SessionID, UserID: row.UserID}); err != nil {
+		slog.Error("failed to revoke session after refresh token reuse", "session_id", row.SessionID, "error", err)
+	}
 }
 
 // VerifyUserPassword reports whether the given plaintext password matches the
@@ -186,37 +447,60 @@ func (s *Service) ListSessions(ctx context.Context, userID int64, currentJTI str
 
 // RevokeUserSession revokes a single session owned by the given user.
 func (s *Service) RevokeUserSession(ctx context.Context, userID, sessionID int64) error {
-	return s.sessions.RevokeSessionByID(ctx, db.RevokeSessionByIDParams{ID: sessionID, UserID: userID})
+	return s.inTx(ctx, func(q SessionStore) error {
+		if err := q.RevokeSessionByID(ctx, db.RevokeSessionByIDParams{ID: sessionID, UserID: userID}); err != nil {
+			return err
+		}
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityUser,
+			EntityID:   userID,
+			Action:     audit.ActionRevokeSession,
+			After:      map[string]int64{"session_id": sessionID},
+		})
+	})
 }
 
 // RevokeAllSessions revokes every active session for a user (used on block).
 func (s *Service) RevokeAllSessions(ctx context.Context, userID int64) error {
-	return s.sessions.RevokeAllUserSessions(ctx, userID)
+	return s.inTx(ctx, func(q SessionStore) error {
+		if err := q.RevokeAllUserSessions(ctx, userID); err != nil {
+			return err
+		}
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityUser,
+			EntityID:   userID,
+			Action:     audit.ActionRevokeSessions,
+		})
+	})
 }
 
 func userFromDB(u db.User) usersvc.User {
-	var lastLoginAt *time.Time
+	var lastLoginAt, emailVerifiedAt *time.Time
 	if u.LastLoginAt.Valid {
 		lastLoginAt = &u.LastLoginAt.Time
 	}
+	if u.EmailVerifiedAt.Valid {
+		emailVerifiedAt = &u.EmailVerifiedAt.Time
+	}
 	return usersvc.User{
-		ID:          u.ID,
-		UserName:    u.UserName,
-		DisplayName: u.DisplayName,
-		Language:    userLanguage(u.Language),
-		Theme:       userTheme(u.Theme),
-		Role:        u.Role,
-		Rating:      int(u.Rating),
-		AvatarUrl:   u.AvatarUrl,
-		Bio:         u.Bio,
-		Telegram:    u.Telegram,
-		Github:      u.Github,
-		Email:       u.Email,
-		LastLoginIp: u.LastLoginIp,
-		LastLoginAt: lastLoginAt,
-		IsBlocked:   u.IsBlocked,
-		CreatedAt:   u.CreatedAt,
-		UpdatedAt:   u.UpdatedAt,
+		ID:              u.ID,
+		UserName:        u.UserName,
+		DisplayName:     u.DisplayName,
+		Language:        userLanguage(u.Language),
+		Theme:           userTheme(u.Theme),
+		Role:            u.Role,
+		Rating:          int(u.Rating),
+		AvatarUrl:       u.AvatarUrl,
+		Bio:             u.Bio,
+		Telegram:        u.Telegram,
+		Github:          u.Github,
+		Email:           u.Email,
+		EmailVerifiedAt: emailVerifiedAt,
+		LastLoginIp:     u.LastLoginIp,
+		LastLoginAt:     lastLoginAt,
+		IsBlocked:       u.IsBlocked,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
 	}
 }
 
@@ -6,9 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/ratelimit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 )
 
@@ -54,6 +57,14 @@ type mockSessionStore struct {
 	authRow    db.GetSessionForAuthRow
 	authErr    error
 	touchCalls int
+	refresh    map[string]*mockRefreshToken
+	revoked    map[int64]bool
+	audit      []db.InsertAuditEventParams
+}
+
+type mockRefreshToken struct {
+	sessionID int64
+	used      bool
 }
 
 func (m *mockSessionStore) CreateSession(_ context.Context, arg db.CreateSessionParams) (db.UserSession, error) {
@@ -79,12 +90,58 @@ func (m *mockSessionStore) TouchSession(_ context.Context, _ db.TouchSessionPara
 
 func (m *mockSessionStore) RevokeSession(_ context.Context, _ string) error { return nil }
 
-func (m *mockSessionStore) RevokeSessionByID(_ context.Context, _ db.RevokeSessionByIDParams) error {
+func (m *mockSessionStore) RevokeSessionByID(_ context.Context, arg db.RevokeSessionByIDParams) error {
+	if m.revoked == nil {
+		m.revoked = map[int64]bool{}
+	}
+	m.revoked[arg.ID] = true
 	return nil
 }
 
 func (m *mockSessionStore) RevokeAllUserSessions(_ context.Context, _ int64) error { return nil }
 
+func (m *mockSessionStore) InsertAuditEvent(_ context.Context, arg db.InsertAuditEventParams) error {
+	m.audit = append(m.audit, arg)
+	return nil
+}
+
+func (m *mockSessionStore) CreateRefreshToken(_ context.Context, arg db.CreateRefreshTokenParams) error {
+	if m.refresh == nil {
+		m.refresh = map[string]*mockRefreshToken{}
+	}
+	m.refresh[arg.TokenHash] = &mockRefreshToken{sessionID: arg.SessionID}
+	return nil
+}
+
+func (m *mockSessionStore) UseRefreshToken(_ context.Context, tokenHash string) (int64, error) {
+	rt, ok := m.refresh[tokenHash]
+	if !ok || rt.used {
+		return 0, pgx.ErrNoRows
+	}
+	rt.used = true
+	return rt.sessionID, nil
+}
+
+func (m *mockSessionStore) GetRefreshToken(_ context.Context, tokenHash string) (db.GetRefreshTokenRow, error) {
+	rt, ok := m.refresh[tokenHash]
+	if !ok {
+		return db.GetRefreshTokenRow{}, pgx.ErrNoRows
+	}
+	row := db.GetRefreshTokenRow{SessionID: rt.sessionID, UserID: m.created[rt.sessionID-1].UserID}
+	row.UsedAt.Valid = rt.used
+	return row, nil
+}
+
+func (m *mockSessionStore) GetSessionForRefresh(_ context.Context, id int64) (db.GetSessionForRefreshRow, error) {
+	if id < 1 || int(id) > len(m.created) {
+		return db.GetSessionForRefreshRow{}, pgx.ErrNoRows
+	}
+	s := m.created[id-1]
+	row := db.GetSessionForRefreshRow{ID: id, Jti: s.Jti, UserID: s.UserID, ExpiresAt: s.ExpiresAt, UserName: "refreshed", Role: "player"}
+	row.RevokedAt.Valid = m.revoked[id]
+	return row, nil
+}
+
 type mockJWT struct{}
 
 func (m *mockJWT) Generate(_ int64, _, userName, _ string) (string, error) {
@@ -93,6 +150,8 @@ func (m *mockJWT) Generate(_ int64, _, userName, _ string) (string, error) {
 
 func (m *mockJWT) TTL() time.Duration { return time.Hour }
 
+func (m *mockJWT) AccessTTL() time.Duration { return 15 * time.Minute }
+
 type mockChecker struct{}
 
 func (m *mockChecker) CheckPassword(hash, plain string) bool {
@@ -118,10 +177,11 @@ func TestLogin_Success(t *testing.T) {
 	addTestUser(store, 1, "alice", "password123")
 
 	svc := NewService(store, &mockSessionStore{}, &mockJWT{}, &mockChecker{})
-	token, user, err := svc.Login(context.Background(), "alice", "password123", "1.2.3.4", "ua")
+	result, err := svc.Login(context.Background(), "alice", "password123", "1.2.3.4", "ua")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
+	token, user := result.Token, result.User
 	if token != "token_alice" {
 		t.Errorf("expected token_alice, got %s", token)
 	}
@@ -139,10 +199,52 @@ func TestLogin_Success(t *testing.T) {
 	}
 }
 
+func TestRefresh_RotatesAndDetectsReuse(t *testing.T) {
+	store := newMockUserStore()
+	addTestUser(store, 1, "alice", "password123")
+	sessions := &mockSessionStore{}
+	svc := NewService(store, sessions, &mockJWT{}, &mockChecker{})
+	ctx := context.Background()
+
+	login, err := svc.Login(ctx, "alice", "password123", "", "")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if login.RefreshToken == "" || !login.TokenExpiresAt.Before(login.SessionExpiresAt) {
+		t.Fatalf("login = %+v, want a refresh token outliving the access token", login)
+	}
+
+	refreshed, err := svc.Refresh(ctx, login.RefreshToken, "")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if refreshed.Token == "" || refreshed.RefreshToken == login.RefreshToken {
+		t.Fatalf("refreshed = %+v, want a new access and refresh token", refreshed)
+	}
+	if !refreshed.SessionExpiresAt.Equal(login.SessionExpiresAt) {
+		t.Errorf("refresh moved the session expiry from %v to %v", login.SessionExpiresAt, refreshed.SessionExpiresAt)
+	}
+
+	// The first token was rotated away; using it again revokes the session,
+	// so the newer token stops working as well.
+	if _, err := svc.Refresh(ctx, login.RefreshToken, ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Fatalf("reused token: err = %v, want unauthorized", err)
+	}
+	if !sessions.revoked[1] {
+		t.Fatal("reusing a refresh token must revoke the session")
+	}
+	if _, err := svc.Refresh(ctx, refreshed.RefreshToken, ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("token of a revoked session: err = %v, want unauthorized", err)
+	}
+	if _, err := svc.Refresh(ctx, "unknown", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("unknown token: err = %v, want unauthorized", err)
+	}
+}
+
 func TestLogin_UserNotFound(t *testing.T) {
 	store := newMockUserStore()
 	svc := NewService(store, &mockSessionStore{}, &mockJWT{}, &mockChecker{})
-	_, _, err := svc.Login(context.Background(), "nobody", "pass", "", "")
+	_, err := svc.Login(context.Background(), "nobody", "pass", "", "")
 	if err != errs.ErrUnauthorized {
 		t.Errorf("expected ErrUnauthorized, got %v", err)
 	}
@@ -153,7 +255,7 @@ func TestLogin_WrongPassword(t *testing.T) {
 	addTestUser(store, 1, "alice", "password123")
 
 	svc := NewService(store, &mockSessionStore{}, &mockJWT{}, &mockChecker{})
-	_, _, err := svc.Login(context.Background(), "alice", "wrong", "", "")
+	_, err := svc.Login(context.Background(), "alice", "wrong", "", "")
 	if err != errs.ErrUnauthorized {
 		t.Errorf("expected ErrUnauthorized, got %v", err)
 	}
@@ -167,12 +269,39 @@ func TestLogin_NilPasswordDigest(t *testing.T) {
 	store.byID[2] = store.users["bob"]
 
 	svc := NewService(store, &mockSessionStore{}, &mockJWT{}, &mockChecker{})
-	_, _, err := svc.Login(context.Background(), "bob", "any", "", "")
+	_, err := svc.Login(context.Background(), "bob", "any", "", "")
 	if err != errs.ErrUnauthorized {
 		t.Errorf("expected ErrUnauthorized, got %v", err)
 	}
 }
 
+func TestLogin_Throttled(t *testing.T) {
+	store := newMockUserStore()
+	addTestUser(store, 1, "alice", "password123")
+
+	svc := NewService(store, &mockSessionStore{}, &mockJWT{}, &mockChecker{})
+	svc.SetLoginThrottle(ratelimit.NewLoginGuard(ratelimit.NewMemoryStore(), ratelimit.LoginPolicy{
+		MaxUserFailures: 3,
+		Lockout:         time.Minute,
+	}))
+	ctx := context.Background()
+
+	// The second failure already makes the next attempt wait.
+	for range 2 {
+		if _, err := svc.Login(ctx, "alice", "wrong", "1.2.3.4", ""); !errors.Is(err, errs.ErrUnauthorized) {
+			t.Fatalf("wrong password: expected ErrUnauthorized, got %v", err)
+		}
+	}
+	_, err := svc.Login(ctx, "alice", "password123", "1.2.3.4", "")
+	var rl *errs.RateLimitError
+	if !errors.As(err, &rl) || rl.RetryAfter <= 0 || rl.RetryAfter > time.Second {
+		t.Fatalf("throttled user: expected a RateLimitError, got %v", err)
+	}
+	if _, err := svc.Login(ctx, "bob", "wrong", "1.2.3.4", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Fatalf("other user: expected ErrUnauthorized, got %v", err)
+	}
+}
+
 func TestMe_Success(t *testing.T) {
 	store := newMockUserStore()
 	addTestUser(store, 1, "alice", "password123")
@@ -254,6 +383,32 @@ func TestValidateAndTouch(t *testing.T) {
 	})
 }
 
+func TestRevokeUserSession_Audited(t *testing.T) {
+	sessions := &mockSessionStore{}
+	svc := NewService(newMockUserStore(), sessions, &mockJWT{}, &mockChecker{})
+	ctx := audit.WithActor(context.Background(), audit.Actor{UserID: 1, UserName: "root", IP: "10.0.0.1", RequestID: "req-1"})
+
+	if err := svc.RevokeUserSession(ctx, 7, 42); err != nil {
+		t.Fatalf("RevokeUserSession: %v", err)
+	}
+	if !sessions.revoked[42] {
+		t.Fatal("session 42 not revoked")
+	}
+	if len(sessions.audit) != 1 {
+		t.Fatalf("audit events = %d, want 1", len(sessions.audit))
+	}
+	event := sessions.audit[0]
+	if event.Action != audit.ActionRevokeSession || event.EntityType != audit.EntityUser || event.EntityID != "7" {
+		t.Errorf("audit event = %s %s/%s", event.Action, event.EntityType, event.EntityID)
+	}
+	if event.ActorID == nil || *event.ActorID != 1 || *event.IpAddress != "10.0.0.1" || *event.RequestID != "req-1" {
+		t.Errorf("audit actor = %v %v %v", event.ActorID, event.IpAddress, event.RequestID)
+	}
+	if string(event.After) != `{"session_id":42}` {
+		t.Errorf("audit after = %s", event.After)
+	}
+}
+
 func TestMe_NotFound(t *testing.T) {
 	store := newMockUserStore()
 	svc := NewService(store, &mockSessionStore{}, &mockJWT{}, &mockChecker{})
@@ -0,0 +1,436 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+const (
+	// loginChallengeTTL is how long the second login step may take.
+	loginChallengeTTL = 5 * time.Minute
+	// maxChallengeAttempts wrong codes end a challenge; the user starts over
+	// with the password.
+	maxChallengeAttempts = 5
+	recoveryCodeCount    = 10
+
+	fieldCode  = "code"
+	fieldRoles = "roles"
+)
+
+var (
+	errTwoFactorNotConfigured = fmt.Errorf("%w: two-factor authentication is not configured on this server", errs.ErrConflict)
+	errTwoFactorEnabled       = fmt.Errorf("%w: two-factor authentication is already enabled", errs.ErrConflict)
+	errTwoFactorNotEnabled    = fmt.Errorf("%w: two-factor authentication is not enabled", errs.ErrConflict)
+	errTwoFactorRequired      = fmt.Errorf("%w: two-factor authentication is required for your role", errs.ErrForbidden)
+	errChallengeInvalid       = fmt.Errorf("%w: login challenge is invalid or expired", errs.ErrUnauthorized)
+	errSecondFactorInvalid    = fmt.Errorf("%w: invalid two-factor code", errs.ErrUnauthorized)
+
+	twoFactorRoles = map[string]bool{"guest": true, "player": true, "admin": true}
+)
+
+// SecretSealer encrypts TOTP secrets at rest (satisfied by *auth.SecretBox).
+type SecretSealer interface {
+	Seal(plaintext []byte) ([]byte, error)
+	Open(ciphertext []byte) ([]byte, error)
+}
+
+type TwoFactorStore interface {
+	GetUserTwoFactor(ctx context.Context, userID int64) (db.UserTwoFactor, error)
+	StartUserTwoFactorEnrollment(ctx context.Context, arg db.StartUserTwoFactorEnrollmentParams) (db.UserTwoFactor, error)
+	UseUserTwoFactorStep(ctx context.Context, arg db.UseUserTwoFactorStepParams) (int64, error)
+	DeleteUserTwoFactor(ctx context.Context, userID int64) error
+	CreateUserRecoveryCode(ctx context.Context, arg db.CreateUserRecoveryCodeParams) error
+	UseUserRecoveryCode(ctx context.Context, arg db.UseUserRecoveryCodeParams) (int64, error)
+	CountUnusedUserRecoveryCodes(ctx context.Context, userID int64) (int64, error)
+	DeleteUserRecoveryCodes(ctx context.Context, userID int64) error
+	CreateLoginChallenge(ctx context.Context, arg db.CreateLoginChallengeParams) error
+	GetLoginChallenge(ctx context.Context, tokenHash string) (db.LoginChallenge, error)
+	RecordLoginChallengeFailure(ctx context.Context, tokenHash string) (int32, error)
+	DeleteLoginChallenge(ctx context.Context, tokenHash string) error
+	DeleteExpiredLoginChallenges(ctx context.Context) error
+	ListTwoFactorRequiredRoles(ctx context.Context) ([]string, error)
+	IsTwoFactorRequiredForRole(ctx context.Context, role string) (bool, error)
+	DeleteTwoFactorRequiredRoles(ctx context.Context) error
+	AddTwoFactorRequiredRole(ctx context.Context, arg db.AddTwoFactorRequiredRoleParams) error
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+}
+
+// TwoFactorService manages TOTP enrollment, recovery codes and the roles that
+// must use a second factor. Service consults it on every sign-in.
+type TwoFactorService struct {
+	store  TwoFactorStore
+	box    SecretSealer
+	issuer string
+	tx     TxRunner
+	now    func() time.Time
+}
+
+// NewTwoFactorService builds the service; without a box, enrollment is
+// refused and nobody can be asked for a second factor.
+func NewTwoFactorService(store TwoFactorStore, box SecretSealer, issuer string) *TwoFactorService {
+	return &TwoFactorService{store: store, box: box, issuer: issuer, now: time.Now}
+}
+
+// SetTxRunner makes replacing recovery codes and role policies atomic.
+func (s *TwoFactorService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+func (s *TwoFactorService) inTx(ctx context.Context, fn func(q TwoFactorStore) error) error {
+	if s.tx != nil {
+		return s.tx.RunInTx(ctx, func(q *db.Queries) error { return fn(q) })
+	}
+	return fn(s.store)
+}
+
+// TwoFactorStatus is what a user sees about their own second factor.
+type TwoFactorStatus struct {
+	Enabled                bool
+	EnabledAt              *time.Time
+	RecoveryCodesRemaining int
+	// Required is set when the user's role must use a second factor.
+	Required bool
+}
+
+// Enrollment is a new, not yet confirmed authenticator secret.
+type Enrollment struct {
+	Secret    string
+	URL       string
+	QRCodePNG []byte
+}
+
+// Challenge is handed out instead of a session when the password was right
+// but a second factor is still needed.
+type Challenge struct {
+	Token     string
+	ExpiresAt time.Time
+	// EnrollmentRequired means the role needs 2FA and the user has none yet:
+	// the user enrolls with the challenge token before completing the login.
+	EnrollmentRequired bool
+}
+
+func (s *TwoFactorService) Status(ctx context.Context, userID int64, role string) (*TwoFactorStatus, error) {
+	required, err := s.store.IsTwoFactorRequiredForRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	status := &TwoFactorStatus{Required: required}
+	row, err := s.store.GetUserTwoFactor(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !row.ConfirmedAt.Valid) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := s.store.CountUnusedUserRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	status.Enabled = true
+	status.EnabledAt = &row.ConfirmedAt.Time
+	status.RecoveryCodesRemaining = int(remaining)
+	return status, nil
+}
+
+// BeginEnrollment generates a new secret for the user. Until a code confirms
+// it, sign-in works as before and enrollment can be restarted.
+func (s *TwoFactorService) BeginEnrollment(ctx context.Context, userID int64, userName string) (*Enrollment, error) {
+	if s.box == nil {
+		return nil, errTwoFactorNotConfigured
+	}
+	key, err := auth.NewTOTPKey(s.issuer, userName)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.box.Seal([]byte(key.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("sealing totp secret: %w", err)
+	}
+	_, err = s.store.StartUserTwoFactorEnrollment(ctx, db.StartUserTwoFactorEnrollmentParams{UserID: userID, SecretCiphertext: sealed})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errTwoFactorEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Enrollment{Secret: key.Secret, URL: key.URL, QRCodePNG: key.QRCodePNG}, nil
+}
+
+// ConfirmEnrollment enables 2FA with the first code from the authenticator
+// and returns fresh recovery codes. They are shown once and stored hashed.
+func (s *TwoFactorService) ConfirmEnrollment(ctx context.Context, userID int64, code string) ([]string, error) {
+	row, err := s.store.GetUserTwoFactor(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.NewValidationError(map[string]string{fieldCode: "start the enrollment first"})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if row.ConfirmedAt.Valid {
+		return nil, errTwoFactorEnabled
+	}
+	if err := s.useCode(ctx, row, code); err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldCode: "code does not match the authenticator"})
+	}
+	slog.Info("two-factor authentication enabled", "user_id", userID)
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking a
+// current authenticator code.
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error) {
+	row, err := s.enabledRow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.useCode(ctx, *row, code); err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldCode: "code does not match the authenticator"})
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// Disable turns 2FA off after checking a code or a recovery code. Users whose
+// role requires 2FA cannot turn it off; an admin can reset it instead.
+func (s *TwoFactorService) Disable(ctx context.Context, userID int64, role, code, recoveryCode string) error {
+	required, err := s.store.IsTwoFactorRequiredForRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errTwoFactorRequired
+	}
+	if _, err := s.enabledRow(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.verify(ctx, userID, code, recoveryCode); err != nil {
+		return err
+	}
+	if err := s.remove(ctx, userID); err != nil {
+		return err
+	}
+	slog.Info("two-factor authentication disabled", "user_id", userID)
+	return nil
+}
+
+// Reset removes a user's second factor without a code, for an admin helping
+// a user who lost the authenticator and the recovery codes.
+func (s *TwoFactorService) Reset(ctx context.Context, userID, actorID int64) error {
+	err := s.inTx(ctx, func(q TwoFactorStore) error {
+		if err := deleteTwoFactor(ctx, q, userID); err != nil {
+			return err
+		}
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityUser,
+			EntityID:   userID,
+			Action:     audit.ActionResetTwoFactor,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	slog.Info("two-factor authentication reset", "user_id", userID, "reset_by", actorID)
+	return nil
+}
+
+func (s *TwoFactorService) remove(ctx context.Context, userID int64) error {
+	return s.inTx(ctx, func(q TwoFactorStore) error {
+		return deleteTwoFactor(ctx, q, userID)
+	})
+}
+
+func deleteTwoFactor(ctx context.Context, q TwoFactorStore, userID int64) error {
+	if err := q.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+	return q.DeleteUserTwoFactor(ctx, userID)
+}
+
+// RequiredRoles lists the roles that must use a second factor.
+func (s *TwoFactorService) RequiredRoles(ctx context.Context) ([]string, error) {
+	roles, err := s.store.ListTwoFactorRequiredRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if roles == nil {
+		roles = []string{}
+	}
+	return roles, nil
+}
+
+// SetRequiredRoles replaces the roles that must use a second factor. Users of
+// those roles without 2FA are asked to enroll at their next sign-in.
+func (s *TwoFactorService) SetRequiredRoles(ctx context.Context, roles []string, actorID int64) ([]string, error) {
+	for _, role := range roles {
+		if !twoFactorRoles[role] {
+			return nil, errs.NewValidationError(map[string]string{fieldRoles: fmt.Sprintf("unknown role %q", role)})
+		}
+	}
+	if len(roles) > 0 && s.box == nil {
+		return nil, errTwoFactorNotConfigured
+	}
+	err := s.inTx(ctx, func(q TwoFactorStore) error {
+		if err := q.DeleteTwoFactorRequiredRoles(ctx); err != nil {
+			return err
+		}
+		for _, role := range roles {
+			if err := q.AddTwoFactorRequiredRole(ctx, db.AddTwoFactorRequiredRoleParams{Role: role, CreatedBy: &actorID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("two-factor role policy changed", "roles", roles, "changed_by", actorID)
+	return s.RequiredRoles(ctx)
+}
+
+// challengeFor returns the challenge a sign-in of this user must pass, or nil
+// when the password is enough.
+func (s *TwoFactorService) challengeFor(ctx context.Context, user db.User) (*Challenge, error) {
+	if s.box == nil {
+		return nil, nil
+	}
+	row, err := s.store.GetUserTwoFactor(ctx, user.ID)
+	enabled := err == nil && row.ConfirmedAt.Valid
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	enroll := false
+	if !enabled {
+		required, err := s.store.IsTwoFactorRequiredForRole(ctx, user.Role)
+		if err != nil {
+			return nil, err
+		}
+		if !required {
+			return nil, nil
+		}
+		enroll = true
+	}
+
+	token, err := auth.NewSessionID()
+	if err != nil {
+		return nil, fmt.Errorf("generating challenge token: %w", err)
+	}
+	if err := s.store.DeleteExpiredLoginChallenges(ctx); err != nil {
+		slog.Warn("failed to delete expired login challenges", "error", err)
+	}
+	expiresAt := s.now().Add(loginChallengeTTL)
+	if err := s.store.CreateLoginChallenge(ctx, db.CreateLoginChallengeParams{
+		TokenHash:  auth.HashToken(token),
+		UserID:     user.ID,
+		Enrollment: enroll,
+		ExpiresAt:  expiresAt,
+	}); err != nil {
+		return nil, fmt.Errorf("creating login challenge: %w", err)
+	}
+	return &Challenge{Token: token, ExpiresAt: expiresAt, EnrollmentRequired: enroll}, nil
+}
+
+func (s *TwoFactorService) challenge(ctx context.Context, token string) (db.LoginChallenge, error) {
+	row, err := s.store.GetLoginChallenge(ctx, auth.HashToken(token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.LoginChallenge{}, errChallengeInvalid
+	}
+	return row, err
+}
+
+// failChallenge counts a wrong code and ends the challenge after too many.
+func (s *TwoFactorService) failChallenge(ctx context.Context, token string) {
+	hash := auth.HashToken(token)
+	attempts, err := s.store.RecordLoginChallengeFailure(ctx, hash)
+	if err == nil && attempts >= maxChallengeAttempts {
+		err = s.store.DeleteLoginChallenge(ctx, hash)
+	}
+	if err != nil {
+		slog.Warn("failed to record login challenge failure", "error", err)
+	}
+}
+
+func (s *TwoFactorService) enabledRow(ctx context.Context, userID int64) (*db.UserTwoFactor, error) {
+	row, err := s.store.GetUserTwoFactor(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !row.ConfirmedAt.Valid) {
+		return nil, errTwoFactorNotEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// verify accepts either a current authenticator code or an unused recovery
+// code of an enabled second factor.
+func (s *TwoFactorService) verify(ctx context.Context, userID int64, code, recoveryCode string) error {
+	if recoveryCode != "" {
+		n, err := s.store.UseUserRecoveryCode(ctx, db.UseUserRecoveryCodeParams{UserID: userID, CodeHash: auth.HashRecoveryCode(recoveryCode)})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errSecondFactorInvalid
+		}
+		slog.Info("recovery code used", "user_id", userID)
+		return nil
+	}
+	row, err := s.enabledRow(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.useCode(ctx, *row, code)
+}
+
+// useCode checks code against the stored secret and burns its time step.
+func (s *TwoFactorService) useCode(ctx context.Context, row db.UserTwoFactor, code string) error {
+	if s.box == nil {
+		return errTwoFactorNotConfigured
+	}
+	secret, err := s.box.Open(row.SecretCiphertext)
+	if err != nil {
+		return fmt.Errorf("opening totp secret: %w", err)
+	}
+	step, ok := auth.VerifyTOTP(string(secret), code, s.now())
+	if !ok {
+		return errSecondFactorInvalid
+	}
+	n, err := s.store.UseUserTwoFactorStep(ctx, db.UseUserTwoFactorStepParams{UserID: row.UserID, Step: step})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errSecondFactorInvalid
+	}
+	return nil
+}
+
+func (s *TwoFactorService) replaceRecoveryCodes(ctx context.Context, userID int64) ([]string, error) {
+	codes, err := auth.NewRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, fmt.Errorf("generating recovery codes: %w", err)
+	}
+	err = s.inTx(ctx, func(q TwoFactorStore) error {
+		if err := q.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+			return err
+		}
+		for _, code := range codes {
+			if err := q.CreateUserRecoveryCode(ctx, db.CreateUserRecoveryCodeParams{UserID: userID, CodeHash: auth.HashRecoveryCode(code)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
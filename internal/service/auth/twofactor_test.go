@@ -0,0 +1,338 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type mockTwoFactorStore struct {
+	totp       map[int64]*db.UserTwoFactor
+	codes      map[int64]map[string]bool // hash -> used
+	challenges map[string]*db.LoginChallenge
+	roles      map[string]bool
+	audit      []db.InsertAuditEventParams
+}
+
+func newMockTwoFactorStore() *mockTwoFactorStore {
+	return &mockTwoFactorStore{
+		totp:       map[int64]*db.UserTwoFactor{},
+		codes:      map[int64]map[string]bool{},
+		challenges: map[string]*db.LoginChallenge{},
+		roles:      map[string]bool{},
+	}
+}
+
+func (m *mockTwoFactorStore) GetUserTwoFactor(_ context.Context, userID int64) (db.UserTwoFactor, error) {
+	row, ok := m.totp[userID]
+	if !ok {
+		return db.UserTwoFactor{}, pgx.ErrNoRows
+	}
+	return *row, nil
+}
+
+func (m *mockTwoFactorStore) StartUserTwoFactorEnrollment(_ context.Context, arg db.StartUserTwoFactorEnrollmentParams) (db.UserTwoFactor, error) {
+	if row, ok := m.totp[arg.UserID]; ok && row.ConfirmedAt.Valid {
+		return db.UserTwoFactor{}, pgx.ErrNoRows
+	}
+	row := &db.UserTwoFactor{UserID: arg.UserID, SecretCiphertext: arg.SecretCiphertext}
+	m.totp[arg.UserID] = row
+	return *row, nil
+}
+
+func (m *mockTwoFactorStore) UseUserTwoFactorStep(_ context.Context, arg db.UseUserTwoFactorStepParams) (int64, error) {
+	row, ok := m.totp[arg.UserID]
+	if !ok || (row.LastUsedStep != nil && *row.LastUsedStep >= arg.Step) {
+		return 0, nil
+	}
+	step := arg.Step
+	row.LastUsedStep = &step
+	if !row.ConfirmedAt.Valid {
+		row.ConfirmedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	}
+	return 1, nil
+}
+
+func (m *mockTwoFactorStore) DeleteUserTwoFactor(_ context.Context, userID int64) error {
+	delete(m.totp, userID)
+	return nil
+}
+
+func (m *mockTwoFactorStore) CreateUserRecoveryCode(_ context.Context, arg db.CreateUserRecoveryCodeParams) error {
+	if m.codes[arg.UserID] == nil {
+		m.codes[arg.UserID] = map[string]bool{}
+	}
+	m.codes[arg.UserID][arg.CodeHash] = false
+	return nil
+}
+
+func (m *mockTwoFactorStore) UseUserRecoveryCode(_ context.Context, arg db.UseUserRecoveryCodeParams) (int64, error) {
+	used, ok := m.codes[arg.UserID][arg.CodeHash]
+	if !ok || used {
+		return 0, nil
+	}
+	m.codes[arg.UserID][arg.CodeHash] = true
+	return 1, nil
+}
+
+func (m *mockTwoFactorStore) CountUnusedUserRecoveryCodes(_ context.Context, userID int64) (int64, error) {
+	var n int64
+	for _, used := range m.codes[userID] {
+		if !used {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *mockTwoFactorStore) DeleteUserRecoveryCodes(_ context.Context, userID int64) error {
+	delete(m.codes, userID)
+	return nil
+}
+
+func (m *mockTwoFactorStore) CreateLoginChallenge(_ context.Context, arg db.CreateLoginChallengeParams) error {
+	m.challenges[arg.TokenHash] = &db.LoginChallenge{TokenHash: arg.TokenHash, UserID: arg.UserID, Enrollment: arg.Enrollment, ExpiresAt: arg.ExpiresAt}
+	return nil
+}
+
+func (m *mockTwoFactorStore) GetLoginChallenge(_ context.Context, tokenHash string) (db.LoginChallenge, error) {
+	row, ok := m.challenges[tokenHash]
+	if !ok || !row.ExpiresAt.After(time.Now()) {
+		return db.LoginChallenge{}, pgx.ErrNoRows
+	}
+	return *row, nil
+}
+
+func (m *mockTwoFactorStore) RecordLoginChallengeFailure(_ context.Context, tokenHash string) (int32, error) {
+	row, ok := m.challenges[tokenHash]
+	if !ok {
+		return 0, pgx.ErrNoRows
+	}
+	row.Attempts++
+	return row.Attempts, nil
+}
+
+func (m *mockTwoFactorStore) DeleteLoginChallenge(_ context.Context, tokenHash string) error {
+	delete(m.challenges, tokenHash)
+	return nil
+}
+
+func (m *mockTwoFactorStore) DeleteExpiredLoginChallenges(_ context.Context) error { return nil }
+
+func (m *mockTwoFactorStore) ListTwoFactorRequiredRoles(_ context.Context) ([]string, error) {
+	var out []string
+	for role := range m.roles {
+		out = append(out, role)
+	}
+	return out, nil
+}
+
+func (m *mockTwoFactorStore) IsTwoFactorRequiredForRole(_ context.Context, role string) (bool, error) {
+	return m.roles[role], nil
+}
+
+func (m *mockTwoFactorStore) DeleteTwoFactorRequiredRoles(_ context.Context) error {
+	m.roles = map[string]bool{}
+	return nil
+}
+
+func (m *mockTwoFactorStore) AddTwoFactorRequiredRole(_ context.Context, arg db.AddTwoFactorRequiredRoleParams) error {
+	m.roles[arg.Role] = true
+	return nil
+}
+
+func (m *mockTwoFactorStore) InsertAuditEvent(_ context.Context, arg db.InsertAuditEventParams) error {
+	m.audit = append(m.audit, arg)
+	return nil
+}
+
+// plainBox "encrypts" by copying, which is enough to test the flows.
+type plainBox struct{}
+
+func (plainBox) Seal(p []byte) ([]byte, error) { return append([]byte(nil), p...), nil }
+func (plainBox) Open(c []byte) ([]byte, error) { return append([]byte(nil), c...), nil }
+
+type twoFactorFixture struct {
+	svc      *Service
+	tf       *TwoFactorService
+	store    *mockTwoFactorStore
+	sessions *mockSessionStore
+	clock    time.Time
+}
+
+func newTwoFactorFixture(t *testing.T) *twoFactorFixture {
+	t.Helper()
+	users := newMockUserStore()
+	addTestUser(users, 1, "alice", "pw")
+	f := &twoFactorFixture{store: newMockTwoFactorStore(), sessions: &mockSessionStore{}, clock: time.Now()}
+	f.svc = NewService(users, f.sessions, &mockJWT{}, &mockChecker{})
+	f.tf = NewTwoFactorService(f.store, plainBox{}, "ctf01d")
+	f.tf.now = func() time.Time { return f.clock }
+	f.svc.SetTwoFactor(f.tf)
+	return f
+}
+
+// code returns the authenticator code for the next time step, so every call
+// yields a code that has not been used yet.
+func (f *twoFactorFixture) code(t *testing.T, secret string) string {
+	t.Helper()
+	f.clock = f.clock.Add(30 * time.Second)
+	code, err := totp.GenerateCode(secret, f.clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func (f *twoFactorFixture) enroll(t *testing.T) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+	enrollment, err := f.tf.BeginEnrollment(ctx, 1, "alice")
+	if err != nil {
+		t.Fatalf("BeginEnrollment: %v", err)
+	}
+	u, err := url.Parse(enrollment.URL)
+	if err != nil || u.Query().Get("secret") != enrollment.Secret {
+		t.Fatalf("otpauth URL %q does not carry the secret", enrollment.URL)
+	}
+	codes, err := f.tf.ConfirmEnrollment(ctx, 1, f.code(t, enrollment.Secret))
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment: %v", err)
+	}
+	return enrollment.Secret, codes
+}
+
+func TestTwoFactorLogin_RequiresCodeAfterEnrollment(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	ctx := context.Background()
+	secret, recovery := f.enroll(t)
+	if len(recovery) != recoveryCodeCount {
+		t.Fatalf("recovery codes = %d, want %d", len(recovery), recoveryCodeCount)
+	}
+
+	result, err := f.svc.Login(ctx, "alice", "pw", "", "")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if result.Challenge == nil || result.Token != "" || len(f.sessions.created) != 0 {
+		t.Fatalf("result = %+v, want a challenge and no session", result)
+	}
+
+	if _, err := f.svc.CompleteTwoFactor(ctx, result.Challenge.Token, "000000", "", "", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Fatalf("wrong code: err = %v, want unauthorized", err)
+	}
+	code := f.code(t, secret)
+	done, err := f.svc.CompleteTwoFactor(ctx, result.Challenge.Token, code, "", "", "")
+	if err != nil {
+		t.Fatalf("CompleteTwoFactor: %v", err)
+	}
+	if done.Token != "token_alice" || len(f.sessions.created) != 1 {
+		t.Errorf("result = %+v, want a session", done)
+	}
+	if _, err := f.svc.CompleteTwoFactor(ctx, result.Challenge.Token, code, "", "", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("used challenge: err = %v, want unauthorized", err)
+	}
+
+	// A code is accepted once, even within its time step.
+	again, _ := f.svc.Login(ctx, "alice", "pw", "", "")
+	if _, err := f.svc.CompleteTwoFactor(ctx, again.Challenge.Token, code, "", "", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("replayed code: err = %v, want unauthorized", err)
+	}
+	if _, err := f.svc.CompleteTwoFactor(ctx, again.Challenge.Token, "", recovery[0], "", ""); err != nil {
+		t.Fatalf("recovery code: %v", err)
+	}
+	third, _ := f.svc.Login(ctx, "alice", "pw", "", "")
+	if _, err := f.svc.CompleteTwoFactor(ctx, third.Challenge.Token, "", recovery[0], "", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("reused recovery code: err = %v, want unauthorized", err)
+	}
+}
+
+func TestTwoFactorLogin_ChallengeEndsAfterTooManyFailures(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	ctx := context.Background()
+	secret, _ := f.enroll(t)
+
+	result, _ := f.svc.Login(ctx, "alice", "pw", "", "")
+	for i := 0; i < maxChallengeAttempts; i++ {
+		_, _ = f.svc.CompleteTwoFactor(ctx, result.Challenge.Token, "000000", "", "", "")
+	}
+	if _, err := f.svc.CompleteTwoFactor(ctx, result.Challenge.Token, f.code(t, secret), "", "", ""); !errors.Is(err, errChallengeInvalid) {
+		t.Errorf("err = %v, want the challenge to be gone", err)
+	}
+}
+
+func TestTwoFactorLogin_RoleRequiresEnrollment(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	ctx := context.Background()
+	if _, err := f.tf.SetRequiredRoles(ctx, []string{"player"}, 99); err != nil {
+		t.Fatalf("SetRequiredRoles: %v", err)
+	}
+
+	result, err := f.svc.Login(ctx, "alice", "pw", "", "")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if result.Challenge == nil || !result.Challenge.EnrollmentRequired {
+		t.Fatalf("result = %+v, want an enrollment challenge", result)
+	}
+	enrollment, err := f.svc.EnrollWithChallenge(ctx, result.Challenge.Token)
+	if err != nil {
+		t.Fatalf("EnrollWithChallenge: %v", err)
+	}
+	done, err := f.svc.CompleteTwoFactor(ctx, result.Challenge.Token, f.code(t, enrollment.Secret), "", "", "")
+	if err != nil {
+		t.Fatalf("CompleteTwoFactor: %v", err)
+	}
+	if done.Token == "" || len(done.RecoveryCodes) != recoveryCodeCount {
+		t.Errorf("result = %+v, want a session and recovery codes", done)
+	}
+
+	if err := f.tf.Disable(ctx, 1, "player", f.code(t, enrollment.Secret), ""); !errors.Is(err, errs.ErrForbidden) {
+		t.Errorf("Disable under a required role: err = %v, want forbidden", err)
+	}
+	if err := f.tf.Reset(ctx, 1, 99); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if len(f.store.audit) != 1 || f.store.audit[0].Action != audit.ActionResetTwoFactor || f.store.audit[0].EntityID != "1" {
+		t.Errorf("audit events = %+v, want one 2FA reset of user 1", f.store.audit)
+	}
+	status, err := f.tf.Status(ctx, 1, "player")
+	if err != nil || status.Enabled || !status.Required {
+		t.Errorf("status after reset = %+v, %v", status, err)
+	}
+}
+
+func TestTwoFactor_DisableAndPolicyValidation(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	ctx := context.Background()
+	secret, _ := f.enroll(t)
+
+	if _, err := f.tf.BeginEnrollment(ctx, 1, "alice"); !errors.Is(err, errs.ErrConflict) {
+		t.Errorf("re-enrollment: err = %v, want conflict", err)
+	}
+	if err := f.tf.Disable(ctx, 1, "player", "000000", ""); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("Disable with a wrong code: err = %v, want unauthorized", err)
+	}
+	if err := f.tf.Disable(ctx, 1, "player", f.code(t, secret), ""); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	result, _ := f.svc.Login(ctx, "alice", "pw", "", "")
+	if result.Challenge != nil {
+		t.Error("login after disabling must not ask for a code")
+	}
+
+	var ve *errs.ValidationError
+	if _, err := f.tf.SetRequiredRoles(ctx, []string{"root"}, 99); !errors.As(err, &ve) {
+		t.Errorf("unknown role: err = %v, want validation error", err)
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"strconv"
 	"time"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/ports"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
 )
 
 type BuilderQuerier interface {
@@ -17,11 +21,13 @@ type BuilderQuerier interface {
 	ListServiceIDsByGame(ctx context.Context, gameID int64) ([]int64, error)
 	GetServiceByID(ctx context.Context, id int64) (db.Service, error)
 	GetTeamByID(ctx context.Context, id int64) (db.Team, error)
+	ListGameServicePins(ctx context.Context, gameID int64) ([]db.ListGameServicePinsRow, error)
 }
 
 type Builder struct {
 	q          BuilderQuerier
 	storageDir string
+	store      storage.Storage
 }
 
 func NewBuilder(q BuilderQuerier) *Builder {
@@ -32,6 +38,13 @@ func (b *Builder) SetStorageDir(dir string) {
 	b.storageDir = dir
 }
 
+// SetStorage makes the builder copy archives out of store into a temporary
+// directory, for backends without a local directory such as S3. Callers must
+// Cleanup the result.
+func (b *Builder) SetStorage(store storage.Storage) {
+	b.store = store
+}
+
 type BuildResult struct {
 	Game       GameParams
 	Scoreboard ScoreboardParams
@@ -39,6 +52,17 @@ type BuildResult struct {
 	Checkers   []CheckerParams
 	Options    Options
 	Warnings   []string
+	// ModifiedAt is when the game was last changed.
+	ModifiedAt time.Time
+
+	tempDir string
+}
+
+// Cleanup removes the archives copied out of storage for the export.
+func (r *BuildResult) Cleanup() {
+	if r.tempDir != "" {
+		_ = os.RemoveAll(r.tempDir)
+	}
 }
 
 func (b *Builder) BuildParams(ctx context.Context, gameID int64, req Ctf01dExportRequest) (*BuildResult, error) {
@@ -57,6 +81,11 @@ func (b *Builder) BuildParams(ctx context.Context, gameID int64, req Ctf01dExpor
 		return nil, fmt.Errorf("list services: %w", err)
 	}
 
+	pins, err := b.loadPins(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := buildOptions(req)
 
 	gameParams := buildGameParams(game, req)
@@ -65,7 +94,15 @@ func (b *Builder) BuildParams(ctx context.Context, gameID int64, req Ctf01dExpor
 	var warnings []string
 	warnings = append(warnings, teamWarnings...)
 
-	checkerParams, checkerWarnings := b.buildCheckerParams(ctx, serviceIDs)
+	var tempDir string
+	if b.store != nil {
+		tempDir, err = os.MkdirTemp("", "ctf01d-export-*")
+		if err != nil {
+			return nil, fmt.Errorf("creating export directory: %w", err)
+		}
+	}
+
+	checkerParams, checkerWarnings := b.buildCheckerParams(ctx, serviceIDs, pins, tempDir)
 	warnings = append(warnings, checkerWarnings...)
 
 	scoreParams := buildScoreboardParams(req)
@@ -77,6 +114,8 @@ func (b *Builder) BuildParams(ctx context.Context, gameID int64, req Ctf01dExpor
 		Checkers:   checkerParams,
 		Options:    opts,
 		Warnings:   warnings,
+		ModifiedAt: game.UpdatedAt,
+		tempDir:    tempDir,
 	}, nil
 }
 
@@ -125,6 +164,11 @@ func (b *Builder) BuildOptions(ctx context.Context, gameID int64) (*Ctf01dExport
 		return nil, fmt.Errorf("list services: %w", err)
 	}
 
+	pins, err := b.loadPins(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
 	var warnings []string
 
 	for _, gt := range gameTeams {
@@ -136,9 +180,12 @@ func (b *Builder) BuildOptions(ctx context.Context, gameID int64) (*Ctf01dExport
 		}
 	}
 
+	var servicePorts []ports.ServicePorts
 	for _, sid := range serviceIDs {
 		svc, serr := b.q.GetServiceByID(ctx, sid)
 		if serr == nil {
+			servicePorts = append(servicePorts, ports.ServicePorts{ServiceID: svc.ID, Name: svc.Name, Ports: svc.Ports})
+			applyPin(&svc, pins)
 			if svc.CheckerLocalPath == nil || *svc.CheckerLocalPath == "" {
 				warnings = append(warnings, fmt.Sprintf("service %q (id=%d) has no local checker archive", svc.Name, svc.ID))
 			}
@@ -147,6 +194,7 @@ func (b *Builder) BuildOptions(ctx context.Context, gameID int64) (*Ctf01dExport
 			}
 		}
 	}
+	warnings = append(warnings, portConflictWarnings(servicePorts)...)
 
 	opts := &Ctf01dExportOptions{
 		FlagTtlMin:      defaultFlagTTLMin,
@@ -249,9 +297,10 @@ func (b *Builder) buildTeamParams(ctx context.Context, gameTeams []db.GameTeam)
 	return teams, warnings
 }
 
-func (b *Builder) buildCheckerParams(ctx context.Context, serviceIDs []int64) ([]CheckerParams, []string) {
+func (b *Builder) buildCheckerParams(ctx context.Context, serviceIDs []int64, pins map[int64]db.ListGameServicePinsRow, tempDir string) ([]CheckerParams, []string) {
 	var checkers []CheckerParams
 	var warnings []string
+	var servicePorts []ports.ServicePorts
 
 	for _, sid := range serviceIDs {
 		svc, err := b.q.GetServiceByID(ctx, sid)
@@ -259,6 +308,8 @@ func (b *Builder) buildCheckerParams(ctx context.Context, serviceIDs []int64) ([
 			warnings = append(warnings, fmt.Sprintf("service id=%d not found", sid))
 			continue
 		}
+		servicePorts = append(servicePorts, ports.ServicePorts{ServiceID: svc.ID, Name: svc.Name, Ports: svc.Ports})
+		applyPin(&svc, pins)
 
 		cp := CheckerParams{
 			ID:                normalizeID(svc.Name),
@@ -272,15 +323,25 @@ func (b *Builder) buildCheckerParams(ctx context.Context, serviceIDs []int64) ([
 		}
 
 		if svc.CheckerLocalPath != nil && *svc.CheckerLocalPath != "" {
-			cp.BundlePath = b.resolveStoragePath(*svc.CheckerLocalPath)
-			cp.CheckerFromBundle = true
+			bundlePath, err := b.bundlePath(ctx, *svc.CheckerLocalPath, tempDir)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("service %q: checker archive unavailable: %v", svc.Name, err))
+			} else {
+				cp.BundlePath = bundlePath
+				cp.CheckerFromBundle = true
+			}
 		} else {
 			warnings = append(warnings, fmt.Sprintf("service %q has no local checker archive", svc.Name))
 		}
 
 		if svc.ServiceLocalPath != nil && *svc.ServiceLocalPath != "" {
 			if cp.BundlePath == "" {
-				cp.BundlePath = b.resolveStoragePath(*svc.ServiceLocalPath)
+				bundlePath, err := b.bundlePath(ctx, *svc.ServiceLocalPath, tempDir)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("service %q: service archive unavailable: %v", svc.Name, err))
+				} else {
+					cp.BundlePath = bundlePath
+				}
 			}
 		}
 
@@ -313,9 +374,78 @@ func (b *Builder) buildCheckerParams(ctx context.Context, serviceIDs []int64) ([
 		checkers = append(checkers, cp)
 	}
 
+	warnings = append(warnings, portConflictWarnings(servicePorts)...)
 	return checkers, warnings
 }
 
+// portConflictWarnings reports services of the game that share a vulnbox port.
+func portConflictWarnings(services []ports.ServicePorts) []string {
+	conflicts := ports.FindConflicts(services)
+	warnings := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		warnings[i] = "port conflict: " + c.String()
+	}
+	return warnings
+}
+
+// loadPins returns the archive versions the game is pinned to, keyed by service ID.
+func (b *Builder) loadPins(ctx context.Context, gameID int64) (map[int64]db.ListGameServicePinsRow, error) {
+	rows, err := b.q.ListGameServicePins(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("list pins: %w", err)
+	}
+	pins := make(map[int64]db.ListGameServicePinsRow, len(rows))
+	for _, r := range rows {
+		pins[r.ServiceID] = r
+	}
+	return pins, nil
+}
+
+// applyPin swaps the service's current archives for the pinned versions so
+// the export uses what the game was pinned to.
+func applyPin(svc *db.Service, pins map[int64]db.ListGameServicePinsRow) {
+	pin, ok := pins[svc.ID]
+	if !ok {
+		return
+	}
+	if pin.ServiceStorageKey != nil {
+		svc.ServiceLocalPath = pin.ServiceStorageKey
+	}
+	if pin.CheckerStorageKey != nil {
+		svc.CheckerLocalPath = pin.CheckerStorageKey
+	}
+}
+
+// bundlePath returns a local path of the archive stored under key, copying it
+// into tempDir when the builder reads through a storage backend.
+func (b *Builder) bundlePath(ctx context.Context, key, tempDir string) (string, error) {
+	if b.store == nil {
+		return b.resolveStoragePath(key), nil
+	}
+	rc, err := b.store.Open(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	dest := filepath.Join(tempDir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(dest), dirMode); err != nil {
+		return "", fmt.Errorf("creating directory: %w", err)
+	}
+	f, err := os.Create(dest)
+	if err != nil {
+		return "", fmt.Errorf("creating file: %w", err)
+	}
+	if _, err := io.Copy(f, rc); err != nil {
+		f.Close()
+		return "", fmt.Errorf("copying archive: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("closing file: %w", err)
+	}
+	return dest, nil
+}
+
 func (b *Builder) resolveStoragePath(key string) string {
 	if b.storageDir == "" {
 		return key
@@ -3,12 +3,16 @@ package ctf01d
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
 )
 
 type mockBuilderQuerier struct {
@@ -17,6 +21,7 @@ type mockBuilderQuerier struct {
 	serviceIDs []int64
 	services   map[int64]db.Service
 	teams      map[int64]db.Team
+	pins       []db.ListGameServicePinsRow
 }
 
 func (m *mockBuilderQuerier) GetGameByID(_ context.Context, _ int64) (db.Game, error) {
@@ -39,6 +44,10 @@ func (m *mockBuilderQuerier) GetTeamByID(_ context.Context, id int64) (db.Team,
 	return m.teams[id], nil
 }
 
+func (m *mockBuilderQuerier) ListGameServicePins(_ context.Context, _ int64) ([]db.ListGameServicePinsRow, error) {
+	return m.pins, nil
+}
+
 func strPtr(s string) *string { return &s }
 
 func makeMockQ() *mockBuilderQuerier {
@@ -216,6 +225,90 @@ func TestBuildParams_Warnings(t *testing.T) {
 	}
 }
 
+func TestBuildParams_PinnedArchives(t *testing.T) {
+	mq := makeMockQ()
+	mq.pins = []db.ListGameServicePinsRow{
+		{ServiceID: 200, ServiceStorageKey: strPtr("services/200/versions/service-old.zip"), CheckerStorageKey: strPtr("services/200/versions/checker-old.zip")},
+		{ServiceID: 201, ServiceStorageKey: strPtr("services/201/versions/service-old.zip")},
+	}
+	b := NewBuilder(mq)
+	b.SetStorageDir("/data")
+
+	result, err := b.BuildParams(context.Background(), 1, Ctf01dExportRequest{})
+	if err != nil {
+		t.Fatalf("BuildParams: %v", err)
+	}
+	if got := result.Checkers[0].BundlePath; got != "/data/services/200/versions/checker-old.zip" {
+		t.Errorf("Checkers[0].BundlePath = %q, want pinned checker archive", got)
+	}
+	if got := result.Checkers[1].BundlePath; got != "/data/services/201/versions/service-old.zip" {
+		t.Errorf("Checkers[1].BundlePath = %q, want pinned service archive", got)
+	}
+}
+
+func TestBuildParams_CopiesArchivesFromStorage(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := store.Save(ctx, "services/200/versions/checker-old.zip", strings.NewReader("checker")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	mq := makeMockQ()
+	mq.pins = []db.ListGameServicePinsRow{
+		{ServiceID: 200, CheckerStorageKey: strPtr("services/200/versions/checker-old.zip")},
+		{ServiceID: 201, ServiceStorageKey: strPtr("services/201/versions/missing.zip")},
+	}
+	b := NewBuilder(mq)
+	b.SetStorage(store)
+
+	result, err := b.BuildParams(ctx, 1, Ctf01dExportRequest{})
+	if err != nil {
+		t.Fatalf("BuildParams: %v", err)
+	}
+	bundle := result.Checkers[0].BundlePath
+	data, err := os.ReadFile(bundle)
+	if err != nil || string(data) != "checker" {
+		t.Fatalf("copied archive %q = %q, %v", bundle, data, err)
+	}
+	if result.Checkers[1].BundlePath != "" {
+		t.Errorf("Checkers[1].BundlePath = %q, want empty for a missing archive", result.Checkers[1].BundlePath)
+	}
+
+	result.Cleanup()
+	if _, err := os.Stat(bundle); !os.IsNotExist(err) {
+		t.Errorf("Cleanup should remove copied archives, stat error = %v", err)
+	}
+}
+
+func TestBuildParams_PortConflicts(t *testing.T) {
+	mq := makeMockQ()
+	for id, ports := range map[int64][]int32{200: {8080, 9000}, 201: {8080}} {
+		svc := mq.services[id]
+		svc.Ports = ports
+		mq.services[id] = svc
+	}
+	b := NewBuilder(mq)
+
+	result, err := b.BuildParams(context.Background(), 1, Ctf01dExportRequest{})
+	if err != nil {
+		t.Fatalf("BuildParams: %v", err)
+	}
+	want := "port conflict: port 8080 is used by " + mq.services[200].Name + ", " + mq.services[201].Name + " (free: 8081)"
+	if !slices.Contains(result.Warnings, want) {
+		t.Errorf("Warnings = %v, want %q", result.Warnings, want)
+	}
+
+	opts, err := b.BuildOptions(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("BuildOptions: %v", err)
+	}
+	if !slices.Contains(opts.Warnings, want) {
+		t.Errorf("option warnings = %v, want %q", opts.Warnings, want)
+	}
+}
+
 func TestBuildParams_Ctf01dOverrides(t *testing.T) {
 	mq := makeMockQ()
 	mq.gameTeams[0].Ctf01dOverrides = json.RawMessage(`{"ctf01d_custom_field": "custom_value"}`)
@@ -0,0 +1,219 @@
+package games
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+const (
+	archiveKindService = "service"
+	archiveKindChecker = "checker"
+	archiveSourceGit   = "git"
+
+	fieldArchiveVersionID = "archive_version_id"
+	fieldGitCommit        = "git_commit"
+)
+
+var gitCommitRe = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+
+// PinParams selects the archive a game should use for a service: either a
+// stored archive version or the git commit an archive was built from.
+type PinParams struct {
+	ArchiveVersionID *int64
+	GitCommit        *string
+}
+
+// ServiceGamePin describes how a game uses a service. Games that are not
+// pinned follow the service's current archive and change with every update.
+type ServiceGamePin struct {
+	GameID                 int64   `json:"game_id"`
+	GameName               *string `json:"game_name"`
+	GameFinalized          bool    `json:"game_finalized"`
+	Status                 string  `json:"status"`
+	PinnedServiceVersionID *int64  `json:"pinned_service_version_id"`
+	PinnedCheckerVersionID *int64  `json:"pinned_checker_version_id"`
+	FollowsCurrent         bool    `json:"follows_current"`
+}
+
+// PinService pins a linked service to an archive version. The pin covers both
+// archives: the service and checker versions that were active when the
+// selected version was stored.
+func (s *Service) PinService(ctx context.Context, gameID, serviceID int64, params PinParams) (*GameServiceLink, error) {
+	if err := s.ensurePinsEditable(ctx, gameID); err != nil {
+		return nil, err
+	}
+
+	anchor, err := s.resolvePinVersion(ctx, serviceID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var pin db.SetGameServicePinParams
+	pin.GameID = gameID
+	pin.ServiceID = serviceID
+	pin.PinnedServiceVersionID, err = s.versionAsOf(ctx, anchor, archiveKindService)
+	if err != nil {
+		return nil, err
+	}
+	pin.PinnedCheckerVersionID, err = s.versionAsOf(ctx, anchor, archiveKindChecker)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := s.gamesSvc.SetGameServicePin(ctx, pin)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errs.ErrNotFound
+	}
+	return s.findServiceLink(ctx, gameID, serviceID)
+}
+
+// UnpinService makes the game follow the service's current archive again.
+func (s *Service) UnpinService(ctx context.Context, gameID, serviceID int64) error {
+	if err := s.ensurePinsEditable(ctx, gameID); err != nil {
+		return err
+	}
+	n, err := s.gamesSvc.SetGameServicePin(ctx, db.SetGameServicePinParams{GameID: gameID, ServiceID: serviceID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// ListServicePins lists the games that use a service. With versionID set,
+// only games pinned to that version are returned, i.e. the games affected by
+// moving that pin. Pins of a non-public service are only visible to admins.
+func (s *Service) ListServicePins(ctx context.Context, serviceID int64, versionID *int64, isAdmin bool) ([]ServiceGamePin, error) {
+	svc, err := s.gamesSvc.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	if !svc.Public && !isAdmin {
+		return nil, errs.ErrNotFound
+	}
+
+	rows, err := s.gamesSvc.ListGamePinsByService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]ServiceGamePin, 0, len(rows))
+	for _, r := range rows {
+		if versionID != nil && !int64PtrIs(r.PinnedServiceVersionID, *versionID) && !int64PtrIs(r.PinnedCheckerVersionID, *versionID) {
+			continue
+		}
+		out = append(out, ServiceGamePin{
+			GameID:                 r.GameID,
+			GameName:               r.GameName,
+			GameFinalized:          r.GameFinalized,
+			Status:                 r.Status,
+			PinnedServiceVersionID: r.PinnedServiceVersionID,
+			PinnedCheckerVersionID: r.PinnedCheckerVersionID,
+			FollowsCurrent:         r.PinnedServiceVersionID == nil && r.PinnedCheckerVersionID == nil,
+		})
+	}
+	return out, nil
+}
+
+// ensurePinsEditable rejects pin changes on finalized games: their archives
+// were frozen at finalization so results stay reproducible.
+func (s *Service) ensurePinsEditable(ctx context.Context, gameID int64) error {
+	game, err := s.games.GetGameByID(ctx, gameID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if game.Finalized {
+		return errs.ErrConflict
+	}
+	return nil
+}
+
+func (s *Service) resolvePinVersion(ctx context.Context, serviceID int64, params PinParams) (db.ServiceArchiveVersion, error) {
+	commit := ""
+	if params.GitCommit != nil {
+		commit = strings.ToLower(strings.TrimSpace(*params.GitCommit))
+	}
+
+	switch {
+	case params.ArchiveVersionID != nil && commit != "":
+		return db.ServiceArchiveVersion{}, errs.NewValidationError(map[string]string{fieldArchiveVersionID: "specify either archive_version_id or git_commit"})
+	case params.ArchiveVersionID != nil:
+		v, err := s.gamesSvc.GetServiceArchiveVersion(ctx, db.GetServiceArchiveVersionParams{ID: *params.ArchiveVersionID, ServiceID: serviceID})
+		if repository.IsNoRows(err) {
+			return v, errs.NewValidationError(map[string]string{fieldArchiveVersionID: "archive version not found for this service"})
+		}
+		return v, err
+	case commit != "":
+		if !gitCommitRe.MatchString(commit) {
+			return db.ServiceArchiveVersion{}, errs.NewValidationError(map[string]string{fieldGitCommit: "must be a commit hash (at least 7 hex characters)"})
+		}
+		v, err := s.gamesSvc.GetLatestServiceArchiveVersionByCommit(ctx, db.GetLatestServiceArchiveVersionByCommitParams{ServiceID: serviceID, Commit: commit})
+		if repository.IsNoRows(err) {
+			return v, errs.NewValidationError(map[string]string{fieldGitCommit: "no stored archive was built from this commit"})
+		}
+		return v, err
+	default:
+		return db.ServiceArchiveVersion{}, errs.NewValidationError(map[string]string{fieldArchiveVersionID: "archive_version_id or git_commit is required"})
+	}
+}
+
+func (s *Service) versionAsOf(ctx context.Context, anchor db.ServiceArchiveVersion, kind string) (*int64, error) {
+	if anchor.Kind == kind {
+		return &anchor.ID, nil
+	}
+	v, err := s.gamesSvc.GetServiceArchiveVersionAsOf(ctx, db.GetServiceArchiveVersionAsOfParams{
+		ServiceID: anchor.ServiceID,
+		Kind:      kind,
+		CreatedAt: anchor.CreatedAt,
+	})
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v.ID, nil
+}
+
+func (s *Service) findServiceLink(ctx context.Context, gameID, serviceID int64) (*GameServiceLink, error) {
+	links, err := s.ListServices(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range links {
+		if links[i].ServiceID == serviceID {
+			return &links[i], nil
+		}
+	}
+	return nil, errs.ErrNotFound
+}
+
+func gameServiceLinkFromDB(r db.ListServicesByGameRow) GameServiceLink {
+	link := GameServiceLink{
+		ServiceID:              r.ServiceID,
+		Status:                 r.Status,
+		PinnedServiceVersionID: r.PinnedServiceVersionID,
+		PinnedCheckerVersionID: r.PinnedCheckerVersionID,
+	}
+	switch {
+	case r.ServiceSourceKind != nil && *r.ServiceSourceKind == archiveSourceGit:
+		link.PinnedGitCommit = r.ServiceSourceRef
+	case r.CheckerSourceKind != nil && *r.CheckerSourceKind == archiveSourceGit:
+		link.PinnedGitCommit = r.CheckerSourceRef
+	}
+	return link
+}
+
+func int64PtrIs(p *int64, v int64) bool {
+	return p != nil && *p == v
+}
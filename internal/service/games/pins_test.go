@@ -0,0 +1,271 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func (m *mockGamesServiceQuerier) SetGameServicePin(_ context.Context, arg db.SetGameServicePinParams) (int64, error) {
+	key := svcKey(arg.GameID, arg.ServiceID)
+	if !m.pairs[key] {
+		return 0, nil
+	}
+	if m.pins == nil {
+		m.pins = map[string]db.SetGameServicePinParams{}
+	}
+	m.pins[key] = arg
+	return 1, nil
+}
+
+func (m *mockGamesServiceQuerier) PinCurrentServiceVersions(_ context.Context, gameID int64) error {
+	m.pinnedAll = append(m.pinnedAll, gameID)
+	return nil
+}
+
+func (m *mockGamesServiceQuerier) ListGamePinsByService(_ context.Context, serviceID int64) ([]db.ListGamePinsByServiceRow, error) {
+	var out []db.ListGamePinsByServiceRow
+	for key := range m.pairs {
+		var g, s int64
+		if _, err := fmt.Sscanf(key, "%d:%d", &g, &s); err != nil || s != serviceID {
+			continue
+		}
+		pin := m.pins[key]
+		out = append(out, db.ListGamePinsByServiceRow{
+			GameID:                 g,
+			Status:                 "planning",
+			PinnedServiceVersionID: pin.PinnedServiceVersionID,
+			PinnedCheckerVersionID: pin.PinnedCheckerVersionID,
+		})
+	}
+	return out, nil
+}
+
+func (m *mockGamesServiceQuerier) GetServiceArchiveVersion(_ context.Context, arg db.GetServiceArchiveVersionParams) (db.ServiceArchiveVersion, error) {
+	for _, v := range m.versions {
+		if v.ID == arg.ID && v.ServiceID == arg.ServiceID {
+			return v, nil
+		}
+	}
+	return db.ServiceArchiveVersion{}, pgx.ErrNoRows
+}
+
+func (m *mockGamesServiceQuerier) GetLatestServiceArchiveVersionByCommit(_ context.Context, arg db.GetLatestServiceArchiveVersionByCommitParams) (db.ServiceArchiveVersion, error) {
+	var found *db.ServiceArchiveVersion
+	for i, v := range m.versions {
+		if v.ServiceID != arg.ServiceID || v.SourceKind != archiveSourceGit || v.SourceRef == nil || !strings.HasPrefix(*v.SourceRef, arg.Commit) {
+			continue
+		}
+		if found == nil || v.CreatedAt.After(found.CreatedAt) {
+			found = &m.versions[i]
+		}
+	}
+	if found == nil {
+		return db.ServiceArchiveVersion{}, pgx.ErrNoRows
+	}
+	return *found, nil
+}
+
+func (m *mockGamesServiceQuerier) GetServiceArchiveVersionAsOf(_ context.Context, arg db.GetServiceArchiveVersionAsOfParams) (db.ServiceArchiveVersion, error) {
+	var found *db.ServiceArchiveVersion
+	for i, v := range m.versions {
+		if v.ServiceID != arg.ServiceID || v.Kind != arg.Kind || v.CreatedAt.After(arg.CreatedAt) {
+			continue
+		}
+		if found == nil || v.CreatedAt.After(found.CreatedAt) {
+			found = &m.versions[i]
+		}
+	}
+	if found == nil {
+		return db.ServiceArchiveVersion{}, pgx.ErrNoRows
+	}
+	return *found, nil
+}
+
+func newPinFixture(t *testing.T) (*Service, *mockGamesServiceQuerier, int64) {
+	t.Helper()
+	gq, gsq, rq, frq, tx := newMocks()
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
+	name := "pinned"
+	game := mustCreateGame(t, svc, CreateParams{Name: &name})
+	if err := svc.AddService(context.Background(), game.ID, 7, nil); err != nil {
+		t.Fatalf("AddService: %v", err)
+	}
+
+	t0 := time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	commitA := "aaaaaaa1111111111111111111111111111111aa"
+	commitB := "bbbbbbb2222222222222222222222222222222bb"
+	gsq.versions = []db.ServiceArchiveVersion{
+		{ID: 1, ServiceID: 7, Kind: archiveKindService, SourceKind: archiveSourceGit, SourceRef: &commitA, CreatedAt: t0},
+		{ID: 2, ServiceID: 7, Kind: archiveKindChecker, SourceKind: archiveSourceGit, SourceRef: &commitA, CreatedAt: t0},
+		{ID: 3, ServiceID: 7, Kind: archiveKindService, SourceKind: archiveSourceGit, SourceRef: &commitB, CreatedAt: t1},
+		{ID: 4, ServiceID: 7, Kind: archiveKindChecker, SourceKind: archiveSourceGit, SourceRef: &commitB, CreatedAt: t1},
+	}
+	return svc, gsq, game.ID
+}
+
+func TestPinService_ByVersionPinsMatchingChecker(t *testing.T) {
+	svc, _, gameID := newPinFixture(t)
+
+	versionID := int64(1)
+	link, err := svc.PinService(context.Background(), gameID, 7, PinParams{ArchiveVersionID: &versionID})
+	if err != nil {
+		t.Fatalf("PinService: %v", err)
+	}
+	if link.PinnedServiceVersionID == nil || *link.PinnedServiceVersionID != 1 {
+		t.Errorf("PinnedServiceVersionID = %v, want 1", link.PinnedServiceVersionID)
+	}
+	if link.PinnedCheckerVersionID == nil || *link.PinnedCheckerVersionID != 2 {
+		t.Errorf("PinnedCheckerVersionID = %v, want 2", link.PinnedCheckerVersionID)
+	}
+}
+
+func TestPinService_ByAbbreviatedCommit(t *testing.T) {
+	svc, _, gameID := newPinFixture(t)
+
+	commit := "BBBBBBB"
+	link, err := svc.PinService(context.Background(), gameID, 7, PinParams{GitCommit: &commit})
+	if err != nil {
+		t.Fatalf("PinService: %v", err)
+	}
+	if link.PinnedServiceVersionID == nil || *link.PinnedServiceVersionID != 3 {
+		t.Errorf("PinnedServiceVersionID = %v, want 3", link.PinnedServiceVersionID)
+	}
+	if link.PinnedCheckerVersionID == nil || *link.PinnedCheckerVersionID != 4 {
+		t.Errorf("PinnedCheckerVersionID = %v, want 4", link.PinnedCheckerVersionID)
+	}
+}
+
+func TestPinService_Validation(t *testing.T) {
+	svc, _, gameID := newPinFixture(t)
+	ctx := context.Background()
+
+	unknown := int64(99)
+	badCommit := "xyz"
+	missingCommit := "ccccccc"
+	tests := []struct {
+		name   string
+		params PinParams
+	}{
+		{"empty", PinParams{}},
+		{"both", PinParams{ArchiveVersionID: &unknown, GitCommit: &missingCommit}},
+		{"unknown version", PinParams{ArchiveVersionID: &unknown}},
+		{"malformed commit", PinParams{GitCommit: &badCommit}},
+		{"unknown commit", PinParams{GitCommit: &missingCommit}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.PinService(ctx, gameID, 7, tt.params)
+			var ve *errs.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPinService_NotLinked(t *testing.T) {
+	svc, gsq, gameID := newPinFixture(t)
+	gsq.versions = append(gsq.versions, db.ServiceArchiveVersion{ID: 10, ServiceID: 8, Kind: archiveKindService, CreatedAt: time.Now()})
+
+	versionID := int64(10)
+	if _, err := svc.PinService(context.Background(), gameID, 8, PinParams{ArchiveVersionID: &versionID}); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPinService_FinalizedGame(t *testing.T) {
+	svc, gsq, gameID := newPinFixture(t)
+	ctx := context.Background()
+
+	if _, err := svc.Finalize(ctx, gameID); err != nil {
+		t.Fatalf("Finalize: %v", err)
+	}
+	if len(gsq.pinnedAll) != 1 || gsq.pinnedAll[0] != gameID {
+		t.Errorf("Finalize should pin current versions, got %v", gsq.pinnedAll)
+	}
+
+	versionID := int64(1)
+	if _, err := svc.PinService(ctx, gameID, 7, PinParams{ArchiveVersionID: &versionID}); !errors.Is(err, errs.ErrConflict) {
+		t.Fatalf("PinService on finalized game: expected ErrConflict, got %v", err)
+	}
+	if err := svc.UnpinService(ctx, gameID, 7); !errors.Is(err, errs.ErrConflict) {
+		t.Fatalf("UnpinService on finalized game: expected ErrConflict, got %v", err)
+	}
+}
+
+func TestListServicePins(t *testing.T) {
+	svc, _, gameID := newPinFixture(t)
+	ctx := context.Background()
+
+	name := "other"
+	other := mustCreateGame(t, svc, CreateParams{Name: &name})
+	if err := svc.AddService(ctx, other.ID, 7, nil); err != nil {
+		t.Fatalf("AddService: %v", err)
+	}
+	versionID := int64(1)
+	if _, err := svc.PinService(ctx, gameID, 7, PinParams{ArchiveVersionID: &versionID}); err != nil {
+		t.Fatalf("PinService: %v", err)
+	}
+
+	all, err := svc.ListServicePins(ctx, 7, nil, false)
+	if err != nil {
+		t.Fatalf("ListServicePins: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("len = %d, want 2", len(all))
+	}
+	for _, p := range all {
+		if p.GameID == other.ID && !p.FollowsCurrent {
+			t.Error("unpinned game should follow the current archive")
+		}
+		if p.GameID == gameID && p.FollowsCurrent {
+			t.Error("pinned game should not follow the current archive")
+		}
+	}
+
+	affected, err := svc.ListServicePins(ctx, 7, &versionID, false)
+	if err != nil {
+		t.Fatalf("ListServicePins(version): %v", err)
+	}
+	if len(affected) != 1 || affected[0].GameID != gameID {
+		t.Errorf("affected = %+v, want only game %d", affected, gameID)
+	}
+
+	if err := svc.UnpinService(ctx, gameID, 7); err != nil {
+		t.Fatalf("UnpinService: %v", err)
+	}
+	links, err := svc.ListServices(ctx, gameID)
+	if err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	if len(links) != 1 || links[0].PinnedServiceVersionID != nil {
+		t.Errorf("service should be unpinned: %+v", links)
+	}
+}
+
+func TestListServicePins_HiddenForNonPublicService(t *testing.T) {
+	svc, gsq, _ := newPinFixture(t)
+	ctx := context.Background()
+	gsq.private = map[int64]bool{7: true}
+
+	if _, err := svc.ListServicePins(ctx, 7, nil, false); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	pins, err := svc.ListServicePins(ctx, 7, nil, true)
+	if err != nil {
+		t.Fatalf("ListServicePins as admin: %v", err)
+	}
+	if len(pins) != 1 {
+		t.Errorf("len = %d, want 1", len(pins))
+	}
+}
@@ -0,0 +1,89 @@
+package games
+
+import (
+	"context"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/ports"
+)
+
+const fieldPorts = "ports"
+
+// PortConflicts lists the port conflicts between the services of a game.
+func (s *Service) PortConflicts(ctx context.Context, gameID int64) ([]ports.Conflict, error) {
+	if _, err := s.games.GetGameByID(ctx, gameID); err != nil {
+		return nil, mapNotFound(err)
+	}
+	services, err := gameServicePorts(ctx, s.gamesSvc, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return ports.FindConflicts(services), nil
+}
+
+func gameServicePorts(ctx context.Context, q GamesServiceQuerier, gameID int64) ([]ports.ServicePorts, error) {
+	rows, err := q.ListGameServicePorts(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]ports.ServicePorts, len(rows))
+	for i, r := range rows {
+		services[i] = ports.ServicePorts{ServiceID: r.ID, Name: r.Name, Ports: r.Ports}
+	}
+	return services, nil
+}
+
+// checkAddServicePorts rejects adding a service whose ports are already
+// taken by another service of the game. It runs in the transaction that adds
+// the service, after the game row has been locked.
+func checkAddServicePorts(ctx context.Context, q GamesServiceQuerier, gameID, serviceID int64) error {
+	svc, err := q.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if len(svc.Ports) == 0 {
+		return nil
+	}
+
+	existing, err := gameServicePorts(ctx, q, gameID)
+	if err != nil {
+		return err
+	}
+	services := make([]ports.ServicePorts, 0, len(existing)+1)
+	for _, other := range existing {
+		if other.ServiceID != serviceID {
+			services = append(services, other)
+		}
+	}
+	services = append(services, ports.ServicePorts{ServiceID: svc.ID, Name: svc.Name, Ports: svc.Ports})
+
+	// Conflicts the game already has between other services are reported by
+	// PortConflicts and must not prevent adding an unrelated service.
+	var conflicts []ports.Conflict
+	for _, c := range ports.FindConflicts(services) {
+		if c.Involves(serviceID) {
+			conflicts = append(conflicts, c)
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return errs.NewValidationError(map[string]string{fieldPorts: ports.Message(conflicts)})
+}
+
+// checkPublishPorts blocks publishing a game with port conflicts when the
+// service is configured to do so.
+func (s *Service) checkPublishPorts(ctx context.Context, gameID int64) error {
+	if !s.blockPublishOnPortConflicts {
+		return nil
+	}
+	services, err := gameServicePorts(ctx, s.gamesSvc, gameID)
+	if err != nil {
+		return err
+	}
+	conflicts := ports.FindConflicts(services)
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return errs.NewValidationError(map[string]string{fieldPorts: ports.Message(conflicts)})
+}
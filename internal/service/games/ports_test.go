@@ -0,0 +1,118 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func (m *mockGamesServiceQuerier) ListGameServicePorts(_ context.Context, gameID int64) ([]db.ListGameServicePortsRow, error) {
+	var out []db.ListGameServicePortsRow
+	for key := range m.pairs {
+		var g, s int64
+		if _, err := fmt.Sscanf(key, "%d:%d", &g, &s); err != nil || g != gameID {
+			continue
+		}
+		out = append(out, db.ListGameServicePortsRow{ID: s, Name: fmt.Sprintf("svc%d", s), Ports: m.ports[s]})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (m *mockGamesServiceQuerier) GetServiceByID(_ context.Context, id int64) (db.Service, error) {
+	return db.Service{ID: id, Name: fmt.Sprintf("svc%d", id), Ports: m.ports[id], Public: !m.private[id]}, nil
+}
+
+func TestAddService_RejectsPortConflict(t *testing.T) {
+	gq, gsq, rq, frq, tx := newMocks()
+	gsq.ports = map[int64][]int32{10: {8080}, 11: {8080, 9000}, 12: {9000}}
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
+	name := "game"
+	mustCreateGame(t, svc, CreateParams{Name: &name})
+	ctx := context.Background()
+
+	if err := svc.AddService(ctx, 1, 10, nil); err != nil {
+		t.Fatalf("AddService: %v", err)
+	}
+	err := svc.AddService(ctx, 1, 11, nil)
+	var ve *errs.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if msg := ve.Fields[fieldPorts]; !strings.Contains(msg, "port 8080") || !strings.Contains(msg, "free: 8081") {
+		t.Errorf("ports message = %q", msg)
+	}
+	if gsq.pairs[svcKey(1, 11)] {
+		t.Error("conflicting service must not be added")
+	}
+
+	// Re-adding a service that is already in the game does not conflict
+	// with itself.
+	if err := svc.AddService(ctx, 1, 10, nil); err != nil {
+		t.Fatalf("re-adding service: %v", err)
+	}
+}
+
+func TestAddService_IgnoresExistingConflicts(t *testing.T) {
+	gq, gsq, rq, frq, tx := newMocks()
+	gsq.ports = map[int64][]int32{10: {8080}, 11: {8080}, 12: {9000}}
+	gsq.pairs[svcKey(1, 10)] = true
+	gsq.pairs[svcKey(1, 11)] = true
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
+	name := "game"
+	mustCreateGame(t, svc, CreateParams{Name: &name})
+
+	if err := svc.AddService(context.Background(), 1, 12, nil); err != nil {
+		t.Fatalf("AddService: %v", err)
+	}
+}
+
+func TestPublish_PortConflicts(t *testing.T) {
+	gq, gsq, rq, frq, tx := newMocks()
+	gsq.ports = map[int64][]int32{10: {8080}, 11: {8080}}
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
+	ctx := context.Background()
+
+	notPublished := false
+	name := "ports"
+	game, err := svc.Create(ctx, CreateParams{Name: &name, Published: &notPublished})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	gsq.pairs[svcKey(game.ID, 10)] = true
+	gsq.pairs[svcKey(game.ID, 11)] = true
+
+	conflicts, err := svc.PortConflicts(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("PortConflicts: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Port != 8080 {
+		t.Fatalf("conflicts = %#v", conflicts)
+	}
+
+	svc.SetBlockPublishOnPortConflicts(true)
+	var ve *errs.ValidationError
+	if _, err := svc.Publish(ctx, game.ID); !errors.As(err, &ve) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	svc.SetBlockPublishOnPortConflicts(false)
+	if _, err := svc.Publish(ctx, game.ID); err != nil {
+		t.Fatalf("Publish without blocking: %v", err)
+	}
+}
+
+func TestAddService_UnknownGame(t *testing.T) {
+	gq, gsq, rq, frq, tx := newMocks()
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
+
+	if err := svc.AddService(context.Background(), 42, 10, nil); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
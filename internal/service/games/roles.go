@@ -0,0 +1,199 @@
+package games
+
+import (
+	"context"
+	"time"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+// Roles a user can hold in a single game. Organizers run the game: they edit
+// it, manage its teams, services, results and exports. The jury records
+// results. Observers only watch. All of them see the scoreboard while it is
+// hidden from players.
+const (
+	RoleOrganizer = "organizer"
+	RoleJury      = "jury"
+	RoleObserver  = "observer"
+)
+
+const (
+	fieldRole   = "role"
+	fieldUserID = "user_id"
+)
+
+// ValidRole reports whether role is a game role.
+func ValidRole(role string) bool {
+	switch role {
+	case RoleOrganizer, RoleJury, RoleObserver:
+		return true
+	default:
+		return false
+	}
+}
+
+// RoleAccess is who asks to change the roles of a game: admins hand out every
+// role, organizers of the game the jury and observer roles.
+type RoleAccess struct {
+	Admin     bool
+	Organizer bool
+}
+
+type GameRole struct {
+	UserID      int64
+	UserName    string
+	DisplayName string
+	AvatarUrl   *string
+	Role        string
+	CreatedAt   time.Time
+}
+
+type RoleQuerier interface {
+	ListGameRoles(ctx context.Context, gameID int64) ([]db.ListGameRolesRow, error)
+	GetGameRole(ctx context.Context, arg db.GetGameRoleParams) (string, error)
+	GetGameRoleByGameTeam(ctx context.Context, arg db.GetGameRoleByGameTeamParams) (string, error)
+	GetGameRoleByResult(ctx context.Context, arg db.GetGameRoleByResultParams) (string, error)
+	SetGameRole(ctx context.Context, arg db.SetGameRoleParams) error
+	DeleteGameRole(ctx context.Context, arg db.DeleteGameRoleParams) (int64, error)
+}
+
+// RoleOf returns the role of the user in the game, or "" if they hold none.
+func (s *Service) RoleOf(ctx context.Context, gameID, userID int64) (string, error) {
+	return noRole(s.roles.GetGameRole(ctx, db.GetGameRoleParams{GameID: gameID, UserID: userID}))
+}
+
+// RoleOfGameTeam returns the role of the user in the game the game team
+// plays in, or "" if they hold none.
+func (s *Service) RoleOfGameTeam(ctx context.Context, gameTeamID, userID int64) (string, error) {
+	return noRole(s.roles.GetGameRoleByGameTeam(ctx, db.GetGameRoleByGameTeamParams{GameTeamID: gameTeamID, UserID: userID}))
+}
+
+// RoleOfResult returns the role of the user in the game the result belongs
+// to, or "" if they hold none.
+func (s *Service) RoleOfResult(ctx context.Context, resultID, userID int64) (string, error) {
+	return noRole(s.roles.GetGameRoleByResult(ctx, db.GetGameRoleByResultParams{ResultID: resultID, UserID: userID}))
+}
+
+func noRole(role string, err error) (string, error) {
+	if repository.IsNoRows(err) {
+		return "", nil
+	}
+	return role, err
+}
+
+// ListRoles returns the users holding a role in the game.
+func (s *Service) ListRoles(ctx context.Context, gameID int64) ([]GameRole, error) {
+	if _, err := s.games.GetGameByID(ctx, gameID); err != nil {
+		return nil, mapNotFound(err)
+	}
+	rows, err := s.roles.ListGameRoles(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]GameRole, len(rows))
+	for i, row := range rows {
+		roles[i] = GameRole{
+			UserID:      row.UserID,
+			UserName:    row.UserName,
+			DisplayName: row.DisplayName,
+			AvatarUrl:   row.AvatarUrl,
+			Role:        row.Role,
+			CreatedAt:   row.CreatedAt,
+		}
+	}
+	return roles, nil
+}
+
+// SetRole gives the user a role in the game, replacing the one they held.
+// Organizers may appoint the jury and observers but leave other organizers
+// to admins.
+func (s *Service) SetRole(ctx context.Context, gameID, userID int64, role string, access RoleAccess) ([]GameRole, error) {
+	if !ValidRole(role) {
+		return nil, errs.NewValidationError(map[string]string{fieldRole: "must be one of organizer, jury, observer"})
+	}
+	if err := s.checkRoleChange(ctx, gameID, userID, role, access); err != nil {
+		return nil, err
+	}
+	err := s.tx.RunInTx(ctx, func(q *db.Queries) error {
+		tq := s.txQ(q)
+		current, err := noRole(tq.roles.GetGameRole(ctx, db.GetGameRoleParams{GameID: gameID, UserID: userID}))
+		if err != nil {
+			return err
+		}
+		if err := tq.roles.SetGameRole(ctx, db.SetGameRoleParams{GameID: gameID, UserID: userID, Role: role}); err != nil {
+			if repository.IsForeignKeyViolation(err) {
+				return errs.NewValidationError(map[string]string{fieldUserID: "user not found"})
+			}
+			return err
+		}
+		return recordRole(ctx, tq.games, audit.ActionSetGameRole, gameID, userID, current, role)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.ListRoles(ctx, gameID)
+}
+
+// RemoveRole takes the user's role in the game away.
+func (s *Service) RemoveRole(ctx context.Context, gameID, userID int64, access RoleAccess) error {
+	if err := s.checkRoleChange(ctx, gameID, userID, "", access); err != nil {
+		return err
+	}
+	return s.tx.RunInTx(ctx, func(q *db.Queries) error {
+		tq := s.txQ(q)
+		current, err := noRole(tq.roles.GetGameRole(ctx, db.GetGameRoleParams{GameID: gameID, UserID: userID}))
+		if err != nil {
+			return err
+		}
+		removed, err := tq.roles.DeleteGameRole(ctx, db.DeleteGameRoleParams{GameID: gameID, UserID: userID})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return errs.ErrNotFound
+		}
+		return recordRole(ctx, tq.games, audit.ActionRemoveGameRole, gameID, userID, current, "")
+	})
+}
+
+// gameRoles is what the audit log keeps of the roles a change touched, keyed
+// by user ID.
+type gameRoles map[int64]string
+
+// recordRole logs the change of a user's role in a game; an empty role is
+// none.
+func recordRole(ctx context.Context, q audit.Inserter, action string, gameID, userID int64, before, after string) error {
+	entry := audit.Entry{EntityType: audit.EntityGame, EntityID: gameID, Action: action}
+	if before != "" {
+		entry.Before = gameRoles{userID: before}
+	}
+	if after != "" {
+		entry.After = gameRoles{userID: after}
+	}
+	return audit.Record(ctx, q, entry)
+}
+
+// checkRoleChange allows admins everything and organizers to move users
+// between no role, jury and observer.
+func (s *Service) checkRoleChange(ctx context.Context, gameID, userID int64, role string, access RoleAccess) error {
+	if access.Admin {
+		if _, err := s.games.GetGameByID(ctx, gameID); err != nil {
+			return mapNotFound(err)
+		}
+		return nil
+	}
+	if !access.Organizer || role == RoleOrganizer {
+		return errs.ErrForbidden
+	}
+	current, err := s.RoleOf(ctx, gameID, userID)
+	if err != nil {
+		return err
+	}
+	if current == RoleOrganizer {
+		return errs.ErrForbidden
+	}
+	return nil
+}
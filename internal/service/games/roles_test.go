@@ -0,0 +1,213 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type mockRoleQuerier struct {
+	roles     map[[2]int64]string
+	users     map[int64]string
+	gameTeams map[int64]int64
+	results   map[int64]int64
+}
+
+func newMockRoleQuerier() *mockRoleQuerier {
+	return &mockRoleQuerier{
+		roles:     make(map[[2]int64]string),
+		users:     map[int64]string{1: "root", 7: "alice", 8: "bob"},
+		gameTeams: make(map[int64]int64),
+		results:   make(map[int64]int64),
+	}
+}
+
+func (m *mockRoleQuerier) ListGameRoles(_ context.Context, gameID int64) ([]db.ListGameRolesRow, error) {
+	var rows []db.ListGameRolesRow
+	for key, role := range m.roles {
+		if key[0] == gameID {
+			rows = append(rows, db.ListGameRolesRow{
+				GameID: gameID, UserID: key[1], Role: role,
+				UserName: m.users[key[1]], DisplayName: m.users[key[1]], CreatedAt: time.Now(),
+			})
+		}
+	}
+	return rows, nil
+}
+
+func (m *mockRoleQuerier) role(gameID, userID int64) (string, error) {
+	role, ok := m.roles[[2]int64{gameID, userID}]
+	if !ok {
+		return "", pgx.ErrNoRows
+	}
+	return role, nil
+}
+
+func (m *mockRoleQuerier) GetGameRole(_ context.Context, arg db.GetGameRoleParams) (string, error) {
+	return m.role(arg.GameID, arg.UserID)
+}
+
+func (m *mockRoleQuerier) GetGameRoleByGameTeam(_ context.Context, arg db.GetGameRoleByGameTeamParams) (string, error) {
+	return m.role(m.gameTeams[arg.GameTeamID], arg.UserID)
+}
+
+func (m *mockRoleQuerier) GetGameRoleByResult(_ context.Context, arg db.GetGameRoleByResultParams) (string, error) {
+	return m.role(m.results[arg.ResultID], arg.UserID)
+}
+
+func (m *mockRoleQuerier) SetGameRole(_ context.Context, arg db.SetGameRoleParams) error {
+	if _, ok := m.users[arg.UserID]; !ok {
+		return &pgconn.PgError{Code: "23503"}
+	}
+	m.roles[[2]int64{arg.GameID, arg.UserID}] = arg.Role
+	return nil
+}
+
+func (m *mockRoleQuerier) DeleteGameRole(_ context.Context, arg db.DeleteGameRoleParams) (int64, error) {
+	key := [2]int64{arg.GameID, arg.UserID}
+	if _, ok := m.roles[key]; !ok {
+		return 0, nil
+	}
+	delete(m.roles, key)
+	return 1, nil
+}
+
+func TestCreate_CreatorBecomesOrganizer(t *testing.T) {
+	ctx := context.Background()
+	gq, gsq, rq, frq, tx := newMocks()
+	roles := newMockRoleQuerier()
+	svc := NewService(gq, gsq, rq, frq, roles, tx)
+
+	name := "training"
+	game := mustCreateGame(t, svc, CreateParams{Name: &name, CreatorID: 7})
+	if role, _ := svc.RoleOf(ctx, game.ID, 7); role != RoleOrganizer {
+		t.Fatalf("creator role = %q, want organizer", role)
+	}
+
+	other := mustCreateGame(t, svc, CreateParams{Name: &name})
+	if list, _ := svc.ListRoles(ctx, other.ID); len(list) != 0 {
+		t.Fatalf("game without a creator has roles %+v", list)
+	}
+}
+
+func TestRoles_AdminManagesAll(t *testing.T) {
+	ctx := context.Background()
+	gq, gsq, rq, frq, tx := newMocks()
+	roles := newMockRoleQuerier()
+	svc := NewService(gq, gsq, rq, frq, roles, tx)
+	name := "training"
+	game := mustCreateGame(t, svc, CreateParams{Name: &name})
+	admin := RoleAccess{Admin: true}
+
+	var ve *errs.ValidationError
+	if _, err := svc.SetRole(ctx, game.ID, 7, "judge", admin); !errors.As(err, &ve) || ve.Fields[fieldRole] == "" {
+		t.Fatalf("unknown role error = %v, want role validation error", err)
+	}
+	if _, err := svc.SetRole(ctx, game.ID, 99, RoleJury, admin); !errors.As(err, &ve) || ve.Fields[fieldUserID] == "" {
+		t.Fatalf("unknown user error = %v, want user_id validation error", err)
+	}
+	if _, err := svc.SetRole(ctx, 404, 7, RoleJury, admin); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("unknown game error = %v, want ErrNotFound", err)
+	}
+
+	list, err := svc.SetRole(ctx, game.ID, 7, RoleOrganizer, admin)
+	if err != nil {
+		t.Fatalf("SetRole: %v", err)
+	}
+	if len(list) != 1 || list[0].UserName != "alice" || list[0].Role != RoleOrganizer {
+		t.Fatalf("roles = %+v", list)
+	}
+	if _, err := svc.SetRole(ctx, game.ID, 7, RoleObserver, admin); err != nil {
+		t.Fatalf("SetRole replace: %v", err)
+	}
+	if role, _ := svc.RoleOf(ctx, game.ID, 7); role != RoleObserver {
+		t.Fatalf("role after replace = %q", role)
+	}
+
+	if err := svc.RemoveRole(ctx, game.ID, 7, admin); err != nil {
+		t.Fatalf("RemoveRole: %v", err)
+	}
+	if err := svc.RemoveRole(ctx, game.ID, 7, admin); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("second RemoveRole error = %v, want ErrNotFound", err)
+	}
+	if role, err := svc.RoleOf(ctx, game.ID, 7); err != nil || role != "" {
+		t.Fatalf("RoleOf after removal = %q, %v", role, err)
+	}
+
+	want := []struct{ action, before, after string }{
+		{audit.ActionSetGameRole, "", `{"7":"organizer"}`},
+		{audit.ActionSetGameRole, `{"7":"organizer"}`, `{"7":"observer"}`},
+		{audit.ActionRemoveGameRole, `{"7":"observer"}`, ""},
+	}
+	if len(gq.audit) != len(want) {
+		t.Fatalf("audit events = %+v, want %d", gq.audit, len(want))
+	}
+	for i, w := range want {
+		e := gq.audit[i]
+		if e.EntityType != audit.EntityGame || e.Action != w.action || string(e.Before) != w.before || string(e.After) != w.after {
+			t.Errorf("audit event %d = %s %s %s -> %s, want %s %s -> %s", i, e.EntityType, e.Action, e.Before, e.After, w.action, w.before, w.after)
+		}
+	}
+}
+
+func TestRoles_OrganizerLimits(t *testing.T) {
+	ctx := context.Background()
+	gq, gsq, rq, frq, tx := newMocks()
+	roles := newMockRoleQuerier()
+	svc := NewService(gq, gsq, rq, frq, roles, tx)
+	name := "training"
+	game := mustCreateGame(t, svc, CreateParams{Name: &name, CreatorID: 1})
+	organizer := RoleAccess{Organizer: true}
+
+	if _, err := svc.SetRole(ctx, game.ID, 7, RoleJury, RoleAccess{}); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("outsider SetRole error = %v, want ErrForbidden", err)
+	}
+	if _, err := svc.SetRole(ctx, game.ID, 7, RoleOrganizer, organizer); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("organizer appointing an organizer: %v, want ErrForbidden", err)
+	}
+	if _, err := svc.SetRole(ctx, game.ID, 1, RoleJury, organizer); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("organizer demoting an organizer: %v, want ErrForbidden", err)
+	}
+	if err := svc.RemoveRole(ctx, game.ID, 1, organizer); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("organizer removing an organizer: %v, want ErrForbidden", err)
+	}
+
+	if _, err := svc.SetRole(ctx, game.ID, 7, RoleJury, organizer); err != nil {
+		t.Fatalf("organizer appointing the jury: %v", err)
+	}
+	if _, err := svc.SetRole(ctx, game.ID, 7, RoleObserver, organizer); err != nil {
+		t.Fatalf("organizer moving jury to observers: %v", err)
+	}
+	if err := svc.RemoveRole(ctx, game.ID, 7, organizer); err != nil {
+		t.Fatalf("organizer removing an observer: %v", err)
+	}
+}
+
+func TestRoles_ThroughGameTeamsAndResults(t *testing.T) {
+	ctx := context.Background()
+	gq, gsq, rq, frq, tx := newMocks()
+	roles := newMockRoleQuerier()
+	svc := NewService(gq, gsq, rq, frq, roles, tx)
+	roles.roles[[2]int64{3, 7}] = RoleJury
+	roles.gameTeams[10] = 3
+	roles.results[20] = 3
+	roles.results[21] = 4
+
+	if role, err := svc.RoleOfGameTeam(ctx, 10, 7); err != nil || role != RoleJury {
+		t.Fatalf("RoleOfGameTeam = %q, %v", role, err)
+	}
+	if role, err := svc.RoleOfResult(ctx, 20, 7); err != nil || role != RoleJury {
+		t.Fatalf("RoleOfResult = %q, %v", role, err)
+	}
+	if role, err := svc.RoleOfResult(ctx, 21, 7); err != nil || role != "" {
+		t.Fatalf("RoleOfResult in another game = %q, %v", role, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
@@ -41,10 +42,14 @@ type Game struct {
 	ScoreboardStatusVal  ScoreboardStatus   `json:"scoreboard_status"`
 }
 
-// GameServiceLink is a service attached to a game together with its planning status.
+// GameServiceLink is a service attached to a game together with its planning
+// status and the archive versions the game is pinned to, if any.
 type GameServiceLink struct {
-	ServiceID int64  `json:"service_id"`
-	Status    string `json:"status"`
+	ServiceID              int64   `json:"service_id"`
+	Status                 string  `json:"status"`
+	PinnedServiceVersionID *int64  `json:"pinned_service_version_id"`
+	PinnedCheckerVersionID *int64  `json:"pinned_checker_version_id"`
+	PinnedGitCommit        *string `json:"pinned_git_commit"`
 }
 
 type GameListResult struct {
@@ -73,6 +78,8 @@ type CreateParams struct {
 	Published            *bool      `json:"published"`
 	Theme                *string    `json:"theme"`
 	Requirements         *string    `json:"requirements"`
+	// CreatorID, when set, becomes the organizer of the new game.
+	CreatorID int64 `json:"-"`
 }
 
 type UpdateParams struct {
@@ -98,12 +105,14 @@ type UpdateParams struct {
 type GameQuerier interface {
 	CreateGame(ctx context.Context, arg db.CreateGameParams) (db.Game, error)
 	GetGameByID(ctx context.Context, id int64) (db.Game, error)
+	LockGameForUpdate(ctx context.Context, id int64) (int64, error)
 	ListGames(ctx context.Context, arg db.ListGamesParams) ([]db.Game, error)
 	CountGames(ctx context.Context, arg db.CountGamesParams) (int64, error)
 	UpdateGame(ctx context.Context, arg db.UpdateGameParams) (db.Game, error)
 	DeleteGame(ctx context.Context, id int64) error
 	SetFinalized(ctx context.Context, arg db.SetFinalizedParams) (db.Game, error)
 	SetPublished(ctx context.Context, arg db.SetPublishedParams) (db.Game, error)
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
 }
 
 type GamesServiceQuerier interface {
@@ -111,6 +120,14 @@ type GamesServiceQuerier interface {
 	RemoveService(ctx context.Context, arg db.RemoveServiceParams) error
 	ListServicesByGame(ctx context.Context, gameID int64) ([]db.ListServicesByGameRow, error)
 	SetServiceStatus(ctx context.Context, arg db.SetServiceStatusParams) error
+	SetGameServicePin(ctx context.Context, arg db.SetGameServicePinParams) (int64, error)
+	PinCurrentServiceVersions(ctx context.Context, gameID int64) error
+	ListGamePinsByService(ctx context.Context, serviceID int64) ([]db.ListGamePinsByServiceRow, error)
+	GetServiceArchiveVersion(ctx context.Context, arg db.GetServiceArchiveVersionParams) (db.ServiceArchiveVersion, error)
+	GetLatestServiceArchiveVersionByCommit(ctx context.Context, arg db.GetLatestServiceArchiveVersionByCommitParams) (db.ServiceArchiveVersion, error)
+	GetServiceArchiveVersionAsOf(ctx context.Context, arg db.GetServiceArchiveVersionAsOfParams) (db.ServiceArchiveVersion, error)
+	ListGameServicePorts(ctx context.Context, gameID int64) ([]db.ListGameServicePortsRow, error)
+	GetServiceByID(ctx context.Context, id int64) (db.Service, error)
 }
 
 type ResultQuerier interface {
@@ -132,11 +149,20 @@ type Service struct {
 	gamesSvc     GamesServiceQuerier
 	results      ResultQuerier
 	finalResults FinalResultQuerier
+	roles        RoleQuerier
 	tx           TxRunner
+
+	blockPublishOnPortConflicts bool
+}
+
+func NewService(games GameQuerier, gamesSvc GamesServiceQuerier, results ResultQuerier, finalResults FinalResultQuerier, roles RoleQuerier, tx TxRunner) *Service {
+	return &Service{games: games, gamesSvc: gamesSvc, results: results, finalResults: finalResults, roles: roles, tx: tx}
 }
 
-func NewService(games GameQuerier, gamesSvc GamesServiceQuerier, results ResultQuerier, finalResults FinalResultQuerier, tx TxRunner) *Service {
-	return &Service{games: games, gamesSvc: gamesSvc, results: results, finalResults: finalResults, tx: tx}
+// SetBlockPublishOnPortConflicts makes Publish fail while services of the game
+// share a vulnbox port.
+func (s *Service) SetBlockPublishOnPortConflicts(block bool) {
+	s.blockPublishOnPortConflicts = block
 }
 
 type txQueriers struct {
@@ -144,13 +170,14 @@ type txQueriers struct {
 	gamesSvc     GamesServiceQuerier
 	results      ResultQuerier
 	finalResults FinalResultQuerier
+	roles        RoleQuerier
 }
 
 func (s *Service) txQ(q *db.Queries) *txQueriers {
 	if q == nil {
-		return &txQueriers{games: s.games, gamesSvc: s.gamesSvc, results: s.results, finalResults: s.finalResults}
+		return &txQueriers{games: s.games, gamesSvc: s.gamesSvc, results: s.results, finalResults: s.finalResults, roles: s.roles}
 	}
-	return &txQueriers{games: q, gamesSvc: q, results: q, finalResults: q}
+	return &txQueriers{games: q, gamesSvc: q, results: q, finalResults: q, roles: q}
 }
 
 func validHTTPURL(s string) bool {
@@ -212,30 +239,42 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (*Game, error
 		published = *params.Published
 	}
 
-	dbGame, err := s.games.CreateGame(ctx, db.CreateGameParams{
-		Name:                 params.Name,
-		Organizer:            params.Organizer,
-		StartsAt:             timeToTimestamptz(params.StartsAt),
-		EndsAt:               timeToTimestamptz(params.EndsAt),
-		AvatarUrl:            params.AvatarUrl,
-		SiteUrl:              params.SiteUrl,
-		CtftimeUrl:           params.CtftimeUrl,
-		Finalized:            false,
-		FinalizedAt:          pgtype.Timestamptz{},
-		RegistrationOpensAt:  timeToTimestamptz(params.RegistrationOpensAt),
-		RegistrationClosesAt: timeToTimestamptz(params.RegistrationClosesAt),
-		ScoreboardOpensAt:    timeToTimestamptz(params.ScoreboardOpensAt),
-		ScoreboardClosesAt:   timeToTimestamptz(params.ScoreboardClosesAt),
-		VpnUrl:               params.VpnUrl,
-		VpnConfigUrl:         params.VpnConfigUrl,
-		AccessInstructions:   params.AccessInstructions,
-		AccessSecret:         params.AccessSecret,
-		Published:            published,
-		Theme:                params.Theme,
-		Requirements:         params.Requirements,
+	var dbGame db.Game
+	err := s.tx.RunInTx(ctx, func(q *db.Queries) error {
+		tq := s.txQ(q)
+		var err error
+		dbGame, err = tq.games.CreateGame(ctx, db.CreateGameParams{
+			Name:                 params.Name,
+			Organizer:            params.Organizer,
+			StartsAt:             timeToTimestamptz(params.StartsAt),
+			EndsAt:               timeToTimestamptz(params.EndsAt),
+			AvatarUrl:            params.AvatarUrl,
+			SiteUrl:              params.SiteUrl,
+			CtftimeUrl:           params.CtftimeUrl,
+			Finalized:            false,
+			FinalizedAt:          pgtype.Timestamptz{},
+			RegistrationOpensAt:  timeToTimestamptz(params.RegistrationOpensAt),
+			RegistrationClosesAt: timeToTimestamptz(params.RegistrationClosesAt),
+			ScoreboardOpensAt:    timeToTimestamptz(params.ScoreboardOpensAt),
+			ScoreboardClosesAt:   timeToTimestamptz(params.ScoreboardClosesAt),
+			VpnUrl:               params.VpnUrl,
+			VpnConfigUrl:         params.VpnConfigUrl,
+			AccessInstructions:   params.AccessInstructions,
+			AccessSecret:         params.AccessSecret,
+			Published:            published,
+			Theme:                params.Theme,
+			Requirements:         params.Requirements,
+		})
+		if err != nil {
+			return mapDBError(err)
+		}
+		if params.CreatorID == 0 {
+			return nil
+		}
+		return tq.roles.SetGameRole(ctx, db.SetGameRoleParams{GameID: dbGame.ID, UserID: params.CreatorID, Role: RoleOrganizer})
 	})
 	if err != nil {
-		return nil, mapDBError(err)
+		return nil, err
 	}
 	g := fromDB(dbGame)
 	return &g, nil
@@ -348,6 +387,9 @@ func (s *Service) Delete(ctx context.Context, id int64) error {
 
 // Publish flips a planning game into the published games section.
 func (s *Service) Publish(ctx context.Context, id int64) (*Game, error) {
+	if err := s.checkPublishPorts(ctx, id); err != nil {
+		return nil, err
+	}
 	dbGame, err := s.games.SetPublished(ctx, db.SetPublishedParams{ID: id, Published: true})
 	if err != nil {
 		return nil, mapNotFound(err)
@@ -361,7 +403,18 @@ func (s *Service) AddService(ctx context.Context, gameID, serviceID int64, statu
 	if status != nil && *status != "" {
 		st = *status
 	}
-	return s.gamesSvc.AddService(ctx, db.AddServiceParams{GameID: gameID, ServiceID: serviceID, Status: st})
+	return s.tx.RunInTx(ctx, func(q *db.Queries) error {
+		tq := s.txQ(q)
+		// Concurrent additions to the same game wait on the game row, so
+		// each of them checks ports against the services already added.
+		if _, err := tq.games.LockGameForUpdate(ctx, gameID); err != nil {
+			return mapNotFound(err)
+		}
+		if err := checkAddServicePorts(ctx, tq.gamesSvc, gameID, serviceID); err != nil {
+			return err
+		}
+		return tq.gamesSvc.AddService(ctx, db.AddServiceParams{GameID: gameID, ServiceID: serviceID, Status: st})
+	})
 }
 
 func (s *Service) RemoveService(ctx context.Context, gameID, serviceID int64) error {
@@ -379,7 +432,7 @@ func (s *Service) ListServices(ctx context.Context, gameID int64) ([]GameService
 	}
 	links := make([]GameServiceLink, len(rows))
 	for i, r := range rows {
-		links[i] = GameServiceLink{ServiceID: r.ServiceID, Status: r.Status}
+		links[i] = gameServiceLinkFromDB(r)
 	}
 	return links, nil
 }
@@ -396,10 +449,19 @@ func (s *Service) Finalize(ctx context.Context, gameID int64) (*Game, error) {
 	err = s.tx.RunInTx(ctx, func(q *db.Queries) error {
 		tq := s.txQ(q)
 		now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
-		_, err := tq.games.SetFinalized(ctx, db.SetFinalizedParams{ID: gameID, Finalized: true, FinalizedAt: now})
+		finalized, err := tq.games.SetFinalized(ctx, db.SetFinalizedParams{ID: gameID, Finalized: true, FinalizedAt: now})
 		if err != nil {
 			return err
 		}
+		if err := recordFinalization(ctx, tq.games, audit.ActionFinalize, game, finalized); err != nil {
+			return err
+		}
+
+		// Freeze the archives the game was played with so later service
+		// updates do not change what an export of this game contains.
+		if err := tq.gamesSvc.PinCurrentServiceVersions(ctx, gameID); err != nil {
+			return err
+		}
 
 		if err := tq.finalResults.DeleteFinalResultsByGame(ctx, gameID); err != nil {
 			return err
@@ -462,10 +524,13 @@ func (s *Service) Unfinalize(ctx context.Context, gameID int64) (*Game, error) {
 
 	err = s.tx.RunInTx(ctx, func(q *db.Queries) error {
 		tq := s.txQ(q)
-		_, err := tq.games.SetFinalized(ctx, db.SetFinalizedParams{ID: gameID, Finalized: false, FinalizedAt: pgtype.Timestamptz{}})
+		unfinalized, err := tq.games.SetFinalized(ctx, db.SetFinalizedParams{ID: gameID, Finalized: false, FinalizedAt: pgtype.Timestamptz{}})
 		if err != nil {
 			return err
 		}
+		if err := recordFinalization(ctx, tq.games, audit.ActionUnfinalize, game, unfinalized); err != nil {
+			return err
+		}
 		return tq.finalResults.DeleteFinalResultsByGame(ctx, gameID)
 	})
 	if err != nil {
@@ -480,6 +545,30 @@ func (s *Service) Unfinalize(ctx context.Context, gameID int64) (*Game, error) {
 	return &g, nil
 }
 
+// finalization is the part of a game that finalizing changes.
+type finalization struct {
+	Finalized   bool       `json:"finalized"`
+	FinalizedAt *time.Time `json:"finalized_at"`
+}
+
+func finalizationOf(g db.Game) finalization {
+	f := finalization{Finalized: g.Finalized}
+	if g.FinalizedAt.Valid {
+		f.FinalizedAt = &g.FinalizedAt.Time
+	}
+	return f
+}
+
+func recordFinalization(ctx context.Context, q audit.Inserter, action string, before, after db.Game) error {
+	return audit.Record(ctx, q, audit.Entry{
+		EntityType: audit.EntityGame,
+		EntityID:   before.ID,
+		Action:     action,
+		Before:     finalizationOf(before),
+		After:      finalizationOf(after),
+	})
+}
+
 func fromDB(g db.Game) Game {
 	now := time.Now()
 	var startsAt *time.Time
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 )
@@ -15,11 +16,17 @@ import (
 type mockGameQuerier struct {
 	games  map[int64]db.Game
 	nextID int64
+	audit  []db.InsertAuditEventParams
 }
 
 type mockGamesServiceQuerier struct {
-	pairs    map[string]bool
-	statuses map[string]string
+	pairs     map[string]bool
+	statuses  map[string]string
+	pins      map[string]db.SetGameServicePinParams
+	versions  []db.ServiceArchiveVersion
+	pinnedAll []int64
+	ports     map[int64][]int32
+	private   map[int64]bool
 }
 
 type mockResultQuerier struct {
@@ -82,6 +89,13 @@ func (m *mockGameQuerier) GetGameByID(_ context.Context, id int64) (db.Game, err
 	return g, nil
 }
 
+func (m *mockGameQuerier) LockGameForUpdate(_ context.Context, id int64) (int64, error) {
+	if _, ok := m.games[id]; !ok {
+		return 0, pgx.ErrNoRows
+	}
+	return id, nil
+}
+
 func (m *mockGameQuerier) ListGames(_ context.Context, arg db.ListGamesParams) ([]db.Game, error) {
 	var result []db.Game
 	for i := int32(0); i < arg.Limit; i++ {
@@ -121,6 +135,11 @@ func (m *mockGameQuerier) DeleteGame(_ context.Context, id int64) error {
 	return nil
 }
 
+func (m *mockGameQuerier) InsertAuditEvent(_ context.Context, arg db.InsertAuditEventParams) error {
+	m.audit = append(m.audit, arg)
+	return nil
+}
+
 func (m *mockGameQuerier) SetFinalized(_ context.Context, arg db.SetFinalizedParams) (db.Game, error) {
 	g, ok := m.games[arg.ID]
 	if !ok {
@@ -165,7 +184,13 @@ func (m *mockGamesServiceQuerier) ListServicesByGame(_ context.Context, gameID i
 			if status == "" {
 				status = "planning"
 			}
-			result = append(result, db.ListServicesByGameRow{ServiceID: s, Status: status})
+			pin := m.pins[key]
+			result = append(result, db.ListServicesByGameRow{
+				ServiceID:              s,
+				Status:                 status,
+				PinnedServiceVersionID: pin.PinnedServiceVersionID,
+				PinnedCheckerVersionID: pin.PinnedCheckerVersionID,
+			})
 		}
 	}
 	return result, nil
@@ -209,7 +234,7 @@ func ptrStr(v string) *string { return &v }
 
 func TestCreate_Success(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	game, err := svc.Create(context.Background(), CreateParams{Name: &name})
@@ -223,7 +248,7 @@ func TestCreate_Success(t *testing.T) {
 
 func TestCreate_InvalidURL(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	badURL := "not-a-url"
 	_, err := svc.Create(context.Background(), CreateParams{
@@ -237,7 +262,7 @@ func TestCreate_InvalidURL(t *testing.T) {
 
 func TestGetByID_Success(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	mustCreateGame(t, svc, CreateParams{Name: &name})
@@ -253,7 +278,7 @@ func TestGetByID_Success(t *testing.T) {
 
 func TestGetByID_NotFound(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	_, err := svc.GetByID(context.Background(), 999)
 	if err != errs.ErrNotFound {
@@ -263,7 +288,7 @@ func TestGetByID_NotFound(t *testing.T) {
 
 func TestList(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	for i := 0; i < 5; i++ {
 		n := fmt.Sprintf("Game %d", i)
@@ -284,7 +309,7 @@ func TestList(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	mustCreateGame(t, svc, CreateParams{Name: &name})
@@ -301,7 +326,7 @@ func TestUpdate(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	mustCreateGame(t, svc, CreateParams{Name: &name})
@@ -318,7 +343,7 @@ func TestDelete(t *testing.T) {
 
 func TestFinalize_Success(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	mustCreateGame(t, svc, CreateParams{Name: &name})
@@ -348,11 +373,18 @@ func TestFinalize_Success(t *testing.T) {
 	if fr[1].Score != 200 || *fr[1].Position != 2 {
 		t.Errorf("second result: score=%d pos=%d, want 200/2", fr[1].Score, *fr[1].Position)
 	}
+
+	if len(gq.audit) != 1 || gq.audit[0].Action != audit.ActionFinalize || gq.audit[0].EntityID != "1" {
+		t.Fatalf("audit events = %+v, want one finalize of game 1", gq.audit)
+	}
+	if before := string(gq.audit[0].Before); before != `{"finalized":false,"finalized_at":null}` {
+		t.Errorf("audit before = %s", before)
+	}
 }
 
 func TestFinalize_AlreadyFinalized(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	mustCreateGame(t, svc, CreateParams{Name: &name})
@@ -368,7 +400,7 @@ func TestFinalize_AlreadyFinalized(t *testing.T) {
 
 func TestUnfinalize_Success(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	mustCreateGame(t, svc, CreateParams{Name: &name})
@@ -387,7 +419,7 @@ func TestUnfinalize_Success(t *testing.T) {
 
 func TestUnfinalize_NotFinalized(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	mustCreateGame(t, svc, CreateParams{Name: &name})
@@ -400,7 +432,9 @@ func TestUnfinalize_NotFinalized(t *testing.T) {
 
 func TestAddService(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
+	name := "game"
+	mustCreateGame(t, svc, CreateParams{Name: &name})
 
 	err := svc.AddService(context.Background(), 1, 10, nil)
 	if err != nil {
@@ -413,7 +447,9 @@ func TestAddService(t *testing.T) {
 
 func TestRemoveService(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
+	name := "game"
+	mustCreateGame(t, svc, CreateParams{Name: &name})
 
 	if err := svc.AddService(context.Background(), 1, 10, nil); err != nil {
 		t.Fatalf("AddService: %v", err)
@@ -429,7 +465,7 @@ func TestRemoveService(t *testing.T) {
 
 func TestCreate_PlanningDefaultsAndPublish(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	notPublished := false
 	game, err := svc.Create(context.Background(), CreateParams{
@@ -459,7 +495,7 @@ func TestCreate_PlanningDefaultsAndPublish(t *testing.T) {
 
 func TestCreate_DefaultsPublished(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	game, err := svc.Create(context.Background(), CreateParams{Name: ptrStr("Quick Game")})
 	if err != nil {
@@ -472,7 +508,9 @@ func TestCreate_DefaultsPublished(t *testing.T) {
 
 func TestServiceStatusFlow(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
+	name := "game"
+	mustCreateGame(t, svc, CreateParams{Name: &name})
 
 	if err := svc.AddService(context.Background(), 1, 10, ptrStr("design")); err != nil {
 		t.Fatalf("AddService: %v", err)
@@ -515,7 +553,7 @@ func TestValidHTTPURL(t *testing.T) {
 
 func TestCreate_ValidURLs(t *testing.T) {
 	gq, gsq, rq, frq, tx := newMocks()
-	svc := NewService(gq, gsq, rq, frq, tx)
+	svc := NewService(gq, gsq, rq, frq, newMockRoleQuerier(), tx)
 
 	name := "Test Game"
 	siteUrl := "https://example.com"
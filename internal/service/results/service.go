@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
@@ -35,6 +36,8 @@ type GameQuerier interface {
 type Querier interface {
 	CreateResult(ctx context.Context, arg db.CreateResultParams) (db.Result, error)
 	GetResultByID(ctx context.Context, id int64) (db.Result, error)
+	LockResult(ctx context.Context, id int64) (db.Result, error)
+	LockResultByGameAndTeam(ctx context.Context, arg db.LockResultByGameAndTeamParams) (db.Result, error)
 	ListResultsByGame(ctx context.Context, gameID int64) ([]db.Result, error)
 	ListResultsByTeam(ctx context.Context, teamID int64) ([]db.Result, error)
 	ListResultsByGameAndTeam(ctx context.Context, arg db.ListResultsByGameAndTeamParams) ([]db.Result, error)
@@ -42,17 +45,57 @@ type Querier interface {
 	UpsertResult(ctx context.Context, arg db.UpsertResultParams) (db.Result, error)
 	UpdateResult(ctx context.Context, arg db.UpdateResultParams) (db.Result, error)
 	DeleteResult(ctx context.Context, id int64) error
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+}
+
+type TxRunner interface {
+	RunInTx(ctx context.Context, fn func(queries *db.Queries) error) error
 }
 
 type Service struct {
 	q     Querier
 	games GameQuerier
+	tx    TxRunner
 }
 
 func NewService(q Querier, games GameQuerier) *Service {
 	return &Service{q: q, games: games}
 }
 
+// SetTxRunner makes every change to a result commit together with its audit
+// event.
+func (s *Service) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+func (s *Service) inTx(ctx context.Context, fn func(q Querier) error) error {
+	if s.tx != nil {
+		return s.tx.RunInTx(ctx, func(q *db.Queries) error { return fn(q) })
+	}
+	return fn(s.q)
+}
+
+// resultState is what the audit log keeps of a result.
+type resultState struct {
+	GameID int64  `json:"game_id"`
+	TeamID int64  `json:"team_id"`
+	Score  *int32 `json:"score"`
+}
+
+func stateOf(r db.Result) *resultState {
+	return &resultState{GameID: r.GameID, TeamID: r.TeamID, Score: r.Score}
+}
+
+func record(ctx context.Context, q Querier, id int64, action string, before, after *resultState) error {
+	return audit.Record(ctx, q, audit.Entry{
+		EntityType: audit.EntityResult,
+		EntityID:   id,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	})
+}
+
 func (s *Service) checkNotFinalized(ctx context.Context, gameID int64, callerRole string) error {
 	if callerRole == "admin" {
 		return nil
@@ -71,13 +114,21 @@ func (s *Service) Create(ctx context.Context, params CreateParams, callerRole st
 	if err := s.checkNotFinalized(ctx, params.GameID, callerRole); err != nil {
 		return nil, err
 	}
-	dbResult, err := s.q.CreateResult(ctx, db.CreateResultParams{
-		GameID: params.GameID,
-		TeamID: params.TeamID,
-		Score:  params.Score,
+	var dbResult db.Result
+	err := s.inTx(ctx, func(q Querier) error {
+		var err error
+		dbResult, err = q.CreateResult(ctx, db.CreateResultParams{
+			GameID: params.GameID,
+			TeamID: params.TeamID,
+			Score:  params.Score,
+		})
+		if err != nil {
+			return mapDBError(err)
+		}
+		return record(ctx, q, dbResult.ID, audit.ActionCreate, nil, stateOf(dbResult))
 	})
 	if err != nil {
-		return nil, mapDBError(err)
+		return nil, err
 	}
 	r := fromDB(dbResult)
 	return &r, nil
@@ -144,46 +195,74 @@ func (s *Service) Upsert(ctx context.Context, gameID, teamID int64, score *int32
 	if err := s.checkNotFinalized(ctx, gameID, callerRole); err != nil {
 		return nil, err
 	}
-	dbResult, err := s.q.UpsertResult(ctx, db.UpsertResultParams{
-		GameID: gameID,
-		TeamID: teamID,
-		Score:  score,
+	var dbResult db.Result
+	err := s.inTx(ctx, func(q Querier) error {
+		var before *resultState
+		existing, err := q.LockResultByGameAndTeam(ctx, db.LockResultByGameAndTeamParams{GameID: gameID, TeamID: teamID})
+		switch {
+		case err == nil:
+			before = stateOf(existing)
+		case !repository.IsNoRows(err):
+			return err
+		}
+		dbResult, err = q.UpsertResult(ctx, db.UpsertResultParams{
+			GameID: gameID,
+			TeamID: teamID,
+			Score:  score,
+		})
+		if err != nil {
+			return mapDBError(err)
+		}
+		return record(ctx, q, dbResult.ID, audit.ActionUpdate, before, stateOf(dbResult))
 	})
 	if err != nil {
-		return nil, mapDBError(err)
+		return nil, err
 	}
 	r := fromDB(dbResult)
 	return &r, nil
 }
 
 func (s *Service) Update(ctx context.Context, id int64, params UpdateParams, callerRole string) (*Result, error) {
-	dbResult, err := s.q.GetResultByID(ctx, id)
-	if err != nil {
-		return nil, mapNotFound(err)
-	}
-	if err := s.checkNotFinalized(ctx, dbResult.GameID, callerRole); err != nil {
-		return nil, err
-	}
-	dbResult, err = s.q.UpdateResult(ctx, db.UpdateResultParams{
-		ID:    id,
-		Score: params.Score,
+	var dbResult db.Result
+	err := s.inTx(ctx, func(q Querier) error {
+		current, err := q.LockResult(ctx, id)
+		if err != nil {
+			return mapNotFound(err)
+		}
+		if err := s.checkNotFinalized(ctx, current.GameID, callerRole); err != nil {
+			return err
+		}
+		before := stateOf(current)
+		dbResult, err = q.UpdateResult(ctx, db.UpdateResultParams{
+			ID:    id,
+			Score: params.Score,
+		})
+		if err != nil {
+			return mapNotFound(err)
+		}
+		return record(ctx, q, id, audit.ActionUpdate, before, stateOf(dbResult))
 	})
 	if err != nil {
-		return nil, mapNotFound(err)
+		return nil, err
 	}
 	r := fromDB(dbResult)
 	return &r, nil
 }
 
 func (s *Service) Delete(ctx context.Context, id int64, callerRole string) error {
-	dbResult, err := s.q.GetResultByID(ctx, id)
-	if err != nil {
-		return mapNotFound(err)
-	}
-	if err := s.checkNotFinalized(ctx, dbResult.GameID, callerRole); err != nil {
-		return err
-	}
-	return s.q.DeleteResult(ctx, id)
+	return s.inTx(ctx, func(q Querier) error {
+		dbResult, err := q.LockResult(ctx, id)
+		if err != nil {
+			return mapNotFound(err)
+		}
+		if err := s.checkNotFinalized(ctx, dbResult.GameID, callerRole); err != nil {
+			return err
+		}
+		if err := q.DeleteResult(ctx, id); err != nil {
+			return err
+		}
+		return record(ctx, q, id, audit.ActionDelete, stateOf(dbResult), nil)
+	})
 }
 
 func fromDB(r db.Result) Result {
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 )
@@ -20,6 +21,7 @@ type mockQuerier struct {
 	results map[int64]db.Result
 	nextID  int64
 	byGame  map[int64][]db.Result
+	audit   []db.InsertAuditEventParams
 }
 
 func newMocks() (*mockGameQuerier, *mockQuerier) {
@@ -100,6 +102,19 @@ func (m *mockQuerier) ListAllResults(_ context.Context) ([]db.Result, error) {
 	return result, nil
 }
 
+func (m *mockQuerier) LockResult(ctx context.Context, id int64) (db.Result, error) {
+	return m.GetResultByID(ctx, id)
+}
+
+func (m *mockQuerier) LockResultByGameAndTeam(_ context.Context, arg db.LockResultByGameAndTeamParams) (db.Result, error) {
+	for _, r := range m.results {
+		if r.GameID == arg.GameID && r.TeamID == arg.TeamID {
+			return r, nil
+		}
+	}
+	return db.Result{}, pgx.ErrNoRows
+}
+
 func (m *mockQuerier) UpsertResult(_ context.Context, arg db.UpsertResultParams) (db.Result, error) {
 	for id, r := range m.results {
 		if r.GameID == arg.GameID && r.TeamID == arg.TeamID {
@@ -130,6 +145,11 @@ func (m *mockQuerier) DeleteResult(_ context.Context, id int64) error {
 	return nil
 }
 
+func (m *mockQuerier) InsertAuditEvent(_ context.Context, arg db.InsertAuditEventParams) error {
+	m.audit = append(m.audit, arg)
+	return nil
+}
+
 func TestCreate_Success(t *testing.T) {
 	gq, q := newMocks()
 	svc := NewService(q, gq)
@@ -232,6 +252,17 @@ func TestUpsert(t *testing.T) {
 	if *r.Score != 150 {
 		t.Errorf("Score = %d, want 150", *r.Score)
 	}
+	upsert := q.audit[len(q.audit)-1]
+	if string(upsert.Before) != `{"score":100}` || string(upsert.After) != `{"score":150}` {
+		t.Errorf("audit diff = %s -> %s, want the replaced score as before", upsert.Before, upsert.After)
+	}
+
+	if _, err := svc.Upsert(context.Background(), 1, 2, &s2, "player"); err != nil {
+		t.Fatalf("Upsert new: %v", err)
+	}
+	if created := q.audit[len(q.audit)-1]; created.Before != nil {
+		t.Errorf("audit before = %s for a new result, want none", created.Before)
+	}
 }
 
 func TestUpdate(t *testing.T) {
@@ -250,6 +281,17 @@ func TestUpdate(t *testing.T) {
 	if *r.Score != 200 {
 		t.Errorf("Score = %d, want 200", *r.Score)
 	}
+
+	if len(q.audit) != 2 {
+		t.Fatalf("audit events = %d, want create and update", len(q.audit))
+	}
+	update := q.audit[1]
+	if update.Action != audit.ActionUpdate || update.EntityID != "1" {
+		t.Errorf("audit event = %s %s/%s", update.Action, update.EntityType, update.EntityID)
+	}
+	if string(update.Before) != `{"score":100}` || string(update.After) != `{"score":200}` {
+		t.Errorf("audit diff = %s -> %s, want only the score", update.Before, update.After)
+	}
 }
 
 func TestUpdate_FinalizedForbidden(t *testing.T) {
@@ -285,6 +327,9 @@ func TestDelete(t *testing.T) {
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
+	if last := q.audit[len(q.audit)-1]; last.Action != audit.ActionDelete || last.Before == nil || last.After != nil {
+		t.Errorf("delete audit event = %+v, want the removed result as before", last)
+	}
 }
 
 func TestDelete_FinalizedForbidden(t *testing.T) {
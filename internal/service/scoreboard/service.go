@@ -62,7 +62,10 @@ func NewService(games GameQuerier, results ResultQuerier, finalResults FinalResu
 	return &Service{games: games, results: results, finalResults: finalResults, teams: teams}
 }
 
-func (s *Service) ForGame(ctx context.Context, gameID int64, viewerRole string) (*Scoreboard, error) {
+// ForGame returns the standings of a game. A scoreboard that is not open yet
+// or already closed is only shown to viewers with seesHidden: admins and
+// users holding a role in the game.
+func (s *Service) ForGame(ctx context.Context, gameID int64, seesHidden bool) (*Scoreboard, error) {
 	game, err := s.games.GetGameByID(ctx, gameID)
 	if err != nil {
 		return nil, errs.ErrNotFound
@@ -80,7 +83,7 @@ func (s *Service) ForGame(ctx context.Context, gameID int64, viewerRole string)
 
 	sbStatus := gamesvc.ComputeScoreboardStatus(scOpensAt, scClosesAt, now)
 
-	if viewerRole != "admin" && (sbStatus == gamesvc.ScoreClosed || sbStatus == gamesvc.ScoreUpcoming) {
+	if !seesHidden && (sbStatus == gamesvc.ScoreClosed || sbStatus == gamesvc.ScoreUpcoming) {
 		return nil, errs.ErrForbidden
 	}
 
@@ -87,7 +87,7 @@ func TestForGame_Finalized(t *testing.T) {
 		{TeamID: 2, Score: 100, Position: ptrInt32(2)},
 	}
 
-	sb, err := svc.ForGame(context.Background(), 1, "admin")
+	sb, err := svc.ForGame(context.Background(), 1, true)
 	if err != nil {
 		t.Fatalf("ForGame: %v", err)
 	}
@@ -110,7 +110,7 @@ func TestForGame_NotFinalized(t *testing.T) {
 		{TeamID: 1, Score: &s1},
 	}
 
-	sb, err := svc.ForGame(context.Background(), 1, "admin")
+	sb, err := svc.ForGame(context.Background(), 1, true)
 	if err != nil {
 		t.Fatalf("ForGame: %v", err)
 	}
@@ -126,7 +126,7 @@ func TestForGame_NotFound(t *testing.T) {
 	gq, rq, frq, tq := newMocks()
 	svc := NewService(gq, rq, frq, tq)
 
-	_, err := svc.ForGame(context.Background(), 999, "admin")
+	_, err := svc.ForGame(context.Background(), 999, true)
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
@@ -143,7 +143,7 @@ func TestForGame_ClosedScoreboard(t *testing.T) {
 		ScoreboardClosesAt: pgtype.Timestamptz{Time: future.Add(2 * time.Hour), Valid: true},
 	}
 
-	_, err := svc.ForGame(context.Background(), 1, "player")
+	_, err := svc.ForGame(context.Background(), 1, false)
 	if err != errs.ErrForbidden {
 		t.Errorf("expected ErrForbidden for closed scoreboard, got %v", err)
 	}
@@ -159,7 +159,7 @@ func TestForGame_AdminCanSeeClosed(t *testing.T) {
 		ScoreboardOpensAt: pgtype.Timestamptz{Time: future, Valid: true},
 	}
 
-	sb, err := svc.ForGame(context.Background(), 1, "admin")
+	sb, err := svc.ForGame(context.Background(), 1, true)
 	if err != nil {
 		t.Fatalf("admin should see closed scoreboard, got %v", err)
 	}
@@ -174,7 +174,7 @@ func TestForGame_EmptyEntries(t *testing.T) {
 
 	gq.games[1] = db.Game{ID: 1, Finalized: false}
 
-	sb, err := svc.ForGame(context.Background(), 1, "admin")
+	sb, err := svc.ForGame(context.Background(), 1, true)
 	if err != nil {
 		t.Fatalf("ForGame: %v", err)
 	}
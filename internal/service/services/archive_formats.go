@@ -0,0 +1,168 @@
+package services
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+const (
+	tarMagicOffset   = 257
+	archiveSniffSize = 512
+)
+
+var (
+	tarMagic = []byte("ustar")
+
+	errUnsupportedArchive = errors.New("unsupported archive format (expected zip, tar or tar.gz)")
+)
+
+func hasZipMagic(data []byte) bool {
+	return len(data) >= zipMagicSize && bytes.Equal(data[:zipMagicSize], zipMagic)
+}
+
+func hasGzipMagic(data []byte) bool {
+	return len(data) >= len(gzipMagic) && bytes.Equal(data[:len(gzipMagic)], gzipMagic)
+}
+
+func hasTarMagic(data []byte) bool {
+	end := tarMagicOffset + len(tarMagic)
+	return len(data) >= end && bytes.Equal(data[tarMagicOffset:end], tarMagic)
+}
+
+// normalizeArchive returns data as a zip archive. Zip input is returned
+// unchanged; tar and tar.gz are converted with the same path, size and file
+// count limits that apply to zip bundles.
+func normalizeArchive(data []byte) ([]byte, error) {
+	switch {
+	case hasZipMagic(data):
+		return data, nil
+	case hasGzipMagic(data):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("reading gzip: %w", err)
+		}
+		defer gz.Close()
+		return tarToZip(gz)
+	case hasTarMagic(data):
+		return tarToZip(bytes.NewReader(data))
+	default:
+		return nil, errUnsupportedArchive
+	}
+}
+
+// tarToZip rewrites a tar stream as a zip archive. Links are rejected because
+// they could point outside the extracted tree.
+func tarToZip(r io.Reader) ([]byte, error) {
+	tr := tar.NewReader(r)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	var totalBytes int64
+	fileCount := 0
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = w.Close()
+			return nil, fmt.Errorf("reading tar: %w", err)
+		}
+
+		raw := strings.TrimSuffix(strings.TrimPrefix(hdr.Name, "./"), "/")
+		if raw == "" || raw == "." {
+			continue
+		}
+		name := safeRelPath(raw)
+		if name == "" {
+			_ = w.Close()
+			return nil, fmt.Errorf("invalid path in archive: %q", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if _, err := w.Create(name + "/"); err != nil {
+				_ = w.Close()
+				return nil, err
+			}
+		case tar.TypeReg:
+			if err := copyTarEntry(w, tr, hdr, name, &totalBytes, &fileCount); err != nil {
+				_ = w.Close()
+				return nil, err
+			}
+		case tar.TypeSymlink, tar.TypeLink:
+			_ = w.Close()
+			return nil, fmt.Errorf("archive contains unsupported link %q", hdr.Name)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func copyTarEntry(w *zip.Writer, tr *tar.Reader, hdr *tar.Header, name string, totalBytes *int64, fileCount *int) error {
+	*fileCount++
+	if *fileCount > maxFiles {
+		return errors.New("too many files in archive")
+	}
+	if hdr.Size > maxEntryBytes {
+		return fmt.Errorf("file in archive too large (%d bytes)", hdr.Size)
+	}
+	*totalBytes += hdr.Size
+	if *totalBytes > maxTotalBytes {
+		return fmt.Errorf("archive too large (total %d bytes)", *totalBytes)
+	}
+
+	fh := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: hdr.ModTime}
+	fh.SetMode(hdr.FileInfo().Mode())
+	out, err := w.CreateHeader(fh)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, io.LimitReader(tr, maxEntryBytes+entryReadOverhead)); err != nil {
+		return fmt.Errorf("reading %s: %w", hdr.Name, err)
+	}
+	return nil
+}
+
+// zipStream returns r as a zip stream of at most limit bytes. Zip input is
+// streamed unchanged; tar and tar.gz are buffered and converted.
+func zipStream(r io.Reader, limit int64) (io.Reader, error) {
+	header := make([]byte, archiveSniffSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading archive header: %w", err)
+	}
+	header = header[:n]
+
+	if hasZipMagic(header) {
+		return io.MultiReader(bytes.NewReader(header), io.LimitReader(r, limit+uploadLimitOverhead)), nil
+	}
+	if !hasGzipMagic(header) && !hasTarMagic(header) {
+		return nil, errUnsupportedArchive
+	}
+
+	data, err := io.ReadAll(io.MultiReader(bytes.NewReader(header), io.LimitReader(r, limit+uploadLimitOverhead)))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("archive exceeds maximum size (%d bytes)", limit)
+	}
+	converted, err := normalizeArchive(data)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(converted), nil
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type tarEntry struct {
+	hdr  tar.Header
+	body string
+}
+
+func createTar(entries []tarEntry, compress bool) []byte {
+	var buf bytes.Buffer
+	var out io.Writer = &buf
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(&buf)
+		out = gz
+	}
+	tw := tar.NewWriter(out)
+	for _, e := range entries {
+		hdr := e.hdr
+		if hdr.Typeflag == tar.TypeReg {
+			hdr.Size = int64(len(e.body))
+		}
+		if hdr.Mode == 0 {
+			hdr.Mode = 0o644
+		}
+		if err := tw.WriteHeader(&hdr); err != nil {
+			panic(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil {
+			panic(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		panic(err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			panic(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// zipToTar repacks a zip fixture as tar, prefixing names with "./" like GNU tar.
+func zipToTar(zipBytes []byte, compress bool) []byte {
+	zr, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	if err != nil {
+		panic(err)
+	}
+	entries := []tarEntry{{hdr: tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0o755}}}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			panic(err)
+		}
+		body, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			panic(err)
+		}
+		entries = append(entries, tarEntry{hdr: tar.Header{Name: "./" + f.Name, Typeflag: tar.TypeReg}, body: string(body)})
+	}
+	return createTar(entries, compress)
+}
+
+func TestImportFromZipUpload_TarGz(t *testing.T) {
+	archive := zipToTar(createSourceImportZip("repo", "TarService", "TarService", "Imported from tar.gz", nil), true)
+
+	q := newMockImportQuerier()
+	store := newMemStorage()
+	svc := NewImportService(q, store, 50*1024*1024)
+
+	result, err := svc.ImportFromZipUpload(context.Background(), archive, true)
+	if err != nil {
+		t.Fatalf("ImportFromZipUpload: %v", err)
+	}
+	if result.Service.Name != "TarService" {
+		t.Errorf("Name = %q, want TarService", result.Service.Name)
+	}
+	if stored := store.files[*result.Service.ServiceLocalPath]; !hasZipMagic(stored) {
+		t.Error("stored bundle should be a zip archive")
+	}
+	if result.Service.CheckerLocalPath == nil {
+		t.Error("CheckerLocalPath should be set from the tar checker directory")
+	}
+}
+
+func TestPreviewFromZipUpload_Tar(t *testing.T) {
+	archive := zipToTar(createSourceImportZip("repo", "plain", "plain", "Plain tar", nil), false)
+
+	svc := NewImportService(newMockImportQuerier(), newMemStorage(), 50*1024*1024)
+	preview, err := svc.PreviewFromZipUpload(context.Background(), archive, true)
+	if err != nil {
+		t.Fatalf("PreviewFromZipUpload: %v", err)
+	}
+	if preview.ServiceName != "plain" || preview.RootDirectory != "repo" {
+		t.Errorf("preview = %+v", preview)
+	}
+}
+
+func TestNormalizeArchive_RejectsUnsafeTar(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		want    string
+	}{
+		{
+			name:    "parent traversal",
+			entries: []tarEntry{{hdr: tar.Header{Name: "repo/../../etc/passwd", Typeflag: tar.TypeReg}, body: "x"}},
+			want:    "invalid path",
+		},
+		{
+			name:    "symlink",
+			entries: []tarEntry{{hdr: tar.Header{Name: "repo/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}}},
+			want:    "unsupported link",
+		},
+		{
+			name:    "hard link",
+			entries: []tarEntry{{hdr: tar.Header{Name: "repo/link", Typeflag: tar.TypeLink, Linkname: "repo/other"}}},
+			want:    "unsupported link",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := normalizeArchive(createTar(tt.entries, true))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeArchive_Unsupported(t *testing.T) {
+	if _, err := normalizeArchive([]byte("definitely not an archive")); !errors.Is(err, errUnsupportedArchive) {
+		t.Fatalf("expected errUnsupportedArchive, got %v", err)
+	}
+}
+
+func TestUploadArchives_TarGz(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+
+	archive := createTar([]tarEntry{
+		{hdr: tar.Header{Name: "checker/", Typeflag: tar.TypeDir}},
+		{hdr: tar.Header{Name: "checker/checker.py", Typeflag: tar.TypeReg}, body: "exit(101)\n"},
+	}, true)
+
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	result, err := arcSvc.UploadArchives(context.Background(), id, nil, bytes.NewReader(archive), Access{Admin: true})
+	if err != nil {
+		t.Fatalf("UploadArchives: %v", err)
+	}
+	if stored := store.files[*result.CheckerLocalPath]; !hasZipMagic(stored) {
+		t.Error("uploaded tar.gz should be stored as zip")
+	}
+}
+
+func TestUploadArchives_TarExceedsSize(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+
+	archive := createTar([]tarEntry{
+		{hdr: tar.Header{Name: "service/data.bin", Typeflag: tar.TypeReg}, body: strings.Repeat("x", 4096)},
+	}, false)
+
+	arcSvc := NewArchiveService(q, store, 1024)
+	_, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader(archive), nil, Access{Admin: true})
+	var ve *errs.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(store.files) != 0 {
+		t.Errorf("nothing should be stored, got %d files", len(store.files))
+	}
+}
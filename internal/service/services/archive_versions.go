@@ -0,0 +1,483 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
+)
+
+const (
+	ArchiveSourceURL    = "url"
+	ArchiveSourceUpload = "upload"
+	ArchiveSourceGit    = "git"
+
+	// DefaultArchiveRetention is how many versions per service and kind are
+	// kept when no explicit retention is configured.
+	DefaultArchiveRetention = 10
+
+	fieldKind    = "kind"
+	fieldVersion = "version"
+)
+
+// ArchiveVersionQuerier stores archive versions and switches the active one.
+type ArchiveVersionQuerier interface {
+	BlobQuerier
+	CreateServiceArchiveVersion(ctx context.Context, arg db.CreateServiceArchiveVersionParams) (db.ServiceArchiveVersion, error)
+	GetServiceArchiveVersion(ctx context.Context, arg db.GetServiceArchiveVersionParams) (db.ServiceArchiveVersion, error)
+	GetServiceArchiveVersionByKey(ctx context.Context, arg db.GetServiceArchiveVersionByKeyParams) (db.ServiceArchiveVersion, error)
+	ListServiceArchiveVersions(ctx context.Context, arg db.ListServiceArchiveVersionsParams) ([]db.ListServiceArchiveVersionsRow, error)
+	DeleteServiceArchiveVersion(ctx context.Context, id int64) error
+	SetServiceLocal(ctx context.Context, arg db.SetServiceLocalParams) (db.Service, error)
+	SetCheckerLocal(ctx context.Context, arg db.SetCheckerLocalParams) (db.Service, error)
+}
+
+type ArchiveVersion struct {
+	ID         int64
+	ServiceID  int64
+	Kind       string
+	SHA256     string
+	Size       int64
+	SourceKind string
+	SourceRef  *string
+	CreatedAt  time.Time
+	Current    bool
+	// Pinned is set when a game pins this version; pinned versions are never
+	// pruned.
+	Pinned bool
+}
+
+// ArchiveVersionDiff compares the file lists of two archive versions. A file
+// is modified when its size or CRC-32 differs.
+type ArchiveVersionDiff struct {
+	From      ArchiveVersion
+	To        ArchiveVersion
+	Added     []string
+	Removed   []string
+	Modified  []string
+	Unchanged int
+}
+
+// archiveSource describes where a stored archive came from: the download URL,
+// an upload, or the git commit it was built from.
+type archiveSource struct {
+	Kind string
+	Ref  string
+}
+
+// archiveVersionStore records every archive as a version so older versions
+// survive a redownload, upload or git sync. Archives are stored as
+// content-addressed blobs: saving the same bundle again reuses its object.
+type archiveVersionStore struct {
+	q         ArchiveVersionQuerier
+	store     storage.Storage
+	retention int
+}
+
+// put stores an archive as a blob and returns its key; see putBlob.
+func (v *archiveVersionStore) put(ctx context.Context, r io.Reader, maxBytes int64) (string, storage.FileInfo, error) {
+	return putBlob(ctx, v.q, v.store, r, maxBytes)
+}
+
+// putBytes stores an in-memory archive as a blob and returns its key.
+func (v *archiveVersionStore) putBytes(ctx context.Context, data []byte) (string, storage.FileInfo, error) {
+	return putBlobBytes(ctx, v.q, v.store, data)
+}
+
+// stagedArchive is an archive saved to storage that is not a version yet.
+type stagedArchive struct {
+	kind   string
+	key    string
+	info   storage.FileInfo
+	source archiveSource
+}
+
+// activateStaged records the staged archives as versions and points the
+// service at them in one transaction, so a failure never leaves a version the
+// service does not use or only one of its archives replaced. then, when set,
+// runs in the same transaction with the updated service. Older versions are
+// pruned once the transaction is committed; on failure the staged objects are
+// discarded.
+func (v *archiveVersionStore) activateStaged(
+	ctx context.Context,
+	runInTx func(ctx context.Context, fn func(q *db.Queries) error) error,
+	serviceID int64,
+	staged []stagedArchive,
+	at time.Time,
+	then func(q *db.Queries, svc db.Service) error,
+) (db.Service, error) {
+	var svc db.Service
+	err := runInTx(ctx, func(q *db.Queries) error {
+		versions := *v
+		if q != nil {
+			versions.q = q
+		}
+		for _, a := range staged {
+			var err error
+			if svc, err = versions.activate(ctx, serviceID, a.kind, a.key, a.info, a.source, at); err != nil {
+				return err
+			}
+		}
+		if then == nil {
+			return nil
+		}
+		return then(q, svc)
+	})
+	if err != nil {
+		for _, a := range staged {
+			v.discard(ctx, a.key)
+		}
+		return db.Service{}, err
+	}
+	for _, a := range staged {
+		v.prune(ctx, serviceID, a.kind, a.key)
+	}
+	return svc, nil
+}
+
+// activate records the archive saved under key as a new version and points the
+// service at it. Service archives keep their README with the version. It runs
+// inside the transaction of activateStaged.
+func (v *archiveVersionStore) activate(
+	ctx context.Context,
+	serviceID int64,
+	kind string,
+	key string,
+	info storage.FileInfo,
+	source archiveSource,
+	at time.Time,
+) (db.Service, error) {
+	var readme, readmePath *string
+	if kind == kindService {
+		readme, readmePath = readArchiveReadme(ctx, v.store, key)
+	}
+	if _, err := v.q.CreateServiceArchiveVersion(ctx, db.CreateServiceArchiveVersionParams{
+		ServiceID:  serviceID,
+		Kind:       kind,
+		StorageKey: key,
+		Sha256:     info.SHA256,
+		Size:       info.Size,
+		SourceKind: source.Kind,
+		SourceRef:  optionalImportedString(source.Ref),
+		Readme:     readme,
+		ReadmePath: readmePath,
+		CreatedAt:  at,
+	}); err != nil {
+		return db.Service{}, fmt.Errorf("recording archive version: %w", err)
+	}
+
+	return v.setLocal(ctx, serviceID, kind, key, info.SHA256, info.Size, at)
+}
+
+func (v *archiveVersionStore) setLocal(
+	ctx context.Context,
+	serviceID int64,
+	kind string,
+	key string,
+	sha string,
+	size int64,
+	at time.Time,
+) (db.Service, error) {
+	size32, err := int32Size(size)
+	if err != nil {
+		return db.Service{}, err
+	}
+
+	var svc db.Service
+	if kind == kindChecker {
+		svc, err = v.q.SetCheckerLocal(ctx, db.SetCheckerLocalParams{
+			ID:                  serviceID,
+			CheckerLocalPath:    &key,
+			CheckerLocalSize:    &size32,
+			CheckerLocalSha256:  &sha,
+			CheckerDownloadedAt: pgtypeTz(at),
+		})
+	} else {
+		svc, err = v.q.SetServiceLocal(ctx, db.SetServiceLocalParams{
+			ID:                  serviceID,
+			ServiceLocalPath:    &key,
+			ServiceLocalSize:    &size32,
+			ServiceLocalSha256:  &sha,
+			ServiceDownloadedAt: pgtypeTz(at),
+		})
+	}
+	if err != nil {
+		return db.Service{}, mapDBError(err)
+	}
+	return svc, nil
+}
+
+// prune deletes the oldest versions beyond the retention limit. The active
+// version and versions pinned by games are always kept. Failures are logged: a
+// leftover object only costs disk space and is retried on the next save.
+func (v *archiveVersionStore) prune(ctx context.Context, serviceID int64, kind, currentKey string) {
+	if v.retention <= 0 {
+		return
+	}
+
+	rows, err := v.q.ListServiceArchiveVersions(ctx, db.ListServiceArchiveVersionsParams{
+		ServiceID: serviceID,
+		Kind:      &kind,
+	})
+	if err != nil {
+		slog.Warn("failed to list archive versions for pruning", "service_id", serviceID, "kind", kind, "error", err)
+		return
+	}
+
+	kept := 0
+	for _, row := range rows {
+		if row.Pinned {
+			continue
+		}
+		if row.StorageKey == currentKey || kept < v.retention {
+			kept++
+			continue
+		}
+		// Blobs may be shared with other versions; dropping the version
+		// releases its reference and the blob GC removes the object.
+		if isBlobKey(row.StorageKey) {
+			if err := v.q.DeleteServiceArchiveVersion(ctx, row.ID); err != nil {
+				slog.Warn("failed to delete archive version", "service_id", serviceID, "version_id", row.ID, "error", err)
+			}
+			continue
+		}
+		if err := v.store.Delete(ctx, row.StorageKey); err != nil {
+			slog.Warn("failed to delete archive version object", "service_id", serviceID, "key", row.StorageKey, "error", err)
+			continue
+		}
+		if err := v.q.DeleteServiceArchiveVersion(ctx, row.ID); err != nil {
+			slog.Warn("failed to delete archive version", "service_id", serviceID, "version_id", row.ID, "error", err)
+		}
+	}
+}
+
+// discard deletes an archive that was saved but not recorded as a version.
+// Blobs may already be shared, so they are only (re)recorded as unreferenced:
+// a rolled back transaction loses the blob row, and without it the GC would
+// never see the object.
+func (v *archiveVersionStore) discard(ctx context.Context, key string) {
+	if isBlobKey(key) {
+		info, err := v.store.Stat(ctx, key)
+		if err == nil {
+			_, err = v.q.UpsertStorageBlob(ctx, db.UpsertStorageBlobParams{Sha256: path.Base(key), StorageKey: key, Size: info.Size})
+		}
+		if err != nil {
+			slog.Warn("failed to record unreferenced blob", "key", key, "error", err)
+		}
+		return
+	}
+	if err := v.store.Delete(ctx, key); err != nil {
+		slog.Warn("failed to delete unrecorded archive", "key", key, "error", err)
+	}
+}
+
+// SetArchiveRetention sets how many archive versions are kept per service and
+// kind; zero or less keeps all of them.
+func (s *ArchiveService) SetArchiveRetention(retention int) {
+	s.versions.retention = retention
+}
+
+// ListVersions lists the archive versions of a service. Versions of a
+// non-public service are only visible to admins and its authors.
+func (s *ArchiveService) ListVersions(ctx context.Context, id int64, kind string, access Access) ([]ArchiveVersion, error) {
+	svc, err := s.visibleService(ctx, id, access)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.ListServiceArchiveVersionsParams{ServiceID: id}
+	if kind != "" {
+		kind = strings.ToLower(kind)
+		if kind != kindService && kind != kindChecker {
+			return nil, errs.NewValidationError(map[string]string{fieldKind: "must be 'service' or 'checker'"})
+		}
+		arg.Kind = &kind
+	}
+
+	rows, err := s.q.ListServiceArchiveVersions(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ArchiveVersion, len(rows))
+	for i, row := range rows {
+		out[i] = archiveVersionFromDB(archiveVersionFromListRow(row), svc)
+		out[i].Pinned = row.Pinned
+	}
+	return out, nil
+}
+
+// DiffVersions compares the file lists of two archive versions of a service
+// with the same visibility rules as ListVersions.
+func (s *ArchiveService) DiffVersions(ctx context.Context, id, fromID, toID int64, access Access) (*ArchiveVersionDiff, error) {
+	svc, err := s.visibleService(ctx, id, access)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.q.GetServiceArchiveVersion(ctx, db.GetServiceArchiveVersionParams{ID: fromID, ServiceID: id})
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	to, err := s.q.GetServiceArchiveVersion(ctx, db.GetServiceArchiveVersionParams{ID: toID, ServiceID: id})
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	fromFiles, err := s.archiveFileIndex(ctx, from.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	toFiles, err := s.archiveFileIndex(ctx, to.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ArchiveVersionDiff{
+		From:     archiveVersionFromDB(from, svc),
+		To:       archiveVersionFromDB(to, svc),
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []string{},
+	}
+	for name, sig := range toFiles {
+		prev, ok := fromFiles[name]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, name)
+		case prev != sig:
+			diff.Modified = append(diff.Modified, name)
+		default:
+			diff.Unchanged++
+		}
+	}
+	for name := range fromFiles {
+		if _, ok := toFiles[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Modified)
+	return diff, nil
+}
+
+func (s *ArchiveService) visibleService(ctx context.Context, id int64, access Access) (db.Service, error) {
+	svc, err := s.q.GetServiceByID(ctx, id)
+	if err != nil {
+		return db.Service{}, mapNotFound(err)
+	}
+	if !svc.Public && !access.CanManage() {
+		return db.Service{}, errs.ErrNotFound
+	}
+	return svc, nil
+}
+
+// Rollback makes an older archive version active again. The version history
+// itself is not rewritten, so rolling forward is another rollback.
+func (s *ArchiveService) Rollback(ctx context.Context, id, versionID int64, access Access) (*ServiceModel, error) {
+	if !access.CanManage() {
+		return nil, errs.ErrForbidden
+	}
+
+	version, err := s.q.GetServiceArchiveVersion(ctx, db.GetServiceArchiveVersionParams{ID: versionID, ServiceID: id})
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	if _, err := s.store.Stat(ctx, version.StorageKey); err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldVersion: "archive object for this version is missing from storage"})
+	}
+
+	svc, err := s.versions.setLocal(ctx, id, version.Kind, version.StorageKey, version.Sha256, version.Size, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	result := fromDB(svc, true)
+	return &result, nil
+}
+
+type zipEntrySignature struct {
+	Size  uint64
+	CRC32 uint32
+}
+
+// archiveFileIndex reads the file list of a stored archive from its central
+// directory. The object is read through seeks, so only the directory and not
+// the whole archive is loaded into memory.
+func (s *ArchiveService) archiveFileIndex(ctx context.Context, key string) (map[string]zipEntrySignature, error) {
+	rc, err := s.store.Open(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive version: %w", err)
+	}
+	defer rc.Close()
+
+	zr, err := openStoredZip(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]zipEntrySignature, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		files[f.Name] = zipEntrySignature{Size: f.UncompressedSize64, CRC32: f.CRC32}
+	}
+	return files, nil
+}
+
+// seekReaderAt adapts a storage object to io.ReaderAt. It is not safe for
+// concurrent use, which zip.Reader does not need while listing files.
+type seekReaderAt struct {
+	rs io.ReadSeeker
+}
+
+func (r seekReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := r.rs.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(r.rs, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func archiveVersionFromListRow(row db.ListServiceArchiveVersionsRow) db.ServiceArchiveVersion {
+	return db.ServiceArchiveVersion{
+		ID:         row.ID,
+		ServiceID:  row.ServiceID,
+		Kind:       row.Kind,
+		StorageKey: row.StorageKey,
+		Sha256:     row.Sha256,
+		Size:       row.Size,
+		SourceKind: row.SourceKind,
+		SourceRef:  row.SourceRef,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func archiveVersionFromDB(row db.ServiceArchiveVersion, svc db.Service) ArchiveVersion {
+	current := svc.ServiceLocalPath
+	if row.Kind == kindChecker {
+		current = svc.CheckerLocalPath
+	}
+	return ArchiveVersion{
+		ID:         row.ID,
+		ServiceID:  row.ServiceID,
+		Kind:       row.Kind,
+		SHA256:     row.Sha256,
+		Size:       row.Size,
+		SourceKind: row.SourceKind,
+		SourceRef:  row.SourceRef,
+		CreatedAt:  row.CreatedAt,
+		Current:    current != nil && *current == row.StorageKey,
+	}
+}
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+// mockArchiveVersions is embedded into the archive and import querier mocks.
+type mockArchiveVersions struct {
+	versions      []db.ServiceArchiveVersion
+	pinned        map[int64]bool
+	nextVersionID int64
+	blobs         map[string]*db.StorageBlob
+	exports       []db.GameExport
+}
+
+func (m *mockArchiveVersions) CreateServiceArchiveVersion(_ context.Context, arg db.CreateServiceArchiveVersionParams) (db.ServiceArchiveVersion, error) {
+	m.nextVersionID++
+	row := db.ServiceArchiveVersion{
+		ID:         m.nextVersionID,
+		ServiceID:  arg.ServiceID,
+		Kind:       arg.Kind,
+		StorageKey: arg.StorageKey,
+		Sha256:     arg.Sha256,
+		Size:       arg.Size,
+		SourceKind: arg.SourceKind,
+		SourceRef:  arg.SourceRef,
+		Readme:     arg.Readme,
+		ReadmePath: arg.ReadmePath,
+		CreatedAt:  arg.CreatedAt,
+	}
+	m.versions = append(m.versions, row)
+	return row, nil
+}
+
+func (m *mockArchiveVersions) GetServiceArchiveVersion(_ context.Context, arg db.GetServiceArchiveVersionParams) (db.ServiceArchiveVersion, error) {
+	for _, row := range m.versions {
+		if row.ID == arg.ID && row.ServiceID == arg.ServiceID {
+			return row, nil
+		}
+	}
+	return db.ServiceArchiveVersion{}, pgx.ErrNoRows
+}
+
+func (m *mockArchiveVersions) GetServiceArchiveVersionByKey(_ context.Context, arg db.GetServiceArchiveVersionByKeyParams) (db.ServiceArchiveVersion, error) {
+	for _, row := range m.versions {
+		if row.StorageKey == arg.StorageKey && row.ServiceID == arg.ServiceID {
+			return row, nil
+		}
+	}
+	return db.ServiceArchiveVersion{}, pgx.ErrNoRows
+}
+
+func (m *mockArchiveVersions) ListServiceArchiveVersions(_ context.Context, arg db.ListServiceArchiveVersionsParams) ([]db.ListServiceArchiveVersionsRow, error) {
+	var out []db.ListServiceArchiveVersionsRow
+	for _, row := range m.versions {
+		if row.ServiceID != arg.ServiceID || (arg.Kind != nil && row.Kind != *arg.Kind) {
+			continue
+		}
+		out = append(out, db.ListServiceArchiveVersionsRow{
+			ID:         row.ID,
+			ServiceID:  row.ServiceID,
+			Kind:       row.Kind,
+			StorageKey: row.StorageKey,
+			Sha256:     row.Sha256,
+			Size:       row.Size,
+			SourceKind: row.SourceKind,
+			SourceRef:  row.SourceRef,
+			CreatedAt:  row.CreatedAt,
+			Pinned:     m.pinned[row.ID],
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	return out, nil
+}
+
+func (m *mockArchiveVersions) DeleteServiceArchiveVersion(_ context.Context, id int64) error {
+	for i, row := range m.versions {
+		if row.ID == id {
+			m.versions = append(m.versions[:i], m.versions[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func TestUploadArchives_KeepsPreviousVersions(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	ctx := context.Background()
+
+	first, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(10)), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("first upload: %v", err)
+	}
+	second, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(20)), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("second upload: %v", err)
+	}
+	if *first.ServiceLocalPath == *second.ServiceLocalPath {
+		t.Fatal("each upload must be stored under its own key")
+	}
+	if _, ok := store.files[*first.ServiceLocalPath]; !ok {
+		t.Fatal("previous archive must be kept in storage")
+	}
+
+	versions, err := arcSvc.ListVersions(ctx, id, kindService, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("ListVersions: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("len(versions) = %d, want 2", len(versions))
+	}
+	if !versions[0].Current || versions[1].Current {
+		t.Errorf("only the newest version should be current: %+v", versions)
+	}
+	if versions[0].SourceKind != ArchiveSourceUpload {
+		t.Errorf("SourceKind = %q, want %q", versions[0].SourceKind, ArchiveSourceUpload)
+	}
+	if versions[1].SHA256 != *first.ServiceLocalSha256 {
+		t.Errorf("older version sha256 = %q, want %q", versions[1].SHA256, *first.ServiceLocalSha256)
+	}
+
+	if len(q.audit) != 2 || q.audit[1].Action != audit.ActionUploadArchives {
+		t.Fatalf("audit events = %+v, want one per upload", q.audit)
+	}
+	wantBefore := `{"service_sha256":"` + *first.ServiceLocalSha256 + `"}`
+	if string(q.audit[1].Before) != wantBefore {
+		t.Errorf("audit before = %s, want %s", q.audit[1].Before, wantBefore)
+	}
+}
+
+func TestUploadArchives_PrunesBeyondRetention(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	arcSvc.SetArchiveRetention(2)
+	ctx := context.Background()
+
+	var keys []string
+	for i := 1; i <= 3; i++ {
+		result, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(i*10)), nil, Access{Admin: true})
+		if err != nil {
+			t.Fatalf("upload %d: %v", i, err)
+		}
+		keys = append(keys, *result.ServiceLocalPath)
+	}
+
+	if len(q.versions) != 2 {
+		t.Fatalf("len(versions) = %d, want 2", len(q.versions))
+	}
+	if got := q.refCount(keys[0]); got != 0 {
+		t.Errorf("oldest blob ref count = %d, want 0 so the GC removes it", got)
+	}
+	for _, key := range keys[1:] {
+		if _, ok := store.files[key]; !ok {
+			t.Errorf("archive %s should be kept", key)
+		}
+	}
+}
+
+func TestUploadArchives_KeepsPinnedVersions(t *testing.T) {
+	q := newMockArchiveQuerier()
+	q.pinned = map[int64]bool{1: true}
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	arcSvc.SetArchiveRetention(1)
+	ctx := context.Background()
+
+	first, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(10)), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("first upload: %v", err)
+	}
+	for i := 2; i <= 3; i++ {
+		if _, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(i*10)), nil, Access{Admin: true}); err != nil {
+			t.Fatalf("upload %d: %v", i, err)
+		}
+	}
+
+	if len(q.versions) != 2 {
+		t.Fatalf("len(versions) = %d, want pinned + current", len(q.versions))
+	}
+	if _, ok := store.files[*first.ServiceLocalPath]; !ok {
+		t.Error("pinned archive must not be pruned")
+	}
+}
+
+func TestRollback_RestoresPreviousVersion(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	ctx := context.Background()
+
+	first, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(10)), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("first upload: %v", err)
+	}
+	if _, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(20)), nil, Access{Admin: true}); err != nil {
+		t.Fatalf("second upload: %v", err)
+	}
+
+	if _, err := arcSvc.Rollback(ctx, id, 1, Access{}); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("non-admin rollback: expected ErrForbidden, got %v", err)
+	}
+
+	result, err := arcSvc.Rollback(ctx, id, 1, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if *result.ServiceLocalPath != *first.ServiceLocalPath || *result.ServiceLocalSha256 != *first.ServiceLocalSha256 {
+		t.Errorf("rollback did not restore the first archive: %+v", result)
+	}
+	if len(q.versions) != 2 {
+		t.Errorf("rollback must not add versions, got %d", len(q.versions))
+	}
+
+	if _, err := arcSvc.Rollback(ctx, id, 99, Access{Admin: true}); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("unknown version: expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRollback_MissingObject(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	ctx := context.Background()
+
+	result, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(10)), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	delete(store.files, *result.ServiceLocalPath)
+
+	_, err = arcSvc.Rollback(ctx, id, 1, Access{Admin: true})
+	var ve *errs.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	ctx := context.Background()
+
+	before := createZip(map[string]string{"a.txt": "a", "b.txt": "b", "same.txt": "same"})
+	after := createZip(map[string]string{"b.txt": "b2", "c.txt": "c", "same.txt": "same"})
+	if _, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(before), nil, Access{Admin: true}); err != nil {
+		t.Fatalf("first upload: %v", err)
+	}
+	if _, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(after), nil, Access{Admin: true}); err != nil {
+		t.Fatalf("second upload: %v", err)
+	}
+
+	diff, err := arcSvc.DiffVersions(ctx, id, 1, 2, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("DiffVersions: %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0] != "c.txt" {
+		t.Errorf("Added = %v, want [c.txt]", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != "a.txt" {
+		t.Errorf("Removed = %v, want [a.txt]", diff.Removed)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0] != "b.txt" {
+		t.Errorf("Modified = %v, want [b.txt]", diff.Modified)
+	}
+	if diff.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", diff.Unchanged)
+	}
+
+	if _, err := arcSvc.DiffVersions(ctx, id, 1, 42, Access{Admin: true}); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown version, got %v", err)
+	}
+}
+
+func TestArchiveVersions_HiddenForNonPublicService(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "private-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	ctx := context.Background()
+
+	for _, data := range [][]byte{createZip(map[string]string{"a.txt": "a"}), createZip(map[string]string{"a.txt": "b"})} {
+		if _, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(data), nil, Access{Admin: true}); err != nil {
+			t.Fatalf("upload: %v", err)
+		}
+	}
+
+	if _, err := arcSvc.ListVersions(ctx, id, "", Access{}); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("ListVersions: expected ErrNotFound, got %v", err)
+	}
+	if _, err := arcSvc.DiffVersions(ctx, id, 1, 2, Access{}); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("DiffVersions: expected ErrNotFound, got %v", err)
+	}
+
+	author := Access{Author: true}
+	if _, err := arcSvc.ListVersions(ctx, id, "", author); err != nil {
+		t.Errorf("ListVersions as author: %v", err)
+	}
+	if _, err := arcSvc.DiffVersions(ctx, id, 1, 2, author); err != nil {
+		t.Errorf("DiffVersions as author: %v", err)
+	}
+
+	q.services[id].Public = true
+	if _, err := arcSvc.ListVersions(ctx, id, "", Access{}); err != nil {
+		t.Errorf("ListVersions on public service: %v", err)
+	}
+}
+
+func TestListVersions_InvalidKind(t *testing.T) {
+	q := newMockArchiveQuerier()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, newMemStorage(), 1024)
+
+	_, err := arcSvc.ListVersions(context.Background(), id, "exploit", Access{Admin: true})
+	var ve *errs.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestImportFromZip_RecordsVersions(t *testing.T) {
+	q := newMockImportQuerier()
+	store := newMemStorage()
+	svc := NewImportService(q, store, 10*1024*1024)
+
+	zipBytes := createSourceImportZip("repo", "VersionedSvc", "VersionedSvc", "Versioned", nil)
+	result, err := svc.ImportFromZip(context.Background(), zipBytes, true)
+	if err != nil {
+		t.Fatalf("ImportFromZip: %v", err)
+	}
+
+	kinds := map[string]string{}
+	for _, v := range q.versions {
+		if v.ServiceID != result.Service.ID {
+			t.Errorf("version recorded for service %d, want %d", v.ServiceID, result.Service.ID)
+		}
+		if v.SourceKind != ArchiveSourceUpload {
+			t.Errorf("SourceKind = %q, want %q", v.SourceKind, ArchiveSourceUpload)
+		}
+		kinds[v.Kind] = v.StorageKey
+	}
+	if kinds[kindService] != *result.Service.ServiceLocalPath {
+		t.Errorf("service version key = %q, want %q", kinds[kindService], *result.Service.ServiceLocalPath)
+	}
+	if result.Service.CheckerLocalPath != nil && kinds[kindChecker] != *result.Service.CheckerLocalPath {
+		t.Errorf("checker version key = %q, want %q", kinds[kindChecker], *result.Service.CheckerLocalPath)
+	}
+}
@@ -1,7 +1,6 @@
 package services
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -12,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
@@ -28,21 +28,34 @@ const (
 
 type ArchiveQuerier interface {
 	GetServiceByID(ctx context.Context, id int64) (db.Service, error)
-	SetServiceLocal(ctx context.Context, arg db.SetServiceLocalParams) (db.Service, error)
-	SetCheckerLocal(ctx context.Context, arg db.SetCheckerLocalParams) (db.Service, error)
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+	ArchiveVersionQuerier
 }
 
 type ArchiveService struct {
 	q              ArchiveQuerier
 	store          storage.Storage
+	versions       archiveVersionStore
 	maxUploadBytes int64
 	httpClient     *http.Client
+	tx             TxRunner
+}
+
+// archiveState is what the audit log keeps of the archives of a service.
+type archiveState struct {
+	ServiceSHA256 *string `json:"service_sha256"`
+	CheckerSHA256 *string `json:"checker_sha256"`
+}
+
+func archiveStateOf(svc db.Service) archiveState {
+	return archiveState{ServiceSHA256: svc.ServiceLocalSha256, CheckerSHA256: svc.CheckerLocalSha256}
 }
 
 func NewArchiveService(q ArchiveQuerier, store storage.Storage, maxUploadBytes int64) *ArchiveService {
 	return &ArchiveService{
 		q:              q,
 		store:          store,
+		versions:       archiveVersionStore{q: q, store: store, retention: DefaultArchiveRetention},
 		maxUploadBytes: maxUploadBytes,
 		httpClient: &http.Client{
 			Timeout: archiveDownloadTimeout,
@@ -68,19 +81,9 @@ func ssrfSafeDialContext(ctx context.Context, network, addr string) (net.Conn, e
 	if err != nil {
 		return nil, fmt.Errorf("invalid address: %w", err)
 	}
-	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	allowed, err := resolvePublicHost(ctx, host)
 	if err != nil {
-		return nil, fmt.Errorf("resolving host %s: %w", host, err)
-	}
-	var allowed []net.IPAddr
-	for _, ip := range ips {
-		if blockedIPCheck(ip.IP) {
-			return nil, errBlockedHost
-		}
-		allowed = append(allowed, ip)
-	}
-	if len(allowed) == 0 {
-		return nil, fmt.Errorf("no resolved addresses for host %s", host)
+		return nil, err
 	}
 	d := net.Dialer{}
 	var lastErr error
@@ -94,147 +97,197 @@ func ssrfSafeDialContext(ctx context.Context, network, addr string) (net.Conn, e
 	return nil, lastErr
 }
 
-func (s *ArchiveService) Redownload(ctx context.Context, id int64, isAdmin bool) (*ServiceModel, error) {
+// resolvePublicHost resolves host and fails with errBlockedHost when any of
+// its addresses is loopback, private, link-local or otherwise not public.
+// Callers connect only to the returned addresses so a second lookup cannot
+// point them elsewhere.
+func resolvePublicHost(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("resolving host %s: %w", host, err)
+	}
+	for _, ip := range ips {
+		if blockedIPCheck(ip.IP) {
+			return nil, errBlockedHost
+		}
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no resolved addresses for host %s", host)
+	}
+	return ips, nil
+}
+
+func (s *ArchiveService) Redownload(ctx context.Context, id int64, access Access) (*ServiceModel, error) {
+	if !access.CanManage() {
+		return nil, errs.ErrForbidden
+	}
 	svc, err := s.q.GetServiceByID(ctx, id)
 	if err != nil {
 		return nil, mapNotFound(err)
 	}
 
-	now := time.Now()
-
+	var staged []stagedArchive
 	if svc.ServiceArchiveUrl != nil && *svc.ServiceArchiveUrl != "" {
-		info, err := s.downloadAndSave(ctx, *svc.ServiceArchiveUrl, fmt.Sprintf("services/%d/service.zip", id))
+		key, info, err := s.downloadAndSave(ctx, *svc.ServiceArchiveUrl)
 		if err != nil {
 			return nil, fmt.Errorf("downloading service archive: %w", err)
 		}
-		size, err := int32Size(info.Size)
-		if err != nil {
-			return nil, err
-		}
-		path := fmt.Sprintf("services/%d/service.zip", id)
-		svc, err = s.q.SetServiceLocal(ctx, db.SetServiceLocalParams{
-			ID:                  id,
-			ServiceLocalPath:    &path,
-			ServiceLocalSize:    &size,
-			ServiceLocalSha256:  &info.SHA256,
-			ServiceDownloadedAt: pgtypeTz(now),
+		staged = append(staged, stagedArchive{
+			kind:   kindService,
+			key:    key,
+			info:   info,
+			source: archiveSource{Kind: ArchiveSourceURL, Ref: *svc.ServiceArchiveUrl},
 		})
-		if err != nil {
-			return nil, mapDBError(err)
-		}
 	}
-
 	if svc.CheckerArchiveUrl != nil && *svc.CheckerArchiveUrl != "" {
-		info, err := s.downloadAndSave(ctx, *svc.CheckerArchiveUrl, fmt.Sprintf("services/%d/checker.zip", id))
+		key, info, err := s.downloadAndSave(ctx, *svc.CheckerArchiveUrl)
 		if err != nil {
+			s.discardStaged(ctx, staged)
 			return nil, fmt.Errorf("downloading checker archive: %w", err)
 		}
-		size, err := int32Size(info.Size)
-		if err != nil {
-			return nil, err
-		}
-		path := fmt.Sprintf("services/%d/checker.zip", id)
-		svc, err = s.q.SetCheckerLocal(ctx, db.SetCheckerLocalParams{
-			ID:                  id,
-			CheckerLocalPath:    &path,
-			CheckerLocalSize:    &size,
-			CheckerLocalSha256:  &info.SHA256,
-			CheckerDownloadedAt: pgtypeTz(now),
+		staged = append(staged, stagedArchive{
+			kind:   kindChecker,
+			key:    key,
+			info:   info,
+			source: archiveSource{Kind: ArchiveSourceURL, Ref: *svc.CheckerArchiveUrl},
 		})
+	}
+
+	if len(staged) > 0 {
+		svc, err = s.versions.activateStaged(ctx, s.runInTx, id, staged, time.Now(), s.recordArchives(ctx, id, audit.ActionRedownload, archiveStateOf(svc)))
 		if err != nil {
-			return nil, mapDBError(err)
+			return nil, err
 		}
 	}
 
-	result := fromDB(svc, isAdmin)
+	result := fromDB(svc, true)
 	return &result, nil
 }
 
-func (s *ArchiveService) UploadArchives(ctx context.Context, id int64, serviceFile, checkerFile io.Reader, isAdmin bool) (*ServiceModel, error) {
+func (s *ArchiveService) discardStaged(ctx context.Context, staged []stagedArchive) {
+	for _, a := range staged {
+		s.versions.discard(ctx, a.key)
+	}
+}
+
+// SetTxRunner makes an upload of both archives activate them together with
+// its audit event.
+func (s *ArchiveService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+func (s *ArchiveService) UploadArchives(ctx context.Context, id int64, serviceFile, checkerFile io.Reader, access Access) (*ServiceModel, error) {
+	if !access.CanManage() {
+		return nil, errs.ErrForbidden
+	}
 	svc, err := s.q.GetServiceByID(ctx, id)
 	if err != nil {
 		return nil, mapNotFound(err)
 	}
+	before := archiveStateOf(svc)
 
-	now := time.Now()
-
+	source := archiveSource{Kind: ArchiveSourceUpload}
+	var staged []stagedArchive
 	if serviceFile != nil {
-		info, err := s.saveUploaded(ctx, serviceFile, fmt.Sprintf("services/%d/service.zip", id))
+		key, info, err := s.saveUploaded(ctx, serviceFile)
 		if err != nil {
 			return nil, fmt.Errorf("saving service archive: %w", err)
 		}
-		size, err := int32Size(info.Size)
-		if err != nil {
-			return nil, err
-		}
-		path := fmt.Sprintf("services/%d/service.zip", id)
-		svc, err = s.q.SetServiceLocal(ctx, db.SetServiceLocalParams{
-			ID:                  id,
-			ServiceLocalPath:    &path,
-			ServiceLocalSize:    &size,
-			ServiceLocalSha256:  &info.SHA256,
-			ServiceDownloadedAt: pgtypeTz(now),
-		})
-		if err != nil {
-			return nil, mapDBError(err)
-		}
+		staged = append(staged, stagedArchive{kind: kindService, key: key, info: info, source: source})
 	}
-
 	if checkerFile != nil {
-		info, err := s.saveUploaded(ctx, checkerFile, fmt.Sprintf("services/%d/checker.zip", id))
+		key, info, err := s.saveUploaded(ctx, checkerFile)
 		if err != nil {
+			s.discardStaged(ctx, staged)
 			return nil, fmt.Errorf("saving checker archive: %w", err)
 		}
-		size, err := int32Size(info.Size)
-		if err != nil {
-			return nil, err
+		staged = append(staged, stagedArchive{kind: kindChecker, key: key, info: info, source: source})
+	}
+	if len(staged) == 0 {
+		result := fromDB(svc, true)
+		return &result, nil
+	}
+
+	svc, err = s.versions.activateStaged(ctx, s.runInTx, id, staged, time.Now(), s.recordArchives(ctx, id, audit.ActionUploadArchives, before))
+	if err != nil {
+		return nil, err
+	}
+
+	result := fromDB(svc, true)
+	return &result, nil
+}
+
+// recordArchives returns the activateStaged callback that logs the change of
+// the archive hashes of a service in the activating transaction.
+func (s *ArchiveService) recordArchives(ctx context.Context, id int64, action string, before archiveState) func(q *db.Queries, svc db.Service) error {
+	return func(q *db.Queries, svc db.Service) error {
+		events := audit.Inserter(s.q)
+		if q != nil {
+			events = q
 		}
-		path := fmt.Sprintf("services/%d/checker.zip", id)
-		svc, err = s.q.SetCheckerLocal(ctx, db.SetCheckerLocalParams{
-			ID:                  id,
-			CheckerLocalPath:    &path,
-			CheckerLocalSize:    &size,
-			CheckerLocalSha256:  &info.SHA256,
-			CheckerDownloadedAt: pgtypeTz(now),
+		return audit.Record(ctx, events, audit.Entry{
+			EntityType: audit.EntityService,
+			EntityID:   id,
+			Action:     action,
+			Before:     before,
+			After:      archiveStateOf(svc),
 		})
-		if err != nil {
-			return nil, mapDBError(err)
-		}
 	}
+}
 
-	result := fromDB(svc, isAdmin)
-	return &result, nil
+func (s *ArchiveService) runInTx(ctx context.Context, fn func(q *db.Queries) error) error {
+	if s.tx == nil {
+		return fn(nil)
+	}
+	return s.tx.RunInTx(ctx, fn)
+}
+
+// ArchiveFile is an opened service archive with the metadata HTTP caching
+// and range requests need.
+type ArchiveFile struct {
+	io.ReadSeekCloser
+	Filename string
+	// SHA256 and ModifiedAt are empty for archives stored before they were
+	// recorded.
+	SHA256     string
+	ModifiedAt time.Time
 }
 
-func (s *ArchiveService) OpenLocal(ctx context.Context, id int64, kind string) (io.ReadSeekCloser, string, error) {
+func (s *ArchiveService) OpenLocal(ctx context.Context, id int64, kind string) (*ArchiveFile, error) {
 	svc, err := s.q.GetServiceByID(ctx, id)
 	if err != nil {
-		return nil, "", mapNotFound(err)
+		return nil, mapNotFound(err)
 	}
+	return s.openCurrent(ctx, svc, kind)
+}
 
-	var key string
+// openCurrent opens the current archive of the given kind of svc.
+func (s *ArchiveService) openCurrent(ctx context.Context, svc db.Service, kind string) (*ArchiveFile, error) {
+	var key, sha *string
+	var modified time.Time
 	switch strings.ToLower(kind) {
 	case kindService:
-		if svc.ServiceLocalPath == nil {
-			return nil, "", errs.ErrNotFound
-		}
-		key = *svc.ServiceLocalPath
+		key, sha, modified = svc.ServiceLocalPath, svc.ServiceLocalSha256, svc.ServiceDownloadedAt.Time
 	case kindChecker:
-		if svc.CheckerLocalPath == nil {
-			return nil, "", errs.ErrNotFound
-		}
-		key = *svc.CheckerLocalPath
+		key, sha, modified = svc.CheckerLocalPath, svc.CheckerLocalSha256, svc.CheckerDownloadedAt.Time
 	default:
-		return nil, "", errs.NewValidationError(map[string]string{"kind": "must be 'service' or 'checker'"})
+		return nil, errs.NewValidationError(map[string]string{"kind": "must be 'service' or 'checker'"})
+	}
+	if key == nil {
+		return nil, errs.ErrNotFound
 	}
 
-	rc, err := s.store.Open(ctx, key)
+	rc, err := s.store.Open(ctx, *key)
 	if err != nil {
-		return nil, "", fmt.Errorf("opening archive: %w", err)
+		return nil, fmt.Errorf("opening archive: %w", err)
 	}
 
-	filename := fmt.Sprintf("%s-%s.zip", svc.Name, kind)
-	return rc, filename, nil
+	return &ArchiveFile{
+		ReadSeekCloser: rc,
+		Filename:       fmt.Sprintf("%s-%s.zip", svc.Name, kind),
+		SHA256:         derefString(sha),
+		ModifiedAt:     modified,
+	}, nil
 }
 
 var errBlockedHost = errors.New("URL resolves to a blocked or private address")
@@ -293,75 +346,35 @@ func isBlockedIP(ip net.IP) bool {
 	return false
 }
 
-func (s *ArchiveService) downloadAndSave(ctx context.Context, archiveURL, key string) (storage.FileInfo, error) {
+func (s *ArchiveService) downloadAndSave(ctx context.Context, archiveURL string) (string, storage.FileInfo, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
 	if err != nil {
-		return storage.FileInfo{}, fmt.Errorf("creating request: %w", err)
+		return "", storage.FileInfo{}, fmt.Errorf("creating request: %w", err)
 	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return storage.FileInfo{}, fmt.Errorf("downloading: %w", err)
+		return "", storage.FileInfo{}, fmt.Errorf("downloading: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return storage.FileInfo{}, fmt.Errorf("download returned status %d", resp.StatusCode)
-	}
-
-	header := make([]byte, zipMagicSize)
-	n, err := io.ReadFull(resp.Body, header)
-	if err != nil {
-		return storage.FileInfo{}, fmt.Errorf("reading archive header: %w", err)
-	}
-	if !bytes.Equal(header[:n], zipMagic) {
-		return storage.FileInfo{}, errors.New("downloaded file is not a valid ZIP archive")
+		return "", storage.FileInfo{}, fmt.Errorf("download returned status %d", resp.StatusCode)
 	}
 
-	limited := io.LimitReader(resp.Body, s.maxUploadBytes+uploadLimitOverhead)
-	reader := io.MultiReader(bytes.NewReader(header), limited)
-
-	info, err := s.store.Save(ctx, key, reader)
+	reader, err := zipStream(resp.Body, s.maxUploadBytes)
 	if err != nil {
-		return storage.FileInfo{}, fmt.Errorf("saving to storage: %w", err)
+		return "", storage.FileInfo{}, fmt.Errorf("downloaded file: %w", err)
 	}
-
-	if info.Size > s.maxUploadBytes {
-		if err := s.store.Delete(ctx, key); err != nil {
-			return storage.FileInfo{}, fmt.Errorf("deleting oversized archive: %w", err)
-		}
-		return storage.FileInfo{}, fmt.Errorf("archive exceeds maximum size (%d bytes)", s.maxUploadBytes)
-	}
-
-	return info, nil
+	return s.versions.put(ctx, reader, s.maxUploadBytes)
 }
 
-func (s *ArchiveService) saveUploaded(ctx context.Context, r io.Reader, key string) (storage.FileInfo, error) {
-	header := make([]byte, zipMagicSize)
-	n, err := io.ReadFull(r, header)
+func (s *ArchiveService) saveUploaded(ctx context.Context, r io.Reader) (string, storage.FileInfo, error) {
+	reader, err := zipStream(r, s.maxUploadBytes)
 	if err != nil {
-		return storage.FileInfo{}, fmt.Errorf("reading archive header: %w", err)
-	}
-	if !bytes.Equal(header[:n], zipMagic) {
-		return storage.FileInfo{}, errs.NewValidationError(map[string]string{
-			fieldArchive: "file is not a valid ZIP archive",
+		return "", storage.FileInfo{}, errs.NewValidationError(map[string]string{
+			fieldArchive: err.Error(),
 		})
 	}
-
-	limited := io.LimitReader(r, s.maxUploadBytes+uploadLimitOverhead)
-	reader := io.MultiReader(bytes.NewReader(header), limited)
-
-	info, err := s.store.Save(ctx, key, reader)
-	if err != nil {
-		return storage.FileInfo{}, fmt.Errorf("saving to storage: %w", err)
-	}
-
-	if info.Size > s.maxUploadBytes {
-		if err := s.store.Delete(ctx, key); err != nil {
-			return storage.FileInfo{}, fmt.Errorf("deleting oversized archive: %w", err)
-		}
-		return storage.FileInfo{}, fmt.Errorf("archive exceeds maximum size (%d bytes)", s.maxUploadBytes)
-	}
-
-	return info, nil
+	return s.versions.put(ctx, reader, s.maxUploadBytes)
 }
@@ -29,8 +29,15 @@ func TestMain(m *testing.M) {
 }
 
 type mockArchiveQuerier struct {
+	mockArchiveVersions
 	services map[int64]*db.Service
 	nextID   int64
+	audit    []db.InsertAuditEventParams
+}
+
+func (m *mockArchiveQuerier) InsertAuditEvent(_ context.Context, arg db.InsertAuditEventParams) error {
+	m.audit = append(m.audit, arg)
+	return nil
 }
 
 func newMockArchiveQuerier() *mockArchiveQuerier {
@@ -101,7 +108,7 @@ func (s *memStorage) Save(_ context.Context, key string, r io.Reader) (storage.F
 func (s *memStorage) Open(_ context.Context, key string) (io.ReadSeekCloser, error) {
 	data, ok := s.files[key]
 	if !ok {
-		return nil, fmt.Errorf("file not found: %s", key)
+		return nil, fmt.Errorf("%w: %s", storage.ErrFileNotFound, key)
 	}
 	return &readSeekCloser{Reader: bytes.NewReader(data)}, nil
 }
@@ -156,7 +163,7 @@ func TestRedownload_Success(t *testing.T) {
 	})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	result, err := arcSvc.Redownload(context.Background(), id, true)
+	result, err := arcSvc.Redownload(context.Background(), id, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("Redownload: %v", err)
 	}
@@ -187,12 +194,12 @@ func TestRedownload_NotZip(t *testing.T) {
 	})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	_, err := arcSvc.Redownload(context.Background(), id, true)
+	_, err := arcSvc.Redownload(context.Background(), id, Access{Admin: true})
 	if err == nil {
 		t.Fatal("expected error for non-zip download")
 	}
-	if !strings.Contains(err.Error(), "not a valid ZIP") {
-		t.Errorf("error should mention invalid ZIP, got: %v", err)
+	if !strings.Contains(err.Error(), "unsupported archive format") {
+		t.Errorf("error should mention the unsupported format, got: %v", err)
 	}
 }
 
@@ -214,7 +221,7 @@ func TestRedownload_ExceedsSize(t *testing.T) {
 	})
 
 	arcSvc := NewArchiveService(q, store, 100)
-	_, err := arcSvc.Redownload(context.Background(), id, true)
+	_, err := arcSvc.Redownload(context.Background(), id, Access{Admin: true})
 	if err == nil {
 		t.Fatal("expected error for exceeding size")
 	}
@@ -242,7 +249,7 @@ func TestRedownload_BothArchives(t *testing.T) {
 	})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	result, err := arcSvc.Redownload(context.Background(), id, true)
+	result, err := arcSvc.Redownload(context.Background(), id, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("Redownload: %v", err)
 	}
@@ -254,6 +261,67 @@ func TestRedownload_BothArchives(t *testing.T) {
 	}
 }
 
+func TestRedownload_ActivatesBothInOneTransaction(t *testing.T) {
+	zipData := makeZipData(50)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, err := w.Write(zipData); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	q := newMockArchiveQuerier()
+	id := q.addService(db.Service{
+		Name:              "test-svc",
+		ServiceArchiveUrl: strPtr(server.URL + "/service.zip"),
+		CheckerArchiveUrl: strPtr(server.URL + "/checker.zip"),
+	})
+
+	arcSvc := NewArchiveService(q, newMemStorage(), 10*1024*1024)
+	tx := &recordingTx{}
+	arcSvc.SetTxRunner(tx)
+	if _, err := arcSvc.Redownload(context.Background(), id, Access{Admin: true}); err != nil {
+		t.Fatalf("Redownload: %v", err)
+	}
+	if tx.calls != 1 {
+		t.Errorf("RunInTx calls = %d, want 1", tx.calls)
+	}
+}
+
+func TestRedownload_CheckerFailureKeepsServiceArchive(t *testing.T) {
+	zipData := makeZipData(50)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/checker.zip") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := w.Write(zipData); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	q := newMockArchiveQuerier()
+	id := q.addService(db.Service{
+		Name:              "test-svc",
+		ServiceArchiveUrl: strPtr(server.URL + "/service.zip"),
+		CheckerArchiveUrl: strPtr(server.URL + "/checker.zip"),
+	})
+
+	arcSvc := NewArchiveService(q, newMemStorage(), 10*1024*1024)
+	if _, err := arcSvc.Redownload(context.Background(), id, Access{Admin: true}); err == nil {
+		t.Fatal("expected error for missing checker archive")
+	}
+	if q.services[id].ServiceLocalPath != nil {
+		t.Error("service archive was activated although the checker download failed")
+	}
+	if len(q.versions) != 0 {
+		t.Errorf("versions = %d, want none", len(q.versions))
+	}
+}
+
 func TestRedownload_NoURLs(t *testing.T) {
 	q := newMockArchiveQuerier()
 	store := newMemStorage()
@@ -262,7 +330,7 @@ func TestRedownload_NoURLs(t *testing.T) {
 	})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	result, err := arcSvc.Redownload(context.Background(), id, true)
+	result, err := arcSvc.Redownload(context.Background(), id, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("Redownload: %v", err)
 	}
@@ -279,14 +347,14 @@ func TestUploadArchives_Success(t *testing.T) {
 	zipData := makeZipData(100)
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	result, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader(zipData), nil, true)
+	result, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader(zipData), nil, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("UploadArchives: %v", err)
 	}
 	if result.ServiceLocalPath == nil {
 		t.Fatal("ServiceLocalPath should not be nil")
 	}
-	if _, ok := store.files["services/1/service.zip"]; !ok {
+	if _, ok := store.files[*result.ServiceLocalPath]; !ok {
 		t.Fatal("file should be saved in storage")
 	}
 }
@@ -300,7 +368,7 @@ func TestUploadArchives_BothFiles(t *testing.T) {
 	checkerZip := makeZipData(30)
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	result, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader(serviceZip), bytes.NewReader(checkerZip), true)
+	result, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader(serviceZip), bytes.NewReader(checkerZip), Access{Admin: true})
 	if err != nil {
 		t.Fatalf("UploadArchives: %v", err)
 	}
@@ -318,7 +386,7 @@ func TestUploadArchives_NotZip(t *testing.T) {
 	id := q.addService(db.Service{Name: "test-svc"})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	_, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader([]byte("not a zip")), nil, true)
+	_, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader([]byte("not a zip")), nil, Access{Admin: true})
 	if err == nil {
 		t.Fatal("expected error for non-zip upload")
 	}
@@ -332,7 +400,7 @@ func TestUploadArchives_ExceedsSize(t *testing.T) {
 	zipData := makeZipData(1000)
 
 	arcSvc := NewArchiveService(q, store, 100)
-	_, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader(zipData), nil, true)
+	_, err := arcSvc.UploadArchives(context.Background(), id, bytes.NewReader(zipData), nil, Access{Admin: true})
 	if err == nil {
 		t.Fatal("expected error for exceeding size")
 	}
@@ -358,14 +426,14 @@ func TestOpenLocal_Service(t *testing.T) {
 	})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	rc, filename, err := arcSvc.OpenLocal(ctx, 1, "service")
+	rc, err := arcSvc.OpenLocal(ctx, 1, "service")
 	if err != nil {
 		t.Fatalf("OpenLocal: %v", err)
 	}
 	defer rc.Close()
 
-	if filename != "test-svc-service.zip" {
-		t.Errorf("filename = %q, want %q", filename, "test-svc-service.zip")
+	if rc.Filename != "test-svc-service.zip" {
+		t.Errorf("filename = %q, want %q", rc.Filename, "test-svc-service.zip")
 	}
 
 	data, err := io.ReadAll(rc)
@@ -394,14 +462,14 @@ func TestOpenLocal_Checker(t *testing.T) {
 	})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	rc, filename, err := arcSvc.OpenLocal(ctx, 1, "checker")
+	rc, err := arcSvc.OpenLocal(ctx, 1, "checker")
 	if err != nil {
 		t.Fatalf("OpenLocal: %v", err)
 	}
 	defer rc.Close()
 
-	if filename != "test-svc-checker.zip" {
-		t.Errorf("filename = %q, want %q", filename, "test-svc-checker.zip")
+	if rc.Filename != "test-svc-checker.zip" {
+		t.Errorf("filename = %q, want %q", rc.Filename, "test-svc-checker.zip")
 	}
 }
 
@@ -411,7 +479,7 @@ func TestOpenLocal_NoArchive(t *testing.T) {
 	q.addService(db.Service{Name: "test-svc"})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	_, _, err := arcSvc.OpenLocal(context.Background(), 1, "service")
+	_, err := arcSvc.OpenLocal(context.Background(), 1, "service")
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
@@ -423,7 +491,7 @@ func TestOpenLocal_InvalidKind(t *testing.T) {
 	q.addService(db.Service{Name: "test-svc"})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	_, _, err := arcSvc.OpenLocal(context.Background(), 1, "invalid")
+	_, err := arcSvc.OpenLocal(context.Background(), 1, "invalid")
 	if _, ok := err.(*errs.ValidationError); !ok {
 		t.Errorf("expected ValidationError, got %v", err)
 	}
@@ -434,7 +502,7 @@ func TestOpenLocal_NotFound(t *testing.T) {
 	store := newMemStorage()
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	_, _, err := arcSvc.OpenLocal(context.Background(), 999, "service")
+	_, err := arcSvc.OpenLocal(context.Background(), 999, "service")
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
@@ -454,7 +522,7 @@ func TestRedownload_HTTPError(t *testing.T) {
 	})
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	_, err := arcSvc.Redownload(context.Background(), id, true)
+	_, err := arcSvc.Redownload(context.Background(), id, Access{Admin: true})
 	if err == nil {
 		t.Fatal("expected error for HTTP 500")
 	}
@@ -465,7 +533,7 @@ func TestRedownload_ServiceNotFound(t *testing.T) {
 	store := newMemStorage()
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	_, err := arcSvc.Redownload(context.Background(), 999, true)
+	_, err := arcSvc.Redownload(context.Background(), 999, Access{Admin: true})
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
@@ -477,7 +545,7 @@ func TestUploadArchives_NotFound(t *testing.T) {
 	zipData := makeZipData(50)
 
 	arcSvc := NewArchiveService(q, store, 10*1024*1024)
-	_, err := arcSvc.UploadArchives(context.Background(), 999, bytes.NewReader(zipData), nil, true)
+	_, err := arcSvc.UploadArchives(context.Background(), 999, bytes.NewReader(zipData), nil, Access{Admin: true})
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
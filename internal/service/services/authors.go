@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+const fieldUserID = "user_id"
+
+// Access is what the caller may do with a service: admins manage every
+// service, authors the services they are linked to.
+type Access struct {
+	Admin  bool
+	Author bool
+}
+
+// CanManage reports whether the caller may edit and maintain the service and
+// see its private fields.
+func (a Access) CanManage() bool {
+	return a.Admin || a.Author
+}
+
+type ServiceAuthor struct {
+	UserID      int64
+	UserName    string
+	DisplayName string
+	AvatarUrl   *string
+	CreatedAt   time.Time
+}
+
+type AuthorQuerier interface {
+	ListServiceAuthors(ctx context.Context, serviceID int64) ([]db.ListServiceAuthorsRow, error)
+	AddServiceAuthor(ctx context.Context, arg db.AddServiceAuthorParams) error
+	RemoveServiceAuthor(ctx context.Context, arg db.RemoveServiceAuthorParams) (int64, error)
+	IsServiceAuthor(ctx context.Context, arg db.IsServiceAuthorParams) (bool, error)
+}
+
+// IsAuthor reports whether the user is linked to the service as an author.
+func (s *Service) IsAuthor(ctx context.Context, serviceID, userID int64) (bool, error) {
+	return s.q.IsServiceAuthor(ctx, db.IsServiceAuthorParams{ServiceID: serviceID, UserID: userID})
+}
+
+// ListAuthors returns the users credited as authors of a service.
+func (s *Service) ListAuthors(ctx context.Context, serviceID int64, access Access) ([]ServiceAuthor, error) {
+	svc, err := s.q.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	if !svc.Public && !access.CanManage() {
+		return nil, errs.ErrNotFound
+	}
+
+	rows, err := s.q.ListServiceAuthors(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	authors := make([]ServiceAuthor, len(rows))
+	for i, row := range rows {
+		authors[i] = ServiceAuthor{
+			UserID:      row.UserID,
+			UserName:    row.UserName,
+			DisplayName: row.DisplayName,
+			AvatarUrl:   row.AvatarUrl,
+			CreatedAt:   row.CreatedAt,
+		}
+	}
+	return authors, nil
+}
+
+// AddAuthor links a user to a service as an author. Only admins hand out
+// authorship.
+func (s *Service) AddAuthor(ctx context.Context, serviceID, userID int64, isAdmin bool) ([]ServiceAuthor, error) {
+	if !isAdmin {
+		return nil, errs.ErrForbidden
+	}
+	if _, err := s.q.GetServiceByID(ctx, serviceID); err != nil {
+		return nil, mapNotFound(err)
+	}
+	if err := s.q.AddServiceAuthor(ctx, db.AddServiceAuthorParams{ServiceID: serviceID, UserID: userID}); err != nil {
+		if repository.IsForeignKeyViolation(err) {
+			return nil, errs.NewValidationError(map[string]string{fieldUserID: "user not found"})
+		}
+		return nil, err
+	}
+	return s.ListAuthors(ctx, serviceID, Access{Admin: true})
+}
+
+// RemoveAuthor unlinks an author from a service.
+func (s *Service) RemoveAuthor(ctx context.Context, serviceID, userID int64, isAdmin bool) error {
+	if !isAdmin {
+		return errs.ErrForbidden
+	}
+	removed, err := s.q.RemoveServiceAuthor(ctx, db.RemoveServiceAuthorParams{ServiceID: serviceID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
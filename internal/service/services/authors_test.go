@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func (m *mockQuerier) ListServiceAuthors(_ context.Context, serviceID int64) ([]db.ListServiceAuthorsRow, error) {
+	rows := make([]db.ListServiceAuthorsRow, 0, len(m.authors[serviceID]))
+	for _, userID := range m.authors[serviceID] {
+		rows = append(rows, db.ListServiceAuthorsRow{
+			ServiceID:   serviceID,
+			UserID:      userID,
+			UserName:    m.users[userID],
+			DisplayName: m.users[userID],
+			CreatedAt:   time.Now(),
+		})
+	}
+	return rows, nil
+}
+
+func (m *mockQuerier) AddServiceAuthor(_ context.Context, arg db.AddServiceAuthorParams) error {
+	if _, ok := m.users[arg.UserID]; !ok {
+		return &pgconn.PgError{Code: "23503"}
+	}
+	if m.authors == nil {
+		m.authors = make(map[int64][]int64)
+	}
+	if !slices.Contains(m.authors[arg.ServiceID], arg.UserID) {
+		m.authors[arg.ServiceID] = append(m.authors[arg.ServiceID], arg.UserID)
+	}
+	return nil
+}
+
+func (m *mockQuerier) RemoveServiceAuthor(_ context.Context, arg db.RemoveServiceAuthorParams) (int64, error) {
+	authors := m.authors[arg.ServiceID]
+	i := slices.Index(authors, arg.UserID)
+	if i < 0 {
+		return 0, nil
+	}
+	m.authors[arg.ServiceID] = slices.Delete(authors, i, i+1)
+	return 1, nil
+}
+
+func (m *mockQuerier) IsServiceAuthor(_ context.Context, arg db.IsServiceAuthorParams) (bool, error) {
+	return slices.Contains(m.authors[arg.ServiceID], arg.UserID), nil
+}
+
+func TestAuthors_AddListRemove(t *testing.T) {
+	ctx := context.Background()
+	q := newMockQuerier()
+	q.users = map[int64]string{7: "alice"}
+	svc := NewService(q)
+	created := mustCreateService(t, svc, CreateParams{Name: "authored", Public: true})
+
+	if _, err := svc.AddAuthor(ctx, created.ID, 7, false); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("non-admin AddAuthor error = %v, want ErrForbidden", err)
+	}
+	var ve *errs.ValidationError
+	if _, err := svc.AddAuthor(ctx, created.ID, 8, true); !errors.As(err, &ve) || ve.Fields[fieldUserID] == "" {
+		t.Fatalf("unknown user AddAuthor error = %v, want user_id validation error", err)
+	}
+
+	authors, err := svc.AddAuthor(ctx, created.ID, 7, true)
+	if err != nil {
+		t.Fatalf("AddAuthor: %v", err)
+	}
+	if len(authors) != 1 || authors[0].UserName != "alice" {
+		t.Fatalf("authors = %+v", authors)
+	}
+	if ok, _ := svc.IsAuthor(ctx, created.ID, 7); !ok {
+		t.Error("IsAuthor = false after AddAuthor")
+	}
+
+	if err := svc.RemoveAuthor(ctx, created.ID, 7, true); err != nil {
+		t.Fatalf("RemoveAuthor: %v", err)
+	}
+	if err := svc.RemoveAuthor(ctx, created.ID, 7, true); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("second RemoveAuthor error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAccess_AuthorsManageTheirService(t *testing.T) {
+	ctx := context.Background()
+	q := newMockQuerier()
+	svc := NewService(q)
+	created := mustCreateService(t, svc, CreateParams{
+		Name:               "private-service",
+		PrivateDescription: strPtr("flag store in /data"),
+	})
+
+	if _, err := svc.Update(ctx, created.ID, UpdateParams{Name: strPtr("renamed")}, Access{}); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("Update without access error = %v, want ErrForbidden", err)
+	}
+
+	author := Access{Author: true}
+	updated, err := svc.Update(ctx, created.ID, UpdateParams{Name: strPtr("renamed")}, author)
+	if err != nil {
+		t.Fatalf("author Update: %v", err)
+	}
+	if updated.Name != "renamed" || updated.PrivateDescription == nil {
+		t.Errorf("author update result = %+v", updated)
+	}
+
+	got, err := svc.GetByID(ctx, created.ID, author)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.PrivateDescription == nil {
+		t.Error("author does not see the private description")
+	}
+	got, _ = svc.GetByID(ctx, created.ID, Access{})
+	if got.PrivateDescription != nil {
+		t.Error("private description leaked to a non-author")
+	}
+
+	if _, err := svc.ReplaceVulns(ctx, created.ID, []VulnInput{{Title: "IDOR"}}, author); err != nil {
+		t.Fatalf("author ReplaceVulns: %v", err)
+	}
+	if vulns, err := svc.ListVulns(ctx, created.ID, author); err != nil || len(vulns) != 1 {
+		t.Fatalf("author ListVulns = %v, %v", vulns, err)
+	}
+}
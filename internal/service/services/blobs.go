@@ -0,0 +1,330 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
+)
+
+const (
+	blobKeyPrefix = "blobs/"
+
+	// DefaultBlobGCGrace is how long an unreferenced blob is kept. It covers
+	// the window between saving a blob and recording the version that uses it.
+	DefaultBlobGCGrace = 24 * time.Hour
+
+	blobGCBatch = 500
+)
+
+var errArchiveTooLarge = errors.New("archive exceeds maximum size")
+
+// BlobQuerier records content-addressed blobs.
+type BlobQuerier interface {
+	GetStorageBlob(ctx context.Context, sha256 string) (db.StorageBlob, error)
+	UpsertStorageBlob(ctx context.Context, arg db.UpsertStorageBlobParams) (db.StorageBlob, error)
+}
+
+// blobKey is the storage key of the content with the given SHA256.
+func blobKey(sha string) string {
+	return blobKeyPrefix + sha[:2] + "/" + sha
+}
+
+func isBlobKey(key string) bool {
+	return strings.HasPrefix(key, blobKeyPrefix)
+}
+
+// putBlob stores r once per content and returns the key of its blob. The
+// content is spooled to a temporary file to learn its hash before anything is
+// written, so a duplicate costs no storage write. maxBytes > 0 rejects larger
+// content before it is saved.
+func putBlob(ctx context.Context, q BlobQuerier, store storage.Storage, r io.Reader, maxBytes int64) (string, storage.FileInfo, error) {
+	tmp, err := os.CreateTemp("", "ctf01d-blob-*")
+	if err != nil {
+		return "", storage.FileInfo{}, fmt.Errorf("creating temp file: %w", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if err != nil {
+		return "", storage.FileInfo{}, fmt.Errorf("reading archive: %w", err)
+	}
+	if maxBytes > 0 && size > maxBytes {
+		return "", storage.FileInfo{}, fmt.Errorf("%w (%d bytes)", errArchiveTooLarge, maxBytes)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", storage.FileInfo{}, fmt.Errorf("rewinding temp file: %w", err)
+	}
+	return saveBlob(ctx, q, store, storage.FileInfo{Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}, tmp)
+}
+
+// putBlobBytes is putBlob for content already in memory.
+func putBlobBytes(ctx context.Context, q BlobQuerier, store storage.Storage, data []byte) (string, storage.FileInfo, error) {
+	sum := sha256.Sum256(data)
+	info := storage.FileInfo{Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])}
+	return saveBlob(ctx, q, store, info, bytes.NewReader(data))
+}
+
+// saveBlob writes the object unless a healthy blob with the same hash exists.
+// A blob flagged as corrupt is written again, which repairs it.
+func saveBlob(ctx context.Context, q BlobQuerier, store storage.Storage, info storage.FileInfo, r io.Reader) (string, storage.FileInfo, error) {
+	key := blobKey(info.SHA256)
+	existing, err := q.GetStorageBlob(ctx, info.SHA256)
+	switch {
+	case err == nil && !existing.CorruptAt.Valid:
+	case err == nil || errors.Is(err, pgx.ErrNoRows):
+		saved, err := store.Save(ctx, key, r)
+		if err != nil {
+			return "", storage.FileInfo{}, fmt.Errorf("saving to storage: %w", err)
+		}
+		if saved.SHA256 != info.SHA256 {
+			return "", storage.FileInfo{}, fmt.Errorf("saving to storage: checksum mismatch for %s", key)
+		}
+	default:
+		return "", storage.FileInfo{}, fmt.Errorf("looking up blob: %w", err)
+	}
+
+	if _, err := q.UpsertStorageBlob(ctx, db.UpsertStorageBlobParams{
+		Sha256:     info.SHA256,
+		StorageKey: key,
+		Size:       info.Size,
+	}); err != nil {
+		return "", storage.FileInfo{}, fmt.Errorf("recording blob: %w", err)
+	}
+	return key, info, nil
+}
+
+// BlobMaintenanceQuerier is what garbage collection, scrubbing and the key
+// migration need.
+type BlobMaintenanceQuerier interface {
+	BlobQuerier
+	ListUnreferencedStorageBlobs(ctx context.Context, arg db.ListUnreferencedStorageBlobsParams) ([]db.StorageBlob, error)
+	DeleteUnreferencedStorageBlob(ctx context.Context, arg db.DeleteUnreferencedStorageBlobParams) (int64, error)
+	ListStorageBlobsToScrub(ctx context.Context, limit int32) ([]db.StorageBlob, error)
+	MarkStorageBlobVerified(ctx context.Context, sha256 string) error
+	MarkStorageBlobCorrupt(ctx context.Context, sha256 string) error
+	ListCorruptStorageBlobs(ctx context.Context) ([]db.StorageBlob, error)
+	ListLegacyArchiveStorageKeys(ctx context.Context) ([]string, error)
+	ReplaceArchiveVersionStorageKey(ctx context.Context, arg db.ReplaceArchiveVersionStorageKeyParams) error
+	ReplaceServiceLocalStorageKey(ctx context.Context, arg db.ReplaceServiceLocalStorageKeyParams) error
+}
+
+// BlobService maintains the content-addressed archive store: it removes blobs
+// no archive version references and re-hashes stored objects to catch
+// corruption.
+type BlobService struct {
+	q     BlobMaintenanceQuerier
+	store storage.Storage
+	tx    TxRunner
+	grace time.Duration
+}
+
+func NewBlobService(q BlobMaintenanceQuerier, store storage.Storage) *BlobService {
+	return &BlobService{q: q, store: store, grace: DefaultBlobGCGrace}
+}
+
+// SetTxRunner makes the key migration update versions and services of one
+// archive in a single transaction.
+func (s *BlobService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+// SetGCGrace sets how long unreferenced blobs are kept before collection.
+func (s *BlobService) SetGCGrace(grace time.Duration) {
+	s.grace = grace
+}
+
+// CorruptBlob is a blob whose stored content no longer matches its hash.
+type CorruptBlob struct {
+	SHA256     string
+	StorageKey string
+	Size       int64
+	RefCount   int32
+	CorruptAt  time.Time
+}
+
+// ScrubResult counts the blobs checked by a scrub pass.
+type ScrubResult struct {
+	Checked int
+	Corrupt int
+}
+
+// CollectGarbage deletes up to one batch of blobs that have been unreferenced
+// for longer than the grace period and returns how many were removed.
+func (s *BlobService) CollectGarbage(ctx context.Context) (int, error) {
+	before := pgtypeTz(time.Now().Add(-s.grace))
+	rows, err := s.q.ListUnreferencedStorageBlobs(ctx, db.ListUnreferencedStorageBlobsParams{Before: before, Limit: blobGCBatch})
+	if err != nil {
+		return 0, fmt.Errorf("listing unreferenced blobs: %w", err)
+	}
+
+	removed := 0
+	for _, row := range rows {
+		// The row goes first: a blob referenced again in the meantime keeps
+		// its row, and a failed object delete only leaves an orphan file.
+		deleted, err := s.q.DeleteUnreferencedStorageBlob(ctx, db.DeleteUnreferencedStorageBlobParams{Sha256: row.Sha256, Before: before})
+		if err != nil {
+			return removed, fmt.Errorf("deleting blob %s: %w", row.Sha256, err)
+		}
+		if deleted == 0 {
+			continue
+		}
+		if err := s.store.Delete(ctx, row.StorageKey); err != nil {
+			slog.Warn("failed to delete blob object", "key", row.StorageKey, "error", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Scrub re-hashes up to limit blobs, least recently verified first, and flags
+// the ones whose content is missing or no longer matches.
+func (s *BlobService) Scrub(ctx context.Context, limit int) (ScrubResult, error) {
+	var result ScrubResult
+	limit32, err := int32FromInt64(int64(limit))
+	if err != nil {
+		return result, err
+	}
+	rows, err := s.q.ListStorageBlobsToScrub(ctx, limit32)
+	if err != nil {
+		return result, fmt.Errorf("listing blobs to scrub: %w", err)
+	}
+
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		ok, err := s.verify(ctx, row)
+		if err != nil {
+			return result, err
+		}
+		result.Checked++
+		if ok {
+			if err := s.q.MarkStorageBlobVerified(ctx, row.Sha256); err != nil {
+				return result, fmt.Errorf("marking blob verified: %w", err)
+			}
+			continue
+		}
+		result.Corrupt++
+		slog.Error("storage blob is corrupt", "sha256", row.Sha256, "key", row.StorageKey, "ref_count", row.RefCount)
+		if err := s.q.MarkStorageBlobCorrupt(ctx, row.Sha256); err != nil {
+			return result, fmt.Errorf("marking blob corrupt: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// verify reports whether the stored object still hashes to the blob's SHA256.
+// A missing object counts as corrupt; other storage errors abort the scrub.
+func (s *BlobService) verify(ctx context.Context, row db.StorageBlob) (bool, error) {
+	rc, err := s.store.Open(ctx, row.StorageKey)
+	if errors.Is(err, storage.ErrFileNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("opening blob %s: %w", row.StorageKey, err)
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, rc)
+	if err != nil {
+		return false, fmt.Errorf("reading blob %s: %w", row.StorageKey, err)
+	}
+	return size == row.Size && hex.EncodeToString(hash.Sum(nil)) == row.Sha256, nil
+}
+
+// ListCorrupt returns the blobs flagged by the scrubber.
+func (s *BlobService) ListCorrupt(ctx context.Context) ([]CorruptBlob, error) {
+	rows, err := s.q.ListCorruptStorageBlobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]CorruptBlob, len(rows))
+	for i, row := range rows {
+		out[i] = CorruptBlob{
+			SHA256:     row.Sha256,
+			StorageKey: row.StorageKey,
+			Size:       row.Size,
+			RefCount:   row.RefCount,
+			CorruptAt:  row.CorruptAt.Time,
+		}
+	}
+	return out, nil
+}
+
+// MigrateLegacyKeys moves archives stored under per-version keys into blobs,
+// repoints versions and services at them and deletes the old objects.
+// Identical archives collapse into one blob. It returns how many keys moved.
+func (s *BlobService) MigrateLegacyKeys(ctx context.Context) (int, error) {
+	keys, err := s.q.ListLegacyArchiveStorageKeys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing legacy keys: %w", err)
+	}
+
+	moved := 0
+	for _, oldKey := range keys {
+		newKey, err := s.copyToBlob(ctx, oldKey)
+		if errors.Is(err, storage.ErrFileNotFound) {
+			slog.Warn("legacy archive is missing from storage", "key", oldKey)
+			continue
+		}
+		if err != nil {
+			return moved, err
+		}
+
+		arg := db.ReplaceArchiveVersionStorageKeyParams{OldKey: oldKey, NewKey: newKey}
+		repoint := func(q BlobMaintenanceQuerier) error {
+			if err := q.ReplaceArchiveVersionStorageKey(ctx, arg); err != nil {
+				return fmt.Errorf("repointing versions of %s: %w", oldKey, err)
+			}
+			if err := q.ReplaceServiceLocalStorageKey(ctx, db.ReplaceServiceLocalStorageKeyParams{OldKey: oldKey, NewKey: newKey}); err != nil {
+				return fmt.Errorf("repointing services of %s: %w", oldKey, err)
+			}
+			return nil
+		}
+		if s.tx != nil {
+			err = s.tx.RunInTx(ctx, func(q *db.Queries) error { return repoint(q) })
+		} else {
+			err = repoint(s.q)
+		}
+		if err != nil {
+			return moved, err
+		}
+
+		if err := s.store.Delete(ctx, oldKey); err != nil {
+			slog.Warn("failed to delete legacy archive", "key", oldKey, "error", err)
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+func (s *BlobService) copyToBlob(ctx context.Context, key string) (string, error) {
+	rc, err := s.store.Open(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	newKey, _, err := putBlob(ctx, s.q, s.store, rc, 0)
+	if err != nil {
+		return "", fmt.Errorf("moving %s: %w", key, err)
+	}
+	return newKey, nil
+}
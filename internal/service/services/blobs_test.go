@@ -0,0 +1,305 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+// refCount mirrors the count_storage_blob_refs trigger by counting the
+// versions stored under key.
+func (m *mockArchiveVersions) refCount(key string) int32 {
+	var n int32
+	for _, row := range m.versions {
+		if row.StorageKey == key {
+			n++
+		}
+	}
+	for _, row := range m.exports {
+		if row.StorageKey == key {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *mockArchiveVersions) GetStorageBlob(_ context.Context, sha string) (db.StorageBlob, error) {
+	blob, ok := m.blobs[sha]
+	if !ok {
+		return db.StorageBlob{}, pgx.ErrNoRows
+	}
+	blob.RefCount = m.refCount(blob.StorageKey)
+	return *blob, nil
+}
+
+func (m *mockArchiveVersions) UpsertStorageBlob(_ context.Context, arg db.UpsertStorageBlobParams) (db.StorageBlob, error) {
+	if m.blobs == nil {
+		m.blobs = make(map[string]*db.StorageBlob)
+	}
+	blob, ok := m.blobs[arg.Sha256]
+	if !ok {
+		blob = &db.StorageBlob{Sha256: arg.Sha256, StorageKey: arg.StorageKey, Size: arg.Size}
+		m.blobs[arg.Sha256] = blob
+	}
+	if m.refCount(blob.StorageKey) == 0 {
+		blob.UnreferencedAt = pgtypeTz(time.Now())
+	}
+	if blob.CorruptAt.Valid {
+		blob.CorruptAt.Valid = false
+		blob.VerifiedAt = pgtypeTz(time.Now())
+	}
+	return *blob, nil
+}
+
+func (m *mockArchiveQuerier) ListUnreferencedStorageBlobs(_ context.Context, arg db.ListUnreferencedStorageBlobsParams) ([]db.StorageBlob, error) {
+	var out []db.StorageBlob
+	for _, blob := range m.blobs {
+		if m.refCount(blob.StorageKey) == 0 && blob.UnreferencedAt.Time.Before(arg.Before.Time) {
+			out = append(out, *blob)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockArchiveQuerier) DeleteUnreferencedStorageBlob(_ context.Context, arg db.DeleteUnreferencedStorageBlobParams) (int64, error) {
+	blob, ok := m.blobs[arg.Sha256]
+	if !ok || m.refCount(blob.StorageKey) != 0 || !blob.UnreferencedAt.Time.Before(arg.Before.Time) {
+		return 0, nil
+	}
+	delete(m.blobs, arg.Sha256)
+	return 1, nil
+}
+
+func (m *mockArchiveQuerier) ListStorageBlobsToScrub(_ context.Context, limit int32) ([]db.StorageBlob, error) {
+	var out []db.StorageBlob
+	for _, blob := range m.blobs {
+		if int32(len(out)) == limit {
+			break
+		}
+		out = append(out, *blob)
+	}
+	return out, nil
+}
+
+func (m *mockArchiveQuerier) MarkStorageBlobVerified(_ context.Context, sha string) error {
+	if blob, ok := m.blobs[sha]; ok {
+		blob.VerifiedAt = pgtypeTz(time.Now())
+	}
+	return nil
+}
+
+func (m *mockArchiveQuerier) MarkStorageBlobCorrupt(_ context.Context, sha string) error {
+	if blob, ok := m.blobs[sha]; ok {
+		blob.VerifiedAt = pgtypeTz(time.Now())
+		blob.CorruptAt = pgtypeTz(time.Now())
+	}
+	return nil
+}
+
+func (m *mockArchiveQuerier) ListCorruptStorageBlobs(_ context.Context) ([]db.StorageBlob, error) {
+	var out []db.StorageBlob
+	for _, blob := range m.blobs {
+		if blob.CorruptAt.Valid {
+			out = append(out, *blob)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockArchiveQuerier) ListLegacyArchiveStorageKeys(_ context.Context) ([]string, error) {
+	seen := make(map[string]bool)
+	var out []string
+	for _, row := range m.versions {
+		if !isBlobKey(row.StorageKey) && !seen[row.StorageKey] {
+			seen[row.StorageKey] = true
+			out = append(out, row.StorageKey)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockArchiveQuerier) ReplaceArchiveVersionStorageKey(_ context.Context, arg db.ReplaceArchiveVersionStorageKeyParams) error {
+	for i := range m.versions {
+		if m.versions[i].StorageKey == arg.OldKey {
+			m.versions[i].StorageKey = arg.NewKey
+		}
+	}
+	return nil
+}
+
+func (m *mockArchiveQuerier) ReplaceServiceLocalStorageKey(_ context.Context, arg db.ReplaceServiceLocalStorageKeyParams) error {
+	for _, svc := range m.services {
+		if svc.ServiceLocalPath != nil && *svc.ServiceLocalPath == arg.OldKey {
+			svc.ServiceLocalPath = &arg.NewKey
+		}
+		if svc.CheckerLocalPath != nil && *svc.CheckerLocalPath == arg.OldKey {
+			svc.CheckerLocalPath = &arg.NewKey
+		}
+	}
+	return nil
+}
+
+func TestUploadArchives_DeduplicatesIdenticalContent(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	first := q.addService(db.Service{Name: "first"})
+	second := q.addService(db.Service{Name: "second"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	ctx := context.Background()
+	data := makeZipData(10)
+
+	a, err := arcSvc.UploadArchives(ctx, first, bytes.NewReader(data), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("first upload: %v", err)
+	}
+	b, err := arcSvc.UploadArchives(ctx, second, bytes.NewReader(data), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("second upload: %v", err)
+	}
+	if *a.ServiceLocalPath != *b.ServiceLocalPath {
+		t.Fatalf("identical archives stored under %q and %q", *a.ServiceLocalPath, *b.ServiceLocalPath)
+	}
+	if !strings.HasPrefix(*a.ServiceLocalPath, blobKeyPrefix) {
+		t.Errorf("key = %q, want a blob key", *a.ServiceLocalPath)
+	}
+	if len(store.files) != 1 {
+		t.Errorf("stored objects = %d, want 1", len(store.files))
+	}
+	if got := q.refCount(*a.ServiceLocalPath); got != 2 {
+		t.Errorf("ref count = %d, want 2", got)
+	}
+}
+
+func TestBlobService_CollectGarbage(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	arcSvc.SetArchiveRetention(1)
+	ctx := context.Background()
+
+	old, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(10)), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("first upload: %v", err)
+	}
+	current, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(makeZipData(20)), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("second upload: %v", err)
+	}
+	if _, ok := store.files[*old.ServiceLocalPath]; !ok {
+		t.Fatal("pruning must leave the blob to the garbage collector")
+	}
+
+	blobs := NewBlobService(q, store)
+	removed, err := blobs.CollectGarbage(ctx)
+	if err != nil {
+		t.Fatalf("CollectGarbage: %v", err)
+	}
+	if removed != 0 {
+		t.Fatalf("removed = %d within the grace period, want 0", removed)
+	}
+
+	blobs.SetGCGrace(-time.Minute)
+	removed, err = blobs.CollectGarbage(ctx)
+	if err != nil {
+		t.Fatalf("CollectGarbage: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("removed = %d, want 1", removed)
+	}
+	if _, ok := store.files[*old.ServiceLocalPath]; ok {
+		t.Error("unreferenced blob must be deleted")
+	}
+	if _, ok := store.files[*current.ServiceLocalPath]; !ok {
+		t.Error("referenced blob must be kept")
+	}
+}
+
+func TestBlobService_ScrubFlagsAndRepairsCorruption(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "test-svc"})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	ctx := context.Background()
+	data := makeZipData(10)
+
+	svc, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(data), nil, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	key := *svc.ServiceLocalPath
+	store.files[key] = append([]byte(nil), store.files[key][:len(data)-1]...)
+
+	blobs := NewBlobService(q, store)
+	result, err := blobs.Scrub(ctx, 10)
+	if err != nil {
+		t.Fatalf("Scrub: %v", err)
+	}
+	if result.Checked != 1 || result.Corrupt != 1 {
+		t.Fatalf("result = %+v, want 1 checked and 1 corrupt", result)
+	}
+	corrupt, err := blobs.ListCorrupt(ctx)
+	if err != nil {
+		t.Fatalf("ListCorrupt: %v", err)
+	}
+	if len(corrupt) != 1 || corrupt[0].StorageKey != key {
+		t.Fatalf("corrupt = %+v, want %s", corrupt, key)
+	}
+
+	if _, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(data), nil, Access{Admin: true}); err != nil {
+		t.Fatalf("re-upload: %v", err)
+	}
+	if !bytes.Equal(store.files[key], data) {
+		t.Error("saving the same content again must rewrite a corrupt blob")
+	}
+	corrupt, err = blobs.ListCorrupt(ctx)
+	if err != nil {
+		t.Fatalf("ListCorrupt: %v", err)
+	}
+	if len(corrupt) != 0 {
+		t.Errorf("corrupt = %+v after repair, want none", corrupt)
+	}
+}
+
+func TestBlobService_MigrateLegacyKeys(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	data := makeZipData(10)
+	legacy := []string{"services/1/versions/service-a.zip", "services/2/versions/service-b.zip"}
+	for i, key := range legacy {
+		id := q.addService(db.Service{Name: "svc", ServiceLocalPath: &legacy[i]})
+		store.files[key] = data
+		if _, err := q.CreateServiceArchiveVersion(context.Background(), db.CreateServiceArchiveVersionParams{
+			ServiceID: id, Kind: kindService, StorageKey: key, Size: int64(len(data)), SourceKind: ArchiveSourceUpload,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	moved, err := NewBlobService(q, store).MigrateLegacyKeys(context.Background())
+	if err != nil {
+		t.Fatalf("MigrateLegacyKeys: %v", err)
+	}
+	if moved != 2 {
+		t.Fatalf("moved = %d, want 2", moved)
+	}
+	if len(store.files) != 1 {
+		t.Fatalf("stored objects = %d, want one shared blob", len(store.files))
+	}
+	for _, svc := range q.services {
+		if !isBlobKey(*svc.ServiceLocalPath) {
+			t.Errorf("service %d still points at %s", svc.ID, *svc.ServiceLocalPath)
+		}
+	}
+	for _, row := range q.versions {
+		if !isBlobKey(row.StorageKey) {
+			t.Errorf("version %d still points at %s", row.ID, row.StorageKey)
+		}
+	}
+}
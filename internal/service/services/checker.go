@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
 )
@@ -34,7 +35,10 @@ func NewCheckerService(q CheckerQuerier, st storage.Storage) *CheckerService {
 	return &CheckerService{q: q, st: st}
 }
 
-func (cs *CheckerService) CheckChecker(ctx context.Context, id int64, isAdmin bool) (*ServiceModel, error) {
+func (cs *CheckerService) CheckChecker(ctx context.Context, id int64, access Access) (*ServiceModel, error) {
+	if !access.CanManage() {
+		return nil, errs.ErrForbidden
+	}
 	svc, err := cs.q.GetServiceByID(ctx, id)
 	if err != nil {
 		return nil, mapNotFound(err)
@@ -50,7 +54,7 @@ func (cs *CheckerService) CheckChecker(ctx context.Context, id int64, isAdmin bo
 		if err != nil {
 			return nil, err
 		}
-		result := fromDB(svc, isAdmin)
+		result := fromDB(svc, true)
 		return &result, nil
 	}
 
@@ -66,7 +70,7 @@ func (cs *CheckerService) CheckChecker(ctx context.Context, id int64, isAdmin bo
 		return nil, err
 	}
 
-	model := fromDB(svc, isAdmin)
+	model := fromDB(svc, true)
 	return &model, nil
 }
 
@@ -87,6 +91,10 @@ func (cs *CheckerService) inspectCheckerArchive(checkerPath string) CheckerInspe
 }
 
 func InspectCheckerFromBytes(data []byte) CheckerInspectionResult {
+	data, err := normalizeArchive(data)
+	if err != nil {
+		return CheckerInspectionResult{Status: checkStatusUnknown}
+	}
 	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
 	if err != nil {
 		return CheckerInspectionResult{Status: checkStatusUnknown}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+const (
+	// DefaultDownloadLinkTTL is the lifetime of a link minted without one.
+	DefaultDownloadLinkTTL = 24 * time.Hour
+	maxDownloadLinkTTL     = 7 * 24 * time.Hour
+
+	fieldExpiresIn    = "expires_in_seconds"
+	fieldMaxDownloads = "max_downloads"
+)
+
+var (
+	errDownloadLinkInvalid  = fmt.Errorf("%w: download link is invalid, expired or used up", errs.ErrForbidden)
+	errDownloadLinkReplaced = fmt.Errorf("%w: the archive of this download link has been replaced", errs.ErrNotFound)
+)
+
+// URLSigner signs download link parameters (satisfied by *auth.URLSigner).
+type URLSigner interface {
+	Sign(payload string) string
+	Verify(payload, signature string) bool
+}
+
+type DownloadLinkQuerier interface {
+	GetServiceByID(ctx context.Context, id int64) (db.Service, error)
+	GetServiceDownloadLink(ctx context.Context, id int64) (db.ServiceDownloadLink, error)
+	CreateServiceDownloadLink(ctx context.Context, arg db.CreateServiceDownloadLinkParams) (db.ServiceDownloadLink, error)
+	UseServiceDownloadLink(ctx context.Context, arg db.UseServiceDownloadLinkParams) (db.ServiceDownloadLink, error)
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+}
+
+// DownloadLinkService mints signed links to one service archive and serves
+// them without authentication.
+type DownloadLinkService struct {
+	q        DownloadLinkQuerier
+	archives *ArchiveService
+	signer   URLSigner
+	tx       TxRunner
+}
+
+func NewDownloadLinkService(q DownloadLinkQuerier, archives *ArchiveService, signer URLSigner) *DownloadLinkService {
+	return &DownloadLinkService{q: q, archives: archives, signer: signer}
+}
+
+// SetTxRunner records the audit event of a new link in the transaction
+// creating it.
+func (s *DownloadLinkService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+// downloadLinkState is what the audit log keeps of an issued link.
+type downloadLinkState struct {
+	LinkID       int64     `json:"link_id"`
+	Kind         string    `json:"kind"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	MaxDownloads *int32    `json:"max_downloads"`
+}
+
+type DownloadLinkCreate struct {
+	Kind string
+	// TTL of zero means DefaultDownloadLinkTTL.
+	TTL          time.Duration
+	MaxDownloads *int
+}
+
+type DownloadLink struct {
+	ID            int64
+	ServiceID     int64
+	Kind          string
+	ExpiresAt     time.Time
+	MaxDownloads  *int32
+	DownloadCount int32
+	CreatedAt     time.Time
+	// Signature authorizes the link; the handler puts it into the URL.
+	Signature string
+}
+
+// Create mints a link to the current archive of the given kind. The link
+// covers that archive only: once it is replaced, the link stops working. Only
+// admins may mint links; actorID is recorded as the issuer.
+func (s *DownloadLinkService) Create(ctx context.Context, serviceID int64, in DownloadLinkCreate, actorID int64, access Access) (*DownloadLink, error) {
+	if !access.Admin {
+		return nil, errs.ErrForbidden
+	}
+
+	kind := strings.ToLower(in.Kind)
+	fields := map[string]string{}
+	if kind != kindService && kind != kindChecker {
+		fields[fieldKind] = "must be 'service' or 'checker'"
+	}
+	ttl := in.TTL
+	if ttl == 0 {
+		ttl = DefaultDownloadLinkTTL
+	}
+	if ttl < time.Minute || ttl > maxDownloadLinkTTL {
+		fields[fieldExpiresIn] = "must be between 60 seconds and 7 days"
+	}
+	var maxDownloads *int32
+	if in.MaxDownloads != nil {
+		n, err := int32FromInt64(int64(*in.MaxDownloads))
+		if err != nil || n <= 0 {
+			fields[fieldMaxDownloads] = "must be a positive number"
+		}
+		maxDownloads = &n
+	}
+	if len(fields) > 0 {
+		return nil, errs.NewValidationError(fields)
+	}
+
+	svc, err := s.q.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	key := currentArchiveKey(svc, kind)
+	if key == "" {
+		return nil, errs.NewValidationError(map[string]string{fieldKind: "service has no stored archive of this kind"})
+	}
+
+	var row db.ServiceDownloadLink
+	err = s.runInTx(ctx, func(q DownloadLinkQuerier) error {
+		row, err = q.CreateServiceDownloadLink(ctx, db.CreateServiceDownloadLinkParams{
+			ServiceID:  serviceID,
+			Kind:       kind,
+			StorageKey: &key,
+			// Signed expiries are whole seconds; the row must agree with them.
+			ExpiresAt:    time.Now().Add(ttl).Truncate(time.Second),
+			MaxDownloads: maxDownloads,
+			CreatedBy:    &actorID,
+		})
+		if err != nil {
+			return err
+		}
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityService,
+			EntityID:   serviceID,
+			Action:     audit.ActionCreateDownloadLink,
+			After: downloadLinkState{
+				LinkID:       row.ID,
+				Kind:         row.Kind,
+				ExpiresAt:    row.ExpiresAt,
+				MaxDownloads: row.MaxDownloads,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("download link issued",
+		"link_id", row.ID, "service_id", serviceID, "kind", kind,
+		"expires_at", row.ExpiresAt, "max_downloads", in.MaxDownloads, "issued_by", actorID)
+
+	link := downloadLinkFromDB(row)
+	link.Signature = s.signer.Sign(downloadLinkPayload(row.ID, serviceID, kind, row.ExpiresAt.Unix(), key))
+	return &link, nil
+}
+
+// Open checks a signed link and counts the download before opening the
+// archive it was issued for. Every failure of the link itself looks the same
+// to the caller, so links cannot be probed; a valid link whose archive has
+// been replaced since is not found.
+func (s *DownloadLinkService) Open(ctx context.Context, serviceID int64, kind string, linkID, expires int64, signature, clientIP string) (*ArchiveFile, error) {
+	kind = strings.ToLower(kind)
+	link, err := s.q.GetServiceDownloadLink(ctx, linkID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	key := derefString(link.StorageKey)
+	if key == "" || !s.signer.Verify(downloadLinkPayload(linkID, serviceID, kind, expires, key), signature) {
+		slog.Warn("download link rejected", "link_id", linkID, "service_id", serviceID, "reason", "bad signature", "ip", clientIP)
+		return nil, errDownloadLinkInvalid
+	}
+	if time.Now().Unix() >= expires {
+		slog.Warn("download link rejected", "link_id", linkID, "service_id", serviceID, "reason", "expired", "ip", clientIP)
+		return nil, errDownloadLinkInvalid
+	}
+
+	svc, err := s.q.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	if currentArchiveKey(svc, kind) != key {
+		slog.Warn("download link rejected", "link_id", linkID, "service_id", serviceID, "reason", "archive replaced", "ip", clientIP)
+		return nil, errDownloadLinkReplaced
+	}
+
+	row, err := s.q.UseServiceDownloadLink(ctx, db.UseServiceDownloadLinkParams{
+		ID:        linkID,
+		ServiceID: serviceID,
+		Kind:      kind,
+		Ip:        clientIP,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		slog.Warn("download link rejected", "link_id", linkID, "service_id", serviceID, "reason", "expired or used up", "ip", clientIP)
+		return nil, errDownloadLinkInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("download link used",
+		"link_id", row.ID, "service_id", serviceID, "kind", kind,
+		"download_count", row.DownloadCount, "ip", clientIP)
+
+	// svc is the row whose key matched, so this opens the signed object.
+	return s.archives.openCurrent(ctx, svc, kind)
+}
+
+func (s *DownloadLinkService) runInTx(ctx context.Context, fn func(q DownloadLinkQuerier) error) error {
+	if s.tx == nil {
+		return fn(s.q)
+	}
+	return s.tx.RunInTx(ctx, func(q *db.Queries) error { return fn(q) })
+}
+
+// currentArchiveKey is the storage key of the current archive of the given
+// kind, or "" when there is none.
+func currentArchiveKey(svc db.Service, kind string) string {
+	if kind == kindChecker {
+		return derefString(svc.CheckerLocalPath)
+	}
+	return derefString(svc.ServiceLocalPath)
+}
+
+func downloadLinkPayload(linkID, serviceID int64, kind string, expires int64, storageKey string) string {
+	return fmt.Sprintf("archive:%d:%d:%s:%d:%s", linkID, serviceID, kind, expires, storageKey)
+}
+
+func downloadLinkFromDB(row db.ServiceDownloadLink) DownloadLink {
+	return DownloadLink{
+		ID:            row.ID,
+		ServiceID:     row.ServiceID,
+		Kind:          row.Kind,
+		ExpiresAt:     row.ExpiresAt,
+		MaxDownloads:  row.MaxDownloads,
+		DownloadCount: row.DownloadCount,
+		CreatedAt:     row.CreatedAt,
+	}
+}
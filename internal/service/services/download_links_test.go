@@ -0,0 +1,174 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type mockDownloadLinkQuerier struct {
+	*mockArchiveQuerier
+	links map[int64]*db.ServiceDownloadLink
+}
+
+func (m *mockDownloadLinkQuerier) CreateServiceDownloadLink(_ context.Context, arg db.CreateServiceDownloadLinkParams) (db.ServiceDownloadLink, error) {
+	row := &db.ServiceDownloadLink{
+		ID:           int64(len(m.links) + 1),
+		ServiceID:    arg.ServiceID,
+		Kind:         arg.Kind,
+		StorageKey:   arg.StorageKey,
+		ExpiresAt:    arg.ExpiresAt,
+		MaxDownloads: arg.MaxDownloads,
+		CreatedBy:    arg.CreatedBy,
+		CreatedAt:    time.Now(),
+	}
+	m.links[row.ID] = row
+	return *row, nil
+}
+
+func (m *mockDownloadLinkQuerier) GetServiceDownloadLink(_ context.Context, id int64) (db.ServiceDownloadLink, error) {
+	row, ok := m.links[id]
+	if !ok {
+		return db.ServiceDownloadLink{}, pgx.ErrNoRows
+	}
+	return *row, nil
+}
+
+func (m *mockDownloadLinkQuerier) UseServiceDownloadLink(_ context.Context, arg db.UseServiceDownloadLinkParams) (db.ServiceDownloadLink, error) {
+	row, ok := m.links[arg.ID]
+	if !ok || row.ServiceID != arg.ServiceID || row.Kind != arg.Kind || !row.ExpiresAt.After(time.Now()) ||
+		(row.MaxDownloads != nil && row.DownloadCount >= *row.MaxDownloads) {
+		return db.ServiceDownloadLink{}, pgx.ErrNoRows
+	}
+	row.DownloadCount++
+	return *row, nil
+}
+
+// fakeSigner "signs" by echoing the payload, which is enough to test that the
+// service signs and checks the right fields.
+type fakeSigner struct{}
+
+func (fakeSigner) Sign(payload string) string { return payload }
+
+func (fakeSigner) Verify(payload, signature string) bool { return payload == signature }
+
+func newDownloadLinkFixture(t *testing.T) (*DownloadLinkService, *ArchiveService, int64) {
+	t.Helper()
+	aq := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := aq.addService(db.Service{Name: "bank"})
+	archives := NewArchiveService(aq, store, 10*1024*1024)
+	if _, err := archives.UploadArchives(context.Background(), id, bytes.NewReader(makeZipData(10)), nil, Access{Admin: true}); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	q := &mockDownloadLinkQuerier{mockArchiveQuerier: aq, links: map[int64]*db.ServiceDownloadLink{}}
+	return NewDownloadLinkService(q, archives, fakeSigner{}), archives, id
+}
+
+func TestDownloadLink_LimitAndSignature(t *testing.T) {
+	links, _, id := newDownloadLinkFixture(t)
+	ctx := context.Background()
+	limit := 1
+
+	link, err := links.Create(ctx, id, DownloadLinkCreate{Kind: kindService, MaxDownloads: &limit}, 1, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	expires := link.ExpiresAt.Unix()
+	events := links.q.(*mockDownloadLinkQuerier).audit
+	if last := events[len(events)-1]; last.Action != audit.ActionCreateDownloadLink || last.EntityID != strconv.FormatInt(id, 10) ||
+		!strings.Contains(string(last.After), `"max_downloads":1`) {
+		t.Errorf("last audit event = %s %s %s, want the issued link", last.Action, last.EntityID, last.After)
+	}
+
+	if _, err := links.Open(ctx, id, kindChecker, link.ID, expires, link.Signature, "192.0.2.1"); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("other kind: err = %v, want forbidden", err)
+	}
+	if _, err := links.Open(ctx, id, kindService, link.ID, expires+3600, link.Signature, "192.0.2.1"); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("extended expiry: err = %v, want forbidden", err)
+	}
+
+	file, err := links.Open(ctx, id, kindService, link.ID, expires, link.Signature, "192.0.2.1")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	data, _ := io.ReadAll(file)
+	file.Close()
+	if len(data) == 0 || file.Filename != "bank-service.zip" {
+		t.Errorf("got %d bytes as %q", len(data), file.Filename)
+	}
+	if file.SHA256 == "" || file.ModifiedAt.IsZero() {
+		t.Errorf("archive must carry its checksum and time for caching: %+v", file)
+	}
+
+	if _, err := links.Open(ctx, id, kindService, link.ID, expires, link.Signature, "192.0.2.1"); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("second download: err = %v, want forbidden", err)
+	}
+}
+
+func TestDownloadLink_Expired(t *testing.T) {
+	links, _, id := newDownloadLinkFixture(t)
+	ctx := context.Background()
+	link, err := links.Create(ctx, id, DownloadLinkCreate{Kind: kindService}, 1, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	past := time.Now().Add(-time.Minute).Unix()
+	key := *links.q.(*mockDownloadLinkQuerier).links[link.ID].StorageKey
+	sig := fakeSigner{}.Sign(downloadLinkPayload(link.ID, id, kindService, past, key))
+
+	if _, err := links.Open(ctx, id, kindService, link.ID, past, sig, "192.0.2.1"); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("err = %v, want forbidden", err)
+	}
+}
+
+func TestDownloadLink_ReplacedArchive(t *testing.T) {
+	links, archives, id := newDownloadLinkFixture(t)
+	ctx := context.Background()
+
+	link, err := links.Create(ctx, id, DownloadLinkCreate{Kind: kindService}, 1, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := archives.UploadArchives(ctx, id, bytes.NewReader(makeZipData(20)), nil, Access{Admin: true}); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	_, err = links.Open(ctx, id, kindService, link.ID, link.ExpiresAt.Unix(), link.Signature, "192.0.2.1")
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("err = %v, want not found", err)
+	}
+	if got := links.q.(*mockDownloadLinkQuerier).links[link.ID].DownloadCount; got != 0 {
+		t.Errorf("download count = %d, want 0", got)
+	}
+}
+
+func TestDownloadLink_CreateRules(t *testing.T) {
+	links, _, id := newDownloadLinkFixture(t)
+	ctx := context.Background()
+
+	if _, err := links.Create(ctx, id, DownloadLinkCreate{Kind: kindService}, 1, Access{Author: true}); !errors.Is(err, errs.ErrForbidden) {
+		t.Errorf("author: err = %v, want forbidden", err)
+	}
+	zero := 0
+	if _, err := links.Create(ctx, id, DownloadLinkCreate{Kind: kindService, MaxDownloads: &zero}, 1, Access{Admin: true}); !isValidationError(err) {
+		t.Errorf("zero limit: err = %v, want validation error", err)
+	}
+	if _, err := links.Create(ctx, id, DownloadLinkCreate{Kind: kindService, TTL: 30 * 24 * time.Hour}, 1, Access{Admin: true}); !isValidationError(err) {
+		t.Errorf("long ttl: err = %v, want validation error", err)
+	}
+	if _, err := links.Create(ctx, id, DownloadLinkCreate{Kind: kindChecker}, 1, Access{Admin: true}); !isValidationError(err) {
+		t.Errorf("missing checker archive: err = %v, want validation error", err)
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
+)
+
+// exportsKept is how many exports of a game keep their blob. Older ones are
+// pruned and left to the blob garbage collector.
+const exportsKept = 5
+
+// ExportQuerier records built game exports.
+type ExportQuerier interface {
+	BlobQuerier
+	CreateGameExport(ctx context.Context, arg db.CreateGameExportParams) (db.GameExport, error)
+	GetGameExport(ctx context.Context, arg db.GetGameExportParams) (db.GameExport, error)
+	PruneGameExports(ctx context.Context, arg db.PruneGameExportsParams) error
+}
+
+// ExportStore keeps built game exports as storage blobs, so they are counted
+// like archive versions and the same archive can be served again.
+type ExportStore struct {
+	q     ExportQuerier
+	store storage.Storage
+	tx    TxRunner
+}
+
+func NewExportStore(q ExportQuerier, store storage.Storage) *ExportStore {
+	return &ExportStore{q: q, store: store}
+}
+
+// SetTxRunner records an export and prunes the old ones in one transaction.
+func (s *ExportStore) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+// GameExport is a stored export of a game.
+type GameExport struct {
+	SHA256     string
+	Filename   string
+	ModifiedAt time.Time
+}
+
+// Save stores the export of a game and returns its record. modifiedAt is
+// when the game was last changed.
+func (s *ExportStore) Save(ctx context.Context, gameID int64, filename string, data []byte, modifiedAt time.Time) (GameExport, error) {
+	key, info, err := putBlobBytes(ctx, s.q, s.store, data)
+	if err != nil {
+		return GameExport{}, fmt.Errorf("storing export: %w", err)
+	}
+
+	var row db.GameExport
+	err = s.runInTx(ctx, func(q ExportQuerier) error {
+		row, err = q.CreateGameExport(ctx, db.CreateGameExportParams{
+			GameID:     gameID,
+			StorageKey: key,
+			Sha256:     info.SHA256,
+			Size:       info.Size,
+			Filename:   filename,
+			ModifiedAt: modifiedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("recording export: %w", err)
+		}
+		if err := q.PruneGameExports(ctx, db.PruneGameExportsParams{GameID: gameID, Keep: exportsKept}); err != nil {
+			return fmt.Errorf("pruning exports: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return GameExport{}, err
+	}
+	return GameExport{SHA256: row.Sha256, Filename: row.Filename, ModifiedAt: row.ModifiedAt}, nil
+}
+
+// Open returns the stored export of a game with the given SHA256.
+func (s *ExportStore) Open(ctx context.Context, gameID int64, sha string) (*ArchiveFile, error) {
+	row, err := s.q.GetGameExport(ctx, db.GetGameExportParams{GameID: gameID, Sha256: sha})
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	rc, err := s.store.Open(ctx, row.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("opening export: %w", err)
+	}
+	return &ArchiveFile{
+		ReadSeekCloser: rc,
+		Filename:       row.Filename,
+		SHA256:         row.Sha256,
+		ModifiedAt:     row.ModifiedAt,
+	}, nil
+}
+
+func (s *ExportStore) runInTx(ctx context.Context, fn func(q ExportQuerier) error) error {
+	if s.tx == nil {
+		return fn(s.q)
+	}
+	return s.tx.RunInTx(ctx, func(q *db.Queries) error { return fn(q) })
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func (m *mockArchiveVersions) CreateGameExport(_ context.Context, arg db.CreateGameExportParams) (db.GameExport, error) {
+	// Rows are kept oldest first; exporting again moves a row to the end.
+	for i, row := range m.exports {
+		if row.GameID == arg.GameID && row.Sha256 == arg.Sha256 {
+			row.Filename = arg.Filename
+			row.ModifiedAt = arg.ModifiedAt
+			m.exports = append(append(m.exports[:i:i], m.exports[i+1:]...), row)
+			return row, nil
+		}
+	}
+	row := db.GameExport{
+		GameID:     arg.GameID,
+		StorageKey: arg.StorageKey,
+		Sha256:     arg.Sha256,
+		Size:       arg.Size,
+		Filename:   arg.Filename,
+		ModifiedAt: arg.ModifiedAt,
+		CreatedAt:  time.Now(),
+	}
+	m.exports = append(m.exports, row)
+	return row, nil
+}
+
+func (m *mockArchiveVersions) GetGameExport(_ context.Context, arg db.GetGameExportParams) (db.GameExport, error) {
+	for _, row := range m.exports {
+		if row.GameID == arg.GameID && row.Sha256 == arg.Sha256 {
+			return row, nil
+		}
+	}
+	return db.GameExport{}, pgx.ErrNoRows
+}
+
+func (m *mockArchiveVersions) PruneGameExports(_ context.Context, arg db.PruneGameExportsParams) error {
+	kept := 0
+	var out []db.GameExport
+	for i := len(m.exports) - 1; i >= 0; i-- {
+		row := m.exports[i]
+		if row.GameID == arg.GameID {
+			if kept == int(arg.Keep) {
+				if blob, ok := m.blobs[row.Sha256]; ok && m.refCount(row.StorageKey) == 1 {
+					blob.UnreferencedAt = pgtypeTz(time.Now())
+				}
+				continue
+			}
+			kept++
+		}
+		out = append([]db.GameExport{row}, out...)
+	}
+	m.exports = out
+	return nil
+}
+
+func TestExportStore_CountsAndPrunesExports(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	exports := NewExportStore(q, store)
+	ctx := context.Background()
+	modified := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	first, err := exports.Save(ctx, 1, "game.zip", []byte("export 0"), modified)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := exports.Save(ctx, 1, "game.zip", []byte("export 0"), modified); err != nil {
+		t.Fatalf("Save again: %v", err)
+	}
+	key := blobKey(first.SHA256)
+	if got := q.refCount(key); got != 1 {
+		t.Fatalf("ref count after saving the same export twice = %d, want 1", got)
+	}
+	if !first.ModifiedAt.Equal(modified) {
+		t.Errorf("ModifiedAt = %v, want %v", first.ModifiedAt, modified)
+	}
+
+	for i := 1; i <= exportsKept; i++ {
+		if _, err := exports.Save(ctx, 1, "game.zip", []byte(fmt.Sprintf("export %d", i)), modified); err != nil {
+			t.Fatalf("Save %d: %v", i, err)
+		}
+	}
+	if len(q.exports) != exportsKept {
+		t.Fatalf("kept exports = %d, want %d", len(q.exports), exportsKept)
+	}
+	if got := q.refCount(key); got != 0 {
+		t.Fatalf("ref count of the pruned export = %d, want 0", got)
+	}
+
+	blobs := NewBlobService(q, store)
+	blobs.SetGCGrace(-time.Minute)
+	removed, err := blobs.CollectGarbage(ctx)
+	if err != nil {
+		t.Fatalf("CollectGarbage: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("removed = %d, want 1", removed)
+	}
+	if _, ok := store.files[key]; ok {
+		t.Error("pruned export blob must be deleted")
+	}
+}
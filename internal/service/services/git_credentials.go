@@ -0,0 +1,429 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+const (
+	GitCredentialKindHTTPSToken = "https_token"
+	GitCredentialKindSSHKey     = "ssh_key"
+
+	fieldCredentialID = "credential_id"
+	fieldSecret       = "secret"
+	fieldHostPattern  = "host_pattern"
+
+	defaultGitTokenUsername = "x-access-token"
+)
+
+// GitCredential is the admin-facing view of a stored credential. The secret
+// itself never leaves the service layer.
+type GitCredential struct {
+	ID          int64
+	Name        string
+	Kind        string
+	HostPattern *string
+	Username    *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type GitCredentialCreateParams struct {
+	Name        string
+	Kind        string
+	HostPattern *string
+	Username    *string
+	Secret      string
+}
+
+type GitCredentialUpdateParams struct {
+	Name        *string
+	HostPattern *string
+	Username    *string
+	Secret      *string
+}
+
+type GitCredentialQuerier interface {
+	CreateGitCredential(ctx context.Context, arg db.CreateGitCredentialParams) (db.GitCredential, error)
+	GetGitCredentialByID(ctx context.Context, id int64) (db.GitCredential, error)
+	LockGitCredential(ctx context.Context, id int64) (db.GitCredential, error)
+	ListGitCredentials(ctx context.Context) ([]db.GitCredential, error)
+	ListGitCredentialsWithHostPattern(ctx context.Context) ([]db.GitCredential, error)
+	UpdateGitCredential(ctx context.Context, arg db.UpdateGitCredentialParams) (db.GitCredential, error)
+	DeleteGitCredential(ctx context.Context, id int64) error
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+}
+
+// SecretSealer encrypts credential secrets at rest (satisfied by
+// *auth.SecretBox).
+type SecretSealer interface {
+	Seal(plaintext []byte) ([]byte, error)
+	Open(ciphertext []byte) ([]byte, error)
+}
+
+type GitCredentialService struct {
+	q   GitCredentialQuerier
+	box SecretSealer
+	tx  TxRunner
+}
+
+// NewGitCredentialService creates the credential store. box may be nil when no
+// encryption key is configured; credentials then cannot be created or used.
+func NewGitCredentialService(q GitCredentialQuerier, box SecretSealer) *GitCredentialService {
+	return &GitCredentialService{q: q, box: box}
+}
+
+// SetTxRunner records the audit event of a credential change in the
+// transaction making it.
+func (s *GitCredentialService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+// gitCredentialState is what the audit log keeps of a credential. The secret
+// is never logged, only that it was replaced.
+type gitCredentialState struct {
+	Name           string  `json:"name"`
+	Kind           string  `json:"kind"`
+	HostPattern    *string `json:"host_pattern"`
+	Username       *string `json:"username"`
+	SecretReplaced bool    `json:"secret_replaced,omitempty"`
+}
+
+func gitCredentialStateOf(row db.GitCredential) *gitCredentialState {
+	return &gitCredentialState{Name: row.Name, Kind: row.Kind, HostPattern: row.HostPattern, Username: row.Username}
+}
+
+// gitCredential is a decrypted credential handed to the git fetcher.
+type gitCredential struct {
+	Kind     string
+	Username string
+	Secret   string
+}
+
+// gitCredentialResolver picks the credential for a clone: the explicitly
+// attached one when id is set, otherwise the most specific host pattern match.
+type gitCredentialResolver interface {
+	resolveGitCredential(ctx context.Context, id *int64, ref *gitRepoReference) (*gitCredential, error)
+}
+
+func (s *GitCredentialService) List(ctx context.Context, isAdmin bool) ([]GitCredential, error) {
+	if !isAdmin {
+		return nil, errs.ErrForbidden
+	}
+	rows, err := s.q.ListGitCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]GitCredential, len(rows))
+	for i, row := range rows {
+		out[i] = gitCredentialFromDB(row)
+	}
+	return out, nil
+}
+
+func (s *GitCredentialService) GetByID(ctx context.Context, id int64, isAdmin bool) (*GitCredential, error) {
+	if !isAdmin {
+		return nil, errs.ErrForbidden
+	}
+	row, err := s.q.GetGitCredentialByID(ctx, id)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	cred := gitCredentialFromDB(row)
+	return &cred, nil
+}
+
+func (s *GitCredentialService) Create(ctx context.Context, params GitCredentialCreateParams, isAdmin bool) (*GitCredential, error) {
+	if !isAdmin {
+		return nil, errs.ErrForbidden
+	}
+
+	fields := make(map[string]string)
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		fields[fieldName] = "name is required"
+	}
+	if params.Kind != GitCredentialKindHTTPSToken && params.Kind != GitCredentialKindSSHKey {
+		fields["kind"] = "kind must be https_token or ssh_key"
+	}
+	if msg := validateHostPattern(params.HostPattern); msg != "" {
+		fields[fieldHostPattern] = msg
+	}
+	if msg := validateGitSecret(params.Kind, params.Secret); msg != "" {
+		fields[fieldSecret] = msg
+	}
+	if len(fields) > 0 {
+		return nil, errs.NewValidationError(fields)
+	}
+
+	sealed, err := s.seal(params.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var row db.GitCredential
+	err = s.runInTx(ctx, func(q GitCredentialQuerier) error {
+		row, err = q.CreateGitCredential(ctx, db.CreateGitCredentialParams{
+			Name:             name,
+			Kind:             params.Kind,
+			HostPattern:      optionalTrimmedPtr(params.HostPattern),
+			Username:         optionalTrimmedPtr(params.Username),
+			SecretCiphertext: sealed,
+		})
+		if err != nil {
+			return mapDBError(err)
+		}
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityGitCredential,
+			EntityID:   row.ID,
+			Action:     audit.ActionCreate,
+			After:      gitCredentialStateOf(row),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	cred := gitCredentialFromDB(row)
+	return &cred, nil
+}
+
+func (s *GitCredentialService) Update(ctx context.Context, id int64, params GitCredentialUpdateParams, isAdmin bool) (*GitCredential, error) {
+	if !isAdmin {
+		return nil, errs.ErrForbidden
+	}
+
+	current, err := s.q.GetGitCredentialByID(ctx, id)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	fields := make(map[string]string)
+	if params.Name != nil && strings.TrimSpace(*params.Name) == "" {
+		fields[fieldName] = "name must not be empty"
+	}
+	if msg := validateHostPattern(params.HostPattern); msg != "" {
+		fields[fieldHostPattern] = msg
+	}
+	if params.Secret != nil {
+		if msg := validateGitSecret(current.Kind, *params.Secret); msg != "" {
+			fields[fieldSecret] = msg
+		}
+	}
+	if len(fields) > 0 {
+		return nil, errs.NewValidationError(fields)
+	}
+
+	arg := db.UpdateGitCredentialParams{
+		ID:          id,
+		HostPattern: trimmedPtr(params.HostPattern),
+		Username:    trimmedPtr(params.Username),
+	}
+	if params.Name != nil {
+		name := strings.TrimSpace(*params.Name)
+		arg.Name = &name
+	}
+	if params.Secret != nil {
+		sealed, err := s.seal(*params.Secret)
+		if err != nil {
+			return nil, err
+		}
+		arg.SecretCiphertext = sealed
+	}
+
+	var row db.GitCredential
+	err = s.runInTx(ctx, func(q GitCredentialQuerier) error {
+		locked, err := q.LockGitCredential(ctx, id)
+		if err != nil {
+			return mapNotFound(err)
+		}
+		row, err = q.UpdateGitCredential(ctx, arg)
+		if err != nil {
+			if repository.IsNoRows(err) {
+				return errs.ErrNotFound
+			}
+			return mapDBError(err)
+		}
+		after := gitCredentialStateOf(row)
+		after.SecretReplaced = arg.SecretCiphertext != nil
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityGitCredential,
+			EntityID:   id,
+			Action:     audit.ActionUpdate,
+			Before:     gitCredentialStateOf(locked),
+			After:      after,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	cred := gitCredentialFromDB(row)
+	return &cred, nil
+}
+
+func (s *GitCredentialService) Delete(ctx context.Context, id int64, isAdmin bool) error {
+	if !isAdmin {
+		return errs.ErrForbidden
+	}
+	return s.runInTx(ctx, func(q GitCredentialQuerier) error {
+		locked, err := q.LockGitCredential(ctx, id)
+		if err != nil {
+			return mapNotFound(err)
+		}
+		if err := q.DeleteGitCredential(ctx, id); err != nil {
+			return err
+		}
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityGitCredential,
+			EntityID:   id,
+			Action:     audit.ActionDelete,
+			Before:     gitCredentialStateOf(locked),
+		})
+	})
+}
+
+func (s *GitCredentialService) runInTx(ctx context.Context, fn func(q GitCredentialQuerier) error) error {
+	if s.tx == nil {
+		return fn(s.q)
+	}
+	return s.tx.RunInTx(ctx, func(q *db.Queries) error { return fn(q) })
+}
+
+func (s *GitCredentialService) resolveGitCredential(ctx context.Context, id *int64, ref *gitRepoReference) (*gitCredential, error) {
+	if id != nil {
+		row, err := s.q.GetGitCredentialByID(ctx, *id)
+		if err != nil {
+			if repository.IsNoRows(err) {
+				return nil, errors.New("git credential not found")
+			}
+			return nil, fmt.Errorf("loading git credential: %w", err)
+		}
+		if !gitCredentialSupports(row.Kind, ref) {
+			return nil, fmt.Errorf("git credential %q (%s) cannot be used with this repository URL", row.Name, row.Kind)
+		}
+		return s.open(row)
+	}
+
+	if ref.Host == localRepoHost {
+		return nil, nil
+	}
+	rows, err := s.q.ListGitCredentialsWithHostPattern(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing git credentials: %w", err)
+	}
+	// Rows are ordered by pattern length, so the first match is the most
+	// specific one.
+	for _, row := range rows {
+		if row.HostPattern == nil || !gitCredentialSupports(row.Kind, ref) {
+			continue
+		}
+		if matchGitHostPattern(*row.HostPattern, ref) {
+			return s.open(row)
+		}
+	}
+	return nil, nil
+}
+
+func (s *GitCredentialService) seal(secret string) ([]byte, error) {
+	if s.box == nil {
+		return nil, errs.NewValidationError(map[string]string{fieldSecret: "GIT_CREDENTIALS_KEY is not configured"})
+	}
+	sealed, err := s.box.Seal([]byte(secret))
+	if err != nil {
+		return nil, fmt.Errorf("encrypting git credential: %w", err)
+	}
+	return sealed, nil
+}
+
+func (s *GitCredentialService) open(row db.GitCredential) (*gitCredential, error) {
+	if s.box == nil {
+		return nil, errors.New("git credentials are not configured on this server")
+	}
+	plain, err := s.box.Open(row.SecretCiphertext)
+	if err != nil {
+		return nil, fmt.Errorf("git credential %q cannot be decrypted", row.Name)
+	}
+	cred := &gitCredential{Kind: row.Kind, Secret: string(plain)}
+	if row.Username != nil {
+		cred.Username = *row.Username
+	}
+	return cred, nil
+}
+
+// matchGitHostPattern matches a glob against the repository host, or against
+// host/owner/repo when the pattern contains a slash (e.g. "github.com/acme/*").
+func matchGitHostPattern(pattern string, ref *gitRepoReference) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	target := strings.ToLower(ref.Host)
+	if strings.Contains(pattern, "/") {
+		target = target + "/" + strings.ToLower(ref.RepoPath)
+	}
+	ok, err := path.Match(pattern, target)
+	return err == nil && ok
+}
+
+func gitCredentialSupports(kind string, ref *gitRepoReference) bool {
+	switch kind {
+	case GitCredentialKindHTTPSToken:
+		return ref.Scheme == "https"
+	case GitCredentialKindSSHKey:
+		return ref.Scheme == "ssh"
+	default:
+		return false
+	}
+}
+
+func validateHostPattern(pattern *string) string {
+	if pattern == nil || strings.TrimSpace(*pattern) == "" {
+		return ""
+	}
+	if _, err := path.Match(strings.TrimSpace(*pattern), ""); err != nil {
+		return "host_pattern must be a valid glob"
+	}
+	return ""
+}
+
+func validateGitSecret(kind, secret string) string {
+	if strings.TrimSpace(secret) == "" {
+		return "secret is required"
+	}
+	if kind == GitCredentialKindSSHKey && !strings.Contains(secret, "PRIVATE KEY-----") {
+		return "secret must be a PEM or OpenSSH private key"
+	}
+	return ""
+}
+
+func optionalTrimmedPtr(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	return optionalTrimmedString(*value)
+}
+
+func trimmedPtr(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	return &trimmed
+}
+
+func gitCredentialFromDB(row db.GitCredential) GitCredential {
+	return GitCredential{
+		ID:          row.ID,
+		Name:        row.Name,
+		Kind:        row.Kind,
+		HostPattern: row.HostPattern,
+		Username:    row.Username,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -13,29 +14,40 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
 const (
 	localRepoHost  = "local"
 	defaultRepoDir = "repo"
+
+	gitIdentityFile = "git_identity"
+	redactedSecret  = "***"
 )
 
 type GitImportRequest struct {
-	RepoURL string
-	Ref     string
-	Subdir  string
+	RepoURL      string
+	Ref          string
+	Subdir       string
+	CredentialID *int64
+	// Anonymous fetches ignore stored credentials, including host-matched
+	// ones, for repositories named by non-admins. They only reach public
+	// https hosts; see publicGitCommand.
+	Anonymous bool
 }
 
 type GitSourceInput struct {
-	RepoURL string
-	Ref     string
-	Subdir  string
+	RepoURL      string
+	Ref          string
+	Subdir       string
+	CredentialID *int64
 }
 
 type gitRepoReference struct {
 	CloneURL string
 	RepoURL  string
+	Scheme   string
 	Host     string
 	RepoPath string
 	Owner    string
@@ -45,12 +57,13 @@ type gitRepoReference struct {
 }
 
 type fetchedGitRepo struct {
-	ZipBytes []byte
-	Commit   string
-	Ref      string
-	RepoURL  string
-	Subdir   string
-	Source   importSourceInfo
+	ZipBytes     []byte
+	Commit       string
+	Ref          string
+	RepoURL      string
+	Subdir       string
+	CredentialID *int64
+	Source       importSourceInfo
 }
 
 type gitArchiveFetcher interface {
@@ -59,13 +72,25 @@ type gitArchiveFetcher interface {
 
 type execGitArchiveFetcher struct {
 	maxArchiveBytes int64
+	credentials     gitCredentialResolver
 }
 
 func newExecGitArchiveFetcher(maxArchiveBytes int64) *execGitArchiveFetcher {
 	return &execGitArchiveFetcher{maxArchiveBytes: maxArchiveBytes}
 }
 
+// Fetch clones the repository and returns it as a zip. Errors are scrubbed of
+// credentials (configured secrets and URL userinfo) because they end up in API
+// responses, git_sync_error and logs.
 func (f *execGitArchiveFetcher) Fetch(ctx context.Context, req GitImportRequest) (*fetchedGitRepo, error) {
+	fetched, err := f.fetch(ctx, req)
+	if err != nil {
+		return nil, errors.New(redactURLCredentials(err.Error()))
+	}
+	return fetched, nil
+}
+
+func (f *execGitArchiveFetcher) fetch(ctx context.Context, req GitImportRequest) (*fetchedGitRepo, error) {
 	ref, err := normalizeGitImportRequest(req)
 	if err != nil {
 		return nil, err
@@ -77,8 +102,18 @@ func (f *execGitArchiveFetcher) Fetch(ctx context.Context, req GitImportRequest)
 	}
 	defer os.RemoveAll(tmpDir)
 
+	var git gitCommand
+	if req.Anonymous {
+		git, err = publicGitCommand(ctx, ref)
+	} else {
+		git, err = f.gitCommandFor(ctx, req.CredentialID, ref, tmpDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	cloneDir := filepath.Join(tmpDir, defaultRepoDir)
-	if _, err := runGitCommand(ctx, "", "clone", "--depth", "1", "--single-branch", ref.CloneURL, cloneDir); err != nil {
+	if _, err := git.run(ctx, "", "clone", "--depth", "1", "--single-branch", ref.CloneURL, cloneDir); err != nil {
 		return nil, fmt.Errorf("git clone: %w", err)
 	}
 	if err := ensureDirectorySizeLimit(cloneDir, effectiveGitSizeLimit(f.maxArchiveBytes)); err != nil {
@@ -87,10 +122,10 @@ func (f *execGitArchiveFetcher) Fetch(ctx context.Context, req GitImportRequest)
 
 	requestedRef := ref.Ref
 	if requestedRef != "" {
-		if _, err := runGitCommand(ctx, cloneDir, "fetch", "--depth", "1", "origin", requestedRef); err != nil {
+		if _, err := git.run(ctx, cloneDir, "fetch", "--depth", "1", "origin", requestedRef); err != nil {
 			return nil, fmt.Errorf("git fetch %q: %w", requestedRef, err)
 		}
-		if _, err := runGitCommand(ctx, cloneDir, "checkout", "--detach", "FETCH_HEAD"); err != nil {
+		if _, err := git.run(ctx, cloneDir, "checkout", "--detach", "FETCH_HEAD"); err != nil {
 			return nil, fmt.Errorf("git checkout %q: %w", requestedRef, err)
 		}
 	}
@@ -98,7 +133,7 @@ func (f *execGitArchiveFetcher) Fetch(ctx context.Context, req GitImportRequest)
 		return nil, err
 	}
 
-	commit, err := runGitCommand(ctx, cloneDir, "rev-parse", "HEAD")
+	commit, err := git.run(ctx, cloneDir, "rev-parse", "HEAD")
 	if err != nil {
 		return nil, fmt.Errorf("git rev-parse: %w", err)
 	}
@@ -122,21 +157,27 @@ func (f *execGitArchiveFetcher) Fetch(ctx context.Context, req GitImportRequest)
 	}
 
 	return &fetchedGitRepo{
-		ZipBytes: zipBytes,
-		Commit:   strings.TrimSpace(string(commit)),
-		Ref:      requestedRef,
-		RepoURL:  ref.RepoURL,
-		Subdir:   ref.Subdir,
-		Source: importSourceInfo{
-			Source: sourceGit,
-			Owner:  ref.Owner,
-			Repo:   ref.Repo,
-			Host:   ref.Host,
-			Path:   ref.RepoPath,
-		},
+		ZipBytes:     zipBytes,
+		Commit:       strings.TrimSpace(string(commit)),
+		Ref:          requestedRef,
+		RepoURL:      ref.RepoURL,
+		Subdir:       ref.Subdir,
+		CredentialID: req.CredentialID,
+		Source:       ref.sourceInfo(),
 	}, nil
 }
 
+// sourceInfo describes the repository to the import preview.
+func (r *gitRepoReference) sourceInfo() importSourceInfo {
+	return importSourceInfo{
+		Source: sourceGit,
+		Owner:  r.Owner,
+		Repo:   r.Repo,
+		Host:   r.Host,
+		Path:   r.RepoPath,
+	}
+}
+
 func normalizeGitSourceInput(input *GitSourceInput) (string, *string, *string, *string, error) {
 	if input == nil {
 		return sourceManual, nil, nil, nil, nil
@@ -162,6 +203,22 @@ func normalizeGitSourceInput(input *GitSourceInput) (string, *string, *string, *
 	return sourceGit, repoURLPtr, refPtr, subdirPtr, nil
 }
 
+// gitSourceCredentialID resolves the credential of a git source. An omitted
+// credential keeps the current one, so clients that only edit the repository
+// URL do not drop it; an explicit 0 clears it.
+func gitSourceCredentialID(sourceKind string, input *GitSourceInput, current *int64) *int64 {
+	if sourceKind != sourceGit || input == nil {
+		return nil
+	}
+	if input.CredentialID == nil {
+		return current
+	}
+	if *input.CredentialID == 0 {
+		return nil
+	}
+	return input.CredentialID
+}
+
 func normalizeGitImportRequest(req GitImportRequest) (*gitRepoReference, error) {
 	repoURL := strings.TrimSpace(req.RepoURL)
 	if repoURL == "" {
@@ -200,6 +257,7 @@ func parseGitRepoURL(repoURL string) (*gitRepoReference, error) {
 		return &gitRepoReference{
 			CloneURL: repoURL,
 			RepoURL:  repoURL,
+			Scheme:   "file",
 			Host:     localRepoHost,
 			RepoPath: filepath.Clean(repoURL),
 			Repo:     repo,
@@ -216,7 +274,7 @@ func parseGitRepoURL(repoURL string) (*gitRepoReference, error) {
 			if at >= 0 {
 				host = hostPart[at+1:]
 			}
-			return buildGitRepoReference(repoURL, repoURL, host, pathPart, "")
+			return buildGitRepoReference(repoURL, repoURL, "ssh", host, pathPart, "")
 		}
 	}
 
@@ -241,10 +299,10 @@ func parseGitRepoURL(repoURL string) (*gitRepoReference, error) {
 		repoPath = filepath.Clean(parsedURL.Path)
 	}
 
-	return buildGitRepoReference(cloneURL, repoURL, host, repoPath, ref)
+	return buildGitRepoReference(cloneURL, repoURL, parsedURL.Scheme, host, repoPath, ref)
 }
 
-func buildGitRepoReference(cloneURL, repoURL, host, repoPath, ref string) (*gitRepoReference, error) {
+func buildGitRepoReference(cloneURL, repoURL, scheme, host, repoPath, ref string) (*gitRepoReference, error) {
 	repoPath = strings.TrimSpace(repoPath)
 	repoPath = strings.TrimPrefix(repoPath, "/")
 	repoPath = strings.TrimSuffix(repoPath, "/")
@@ -266,6 +324,7 @@ func buildGitRepoReference(cloneURL, repoURL, host, repoPath, ref string) (*gitR
 	return &gitRepoReference{
 		CloneURL: cloneURL,
 		RepoURL:  strings.TrimSpace(repoURL),
+		Scheme:   scheme,
 		Host:     host,
 		RepoPath: repoPath,
 		Owner:    owner,
@@ -274,26 +333,154 @@ func buildGitRepoReference(cloneURL, repoURL, host, repoPath, ref string) (*gitR
 	}, nil
 }
 
-func runGitCommand(ctx context.Context, workdir string, args ...string) ([]byte, error) {
+// gitCommand carries the per-fetch environment (credentials) and the secret
+// values that must be scrubbed from git output before it is surfaced.
+type gitCommand struct {
+	env     []string
+	secrets []string
+}
+
+func (g gitCommand) run(ctx context.Context, workdir string, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, "git", args...)
 	if workdir != "" {
 		cmd.Dir = workdir
 	}
+	// Later entries win, so credential-specific settings override defaults.
 	cmd.Env = append(os.Environ(),
 		"GIT_TERMINAL_PROMPT=0",
 		"GIT_SSH_COMMAND=ssh -o BatchMode=yes",
 	)
+	cmd.Env = append(cmd.Env, g.env...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		msg := strings.TrimSpace(string(out))
 		if msg == "" {
 			msg = err.Error()
 		}
-		return nil, errors.New(msg)
+		return nil, errors.New(redactSecrets(msg, g.secrets))
 	}
 	return out, nil
 }
 
+// gitCommandFor resolves the credential for ref and turns it into git
+// environment. HTTPS tokens are sent as an Authorization header scoped to the
+// repository origin; SSH keys are written to a 0600 file inside tmpDir, which
+// the caller removes after the fetch.
+func (f *execGitArchiveFetcher) gitCommandFor(ctx context.Context, credentialID *int64, ref *gitRepoReference, tmpDir string) (gitCommand, error) {
+	if f.credentials == nil {
+		if credentialID != nil {
+			return gitCommand{}, errors.New("git credentials are not configured on this server")
+		}
+		return gitCommand{}, nil
+	}
+
+	cred, err := f.credentials.resolveGitCredential(ctx, credentialID, ref)
+	if err != nil || cred == nil {
+		return gitCommand{}, err
+	}
+
+	switch cred.Kind {
+	case GitCredentialKindHTTPSToken:
+		origin, err := url.Parse(ref.CloneURL)
+		if err != nil {
+			return gitCommand{}, fmt.Errorf("invalid git URL: %w", err)
+		}
+		username := cred.Username
+		if username == "" {
+			username = defaultGitTokenUsername
+		}
+		basic := base64.StdEncoding.EncodeToString([]byte(username + ":" + cred.Secret))
+		return gitCommand{
+			env: []string{
+				"GIT_CONFIG_COUNT=1",
+				fmt.Sprintf("GIT_CONFIG_KEY_0=http.%s://%s/.extraHeader", origin.Scheme, origin.Host),
+				"GIT_CONFIG_VALUE_0=Authorization: Basic " + basic,
+			},
+			secrets: []string{cred.Secret, basic},
+		}, nil
+	case GitCredentialKindSSHKey:
+		keyPath := filepath.Join(tmpDir, gitIdentityFile)
+		key := cred.Secret
+		if !strings.HasSuffix(key, "\n") {
+			key += "\n"
+		}
+		if err := os.WriteFile(keyPath, []byte(key), 0o600); err != nil {
+			return gitCommand{}, fmt.Errorf("write ssh key: %w", err)
+		}
+		return gitCommand{
+			env: []string{
+				"GIT_SSH_COMMAND=ssh -i '" + keyPath + "' -o IdentitiesOnly=yes -o BatchMode=yes -o StrictHostKeyChecking=accept-new",
+			},
+			secrets: []string{cred.Secret},
+		}, nil
+	default:
+		return gitCommand{}, fmt.Errorf("unsupported git credential kind %q", cred.Kind)
+	}
+}
+
+// publicGitCommand confines an anonymous fetch to a public https host, as URL
+// downloads of archives are. The host is resolved and checked once and git is
+// pinned to the checked addresses, so a second lookup cannot point it at an
+// internal address; redirects and other protocols are refused.
+func publicGitCommand(ctx context.Context, ref *gitRepoReference) (gitCommand, error) {
+	origin, err := url.Parse(ref.CloneURL)
+	if err != nil {
+		return gitCommand{}, fmt.Errorf("invalid git URL: %w", err)
+	}
+	if origin.Scheme != "https" {
+		return gitCommand{}, errors.New("only https repositories can be fetched anonymously")
+	}
+	host, port := origin.Hostname(), origin.Port()
+	if port == "" {
+		port = "443"
+	}
+	addrs, err := resolvePublicHost(ctx, host)
+	if err != nil {
+		return gitCommand{}, err
+	}
+	pinned := make([]string, len(addrs))
+	for i, addr := range addrs {
+		pinned[i] = addr.IP.String()
+		if addr.IP.To4() == nil {
+			pinned[i] = "[" + pinned[i] + "]"
+		}
+	}
+	return gitCommand{
+		env: []string{
+			"GIT_ALLOW_PROTOCOL=https",
+			"GIT_CONFIG_COUNT=2",
+			"GIT_CONFIG_KEY_0=http.curloptResolve",
+			"GIT_CONFIG_VALUE_0=" + host + ":" + port + ":" + strings.Join(pinned, ","),
+			"GIT_CONFIG_KEY_1=http.followRedirects",
+			"GIT_CONFIG_VALUE_1=false",
+		},
+	}, nil
+}
+
+var (
+	httpURLUserinfoRe  = regexp.MustCompile(`(?i)(https?://)[^/\s@]+@`)
+	otherURLPasswordRe = regexp.MustCompile(`(?i)([a-z][a-z0-9+.-]*://[^/\s:@]*):[^/\s@]+@`)
+)
+
+// redactURLCredentials masks userinfo embedded in URLs: the whole userinfo for
+// http(s), where tokens are commonly passed as the user name, and the password
+// part for other schemes so "ssh://git@host" stays readable.
+func redactURLCredentials(msg string) string {
+	msg = httpURLUserinfoRe.ReplaceAllString(msg, "${1}"+redactedSecret+"@")
+	return otherURLPasswordRe.ReplaceAllString(msg, "${1}:"+redactedSecret+"@")
+}
+
+func redactSecrets(msg string, secrets []string) string {
+	for _, secret := range secrets {
+		secret = strings.TrimSpace(secret)
+		if secret == "" {
+			continue
+		}
+		msg = strings.ReplaceAll(msg, secret, redactedSecret)
+	}
+	return redactURLCredentials(msg)
+}
+
 func zipDirectory(rootDir, rootName string, maxArchiveBytes int64) ([]byte, error) {
 	// Open a root-scoped handle so file reads inside the walk cannot be
 	// redirected outside the repository via a symlink swap (TOCTOU).
@@ -50,9 +50,15 @@ type BundleMetadata struct {
 	Author            string
 	Copyright         string
 	License           string
-	Ctf01dTraining    json.RawMessage
-	Manifest          *ServiceManifest
-	CheckerScriptOK   bool
+	// Readme is the full README markdown of the bundle.
+	Readme         string
+	Ctf01dTraining json.RawMessage
+	Manifest       *ServiceManifest
+	// ManifestValidation holds schema diagnostics for the manifest, if any.
+	ManifestValidation *ManifestValidation
+	// Compose is the lint result of the service's compose file, if any.
+	Compose         *ComposeAnalysis
+	CheckerScriptOK bool
 }
 
 func safeRelPath(rel string) string {
@@ -446,6 +452,7 @@ func ExtractMetadata(bundleZipBytes []byte) (*BundleMetadata, error) {
 	licenseText := readLicenseFromZip(r)
 	trainingJSON := readTrainingJSONFromZip(r)
 	manifestYAML := readServiceManifestFromZip(r)
+	composeName, composeYAML := readComposeFromZip(r)
 
 	var training map[string]any
 	if trainingJSON != nil {
@@ -454,12 +461,26 @@ func ExtractMetadata(bundleZipBytes []byte) (*BundleMetadata, error) {
 
 	meta := &BundleMetadata{}
 	if manifestYAML != nil {
-		manifest, err := parseServiceManifest(manifestYAML)
+		validation, err := ValidateServiceManifest(manifestYAML)
 		if err != nil {
 			return nil, err
 		}
-		meta.Manifest = manifest
-		meta.CheckerScriptOK = checkerScriptExists(r, manifest.ScriptPath)
+		meta.ManifestValidation = validation
+
+		// A manifest that cannot be parsed is reported through the schema
+		// diagnostics so the preview can point at the offending line.
+		manifest, err := parseServiceManifest(manifestYAML)
+		switch {
+		case err == nil:
+			meta.Manifest = manifest
+			meta.CheckerScriptOK = checkerScriptExists(r, manifest.ScriptPath)
+		case validation.Valid:
+			return nil, err
+		}
+	}
+
+	if composeYAML != nil {
+		meta.Compose = analyzeCompose(composeName, composeYAML)
 	}
 
 	meta.Ctf01dTraining = mergeTrainingMetadata(training, meta.Manifest)
@@ -484,6 +505,9 @@ func ExtractMetadata(bundleZipBytes []byte) (*BundleMetadata, error) {
 		}
 	}
 
+	if readme != nil {
+		meta.Readme = string(readme)
+	}
 	if meta.Name == "" && readme != nil {
 		meta.Name = extractTitle(readme)
 	}
@@ -513,6 +537,10 @@ func BuildBundle(zipBytes []byte) ([]byte, error) {
 	if len(zipBytes) == 0 {
 		return nil, errors.New("empty zip")
 	}
+	zipBytes, err := normalizeArchive(zipBytes)
+	if err != nil {
+		return nil, err
+	}
 
 	srcReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
 	if err != nil {
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"archive/zip"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxComposePortRange bounds how many ports a single "8000-8100:80" style
+// mapping may expand to.
+const maxComposePortRange = 1000
+
+const composeNetworkHost = "host"
+
+// ComposeAnalysis is the result of linting the bundle's compose file.
+type ComposeAnalysis struct {
+	File           string
+	ParseError     string
+	Services       []string
+	PublishedPorts []int
+	HostNetwork    bool
+	Findings       []ComposeFinding
+}
+
+// ComposeFinding is a single lint problem of a compose service.
+type ComposeFinding struct {
+	Service string
+	Message string
+}
+
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string `yaml:"image"`
+	Ports       []any  `yaml:"ports"`
+	Privileged  any    `yaml:"privileged"`
+	NetworkMode string `yaml:"network_mode"`
+	Restart     string `yaml:"restart"`
+	Deploy      struct {
+		RestartPolicy map[string]any `yaml:"restart_policy"`
+	} `yaml:"deploy"`
+}
+
+func readComposeFromZip(zr *zip.Reader) (string, []byte) {
+	for _, name := range composeCandidates {
+		if data := readEntryFromZip(zr, "service/"+name); data != nil {
+			return name, data
+		}
+	}
+	return "", nil
+}
+
+// analyzeCompose parses a compose file and reports published ports together
+// with settings that do not belong in a training service: privileged
+// containers, host networking, floating latest images and missing restart
+// policies.
+func analyzeCompose(name string, data []byte) *ComposeAnalysis {
+	analysis := &ComposeAnalysis{File: name}
+
+	var file composeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		analysis.ParseError = strings.TrimPrefix(err.Error(), "yaml: ")
+		return analysis
+	}
+	if len(file.Services) == 0 {
+		analysis.ParseError = "no services are defined"
+		return analysis
+	}
+
+	for svcName := range file.Services {
+		analysis.Services = append(analysis.Services, svcName)
+	}
+	sort.Strings(analysis.Services)
+
+	published := make(map[int]struct{})
+	for _, svcName := range analysis.Services {
+		svc := file.Services[svcName]
+		analysis.lintService(svcName, svc)
+		for _, spec := range svc.Ports {
+			ports, err := composePublishedPorts(spec)
+			if err != nil {
+				analysis.addFinding(svcName, err.Error())
+				continue
+			}
+			for _, port := range ports {
+				published[port] = struct{}{}
+			}
+		}
+	}
+
+	for port := range published {
+		analysis.PublishedPorts = append(analysis.PublishedPorts, port)
+	}
+	sort.Ints(analysis.PublishedPorts)
+	return analysis
+}
+
+func (a *ComposeAnalysis) addFinding(service, message string) {
+	a.Findings = append(a.Findings, ComposeFinding{Service: service, Message: message})
+}
+
+func (a *ComposeAnalysis) lintService(name string, svc composeService) {
+	if privileged, ok := manifestBool(svc.Privileged); ok && privileged {
+		a.addFinding(name, "runs a privileged container")
+	}
+	if strings.EqualFold(strings.TrimSpace(svc.NetworkMode), composeNetworkHost) {
+		a.HostNetwork = true
+		a.addFinding(name, "uses host networking")
+	}
+	if msg := composeImageTagProblem(svc.Image); msg != "" {
+		a.addFinding(name, msg)
+	}
+	if strings.TrimSpace(svc.Restart) == "" && svc.Deploy.RestartPolicy == nil {
+		a.addFinding(name, "has no restart policy")
+	}
+}
+
+// composeImageTagProblem reports images that float with the registry. Images
+// pinned by digest are fine; locally built services usually have no image.
+func composeImageTagProblem(image string) string {
+	image = strings.TrimSpace(image)
+	if image == "" || strings.Contains(image, "@") || strings.Contains(image, "$") {
+		return ""
+	}
+	repo := image
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		repo = image[i+1:]
+	}
+	i := strings.LastIndex(repo, ":")
+	switch {
+	case i < 0:
+		return "image " + image + " has no tag and resolves to latest"
+	case repo[i+1:] == "latest":
+		return "image " + image + " uses the latest tag"
+	default:
+		return ""
+	}
+}
+
+// composePublishedPorts returns the host ports a compose port entry publishes
+// on a non-loopback address. Container-only entries publish nothing.
+func composePublishedPorts(spec any) ([]int, error) {
+	switch typed := spec.(type) {
+	case int:
+		return nil, nil
+	case string:
+		return parseComposePortString(typed)
+	case map[string]any:
+		if isLoopbackHost(fmt.Sprint(typed["host_ip"])) {
+			return nil, nil
+		}
+		published, ok := typed["published"]
+		if !ok {
+			return nil, nil
+		}
+		return parsePortRange(fmt.Sprint(published))
+	default:
+		return nil, fmt.Errorf("has an unsupported port entry %v", spec)
+	}
+}
+
+func parseComposePortString(spec string) ([]int, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.Contains(spec, "$") {
+		return nil, fmt.Errorf("has port %q with a variable that cannot be checked", spec)
+	}
+	if i := strings.Index(spec, "/"); i >= 0 {
+		spec = spec[:i]
+	}
+
+	hostIP := ""
+	if strings.HasPrefix(spec, "[") {
+		end := strings.Index(spec, "]:")
+		if end < 0 {
+			return nil, fmt.Errorf("has an invalid port %q", spec)
+		}
+		hostIP = spec[1:end]
+		spec = spec[end+2:]
+	}
+
+	parts := strings.Split(spec, ":")
+	var host string
+	switch len(parts) {
+	case 1:
+		return nil, nil
+	case 2:
+		host = parts[0]
+	case 3:
+		hostIP, host = parts[0], parts[1]
+	default:
+		return nil, fmt.Errorf("has an invalid port %q", spec)
+	}
+	if host == "" || isLoopbackHost(hostIP) {
+		return nil, nil
+	}
+	return parsePortRange(host)
+}
+
+func parsePortRange(value string) ([]int, error) {
+	value = strings.TrimSpace(value)
+	lo, hi, isRange := strings.Cut(value, "-")
+	start, err := strconv.Atoi(lo)
+	if err != nil {
+		return nil, fmt.Errorf("has an invalid port %q", value)
+	}
+	end := start
+	if isRange {
+		if end, err = strconv.Atoi(hi); err != nil {
+			return nil, fmt.Errorf("has an invalid port %q", value)
+		}
+	}
+	if start < 1 || end > 65535 || end < start {
+		return nil, fmt.Errorf("has an invalid port %q", value)
+	}
+	if end-start >= maxComposePortRange {
+		return nil, fmt.Errorf("has port range %q that is too large to check", value)
+	}
+	ports := make([]int, 0, end-start+1)
+	for port := start; port <= end; port++ {
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+func isLoopbackHost(host string) bool {
+	switch strings.TrimSpace(host) {
+	case "127.0.0.1", "::1", "localhost":
+		return true
+	}
+	return false
+}
+
+type declaredPorts struct {
+	source string
+	ports  []int
+}
+
+// addComposeRequirements records the compose lint findings and compares the
+// published ports with the manifest and the ports already registered for the
+// service.
+func addComposeRequirements(preview *ImportPreview, meta *BundleMetadata, registered []int32) {
+	if meta == nil || meta.Compose == nil {
+		return
+	}
+	compose := meta.Compose
+	title := "docker compose"
+	if compose.ParseError != "" {
+		preview.addRequirement("compose_parse", title, "warning", compose.File+" could not be analyzed: "+compose.ParseError)
+		return
+	}
+
+	if len(compose.Findings) == 0 {
+		preview.addRequirement("compose_lint", title, "ok", "no privileged containers, host networking, latest images or missing restart policies")
+	}
+	for i, finding := range compose.Findings {
+		preview.addRequirement(fmt.Sprintf("compose_lint_%d", i+1), title, "warning", "service "+finding.Service+" "+finding.Message)
+	}
+
+	if compose.HostNetwork && len(compose.PublishedPorts) == 0 {
+		return
+	}
+
+	var declared []declaredPorts
+	if meta.Manifest != nil && len(meta.Manifest.Ports) > 0 {
+		declared = append(declared, declaredPorts{source: serviceManifestYAML, ports: meta.Manifest.Ports})
+	}
+	if len(registered) > 0 {
+		ports := make([]int, len(registered))
+		for i, port := range registered {
+			ports[i] = int(port)
+		}
+		declared = append(declared, declaredPorts{source: "service ports", ports: ports})
+	}
+
+	if len(declared) == 0 {
+		if len(compose.PublishedPorts) > 0 {
+			preview.addRequirement("compose_ports", "published ports", "ok", "compose publishes "+formatPorts(compose.PublishedPorts))
+		}
+		return
+	}
+
+	var mismatches []string
+	for _, d := range declared {
+		missing, extra := diffPorts(d.ports, compose.PublishedPorts)
+		if len(missing) > 0 {
+			mismatches = append(mismatches, d.source+" declares "+formatPorts(missing)+" which compose does not publish")
+		}
+		if len(extra) > 0 {
+			mismatches = append(mismatches, "compose publishes "+formatPorts(extra)+" which "+d.source+" does not declare")
+		}
+	}
+	if len(mismatches) == 0 {
+		preview.addRequirement("compose_ports", "published ports", "ok", "published ports match: "+formatPorts(compose.PublishedPorts))
+		return
+	}
+	preview.addRequirement("compose_ports", "published ports", "warning", strings.Join(mismatches, "; "))
+}
+
+// diffPorts returns the ports of want missing from got and those of got not
+// in want.
+func diffPorts(want, got []int) (missing, extra []int) {
+	wantSet := make(map[int]bool, len(want))
+	for _, port := range want {
+		wantSet[port] = true
+	}
+	gotSet := make(map[int]bool, len(got))
+	for _, port := range got {
+		gotSet[port] = true
+		if !wantSet[port] {
+			extra = append(extra, port)
+		}
+	}
+	for _, port := range want {
+		if !gotSet[port] {
+			missing = append(missing, port)
+		}
+	}
+	sort.Ints(missing)
+	sort.Ints(extra)
+	return missing, extra
+}
+
+func formatPorts(ports []int) string {
+	parts := make([]string, len(ports))
+	for i, port := range ports {
+		parts[i] = strconv.Itoa(port)
+	}
+	return strings.Join(parts, ", ")
+}
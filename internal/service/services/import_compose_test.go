@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func TestAnalyzeCompose_PublishedPorts(t *testing.T) {
+	analysis := analyzeCompose("docker-compose.yml", []byte(`
+services:
+  web:
+    build: .
+    restart: unless-stopped
+    ports:
+      - "8080:80"
+      - "0.0.0.0:8443:443/tcp"
+      - "9000-9002:9000-9002"
+      - "127.0.0.1:5432:5432"
+      - "3000"
+      - 4000
+  db:
+    image: postgres:16
+    deploy:
+      restart_policy:
+        condition: on-failure
+    ports:
+      - target: 6379
+        published: "6379"
+      - target: 22
+        published: 2222
+        host_ip: 127.0.0.1
+`))
+	if analysis.ParseError != "" {
+		t.Fatalf("ParseError = %q", analysis.ParseError)
+	}
+	want := []int{6379, 8080, 8443, 9000, 9001, 9002}
+	if !reflect.DeepEqual(analysis.PublishedPorts, want) {
+		t.Errorf("PublishedPorts = %v, want %v", analysis.PublishedPorts, want)
+	}
+	if len(analysis.Findings) != 0 {
+		t.Errorf("unexpected findings: %#v", analysis.Findings)
+	}
+}
+
+func TestAnalyzeCompose_LintFindings(t *testing.T) {
+	analysis := analyzeCompose("compose.yml", []byte(`
+services:
+  app:
+    image: example/app:latest
+    privileged: true
+  cache:
+    image: redis
+    network_mode: host
+    restart: always
+  pinned:
+    image: registry.local:5000/app@sha256:abcdef
+    restart: always
+`))
+	var messages []string
+	for _, f := range analysis.Findings {
+		messages = append(messages, f.Service+" "+f.Message)
+	}
+	want := []string{
+		"app runs a privileged container",
+		"app image example/app:latest uses the latest tag",
+		"app has no restart policy",
+		"cache uses host networking",
+		"cache image redis has no tag and resolves to latest",
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("findings = %#v, want %#v", messages, want)
+	}
+	if !analysis.HostNetwork {
+		t.Error("HostNetwork = false, want true")
+	}
+}
+
+func TestAnalyzeCompose_InvalidFile(t *testing.T) {
+	if a := analyzeCompose("docker-compose.yml", []byte("services: [")); a.ParseError == "" {
+		t.Error("expected parse error for malformed YAML")
+	}
+	if a := analyzeCompose("docker-compose.yml", []byte("services: {}")); a.ParseError == "" {
+		t.Error("expected parse error for compose without services")
+	}
+}
+
+func TestAddComposeRequirements_PortMismatch(t *testing.T) {
+	meta := &BundleMetadata{
+		Manifest: &ServiceManifest{Ports: []int{8080, 9090}},
+		Compose:  &ComposeAnalysis{File: "docker-compose.yml", PublishedPorts: []int{8080, 8081}},
+	}
+	preview := &ImportPreview{}
+	addComposeRequirements(preview, meta, []int32{8080, 8081})
+
+	var ports *ImportValidationItem
+	for i := range preview.Requirements {
+		if preview.Requirements[i].ID == "compose_ports" {
+			ports = &preview.Requirements[i]
+		}
+	}
+	if ports == nil {
+		t.Fatalf("no compose_ports requirement in %#v", preview.Requirements)
+	}
+	if ports.Status != "warning" {
+		t.Errorf("Status = %q, want warning", ports.Status)
+	}
+	for _, want := range []string{"declares 9090 which compose does not publish", "compose publishes 8081 which " + serviceManifestYAML} {
+		if !strings.Contains(ports.Message, want) {
+			t.Errorf("message %q does not contain %q", ports.Message, want)
+		}
+	}
+	if strings.Contains(ports.Message, "service ports") {
+		t.Errorf("registered ports match compose, got %q", ports.Message)
+	}
+}
+
+func TestPreviewFromGit_ComposeChecksAgainstExistingService(t *testing.T) {
+	repoZip := createZip(map[string]string{
+		"2026-cybersibir-service-notes/README.md":           "# Notes",
+		"2026-cybersibir-service-notes/.ctf01d-service.yml": "ports: [8080]\nchecker-config-v1:\n  id: notes\n  script_path: ./checker.py\n",
+		"2026-cybersibir-service-notes/vuln-service/docker-compose.yml": "services:\n" +
+			"  notes:\n" +
+			"    build: .\n" +
+			"    privileged: true\n" +
+			"    ports: [\"8080:8080\"]\n",
+		"2026-cybersibir-service-notes/checker_notes/checker.py": "exit(101)",
+		"2026-cybersibir-service-notes/writeups/README.md":       "writeup",
+		"2026-cybersibir-service-notes/exploits/poc.py":          "exploit",
+	})
+
+	q := newMockImportQuerier()
+	q.services[7] = &db.Service{ID: 7, Name: "notes", Ports: []int32{8080, 8443}}
+	q.byName["notes"] = 7
+	svc := NewImportService(q, newMemStorage(), 50*1024*1024)
+	svc.gitFetcher = fakeGitFetcher{
+		fetched: &fetchedGitRepo{
+			ZipBytes: repoZip,
+			Commit:   strings.Repeat("c", 40),
+			Ref:      "main",
+			Source:   importSourceInfo{Source: sourceGit, Owner: "org", Repo: "2026-cybersibir-service-notes"},
+		},
+	}
+
+	preview, err := svc.PreviewFromGit(context.Background(), GitImportRequest{RepoURL: "https://github.com/org/2026-cybersibir-service-notes"}, true)
+	if err != nil {
+		t.Fatalf("PreviewFromGit: %v", err)
+	}
+	if !preview.Valid {
+		t.Fatalf("compose findings must not block the import: %#v", preview.Requirements)
+	}
+
+	byID := make(map[string]ImportValidationItem, len(preview.Requirements))
+	for _, item := range preview.Requirements {
+		byID[item.ID] = item
+	}
+	if item := byID["compose_lint_1"]; item.Status != "warning" || !strings.Contains(item.Message, "privileged") {
+		t.Errorf("compose_lint_1 = %+v", item)
+	}
+	if item := byID["compose_ports"]; item.Status != "warning" || !strings.Contains(item.Message, "service ports declares 8443") {
+		t.Errorf("compose_ports = %+v", item)
+	}
+}
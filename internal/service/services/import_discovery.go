@@ -0,0 +1,324 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
+)
+
+const fieldSubdirs = "subdirs"
+
+// ImportDiscovery lists the services found in a monorepo at one commit.
+type ImportDiscovery struct {
+	Commit   string
+	Services []*ImportPreview
+}
+
+type discoveredImport struct {
+	Subdir   string
+	Prepared *preparedImport
+}
+
+// DiscoverFromGit scans a repository for service directories and previews
+// each of them as a separate import. A directory is a service when it holds a
+// service manifest or both a service and a checker directory.
+func (s *ImportService) DiscoverFromGit(ctx context.Context, req GitImportRequest, isAdmin bool) (*ImportDiscovery, error) {
+	if !isAdmin {
+		return nil, errs.ErrForbidden
+	}
+
+	fetched, err := s.gitFetcher.Fetch(ctx, req)
+	if err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldRepoURL: err.Error()})
+	}
+
+	found, err := s.discover(ctx, fetched, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	discovery := &ImportDiscovery{
+		Commit:   fetched.Commit,
+		Services: make([]*ImportPreview, len(found)),
+	}
+	for i, d := range found {
+		discovery.Services[i] = d.Prepared.Preview
+	}
+	return discovery, nil
+}
+
+// ImportDiscoveredFromGit imports the selected service directories of a
+// repository. Either all of them are imported or none: database changes run
+// in one transaction and stored archives are removed again on failure.
+func (s *ImportService) ImportDiscoveredFromGit(ctx context.Context, req GitImportRequest, subdirs []string, isAdmin bool) ([]*ImportResult, error) {
+	if !isAdmin {
+		return nil, errs.ErrForbidden
+	}
+	if len(subdirs) == 0 {
+		return nil, errs.NewValidationError(map[string]string{fieldSubdirs: "select at least one service directory"})
+	}
+
+	fetched, err := s.gitFetcher.Fetch(ctx, req)
+	if err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldRepoURL: err.Error()})
+	}
+
+	found, err := s.discover(ctx, fetched, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectDiscovered(found, subdirs)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &recordingStorage{Storage: s.store}
+	var results []*ImportResult
+	err = s.runInTx(ctx, func(q *db.Queries) error {
+		scoped := s.scoped(q, store)
+		results = results[:0]
+		for _, d := range selected {
+			repo := *fetched
+			repo.Subdir = d.Prepared.Preview.Subdir
+			result, err := scoped.importPrepared(ctx, &repo, d.Prepared, isAdmin)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		for _, key := range store.saved {
+			s.versions.discard(ctx, key)
+		}
+		return nil, err
+	}
+
+	for _, result := range results {
+		s.pruneImported(ctx, result.Service)
+	}
+	return results, nil
+}
+
+func (s *ImportService) discover(ctx context.Context, fetched *fetchedGitRepo, isAdmin bool) ([]discoveredImport, error) {
+	zr, err := zip.NewReader(bytes.NewReader(fetched.ZipBytes), int64(len(fetched.ZipBytes)))
+	if err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldArchive: fmt.Sprintf("reading zip: %v", err)})
+	}
+
+	rootPrefix := detectRootPrefix(zr)
+	dirs := discoverServiceDirs(zr, rootPrefix)
+	if len(dirs) == 0 {
+		return nil, errs.NewValidationError(map[string]string{fieldRepoURL: "no service directories found in repository"})
+	}
+
+	found := make([]discoveredImport, 0, len(dirs))
+	names := make(map[string]int, len(dirs))
+	for _, dir := range dirs {
+		zipBytes := fetched.ZipBytes
+		if dir != "" {
+			zipBytes, err = subtreeZip(zr, rootPrefix+dir+"/", path.Base(dir))
+			if err != nil {
+				return nil, errs.NewValidationError(map[string]string{fieldArchive: fmt.Sprintf("%s: %v", dir, err)})
+			}
+		}
+
+		prepared, err := s.prepareImport(ctx, zipBytes, fetched.Source, isAdmin, nil)
+		if err != nil {
+			return nil, err
+		}
+		prepared.Preview.Subdir = path.Join(fetched.Subdir, dir)
+		found = append(found, discoveredImport{Subdir: prepared.Preview.Subdir, Prepared: prepared})
+		if prepared.Name != "" {
+			names[prepared.Name]++
+		}
+	}
+
+	for _, d := range found {
+		if names[d.Prepared.Name] > 1 {
+			d.Prepared.Preview.addRequirement("duplicate_in_repository", "Repository services", "error",
+				"another directory in this repository is imported as "+d.Prepared.Name)
+			finalizeImportPreview(d.Prepared.Preview)
+		}
+	}
+	return found, nil
+}
+
+// discoverServiceDirs returns the outermost directories, relative to
+// rootPrefix, that look like a service. Directories nested in a service are
+// part of it and are not reported separately.
+func discoverServiceDirs(zr *zip.Reader, rootPrefix string) []string {
+	children := make(map[string]map[string]bool)
+	manifests := make(map[string]bool)
+	for _, f := range zr.File {
+		name := strings.TrimPrefix(f.Name, "/")
+		if !strings.HasPrefix(name, rootPrefix) {
+			continue
+		}
+		rel := safeRelPath(strings.TrimPrefix(name, rootPrefix))
+		if rel == "" {
+			continue
+		}
+
+		parts := strings.Split(rel, "/")
+		dirParts := parts
+		if !f.FileInfo().IsDir() {
+			dirParts = parts[:len(parts)-1]
+			base := parts[len(parts)-1]
+			for _, candidate := range serviceManifestCandidates {
+				if base == candidate {
+					manifests[strings.Join(dirParts, "/")] = true
+				}
+			}
+		}
+		for i := range dirParts {
+			parent := strings.Join(dirParts[:i], "/")
+			if children[parent] == nil {
+				children[parent] = make(map[string]bool)
+			}
+			children[parent][dirParts[i]] = true
+		}
+	}
+
+	var candidates []string
+	for dir := range children {
+		if manifests[dir] || looksLikeServiceDir(children[dir]) {
+			candidates = append(candidates, dir)
+		}
+	}
+	for dir := range manifests {
+		if children[dir] == nil {
+			candidates = append(candidates, dir)
+		}
+	}
+	sort.Strings(candidates)
+
+	var dirs []string
+	for _, dir := range candidates {
+		nested := false
+		for _, kept := range dirs {
+			if kept == "" || strings.HasPrefix(dir, kept+"/") {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func looksLikeServiceDir(children map[string]bool) bool {
+	hasService := children[kindService] || children[kindVulnService]
+	hasChecker := children[kindChecker]
+	for name := range children {
+		if strings.HasPrefix(name, checkerDirPrefix) {
+			hasChecker = true
+		}
+	}
+	return hasService && hasChecker
+}
+
+// subtreeZip copies the entries under fromPrefix into a new archive rooted at
+// rootName, so a monorepo directory can be imported like a standalone repo.
+func subtreeZip(zr *zip.Reader, fromPrefix, rootName string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	var totalBytes int64
+	fileCount := 0
+	if _, err := copyTree(zr, w, fromPrefix, rootName+"/", nil, &totalBytes, &fileCount, nil); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func selectDiscovered(found []discoveredImport, subdirs []string) ([]discoveredImport, error) {
+	bySubdir := make(map[string]discoveredImport, len(found))
+	for _, d := range found {
+		bySubdir[d.Subdir] = d
+	}
+
+	selected := make([]discoveredImport, 0, len(subdirs))
+	seen := make(map[string]bool, len(subdirs))
+	for _, subdir := range subdirs {
+		clean := strings.Trim(strings.TrimSpace(subdir), "/")
+		d, ok := bySubdir[clean]
+		if !ok {
+			return nil, errs.NewValidationError(map[string]string{fieldSubdirs: fmt.Sprintf("%q is not a discovered service directory", subdir)})
+		}
+		if seen[clean] {
+			continue
+		}
+		seen[clean] = true
+		if err := validatePreparedImport(d.Prepared.Preview, fieldSubdirs); err != nil {
+			return nil, err
+		}
+		selected = append(selected, d)
+	}
+	return selected, nil
+}
+
+func (s *ImportService) runInTx(ctx context.Context, fn func(q *db.Queries) error) error {
+	if s.tx == nil {
+		return fn(nil)
+	}
+	return s.tx.RunInTx(ctx, fn)
+}
+
+// scoped returns a copy of the service that works on the transaction's
+// queries and records stored objects. Pruning is postponed until commit so a
+// rollback never loses archives that are still referenced, and the copy runs
+// everything in the caller's transaction instead of starting its own.
+func (s *ImportService) scoped(q *db.Queries, store storage.Storage) *ImportService {
+	scoped := *s
+	scoped.tx = nil
+	if q != nil {
+		scoped.q = q
+		scoped.versions.q = q
+	}
+	scoped.store = store
+	scoped.versions.store = store
+	scoped.versions.retention = 0
+	return &scoped
+}
+
+func (s *ImportService) pruneImported(ctx context.Context, svc *ServiceModel) {
+	if svc == nil {
+		return
+	}
+	if svc.ServiceLocalPath != nil {
+		s.versions.prune(ctx, svc.ID, kindService, *svc.ServiceLocalPath)
+	}
+	if svc.CheckerLocalPath != nil {
+		s.versions.prune(ctx, svc.ID, kindChecker, *svc.CheckerLocalPath)
+	}
+}
+
+// recordingStorage remembers the keys saved through it.
+type recordingStorage struct {
+	storage.Storage
+	saved []string
+}
+
+func (r *recordingStorage) Save(ctx context.Context, key string, rd io.Reader) (storage.FileInfo, error) {
+	info, err := r.Storage.Save(ctx, key, rd)
+	if err == nil {
+		r.saved = append(r.saved, key)
+	}
+	return info, err
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func monorepoFiles(root string, services map[string]string) map[string]string {
+	files := map[string]string{
+		root + "/README.md": "# Training\n",
+	}
+	for dir, id := range services {
+		prefix := root + "/" + dir + "/"
+		files[prefix+"README.md"] = "# " + id + "\n\nService " + id
+		files[prefix+".ctf01d-service.yml"] = fmt.Sprintf("checker-config-v0.5.2:\n  id: %s\n  service_name: %s\n  script_path: ./checker.py\n", id, id)
+		files[prefix+"vuln-service/docker-compose.yml"] = "services: {}\n"
+		files[prefix+"vuln-service/app.py"] = "print('service')\n"
+		files[prefix+"checker_"+id+"/checker.py"] = "exit(101)\n"
+		files[prefix+"writeups/README.md"] = "writeup\n"
+		files[prefix+"exploits/poc.py"] = "exploit\n"
+	}
+	return files
+}
+
+func newDiscoveryService(q ImportQuerier, files map[string]string) (*ImportService, *memStorage) {
+	store := newMemStorage()
+	svc := NewImportService(q, store, 50*1024*1024)
+	svc.gitFetcher = fakeGitFetcher{
+		fetched: &fetchedGitRepo{
+			ZipBytes: createZip(files),
+			Commit:   strings.Repeat("c", 40),
+			RepoURL:  "https://example.com/team/training.git",
+			Source:   importSourceInfo{Source: sourceGit, Host: "example.com", Owner: "team", Repo: "training", Path: "team/training"},
+		},
+	}
+	return svc, store
+}
+
+func TestDiscoverServiceDirs(t *testing.T) {
+	files := monorepoFiles("training", map[string]string{
+		"services/bank":  "bank",
+		"services/notes": "notes",
+	})
+	files["training/legacy/shop/service/app.py"] = "print('shop')\n"
+	files["training/legacy/shop/checker/checker.py"] = "exit(101)\n"
+	files["training/legacy/shop/service/checker/nested.py"] = "ignored\n"
+	files["training/tools/service/run.sh"] = "no checker here\n"
+
+	zr := mustZipReader(t, createZip(files))
+	got := discoverServiceDirs(zr, detectRootPrefix(zr))
+	want := []string{"legacy/shop", "services/bank", "services/notes"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("dirs = %v, want %v", got, want)
+	}
+}
+
+func TestDiscoverFromGit_PreviewsEveryService(t *testing.T) {
+	q := newMockImportQuerier()
+	q.services[1] = &db.Service{ID: 1, Name: "notes"}
+	q.byName["notes"] = 1
+	svc, _ := newDiscoveryService(q, monorepoFiles("training", map[string]string{
+		"bank":  "bank",
+		"notes": "notes",
+	}))
+
+	discovery, err := svc.DiscoverFromGit(context.Background(), GitImportRequest{RepoURL: "https://example.com/team/training.git"}, true)
+	if err != nil {
+		t.Fatalf("DiscoverFromGit: %v", err)
+	}
+	if len(discovery.Services) != 2 {
+		t.Fatalf("len(Services) = %d, want 2", len(discovery.Services))
+	}
+	bank, notes := discovery.Services[0], discovery.Services[1]
+	if bank.Subdir != "bank" || bank.ServiceName != "bank" || !bank.Valid {
+		t.Errorf("bank preview = %+v", bank)
+	}
+	if notes.ExistingServiceID == nil || *notes.ExistingServiceID != 1 {
+		t.Errorf("notes should be reported as existing service 1, got %v", notes.ExistingServiceID)
+	}
+}
+
+func TestDiscoverFromGit_DuplicateNamesInRepository(t *testing.T) {
+	svc, _ := newDiscoveryService(newMockImportQuerier(), monorepoFiles("training", map[string]string{
+		"2025/bank": "bank",
+		"2026/bank": "bank",
+	}))
+
+	discovery, err := svc.DiscoverFromGit(context.Background(), GitImportRequest{RepoURL: "https://example.com/team/training.git"}, true)
+	if err != nil {
+		t.Fatalf("DiscoverFromGit: %v", err)
+	}
+	for _, p := range discovery.Services {
+		if p.Valid {
+			t.Errorf("%s should be invalid because the name is used twice", p.Subdir)
+		}
+	}
+}
+
+func TestDiscoverFromGit_RequiresAdmin(t *testing.T) {
+	svc, _ := newDiscoveryService(newMockImportQuerier(), nil)
+	if _, err := svc.DiscoverFromGit(context.Background(), GitImportRequest{}, false); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestImportDiscoveredFromGit_ImportsSelected(t *testing.T) {
+	q := newMockImportQuerier()
+	svc, store := newDiscoveryService(q, monorepoFiles("training", map[string]string{
+		"services/bank":  "bank",
+		"services/notes": "notes",
+		"services/shop":  "shop",
+	}))
+	tx := &recordingTx{}
+	svc.SetTxRunner(tx)
+
+	results, err := svc.ImportDiscoveredFromGit(context.Background(), GitImportRequest{RepoURL: "https://example.com/team/training.git"},
+		[]string{"services/bank", "/services/shop/"}, true)
+	if err != nil {
+		t.Fatalf("ImportDiscoveredFromGit: %v", err)
+	}
+	if tx.calls != 1 {
+		t.Errorf("RunInTx calls = %d, want 1", tx.calls)
+	}
+	if len(results) != 2 || results[0].Service.Name != "bank" || results[1].Service.Name != "shop" {
+		t.Fatalf("results = %+v", results)
+	}
+	if _, ok := q.byName["notes"]; ok {
+		t.Error("unselected service should not be imported")
+	}
+	bank := q.services[q.byName["bank"]]
+	if bank.GitSubdir == nil || *bank.GitSubdir != "services/bank" {
+		t.Errorf("GitSubdir = %v, want services/bank", bank.GitSubdir)
+	}
+	if _, ok := store.files[*results[0].Service.ServiceLocalPath]; !ok {
+		t.Error("service archive should be stored")
+	}
+}
+
+func TestImportDiscoveredFromGit_RejectsUnknownSubdir(t *testing.T) {
+	svc, _ := newDiscoveryService(newMockImportQuerier(), monorepoFiles("training", map[string]string{"bank": "bank"}))
+
+	_, err := svc.ImportDiscoveredFromGit(context.Background(), GitImportRequest{}, []string{"missing"}, true)
+	var ve *errs.ValidationError
+	if !errors.As(err, &ve) || ve.Fields[fieldSubdirs] == "" {
+		t.Fatalf("expected subdirs validation error, got %v", err)
+	}
+}
+
+type failingCreateQuerier struct {
+	*mockImportQuerier
+	failName string
+}
+
+func (q failingCreateQuerier) CreateService(ctx context.Context, arg db.CreateServiceParams) (db.Service, error) {
+	if arg.Name == q.failName {
+		return db.Service{}, errors.New("boom")
+	}
+	return q.mockImportQuerier.CreateService(ctx, arg)
+}
+
+func TestImportDiscoveredFromGit_RecordsArchivesOnFailure(t *testing.T) {
+	q := failingCreateQuerier{mockImportQuerier: newMockImportQuerier(), failName: "notes"}
+	svc, store := newDiscoveryService(q, monorepoFiles("training", map[string]string{
+		"bank":  "bank",
+		"notes": "notes",
+	}))
+
+	if _, err := svc.ImportDiscoveredFromGit(context.Background(), GitImportRequest{}, []string{"bank", "notes"}, true); err == nil {
+		t.Fatal("expected error")
+	}
+	// The transaction rollback drops the blob rows, so every stored blob
+	// must be recorded again for the GC to find it.
+	for key := range store.files {
+		if _, ok := q.blobs[path.Base(key)]; !ok {
+			t.Errorf("stored archive %s is unknown to the blob GC", key)
+		}
+	}
+}
+
+func mustZipReader(t *testing.T, data []byte) *zip.Reader {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+	return zr
+}
+
+type recordingTx struct {
+	calls int
+}
+
+func (tx *recordingTx) RunInTx(_ context.Context, fn func(*db.Queries) error) error {
+	tx.calls++
+	return fn(nil)
+}
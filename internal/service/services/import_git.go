@@ -1,7 +1,6 @@
 package services
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -12,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
@@ -23,26 +23,29 @@ type ImportResult struct {
 }
 
 type ImportValidationItem struct {
-	ID      string
-	Title   string
-	Status  string
-	Message string
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
 }
 
+// ImportPreview is the validation report of an import. Submissions store it as
+// JSON for the reviewer.
 type ImportPreview struct {
-	Source                 string
-	Valid                  bool
-	ServiceName            string
-	RepositoryOwner        string
-	RepositoryName         string
-	ExpectedRepositoryName string
-	RootDirectory          string
-	ServiceDirectory       string
-	CheckerDirectory       string
-	HasDevDirectory        bool
-	ExistingServiceID      *int64
-	Requirements           []ImportValidationItem
-	Warnings               []string
+	Source                 string                 `json:"source"`
+	Valid                  bool                   `json:"valid"`
+	ServiceName            string                 `json:"service_name"`
+	RepositoryOwner        string                 `json:"repository_owner,omitempty"`
+	RepositoryName         string                 `json:"repository_name,omitempty"`
+	ExpectedRepositoryName string                 `json:"expected_repository_name"`
+	RootDirectory          string                 `json:"root_directory,omitempty"`
+	ServiceDirectory       string                 `json:"service_directory,omitempty"`
+	CheckerDirectory       string                 `json:"checker_directory,omitempty"`
+	HasDevDirectory        bool                   `json:"has_dev_directory"`
+	ExistingServiceID      *int64                 `json:"existing_service_id,omitempty"`
+	Subdir                 string                 `json:"subdir,omitempty"`
+	Requirements           []ImportValidationItem `json:"requirements"`
+	Warnings               []string               `json:"warnings"`
 }
 
 type ImportQuerier interface {
@@ -51,17 +54,24 @@ type ImportQuerier interface {
 	ApplyServiceImportMetadata(ctx context.Context, arg db.ApplyServiceImportMetadataParams) (db.Service, error)
 	UpdateService(ctx context.Context, arg db.UpdateServiceParams) (db.Service, error)
 	GetServiceByID(ctx context.Context, id int64) (db.Service, error)
-	SetServiceLocal(ctx context.Context, arg db.SetServiceLocalParams) (db.Service, error)
-	SetCheckerLocal(ctx context.Context, arg db.SetCheckerLocalParams) (db.Service, error)
 	SetGitSource(ctx context.Context, arg db.SetGitSourceParams) (db.Service, error)
 	SetGitSyncState(ctx context.Context, arg db.SetGitSyncStateParams) (db.Service, error)
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+	vulnReplacer
+	ArchiveVersionQuerier
 }
 
 type ImportService struct {
 	q              ImportQuerier
 	store          storage.Storage
+	versions       archiveVersionStore
 	maxUploadBytes int64
 	gitFetcher     gitArchiveFetcher
+	tx             TxRunner
+}
+
+type TxRunner interface {
+	RunInTx(ctx context.Context, fn func(queries *db.Queries) error) error
 }
 
 type preparedImport struct {
@@ -77,11 +87,32 @@ func NewImportService(q ImportQuerier, store storage.Storage, maxUploadBytes int
 	return &ImportService{
 		q:              q,
 		store:          store,
+		versions:       archiveVersionStore{q: q, store: store, retention: DefaultArchiveRetention},
 		maxUploadBytes: maxUploadBytes,
 		gitFetcher:     newExecGitArchiveFetcher(maxUploadBytes),
 	}
 }
 
+// SetGitCredentials enables private repository access: the fetcher asks the
+// resolver for an attached or host-matched credential before every clone.
+func (s *ImportService) SetGitCredentials(resolver *GitCredentialService) {
+	if fetcher, ok := s.gitFetcher.(*execGitArchiveFetcher); ok && resolver != nil {
+		fetcher.credentials = resolver
+	}
+}
+
+// SetArchiveRetention sets how many archive versions are kept per service and
+// kind; zero or less keeps all of them.
+func (s *ImportService) SetArchiveRetention(retention int) {
+	s.versions.retention = retention
+}
+
+// SetTxRunner makes multi-service imports run in a single transaction.
+// Without it each service of a batch is stored as soon as it is imported.
+func (s *ImportService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
 func (s *ImportService) PreviewFromGit(ctx context.Context, req GitImportRequest, isAdmin bool) (*ImportPreview, error) {
 	if !isAdmin {
 		return nil, errs.ErrForbidden
@@ -92,14 +123,20 @@ func (s *ImportService) PreviewFromGit(ctx context.Context, req GitImportRequest
 		return nil, errs.NewValidationError(map[string]string{fieldRepoURL: err.Error()})
 	}
 
-	return s.previewArchive(ctx, fetched.ZipBytes, fetched.Source, isAdmin, nil)
+	preview, err := s.previewArchive(ctx, fetched.ZipBytes, fetched.Source, isAdmin, nil)
+	if err != nil {
+		return nil, err
+	}
+	preview.Subdir = fetched.Subdir
+	return preview, nil
 }
 
-func (s *ImportService) PreviewFromZipUpload(ctx context.Context, zipBytes []byte, isAdmin bool) (*ImportPreview, error) {
-	if len(zipBytes) == 0 {
+func (s *ImportService) PreviewFromZipUpload(ctx context.Context, archiveBytes []byte, isAdmin bool) (*ImportPreview, error) {
+	if len(archiveBytes) == 0 {
 		return nil, errs.NewValidationError(map[string]string{fieldArchive: "file is required"})
 	}
-	if err := validateZipBytes(zipBytes); err != nil {
+	zipBytes, err := normalizeArchive(archiveBytes)
+	if err != nil {
 		return nil, errs.NewValidationError(map[string]string{fieldArchive: err.Error()})
 	}
 
@@ -240,12 +277,18 @@ func addCheckerRequirement(preview *ImportPreview, layout sourceLayout, expected
 }
 
 func addManifestRequirements(preview *ImportPreview, meta *BundleMetadata) {
+	if meta != nil && meta.Manifest == nil && meta.ManifestValidation != nil {
+		preview.addRequirement("service_manifest", serviceManifestYAML, "error", "service manifest could not be read")
+		addManifestSchemaRequirements(preview, meta.ManifestValidation)
+		return
+	}
 	if meta == nil || meta.Manifest == nil {
 		preview.addRequirement("service_manifest", serviceManifestYAML, "warning", "service manifest is not present; checker settings will not be synchronized")
 		return
 	}
 
 	preview.addRequirement("service_manifest", serviceManifestYAML, "ok", "service manifest is present")
+	addManifestSchemaRequirements(preview, meta.ManifestValidation)
 	switch {
 	case meta.Manifest.ID == "":
 		preview.addRequirement("manifest_id", "manifest id", "error", "manifest must define checker-config-*/id")
@@ -265,6 +308,22 @@ func addManifestRequirements(preview *ImportPreview, meta *BundleMetadata) {
 	}
 }
 
+// addManifestSchemaRequirements reports each schema diagnostic as its own
+// requirement so the preview lists every problem with its position.
+func addManifestSchemaRequirements(preview *ImportPreview, validation *ManifestValidation) {
+	if validation == nil {
+		return
+	}
+	title := fmt.Sprintf("manifest schema v%d", validation.SchemaVersion)
+	if validation.Valid {
+		preview.addRequirement("manifest_schema", title, "ok", "service manifest matches the schema")
+		return
+	}
+	for i, d := range validation.Diagnostics {
+		preview.addRequirement(fmt.Sprintf("manifest_schema_%d", i+1), title, "error", d.String())
+	}
+}
+
 func (p *ImportPreview) addRequirement(id, title, status, message string) {
 	p.Requirements = append(p.Requirements, ImportValidationItem{
 		ID:      id,
@@ -274,6 +333,8 @@ func (p *ImportPreview) addRequirement(id, title, status, message string) {
 	})
 }
 
+// addDuplicatePreviewStatus reports name clashes and returns the existing
+// service when the import is going to update it.
 func (s *ImportService) addDuplicatePreviewStatus(
 	ctx context.Context,
 	preview *ImportPreview,
@@ -281,27 +342,28 @@ func (s *ImportService) addDuplicatePreviewStatus(
 	serviceName string,
 	isAdmin bool,
 	currentID *int64,
-) {
+) *db.Service {
 	if serviceName == "" || !serviceNameRe.MatchString(serviceName) {
-		return
+		return nil
 	}
 
 	existing, err := s.q.GetServiceByName(ctx, serviceName)
 	if err != nil {
 		preview.addRequirement("duplicate", "Existing service", "ok", "no service with this name exists")
-		return
+		return nil
 	}
 
 	preview.ExistingServiceID = &existing.ID
 	if currentID != nil && existing.ID == *currentID {
 		preview.addRequirement("duplicate", "Existing service", "ok", "this service will be synchronized")
-		return
+		return &existing
 	}
 	if source.Source == sourceGit && isAdmin {
 		preview.addRequirement("duplicate", "Existing service", "warning", "existing service will be updated by git import")
-		return
+		return &existing
 	}
 	preview.addRequirement("duplicate", "Existing service", "error", "service with this name already exists")
+	return nil
 }
 
 func finalizeImportPreview(preview *ImportPreview) {
@@ -335,6 +397,17 @@ func (s *ImportService) ImportFromGit(ctx context.Context, req GitImportRequest,
 		return nil, err
 	}
 
+	return s.importPrepared(ctx, fetched, prepared, isAdmin)
+}
+
+// importPrepared creates the service described by a validated git import, or
+// updates the existing service with the same name.
+func (s *ImportService) importPrepared(
+	ctx context.Context,
+	fetched *fetchedGitRepo,
+	prepared *preparedImport,
+	isAdmin bool,
+) (*ImportResult, error) {
 	existing, err := s.q.GetServiceByName(ctx, prepared.Name)
 	if err == nil {
 		if !isAdmin {
@@ -350,12 +423,15 @@ func (s *ImportService) ImportFromGit(ctx context.Context, req GitImportRequest,
 		Copyright:         optionalImportedString(prepared.Meta.Copyright),
 		Public:            true,
 		Ctf01dTraining:    prepared.Training,
+		Readme:            optionalImportedString(prepared.Meta.Readme),
+		License:           optionalImportedString(prepared.Meta.License),
 		CheckStatus:       checkStatusUnknown,
 		SourceKind:        sourceGit,
 		GitRepoUrl:        optionalImportedString(fetched.RepoURL),
 		GitRef:            optionalImportedString(fetched.Ref),
 		GitSubdir:         optionalImportedString(fetched.Subdir),
 		GitSyncStatus:     syncStatusUnknown,
+		GitCredentialID:   fetched.CredentialID,
 	})
 	if err != nil {
 		if isDuplicateKey(err) {
@@ -363,16 +439,22 @@ func (s *ImportService) ImportFromGit(ctx context.Context, req GitImportRequest,
 		}
 		return nil, fmt.Errorf("creating service: %w", err)
 	}
+	if err := s.importVulns(ctx, svc.ID, prepared.Meta); err != nil {
+		return nil, err
+	}
 
-	result, err := s.saveBundleArchives(ctx, svc.ID, prepared.BundleBytes, isAdmin, prepared.Preview.Warnings)
+	source := archiveSource{Kind: ArchiveSourceGit, Ref: fetched.Commit}
+	result, err := s.saveBundleArchives(ctx, svc.ID, prepared.BundleBytes, source, isAdmin, prepared.Preview.Warnings)
 	if err != nil {
 		return nil, err
 	}
 	return s.markGitSyncSuccess(ctx, result, fetched.Commit, isAdmin)
 }
 
-func (s *ImportService) SyncFromGit(ctx context.Context, id int64, isAdmin bool) (*ServiceModel, error) {
-	if !isAdmin {
+// SyncFromGit re-imports a service from its configured git source. Authors may
+// sync their own services; the source and its credential are set by admins.
+func (s *ImportService) SyncFromGit(ctx context.Context, id int64, access Access) (*ServiceModel, error) {
+	if !access.CanManage() {
 		return nil, errs.ErrForbidden
 	}
 
@@ -385,7 +467,8 @@ func (s *ImportService) SyncFromGit(ctx context.Context, id int64, isAdmin bool)
 	}
 
 	req := GitImportRequest{
-		RepoURL: *current.GitRepoUrl,
+		RepoURL:      *current.GitRepoUrl,
+		CredentialID: current.GitCredentialID,
 	}
 	if current.GitRef != nil {
 		req.Ref = *current.GitRef
@@ -396,17 +479,17 @@ func (s *ImportService) SyncFromGit(ctx context.Context, id int64, isAdmin bool)
 
 	fetched, err := s.gitFetcher.Fetch(ctx, req)
 	if err != nil {
-		s.markGitSyncFailureAndLog(ctx, id, syncFailureMessage(err))
+		s.markGitSyncFailureAndLog(ctx, current, syncFailureMessage(err))
 		return nil, errs.NewValidationError(map[string]string{fieldRepoURL: err.Error()})
 	}
 
-	prepared, err := s.prepareImport(ctx, fetched.ZipBytes, fetched.Source, isAdmin, &id)
+	prepared, err := s.prepareImport(ctx, fetched.ZipBytes, fetched.Source, access.Admin, &id)
 	if err != nil {
-		s.markGitSyncFailureAndLog(ctx, id, syncFailureMessage(err))
+		s.markGitSyncFailureAndLog(ctx, current, syncFailureMessage(err))
 		return nil, err
 	}
 	if err := validatePreparedImport(prepared.Preview, fieldRepoURL); err != nil {
-		s.markGitSyncFailureAndLog(ctx, id, syncFailureMessage(err))
+		s.markGitSyncFailureAndLog(ctx, current, syncFailureMessage(err))
 		return nil, err
 	}
 	syncedName := current.Name
@@ -418,7 +501,7 @@ func (s *ImportService) SyncFromGit(ctx context.Context, id int64, isAdmin bool)
 		other, err := s.q.GetServiceByName(ctx, syncedName)
 		if err == nil && other.ID != id {
 			conflictErr := errs.ErrConflict
-			s.markGitSyncFailureAndLog(ctx, id, syncFailureMessage(conflictErr))
+			s.markGitSyncFailureAndLog(ctx, current, syncFailureMessage(conflictErr))
 			return nil, conflictErr
 		}
 	}
@@ -430,28 +513,37 @@ func (s *ImportService) SyncFromGit(ctx context.Context, id int64, isAdmin bool)
 		Author:            metaAuthorPtr(prepared.Meta),
 		Copyright:         optionalImportedString(prepared.Meta.Copyright),
 		Ctf01dTraining:    prepared.Training,
+		Readme:            optionalImportedString(prepared.Meta.Readme),
+		License:           optionalImportedString(prepared.Meta.License),
 	})
 	if err != nil {
-		s.markGitSyncFailureAndLog(ctx, id, syncFailureMessage(err))
+		s.markGitSyncFailureAndLog(ctx, current, syncFailureMessage(err))
 		return nil, fmt.Errorf("updating service: %w", err)
 	}
+	if err := s.importVulns(ctx, id, prepared.Meta); err != nil {
+		s.markGitSyncFailureAndLog(ctx, current, syncFailureMessage(err))
+		return nil, err
+	}
 
-	result, err := s.saveBundleArchivesForSvc(ctx, svc, prepared.BundleBytes, isAdmin, prepared.Preview.Warnings)
+	source := archiveSource{Kind: ArchiveSourceGit, Ref: fetched.Commit}
+	_, err = s.saveBundleArchives(ctx, svc.ID, prepared.BundleBytes, source, true, prepared.Preview.Warnings)
 	if err != nil {
-		s.markGitSyncFailureAndLog(ctx, id, syncFailureMessage(err))
+		s.markGitSyncFailureAndLog(ctx, current, syncFailureMessage(err))
 		return nil, err
 	}
-	result, err = s.markGitSyncSuccess(ctx, result, fetched.Commit, isAdmin)
+	synced, err := s.recordGitSync(ctx, current, gitSyncSucceeded(id, fetched.Commit), audit.ActionGitSync)
 	if err != nil {
 		return nil, err
 	}
 
-	return result.Service, nil
+	model := fromDB(synced, true)
+	return &model, nil
 }
 
-func (s *ImportService) ImportFromZip(ctx context.Context, zipBytes []byte, isAdmin bool) (*ImportResult, error) {
-	if err := validateZipBytes(zipBytes); err != nil {
-		return nil, errs.NewValidationError(map[string]string{fieldArchive: fmt.Sprintf("invalid zip: %v", err)})
+func (s *ImportService) ImportFromZip(ctx context.Context, archiveBytes []byte, isAdmin bool) (*ImportResult, error) {
+	zipBytes, err := normalizeArchive(archiveBytes)
+	if err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldArchive: fmt.Sprintf("invalid archive: %v", err)})
 	}
 
 	prepared, err := s.prepareImport(ctx, zipBytes, importSourceInfo{Source: sourceZip}, isAdmin, nil)
@@ -475,6 +567,8 @@ func (s *ImportService) ImportFromZip(ctx context.Context, zipBytes []byte, isAd
 		Copyright:         optionalImportedString(prepared.Meta.Copyright),
 		Public:            true,
 		Ctf01dTraining:    prepared.Training,
+		Readme:            optionalImportedString(prepared.Meta.Readme),
+		License:           optionalImportedString(prepared.Meta.License),
 		CheckStatus:       checkStatusUnknown,
 		SourceKind:        sourceZip,
 		GitSyncStatus:     syncStatusUnknown,
@@ -485,8 +579,12 @@ func (s *ImportService) ImportFromZip(ctx context.Context, zipBytes []byte, isAd
 		}
 		return nil, fmt.Errorf("creating service: %w", err)
 	}
+	if err := s.importVulns(ctx, svc.ID, prepared.Meta); err != nil {
+		return nil, err
+	}
 
-	return s.saveBundleArchives(ctx, svc.ID, prepared.BundleBytes, isAdmin, prepared.Preview.Warnings)
+	source := archiveSource{Kind: ArchiveSourceUpload}
+	return s.saveBundleArchives(ctx, svc.ID, prepared.BundleBytes, source, isAdmin, prepared.Preview.Warnings)
 }
 
 func (s *ImportService) prepareImport(
@@ -513,7 +611,12 @@ func (s *ImportService) prepareImport(
 
 	name := resolveImportServiceName(meta, layout, source)
 	preview := buildImportPreview(layout, source, meta, name)
-	s.addDuplicatePreviewStatus(ctx, preview, source, name, isAdmin, currentID)
+	var registeredPorts []int32
+	if existing := s.addDuplicatePreviewStatus(ctx, preview, source, name, isAdmin, currentID); existing != nil {
+		registeredPorts = existing.Ports
+	}
+	addComposeRequirements(preview, meta, registeredPorts)
+	addVulnRequirements(preview, meta)
 	finalizeImportPreview(preview)
 
 	training := meta.Ctf01dTraining
@@ -549,6 +652,8 @@ func validatePreparedImport(preview *ImportPreview, field string) error {
 	return errs.NewValidationError(map[string]string{field: strings.Join(messages, "; ")})
 }
 
+// syncFailureMessage renders err for git_sync_error. The result is persisted
+// and logged, so URL credentials are masked as a last line of defence.
 func syncFailureMessage(err error) string {
 	if err == nil {
 		return ""
@@ -575,11 +680,11 @@ func syncFailureMessage(err error) string {
 			parts = append(parts, key+": "+value)
 		}
 		if len(parts) > 0 {
-			return strings.Join(parts, "; ")
+			return redactURLCredentials(strings.Join(parts, "; "))
 		}
 	}
 
-	return err.Error()
+	return redactURLCredentials(err.Error())
 }
 
 func optionalImportedString(value string) *string {
@@ -604,23 +709,30 @@ func (s *ImportService) updateServiceFromGitImport(
 		Author:            metaAuthorPtr(prepared.Meta),
 		Copyright:         optionalImportedString(prepared.Meta.Copyright),
 		Ctf01dTraining:    prepared.Training,
+		Readme:            optionalImportedString(prepared.Meta.Readme),
+		License:           optionalImportedString(prepared.Meta.License),
 	}); err != nil {
 		return nil, fmt.Errorf("updating service: %w", err)
 	}
+	if err := s.importVulns(ctx, current.ID, prepared.Meta); err != nil {
+		return nil, err
+	}
 
 	svc, err := s.q.SetGitSource(ctx, db.SetGitSourceParams{
-		ID:            current.ID,
-		SourceKind:    sourceGit,
-		GitRepoUrl:    optionalImportedString(fetched.RepoURL),
-		GitRef:        optionalImportedString(fetched.Ref),
-		GitSubdir:     optionalImportedString(fetched.Subdir),
-		GitSyncStatus: syncStatusUnknown,
+		ID:              current.ID,
+		SourceKind:      sourceGit,
+		GitRepoUrl:      optionalImportedString(fetched.RepoURL),
+		GitRef:          optionalImportedString(fetched.Ref),
+		GitSubdir:       optionalImportedString(fetched.Subdir),
+		GitCredentialID: fetched.CredentialID,
+		GitSyncStatus:   syncStatusUnknown,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("updating git source: %w", err)
 	}
 
-	result, err := s.saveBundleArchivesForSvc(ctx, svc, prepared.BundleBytes, isAdmin, prepared.Preview.Warnings)
+	source := archiveSource{Kind: ArchiveSourceGit, Ref: fetched.Commit}
+	result, err := s.saveBundleArchives(ctx, svc.ID, prepared.BundleBytes, source, isAdmin, prepared.Preview.Warnings)
 	if err != nil {
 		return nil, err
 	}
@@ -631,56 +743,29 @@ func (s *ImportService) saveBundleArchives(
 	ctx context.Context,
 	id int64,
 	bundleBytes []byte,
+	source archiveSource,
 	isAdmin bool,
 	warnings []string,
 ) (*ImportResult, error) {
-	now := time.Now()
-
-	key := fmt.Sprintf("services/%d/service.zip", id)
-	if _, err := s.store.Save(ctx, key, bytes.NewReader(bundleBytes)); err != nil {
-		return nil, fmt.Errorf("saving service archive: %w", err)
-	}
-
-	size, err := int32Len(len(bundleBytes))
+	key, info, err := s.versions.putBytes(ctx, bundleBytes)
 	if err != nil {
-		return nil, err
-	}
-	sha := computeSHA256Hex(bundleBytes)
-	path := key
-	svc, err := s.q.SetServiceLocal(ctx, db.SetServiceLocalParams{
-		ID:                  id,
-		ServiceLocalPath:    &path,
-		ServiceLocalSize:    &size,
-		ServiceLocalSha256:  &sha,
-		ServiceDownloadedAt: pgtypeTz(now),
-	})
-	if err != nil {
-		return nil, mapDBError(err)
+		return nil, fmt.Errorf("saving service archive: %w", err)
 	}
+	staged := []stagedArchive{{kind: kindService, key: key, info: info, source: source}}
 
 	checkerBytes := extractCheckerFromBundle(bundleBytes)
 	if len(checkerBytes) > 0 {
-		ckKey := fmt.Sprintf("services/%d/checker.zip", id)
-		if _, err := s.store.Save(ctx, ckKey, bytes.NewReader(checkerBytes)); err != nil {
+		ckKey, ckInfo, err := s.versions.putBytes(ctx, checkerBytes)
+		if err != nil {
+			s.versions.discard(ctx, key)
 			return nil, fmt.Errorf("saving checker archive: %w", err)
 		}
+		staged = append(staged, stagedArchive{kind: kindChecker, key: ckKey, info: ckInfo, source: source})
+	}
 
-		ckSize, err := int32Len(len(checkerBytes))
-		if err != nil {
-			return nil, err
-		}
-		ckSha := computeSHA256Hex(checkerBytes)
-		ckPath := ckKey
-		svc, err = s.q.SetCheckerLocal(ctx, db.SetCheckerLocalParams{
-			ID:                  id,
-			CheckerLocalPath:    &ckPath,
-			CheckerLocalSize:    &ckSize,
-			CheckerLocalSha256:  &ckSha,
-			CheckerDownloadedAt: pgtypeTz(now),
-		})
-		if err != nil {
-			return nil, mapDBError(err)
-		}
+	svc, err := s.versions.activateStaged(ctx, s.runInTx, id, staged, time.Now(), nil)
+	if err != nil {
+		return nil, err
 	}
 
 	model := fromDB(svc, isAdmin)
@@ -690,16 +775,6 @@ func (s *ImportService) saveBundleArchives(
 	}, nil
 }
 
-func (s *ImportService) saveBundleArchivesForSvc(
-	ctx context.Context,
-	svc db.Service,
-	bundleBytes []byte,
-	isAdmin bool,
-	warnings []string,
-) (*ImportResult, error) {
-	return s.saveBundleArchives(ctx, svc.ID, bundleBytes, isAdmin, warnings)
-}
-
 func (s *ImportService) markGitSyncSuccess(
 	ctx context.Context,
 	result *ImportResult,
@@ -710,13 +785,7 @@ func (s *ImportService) markGitSyncSuccess(
 		return result, nil
 	}
 
-	svc, err := s.q.SetGitSyncState(ctx, db.SetGitSyncStateParams{
-		ID:            result.Service.ID,
-		GitLastCommit: optionalImportedString(commit),
-		GitSyncedAt:   pgtypeTz(time.Now()),
-		GitSyncStatus: syncStatusOK,
-		GitSyncError:  nil,
-	})
+	svc, err := s.q.SetGitSyncState(ctx, gitSyncSucceeded(result.Service.ID, commit))
 	if err != nil {
 		return nil, mapDBError(err)
 	}
@@ -726,24 +795,76 @@ func (s *ImportService) markGitSyncSuccess(
 	return result, nil
 }
 
-func (s *ImportService) markGitSyncFailure(ctx context.Context, id int64, message string) error {
+func gitSyncSucceeded(id int64, commit string) db.SetGitSyncStateParams {
+	return db.SetGitSyncStateParams{
+		ID:            id,
+		GitLastCommit: optionalImportedString(commit),
+		GitSyncedAt:   pgtypeTz(time.Now()),
+		GitSyncStatus: syncStatusOK,
+		GitSyncError:  nil,
+	}
+}
+
+// gitSyncState is what the audit log keeps of a service after a sync.
+type gitSyncState struct {
+	archiveState
+	GitLastCommit *string `json:"git_last_commit"`
+	GitSyncStatus string  `json:"git_sync_status"`
+	GitSyncError  *string `json:"git_sync_error"`
+}
+
+func gitSyncStateOf(svc db.Service) gitSyncState {
+	return gitSyncState{
+		archiveState:  archiveStateOf(svc),
+		GitLastCommit: svc.GitLastCommit,
+		GitSyncStatus: svc.GitSyncStatus,
+		GitSyncError:  svc.GitSyncError,
+	}
+}
+
+// recordGitSync stores the outcome of a sync together with its audit event.
+// current is the service as it was before the sync.
+func (s *ImportService) recordGitSync(ctx context.Context, current db.Service, state db.SetGitSyncStateParams, action string) (db.Service, error) {
+	var svc db.Service
+	err := s.runInTx(ctx, func(q *db.Queries) error {
+		var store ImportQuerier = s.q
+		if q != nil {
+			store = q
+		}
+		var err error
+		svc, err = store.SetGitSyncState(ctx, state)
+		if err != nil {
+			return mapDBError(err)
+		}
+		return audit.Record(ctx, store, audit.Entry{
+			EntityType: audit.EntityService,
+			EntityID:   current.ID,
+			Action:     action,
+			Before:     gitSyncStateOf(current),
+			After:      gitSyncStateOf(svc),
+		})
+	})
+	return svc, err
+}
+
+func (s *ImportService) markGitSyncFailure(ctx context.Context, current db.Service, message string) error {
 	msg := strings.TrimSpace(message)
 	if msg == "" {
 		msg = "git synchronization failed"
 	}
 
-	_, err := s.q.SetGitSyncState(ctx, db.SetGitSyncStateParams{
-		ID:            id,
+	_, err := s.recordGitSync(ctx, current, db.SetGitSyncStateParams{
+		ID:            current.ID,
 		GitLastCommit: nil,
 		GitSyncedAt:   pgtypeTz(time.Now()),
 		GitSyncStatus: syncStatusFailed,
 		GitSyncError:  &msg,
-	})
+	}, audit.ActionGitSyncFailed)
 	return err
 }
 
-func (s *ImportService) markGitSyncFailureAndLog(ctx context.Context, id int64, message string) {
-	if err := s.markGitSyncFailure(ctx, id, message); err != nil {
-		slog.Warn("failed to persist git sync failure state", "service_id", id, "error", err)
+func (s *ImportService) markGitSyncFailureAndLog(ctx context.Context, current db.Service, message string) {
+	if err := s.markGitSyncFailure(ctx, current, message); err != nil {
+		slog.Warn("failed to persist git sync failure state", "service_id", current.ID, "error", err)
 	}
 }
@@ -28,7 +28,12 @@ type ServiceManifest struct {
 	ScriptWait  int
 	RoundSleep  int
 	Enabled     *bool
-	Raw         map[string]any
+	Ports       []int
+	// Vulns is the vulns section; HasVulns tells an empty section from a
+	// missing one.
+	Vulns    []VulnInput
+	HasVulns bool
+	Raw      map[string]any
 }
 
 func parseServiceManifest(data []byte) (*ServiceManifest, error) {
@@ -74,6 +79,28 @@ func parseServiceManifest(data []byte) (*ServiceManifest, error) {
 	if enabled, ok := manifestBool(section["enabled"]); ok {
 		manifest.Enabled = &enabled
 	}
+	if ports, ok := raw["ports"].([]any); ok {
+		for _, value := range ports {
+			if port := manifestInt(value); port > 0 {
+				manifest.Ports = append(manifest.Ports, port)
+			}
+		}
+	}
+
+	if value, ok := raw["vulns"]; ok {
+		manifest.HasVulns = true
+		items, _ := value.([]any)
+		for _, item := range items {
+			fields, _ := item.(map[string]any)
+			manifest.Vulns = append(manifest.Vulns, VulnInput{
+				Title:      trimManifestString(fields["title"]),
+				CWE:        manifestOptionalString(fields["cwe"]),
+				Difficulty: manifestOptionalString(fields["difficulty"]),
+				Exploit:    manifestOptionalString(fields["exploit"]),
+				Fix:        manifestOptionalString(fields["fix"]),
+			})
+		}
+	}
 
 	return manifest, nil
 }
@@ -130,6 +157,18 @@ func trimManifestString(value any) string {
 	return strings.TrimSpace(s)
 }
 
+// manifestOptionalString accepts scalars, so "cwe: 89" works like "cwe: CWE-89".
+func manifestOptionalString(value any) *string {
+	switch value.(type) {
+	case nil, map[string]any, []any:
+		return nil
+	case string:
+		return optionalImportedString(value.(string))
+	default:
+		return optionalImportedString(fmt.Sprint(value))
+	}
+}
+
 func manifestInt(value any) int {
 	switch typed := value.(type) {
 	case int:
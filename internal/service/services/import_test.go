@@ -20,6 +20,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 )
@@ -164,6 +165,7 @@ func createTestGitRepo(t *testing.T, files map[string]string) string {
 }
 
 type mockImportQuerier struct {
+	mockArchiveVersions
 	services    map[int64]*db.Service
 	byName      map[string]int64
 	nextID      int64
@@ -171,6 +173,8 @@ type mockImportQuerier struct {
 	checkStatus map[int64]string
 	checkedAt   map[int64]time.Time
 	localPath   map[int64]map[string]string
+	vulns       map[int64][]db.ServiceVuln
+	audit       []db.InsertAuditEventParams
 }
 
 func newMockImportQuerier() *mockImportQuerier {
@@ -185,6 +189,11 @@ func newMockImportQuerier() *mockImportQuerier {
 	}
 }
 
+func (m *mockImportQuerier) InsertAuditEvent(_ context.Context, arg db.InsertAuditEventParams) error {
+	m.audit = append(m.audit, arg)
+	return nil
+}
+
 func (m *mockImportQuerier) GetServiceByName(_ context.Context, name string) (db.Service, error) {
 	id, ok := m.byName[name]
 	if !ok {
@@ -570,6 +579,9 @@ func TestExtractMetadata_FromReadme(t *testing.T) {
 	if !strings.Contains(meta.Copyright, "Test Author") {
 		t.Errorf("Copyright = %q, should contain 'Test Author'", meta.Copyright)
 	}
+	if meta.Readme != "# Awesome Service\n\nThis is a great service for CTF" {
+		t.Errorf("Readme = %q, want the full README", meta.Readme)
+	}
 }
 
 func TestExtractMetadata_FromTrainingJSON(t *testing.T) {
@@ -855,7 +867,7 @@ func TestCheckerService_CheckChecker_NoArchive(t *testing.T) {
 	q.byName["test"] = id
 
 	cs := NewCheckerService(q, nil)
-	result, err := cs.CheckChecker(context.Background(), id, true)
+	result, err := cs.CheckChecker(context.Background(), id, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("CheckChecker: %v", err)
 	}
@@ -867,7 +879,7 @@ func TestCheckerService_CheckChecker_NoArchive(t *testing.T) {
 func TestCheckerService_CheckChecker_NotFound(t *testing.T) {
 	q := newMockImportQuerier()
 	cs := NewCheckerService(q, nil)
-	_, err := cs.CheckChecker(context.Background(), 999, true)
+	_, err := cs.CheckChecker(context.Background(), 999, Access{Admin: true})
 	if err == nil {
 		t.Fatal("expected error for not found service")
 	}
@@ -1398,7 +1410,7 @@ func TestSyncFromGit_RequiresAdmin(t *testing.T) {
 	store := newMemStorage()
 	svc := NewImportService(q, store, 50*1024*1024)
 
-	_, err := svc.SyncFromGit(context.Background(), 1, false)
+	_, err := svc.SyncFromGit(context.Background(), 1, Access{})
 	if !errors.Is(err, errs.ErrForbidden) {
 		t.Fatalf("expected ErrForbidden, got %v", err)
 	}
@@ -1440,7 +1452,7 @@ func TestSyncFromGit_PreservesUnsetRef(t *testing.T) {
 		},
 	}
 
-	result, err := svc.SyncFromGit(context.Background(), 1, true)
+	result, err := svc.SyncFromGit(context.Background(), 1, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("SyncFromGit: %v", err)
 	}
@@ -1455,6 +1467,12 @@ func TestSyncFromGit_PreservesUnsetRef(t *testing.T) {
 	if current.GitRef != nil {
 		t.Fatalf("stored GitRef = %v, want nil", current.GitRef)
 	}
+	if len(q.audit) != 1 || q.audit[0].Action != audit.ActionGitSync || q.audit[0].EntityID != "1" {
+		t.Fatalf("audit events = %+v, want one git sync of service 1", q.audit)
+	}
+	if !strings.Contains(string(q.audit[0].After), strings.Repeat("d", 40)) {
+		t.Errorf("audit after = %s, want the synced commit", q.audit[0].After)
+	}
 }
 
 func TestSyncFromGit_RejectsLegacyRepositoryLayout(t *testing.T) {
@@ -1494,7 +1512,7 @@ func TestSyncFromGit_RejectsLegacyRepositoryLayout(t *testing.T) {
 		},
 	}
 
-	_, err := svc.SyncFromGit(context.Background(), 1, true)
+	_, err := svc.SyncFromGit(context.Background(), 1, Access{Admin: true})
 	if err == nil {
 		t.Fatal("expected validation error for legacy repository layout")
 	}
@@ -1512,6 +1530,9 @@ func TestSyncFromGit_RejectsLegacyRepositoryLayout(t *testing.T) {
 	if !strings.Contains(*current.GitSyncError, "vuln-service/ directory is required") {
 		t.Fatalf("GitSyncError = %q, want layout validation details", *current.GitSyncError)
 	}
+	if len(q.audit) != 1 || q.audit[0].Action != audit.ActionGitSyncFailed {
+		t.Fatalf("audit events = %+v, want one failed git sync", q.audit)
+	}
 }
 
 func TestParseServiceManifest_DuplicateCheckerSections(t *testing.T) {
@@ -1640,7 +1661,7 @@ func TestCheckerService_WithLocalArchive(t *testing.T) {
 
 	cs := NewCheckerService(q, nil)
 
-	svc, err := cs.CheckChecker(context.Background(), id, true)
+	svc, err := cs.CheckChecker(context.Background(), id, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("CheckChecker: %v", err)
 	}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestSchemaVersion is the latest .ctf01d-service.yml schema version and
+// the one used when a manifest does not declare schema_version.
+const ManifestSchemaVersion = 1
+
+const manifestSchemaURLFormat = "urn:ctf01d:service-manifest:v%d"
+
+//go:embed schemas/ctf01d-service.v1.schema.json
+var manifestSchemaV1 []byte
+
+var manifestSchemaSources = map[int][]byte{
+	1: manifestSchemaV1,
+}
+
+var (
+	manifestSchemasOnce sync.Once
+	manifestSchemas     map[int]*jsonschema.Schema
+	manifestSchemasErr  error
+
+	manifestPrinter = message.NewPrinter(language.English)
+	yamlErrorLineRe = regexp.MustCompile(`line (\d+)`)
+)
+
+// ManifestDiagnostic is a single manifest problem. Path is a JSON pointer into
+// the manifest; Line and Column are 1-based and zero when unknown.
+type ManifestDiagnostic struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (d ManifestDiagnostic) String() string {
+	var sb strings.Builder
+	if d.Line > 0 {
+		fmt.Fprintf(&sb, "line %d", d.Line)
+		if d.Column > 0 {
+			fmt.Fprintf(&sb, ", column %d", d.Column)
+		}
+		sb.WriteString(": ")
+	}
+	if d.Path != "" {
+		sb.WriteString(d.Path + ": ")
+	}
+	sb.WriteString(d.Message)
+	return sb.String()
+}
+
+// ManifestValidation is the result of checking a manifest against its schema.
+type ManifestValidation struct {
+	SchemaVersion int
+	Valid         bool
+	Diagnostics   []ManifestDiagnostic
+}
+
+func (v *ManifestValidation) add(node *yaml.Node, path, msg string) {
+	d := ManifestDiagnostic{Path: path, Message: msg}
+	if node != nil {
+		d.Line = node.Line
+		d.Column = node.Column
+	}
+	v.Diagnostics = append(v.Diagnostics, d)
+}
+
+func loadManifestSchemas() (map[int]*jsonschema.Schema, error) {
+	manifestSchemasOnce.Do(func() {
+		manifestSchemas = make(map[int]*jsonschema.Schema, len(manifestSchemaSources))
+		for version, src := range manifestSchemaSources {
+			doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(src))
+			if err != nil {
+				manifestSchemasErr = fmt.Errorf("manifest schema v%d: %w", version, err)
+				return
+			}
+			url := fmt.Sprintf(manifestSchemaURLFormat, version)
+			c := jsonschema.NewCompiler()
+			if err := c.AddResource(url, doc); err != nil {
+				manifestSchemasErr = fmt.Errorf("manifest schema v%d: %w", version, err)
+				return
+			}
+			sch, err := c.Compile(url)
+			if err != nil {
+				manifestSchemasErr = fmt.Errorf("manifest schema v%d: %w", version, err)
+				return
+			}
+			manifestSchemas[version] = sch
+		}
+	})
+	return manifestSchemas, manifestSchemasErr
+}
+
+// ValidateServiceManifest checks a .ctf01d-service.yml document against the
+// schema version it declares and reports every problem with its position.
+func ValidateServiceManifest(data []byte) (*ManifestValidation, error) {
+	schemas, err := loadManifestSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ManifestValidation{SchemaVersion: ManifestSchemaVersion}
+	defer result.finish()
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		result.add(nil, "", "manifest is empty")
+		return result, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		d := ManifestDiagnostic{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := yamlErrorLineRe.FindStringSubmatch(err.Error()); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+		}
+		result.Diagnostics = append(result.Diagnostics, d)
+		return result, nil
+	}
+	if len(doc.Content) == 0 {
+		result.add(nil, "", "manifest is empty")
+		return result, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		result.add(root, "", "manifest must be a mapping")
+		return result, nil
+	}
+
+	version, ok := manifestSchemaVersion(root, result)
+	if !ok {
+		return result, nil
+	}
+	result.SchemaVersion = version
+
+	var value any
+	if err := root.Decode(&value); err != nil {
+		result.add(root, "", err.Error())
+		return result, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		result.add(root, "", "manifest keys must be strings")
+		return result, nil
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("re-reading manifest: %w", err)
+	}
+
+	if err := schemas[version].Validate(instance); err != nil {
+		var ve *jsonschema.ValidationError
+		if !errors.As(err, &ve) {
+			return nil, err
+		}
+		for _, leaf := range validationLeaves(ve) {
+			result.add(yamlNodeAt(root, leaf.InstanceLocation), jsonPointer(leaf.InstanceLocation), leaf.ErrorKind.LocalizedString(manifestPrinter))
+		}
+	}
+
+	sections := 0
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i]
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(key.Value)), "checker-config-") {
+			sections++
+			if sections > 1 {
+				result.add(key, "/"+escapeJSONPointer(key.Value), "only one checker-config section is allowed")
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (v *ManifestValidation) finish() {
+	sort.SliceStable(v.Diagnostics, func(i, j int) bool {
+		a, b := v.Diagnostics[i], v.Diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	v.Valid = len(v.Diagnostics) == 0
+}
+
+func manifestSchemaVersion(root *yaml.Node, result *ManifestValidation) (int, bool) {
+	node := yamlMappingValue(root, "schema_version")
+	if node == nil {
+		return ManifestSchemaVersion, true
+	}
+	var version int
+	if err := node.Decode(&version); err != nil {
+		result.add(node, "/schema_version", "schema_version must be an integer")
+		return 0, false
+	}
+	if _, ok := manifestSchemaSources[version]; !ok {
+		result.add(node, "/schema_version", fmt.Sprintf("unsupported schema_version %d (latest is %d)", version, ManifestSchemaVersion))
+		return 0, false
+	}
+	return version, true
+}
+
+// validationLeaves flattens the error tree. For anyOf, branches that only
+// failed on type are dropped when another branch matched the type, so
+// "0" for a timing reports the range rather than "want string".
+func validationLeaves(e *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(e.Causes) == 0 {
+		return []*jsonschema.ValidationError{e}
+	}
+	branches := make([][]*jsonschema.ValidationError, 0, len(e.Causes))
+	for _, cause := range e.Causes {
+		branches = append(branches, validationLeaves(cause))
+	}
+	if _, ok := e.ErrorKind.(*kind.AnyOf); ok {
+		var relevant [][]*jsonschema.ValidationError
+		for _, branch := range branches {
+			if !onlyTypeErrors(branch) {
+				relevant = append(relevant, branch)
+			}
+		}
+		if len(relevant) > 0 {
+			branches = relevant
+		}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, branch := range branches {
+		leaves = append(leaves, branch...)
+	}
+	return leaves
+}
+
+func onlyTypeErrors(leaves []*jsonschema.ValidationError) bool {
+	for _, leaf := range leaves {
+		if _, ok := leaf.ErrorKind.(*kind.Type); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlNodeAt follows a JSON pointer through the YAML tree and returns the
+// deepest node it reaches, so missing properties point at their parent.
+func yamlNodeAt(node *yaml.Node, tokens []string) *yaml.Node {
+	for _, tok := range tokens {
+		if node.Kind == yaml.AliasNode && node.Alias != nil {
+			node = node.Alias
+		}
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			next = yamlMappingValue(node, tok)
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(tok); err == nil && i >= 0 && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
+
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, tok := range tokens {
+		sb.WriteString("/" + escapeJSONPointer(tok))
+	}
+	return sb.String()
+}
+
+func escapeJSONPointer(tok string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(tok)
+}
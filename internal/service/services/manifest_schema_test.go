@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func validateManifestForTest(t *testing.T, manifest string) *ManifestValidation {
+	t.Helper()
+	result, err := ValidateServiceManifest([]byte(manifest))
+	if err != nil {
+		t.Fatalf("ValidateServiceManifest: %v", err)
+	}
+	return result
+}
+
+func findDiagnostic(result *ManifestValidation, path string) *ManifestDiagnostic {
+	for i := range result.Diagnostics {
+		if result.Diagnostics[i].Path == path {
+			return &result.Diagnostics[i]
+		}
+	}
+	return nil
+}
+
+func TestValidateServiceManifest_Valid(t *testing.T) {
+	result := validateManifestForTest(t, `
+schema_version: 1
+difficulty: medium
+ports: [8080, 8443]
+tech_stack: [python, postgres]
+authors:
+  - Alice
+  - name: Bob
+    email: bob@example.org
+checker-config-v1:
+  id: bank
+  service_name: Bank
+  script_path: ./checker.py
+  script_wait_in_sec: 5
+  time_sleep_between_run_scripts_in_sec: "15"
+  enabled: yes
+`)
+	if !result.Valid {
+		t.Fatalf("expected valid manifest, got %#v", result.Diagnostics)
+	}
+	if result.SchemaVersion != ManifestSchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", result.SchemaVersion, ManifestSchemaVersion)
+	}
+}
+
+func TestValidateServiceManifest_ReportsPositions(t *testing.T) {
+	result := validateManifestForTest(t, `checker-config-v1:
+  id: bad id
+  script_path: ../checker.py
+  script_wait_in_sec: 0
+ports:
+  - 80
+  - 70000
+difficulty: insane
+`)
+	if result.Valid {
+		t.Fatal("expected invalid manifest")
+	}
+
+	cases := []struct {
+		path   string
+		line   int
+		column int
+	}{
+		{"/checker-config-v1/id", 2, 7},
+		{"/checker-config-v1/script_path", 3, 16},
+		{"/checker-config-v1/script_wait_in_sec", 4, 23},
+		{"/ports/1", 7, 5},
+		{"/difficulty", 8, 13},
+	}
+	for _, tc := range cases {
+		d := findDiagnostic(result, tc.path)
+		if d == nil {
+			t.Errorf("no diagnostic for %s in %#v", tc.path, result.Diagnostics)
+			continue
+		}
+		if d.Line != tc.line || d.Column != tc.column {
+			t.Errorf("%s at %d:%d, want %d:%d", tc.path, d.Line, d.Column, tc.line, tc.column)
+		}
+	}
+
+	if d := findDiagnostic(result, "/checker-config-v1/script_wait_in_sec"); d != nil && strings.Contains(d.Message, "string") {
+		t.Errorf("anyOf type branch leaked into message: %q", d.Message)
+	}
+	if n := len(result.Diagnostics); n != len(cases) {
+		t.Errorf("got %d diagnostics, want %d: %#v", n, len(cases), result.Diagnostics)
+	}
+
+	for i := 1; i < len(result.Diagnostics); i++ {
+		if result.Diagnostics[i].Line < result.Diagnostics[i-1].Line {
+			t.Fatalf("diagnostics are not sorted by line: %#v", result.Diagnostics)
+		}
+	}
+}
+
+func TestValidateServiceManifest_MissingRequiredPointsAtSection(t *testing.T) {
+	result := validateManifestForTest(t, `
+checker-config-v1:
+  id: bank
+`)
+	d := findDiagnostic(result, "/checker-config-v1")
+	if d == nil {
+		t.Fatalf("expected diagnostic for section, got %#v", result.Diagnostics)
+	}
+	if d.Line != 3 || !strings.Contains(d.Message, "script_path") {
+		t.Errorf("diagnostic = %+v", d)
+	}
+}
+
+func TestValidateServiceManifest_MultipleCheckerSections(t *testing.T) {
+	result := validateManifestForTest(t, `checker-config-a:
+  id: first
+  script_path: checker.py
+checker-config-b:
+  id: second
+  script_path: checker.py
+`)
+	d := findDiagnostic(result, "/checker-config-b")
+	if d == nil || d.Line != 4 {
+		t.Fatalf("expected diagnostic on line 4, got %#v", result.Diagnostics)
+	}
+}
+
+func TestValidateServiceManifest_SyntaxError(t *testing.T) {
+	result := validateManifestForTest(t, "id: bank\nports: [80\n")
+	if result.Valid || len(result.Diagnostics) != 1 {
+		t.Fatalf("expected one syntax diagnostic, got %#v", result.Diagnostics)
+	}
+	if result.Diagnostics[0].Line == 0 {
+		t.Errorf("expected a line number, got %+v", result.Diagnostics[0])
+	}
+}
+
+func TestValidateServiceManifest_UnsupportedVersion(t *testing.T) {
+	result := validateManifestForTest(t, "schema_version: 7\nid: bank\n")
+	d := findDiagnostic(result, "/schema_version")
+	if d == nil || !strings.Contains(d.Message, "unsupported schema_version 7") {
+		t.Fatalf("unexpected diagnostics: %#v", result.Diagnostics)
+	}
+}
+
+func TestPreviewFromZipUpload_ManifestSchemaRequirements(t *testing.T) {
+	zipData := createZip(map[string]string{
+		"2027-cybersibir-service-bank/README.md":                       "# Bank\nDesc",
+		"2027-cybersibir-service-bank/vuln-service/docker-compose.yml": "services: {}",
+		"2027-cybersibir-service-bank/vuln-service/" + serviceManifestYAML: "checker-config-v1:\n" +
+			"  id: bank\n" +
+			"  script_path: checker.py\n" +
+			"  script_wait_in_sec: -1\n",
+		"2027-cybersibir-service-bank/checker_bank/checker.py": "exit(101)",
+		"2027-cybersibir-service-bank/writeups/README.md":      "writeup",
+		"2027-cybersibir-service-bank/exploits/poc.py":         "exploit",
+	})
+
+	svc := NewImportService(newMockImportQuerier(), newMemStorage(), 50*1024*1024)
+	preview, err := svc.PreviewFromZipUpload(context.Background(), zipData, true)
+	if err != nil {
+		t.Fatalf("PreviewFromZipUpload: %v", err)
+	}
+	if preview.Valid {
+		t.Fatal("preview should be invalid")
+	}
+	var found bool
+	for _, item := range preview.Requirements {
+		if strings.HasPrefix(item.ID, "manifest_schema_") {
+			found = true
+			if item.Status != "error" || !strings.Contains(item.Message, "line 4") {
+				t.Errorf("requirement = %+v", item)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("no manifest schema requirement in %#v", preview.Requirements)
+	}
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+	"github.com/ctf01d/ctf01d-training-platform/internal/storage"
+)
+
+const (
+	// maxReadmeAssetBytes bounds an image served from a service archive.
+	maxReadmeAssetBytes = 5 * 1024 * 1024
+
+	fieldPath = "path"
+)
+
+// readmeAssetTypes are the files a README may reference from the archive. The
+// asset endpoint serves nothing else, so checkers and exploits stored next to
+// the service stay behind the archive download.
+var readmeAssetTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".svg":  "image/svg+xml",
+}
+
+var (
+	readmeMarkdown = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		// Raw HTML is kept for the <img> and <details> tags READMEs often use;
+		// the output is sanitized afterwards.
+		goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+	)
+	readmePolicy = bluemonday.UGCPolicy()
+)
+
+// ServiceReadme is the README of a service archive version and its rendering.
+type ServiceReadme struct {
+	ServiceID int64
+	// VersionID is nil for services whose README was only recorded at import.
+	VersionID *int64
+	Markdown  string
+	HTML      string
+}
+
+// ReadmeAsset is an image served from a service archive.
+type ReadmeAsset struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// ReadmeAssetURL returns the link a relative README image is rewritten to.
+type ReadmeAssetURL func(serviceID, versionID int64, path string) string
+
+// Readme returns the README of the given service archive version, or of the
+// active one when versionID is nil, rendered to sanitized HTML.
+func (s *ArchiveService) Readme(ctx context.Context, id int64, versionID *int64, isAdmin bool, assetURL ReadmeAssetURL) (*ServiceReadme, error) {
+	svc, err := s.visibleService(ctx, id, Access{Admin: isAdmin})
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.readmeVersion(ctx, svc, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ServiceReadme{ServiceID: id}
+	var rewrite func(string) string
+	switch {
+	case version != nil && version.Readme != nil:
+		result.VersionID = &version.ID
+		result.Markdown = *version.Readme
+		if version.ReadmePath != nil && assetURL != nil {
+			dir := path.Dir(*version.ReadmePath)
+			rewrite = func(src string) string {
+				rel := readmeAssetPath(dir, src)
+				if rel == "" {
+					return src
+				}
+				return assetURL(id, version.ID, rel)
+			}
+		}
+	case versionID == nil && svc.Readme != nil && *svc.Readme != "":
+		result.Markdown = *svc.Readme
+	default:
+		return nil, errs.ErrNotFound
+	}
+
+	rendered, err := renderReadme(result.Markdown, rewrite)
+	if err != nil {
+		return nil, err
+	}
+	result.HTML = rendered
+	return result, nil
+}
+
+// readmeVersion finds the requested service archive version. Without an
+// explicit version it is the active one, which is nil for archives stored
+// before versions were recorded.
+func (s *ArchiveService) readmeVersion(ctx context.Context, svc db.Service, versionID *int64) (*db.ServiceArchiveVersion, error) {
+	if versionID != nil {
+		version, err := s.q.GetServiceArchiveVersion(ctx, db.GetServiceArchiveVersionParams{ID: *versionID, ServiceID: svc.ID})
+		if err != nil {
+			return nil, mapNotFound(err)
+		}
+		if version.Kind != kindService {
+			return nil, errs.ErrNotFound
+		}
+		return &version, nil
+	}
+
+	if svc.ServiceLocalPath == nil {
+		return nil, nil
+	}
+	version, err := s.q.GetServiceArchiveVersionByKey(ctx, db.GetServiceArchiveVersionByKeyParams{
+		ServiceID:  svc.ID,
+		StorageKey: *svc.ServiceLocalPath,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// ReadmeAsset returns an image stored in a service archive version. Only
+// images in the README's directory or below it are served.
+func (s *ArchiveService) ReadmeAsset(ctx context.Context, id, versionID int64, name string, isAdmin bool) (*ReadmeAsset, error) {
+	svc, err := s.visibleService(ctx, id, Access{Admin: isAdmin})
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.readmeVersion(ctx, svc, &versionID)
+	if err != nil {
+		return nil, err
+	}
+	if version.ReadmePath == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	name = safeRelPath(name)
+	contentType := readmeAssetTypes[strings.ToLower(path.Ext(name))]
+	if name == "" || contentType == "" {
+		return nil, errs.NewValidationError(map[string]string{fieldPath: "must be a relative path to an image"})
+	}
+	if !insideDir(path.Dir(*version.ReadmePath), name) {
+		return nil, errs.ErrNotFound
+	}
+
+	rc, err := s.store.Open(ctx, version.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive version: %w", err)
+	}
+	defer rc.Close()
+	zr, err := openStoredZip(rc)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if f.Name != name || f.FileInfo().IsDir() {
+			continue
+		}
+		if f.UncompressedSize64 > maxReadmeAssetBytes {
+			return nil, errs.NewValidationError(map[string]string{fieldPath: "image is too large"})
+		}
+		data, err := readSmallZipEntry(f, maxReadmeAssetBytes)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		return &ReadmeAsset{Name: path.Base(name), ContentType: contentType, Data: data}, nil
+	}
+	return nil, errs.ErrNotFound
+}
+
+// readArchiveReadme reads the README of a stored service archive. Bundles keep
+// it under service/, plain uploads usually at the root. A missing or broken
+// README is not an error: the archive is still a valid version.
+func readArchiveReadme(ctx context.Context, store storage.Storage, key string) (readme, entry *string) {
+	rc, err := store.Open(ctx, key)
+	if err != nil {
+		slog.Warn("failed to open archive for README", "key", key, "error", err)
+		return nil, nil
+	}
+	defer rc.Close()
+	zr, err := openStoredZip(rc)
+	if err != nil {
+		return nil, nil
+	}
+	for _, dir := range []string{"service/", ""} {
+		for _, name := range readmeCandidates {
+			if data := readEntryFromZip(zr, dir+name); data != nil {
+				text, name := string(data), dir+name
+				return &text, &name
+			}
+		}
+	}
+	return nil, nil
+}
+
+// openStoredZip reads the central directory of a stored archive through
+// seeks, without loading the whole object.
+func openStoredZip(rs io.ReadSeeker) (*zip.Reader, error) {
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive version: %w", err)
+	}
+	zr, err := zip.NewReader(seekReaderAt{rs: rs}, size)
+	if err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldVersion: "stored archive is not a valid ZIP file"})
+	}
+	return zr, nil
+}
+
+// renderReadme renders markdown to sanitized HTML. rewrite, when set, maps the
+// src of every image.
+func renderReadme(markdown string, rewrite func(src string) string) (string, error) {
+	var buf bytes.Buffer
+	if err := readmeMarkdown.Convert([]byte(markdown), &buf); err != nil {
+		return "", fmt.Errorf("rendering README: %w", err)
+	}
+	sanitized := readmePolicy.SanitizeBytes(buf.Bytes())
+	if rewrite == nil {
+		return string(sanitized), nil
+	}
+	return rewriteImageSources(sanitized, rewrite)
+}
+
+func rewriteImageSources(fragment []byte, rewrite func(string) string) (string, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(bytes.NewReader(fragment), body)
+	if err != nil {
+		return "", fmt.Errorf("parsing rendered README: %w", err)
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Img {
+			for i, attr := range n.Attr {
+				if attr.Key == "src" {
+					n.Attr[i].Val = rewrite(attr.Val)
+				}
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+
+	var out bytes.Buffer
+	for _, n := range nodes {
+		walk(n)
+		if err := html.Render(&out, n); err != nil {
+			return "", fmt.Errorf("rendering README: %w", err)
+		}
+	}
+	return out.String(), nil
+}
+
+// readmeAssetPath resolves a relative image src against the README's
+// directory. It returns "" for absolute URLs, anchors and paths that leave
+// the directory.
+func readmeAssetPath(dir, src string) string {
+	u, err := url.Parse(strings.TrimSpace(src))
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" || strings.HasPrefix(u.Path, "/") {
+		return ""
+	}
+	resolved := safeRelPath(path.Join(dir, u.Path))
+	if resolved == "" || !insideDir(dir, resolved) {
+		return ""
+	}
+	return resolved
+}
+
+func insideDir(dir, name string) bool {
+	return dir == "." || strings.HasPrefix(name, dir+"/")
+}
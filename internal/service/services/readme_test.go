@@ -0,0 +1,124 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func TestRenderReadme_Sanitizes(t *testing.T) {
+	out, err := renderReadme("# Notes\n\n<script>alert(1)</script>\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n[x](javascript:alert(1))\n", nil)
+	if err != nil {
+		t.Fatalf("renderReadme: %v", err)
+	}
+	for _, bad := range []string{"<script", "javascript:"} {
+		if strings.Contains(out, bad) {
+			t.Errorf("output contains %q: %s", bad, out)
+		}
+	}
+	for _, want := range []string{"<h1", "Notes", "<table>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q: %s", want, out)
+		}
+	}
+}
+
+func TestReadmeAssetPath(t *testing.T) {
+	tests := []struct {
+		dir, src, want string
+	}{
+		{"service", "img/logo.png", "service/img/logo.png"},
+		{"service", "./img/logo.png?raw=1", "service/img/logo.png"},
+		{"service", "../checker/secret.png", ""},
+		{"service", "/abs.png", ""},
+		{"service", "https://example.com/a.png", ""},
+		{"service", "//example.com/a.png", ""},
+		{".", "docs/a.png", "docs/a.png"},
+		{".", "../a.png", ""},
+	}
+	for _, tt := range tests {
+		if got := readmeAssetPath(tt.dir, tt.src); got != tt.want {
+			t.Errorf("readmeAssetPath(%q, %q) = %q, want %q", tt.dir, tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestReadme_StoredPerVersion(t *testing.T) {
+	q := newMockArchiveQuerier()
+	store := newMemStorage()
+	id := q.addService(db.Service{Name: "notes", Public: true})
+	arcSvc := NewArchiveService(q, store, 10*1024*1024)
+	ctx := context.Background()
+
+	archive := createZip(map[string]string{
+		"service/README.md":    "# Notes\n\n![logo](img/logo.png) ![leak](../checker/flag.png) <img src=\"img/raw.gif\">\n",
+		"service/img/logo.png": "png",
+		"checker/flag.png":     "secret",
+	})
+	if _, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(archive), nil, Access{Admin: true}); err != nil {
+		t.Fatalf("UploadArchives: %v", err)
+	}
+	second := createZip(map[string]string{"README.md": "# Notes v2\n"})
+	if _, err := arcSvc.UploadArchives(ctx, id, bytes.NewReader(second), nil, Access{Admin: true}); err != nil {
+		t.Fatalf("UploadArchives: %v", err)
+	}
+
+	assetURL := func(serviceID, versionID int64, path string) string {
+		return "asset:" + path
+	}
+	current, err := arcSvc.Readme(ctx, id, nil, false, assetURL)
+	if err != nil {
+		t.Fatalf("Readme: %v", err)
+	}
+	if current.Markdown != "# Notes v2\n" || current.VersionID == nil {
+		t.Fatalf("current README = %+v", current)
+	}
+
+	firstID := q.versions[0].ID
+	first, err := arcSvc.Readme(ctx, id, &firstID, false, assetURL)
+	if err != nil {
+		t.Fatalf("Readme(first): %v", err)
+	}
+	for _, want := range []string{`src="asset:service/img/logo.png"`, `src="asset:service/img/raw.gif"`, `src="../checker/flag.png"`} {
+		if !strings.Contains(first.HTML, want) {
+			t.Errorf("HTML does not contain %s: %s", want, first.HTML)
+		}
+	}
+
+	asset, err := arcSvc.ReadmeAsset(ctx, id, firstID, "service/img/logo.png", false)
+	if err != nil {
+		t.Fatalf("ReadmeAsset: %v", err)
+	}
+	if asset.ContentType != "image/png" || string(asset.Data) != "png" {
+		t.Errorf("asset = %+v", asset)
+	}
+	if _, err := arcSvc.ReadmeAsset(ctx, id, firstID, "checker/flag.png", false); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("asset outside the README directory: err = %v, want ErrNotFound", err)
+	}
+	var ve *errs.ValidationError
+	if _, err := arcSvc.ReadmeAsset(ctx, id, firstID, "service/README.md", false); !errors.As(err, &ve) {
+		t.Errorf("non-image asset: err = %v, want ValidationError", err)
+	}
+}
+
+func TestReadme_HiddenForNonPublicService(t *testing.T) {
+	q := newMockArchiveQuerier()
+	id := q.addService(db.Service{Name: "notes", Readme: strPtr("# Notes")})
+	arcSvc := NewArchiveService(q, newMemStorage(), 10*1024*1024)
+
+	if _, err := arcSvc.Readme(context.Background(), id, nil, false, nil); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("non-admin: err = %v, want ErrNotFound", err)
+	}
+	readme, err := arcSvc.Readme(context.Background(), id, nil, true, nil)
+	if err != nil {
+		t.Fatalf("admin: %v", err)
+	}
+	if readme.VersionID != nil || !strings.Contains(readme.HTML, "<h1") {
+		t.Errorf("README recorded at import = %+v", readme)
+	}
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+const (
+	// maxSearchTerms bounds how many words of a query reach to_tsquery.
+	maxSearchTerms = 16
+
+	// LicenseNone is the license facet value of services whose LICENSE was
+	// missing or not recognized by detectLicense.
+	LicenseNone = "none"
+
+	snippetMatchStart = '\x02'
+	snippetMatchEnd   = '\x03'
+)
+
+// Games usage buckets of the used-in-games facet.
+const (
+	GamesUsageNone   = "0"
+	GamesUsageOne    = "1"
+	GamesUsageFew    = "2-4"
+	GamesUsageMany   = "5+"
+	gamesUsageFewAt  = 2
+	gamesUsageManyAt = 5
+)
+
+// SearchParams is a catalog search. Values within one filter are alternatives;
+// different filters must all match.
+type SearchParams struct {
+	Query       string
+	TechStack   []string
+	License     []string
+	CheckStatus []string
+	GamesUsage  []string
+	Page        int
+	PerPage     int
+}
+
+// SnippetPart is a piece of a search snippet; Match marks the query terms.
+type SnippetPart struct {
+	Text  string
+	Match bool
+}
+
+type SearchHit struct {
+	Service    ServiceModel
+	Rank       float32
+	GamesCount int
+	Snippet    []SnippetPart
+}
+
+// FacetValue is one value of a facet and how many matching services have it
+// when the other filters are applied.
+type FacetValue struct {
+	Value string
+	Count int
+}
+
+type SearchFacets struct {
+	TechStack   []FacetValue
+	License     []FacetValue
+	CheckStatus []FacetValue
+	GamesUsage  []FacetValue
+}
+
+type SearchResult struct {
+	Items   []SearchHit
+	Facets  SearchFacets
+	Page    int
+	PerPage int
+	Total   int64
+}
+
+type SearchQuerier interface {
+	SearchServiceCandidates(ctx context.Context, arg db.SearchServiceCandidatesParams) ([]db.SearchServiceCandidatesRow, error)
+	SearchServiceSnippets(ctx context.Context, arg db.SearchServiceSnippetsParams) ([]db.SearchServiceSnippetsRow, error)
+	ListServicesByIDs(ctx context.Context, ids []int64) ([]db.Service, error)
+}
+
+// Search runs a full-text search over the catalog: name, tech stack, author,
+// public description and the imported README. Admins also search private
+// descriptions and non-public services. Results are ordered by rank.
+func (s *Service) Search(ctx context.Context, params SearchParams, isAdmin bool) (*SearchResult, error) {
+	page, perPage := params.Page, params.PerPage
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	tsQuery := prefixTSQuery(params.Query)
+	candidates, err := s.q.SearchServiceCandidates(ctx, db.SearchServiceCandidatesParams{
+		Query:          tsQuery,
+		IncludePrivate: isAdmin,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filters := newSearchFilters(params)
+	result := &SearchResult{
+		Items:   []SearchHit{},
+		Facets:  buildSearchFacets(candidates, filters),
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	var matched []db.SearchServiceCandidatesRow
+	for _, c := range candidates {
+		if filters.match(c, "") {
+			matched = append(matched, c)
+		}
+	}
+	result.Total = int64(len(matched))
+
+	start := (page - 1) * perPage
+	if start >= len(matched) {
+		return result, nil
+	}
+	pageRows := matched[start:min(start+perPage, len(matched))]
+	ids := make([]int64, len(pageRows))
+	for i, row := range pageRows {
+		ids[i] = row.ID
+	}
+
+	services, err := s.q.ListServicesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]db.Service, len(services))
+	for _, svc := range services {
+		byID[svc.ID] = svc
+	}
+
+	snippets := map[int64][]SnippetPart{}
+	if tsQuery != "" {
+		rows, err := s.q.SearchServiceSnippets(ctx, db.SearchServiceSnippetsParams{
+			IncludePrivate: isAdmin,
+			Query:          tsQuery,
+			Ids:            ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			snippets[row.ID] = parseSnippet(row.Snippet)
+		}
+	}
+
+	for _, row := range pageRows {
+		svc, ok := byID[row.ID]
+		if !ok {
+			// Deleted between the two queries.
+			continue
+		}
+		result.Items = append(result.Items, SearchHit{
+			Service:    fromDB(svc, isAdmin),
+			Rank:       row.Rank,
+			GamesCount: int(row.GamesCount),
+			Snippet:    snippets[row.ID],
+		})
+	}
+	return result, nil
+}
+
+// prefixTSQuery turns free text into a to_tsquery expression that matches
+// every word as a prefix, so "pyth flask" finds "python" and "flask-login".
+// Only letters and digits are kept, which makes the result safe to pass to
+// to_tsquery without quoting.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = w + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+func parseSnippet(snippet string) []SnippetPart {
+	var parts []SnippetPart
+	match := false
+	var b strings.Builder
+	flush := func() {
+		if b.Len() > 0 {
+			parts = append(parts, SnippetPart{Text: b.String(), Match: match})
+			b.Reset()
+		}
+	}
+	for _, r := range snippet {
+		switch r {
+		case snippetMatchStart:
+			flush()
+			match = true
+		case snippetMatchEnd:
+			flush()
+			match = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	flush()
+	return parts
+}
+
+func gamesUsageBucket(count int32) string {
+	switch {
+	case count <= 0:
+		return GamesUsageNone
+	case count < gamesUsageFewAt:
+		return GamesUsageOne
+	case count < gamesUsageManyAt:
+		return GamesUsageFew
+	default:
+		return GamesUsageMany
+	}
+}
+
+func candidateLicense(c db.SearchServiceCandidatesRow) string {
+	if c.License == nil || *c.License == "" {
+		return LicenseNone
+	}
+	return *c.License
+}
+
+const (
+	facetTechStack   = "tech_stack"
+	facetLicense     = "license"
+	facetCheckStatus = "check_status"
+	facetGamesUsage  = "games_usage"
+)
+
+type searchFilters map[string]map[string]bool
+
+func newSearchFilters(params SearchParams) searchFilters {
+	filters := searchFilters{}
+	add := func(facet string, values []string, fold bool) {
+		for _, v := range values {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			if fold {
+				v = strings.ToLower(v)
+			}
+			if filters[facet] == nil {
+				filters[facet] = map[string]bool{}
+			}
+			filters[facet][v] = true
+		}
+	}
+	add(facetTechStack, params.TechStack, true)
+	add(facetLicense, params.License, false)
+	add(facetCheckStatus, params.CheckStatus, false)
+	add(facetGamesUsage, params.GamesUsage, false)
+	return filters
+}
+
+// match reports whether the candidate passes every filter except skip, which
+// lets a facet count its values as if it had no selection of its own.
+func (f searchFilters) match(c db.SearchServiceCandidatesRow, skip string) bool {
+	for facet, values := range f {
+		if facet == skip {
+			continue
+		}
+		ok := false
+		switch facet {
+		case facetTechStack:
+			for _, tech := range c.TechStack {
+				if values[strings.ToLower(tech)] {
+					ok = true
+					break
+				}
+			}
+		case facetLicense:
+			ok = values[candidateLicense(c)]
+		case facetCheckStatus:
+			ok = values[c.CheckStatus]
+		case facetGamesUsage:
+			ok = values[gamesUsageBucket(c.GamesCount)]
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func buildSearchFacets(candidates []db.SearchServiceCandidatesRow, filters searchFilters) SearchFacets {
+	counts := map[string]map[string]int{
+		facetTechStack:   {},
+		facetLicense:     {},
+		facetCheckStatus: {},
+		facetGamesUsage:  {},
+	}
+	for _, c := range candidates {
+		if filters.match(c, facetTechStack) {
+			seen := map[string]bool{}
+			for _, tech := range c.TechStack {
+				tech = strings.ToLower(tech)
+				if !seen[tech] {
+					seen[tech] = true
+					counts[facetTechStack][tech]++
+				}
+			}
+		}
+		if filters.match(c, facetLicense) {
+			counts[facetLicense][candidateLicense(c)]++
+		}
+		if filters.match(c, facetCheckStatus) {
+			counts[facetCheckStatus][c.CheckStatus]++
+		}
+		if filters.match(c, facetGamesUsage) {
+			counts[facetGamesUsage][gamesUsageBucket(c.GamesCount)]++
+		}
+	}
+
+	return SearchFacets{
+		TechStack:   sortedFacetValues(counts[facetTechStack]),
+		License:     sortedFacetValues(counts[facetLicense]),
+		CheckStatus: sortedFacetValues(counts[facetCheckStatus]),
+		GamesUsage:  orderedFacetValues(counts[facetGamesUsage], GamesUsageNone, GamesUsageOne, GamesUsageFew, GamesUsageMany),
+	}
+}
+
+// sortedFacetValues orders values by count, then alphabetically.
+func sortedFacetValues(counts map[string]int) []FacetValue {
+	values := make([]FacetValue, 0, len(counts))
+	for v, n := range counts {
+		values = append(values, FacetValue{Value: v, Count: n})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+	return values
+}
+
+// orderedFacetValues keeps the buckets in their natural order and includes
+// empty ones so the UI can render a stable list.
+func orderedFacetValues(counts map[string]int, order ...string) []FacetValue {
+	values := make([]FacetValue, len(order))
+	for i, v := range order {
+		values[i] = FacetValue{Value: v, Count: counts[v]}
+	}
+	return values
+}
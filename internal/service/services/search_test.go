@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func (m *mockQuerier) SearchServiceCandidates(_ context.Context, arg db.SearchServiceCandidatesParams) ([]db.SearchServiceCandidatesRow, error) {
+	m.searchArgs = append(m.searchArgs, arg)
+	return m.candidates, nil
+}
+
+func (m *mockQuerier) SearchServiceSnippets(_ context.Context, arg db.SearchServiceSnippetsParams) ([]db.SearchServiceSnippetsRow, error) {
+	var out []db.SearchServiceSnippetsRow
+	for _, id := range arg.Ids {
+		if snippet, ok := m.snippets[id]; ok {
+			out = append(out, db.SearchServiceSnippetsRow{ID: id, Snippet: snippet})
+		}
+	}
+	return out, nil
+}
+
+func (m *mockQuerier) ListServicesByIDs(_ context.Context, ids []int64) ([]db.Service, error) {
+	var out []db.Service
+	for _, id := range ids {
+		if svc, ok := m.services[id]; ok {
+			out = append(out, svc)
+		}
+	}
+	return out, nil
+}
+
+func TestPrefixTSQuery(t *testing.T) {
+	cases := map[string]string{
+		"":                     "",
+		"  Flask ":             "flask:*",
+		"sql-injection & pyth": "sql:* & injection:* & pyth:*",
+		"банк 'OR 1=1 --":      "банк:* & or:* & 1:* & 1:*",
+		"a:* | !b <-> (c)":     "a:* & b:* & c:*",
+	}
+	for in, want := range cases {
+		if got := prefixTSQuery(in); got != want {
+			t.Errorf("prefixTSQuery(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseSnippet(t *testing.T) {
+	got := parseSnippet("A \x02bank\x03 with \x02SQL\x03")
+	want := []SnippetPart{
+		{Text: "A "},
+		{Text: "bank", Match: true},
+		{Text: " with "},
+		{Text: "SQL", Match: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSnippet = %#v, want %#v", got, want)
+	}
+}
+
+func searchFixture() *mockQuerier {
+	q := newMockQuerier()
+	mit := "MIT"
+	for id, name := range map[int64]string{1: "bank", 2: "notes", 3: "shop"} {
+		q.services[id] = db.Service{ID: id, Name: name, Public: true}
+	}
+	q.candidates = []db.SearchServiceCandidatesRow{
+		{ID: 2, TechStack: []string{"Python", "Flask"}, License: &mit, CheckStatus: "ok", GamesCount: 3, Rank: 0.9},
+		{ID: 1, TechStack: []string{"python"}, CheckStatus: "failed", GamesCount: 0, Rank: 0.5},
+		{ID: 3, TechStack: []string{"go"}, License: &mit, CheckStatus: "ok", GamesCount: 7, Rank: 0.1},
+	}
+	q.snippets = map[int64]string{2: "\x02notes\x03 app", 1: "a \x02bank\x03"}
+	return q
+}
+
+func TestSearch_RanksAndSnippets(t *testing.T) {
+	q := searchFixture()
+	svc := NewService(q)
+
+	result, err := svc.Search(context.Background(), SearchParams{Query: "py", PerPage: 2}, false)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if q.searchArgs[0].Query != "py:*" || q.searchArgs[0].IncludePrivate {
+		t.Errorf("search args = %+v", q.searchArgs[0])
+	}
+	if result.Total != 3 || len(result.Items) != 2 {
+		t.Fatalf("Total = %d, items = %d", result.Total, len(result.Items))
+	}
+	if result.Items[0].Service.Name != "notes" || result.Items[1].Service.Name != "bank" {
+		t.Errorf("items are not in rank order: %s, %s", result.Items[0].Service.Name, result.Items[1].Service.Name)
+	}
+	if result.Items[0].GamesCount != 3 || len(result.Items[0].Snippet) != 2 || !result.Items[0].Snippet[0].Match {
+		t.Errorf("first hit = %+v", result.Items[0])
+	}
+
+	page2, err := svc.Search(context.Background(), SearchParams{Query: "py", Page: 2, PerPage: 2}, true)
+	if err != nil {
+		t.Fatalf("Search page 2: %v", err)
+	}
+	if len(page2.Items) != 1 || page2.Items[0].Service.Name != "shop" {
+		t.Errorf("page 2 = %+v", page2.Items)
+	}
+	if !q.searchArgs[1].IncludePrivate {
+		t.Error("admin search must include private descriptions")
+	}
+}
+
+func TestSearch_Facets(t *testing.T) {
+	q := searchFixture()
+	svc := NewService(q)
+
+	result, err := svc.Search(context.Background(), SearchParams{
+		TechStack: []string{"PYTHON"},
+		License:   []string{"MIT"},
+	}, false)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if result.Total != 1 || result.Items[0].Service.ID != 2 {
+		t.Fatalf("items = %+v", result.Items)
+	}
+	if len(result.Items[0].Snippet) != 0 {
+		t.Errorf("empty query must not produce snippets: %+v", result.Items[0].Snippet)
+	}
+
+	// Each facet is counted with the other filters applied but not its own.
+	wantTech := []FacetValue{{Value: "flask", Count: 1}, {Value: "go", Count: 1}, {Value: "python", Count: 1}}
+	if !reflect.DeepEqual(result.Facets.TechStack, wantTech) {
+		t.Errorf("tech facet = %+v, want %+v", result.Facets.TechStack, wantTech)
+	}
+	wantLicense := []FacetValue{{Value: "MIT", Count: 1}, {Value: LicenseNone, Count: 1}}
+	if !reflect.DeepEqual(result.Facets.License, wantLicense) {
+		t.Errorf("license facet = %+v, want %+v", result.Facets.License, wantLicense)
+	}
+	wantUsage := []FacetValue{{Value: GamesUsageNone}, {Value: GamesUsageOne}, {Value: GamesUsageFew, Count: 1}, {Value: GamesUsageMany}}
+	if !reflect.DeepEqual(result.Facets.GamesUsage, wantUsage) {
+		t.Errorf("games facet = %+v, want %+v", result.Facets.GamesUsage, wantUsage)
+	}
+
+	unused, err := svc.Search(context.Background(), SearchParams{GamesUsage: []string{GamesUsageNone}, CheckStatus: []string{"failed"}}, false)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if unused.Total != 1 || unused.Items[0].Service.ID != 1 {
+		t.Errorf("unused items = %+v", unused.Items)
+	}
+}
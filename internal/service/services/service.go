@@ -25,6 +25,13 @@ func strPtrEqual(a, b *string) bool {
 	return *a == *b
 }
 
+func int64PtrEqual(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 type ServiceModel struct {
 	ID                  int64
 	Name                string
@@ -51,20 +58,22 @@ type ServiceModel struct {
 	Ctf01dTraining      json.RawMessage
 	Ports               []int32
 	TechStack           []string
+	License             *string
 	Source              ServiceSourceModel
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
 
 type ServiceSourceModel struct {
-	Kind       string
-	RepoURL    *string
-	Ref        *string
-	Subdir     *string
-	LastCommit *string
-	SyncedAt   *time.Time
-	SyncStatus string
-	SyncError  *string
+	Kind         string
+	RepoURL      *string
+	Ref          *string
+	Subdir       *string
+	CredentialID *int64
+	LastCommit   *string
+	SyncedAt     *time.Time
+	SyncStatus   string
+	SyncError    *string
 }
 
 type ServiceListResult struct {
@@ -119,6 +128,9 @@ type Querier interface {
 	DeleteService(ctx context.Context, id int64) error
 	SetPublic(ctx context.Context, arg db.SetPublicParams) (db.Service, error)
 	SetGitSource(ctx context.Context, arg db.SetGitSourceParams) (db.Service, error)
+	SearchQuerier
+	VulnQuerier
+	AuthorQuerier
 }
 
 type Service struct {
@@ -173,21 +185,22 @@ func (s *Service) Create(ctx context.Context, params CreateParams, isAdmin bool)
 		GitRef:             gitRef,
 		GitSubdir:          gitSubdir,
 		GitSyncStatus:      syncStatusUnknown,
+		GitCredentialID:    gitSourceCredentialID(sourceKind, params.GitSource, nil),
 	})
 	if err != nil {
-		return nil, mapDBError(err)
+		return nil, mapGitSourceDBError(err)
 	}
 
 	svc := fromDB(dbSvc, isAdmin)
 	return &svc, nil
 }
 
-func (s *Service) GetByID(ctx context.Context, id int64, isAdmin bool) (*ServiceModel, error) {
+func (s *Service) GetByID(ctx context.Context, id int64, access Access) (*ServiceModel, error) {
 	dbSvc, err := s.q.GetServiceByID(ctx, id)
 	if err != nil {
 		return nil, mapNotFound(err)
 	}
-	svc := fromDB(dbSvc, isAdmin)
+	svc := fromDB(dbSvc, access.CanManage())
 	return &svc, nil
 }
 
@@ -238,12 +251,17 @@ func (s *Service) List(ctx context.Context, page, perPage int, publicFilter *boo
 	return result, nil
 }
 
-func (s *Service) Update(ctx context.Context, id int64, params UpdateParams, isAdmin bool) (*ServiceModel, error) {
+// Update changes a service. Admins and the service's authors may edit it; the
+// git source stays admin-only because it selects stored credentials.
+func (s *Service) Update(ctx context.Context, id int64, params UpdateParams, access Access) (*ServiceModel, error) {
+	if !access.CanManage() {
+		return nil, errs.ErrForbidden
+	}
 	current, err := s.q.GetServiceByID(ctx, id)
 	if err != nil {
 		return nil, mapNotFound(err)
 	}
-	if params.GitSource != nil && !isAdmin {
+	if params.GitSource != nil && !access.Admin {
 		return nil, errs.ErrForbidden
 	}
 
@@ -324,27 +342,30 @@ func (s *Service) Update(ctx context.Context, id int64, params UpdateParams, isA
 		if err != nil {
 			return nil, errs.NewValidationError(map[string]string{fieldRepoURL: err.Error()})
 		}
+		credentialID := gitSourceCredentialID(sourceKind, params.GitSource, current.GitCredentialID)
 		// Only update git source if it actually changed. Don't reset sync state
 		// on no-op updates, and don't downgrade zip -> manual on empty input.
 		if sourceKind != current.SourceKind ||
 			!strPtrEqual(gitRepoURL, current.GitRepoUrl) ||
 			!strPtrEqual(gitRef, current.GitRef) ||
-			!strPtrEqual(gitSubdir, current.GitSubdir) {
+			!strPtrEqual(gitSubdir, current.GitSubdir) ||
+			!int64PtrEqual(credentialID, current.GitCredentialID) {
 			dbSvc, err = s.q.SetGitSource(ctx, db.SetGitSourceParams{
-				ID:            id,
-				SourceKind:    sourceKind,
-				GitRepoUrl:    gitRepoURL,
-				GitRef:        gitRef,
-				GitSubdir:     gitSubdir,
-				GitSyncStatus: syncStatusUnknown,
+				ID:              id,
+				SourceKind:      sourceKind,
+				GitRepoUrl:      gitRepoURL,
+				GitRef:          gitRef,
+				GitSubdir:       gitSubdir,
+				GitCredentialID: credentialID,
+				GitSyncStatus:   syncStatusUnknown,
 			})
 			if err != nil {
-				return nil, mapDBError(err)
+				return nil, mapGitSourceDBError(err)
 			}
 		}
 	}
 
-	svc := fromDB(dbSvc, isAdmin)
+	svc := fromDB(dbSvc, true)
 	return &svc, nil
 }
 
@@ -387,14 +408,16 @@ func fromDB(s db.Service, isAdmin bool) ServiceModel {
 		Ctf01dTraining:    s.Ctf01dTraining,
 		Ports:             s.Ports,
 		TechStack:         s.TechStack,
+		License:           s.License,
 		Source: ServiceSourceModel{
-			Kind:       s.SourceKind,
-			RepoURL:    s.GitRepoUrl,
-			Ref:        s.GitRef,
-			Subdir:     s.GitSubdir,
-			LastCommit: s.GitLastCommit,
-			SyncStatus: s.GitSyncStatus,
-			SyncError:  s.GitSyncError,
+			Kind:         s.SourceKind,
+			RepoURL:      s.GitRepoUrl,
+			Ref:          s.GitRef,
+			Subdir:       s.GitSubdir,
+			CredentialID: s.GitCredentialID,
+			LastCommit:   s.GitLastCommit,
+			SyncStatus:   s.GitSyncStatus,
+			SyncError:    s.GitSyncError,
 		},
 		CreatedAt: s.CreatedAt,
 		UpdatedAt: s.UpdatedAt,
@@ -517,6 +540,15 @@ func mapDBError(err error) error {
 	return err
 }
 
+// mapGitSourceDBError maps a dangling git_credential_id (the only foreign key
+// on services) to a validation error.
+func mapGitSourceDBError(err error) error {
+	if repository.IsForeignKeyViolation(err) {
+		return errs.NewValidationError(map[string]string{fieldCredentialID: "git credential not found"})
+	}
+	return mapDBError(err)
+}
+
 func int32FromInt64(v int64) (int32, error) {
 	if v < 0 || v > maxInt32Value {
 		return 0, errs.NewValidationError(map[string]string{"pagination": "offset must fit int32"})
@@ -23,6 +23,16 @@ type mockQuerier struct {
 	services map[int64]db.Service
 	byName   map[string]int64
 	nextID   int64
+
+	candidates []db.SearchServiceCandidatesRow
+	snippets   map[int64]string
+	searchArgs []db.SearchServiceCandidatesParams
+
+	vulns     map[int64][]db.ServiceVuln
+	finalized map[int64]bool
+
+	users   map[int64]string
+	authors map[int64][]int64
 }
 
 func newMockQuerier() *mockQuerier {
@@ -68,6 +78,7 @@ func (m *mockQuerier) CreateService(_ context.Context, arg db.CreateServiceParam
 		GitRepoUrl:         arg.GitRepoUrl,
 		GitRef:             arg.GitRef,
 		GitSubdir:          arg.GitSubdir,
+		GitCredentialID:    arg.GitCredentialID,
 		GitSyncStatus:      arg.GitSyncStatus,
 		CreatedAt:          now,
 		UpdatedAt:          now,
@@ -194,6 +205,7 @@ func (m *mockQuerier) SetGitSource(_ context.Context, arg db.SetGitSourceParams)
 	svc.GitRepoUrl = arg.GitRepoUrl
 	svc.GitRef = arg.GitRef
 	svc.GitSubdir = arg.GitSubdir
+	svc.GitCredentialID = arg.GitCredentialID
 	svc.GitLastCommit = nil
 	svc.GitSyncStatus = arg.GitSyncStatus
 	svc.GitSyncError = nil
@@ -334,7 +346,7 @@ func TestGetByID_Success(t *testing.T) {
 
 	mustCreateService(t, svc, CreateParams{Name: "test-service"})
 
-	result, err := svc.GetByID(context.Background(), 1, true)
+	result, err := svc.GetByID(context.Background(), 1, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("GetByID: %v", err)
 	}
@@ -347,7 +359,7 @@ func TestGetByID_NotFound(t *testing.T) {
 	q := newMockQuerier()
 	svc := NewService(q)
 
-	_, err := svc.GetByID(context.Background(), 999, true)
+	_, err := svc.GetByID(context.Background(), 999, Access{Admin: true})
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
@@ -363,12 +375,12 @@ func TestGetByID_AdminMasking(t *testing.T) {
 		PrivateDescription: &private,
 	})
 
-	adminResult, _ := svc.GetByID(context.Background(), 1, true)
+	adminResult, _ := svc.GetByID(context.Background(), 1, Access{Admin: true})
 	if adminResult.PrivateDescription == nil || *adminResult.PrivateDescription != "secret desc" {
 		t.Errorf("admin should see private_description")
 	}
 
-	userResult, _ := svc.GetByID(context.Background(), 1, false)
+	userResult, _ := svc.GetByID(context.Background(), 1, Access{})
 	if userResult.PrivateDescription != nil {
 		t.Errorf("non-admin should not see private_description, got %v", userResult.PrivateDescription)
 	}
@@ -466,7 +478,7 @@ func TestUpdate_Success(t *testing.T) {
 	newName := "updated-service"
 	result, err := svc.Update(context.Background(), 1, UpdateParams{
 		Name: &newName,
-	}, true)
+	}, Access{Admin: true})
 	if err != nil {
 		t.Fatalf("Update: %v", err)
 	}
@@ -481,7 +493,7 @@ func TestUpdate_NotFound(t *testing.T) {
 
 	_, err := svc.Update(context.Background(), 999, UpdateParams{
 		Name: strPtr("x"),
-	}, true)
+	}, Access{Admin: true})
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
@@ -495,7 +507,7 @@ func TestUpdate_InvalidURL(t *testing.T) {
 
 	_, err := svc.Update(context.Background(), 1, UpdateParams{
 		AvatarUrl: strPtr("ftp://bad.com"),
-	}, true)
+	}, Access{Admin: true})
 	if _, ok := err.(*errs.ValidationError); !ok {
 		t.Errorf("expected ValidationError, got %v", err)
 	}
@@ -511,12 +523,54 @@ func TestUpdate_GitSourceRequiresAdmin(t *testing.T) {
 		GitSource: &GitSourceInput{
 			RepoURL: "ssh://git@example.com/team/repo.git",
 		},
-	}, false)
+	}, Access{Author: true})
 	if err != errs.ErrForbidden {
 		t.Fatalf("expected ErrForbidden, got %v", err)
 	}
 }
 
+func TestUpdate_GitSourceKeepsCredentialWhenOmitted(t *testing.T) {
+	q := newMockQuerier()
+	svc := NewService(q)
+
+	credentialID := int64(5)
+	mustCreateService(t, svc, CreateParams{
+		Name: "test-service",
+		GitSource: &GitSourceInput{
+			RepoURL:      "https://example.com/team/repo.git",
+			CredentialID: &credentialID,
+		},
+	})
+
+	result, err := svc.Update(context.Background(), 1, UpdateParams{
+		GitSource: &GitSourceInput{
+			RepoURL: "https://example.com/team/repo.git",
+			Ref:     "develop",
+		},
+	}, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if result.Source.CredentialID == nil || *result.Source.CredentialID != credentialID {
+		t.Fatalf("CredentialID = %v, want %d", result.Source.CredentialID, credentialID)
+	}
+
+	zero := int64(0)
+	result, err = svc.Update(context.Background(), 1, UpdateParams{
+		GitSource: &GitSourceInput{
+			RepoURL:      "https://example.com/team/repo.git",
+			Ref:          "develop",
+			CredentialID: &zero,
+		},
+	}, Access{Admin: true})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if result.Source.CredentialID != nil {
+		t.Errorf("CredentialID = %d, want cleared", *result.Source.CredentialID)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	q := newMockQuerier()
 	svc := NewService(q)
@@ -528,7 +582,7 @@ func TestDelete(t *testing.T) {
 		t.Fatalf("Delete: %v", err)
 	}
 
-	_, err = svc.GetByID(context.Background(), 1, true)
+	_, err = svc.GetByID(context.Background(), 1, Access{Admin: true})
 	if err != errs.ErrNotFound {
 		t.Errorf("expected ErrNotFound after delete, got %v", err)
 	}
@@ -0,0 +1,643 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+const (
+	SubmissionStatusSubmitted        = "submitted"
+	SubmissionStatusChangesRequested = "changes_requested"
+	SubmissionStatusAccepted         = "accepted"
+	SubmissionStatusRejected         = "rejected"
+
+	maxSubmissionCommentLen = 10000
+	submissionKeySuffixLen  = 8
+
+	fieldStatus   = "status"
+	fieldDecision = "decision"
+	fieldBody     = "body"
+	fieldComment  = "comment"
+	fieldParentID = "parent_id"
+)
+
+var submissionStatuses = map[string]bool{
+	SubmissionStatusSubmitted:        true,
+	SubmissionStatusChangesRequested: true,
+	SubmissionStatusAccepted:         true,
+	SubmissionStatusRejected:         true,
+}
+
+// Submission is a service contributed by a player, waiting for or past review.
+type Submission struct {
+	ID          int64
+	SubmitterID int64
+	Status      string
+	SourceKind  string
+	RepoURL     *string
+	Ref         *string
+	Subdir      *string
+	// Commit is the commit a git submission was previewed and is imported at.
+	Commit      *string
+	ServiceName string
+	Preview     *ImportPreview
+	// ServiceID is the service created when the submission was accepted.
+	ServiceID  *int64
+	ReviewerID *int64
+	ReviewedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type SubmissionComment struct {
+	ID           int64
+	SubmissionID int64
+	AuthorID     *int64
+	AuthorName   *string
+	ParentID     *int64
+	Body         string
+	CreatedAt    time.Time
+}
+
+type SubmissionListResult struct {
+	Items   []Submission
+	Page    int
+	PerPage int
+	Total   int64
+}
+
+type SubmissionQuerier interface {
+	CreateServiceSubmission(ctx context.Context, arg db.CreateServiceSubmissionParams) (db.ServiceSubmission, error)
+	GetServiceSubmission(ctx context.Context, id int64) (db.ServiceSubmission, error)
+	LockServiceSubmission(ctx context.Context, id int64) (db.ServiceSubmission, error)
+	ListServiceSubmissions(ctx context.Context, arg db.ListServiceSubmissionsParams) ([]db.ServiceSubmission, error)
+	CountServiceSubmissions(ctx context.Context, arg db.CountServiceSubmissionsParams) (int64, error)
+	ResubmitServiceSubmission(ctx context.Context, arg db.ResubmitServiceSubmissionParams) (db.ServiceSubmission, error)
+	ReviewServiceSubmission(ctx context.Context, arg db.ReviewServiceSubmissionParams) (db.ServiceSubmission, error)
+	ListServiceSubmissionComments(ctx context.Context, submissionID int64) ([]db.ListServiceSubmissionCommentsRow, error)
+	CreateServiceSubmissionComment(ctx context.Context, arg db.CreateServiceSubmissionCommentParams) (db.ServiceSubmissionComment, error)
+	GetServiceSubmissionComment(ctx context.Context, id int64) (db.ServiceSubmissionComment, error)
+	AddServiceAuthor(ctx context.Context, arg db.AddServiceAuthorParams) error
+}
+
+// SubmissionService runs the review queue of player-contributed services.
+// Sources are validated with the import preview on submission and stored as
+// previewed: uploads as they are, git repositories as the fetched tree of the
+// resolved commit. Accepting imports that snapshot, so the service matches
+// what the reviewer saw even if the repository changed since.
+type SubmissionService struct {
+	q       SubmissionQuerier
+	imports *ImportService
+	tx      TxRunner
+}
+
+func NewSubmissionService(q SubmissionQuerier, imports *ImportService) *SubmissionService {
+	return &SubmissionService{q: q, imports: imports}
+}
+
+// SetTxRunner makes a review decision, including the service an acceptance
+// creates, a single transaction.
+func (s *SubmissionService) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+func (s *SubmissionService) runInTx(ctx context.Context, fn func(q *db.Queries) error) error {
+	if s.tx == nil {
+		return fn(nil)
+	}
+	return s.tx.RunInTx(ctx, fn)
+}
+
+// submissionSource is a validated submission source ready to be stored.
+type submissionSource struct {
+	kind       string
+	repoURL    *string
+	ref        *string
+	subdir     *string
+	commit     *string
+	archiveKey *string
+	preview    *ImportPreview
+}
+
+// SubmitGit queues a public git repository for review.
+func (s *SubmissionService) SubmitGit(ctx context.Context, userID int64, req GitImportRequest) (*Submission, error) {
+	src, err := s.prepareGit(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.create(ctx, userID, src)
+}
+
+// SubmitZip queues an uploaded archive for review. The archive is kept in
+// storage until the submission is decided.
+func (s *SubmissionService) SubmitZip(ctx context.Context, userID int64, archive []byte) (*Submission, error) {
+	src, err := s.prepareZip(ctx, userID, archive)
+	if err != nil {
+		return nil, err
+	}
+	return s.create(ctx, userID, src)
+}
+
+// ResubmitGit replaces the source of a submission sent back for changes.
+func (s *SubmissionService) ResubmitGit(ctx context.Context, id, userID int64, req GitImportRequest) (*Submission, error) {
+	current, err := s.resubmittable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	src, err := s.prepareGit(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.resubmit(ctx, current, src)
+}
+
+// ResubmitZip replaces the archive of a submission sent back for changes.
+func (s *SubmissionService) ResubmitZip(ctx context.Context, id, userID int64, archive []byte) (*Submission, error) {
+	current, err := s.resubmittable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	src, err := s.prepareZip(ctx, userID, archive)
+	if err != nil {
+		return nil, err
+	}
+	return s.resubmit(ctx, current, src)
+}
+
+// Get returns a submission to its submitter or an admin.
+func (s *SubmissionService) Get(ctx context.Context, id, userID int64, isAdmin bool) (*Submission, error) {
+	row, err := s.visible(ctx, id, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	return submissionFromDB(row), nil
+}
+
+// List returns the review queue to admins and their own submissions to
+// everyone else.
+func (s *SubmissionService) List(ctx context.Context, page, perPage int, status *string, userID int64, isAdmin bool) (*SubmissionListResult, error) {
+	if status != nil && !submissionStatuses[*status] {
+		return nil, errs.NewValidationError(map[string]string{fieldStatus: "must be submitted, changes_requested, accepted or rejected"})
+	}
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset, err := int32FromInt64(int64(page-1) * int64(perPage))
+	if err != nil {
+		return nil, err
+	}
+	limit, err := int32FromInt64(int64(perPage))
+	if err != nil {
+		return nil, err
+	}
+
+	var submitter *int64
+	if !isAdmin {
+		submitter = &userID
+	}
+	rows, err := s.q.ListServiceSubmissions(ctx, db.ListServiceSubmissionsParams{
+		SubmitterID: submitter,
+		Status:      status,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.q.CountServiceSubmissions(ctx, db.CountServiceSubmissionsParams{
+		SubmitterID: submitter,
+		Status:      status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SubmissionListResult{
+		Items:   make([]Submission, len(rows)),
+		Page:    page,
+		PerPage: perPage,
+		Total:   total,
+	}
+	for i, row := range rows {
+		result.Items[i] = *submissionFromDB(row)
+	}
+	return result, nil
+}
+
+// Review records an admin decision on a queued submission. Accepting imports
+// the service and makes the submitter its author; asking for changes needs a
+// comment telling the submitter what to change. The decision runs in one
+// transaction on the locked submission: of concurrent reviews only the first
+// one counts, and a failed acceptance leaves no service behind.
+func (s *SubmissionService) Review(ctx context.Context, id, reviewerID int64, decision string, comment *string, isAdmin bool) (*Submission, error) {
+	if !isAdmin {
+		return nil, errs.ErrForbidden
+	}
+	switch decision {
+	case SubmissionStatusChangesRequested, SubmissionStatusAccepted, SubmissionStatusRejected:
+	default:
+		return nil, errs.NewValidationError(map[string]string{fieldDecision: "must be changes_requested, accepted or rejected"})
+	}
+	comment = trimmedOrNil(comment)
+	if comment == nil && decision == SubmissionStatusChangesRequested {
+		return nil, errs.NewValidationError(map[string]string{fieldComment: "is required when requesting changes"})
+	}
+	if comment != nil && len(*comment) > maxSubmissionCommentLen {
+		return nil, errs.NewValidationError(map[string]string{fieldComment: fmt.Sprintf("must be at most %d characters", maxSubmissionCommentLen)})
+	}
+
+	store := &recordingStorage{Storage: s.imports.store}
+	var current, row db.ServiceSubmission
+	var created *ServiceModel
+	err := s.runInTx(ctx, func(q *db.Queries) error {
+		subs := s.q
+		if q != nil {
+			subs = q
+		}
+		var err error
+		current, err = subs.LockServiceSubmission(ctx, id)
+		if err != nil {
+			return mapNotFound(err)
+		}
+		if current.Status != SubmissionStatusSubmitted {
+			return errs.ErrConflict
+		}
+
+		var serviceID *int64
+		if decision == SubmissionStatusAccepted {
+			created, err = s.importSubmission(ctx, s.imports.scoped(q, store), current)
+			if err != nil {
+				return err
+			}
+			if err := subs.AddServiceAuthor(ctx, db.AddServiceAuthorParams{ServiceID: created.ID, UserID: current.SubmitterID}); err != nil {
+				return fmt.Errorf("adding submitter as author: %w", err)
+			}
+			serviceID = &created.ID
+		}
+
+		row, err = subs.ReviewServiceSubmission(ctx, db.ReviewServiceSubmissionParams{
+			ID:         id,
+			Status:     decision,
+			ReviewerID: &reviewerID,
+			ServiceID:  serviceID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errs.ErrConflict
+		}
+		if err != nil {
+			return err
+		}
+		if comment != nil {
+			if _, err := subs.CreateServiceSubmissionComment(ctx, db.CreateServiceSubmissionCommentParams{
+				SubmissionID: id,
+				AuthorID:     &reviewerID,
+				Body:         *comment,
+			}); err != nil {
+				return fmt.Errorf("storing review comment: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for _, key := range store.saved {
+			s.imports.versions.discard(ctx, key)
+		}
+		return nil, err
+	}
+
+	s.imports.pruneImported(ctx, created)
+	if decision != SubmissionStatusChangesRequested {
+		s.deleteArchive(ctx, current.ArchiveKey)
+	}
+	return submissionFromDB(row), nil
+}
+
+// ListComments returns the review discussion in posting order; replies point
+// at their parent.
+func (s *SubmissionService) ListComments(ctx context.Context, id, userID int64, isAdmin bool) ([]SubmissionComment, error) {
+	if _, err := s.visible(ctx, id, userID, isAdmin); err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListServiceSubmissionComments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	comments := make([]SubmissionComment, len(rows))
+	for i, row := range rows {
+		comments[i] = SubmissionComment{
+			ID:           row.ID,
+			SubmissionID: row.SubmissionID,
+			AuthorID:     row.AuthorID,
+			AuthorName:   row.AuthorUserName,
+			ParentID:     row.ParentID,
+			Body:         row.Body,
+			CreatedAt:    row.CreatedAt,
+		}
+	}
+	return comments, nil
+}
+
+// AddComment posts to the review discussion of a submission, optionally as a
+// reply to another comment of the same submission.
+func (s *SubmissionService) AddComment(ctx context.Context, id, userID int64, parentID *int64, body string, isAdmin bool) (*SubmissionComment, error) {
+	body = strings.TrimSpace(body)
+	switch {
+	case body == "":
+		return nil, errs.NewValidationError(map[string]string{fieldBody: "is required"})
+	case len(body) > maxSubmissionCommentLen:
+		return nil, errs.NewValidationError(map[string]string{fieldBody: fmt.Sprintf("must be at most %d characters", maxSubmissionCommentLen)})
+	}
+	if _, err := s.visible(ctx, id, userID, isAdmin); err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		parent, err := s.q.GetServiceSubmissionComment(ctx, *parentID)
+		if err != nil || parent.SubmissionID != id {
+			return nil, errs.NewValidationError(map[string]string{fieldParentID: "comment not found in this submission"})
+		}
+	}
+
+	row, err := s.q.CreateServiceSubmissionComment(ctx, db.CreateServiceSubmissionCommentParams{
+		SubmissionID: id,
+		AuthorID:     &userID,
+		ParentID:     parentID,
+		Body:         body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SubmissionComment{
+		ID:           row.ID,
+		SubmissionID: row.SubmissionID,
+		AuthorID:     row.AuthorID,
+		ParentID:     row.ParentID,
+		Body:         row.Body,
+		CreatedAt:    row.CreatedAt,
+	}, nil
+}
+
+func (s *SubmissionService) create(ctx context.Context, userID int64, src *submissionSource) (*Submission, error) {
+	preview, err := json.Marshal(src.preview)
+	if err != nil {
+		return nil, fmt.Errorf("encoding preview: %w", err)
+	}
+	row, err := s.q.CreateServiceSubmission(ctx, db.CreateServiceSubmissionParams{
+		SubmitterID: userID,
+		SourceKind:  src.kind,
+		RepoUrl:     src.repoURL,
+		GitRef:      src.ref,
+		GitSubdir:   src.subdir,
+		GitCommit:   src.commit,
+		ArchiveKey:  src.archiveKey,
+		ServiceName: src.preview.ServiceName,
+		Preview:     preview,
+	})
+	if err != nil {
+		s.deleteArchive(ctx, src.archiveKey)
+		return nil, err
+	}
+	return submissionFromDB(row), nil
+}
+
+func (s *SubmissionService) resubmit(ctx context.Context, current db.ServiceSubmission, src *submissionSource) (*Submission, error) {
+	preview, err := json.Marshal(src.preview)
+	if err != nil {
+		return nil, fmt.Errorf("encoding preview: %w", err)
+	}
+	row, err := s.q.ResubmitServiceSubmission(ctx, db.ResubmitServiceSubmissionParams{
+		ID:          current.ID,
+		SourceKind:  src.kind,
+		RepoUrl:     src.repoURL,
+		GitRef:      src.ref,
+		GitSubdir:   src.subdir,
+		GitCommit:   src.commit,
+		ArchiveKey:  src.archiveKey,
+		ServiceName: src.preview.ServiceName,
+		Preview:     preview,
+	})
+	if err != nil {
+		s.deleteArchive(ctx, src.archiveKey)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrConflict
+		}
+		return nil, err
+	}
+	s.deleteArchive(ctx, current.ArchiveKey)
+	return submissionFromDB(row), nil
+}
+
+// resubmittable loads a submission its submitter may replace: one an admin
+// sent back for changes.
+func (s *SubmissionService) resubmittable(ctx context.Context, id, userID int64) (db.ServiceSubmission, error) {
+	current, err := s.visible(ctx, id, userID, false)
+	if err != nil {
+		return db.ServiceSubmission{}, err
+	}
+	if current.Status != SubmissionStatusChangesRequested {
+		return db.ServiceSubmission{}, errs.ErrConflict
+	}
+	return current, nil
+}
+
+// visible loads a submission for its submitter or an admin; anyone else gets
+// ErrNotFound so submissions of other players stay hidden.
+func (s *SubmissionService) visible(ctx context.Context, id, userID int64, isAdmin bool) (db.ServiceSubmission, error) {
+	row, err := s.q.GetServiceSubmission(ctx, id)
+	if err != nil {
+		return db.ServiceSubmission{}, mapNotFound(err)
+	}
+	if !isAdmin && row.SubmitterID != userID {
+		return db.ServiceSubmission{}, errs.ErrNotFound
+	}
+	return row, nil
+}
+
+// prepareGit previews a submitted repository. Only public https repositories
+// are accepted: players must not reach local paths, internal hosts or the
+// server's stored credentials. Fetch errors are logged but not returned, as
+// git's output would tell the player what the server can connect to.
+func (s *SubmissionService) prepareGit(ctx context.Context, userID int64, req GitImportRequest) (*submissionSource, error) {
+	ref, err := parseGitRepoURL(req.RepoURL)
+	if err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldRepoURL: err.Error()})
+	}
+	if ref.Scheme != "https" {
+		return nil, errs.NewValidationError(map[string]string{fieldRepoURL: "must be a public https repository URL"})
+	}
+	req.CredentialID = nil
+	req.Anonymous = true
+
+	fetched, err := s.imports.gitFetcher.Fetch(ctx, req)
+	if err != nil {
+		slog.Info("failed to fetch submitted repository", "repo_url", redactURLCredentials(req.RepoURL), "error", err)
+		return nil, errs.NewValidationError(map[string]string{fieldRepoURL: "could not fetch the repository: check that it is public and the ref exists"})
+	}
+	preview, err := s.imports.previewArchive(ctx, fetched.ZipBytes, fetched.Source, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	preview.Subdir = fetched.Subdir
+	if err := validatePreparedImport(preview, fieldRepoURL); err != nil {
+		return nil, err
+	}
+
+	key, err := s.saveArchive(ctx, userID, fetched.ZipBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &submissionSource{
+		kind:       sourceGit,
+		repoURL:    optionalImportedString(fetched.RepoURL),
+		ref:        optionalImportedString(fetched.Ref),
+		subdir:     optionalImportedString(fetched.Subdir),
+		commit:     optionalImportedString(fetched.Commit),
+		archiveKey: &key,
+		preview:    preview,
+	}, nil
+}
+
+// prepareZip previews an uploaded archive and stores it for the reviewer.
+func (s *SubmissionService) prepareZip(ctx context.Context, userID int64, archive []byte) (*submissionSource, error) {
+	if len(archive) == 0 {
+		return nil, errs.NewValidationError(map[string]string{fieldArchive: "file is required"})
+	}
+	zipBytes, err := normalizeArchive(archive)
+	if err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldArchive: err.Error()})
+	}
+	preview, err := s.imports.previewArchive(ctx, zipBytes, importSourceInfo{Source: sourceZip}, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePreparedImport(preview, fieldArchive); err != nil {
+		return nil, err
+	}
+
+	key, err := s.saveArchive(ctx, userID, zipBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &submissionSource{kind: sourceZip, archiveKey: &key, preview: preview}, nil
+}
+
+// saveArchive stores the previewed source of a submission for the reviewer
+// and for the import on acceptance.
+func (s *SubmissionService) saveArchive(ctx context.Context, userID int64, zipBytes []byte) (string, error) {
+	key := submissionArchiveKey(userID, time.Now())
+	if _, err := s.imports.store.Save(ctx, key, bytes.NewReader(zipBytes)); err != nil {
+		return "", fmt.Errorf("saving submitted archive: %w", err)
+	}
+	return key, nil
+}
+
+// importSubmission creates the service of an accepted submission from the
+// stored snapshot. It never updates an existing service: a name taken since
+// submission is a conflict.
+func (s *SubmissionService) importSubmission(ctx context.Context, imports *ImportService, sub db.ServiceSubmission) (*ServiceModel, error) {
+	if sub.ArchiveKey == nil {
+		return nil, errs.NewValidationError(map[string]string{fieldArchive: "submitted source is missing; request changes to have it submitted again"})
+	}
+	rc, err := imports.store.Open(ctx, *sub.ArchiveKey)
+	if err != nil {
+		return nil, errs.NewValidationError(map[string]string{fieldArchive: "submitted archive is missing from storage"})
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading submitted archive: %w", err)
+	}
+
+	switch sub.SourceKind {
+	case sourceGit:
+		ref, err := parseGitRepoURL(derefString(sub.RepoUrl))
+		if err != nil {
+			return nil, errs.NewValidationError(map[string]string{fieldRepoURL: err.Error()})
+		}
+		repo := &fetchedGitRepo{
+			ZipBytes: data,
+			Commit:   derefString(sub.GitCommit),
+			Ref:      derefString(sub.GitRef),
+			RepoURL:  ref.RepoURL,
+			Subdir:   derefString(sub.GitSubdir),
+			Source:   ref.sourceInfo(),
+		}
+		prepared, err := imports.prepareImport(ctx, repo.ZipBytes, repo.Source, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := validatePreparedImport(prepared.Preview, fieldRepoURL); err != nil {
+			return nil, err
+		}
+		result, err := imports.importPrepared(ctx, repo, prepared, false)
+		if err != nil {
+			return nil, err
+		}
+		return result.Service, nil
+	case sourceZip:
+		result, err := imports.ImportFromZip(ctx, data, false)
+		if err != nil {
+			return nil, err
+		}
+		return result.Service, nil
+	default:
+		return nil, fmt.Errorf("unknown submission source %q", sub.SourceKind)
+	}
+}
+
+func (s *SubmissionService) deleteArchive(ctx context.Context, key *string) {
+	if key == nil {
+		return
+	}
+	if err := s.imports.store.Delete(ctx, *key); err != nil {
+		slog.Warn("failed to delete submitted archive", "key", *key, "error", err)
+	}
+}
+
+func submissionArchiveKey(userID int64, at time.Time) string {
+	suffix := make([]byte, submissionKeySuffixLen)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("submissions/%d/%s-%s.zip", userID, at.UTC().Format("20060102T150405"), hex.EncodeToString(suffix))
+}
+
+func submissionFromDB(row db.ServiceSubmission) *Submission {
+	sub := &Submission{
+		ID:          row.ID,
+		SubmitterID: row.SubmitterID,
+		Status:      row.Status,
+		SourceKind:  row.SourceKind,
+		RepoURL:     row.RepoUrl,
+		Ref:         row.GitRef,
+		Subdir:      row.GitSubdir,
+		Commit:      row.GitCommit,
+		ServiceName: row.ServiceName,
+		ServiceID:   row.ServiceID,
+		ReviewerID:  row.ReviewerID,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+	if row.ReviewedAt.Valid {
+		sub.ReviewedAt = &row.ReviewedAt.Time
+	}
+	var preview ImportPreview
+	if err := json.Unmarshal(row.Preview, &preview); err == nil {
+		sub.Preview = &preview
+	}
+	return sub
+}
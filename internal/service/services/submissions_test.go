@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+type mockSubmissionQuerier struct {
+	submissions map[int64]*db.ServiceSubmission
+	comments    []db.ServiceSubmissionComment
+	authors     []db.AddServiceAuthorParams
+	nextID      int64
+}
+
+func newMockSubmissionQuerier() *mockSubmissionQuerier {
+	return &mockSubmissionQuerier{submissions: make(map[int64]*db.ServiceSubmission), nextID: 1}
+}
+
+func (m *mockSubmissionQuerier) CreateServiceSubmission(_ context.Context, arg db.CreateServiceSubmissionParams) (db.ServiceSubmission, error) {
+	now := time.Now()
+	row := &db.ServiceSubmission{
+		ID:          m.nextID,
+		SubmitterID: arg.SubmitterID,
+		Status:      SubmissionStatusSubmitted,
+		SourceKind:  arg.SourceKind,
+		RepoUrl:     arg.RepoUrl,
+		GitRef:      arg.GitRef,
+		GitSubdir:   arg.GitSubdir,
+		GitCommit:   arg.GitCommit,
+		ArchiveKey:  arg.ArchiveKey,
+		ServiceName: arg.ServiceName,
+		Preview:     arg.Preview,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	m.submissions[row.ID] = row
+	m.nextID++
+	return *row, nil
+}
+
+func (m *mockSubmissionQuerier) GetServiceSubmission(_ context.Context, id int64) (db.ServiceSubmission, error) {
+	row, ok := m.submissions[id]
+	if !ok {
+		return db.ServiceSubmission{}, pgx.ErrNoRows
+	}
+	return *row, nil
+}
+
+func (m *mockSubmissionQuerier) LockServiceSubmission(ctx context.Context, id int64) (db.ServiceSubmission, error) {
+	return m.GetServiceSubmission(ctx, id)
+}
+
+func (m *mockSubmissionQuerier) ListServiceSubmissions(_ context.Context, arg db.ListServiceSubmissionsParams) ([]db.ServiceSubmission, error) {
+	var rows []db.ServiceSubmission
+	for id := int64(1); id < m.nextID; id++ {
+		row, ok := m.submissions[id]
+		if !ok || (arg.SubmitterID != nil && row.SubmitterID != *arg.SubmitterID) || (arg.Status != nil && row.Status != *arg.Status) {
+			continue
+		}
+		rows = append(rows, *row)
+	}
+	return rows, nil
+}
+
+func (m *mockSubmissionQuerier) CountServiceSubmissions(ctx context.Context, arg db.CountServiceSubmissionsParams) (int64, error) {
+	rows, err := m.ListServiceSubmissions(ctx, db.ListServiceSubmissionsParams{SubmitterID: arg.SubmitterID, Status: arg.Status})
+	return int64(len(rows)), err
+}
+
+func (m *mockSubmissionQuerier) ResubmitServiceSubmission(_ context.Context, arg db.ResubmitServiceSubmissionParams) (db.ServiceSubmission, error) {
+	row, ok := m.submissions[arg.ID]
+	if !ok || row.Status != SubmissionStatusChangesRequested {
+		return db.ServiceSubmission{}, pgx.ErrNoRows
+	}
+	row.Status = SubmissionStatusSubmitted
+	row.SourceKind = arg.SourceKind
+	row.RepoUrl, row.GitRef, row.GitSubdir, row.GitCommit = arg.RepoUrl, arg.GitRef, arg.GitSubdir, arg.GitCommit
+	row.ArchiveKey = arg.ArchiveKey
+	row.ServiceName = arg.ServiceName
+	row.Preview = arg.Preview
+	row.UpdatedAt = time.Now()
+	return *row, nil
+}
+
+func (m *mockSubmissionQuerier) ReviewServiceSubmission(_ context.Context, arg db.ReviewServiceSubmissionParams) (db.ServiceSubmission, error) {
+	row, ok := m.submissions[arg.ID]
+	if !ok || row.Status != SubmissionStatusSubmitted {
+		return db.ServiceSubmission{}, pgx.ErrNoRows
+	}
+	row.Status = arg.Status
+	row.ReviewerID = arg.ReviewerID
+	row.ServiceID = arg.ServiceID
+	row.ReviewedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	return *row, nil
+}
+
+func (m *mockSubmissionQuerier) ListServiceSubmissionComments(_ context.Context, submissionID int64) ([]db.ListServiceSubmissionCommentsRow, error) {
+	var rows []db.ListServiceSubmissionCommentsRow
+	for _, c := range m.comments {
+		if c.SubmissionID == submissionID {
+			rows = append(rows, db.ListServiceSubmissionCommentsRow{
+				ID:           c.ID,
+				SubmissionID: c.SubmissionID,
+				AuthorID:     c.AuthorID,
+				ParentID:     c.ParentID,
+				Body:         c.Body,
+				CreatedAt:    c.CreatedAt,
+			})
+		}
+	}
+	return rows, nil
+}
+
+func (m *mockSubmissionQuerier) CreateServiceSubmissionComment(_ context.Context, arg db.CreateServiceSubmissionCommentParams) (db.ServiceSubmissionComment, error) {
+	c := db.ServiceSubmissionComment{
+		ID:           int64(len(m.comments) + 1),
+		SubmissionID: arg.SubmissionID,
+		AuthorID:     arg.AuthorID,
+		ParentID:     arg.ParentID,
+		Body:         arg.Body,
+		CreatedAt:    time.Now(),
+	}
+	m.comments = append(m.comments, c)
+	return c, nil
+}
+
+func (m *mockSubmissionQuerier) GetServiceSubmissionComment(_ context.Context, id int64) (db.ServiceSubmissionComment, error) {
+	if id < 1 || id > int64(len(m.comments)) {
+		return db.ServiceSubmissionComment{}, pgx.ErrNoRows
+	}
+	return m.comments[id-1], nil
+}
+
+func (m *mockSubmissionQuerier) AddServiceAuthor(_ context.Context, arg db.AddServiceAuthorParams) error {
+	m.authors = append(m.authors, arg)
+	return nil
+}
+
+func TestSubmission_ReviewFlow(t *testing.T) {
+	ctx := context.Background()
+	q := newMockSubmissionQuerier()
+	store := newMemStorage()
+	imports := NewImportService(newMockImportQuerier(), store, 50*1024*1024)
+	svc := NewSubmissionService(q, imports)
+	tx := &recordingTx{}
+	svc.SetTxRunner(tx)
+	const player, admin = int64(7), int64(1)
+
+	sub, err := svc.SubmitZip(ctx, player, createSourceImportZip("repo", "bank", "Bank", "v1", nil))
+	if err != nil {
+		t.Fatalf("SubmitZip: %v", err)
+	}
+	if sub.Status != SubmissionStatusSubmitted || sub.ServiceName != "bank" || sub.Preview == nil {
+		t.Fatalf("submission = %+v", sub)
+	}
+	firstKey := *q.submissions[sub.ID].ArchiveKey
+
+	if _, err := svc.Review(ctx, sub.ID, admin, SubmissionStatusChangesRequested, nil, true); !isValidationError(err) {
+		t.Fatalf("changes without comment: expected validation error, got %v", err)
+	}
+	comment := "add an exploit for the second vuln"
+	if _, err := svc.Review(ctx, sub.ID, admin, SubmissionStatusChangesRequested, &comment, true); err != nil {
+		t.Fatalf("Review(changes_requested): %v", err)
+	}
+	if _, err := svc.Review(ctx, sub.ID, admin, SubmissionStatusAccepted, nil, true); !errors.Is(err, errs.ErrConflict) {
+		t.Fatalf("review of a returned submission: expected ErrConflict, got %v", err)
+	}
+
+	if _, err := svc.ResubmitZip(ctx, sub.ID, player+1, createSourceImportZip("repo", "bank", "Bank", "v2", nil)); !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("resubmit by another player: expected ErrNotFound, got %v", err)
+	}
+	sub, err = svc.ResubmitZip(ctx, sub.ID, player, createSourceImportZip("repo", "bank", "Bank", "v2", nil))
+	if err != nil {
+		t.Fatalf("ResubmitZip: %v", err)
+	}
+	if sub.Status != SubmissionStatusSubmitted {
+		t.Fatalf("Status = %q, want %q", sub.Status, SubmissionStatusSubmitted)
+	}
+	if _, ok := store.files[firstKey]; ok {
+		t.Error("replaced archive should be deleted")
+	}
+
+	sub, err = svc.Review(ctx, sub.ID, admin, SubmissionStatusAccepted, nil, true)
+	if err != nil {
+		t.Fatalf("Review(accepted): %v", err)
+	}
+	if sub.ServiceID == nil || sub.ReviewedAt == nil {
+		t.Fatalf("accepted submission = %+v", sub)
+	}
+	if len(q.authors) != 1 || q.authors[0].UserID != player || q.authors[0].ServiceID != *sub.ServiceID {
+		t.Errorf("authors = %+v, want submitter linked to the service", q.authors)
+	}
+	if tx.calls != 3 {
+		t.Errorf("RunInTx calls = %d, want one per reviewed decision", tx.calls)
+	}
+	for key := range store.files {
+		if strings.HasPrefix(key, "submissions/") {
+			t.Errorf("submitted archive %s should be deleted after the decision", key)
+		}
+	}
+
+	comments, err := svc.ListComments(ctx, sub.ID, player, false)
+	if err != nil {
+		t.Fatalf("ListComments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Body != comment {
+		t.Errorf("comments = %+v", comments)
+	}
+}
+
+func TestSubmission_Visibility(t *testing.T) {
+	ctx := context.Background()
+	q := newMockSubmissionQuerier()
+	imports := NewImportService(newMockImportQuerier(), newMemStorage(), 50*1024*1024)
+	svc := NewSubmissionService(q, imports)
+
+	sub, err := svc.SubmitZip(ctx, 7, createSourceImportZip("repo", "bank", "Bank", "", nil))
+	if err != nil {
+		t.Fatalf("SubmitZip: %v", err)
+	}
+	other, err := svc.SubmitZip(ctx, 8, createSourceImportZip("repo", "shop", "Shop", "", nil))
+	if err != nil {
+		t.Fatalf("SubmitZip: %v", err)
+	}
+
+	if _, err := svc.Get(ctx, sub.ID, 8, false); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("Get by another player: expected ErrNotFound, got %v", err)
+	}
+	if _, err := svc.Get(ctx, sub.ID, 1, true); err != nil {
+		t.Errorf("Get by admin: %v", err)
+	}
+	if _, err := svc.Review(ctx, sub.ID, 7, SubmissionStatusAccepted, nil, false); !errors.Is(err, errs.ErrForbidden) {
+		t.Errorf("Review by player: expected ErrForbidden, got %v", err)
+	}
+
+	list, err := svc.List(ctx, 1, 20, nil, 7, false)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if list.Total != 1 || list.Items[0].ID != sub.ID {
+		t.Errorf("player list = %+v, want only their submission", list.Items)
+	}
+	list, err = svc.List(ctx, 1, 20, nil, 1, true)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if list.Total != 2 {
+		t.Errorf("admin list total = %d, want 2", list.Total)
+	}
+
+	parent, err := svc.AddComment(ctx, other.ID, 8, nil, "question", false)
+	if err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if _, err := svc.AddComment(ctx, sub.ID, 7, &parent.ID, "reply", false); !isValidationError(err) {
+		t.Errorf("reply to a comment of another submission: expected validation error, got %v", err)
+	}
+	reply, err := svc.AddComment(ctx, other.ID, 1, &parent.ID, "answer", true)
+	if err != nil {
+		t.Fatalf("AddComment(reply): %v", err)
+	}
+	if reply.ParentID == nil || *reply.ParentID != parent.ID {
+		t.Errorf("ParentID = %v, want %d", reply.ParentID, parent.ID)
+	}
+}
+
+func TestSubmission_GitRequiresPublicHTTPS(t *testing.T) {
+	imports := NewImportService(newMockImportQuerier(), newMemStorage(), 50*1024*1024)
+	svc := NewSubmissionService(newMockSubmissionQuerier(), imports)
+
+	for _, repoURL := range []string{
+		"git@github.com:test/bank.git",
+		"ssh://git@example.com/test/bank.git",
+		"/srv/repos/bank",
+	} {
+		_, err := svc.SubmitGit(context.Background(), 7, GitImportRequest{RepoURL: repoURL})
+		if !isValidationError(err) {
+			t.Errorf("SubmitGit(%q): expected validation error, got %v", repoURL, err)
+		}
+	}
+}
+
+func TestSubmission_GitAcceptImportsReviewedCommit(t *testing.T) {
+	ctx := context.Background()
+	iq := newMockImportQuerier()
+	imports := NewImportService(iq, newMemStorage(), 50*1024*1024)
+	reviewed := strings.Repeat("a", 40)
+	imports.gitFetcher = fakeGitFetcher{fetched: &fetchedGitRepo{
+		ZipBytes: createSourceImportZip("bank", "bank", "Bank", "reviewed", nil),
+		Commit:   reviewed,
+		RepoURL:  "https://example.com/team/bank",
+		Source:   importSourceInfo{Source: sourceGit, Host: "example.com", Owner: "team", Repo: "bank", Path: "team/bank"},
+	}}
+	svc := NewSubmissionService(newMockSubmissionQuerier(), imports)
+
+	sub, err := svc.SubmitGit(ctx, 7, GitImportRequest{RepoURL: "https://example.com/team/bank"})
+	if err != nil {
+		t.Fatalf("SubmitGit: %v", err)
+	}
+	if sub.Commit == nil || *sub.Commit != reviewed {
+		t.Fatalf("Commit = %v, want %s", sub.Commit, reviewed)
+	}
+
+	// The submitter pushes after review; accepting must not fetch again.
+	imports.gitFetcher = fakeGitFetcher{err: errors.New("repository fetched on acceptance")}
+	sub, err = svc.Review(ctx, sub.ID, 1, SubmissionStatusAccepted, nil, true)
+	if err != nil {
+		t.Fatalf("Review(accepted): %v", err)
+	}
+	created := iq.services[*sub.ServiceID]
+	if created.GitLastCommit == nil || *created.GitLastCommit != reviewed {
+		t.Errorf("GitLastCommit = %v, want %s", created.GitLastCommit, reviewed)
+	}
+	if created.PublicDescription == nil || *created.PublicDescription != "reviewed" {
+		t.Errorf("PublicDescription = %v, want the reviewed snapshot", created.PublicDescription)
+	}
+}
+
+func TestSubmission_GitFetchErrorHidesDetails(t *testing.T) {
+	imports := NewImportService(newMockImportQuerier(), newMemStorage(), 50*1024*1024)
+	imports.gitFetcher = fakeGitFetcher{err: errors.New("git clone: fatal: unable to access 'https://example.com/team/bank/': Failed to connect to 10.0.0.5 port 443")}
+	svc := NewSubmissionService(newMockSubmissionQuerier(), imports)
+
+	_, err := svc.SubmitGit(context.Background(), 7, GitImportRequest{RepoURL: "https://example.com/team/bank"})
+	if !isValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if strings.Contains(err.(*errs.ValidationError).Fields[fieldRepoURL], "10.0.0.5") {
+		t.Errorf("error reveals git output: %v", err)
+	}
+}
+
+func TestExecGitArchiveFetcher_AnonymousRejectsInternalHosts(t *testing.T) {
+	blockedIPCheck = isBlockedIP
+	t.Cleanup(func() { blockedIPCheck = func(net.IP) bool { return false } })
+
+	fetcher := newExecGitArchiveFetcher(0)
+	for _, repoURL := range []string{
+		"https://127.0.0.1/team/bank",
+		"https://169.254.169.254/latest/meta-data",
+		"https://10.0.0.1:8443/team/bank",
+		"https://[::1]/team/bank",
+	} {
+		_, err := fetcher.Fetch(context.Background(), GitImportRequest{RepoURL: repoURL, Anonymous: true})
+		if err == nil || !strings.Contains(err.Error(), errBlockedHost.Error()) {
+			t.Errorf("Fetch(%q): expected blocked host error, got %v", repoURL, err)
+		}
+	}
+}
+
+func isValidationError(err error) bool {
+	var ve *errs.ValidationError
+	return errors.As(err, &ve)
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+const (
+	// maxServiceVulns bounds the catalog of a single service.
+	maxServiceVulns = 50
+	maxVulnTitleLen = 200
+	maxVulnFixLen   = 10000
+
+	fieldVulns = "vulns"
+)
+
+var (
+	vulnDifficulties = map[string]bool{"easy": true, "medium": true, "hard": true}
+	cweRe            = regexp.MustCompile(`^(?i:cwe-)?([1-9][0-9]{0,4})$`)
+)
+
+// Vuln is an intended vulnerability of a service.
+type Vuln struct {
+	ID         int64
+	ServiceID  int64
+	Title      string
+	CWE        *string
+	Difficulty *string
+	// Exploit is the path of the exploit under exploits/ that demonstrates
+	// the vulnerability.
+	Exploit *string
+	Fix     *string
+}
+
+type VulnInput struct {
+	Title      string
+	CWE        *string
+	Difficulty *string
+	Exploit    *string
+	Fix        *string
+}
+
+type VulnQuerier interface {
+	ListServiceVulns(ctx context.Context, serviceID int64) ([]db.ServiceVuln, error)
+	IsServiceInFinalizedGame(ctx context.Context, serviceID int64) (bool, error)
+	vulnReplacer
+}
+
+// vulnReplacer is the part of VulnQuerier imports need.
+type vulnReplacer interface {
+	ReplaceServiceVulns(ctx context.Context, arg db.ReplaceServiceVulnsParams) error
+}
+
+// ListVulns returns the vulnerability catalog of a service. Admins and authors
+// always see it; everyone else only once a game with the service has been
+// finalized.
+func (s *Service) ListVulns(ctx context.Context, serviceID int64, access Access) ([]Vuln, error) {
+	svc, err := s.q.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	if !access.CanManage() {
+		if !svc.Public {
+			return nil, errs.ErrNotFound
+		}
+		revealed, err := s.q.IsServiceInFinalizedGame(ctx, serviceID)
+		if err != nil {
+			return nil, err
+		}
+		if !revealed {
+			return nil, errs.ErrForbidden
+		}
+	}
+
+	rows, err := s.q.ListServiceVulns(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return vulnsFromDB(rows), nil
+}
+
+// ReplaceVulns replaces the vulnerability catalog of a service with vulns, in
+// the given order.
+func (s *Service) ReplaceVulns(ctx context.Context, serviceID int64, vulns []VulnInput, access Access) ([]Vuln, error) {
+	if !access.CanManage() {
+		return nil, errs.ErrForbidden
+	}
+	if _, err := s.q.GetServiceByID(ctx, serviceID); err != nil {
+		return nil, mapNotFound(err)
+	}
+
+	normalized, err := normalizeVulns(vulns)
+	if err != nil {
+		return nil, err
+	}
+	if err := replaceServiceVulns(ctx, s.q, serviceID, normalized); err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListServiceVulns(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return vulnsFromDB(rows), nil
+}
+
+func replaceServiceVulns(ctx context.Context, q vulnReplacer, serviceID int64, vulns []VulnInput) error {
+	arg := db.ReplaceServiceVulnsParams{
+		ServiceID:    serviceID,
+		Titles:       make([]string, len(vulns)),
+		Cwes:         make([]string, len(vulns)),
+		Difficulties: make([]string, len(vulns)),
+		ExploitPaths: make([]string, len(vulns)),
+		Fixes:        make([]string, len(vulns)),
+	}
+	for i, v := range vulns {
+		arg.Titles[i] = v.Title
+		arg.Cwes[i] = derefString(v.CWE)
+		arg.Difficulties[i] = derefString(v.Difficulty)
+		arg.ExploitPaths[i] = derefString(v.Exploit)
+		arg.Fixes[i] = derefString(v.Fix)
+	}
+	if err := q.ReplaceServiceVulns(ctx, arg); err != nil {
+		return fmt.Errorf("replacing service vulns: %w", err)
+	}
+	return nil
+}
+
+// normalizeVulns trims the input, writes CWE ids as CWE-<n> and checks that
+// exploits are relative paths inside exploits/.
+func normalizeVulns(vulns []VulnInput) ([]VulnInput, error) {
+	if len(vulns) > maxServiceVulns {
+		return nil, errs.NewValidationError(map[string]string{fieldVulns: fmt.Sprintf("at most %d vulnerabilities are allowed", maxServiceVulns)})
+	}
+
+	fields := map[string]string{}
+	out := make([]VulnInput, len(vulns))
+	for i, v := range vulns {
+		prefix := fmt.Sprintf("%s[%d].", fieldVulns, i)
+		n := VulnInput{
+			Title:      strings.TrimSpace(v.Title),
+			CWE:        trimmedOrNil(v.CWE),
+			Difficulty: trimmedOrNil(v.Difficulty),
+			Exploit:    trimmedOrNil(v.Exploit),
+			Fix:        trimmedOrNil(v.Fix),
+		}
+		switch {
+		case n.Title == "":
+			fields[prefix+"title"] = "is required"
+		case len(n.Title) > maxVulnTitleLen:
+			fields[prefix+"title"] = fmt.Sprintf("must be at most %d characters", maxVulnTitleLen)
+		}
+		if n.CWE != nil {
+			m := cweRe.FindStringSubmatch(*n.CWE)
+			if m == nil {
+				fields[prefix+"cwe"] = "must be a CWE id such as CWE-89"
+			} else {
+				cwe := "CWE-" + m[1]
+				n.CWE = &cwe
+			}
+		}
+		if n.Difficulty != nil {
+			difficulty := strings.ToLower(*n.Difficulty)
+			if !vulnDifficulties[difficulty] {
+				fields[prefix+"difficulty"] = "must be easy, medium or hard"
+			}
+			n.Difficulty = &difficulty
+		}
+		if n.Exploit != nil {
+			exploit := safeRelPath(strings.TrimPrefix(*n.Exploit, "exploits/"))
+			if exploit == "" {
+				fields[prefix+"exploit"] = "must be a relative path inside exploits/"
+			}
+			n.Exploit = &exploit
+		}
+		if n.Fix != nil && len(*n.Fix) > maxVulnFixLen {
+			fields[prefix+"fix"] = fmt.Sprintf("must be at most %d characters", maxVulnFixLen)
+		}
+		out[i] = n
+	}
+	if len(fields) > 0 {
+		return nil, errs.NewValidationError(fields)
+	}
+	return out, nil
+}
+
+func validationFieldsMessage(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key + " " + fields[key]
+	}
+	return strings.Join(parts, "; ")
+}
+
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	return optionalImportedString(*value)
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func vulnsFromDB(rows []db.ServiceVuln) []Vuln {
+	vulns := make([]Vuln, len(rows))
+	for i, row := range rows {
+		vulns[i] = Vuln{
+			ID:         row.ID,
+			ServiceID:  row.ServiceID,
+			Title:      row.Title,
+			CWE:        row.Cwe,
+			Difficulty: row.Difficulty,
+			Exploit:    row.ExploitPath,
+			Fix:        row.Fix,
+		}
+	}
+	return vulns
+}
+
+// importVulns replaces the vulnerability catalog with the manifest's vulns
+// section. Manifests without the section leave the catalog alone, so a list
+// maintained on the platform survives a sync.
+func (s *ImportService) importVulns(ctx context.Context, serviceID int64, meta *BundleMetadata) error {
+	if meta == nil || meta.Manifest == nil || !meta.Manifest.HasVulns {
+		return nil
+	}
+	vulns, err := normalizeVulns(meta.Manifest.Vulns)
+	if err != nil {
+		return err
+	}
+	return replaceServiceVulns(ctx, s.q, serviceID, vulns)
+}
+
+// addVulnRequirements reports the manifest's vulns section in the preview.
+func addVulnRequirements(preview *ImportPreview, meta *BundleMetadata) {
+	if meta == nil || meta.Manifest == nil || !meta.Manifest.HasVulns {
+		return
+	}
+	title := "vulnerabilities"
+	if _, err := normalizeVulns(meta.Manifest.Vulns); err != nil {
+		var ve *errs.ValidationError
+		if errors.As(err, &ve) {
+			preview.addRequirement(fieldVulns, title, "error", validationFieldsMessage(ve.Fields))
+			return
+		}
+		preview.addRequirement(fieldVulns, title, "error", err.Error())
+		return
+	}
+	preview.addRequirement(fieldVulns, title, "ok", fmt.Sprintf("%d described in %s", len(meta.Manifest.Vulns), serviceManifestYAML))
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
+	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
+)
+
+func replaceMockVulns(store *map[int64][]db.ServiceVuln, arg db.ReplaceServiceVulnsParams) {
+	if *store == nil {
+		*store = make(map[int64][]db.ServiceVuln)
+	}
+	optional := func(v string) *string {
+		if v == "" {
+			return nil
+		}
+		return &v
+	}
+	rows := make([]db.ServiceVuln, len(arg.Titles))
+	for i := range arg.Titles {
+		rows[i] = db.ServiceVuln{
+			ID:          int64(i + 1),
+			ServiceID:   arg.ServiceID,
+			Position:    int32(i + 1),
+			Title:       arg.Titles[i],
+			Cwe:         optional(arg.Cwes[i]),
+			Difficulty:  optional(arg.Difficulties[i]),
+			ExploitPath: optional(arg.ExploitPaths[i]),
+			Fix:         optional(arg.Fixes[i]),
+		}
+	}
+	(*store)[arg.ServiceID] = rows
+}
+
+func (m *mockQuerier) ListServiceVulns(_ context.Context, serviceID int64) ([]db.ServiceVuln, error) {
+	return m.vulns[serviceID], nil
+}
+
+func (m *mockQuerier) ReplaceServiceVulns(_ context.Context, arg db.ReplaceServiceVulnsParams) error {
+	replaceMockVulns(&m.vulns, arg)
+	return nil
+}
+
+func (m *mockQuerier) IsServiceInFinalizedGame(_ context.Context, serviceID int64) (bool, error) {
+	return m.finalized[serviceID], nil
+}
+
+func (m *mockImportQuerier) ReplaceServiceVulns(_ context.Context, arg db.ReplaceServiceVulnsParams) error {
+	replaceMockVulns(&m.vulns, arg)
+	return nil
+}
+
+func TestNormalizeVulns(t *testing.T) {
+	vulns, err := normalizeVulns([]VulnInput{{
+		Title:      " SQL injection in login ",
+		CWE:        strPtr("89"),
+		Difficulty: strPtr("Medium"),
+		Exploit:    strPtr("exploits/sqli.py"),
+		Fix:        strPtr("  "),
+	}})
+	if err != nil {
+		t.Fatalf("normalizeVulns: %v", err)
+	}
+	v := vulns[0]
+	if v.Title != "SQL injection in login" || *v.CWE != "CWE-89" || *v.Difficulty != "medium" || *v.Exploit != "sqli.py" || v.Fix != nil {
+		t.Errorf("normalized = %+v", v)
+	}
+
+	_, err = normalizeVulns([]VulnInput{{CWE: strPtr("SQLi"), Difficulty: strPtr("insane"), Exploit: strPtr("../checker/checker.py")}})
+	var ve *errs.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	for _, field := range []string{"vulns[0].title", "vulns[0].cwe", "vulns[0].difficulty", "vulns[0].exploit"} {
+		if _, ok := ve.Fields[field]; !ok {
+			t.Errorf("missing error for %s in %v", field, ve.Fields)
+		}
+	}
+}
+
+func TestListVulns_RevealedAfterFinalizedGame(t *testing.T) {
+	q := newMockQuerier()
+	svc := NewService(q)
+	created := mustCreateService(t, svc, CreateParams{Name: "notes", Public: true})
+	ctx := context.Background()
+
+	if _, err := svc.ReplaceVulns(ctx, created.ID, []VulnInput{{Title: "IDOR"}}, Access{}); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("non-admin replace: err = %v, want ErrForbidden", err)
+	}
+	if _, err := svc.ReplaceVulns(ctx, created.ID, []VulnInput{{Title: "IDOR"}, {Title: "SSTI", CWE: strPtr("CWE-1336")}}, Access{Admin: true}); err != nil {
+		t.Fatalf("ReplaceVulns: %v", err)
+	}
+
+	if _, err := svc.ListVulns(ctx, created.ID, Access{}); !errors.Is(err, errs.ErrForbidden) {
+		t.Fatalf("before finalization: err = %v, want ErrForbidden", err)
+	}
+	if vulns, err := svc.ListVulns(ctx, created.ID, Access{Admin: true}); err != nil || len(vulns) != 2 {
+		t.Fatalf("admin: vulns = %+v, err = %v", vulns, err)
+	}
+
+	q.finalized = map[int64]bool{created.ID: true}
+	vulns, err := svc.ListVulns(ctx, created.ID, Access{})
+	if err != nil {
+		t.Fatalf("after finalization: %v", err)
+	}
+	if len(vulns) != 2 || vulns[1].Title != "SSTI" || *vulns[1].CWE != "CWE-1336" {
+		t.Errorf("vulns = %+v", vulns)
+	}
+}
+
+func TestImportFromZip_ImportsManifestVulns(t *testing.T) {
+	manifest := `checker-config-v1:
+  id: notes
+  script_path: ./checker.py
+vulns:
+  - title: Path traversal in export
+    cwe: 22
+    difficulty: easy
+    exploit: traversal.py
+    fix: Resolve paths against the export directory.
+`
+	archive := createZip(map[string]string{
+		"repo/README.md":                       "# Notes",
+		"repo/.ctf01d-service.yml":             manifest,
+		"repo/vuln-service/docker-compose.yml": "services:\n  notes:\n    build: .\n    restart: always\n",
+		"repo/vuln-service/app.py":             "print('service')\n",
+		"repo/checker_notes/checker.py":        "exit(101)\nexit(102)\nexit(103)\nexit(104)\n",
+		"repo/writeups/README.md":              "writeup\n",
+		"repo/exploits/traversal.py":           "exploit\n",
+	})
+
+	q := newMockImportQuerier()
+	svc := NewImportService(q, newMemStorage(), 50*1024*1024)
+	result, err := svc.ImportFromZip(context.Background(), archive, true)
+	if err != nil {
+		t.Fatalf("ImportFromZip: %v", err)
+	}
+	vulns := q.vulns[result.Service.ID]
+	if len(vulns) != 1 {
+		t.Fatalf("vulns = %+v", vulns)
+	}
+	if v := vulns[0]; v.Title != "Path traversal in export" || *v.Cwe != "CWE-22" || *v.ExploitPath != "traversal.py" {
+		t.Errorf("imported vuln = %+v", v)
+	}
+}
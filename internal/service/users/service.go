@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
@@ -13,23 +14,25 @@ import (
 )
 
 type User struct {
-	ID          int64      `json:"id"`
-	UserName    string     `json:"user_name"`
-	DisplayName string     `json:"display_name"`
-	Language    string     `json:"language"`
-	Theme       string     `json:"theme"`
-	Role        string     `json:"role"`
-	Rating      int        `json:"rating"`
-	AvatarUrl   *string    `json:"avatar_url,omitempty"`
-	Bio         *string    `json:"bio,omitempty"`
-	Telegram    *string    `json:"telegram,omitempty"`
-	Github      *string    `json:"github,omitempty"`
-	Email       *string    `json:"email,omitempty"`
-	LastLoginIp *string    `json:"last_login_ip,omitempty"`
-	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
-	IsBlocked   bool       `json:"is_blocked"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID          int64   `json:"id"`
+	UserName    string  `json:"user_name"`
+	DisplayName string  `json:"display_name"`
+	Language    string  `json:"language"`
+	Theme       string  `json:"theme"`
+	Role        string  `json:"role"`
+	Rating      int     `json:"rating"`
+	AvatarUrl   *string `json:"avatar_url,omitempty"`
+	Bio         *string `json:"bio,omitempty"`
+	Telegram    *string `json:"telegram,omitempty"`
+	Github      *string `json:"github,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	// EmailVerifiedAt is set once a link sent to Email has been followed.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	LastLoginIp     *string    `json:"last_login_ip,omitempty"`
+	LastLoginAt     *time.Time `json:"last_login_at,omitempty"`
+	IsBlocked       bool       `json:"is_blocked"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 type UserListResult struct {
@@ -123,21 +126,49 @@ type Querier interface {
 	SetUserBlocked(ctx context.Context, arg db.SetUserBlockedParams) (db.User, error)
 	ClearUserTeamCaptaincy(ctx context.Context, captainID *int32) error
 	DeleteUser(ctx context.Context, id int64) error
+	InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error
+}
+
+type TxRunner interface {
+	RunInTx(ctx context.Context, fn func(queries *db.Queries) error) error
 }
 
 type Service struct {
-	q Querier
+	q  Querier
+	tx TxRunner
 }
 
 func NewService(q Querier) *Service {
 	return &Service{q: q}
 }
 
+// SetTxRunner makes role changes and blocking commit together with their
+// audit events.
+func (s *Service) SetTxRunner(tx TxRunner) {
+	s.tx = tx
+}
+
+func (s *Service) inTx(ctx context.Context, fn func(q Querier) error) error {
+	if s.tx != nil {
+		return s.tx.RunInTx(ctx, func(q *db.Queries) error { return fn(q) })
+	}
+	return fn(s.q)
+}
+
 const (
 	minPasswordLength       = 6
 	passwordTooShortMessage = "must be at least 6 characters"
 )
 
+// ValidatePassword applies the password policy shared by every flow that sets
+// a password.
+func ValidatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return errs.NewValidationError(map[string]string{fieldPassword: passwordTooShortMessage})
+	}
+	return nil
+}
+
 func (s *Service) Create(ctx context.Context, params CreateParams) (*User, error) {
 	if params.UserName == "" || !userNameRegex.MatchString(params.UserName) {
 		return nil, errs.NewValidationError(map[string]string{
@@ -149,10 +180,8 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (*User, error
 			"display_name": "is required",
 		})
 	}
-	if len(params.Password) < minPasswordLength {
-		return nil, errs.NewValidationError(map[string]string{
-			fieldPassword: passwordTooShortMessage,
-		})
+	if err := ValidatePassword(params.Password); err != nil {
+		return nil, err
 	}
 
 	role := params.Role
@@ -256,8 +285,8 @@ func (s *Service) Update(ctx context.Context, id int64, params UpdateParams) (*U
 
 	var passwordDigest *string
 	if params.Password != nil {
-		if len(*params.Password) < minPasswordLength {
-			return nil, errs.NewValidationError(map[string]string{fieldPassword: passwordTooShortMessage})
+		if err := ValidatePassword(*params.Password); err != nil {
+			return nil, err
 		}
 		hash, err := auth.HashPassword(*params.Password)
 		if err != nil {
@@ -316,8 +345,8 @@ func (s *Service) UpdateProfile(ctx context.Context, id int64, params ProfileUpd
 
 	var passwordDigest *string
 	if params.Password != nil {
-		if len(*params.Password) < minPasswordLength {
-			return nil, errs.NewValidationError(map[string]string{fieldPassword: passwordTooShortMessage})
+		if err := ValidatePassword(*params.Password); err != nil {
+			return nil, err
 		}
 		hash, err := auth.HashPassword(*params.Password)
 		if err != nil {
@@ -350,8 +379,8 @@ func (s *Service) UpdateProfile(ctx context.Context, id int64, params ProfileUpd
 // untouched. It is intentionally separate from the profile update so changing a
 // password — a critical action — can never clobber other profile data.
 func (s *Service) ChangePassword(ctx context.Context, id int64, password string) (*User, error) {
-	if len(password) < minPasswordLength {
-		return nil, errs.NewValidationError(map[string]string{fieldPassword: passwordTooShortMessage})
+	if err := ValidatePassword(password); err != nil {
+		return nil, err
 	}
 	hash, err := auth.HashPassword(password)
 	if err != nil {
@@ -386,18 +415,58 @@ func (s *Service) SetAvatar(ctx context.Context, id int64, url *string) (*User,
 // SetBlocked toggles the blocked flag for a user. Revoking active sessions is
 // the caller's responsibility (handled in the auth service).
 func (s *Service) SetBlocked(ctx context.Context, id int64, blocked bool) (*User, error) {
-	dbUser, err := s.q.SetUserBlocked(ctx, db.SetUserBlockedParams{ID: id, IsBlocked: blocked})
+	current, err := s.q.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, mapNotFound(err)
 	}
+	action := audit.ActionUnblock
+	if blocked {
+		action = audit.ActionBlock
+	}
+	var dbUser db.User
+	err = s.inTx(ctx, func(q Querier) error {
+		var err error
+		dbUser, err = q.SetUserBlocked(ctx, db.SetUserBlockedParams{ID: id, IsBlocked: blocked})
+		if err != nil {
+			return mapNotFound(err)
+		}
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityUser,
+			EntityID:   id,
+			Action:     action,
+			Before:     map[string]bool{"is_blocked": current.IsBlocked},
+			After:      map[string]bool{"is_blocked": dbUser.IsBlocked},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
 	u := fromDB(dbUser)
 	return &u, nil
 }
 
 func (s *Service) UpdateRole(ctx context.Context, id int64, role string) (*User, error) {
-	dbUser, err := s.q.UpdateUserRole(ctx, db.UpdateUserRoleParams{
-		ID:   id,
-		Role: role,
+	current, err := s.q.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	var dbUser db.User
+	err = s.inTx(ctx, func(q Querier) error {
+		var err error
+		dbUser, err = q.UpdateUserRole(ctx, db.UpdateUserRoleParams{
+			ID:   id,
+			Role: role,
+		})
+		if err != nil {
+			return err
+		}
+		return audit.Record(ctx, q, audit.Entry{
+			EntityType: audit.EntityUser,
+			EntityID:   id,
+			Action:     audit.ActionChangeRole,
+			Before:     map[string]string{"role": current.Role},
+			After:      map[string]string{"role": dbUser.Role},
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -439,28 +508,32 @@ func (s *Service) Delete(ctx context.Context, id int64) error {
 }
 
 func fromDB(u db.User) User {
-	var lastLoginAt *time.Time
+	var lastLoginAt, emailVerifiedAt *time.Time
 	if u.LastLoginAt.Valid {
 		lastLoginAt = &u.LastLoginAt.Time
 	}
+	if u.EmailVerifiedAt.Valid {
+		emailVerifiedAt = &u.EmailVerifiedAt.Time
+	}
 	return User{
-		ID:          u.ID,
-		UserName:    u.UserName,
-		DisplayName: u.DisplayName,
-		Language:    userLanguage(u.Language),
-		Theme:       userTheme(u.Theme),
-		Role:        u.Role,
-		Rating:      int(u.Rating),
-		AvatarUrl:   u.AvatarUrl,
-		Bio:         u.Bio,
-		Telegram:    u.Telegram,
-		Github:      u.Github,
-		Email:       u.Email,
-		LastLoginIp: u.LastLoginIp,
-		LastLoginAt: lastLoginAt,
-		IsBlocked:   u.IsBlocked,
-		CreatedAt:   u.CreatedAt,
-		UpdatedAt:   u.UpdatedAt,
+		ID:              u.ID,
+		UserName:        u.UserName,
+		DisplayName:     u.DisplayName,
+		Language:        userLanguage(u.Language),
+		Theme:           userTheme(u.Theme),
+		Role:            u.Role,
+		Rating:          int(u.Rating),
+		AvatarUrl:       u.AvatarUrl,
+		Bio:             u.Bio,
+		Telegram:        u.Telegram,
+		Github:          u.Github,
+		Email:           u.Email,
+		EmailVerifiedAt: emailVerifiedAt,
+		LastLoginIp:     u.LastLoginIp,
+		LastLoginAt:     lastLoginAt,
+		IsBlocked:       u.IsBlocked,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/domain/errs"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 )
@@ -16,6 +17,7 @@ type mockQuerier struct {
 	users  map[int64]db.User
 	nextID int64
 	byName map[string]int64
+	audit  []db.InsertAuditEventParams
 }
 
 func newMockQuerier() *mockQuerier {
@@ -153,6 +155,11 @@ func (m *mockQuerier) SetUserAvatar(_ context.Context, arg db.SetUserAvatarParam
 	return u, nil
 }
 
+func (m *mockQuerier) InsertAuditEvent(_ context.Context, arg db.InsertAuditEventParams) error {
+	m.audit = append(m.audit, arg)
+	return nil
+}
+
 func (m *mockQuerier) SetUserBlocked(_ context.Context, arg db.SetUserBlockedParams) (db.User, error) {
 	u, ok := m.users[arg.ID]
 	if !ok {
@@ -427,6 +434,16 @@ func TestUpdateRole(t *testing.T) {
 	if u.Role != "admin" {
 		t.Errorf("Role = %q, want %q", u.Role, "admin")
 	}
+	if len(q.audit) != 1 || q.audit[0].Action != audit.ActionChangeRole {
+		t.Fatalf("audit events = %+v, want one role change", q.audit)
+	}
+	if string(q.audit[0].Before) != `{"role":"guest"}` || string(q.audit[0].After) != `{"role":"admin"}` {
+		t.Errorf("audit diff = %s -> %s", q.audit[0].Before, q.audit[0].After)
+	}
+
+	if _, err := svc.UpdateRole(context.Background(), 999, "admin"); err != errs.ErrNotFound {
+		t.Errorf("UpdateRole of unknown user: %v, want ErrNotFound", err)
+	}
 }
 
 func TestDelete(t *testing.T) {
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// s3PartSize is the chunk uploads are streamed in. Objects smaller than one
+// part are sent with a single PUT, so their server-side SHA256 covers the whole
+// object.
+const s3PartSize = 16 * 1024 * 1024
+
+// S3Options configures S3Storage.
+type S3Options struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Prefix    string
+	UseSSL    bool
+}
+
+// S3Storage keeps files in an S3-compatible bucket.
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+func NewS3Storage(ctx context.Context, opts S3Options) (*S3Storage, error) {
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+		// Trailing headers carry the SHA256 checksum of streamed uploads.
+		TrailingHeaders: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating S3 client: %w", err)
+	}
+	exists, err := client.BucketExists(ctx, opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("checking S3 bucket: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("S3 bucket %q does not exist", opts.Bucket)
+	}
+	return &S3Storage{client: client, bucket: opts.Bucket, prefix: strings.Trim(opts.Prefix, "/")}, nil
+}
+
+// Save streams r to the bucket. The server verifies the SHA256 of every part;
+// for single-part objects its checksum is the file hash and must match ours.
+func (s *S3Storage) Save(ctx context.Context, key string, r io.Reader) (FileInfo, error) {
+	name, err := s.objectName(key)
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	hash := sha256.New()
+	info, err := s.client.PutObject(ctx, s.bucket, name, io.TeeReader(r, hash), -1, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+		Checksum:    minio.ChecksumSHA256,
+		PartSize:    s3PartSize,
+	})
+	if err != nil {
+		return FileInfo{}, fmt.Errorf("uploading object: %w", err)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if serverSum, ok := fullObjectSHA256(info.ChecksumSHA256); ok && serverSum != sum {
+		_ = s.client.RemoveObject(ctx, s.bucket, name, minio.RemoveObjectOptions{})
+		return FileInfo{}, fmt.Errorf("uploading object: checksum mismatch for %s", key)
+	}
+	return FileInfo{Size: info.Size, SHA256: sum}, nil
+}
+
+func (s *S3Storage) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	name, err := s.objectName(key)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.client.GetObject(ctx, s.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("opening object: %w", err)
+	}
+	// GetObject is lazy; Stat surfaces a missing key before the caller reads.
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, s.mapError(err, key, "opening object")
+	}
+	return obj, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	name, err := s.objectName(key)
+	if err != nil {
+		return err
+	}
+	if err := s.client.RemoveObject(ctx, s.bucket, name, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("deleting object: %w", err)
+	}
+	return nil
+}
+
+// Stat reports the server-side SHA256 of the object. Multipart objects only
+// have a checksum of their part checksums, so those are hashed by reading them.
+func (s *S3Storage) Stat(ctx context.Context, key string) (FileInfo, error) {
+	name, err := s.objectName(key)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	info, err := s.client.StatObject(ctx, s.bucket, name, minio.StatObjectOptions{Checksum: true})
+	if err != nil {
+		return FileInfo{}, s.mapError(err, key, "stating object")
+	}
+	if sum, ok := fullObjectSHA256(info.ChecksumSHA256); ok {
+		return FileInfo{Size: info.Size, SHA256: sum}, nil
+	}
+
+	obj, err := s.client.GetObject(ctx, s.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return FileInfo{}, fmt.Errorf("opening object for hash: %w", err)
+	}
+	defer obj.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, obj); err != nil {
+		return FileInfo{}, s.mapError(err, key, "computing hash")
+	}
+	return FileInfo{Size: info.Size, SHA256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+func (s *S3Storage) objectName(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return "", fmt.Errorf("%w: key must be a relative path", ErrInvalidKey)
+	}
+	if strings.Contains(key, "..") {
+		return "", fmt.Errorf("%w: key contains path traversal", ErrInvalidKey)
+	}
+	if s.prefix == "" {
+		return key, nil
+	}
+	return path.Join(s.prefix, key), nil
+}
+
+func (s *S3Storage) mapError(err error, key, action string) error {
+	resp := minio.ToErrorResponse(err)
+	if resp.Code == minio.NoSuchKey || resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrFileNotFound, key)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// fullObjectSHA256 decodes a base64 SHA256 checksum into hex. Composite
+// checksums of multipart uploads end in "-<parts>" and are not file hashes.
+func fullObjectSHA256(checksum string) (string, bool) {
+	if checksum == "" || strings.Contains(checksum, "-") {
+		return "", false
+	}
+	raw, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil || len(raw) != sha256.Size {
+		return "", false
+	}
+	return hex.EncodeToString(raw), true
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestFullObjectSHA256(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+	encoded := base64.StdEncoding.EncodeToString(sum[:])
+
+	got, ok := fullObjectSHA256(encoded)
+	if !ok || got != hex.EncodeToString(sum[:]) {
+		t.Errorf("fullObjectSHA256(%q) = %q, %v", encoded, got, ok)
+	}
+	for _, composite := range []string{"", encoded + "-3", "not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, ok := fullObjectSHA256(composite); ok {
+			t.Errorf("fullObjectSHA256(%q) should not be a file hash", composite)
+		}
+	}
+}
+
+func TestS3StorageObjectName(t *testing.T) {
+	s := &S3Storage{prefix: "ctf01d"}
+	name, err := s.objectName("services/1/service.zip")
+	if err != nil || name != "ctf01d/services/1/service.zip" {
+		t.Errorf("objectName = %q, %v", name, err)
+	}
+	for _, key := range []string{"", "/etc/passwd", "services/../../secret"} {
+		if _, err := s.objectName(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("objectName(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+// TestS3StorageRoundTrip runs against a real S3-compatible server, e.g.
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//
+// with S3_TEST_ENDPOINT=localhost:9000 and an existing S3_TEST_BUCKET.
+func TestS3StorageRoundTrip(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT not set")
+	}
+	ctx := context.Background()
+	s, err := NewS3Storage(ctx, S3Options{
+		Endpoint:  endpoint,
+		Bucket:    os.Getenv("S3_TEST_BUCKET"),
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		Prefix:    "storage-test",
+	})
+	if err != nil {
+		t.Fatalf("NewS3Storage: %v", err)
+	}
+
+	for name, content := range map[string][]byte{
+		"single-part": []byte("hello world"),
+		"multipart":   bytes.Repeat([]byte("ctf01d"), s3PartSize/3),
+	} {
+		t.Run(name, func(t *testing.T) {
+			key := "services/1/" + name + ".zip"
+			sum := sha256.Sum256(content)
+			fi, err := s.Save(ctx, key, bytes.NewReader(content))
+			if err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			t.Cleanup(func() { _ = s.Delete(ctx, key) })
+			if fi.Size != int64(len(content)) || fi.SHA256 != hex.EncodeToString(sum[:]) {
+				t.Errorf("Save = %+v", fi)
+			}
+
+			statFi, err := s.Stat(ctx, key)
+			if err != nil {
+				t.Fatalf("Stat: %v", err)
+			}
+			if statFi != fi {
+				t.Errorf("Stat = %+v, want %+v", statFi, fi)
+			}
+
+			rc, err := s.Open(ctx, key)
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			defer rc.Close()
+			if _, err := rc.Seek(int64(len(content))-5, io.SeekStart); err != nil {
+				t.Fatalf("Seek: %v", err)
+			}
+			tail, err := io.ReadAll(rc)
+			if err != nil || !bytes.Equal(tail, content[len(content)-5:]) {
+				t.Errorf("read after seek = %q, %v", tail, err)
+			}
+		})
+	}
+
+	if _, err := s.Open(ctx, "services/1/missing.zip"); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Open(missing) error = %v, want ErrFileNotFound", err)
+	}
+	if _, err := s.Stat(ctx, "services/1/missing.zip"); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Stat(missing) error = %v, want ErrFileNotFound", err)
+	}
+	if err := s.Delete(ctx, "services/1/missing.zip"); err != nil {
+		t.Errorf("Delete(missing): %v", err)
+	}
+}
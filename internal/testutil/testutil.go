@@ -55,7 +55,9 @@ func TruncateAll(t *testing.T, store *repository.Store) {
 		"results",
 		"game_teams",
 		"games_services",
+		"service_archive_versions",
 		"services",
+		"git_credentials",
 		"games",
 		"team_membership_events",
 		"team_memberships",
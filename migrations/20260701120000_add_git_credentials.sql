@@ -0,0 +1,35 @@
+-- +goose Up
+-- Credentials for cloning private git repositories. Secrets are encrypted by
+-- the application (GIT_CREDENTIALS_KEY) before they reach the database.
+
+CREATE TABLE git_credentials (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    kind text NOT NULL,
+    host_pattern text,
+    username text,
+    secret_ciphertext bytea NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT git_credentials_kind_check CHECK (kind IN ('https_token', 'ssh_key'))
+);
+
+CREATE UNIQUE INDEX index_git_credentials_on_name ON git_credentials (name);
+
+CREATE TRIGGER set_git_credentials_updated_at
+    BEFORE UPDATE ON git_credentials
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+ALTER TABLE services ADD COLUMN git_credential_id bigint;
+
+CREATE INDEX index_services_on_git_credential_id ON services (git_credential_id);
+
+ALTER TABLE ONLY services
+    ADD CONSTRAINT fk_services_git_credential_id
+    FOREIGN KEY (git_credential_id) REFERENCES git_credentials(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE services DROP CONSTRAINT fk_services_git_credential_id;
+DROP INDEX IF EXISTS index_services_on_git_credential_id;
+ALTER TABLE services DROP COLUMN git_credential_id;
+DROP TABLE IF EXISTS git_credentials;
@@ -0,0 +1,51 @@
+-- +goose Up
+-- Every stored service/checker archive is kept as a version so a bad redownload,
+-- upload or git sync can be rolled back. services.*_local_path points at the
+-- storage_key of the active version.
+
+CREATE TABLE service_archive_versions (
+    id bigserial PRIMARY KEY,
+    service_id bigint NOT NULL,
+    kind text NOT NULL,
+    storage_key text NOT NULL,
+    sha256 text NOT NULL,
+    size bigint NOT NULL,
+    source_kind text NOT NULL,
+    source_ref text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT service_archive_versions_kind_check CHECK (kind IN ('service', 'checker')),
+    CONSTRAINT service_archive_versions_source_kind_check CHECK (source_kind IN ('url', 'upload', 'git'))
+);
+
+CREATE INDEX index_service_archive_versions_on_service_id_and_kind
+    ON service_archive_versions (service_id, kind, created_at DESC);
+CREATE UNIQUE INDEX index_service_archive_versions_on_storage_key
+    ON service_archive_versions (storage_key);
+
+ALTER TABLE ONLY service_archive_versions
+    ADD CONSTRAINT fk_service_archive_versions_service_id
+    FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE;
+
+-- Archives stored before versioning become the first version of each service.
+INSERT INTO service_archive_versions (service_id, kind, storage_key, sha256, size, source_kind, source_ref, created_at)
+SELECT id, 'service', service_local_path, service_local_sha256, service_local_size,
+    CASE WHEN source_kind = 'git' THEN 'git' WHEN service_archive_url IS NOT NULL THEN 'url' ELSE 'upload' END,
+    CASE WHEN source_kind = 'git' THEN git_last_commit ELSE service_archive_url END,
+    COALESCE(service_downloaded_at, updated_at)
+FROM services
+WHERE service_local_path IS NOT NULL
+  AND service_local_sha256 IS NOT NULL
+  AND service_local_size IS NOT NULL;
+
+INSERT INTO service_archive_versions (service_id, kind, storage_key, sha256, size, source_kind, source_ref, created_at)
+SELECT id, 'checker', checker_local_path, checker_local_sha256, checker_local_size,
+    CASE WHEN source_kind = 'git' THEN 'git' WHEN checker_archive_url IS NOT NULL THEN 'url' ELSE 'upload' END,
+    CASE WHEN source_kind = 'git' THEN git_last_commit ELSE checker_archive_url END,
+    COALESCE(checker_downloaded_at, updated_at)
+FROM services
+WHERE checker_local_path IS NOT NULL
+  AND checker_local_sha256 IS NOT NULL
+  AND checker_local_size IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS service_archive_versions;
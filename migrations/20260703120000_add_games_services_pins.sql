@@ -0,0 +1,25 @@
+-- +goose Up
+-- A game can pin the archive versions it was played with so exports stay
+-- reproducible after the service is updated. NULL follows the current archive.
+
+ALTER TABLE games_services ADD COLUMN pinned_service_version_id bigint;
+ALTER TABLE games_services ADD COLUMN pinned_checker_version_id bigint;
+
+CREATE INDEX index_games_services_on_pinned_service_version_id ON games_services (pinned_service_version_id);
+CREATE INDEX index_games_services_on_pinned_checker_version_id ON games_services (pinned_checker_version_id);
+
+ALTER TABLE ONLY games_services
+    ADD CONSTRAINT fk_games_services_pinned_service_version_id
+    FOREIGN KEY (pinned_service_version_id) REFERENCES service_archive_versions(id);
+
+ALTER TABLE ONLY games_services
+    ADD CONSTRAINT fk_games_services_pinned_checker_version_id
+    FOREIGN KEY (pinned_checker_version_id) REFERENCES service_archive_versions(id);
+
+-- +goose Down
+ALTER TABLE games_services DROP CONSTRAINT fk_games_services_pinned_checker_version_id;
+ALTER TABLE games_services DROP CONSTRAINT fk_games_services_pinned_service_version_id;
+DROP INDEX IF EXISTS index_games_services_on_pinned_checker_version_id;
+DROP INDEX IF EXISTS index_games_services_on_pinned_service_version_id;
+ALTER TABLE games_services DROP COLUMN pinned_checker_version_id;
+ALTER TABLE games_services DROP COLUMN pinned_service_version_id;
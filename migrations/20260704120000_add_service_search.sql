@@ -0,0 +1,70 @@
+-- +goose Up
+-- Full-text search over the service catalog. The README captured at import
+-- and the license detected from LICENSE are stored on the service; the search
+-- documents live in their own table so SELECT * on services stays cheap.
+
+ALTER TABLE services ADD COLUMN readme text;
+ALTER TABLE services ADD COLUMN license text;
+
+CREATE INDEX index_services_on_license ON services (license);
+
+CREATE TABLE service_search_documents (
+    service_id bigint PRIMARY KEY,
+    public_document tsvector NOT NULL,
+    private_document tsvector NOT NULL
+);
+
+CREATE INDEX index_service_search_documents_on_public_document
+    ON service_search_documents USING gin (public_document);
+CREATE INDEX index_service_search_documents_on_private_document
+    ON service_search_documents USING gin (private_document);
+
+ALTER TABLE ONLY service_search_documents
+    ADD CONSTRAINT fk_service_search_documents_service_id
+    FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE;
+
+-- The 'simple' configuration is used because descriptions are a mix of
+-- Russian and English and stemming either language breaks the other.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION refresh_service_search_document()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO service_search_documents (service_id, public_document, private_document)
+    VALUES (
+        NEW.id,
+        setweight(to_tsvector('simple', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('simple', array_to_string(NEW.tech_stack, ' ')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(NEW.author, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(NEW.public_description, '')), 'C') ||
+        setweight(to_tsvector('simple', coalesce(NEW.readme, '')), 'D'),
+        setweight(to_tsvector('simple', coalesce(NEW.private_description, '')), 'C')
+    )
+    ON CONFLICT (service_id) DO UPDATE SET
+        public_document = EXCLUDED.public_document,
+        private_document = EXCLUDED.private_document;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER refresh_services_search_document
+    AFTER INSERT OR UPDATE ON services
+    FOR EACH ROW EXECUTE FUNCTION refresh_service_search_document();
+
+INSERT INTO service_search_documents (service_id, public_document, private_document)
+SELECT
+    id,
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', array_to_string(tech_stack, ' ')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(author, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(public_description, '')), 'C'),
+    setweight(to_tsvector('simple', coalesce(private_description, '')), 'C')
+FROM services;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS refresh_services_search_document ON services;
+DROP FUNCTION IF EXISTS refresh_service_search_document();
+DROP TABLE IF EXISTS service_search_documents;
+DROP INDEX IF EXISTS index_services_on_license;
+ALTER TABLE services DROP COLUMN license;
+ALTER TABLE services DROP COLUMN readme;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- The README of a service archive version, kept so the catalog can render the
+-- documentation of the active (or any older) version without opening the zip.
+-- readme_path is the archive entry it was read from; relative links in the
+-- README resolve against its directory.
+
+ALTER TABLE service_archive_versions ADD COLUMN readme text;
+ALTER TABLE service_archive_versions ADD COLUMN readme_path text;
+
+-- +goose Down
+ALTER TABLE service_archive_versions DROP COLUMN readme_path;
+ALTER TABLE service_archive_versions DROP COLUMN readme;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- The intended vulnerabilities of a service: what organizers check a game
+-- against and what players may read once a game with the service is over.
+
+CREATE TABLE service_vulns (
+    id bigserial PRIMARY KEY,
+    service_id bigint NOT NULL,
+    position integer NOT NULL,
+    title text NOT NULL,
+    cwe text,
+    difficulty text,
+    exploit_path text,
+    fix text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT chk_service_vulns_difficulty CHECK (difficulty IN ('easy', 'medium', 'hard'))
+);
+
+CREATE INDEX index_service_vulns_on_service_id ON service_vulns (service_id, position);
+
+ALTER TABLE ONLY service_vulns
+    ADD CONSTRAINT fk_service_vulns_service_id
+    FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS service_vulns;
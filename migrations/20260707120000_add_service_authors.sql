@@ -0,0 +1,31 @@
+-- +goose Up
+-- Platform users credited as authors of a service. Authors may edit and
+-- maintain their services without being global admins; services.author stays
+-- the free-text credit shown in the catalog.
+
+CREATE TABLE service_authors (
+    service_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (service_id, user_id)
+);
+
+CREATE INDEX index_service_authors_on_user_id ON service_authors (user_id);
+
+ALTER TABLE ONLY service_authors
+    ADD CONSTRAINT fk_service_authors_service_id
+    FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY service_authors
+    ADD CONSTRAINT fk_service_authors_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Link existing services whose author credit is exactly a user name.
+INSERT INTO service_authors (service_id, user_id)
+SELECT s.id, u.id
+FROM services s
+JOIN users u ON lower(u.user_name) = lower(btrim(s.author))
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS service_authors;
@@ -0,0 +1,67 @@
+-- +goose Up
+-- Services contributed by players. A submission keeps its git source or the
+-- uploaded archive and the import preview until an admin accepts it, which
+-- creates the service, or rejects it.
+
+CREATE TABLE service_submissions (
+    id bigserial PRIMARY KEY,
+    submitter_id bigint NOT NULL,
+    status text NOT NULL DEFAULT 'submitted',
+    source_kind text NOT NULL,
+    repo_url text,
+    git_ref text,
+    git_subdir text,
+    archive_key text,
+    service_name text NOT NULL,
+    preview jsonb NOT NULL,
+    service_id bigint,
+    reviewer_id bigint,
+    reviewed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT chk_service_submissions_status CHECK (status IN ('submitted', 'changes_requested', 'accepted', 'rejected')),
+    CONSTRAINT chk_service_submissions_source_kind CHECK (source_kind IN ('git', 'zip'))
+);
+
+CREATE INDEX index_service_submissions_on_status ON service_submissions (status, created_at);
+CREATE INDEX index_service_submissions_on_submitter_id ON service_submissions (submitter_id);
+
+ALTER TABLE ONLY service_submissions
+    ADD CONSTRAINT fk_service_submissions_submitter_id
+    FOREIGN KEY (submitter_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY service_submissions
+    ADD CONSTRAINT fk_service_submissions_service_id
+    FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY service_submissions
+    ADD CONSTRAINT fk_service_submissions_reviewer_id
+    FOREIGN KEY (reviewer_id) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Review discussion of a submission; parent_id threads replies.
+CREATE TABLE service_submission_comments (
+    id bigserial PRIMARY KEY,
+    submission_id bigint NOT NULL,
+    author_id bigint,
+    parent_id bigint,
+    body text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX index_service_submission_comments_on_submission_id ON service_submission_comments (submission_id, id);
+
+ALTER TABLE ONLY service_submission_comments
+    ADD CONSTRAINT fk_service_submission_comments_submission_id
+    FOREIGN KEY (submission_id) REFERENCES service_submissions(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY service_submission_comments
+    ADD CONSTRAINT fk_service_submission_comments_author_id
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY service_submission_comments
+    ADD CONSTRAINT fk_service_submission_comments_parent_id
+    FOREIGN KEY (parent_id) REFERENCES service_submission_comments(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS service_submission_comments;
+DROP TABLE IF EXISTS service_submissions;
@@ -0,0 +1,62 @@
+-- +goose Up
+-- Archives are stored content-addressed under blobs/<sha256 prefix>/<sha256>, so
+-- identical bundles saved by every sync share one object. ref_count follows the
+-- archive versions pointing at a blob (services and game pins point at
+-- versions); blobs left unreferenced past a grace period are garbage collected.
+-- Versions stored before this keep their per-version keys until
+-- `storage-blobs migrate-keys` moves them.
+
+CREATE TABLE storage_blobs (
+    sha256 text PRIMARY KEY,
+    storage_key text NOT NULL,
+    size bigint NOT NULL,
+    ref_count integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    -- When ref_count last dropped to zero; the GC grace period starts here.
+    unreferenced_at timestamptz DEFAULT now(),
+    verified_at timestamptz,
+    corrupt_at timestamptz,
+    CONSTRAINT storage_blobs_ref_count_check CHECK (ref_count >= 0)
+);
+
+CREATE UNIQUE INDEX index_storage_blobs_on_storage_key ON storage_blobs (storage_key);
+CREATE INDEX index_storage_blobs_on_unreferenced_at ON storage_blobs (unreferenced_at) WHERE ref_count = 0;
+CREATE INDEX index_storage_blobs_on_verified_at ON storage_blobs (verified_at NULLS FIRST);
+
+-- Deduplicated versions share a storage key.
+DROP INDEX index_service_archive_versions_on_storage_key;
+CREATE INDEX index_service_archive_versions_on_storage_key
+    ON service_archive_versions (storage_key);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION count_storage_blob_refs()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE storage_blobs
+        SET ref_count = ref_count - 1,
+            unreferenced_at = CASE WHEN ref_count = 1 THEN now() ELSE unreferenced_at END
+        WHERE storage_key = OLD.storage_key;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE storage_blobs
+        SET ref_count = ref_count + 1,
+            unreferenced_at = NULL
+        WHERE storage_key = NEW.storage_key;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER count_service_archive_version_blob_refs
+    AFTER INSERT OR DELETE OR UPDATE OF storage_key ON service_archive_versions
+    FOR EACH ROW EXECUTE FUNCTION count_storage_blob_refs();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS count_service_archive_version_blob_refs ON service_archive_versions;
+DROP FUNCTION IF EXISTS count_storage_blob_refs();
+DROP INDEX IF EXISTS index_service_archive_versions_on_storage_key;
+CREATE UNIQUE INDEX index_service_archive_versions_on_storage_key
+    ON service_archive_versions (storage_key);
+DROP TABLE IF EXISTS storage_blobs;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- Signed download links let jury hosts and provisioning scripts fetch one
+-- archive of a service without a token. The URL carries an HMAC over the link
+-- id, service, kind and expiry; the row enforces the optional download limit
+-- and records who issued the link and when it was last used.
+
+CREATE TABLE service_download_links (
+    id bigserial PRIMARY KEY,
+    service_id bigint NOT NULL,
+    kind text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    max_downloads integer,
+    download_count integer NOT NULL DEFAULT 0,
+    created_by bigint,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    last_used_at timestamptz,
+    last_used_ip text,
+    CONSTRAINT service_download_links_kind_check CHECK (kind IN ('service', 'checker')),
+    CONSTRAINT service_download_links_max_downloads_check CHECK (max_downloads IS NULL OR max_downloads > 0)
+);
+
+CREATE INDEX index_service_download_links_on_service_id ON service_download_links (service_id);
+
+ALTER TABLE ONLY service_download_links
+    ADD CONSTRAINT fk_service_download_links_service_id
+    FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY service_download_links
+    ADD CONSTRAINT fk_service_download_links_created_by
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS service_download_links;
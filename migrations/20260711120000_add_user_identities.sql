@@ -0,0 +1,42 @@
+-- +goose Up
+-- External identities (GitHub, university Keycloak, ...) linked to local users.
+-- A user signs in through any linked identity and gets the same session-backed
+-- JWT as with a password. oidc_login_states keeps the per-attempt state, PKCE
+-- verifier and nonce between the redirect to the provider and the callback.
+
+CREATE TABLE user_identities (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    provider text NOT NULL,
+    subject text NOT NULL,
+    email text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    last_login_at timestamptz
+);
+
+CREATE UNIQUE INDEX index_user_identities_on_provider_subject ON user_identities (provider, subject);
+CREATE UNIQUE INDEX index_user_identities_on_user_id_provider ON user_identities (user_id, provider);
+
+ALTER TABLE ONLY user_identities
+    ADD CONSTRAINT fk_user_identities_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE TABLE oidc_login_states (
+    state text PRIMARY KEY,
+    provider text NOT NULL,
+    code_verifier text NOT NULL,
+    nonce text NOT NULL,
+    link_user_id bigint,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX index_oidc_login_states_on_expires_at ON oidc_login_states (expires_at);
+
+ALTER TABLE ONLY oidc_login_states
+    ADD CONSTRAINT fk_oidc_login_states_link_user_id
+    FOREIGN KEY (link_user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS oidc_login_states;
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,69 @@
+-- +goose Up
+-- TOTP two-factor authentication. The secret is encrypted by the application
+-- (TWO_FACTOR_SECRET_KEY) and becomes active once the first code confirms it;
+-- last_used_step stops a code from being used twice. Recovery codes and login
+-- challenge tokens are stored as SHA-256 hashes. A login that needs a second
+-- factor gets a challenge instead of a session; two_factor_required_roles
+-- forces enrollment for whole roles.
+
+CREATE TABLE user_two_factor (
+    user_id bigint PRIMARY KEY,
+    secret_ciphertext bytea NOT NULL,
+    confirmed_at timestamptz,
+    last_used_step bigint,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TRIGGER set_user_two_factor_updated_at
+    BEFORE UPDATE ON user_two_factor
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+ALTER TABLE ONLY user_two_factor
+    ADD CONSTRAINT fk_user_two_factor_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE TABLE user_recovery_codes (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    code_hash text NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX index_user_recovery_codes_on_user_id_code_hash ON user_recovery_codes (user_id, code_hash);
+
+ALTER TABLE ONLY user_recovery_codes
+    ADD CONSTRAINT fk_user_recovery_codes_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE TABLE login_challenges (
+    token_hash text PRIMARY KEY,
+    user_id bigint NOT NULL,
+    enrollment boolean NOT NULL DEFAULT false,
+    attempts integer NOT NULL DEFAULT 0,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX index_login_challenges_on_expires_at ON login_challenges (expires_at);
+
+ALTER TABLE ONLY login_challenges
+    ADD CONSTRAINT fk_login_challenges_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE TABLE two_factor_required_roles (
+    role text PRIMARY KEY,
+    created_by bigint,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE ONLY two_factor_required_roles
+    ADD CONSTRAINT fk_two_factor_required_roles_created_by
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS two_factor_required_roles;
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Rotating refresh tokens. Access tokens are short-lived JWTs carrying the
+-- session's jti; a refresh token renews them and is replaced on every use.
+-- Used tokens are kept (as SHA-256 hashes) until the session is deleted, so
+-- presenting one again is detected and revokes the session.
+
+CREATE TABLE session_refresh_tokens (
+    id bigserial PRIMARY KEY,
+    session_id bigint NOT NULL,
+    token_hash text NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX index_session_refresh_tokens_on_token_hash ON session_refresh_tokens (token_hash);
+CREATE INDEX index_session_refresh_tokens_on_session_id ON session_refresh_tokens (session_id);
+
+ALTER TABLE ONLY session_refresh_tokens
+    ADD CONSTRAINT fk_session_refresh_tokens_session_id
+    FOREIGN KEY (session_id) REFERENCES user_sessions(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS session_refresh_tokens;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- Personal API tokens for automation (CI pushing results, triggering service
+-- syncs). A token acts as its owner, limited to its scopes; only its SHA-256
+-- hash is stored, next to a short prefix that lets the owner recognize it.
+
+CREATE TABLE user_api_tokens (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    name text NOT NULL,
+    token_hash text NOT NULL,
+    token_prefix text NOT NULL,
+    scopes text[] NOT NULL,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    last_used_ip text,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX index_user_api_tokens_on_token_hash ON user_api_tokens (token_hash);
+CREATE INDEX index_user_api_tokens_on_user_id ON user_api_tokens (user_id);
+
+ALTER TABLE ONLY user_api_tokens
+    ADD CONSTRAINT fk_user_api_tokens_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS user_api_tokens;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Email verification and password reset. A user's address counts as verified
+-- once a link sent to it has been followed; changing the address clears the
+-- mark. Links carry a random token stored here as a SHA-256 hash together with
+-- the address it was sent to. A token is single-use: it is deleted when
+-- consumed.
+
+ALTER TABLE users ADD COLUMN email_verified_at timestamptz;
+
+CREATE TABLE user_email_tokens (
+    token_hash text PRIMARY KEY,
+    user_id bigint NOT NULL,
+    purpose text NOT NULL CHECK (purpose IN ('verify_email', 'reset_password')),
+    email text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX index_user_email_tokens_on_user_id_purpose ON user_email_tokens (user_id, purpose);
+CREATE INDEX index_user_email_tokens_on_expires_at ON user_email_tokens (expires_at);
+
+ALTER TABLE ONLY user_email_tokens
+    ADD CONSTRAINT fk_user_email_tokens_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS user_email_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
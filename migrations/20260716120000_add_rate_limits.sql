@@ -0,0 +1,28 @@
+-- +goose Up
+-- Shared rate limit state, so limits hold across replicas. A bucket holds the
+-- tokens left for one key (operation and caller) as of updated_at; it is full
+-- again by expires_at and may then be dropped. A failure counter counts the
+-- failed sign-ins of one user name or IP address; it is forgotten once
+-- expires_at passes without a new failure.
+
+CREATE TABLE rate_limit_buckets (
+    key text PRIMARY KEY,
+    tokens double precision NOT NULL,
+    updated_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX index_rate_limit_buckets_on_expires_at ON rate_limit_buckets (expires_at);
+
+CREATE TABLE rate_limit_failures (
+    key text PRIMARY KEY,
+    failures integer NOT NULL,
+    last_failure_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX index_rate_limit_failures_on_expires_at ON rate_limit_failures (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS rate_limit_failures;
+DROP TABLE IF EXISTS rate_limit_buckets;
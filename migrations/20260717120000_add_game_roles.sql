@@ -0,0 +1,26 @@
+-- +goose Up
+-- Roles users hold in a single game. Organizers run the game without being
+-- global admins, the jury keeps its results, observers only watch; all of
+-- them see the scoreboard while it is hidden from players.
+
+CREATE TABLE game_roles (
+    game_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (game_id, user_id),
+    CONSTRAINT game_roles_role_check CHECK (role IN ('organizer', 'jury', 'observer'))
+);
+
+CREATE INDEX index_game_roles_on_user_id ON game_roles (user_id);
+
+ALTER TABLE ONLY game_roles
+    ADD CONSTRAINT fk_game_roles_game_id
+    FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY game_roles
+    ADD CONSTRAINT fk_game_roles_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- +goose Down
+DROP TABLE IF EXISTS game_roles;
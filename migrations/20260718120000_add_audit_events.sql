@@ -0,0 +1,30 @@
+-- +goose Up
+-- Administrative and sensitive actions: who did what to which entity, from
+-- where, and the fields the action changed. Rows are written in the same
+-- transaction as the change and never updated.
+
+CREATE TABLE audit_events (
+    id bigserial PRIMARY KEY,
+    actor_id bigint,
+    actor_name text,
+    ip_address text,
+    request_id text,
+    entity_type text NOT NULL,
+    entity_id text NOT NULL,
+    action text NOT NULL,
+    before jsonb,
+    after jsonb,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX index_audit_events_on_entity ON audit_events (entity_type, entity_id);
+CREATE INDEX index_audit_events_on_actor_id ON audit_events (actor_id);
+CREATE INDEX index_audit_events_on_created_at ON audit_events (created_at);
+
+-- Events outlive their actor: the name stays, the link is dropped.
+ALTER TABLE ONLY audit_events
+    ADD CONSTRAINT fk_audit_events_actor_id
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- A git submission keeps the tree it was previewed at: archive_key now holds
+-- the fetched archive for git sources too, and git_commit the commit it was
+-- built from. Accepting imports that snapshot, so a push after review cannot
+-- change the service the reviewer approved.
+
+ALTER TABLE service_submissions ADD COLUMN git_commit text;
+
+-- +goose Down
+ALTER TABLE service_submissions DROP COLUMN IF EXISTS git_commit;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Built ctf01d exports are kept as storage blobs, so they take part in the
+-- blob ref_count and a client can resume a download of the exact archive it
+-- started. Only the last few exports of a game are kept; pruned rows release
+-- their blob to the garbage collector.
+
+CREATE TABLE game_exports (
+    id bigserial PRIMARY KEY,
+    game_id bigint NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    storage_key text NOT NULL,
+    sha256 text NOT NULL,
+    size bigint NOT NULL,
+    filename text NOT NULL,
+    -- Last-Modified of the export: when the game was last changed.
+    modified_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX index_game_exports_on_game_id_and_sha256 ON game_exports (game_id, sha256);
+CREATE INDEX index_game_exports_on_storage_key ON game_exports (storage_key);
+
+CREATE TRIGGER count_game_export_blob_refs
+    AFTER INSERT OR DELETE OR UPDATE OF storage_key ON game_exports
+    FOR EACH ROW EXECUTE FUNCTION count_storage_blob_refs();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS count_game_export_blob_refs ON game_exports;
+DROP TABLE IF EXISTS game_exports;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- A download link covers the archive that was current when it was issued:
+-- storage_key is signed with the link, and a link whose archive has since
+-- been replaced stops working. Links issued before this have no key and are
+-- rejected; they expire within a week anyway.
+
+ALTER TABLE service_download_links ADD COLUMN storage_key text;
+
+-- +goose Down
+ALTER TABLE service_download_links DROP COLUMN IF EXISTS storage_key;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Sign-in attempts are counted before the password is checked, and the
+-- counter returns the failure before the new one, so concurrent attempts each
+-- see a distinct count. previous_failure_at keeps that failure's time.
+
+ALTER TABLE rate_limit_failures ADD COLUMN previous_failure_at timestamptz;
+
+-- +goose Down
+ALTER TABLE rate_limit_failures DROP COLUMN IF EXISTS previous_failure_at;
@@ -11,12 +11,19 @@ import (
 	"log"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
-const requiredRoleExtension = "x-required-role"
+const (
+	requiredRoleExtension       = "x-required-role"
+	resourcePermissionExtension = "x-resource-permission"
+	tokenScopeExtension         = "x-token-scope"
+	rateLimitExtension          = "x-rate-limit"
+)
 
 const (
 	accessPublic        = "public"
@@ -38,8 +45,22 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	permissions, err := collectResourcePermissions(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	scopes, err := collectTokenScopes(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rateLimits, err := collectRateLimits(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	source, err := render(*pkg, roles)
+	source, err := render(*pkg, roles, permissions, scopes, rateLimits)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -102,6 +123,146 @@ func collectRoles(spec *openapi3.T) (map[string]string, error) {
 	return roles, nil
 }
 
+// collectResourcePermissions gathers x-resource-permission: the permission on
+// the {id} resource that grants access to an operation in place of its
+// required role.
+func collectResourcePermissions(spec *openapi3.T) (map[string]string, error) {
+	permissions := make(map[string]string)
+	for _, path := range spec.Paths.Keys() {
+		pathItem := spec.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		for method, operation := range pathItem.Operations() {
+			if operation == nil {
+				continue
+			}
+			value, ok := operation.Extensions[resourcePermissionExtension]
+			if !ok {
+				continue
+			}
+
+			permission, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s %s: %s must be a string", method, path, resourcePermissionExtension)
+			}
+			if !validResourcePermission(permission) {
+				return nil, fmt.Errorf("%s %s: unknown resource permission %q", method, path, permission)
+			}
+			if !strings.Contains(path, "{id}") {
+				return nil, fmt.Errorf("%s %s: %s requires an {id} path parameter", method, path, resourcePermissionExtension)
+			}
+
+			permissions[strings.ToUpper(method)+" "+path] = permission
+		}
+	}
+
+	return permissions, nil
+}
+
+// collectTokenScopes gathers x-token-scope: the API token scope that opens an
+// operation to personal API tokens. Operations without one are closed to them.
+func collectTokenScopes(spec *openapi3.T) (map[string]string, error) {
+	scopes := make(map[string]string)
+	for _, path := range spec.Paths.Keys() {
+		pathItem := spec.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		for method, operation := range pathItem.Operations() {
+			if operation == nil {
+				continue
+			}
+			value, ok := operation.Extensions[tokenScopeExtension]
+			if !ok {
+				continue
+			}
+
+			scope, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s %s: %s must be a string", method, path, tokenScopeExtension)
+			}
+			if !validTokenScope(scope) {
+				return nil, fmt.Errorf("%s %s: unknown token scope %q", method, path, scope)
+			}
+			if !requiresBearerAuth(spec, operation) {
+				return nil, fmt.Errorf("%s %s: %s requires BearerAuth security", method, path, tokenScopeExtension)
+			}
+
+			scopes[strings.ToUpper(method)+" "+path] = scope
+		}
+	}
+
+	return scopes, nil
+}
+
+// collectRateLimits gathers x-rate-limit: the number of requests a caller may
+// make to an operation per period, written as "<limit>/<period>".
+func collectRateLimits(spec *openapi3.T) (map[string]string, error) {
+	limits := make(map[string]string)
+	for _, path := range spec.Paths.Keys() {
+		pathItem := spec.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		for method, operation := range pathItem.Operations() {
+			if operation == nil {
+				continue
+			}
+			value, ok := operation.Extensions[rateLimitExtension]
+			if !ok {
+				continue
+			}
+
+			rate, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s %s: %s must be a string", method, path, rateLimitExtension)
+			}
+			if !validRate(rate) {
+				return nil, fmt.Errorf("%s %s: %s=%q, want <limit>/<period> such as 5/15m", method, path, rateLimitExtension, rate)
+			}
+
+			limits[strings.ToUpper(method)+" "+path] = rate
+		}
+	}
+
+	return limits, nil
+}
+
+func validRate(rate string) bool {
+	limit, period, ok := strings.Cut(rate, "/")
+	if !ok {
+		return false
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		return false
+	}
+	d, err := time.ParseDuration(period)
+	return err == nil && d > 0
+}
+
+func validTokenScope(scope string) bool {
+	switch scope {
+	case "results:read", "results:write", "services:sync", "games:export":
+		return true
+	default:
+		return false
+	}
+}
+
+func validResourcePermission(permission string) bool {
+	switch permission {
+	case "service_author", "game_organizer", "game_team_organizer", "result_jury":
+		return true
+	default:
+		return false
+	}
+}
+
 func requiredRole(operation *openapi3.Operation) (string, bool, error) {
 	value, ok := operation.Extensions[requiredRoleExtension]
 	if !ok {
@@ -155,7 +316,7 @@ func requiresBearerAuth(spec *openapi3.T, operation *openapi3.Operation) bool {
 	return false
 }
 
-func render(pkg string, roles map[string]string) ([]byte, error) {
+func render(pkg string, roles, permissions, scopes, rateLimits map[string]string) ([]byte, error) {
 	var buf bytes.Buffer
 	fmt.Fprintf(&buf, "// Code generated by scripts/openapi-required-roles.go; DO NOT EDIT.\n\n")
 	fmt.Fprintf(&buf, "package %s\n\n", pkg)
@@ -173,6 +334,45 @@ func render(pkg string, roles map[string]string) ([]byte, error) {
 	}
 	fmt.Fprintln(&buf, "}")
 
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// OperationResourcePermissions maps OpenAPI operation keys to the resource-level permission declared via x-resource-permission.")
+	fmt.Fprintln(&buf, "var OperationResourcePermissions = map[string]string{")
+	keys = keys[:0]
+	for key := range permissions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", key, permissions[key])
+	}
+	fmt.Fprintln(&buf, "}")
+
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// OperationTokenScopes maps OpenAPI operation keys to the API token scope declared via x-token-scope.")
+	fmt.Fprintln(&buf, "var OperationTokenScopes = map[string]string{")
+	keys = keys[:0]
+	for key := range scopes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", key, scopes[key])
+	}
+	fmt.Fprintln(&buf, "}")
+
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// OperationRateLimits maps OpenAPI operation keys to the per-caller rate declared via x-rate-limit.")
+	fmt.Fprintln(&buf, "var OperationRateLimits = map[string]string{")
+	keys = keys[:0]
+	for key := range rateLimits {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", key, rateLimits[key])
+	}
+	fmt.Fprintln(&buf, "}")
+
 	source, err := format.Source(buf.Bytes())
 	if err != nil {
 		return nil, fmt.Errorf("format generated source: %w", err)
@@ -71,6 +71,68 @@ components:
 	}
 }
 
+func TestRequiredRolesEmitsTokenScopes(t *testing.T) {
+	output, err := runRequiredRoles(t, `
+openapi: 3.0.3
+info:
+  title: Test
+  version: "1.0"
+paths:
+  /results:
+    post:
+      operationId: createResult
+      x-required-role: player
+      x-token-scope: results:write
+      security:
+        - BearerAuth: []
+      responses:
+        '204':
+          description: OK
+components:
+  securitySchemes:
+    BearerAuth:
+      type: http
+      scheme: bearer
+`)
+	if err != nil {
+		t.Fatalf("expected generator success, got %v: %s", err, output)
+	}
+	if !strings.Contains(output, `"POST /results": "results:write"`) {
+		t.Fatalf("expected token scope in output, got %s", output)
+	}
+}
+
+func TestRequiredRolesFailsOnUnknownTokenScope(t *testing.T) {
+	output, err := runRequiredRoles(t, `
+openapi: 3.0.3
+info:
+  title: Test
+  version: "1.0"
+paths:
+  /results:
+    post:
+      operationId: createResult
+      x-required-role: player
+      x-token-scope: results:delete
+      security:
+        - BearerAuth: []
+      responses:
+        '204':
+          description: OK
+components:
+  securitySchemes:
+    BearerAuth:
+      type: http
+      scheme: bearer
+`)
+	if err == nil {
+		t.Fatalf("expected generator failure, got success")
+	}
+	if !strings.Contains(output, `unknown token scope "results:delete"`) {
+		t.Fatalf("expected unknown scope error, got %s", output)
+	}
+}
+
 func runRequiredRoles(t *testing.T, spec string) (string, error) {
 	t.Helper()
 
@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestAuditLogFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	adminID, adminToken := seedUser(t, store, "admin_audit", "Admin Audit", "admin12345", "admin")
+	userID, userToken := seedUser(t, store, "audited", "Audited", "password123", "player")
+	since := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	t.Log("Step: admin changes a role, blocks the user and finalizes a game")
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, fmt.Sprintf("/api/v1/users/%d/role", userID), map[string]interface{}{"role": "guest"}, adminToken), http.StatusOK, "change role")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, fmt.Sprintf("/api/v1/users/%d/block", userID), map[string]interface{}{"blocked": true}, adminToken), http.StatusOK, "block user")
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/games", map[string]interface{}{"name": "Audit Game"}, adminToken)
+	requireStatus(t, w, http.StatusCreated, "create game")
+	gameID := jsonID(t, parseJSON(t, w))
+	requireStatus(t, makeReq(t, engine, http.MethodPost, fmt.Sprintf("/api/v1/games/%d/finalize", gameID), nil, adminToken), http.StatusOK, "finalize game")
+
+	t.Log("Step: only admins browse the log")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/audit-events", nil, ""), http.StatusUnauthorized, "anonymous lists audit events")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/audit-events", nil, userToken), http.StatusUnauthorized, "blocked user lists audit events")
+
+	t.Log("Step: events of the user carry the actor and the diff")
+	query := url.Values{"entity_type": {"user"}, "entity_id": {fmt.Sprint(userID)}, "since": {since}}
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/audit-events?"+query.Encode(), nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "list user events")
+	body := parseJSON(t, w)
+	items := body["items"].([]interface{})
+	actions := map[string]map[string]interface{}{}
+	for _, item := range items {
+		event := item.(map[string]interface{})
+		actions[event["action"].(string)] = event
+	}
+	for _, action := range []string{"change_role", "block", "revoke_sessions"} {
+		if actions[action] == nil {
+			t.Fatalf("events = %v, want %s", items, action)
+		}
+	}
+	roleChange := actions["change_role"]
+	if roleChange["actor_id"] != float64(adminID) || roleChange["actor_name"] != "admin_audit" || roleChange["request_id"] == nil {
+		t.Errorf("role change actor = %v/%v/%v", roleChange["actor_id"], roleChange["actor_name"], roleChange["request_id"])
+	}
+	if before := roleChange["before"].(map[string]interface{}); before["role"] != "player" {
+		t.Errorf("role change before = %v", before)
+	}
+	if after := roleChange["after"].(map[string]interface{}); after["role"] != "guest" {
+		t.Errorf("role change after = %v", after)
+	}
+
+	t.Log("Step: filters by action and actor")
+	query = url.Values{"action": {"finalize"}, "actor_id": {fmt.Sprint(adminID)}, "entity_id": {fmt.Sprint(gameID)}}
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/audit-events?"+query.Encode(), nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "list finalize events")
+	body = parseJSON(t, w)
+	if total := body["pagination"].(map[string]interface{})["total"]; total != float64(1) {
+		t.Errorf("finalize events = %v, want 1", total)
+	}
+	query = url.Values{"entity_type": {"game"}, "entity_id": {fmt.Sprint(gameID)}, "until": {since}}
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/audit-events?"+query.Encode(), nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "list events before the game existed")
+	if items := parseJSON(t, w)["items"].([]interface{}); len(items) != 0 {
+		t.Errorf("events before the game existed = %v, want none", items)
+	}
+}
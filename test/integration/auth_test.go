@@ -8,14 +8,22 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pquerna/otp/totp"
 	"go.uber.org/zap"
 
+	"github.com/ctf01d/ctf01d-training-platform/internal/audit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/auth"
 	"github.com/ctf01d/ctf01d-training-platform/internal/config"
+	"github.com/ctf01d/ctf01d-training-platform/internal/mail"
+	"github.com/ctf01d/ctf01d-training-platform/internal/ratelimit"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository"
 	"github.com/ctf01d/ctf01d-training-platform/internal/repository/db"
 	"github.com/ctf01d/ctf01d-training-platform/internal/server"
@@ -61,23 +69,60 @@ func setupTest(t *testing.T) (*gin.Engine, *repository.Store) {
 
 	jwtMgr := auth.NewManager("test-integration-secret", 24)
 	userService := usersvc.NewService(store.Queries)
+	userService.SetTxRunner(store)
 	authService := authsvc.NewService(store.Queries, store.Queries, jwtMgr, &auth.PasswordCheckerImpl{})
+	authService.SetTxRunner(store)
+	oidcService := authsvc.NewOIDCService(authService, store.Queries, []auth.IdentityProvider{fakeIdentityProvider{}})
+	oidcService.SetTxRunner(store)
+	totpBox, err := auth.NewSecretBox("test-integration-secret")
+	if err != nil {
+		t.Fatalf("creating two-factor cipher: %v", err)
+	}
+	twoFactorService := authsvc.NewTwoFactorService(store.Queries, totpBox, "ctf01d")
+	twoFactorService.SetTxRunner(store)
+	authService.SetTwoFactor(twoFactorService)
+	limits := ratelimit.NewMemoryStore()
+	authService.SetLoginThrottle(ratelimit.NewLoginGuard(limits, ratelimit.LoginPolicy{
+		MaxUserFailures: 10,
+		MaxIPFailures:   50,
+		Lockout:         15 * time.Minute,
+	}))
+	apiTokenService := authsvc.NewAPITokenService(store.Queries)
+	testOutbox.reset()
+	emailService := authsvc.NewEmailService(store.Queries, testOutbox, "http://localhost:5173")
+	emailService.SetTxRunner(store)
 	universityService := unisvc.NewService(store.Queries)
 	teamService := teamsvc.NewService(store.Queries, store.Queries, store.Queries, store)
 	membershipService := membersvc.NewService(store.Queries, store.Queries, store.Queries, store)
-	gameService := gamesvc.NewService(store.Queries, store.Queries, store.Queries, store.Queries, store)
+	gameService := gamesvc.NewService(store.Queries, store.Queries, store.Queries, store.Queries, store.Queries, store)
 	gameTeamService := gameteamsvc.NewService(store.Queries, store)
 	resultService := resultsvc.NewService(store.Queries, store.Queries)
+	resultService.SetTxRunner(store)
 	writeupService := writeupsvc.NewService(store.Queries, teamService)
 	scoreboardService := scoreboardsvc.NewService(store.Queries, store.Queries, store.Queries, store.Queries)
 	svcService := svcsvc.NewService(store.Queries)
 	svcArchives := svcsvc.NewArchiveService(store.Queries, fileStorage, cfg.Storage.MaxUploadBytes)
+	svcArchives.SetTxRunner(store)
 	svcChecker := svcsvc.NewCheckerService(store.Queries, fileStorage)
 	svcImport := svcsvc.NewImportService(store.Queries, fileStorage, cfg.Storage.MaxUploadBytes)
+	svcImport.SetTxRunner(store)
+	credentialBox, err := auth.NewSecretBox("test-integration-secret")
+	if err != nil {
+		t.Fatalf("creating git credentials cipher: %v", err)
+	}
+	gitCredentials := svcsvc.NewGitCredentialService(store.Queries, credentialBox)
+	gitCredentials.SetTxRunner(store)
+	submissions := svcsvc.NewSubmissionService(store.Queries, svcImport)
+	submissions.SetTxRunner(store)
+	downloadLinks := svcsvc.NewDownloadLinkService(store.Queries, svcArchives, auth.NewURLSigner("test-integration-secret"))
+	downloadLinks.SetTxRunner(store)
 	ctf01dBuilder := ctf01dsvc.NewBuilder(store.Queries)
-	h := handler.New(userService, authService, jwtMgr, universityService, teamService, membershipService, gameService, gameTeamService, resultService, writeupService, scoreboardService, store.Queries, svcService, svcArchives, svcChecker, svcImport, ctf01dBuilder, cfg.Storage.MaxUploadBytes, cfg.Storage.Dir, fileStorage)
+	exports := svcsvc.NewExportStore(store.Queries, fileStorage)
+	exports.SetTxRunner(store)
+	auditLog := audit.NewService(store.Queries)
+	h := handler.New(userService, authService, oidcService, twoFactorService, apiTokenService, emailService, jwtMgr, universityService, teamService, membershipService, gameService, gameTeamService, resultService, writeupService, scoreboardService, store.Queries, svcService, svcArchives, svcChecker, svcImport, gitCredentials, submissions, downloadLinks, ctf01dBuilder, exports, auditLog, cfg.Storage.MaxUploadBytes, cfg.Storage.Dir, fileStorage)
 
-	engine := server.New(cfg, log, store, h)
+	engine := server.New(cfg, log, store, h, limits)
 	return engine, store
 }
 
@@ -280,6 +325,31 @@ func TestAuthFlow(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Fatalf("login player with changed password: %d %s", w.Code, w.Body.String())
 	}
+	refreshToken := parseJSON(t, w)["refresh_token"].(string)
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/session/refresh", map[string]interface{}{
+		"refresh_token": refreshToken,
+	}, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("refresh session: %d %s", w.Code, w.Body.String())
+	}
+	refreshed := parseJSON(t, w)
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/profile", nil, refreshed["token"].(string))
+	if w.Code != http.StatusOK {
+		t.Fatalf("get profile with refreshed token: %d %s", w.Code, w.Body.String())
+	}
+
+	// Presenting the rotated-away token again revokes the session.
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/session/refresh", map[string]interface{}{
+		"refresh_token": refreshToken,
+	}, "")
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("reuse refresh token: got %d, want 401", w.Code)
+	}
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/profile", nil, refreshed["token"].(string))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("access token of a revoked session: got %d, want 401", w.Code)
+	}
 
 	w = makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/users/%d", playerID), nil, playerToken)
 	if w.Code != http.StatusOK {
@@ -328,3 +398,420 @@ func TestAuthFlow(t *testing.T) {
 		t.Fatalf("delete user: %d %s", w.Code, w.Body.String())
 	}
 }
+
+// fakeIdentityProvider signs in whoever the code names: Exchange returns the
+// code as the subject, so a test picks the external account it signs in as.
+type fakeIdentityProvider struct{}
+
+func (fakeIdentityProvider) Name() string        { return "test" }
+func (fakeIdentityProvider) DisplayName() string { return "Test IdP" }
+
+func (fakeIdentityProvider) AuthCodeURL(_ context.Context, state, _, _ string) (string, error) {
+	return "https://idp.example.com/authorize?state=" + state, nil
+}
+
+func (fakeIdentityProvider) Exchange(_ context.Context, code, _, _ string) (*auth.ExternalIdentity, error) {
+	if code == "" {
+		return nil, fmt.Errorf("empty code")
+	}
+	return &auth.ExternalIdentity{Subject: code, UserName: "oidc_" + code, DisplayName: "OIDC " + code}, nil
+}
+
+func TestOIDCFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, playerToken := seedUser(t, store, "player_oidc", "Player OIDC", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodGet, "/api/v1/auth/oidc/providers", nil, "")
+	requireStatus(t, w, http.StatusOK, "list identity providers")
+	if providers := parseItems(t, w); len(providers) != 1 || providers[0]["name"] != "test" {
+		t.Fatalf("providers = %v, want the test provider", providers)
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/oidc/missing/authorize", nil, ""), http.StatusNotFound, "authorize with an unknown provider")
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/auth/oidc/test/authorize", nil, "")
+	requireStatus(t, w, http.StatusOK, "start OIDC sign-in")
+	state := parseJSON(t, w)["state"].(string)
+
+	callback := func(state, code string) *httptest.ResponseRecorder {
+		return makeReq(t, engine, http.MethodPost, "/api/v1/auth/oidc/test/callback", map[string]interface{}{
+			"state": state, "code": code,
+		}, "")
+	}
+	requireStatus(t, callback("forged-state", "alice"), http.StatusUnauthorized, "callback with a forged state")
+	w = callback(state, "alice")
+	requireStatus(t, w, http.StatusOK, "complete OIDC sign-in")
+	signIn := parseJSON(t, w)
+	user := signIn["user"].(map[string]interface{})
+	if user["user_name"] != "oidc_alice" || signIn["linked"] != false {
+		t.Fatalf("sign-in = %v, want a provisioned oidc_alice", signIn)
+	}
+	aliceToken, _ := signIn["token"].(string)
+	if aliceToken == "" || signIn["refresh_token"] == nil {
+		t.Fatal("sign-in must return a session")
+	}
+	requireStatus(t, callback(state, "alice"), http.StatusUnauthorized, "replay a used state")
+
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/profile/identities", nil, aliceToken)
+	requireStatus(t, w, http.StatusOK, "list provisioned identities")
+	identities := parseItems(t, w)
+	if len(identities) != 1 {
+		t.Fatalf("identities = %d, want 1", len(identities))
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("/api/v1/profile/identities/%d", jsonID(t, identities[0])), nil, aliceToken), http.StatusConflict, "unlink the last sign-in method")
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/profile/identities", map[string]interface{}{"provider": "test"}, playerToken)
+	requireStatus(t, w, http.StatusOK, "start identity link")
+	w = callback(parseJSON(t, w)["state"].(string), "player-account")
+	requireStatus(t, w, http.StatusOK, "complete identity link")
+	if linked := parseJSON(t, w); linked["linked"] != true || linked["token"] != nil {
+		t.Fatalf("link callback = %v, want linked without a session", linked)
+	}
+
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/profile/identities", nil, playerToken)
+	requireStatus(t, w, http.StatusOK, "list linked identities")
+	identities = parseItems(t, w)
+	if len(identities) != 1 || identities[0]["provider"] != "test" {
+		t.Fatalf("identities = %v, want the test identity", identities)
+	}
+	unlinkPath := fmt.Sprintf("/api/v1/profile/identities/%d", jsonID(t, identities[0]))
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, unlinkPath, nil, aliceToken), http.StatusNotFound, "unlink another user's identity")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, unlinkPath, nil, playerToken), http.StatusNoContent, "unlink identity")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, unlinkPath, nil, playerToken), http.StatusNotFound, "unlink identity twice")
+}
+
+func totpCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := totp.GenerateCode(secret, at)
+	if err != nil {
+		t.Fatalf("generating totp code: %v", err)
+	}
+	return code
+}
+
+func TestTwoFactorFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_2fa", "Admin 2FA", "password123", "admin")
+	playerID, playerToken := seedUser(t, store, "player_2fa", "Player 2FA", "password123", "player")
+	seedUser(t, store, "newcomer_2fa", "Newcomer 2FA", "password123", "player")
+
+	login := func(userName string) map[string]interface{} {
+		t.Helper()
+		w := makeReq(t, engine, http.MethodPost, "/api/v1/session", map[string]interface{}{
+			"user_name": userName, "password": "password123",
+		}, "")
+		requireStatus(t, w, http.StatusAccepted, "login "+userName+" with two-factor")
+		return parseJSON(t, w)
+	}
+
+	w := makeReq(t, engine, http.MethodGet, "/api/v1/profile/two-factor", nil, playerToken)
+	requireStatus(t, w, http.StatusOK, "two-factor status")
+	if parseJSON(t, w)["enabled"] != false {
+		t.Fatal("two-factor should start disabled")
+	}
+
+	// Each code is taken from a later time step: a step, once used, is burned.
+	now := time.Now()
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/profile/two-factor/enrollment", nil, playerToken)
+	requireStatus(t, w, http.StatusOK, "start enrollment")
+	secret := parseJSON(t, w)["secret"].(string)
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/profile/two-factor/confirm", map[string]interface{}{"code": "000000"}, playerToken), http.StatusUnauthorized, "confirm with a wrong code")
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/profile/two-factor/confirm", map[string]interface{}{
+		"code": totpCode(t, secret, now.Add(-30*time.Second)),
+	}, playerToken)
+	requireStatus(t, w, http.StatusOK, "confirm enrollment")
+	recoveryCodes := parseJSON(t, w)["recovery_codes"].([]interface{})
+	if len(recoveryCodes) != 10 {
+		t.Fatalf("recovery codes = %d, want 10", len(recoveryCodes))
+	}
+
+	challenge := login("player_2fa")
+	challengeToken := challenge["challenge_token"].(string)
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/session/two-factor", map[string]interface{}{
+		"challenge_token": challengeToken, "code": "000000",
+	}, ""), http.StatusUnauthorized, "complete login with a wrong code")
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/session/two-factor", map[string]interface{}{
+		"challenge_token": challengeToken, "recovery_code": recoveryCodes[0],
+	}, "")
+	requireStatus(t, w, http.StatusOK, "complete login with a recovery code")
+	if parseJSON(t, w)["token"] == nil {
+		t.Fatal("completed login must return a session")
+	}
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/profile/two-factor/recovery-codes", map[string]interface{}{
+		"code": totpCode(t, secret, now),
+	}, playerToken)
+	requireStatus(t, w, http.StatusOK, "regenerate recovery codes")
+	recoveryCodes = parseJSON(t, w)["recovery_codes"].([]interface{})
+
+	policyPath := "/api/v1/auth/two-factor/policy"
+	requireStatus(t, makeReq(t, engine, http.MethodGet, policyPath, nil, playerToken), http.StatusForbidden, "player must not read the policy")
+	w = makeReq(t, engine, http.MethodGet, policyPath, nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "get two-factor policy")
+	if roles := parseJSON(t, w)["required_roles"].([]interface{}); len(roles) != 0 {
+		t.Fatalf("required roles = %v, want none", roles)
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodPut, policyPath, map[string]interface{}{"required_roles": []string{"player"}}, adminToken), http.StatusOK, "require two-factor for players")
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/profile/two-factor/disable", map[string]interface{}{
+		"recovery_code": recoveryCodes[0],
+	}, playerToken), http.StatusForbidden, "disable two-factor required by the role")
+
+	challenge = login("newcomer_2fa")
+	if challenge["enrollment_required"] != true {
+		t.Fatalf("challenge = %v, want enrollment_required", challenge)
+	}
+	challengeToken = challenge["challenge_token"].(string)
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/session/two-factor/enrollment", map[string]interface{}{
+		"challenge_token": challengeToken,
+	}, "")
+	requireStatus(t, w, http.StatusOK, "enroll during login")
+	newcomerSecret := parseJSON(t, w)["secret"].(string)
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/session/two-factor", map[string]interface{}{
+		"challenge_token": challengeToken, "code": totpCode(t, newcomerSecret, now),
+	}, "")
+	requireStatus(t, w, http.StatusOK, "finish enrollment during login")
+	if codes, _ := parseJSON(t, w)["recovery_codes"].([]interface{}); len(codes) != 10 {
+		t.Fatalf("recovery codes after enrollment = %d, want 10", len(codes))
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodPut, policyPath, map[string]interface{}{"required_roles": []string{}}, adminToken), http.StatusOK, "clear two-factor policy")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("/api/v1/users/%d/two-factor", playerID), nil, playerToken), http.StatusForbidden, "player must not reset two-factor")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, "/api/v1/users/999999/two-factor", nil, adminToken), http.StatusNotFound, "reset two-factor of a missing user")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("/api/v1/users/%d/two-factor", playerID), nil, adminToken), http.StatusNoContent, "reset two-factor")
+
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/profile/two-factor", nil, playerToken)
+	requireStatus(t, w, http.StatusOK, "two-factor status after reset")
+	if parseJSON(t, w)["enabled"] != false {
+		t.Fatal("two-factor should be disabled after an admin reset")
+	}
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/profile/two-factor/enrollment", nil, playerToken)
+	requireStatus(t, w, http.StatusOK, "enroll again")
+	secret = parseJSON(t, w)["secret"].(string)
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/profile/two-factor/confirm", map[string]interface{}{
+		"code": totpCode(t, secret, now),
+	}, playerToken)
+	requireStatus(t, w, http.StatusOK, "confirm enrollment again")
+	recoveryCodes = parseJSON(t, w)["recovery_codes"].([]interface{})
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/profile/two-factor/disable", map[string]interface{}{
+		"recovery_code": recoveryCodes[0],
+	}, playerToken), http.StatusNoContent, "disable two-factor")
+}
+
+func TestAPITokensFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_pat", "Admin PAT", "password123", "admin")
+
+	createToken := func(body map[string]interface{}) string {
+		t.Helper()
+		w := makeReq(t, engine, http.MethodPost, "/api/v1/profile/api-tokens", body, adminToken)
+		requireStatus(t, w, http.StatusCreated, "create API token")
+		created := parseJSON(t, w)
+		token, _ := created["token"].(string)
+		if !strings.HasPrefix(token, auth.APITokenPrefix) {
+			t.Fatalf("token = %q, want prefix %q", token, auth.APITokenPrefix)
+		}
+		return token
+	}
+
+	readToken := createToken(map[string]interface{}{"name": "CI results", "scopes": []string{"results:read"}})
+	syncToken := createToken(map[string]interface{}{
+		"name": "CI sync", "scopes": []string{"services:sync"}, "expires_at": time.Now().Add(24 * time.Hour),
+	})
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/profile/api-tokens", map[string]interface{}{
+		"name": "bad", "scopes": []string{"results:delete"},
+	}, adminToken), http.StatusUnprocessableEntity, "create API token with an unknown scope")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/profile/api-tokens", map[string]interface{}{
+		"name": "expired", "scopes": []string{"results:read"}, "expires_at": time.Now().Add(-time.Hour),
+	}, adminToken), http.StatusUnprocessableEntity, "create an already expired API token")
+
+	w := makeReq(t, engine, http.MethodGet, "/api/v1/profile/api-tokens", nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "list API tokens")
+	items := parseItems(t, w)
+	if len(items) != 2 {
+		t.Fatalf("API tokens = %d, want 2", len(items))
+	}
+	for _, item := range items {
+		if _, ok := item["token"]; ok {
+			t.Fatal("listed API tokens must not expose the token")
+		}
+	}
+	readTokenID := jsonID(t, items[1])
+
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/results", nil, readToken), http.StatusOK, "list results with a results:read token")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/results", nil, syncToken), http.StatusForbidden, "list results with a services:sync token")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/profile", nil, readToken), http.StatusForbidden, "profile is closed to API tokens")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/profile/api-tokens", map[string]interface{}{
+		"name": "nested", "scopes": []string{"results:read"},
+	}, readToken), http.StatusForbidden, "API tokens cannot create API tokens")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/games", nil, readToken), http.StatusOK, "public route with an API token")
+
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/profile/api-tokens", nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "list API tokens after use")
+	if parseItems(t, w)[1]["last_used_at"] == nil {
+		t.Fatal("last_used_at should be set after the token was used")
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("/api/v1/profile/api-tokens/%d", readTokenID), nil, adminToken), http.StatusNoContent, "revoke API token")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("/api/v1/profile/api-tokens/%d", readTokenID), nil, adminToken), http.StatusNotFound, "revoke a revoked API token")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/results", nil, readToken), http.StatusUnauthorized, "revoked API token")
+}
+
+// outbox collects the mail the server sends, so a test can follow the links.
+type outbox struct {
+	mu   sync.Mutex
+	sent []mail.Message
+}
+
+var testOutbox = &outbox{}
+
+func (o *outbox) Send(_ context.Context, msg mail.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+func (o *outbox) reset() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent = nil
+}
+
+func (o *outbox) count() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.sent)
+}
+
+// lastLinkToken returns the token of the link in the last message sent to
+// the address.
+func (o *outbox) lastLinkToken(t *testing.T, to string) string {
+	t.Helper()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for i := len(o.sent) - 1; i >= 0; i-- {
+		if o.sent[i].To != to {
+			continue
+		}
+		for _, field := range strings.Fields(o.sent[i].Body) {
+			if link, err := url.Parse(field); err == nil && link.Query().Get("token") != "" {
+				return link.Query().Get("token")
+			}
+		}
+		t.Fatalf("no link in mail to %s: %q", to, o.sent[i].Body)
+	}
+	t.Fatalf("no mail sent to %s", to)
+	return ""
+}
+
+func TestEmailVerificationAndPasswordResetFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, token := seedUser(t, store, "player_mail", "Player Mail", "password123", "player")
+	const email = "player.mail@example.com"
+
+	w := makeReq(t, engine, http.MethodPatch, "/api/v1/profile", map[string]interface{}{
+		"display_name": "Player Mail", "email": email, "language": "ru",
+	}, token)
+	requireStatus(t, w, http.StatusOK, "set profile email")
+	if parseJSON(t, w)["email_verified_at"] != nil {
+		t.Fatal("a new email must start unverified")
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/password-reset", map[string]interface{}{
+		"login": "player_mail",
+	}, ""), http.StatusNoContent, "request reset with an unverified email")
+	if testOutbox.count() != 0 {
+		t.Fatal("a reset link must not go to an unverified address")
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/profile/email/verification", nil, token), http.StatusNoContent, "send verification email")
+	verifyToken := testOutbox.lastLinkToken(t, email)
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/email-verification/confirm", map[string]interface{}{
+		"token": verifyToken,
+	}, ""), http.StatusNoContent, "verify email")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/email-verification/confirm", map[string]interface{}{
+		"token": verifyToken,
+	}, ""), http.StatusUnprocessableEntity, "reuse a verification link")
+
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/profile", nil, token)
+	requireStatus(t, w, http.StatusOK, "get profile")
+	if parseJSON(t, w)["email_verified_at"] == nil {
+		t.Fatal("email_verified_at should be set after verification")
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/profile/email/verification", nil, token), http.StatusConflict, "verify a verified email")
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/password-reset", map[string]interface{}{
+		"login": "nobody@example.com",
+	}, ""), http.StatusNoContent, "request reset for an unknown address")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/password-reset", map[string]interface{}{
+		"login": "Player.Mail@example.com",
+	}, ""), http.StatusNoContent, "request reset by email")
+	resetToken := testOutbox.lastLinkToken(t, email)
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/password-reset/confirm", map[string]interface{}{
+		"token": "not-a-token", "password": "n3w-password",
+	}, ""), http.StatusUnprocessableEntity, "reset with an unknown token")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/password-reset/confirm", map[string]interface{}{
+		"token": resetToken, "password": "n3w-password",
+	}, ""), http.StatusNoContent, "reset password")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/password-reset/confirm", map[string]interface{}{
+		"token": resetToken, "password": "another-password",
+	}, ""), http.StatusUnprocessableEntity, "reuse a reset link")
+
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/profile", nil, token), http.StatusUnauthorized, "session after a password reset")
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/session", map[string]interface{}{
+		"user_name": "player_mail", "password": "n3w-password",
+	}, "")
+	requireStatus(t, w, http.StatusOK, "login with the new password")
+	token, _ = parseJSON(t, w)["token"].(string)
+
+	w = makeReq(t, engine, http.MethodPatch, "/api/v1/profile", map[string]interface{}{
+		"display_name": "Player Mail", "email": "other@example.com",
+	}, token)
+	requireStatus(t, w, http.StatusOK, "change profile email")
+	if parseJSON(t, w)["email_verified_at"] != nil {
+		t.Fatal("changing the email must clear its verification")
+	}
+}
+
+func TestRateLimitFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	seedUser(t, store, "player_guarded", "Player Guarded", "password123", "player")
+
+	// The first failures are free, then each one makes the next attempt wait.
+	var w *httptest.ResponseRecorder
+	for attempt := 1; ; attempt++ {
+		w = makeReq(t, engine, http.MethodPost, "/api/v1/session", map[string]interface{}{
+			"user_name": "player_guarded", "password": "guess" + strconv.Itoa(attempt),
+		}, "")
+		if w.Code != http.StatusUnauthorized {
+			break
+		}
+		if attempt > 10 {
+			t.Fatal("password guessing was never throttled")
+		}
+	}
+	requireStatus(t, w, http.StatusTooManyRequests, "guess after repeated failures")
+	if w.Header().Get("Retry-After") == "" {
+		t.Fatal("a throttled login must carry Retry-After")
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/session", map[string]interface{}{
+		"user_name": "player_guarded", "password": "password123",
+	}, ""), http.StatusTooManyRequests, "right password while throttled")
+
+	// POST /auth/password-reset declares x-rate-limit: 5/15m.
+	for i := range 5 {
+		requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/auth/password-reset", map[string]interface{}{
+			"login": "player_guarded",
+		}, ""), http.StatusNoContent, fmt.Sprintf("password reset request %d", i+1))
+	}
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/auth/password-reset", map[string]interface{}{
+		"login": "player_guarded",
+	}, "")
+	requireStatus(t, w, http.StatusTooManyRequests, "password reset request over the limit")
+	if retry, err := strconv.Atoi(w.Header().Get("Retry-After")); err != nil || retry <= 0 {
+		t.Fatalf("Retry-After = %q, want a positive number of seconds", w.Header().Get("Retry-After"))
+	}
+}
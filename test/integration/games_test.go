@@ -1,10 +1,13 @@
 package integration
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 func TestGamesFlow(t *testing.T) {
@@ -68,14 +71,17 @@ func TestGamesFlow(t *testing.T) {
 		t.Errorf("expected 403 for guest creating game, got %d", w.Code)
 	}
 
-	t.Log("Step: Get game - access_secret visible to creator (player)")
+	t.Log("Step: Get game - the creator organizes it and sees access_secret")
 	w = makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/games/%d", gameID), nil, playerToken)
 	if w.Code != http.StatusOK {
 		t.Fatalf("get game: %d %s", w.Code, w.Body.String())
 	}
 	game = parseJSON(t, w)
-	if game["access_secret"] != nil {
-		t.Log("access_secret hidden from non-participant player")
+	if game["viewer_game_role"] != "organizer" {
+		t.Errorf("expected creator to organize the game, got %v", game["viewer_game_role"])
+	}
+	if game["access_secret"] != "super-secret-123" {
+		t.Errorf("organizer should see access_secret, got %v", game["access_secret"])
 	}
 
 	t.Log("Step: List games")
@@ -319,8 +325,14 @@ func TestGamesFlow(t *testing.T) {
 		t.Fatalf("delete result: %d %s", w.Code, w.Body.String())
 	}
 
-	t.Log("Step: Delete game")
+	t.Log("Step: Organizer cannot delete game")
 	w = makeReq(t, engine, http.MethodDelete, fmt.Sprintf("/api/v1/games/%d", gameID), nil, playerToken)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for organizer deleting game, got %d", w.Code)
+	}
+
+	t.Log("Step: Delete game")
+	w = makeReq(t, engine, http.MethodDelete, fmt.Sprintf("/api/v1/games/%d", gameID), nil, adminToken)
 	if w.Code != http.StatusNoContent {
 		t.Fatalf("delete game: %d %s", w.Code, w.Body.String())
 	}
@@ -333,3 +345,191 @@ func TestGamesFlow(t *testing.T) {
 
 	_ = player2ID
 }
+
+func TestGameServicePinsFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, ownerToken := seedUser(t, store, "owner_pins", "Owner Pins", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/games", map[string]interface{}{
+		"name":      "Pinned Game",
+		"starts_at": time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+		"ends_at":   time.Now().Add(48 * time.Hour).Format(time.RFC3339),
+	}, ownerToken)
+	requireStatus(t, w, http.StatusCreated, "create game")
+	gameID := jsonID(t, parseJSON(t, w))
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/services", map[string]interface{}{
+		"name": "pinned-service", "public": true,
+	}, ownerToken)
+	requireStatus(t, w, http.StatusCreated, "create service")
+	serviceID := jsonID(t, parseJSON(t, w))
+	uploadPath := fmt.Sprintf("/api/v1/services/%d/upload-archives", serviceID)
+	archive := createTestZip(t, map[string]string{"service/app.py": "print('v1')\n"})
+	requireStatus(t, makeMultipartUpload(t, engine, uploadPath, archive, "service_archive", "service.zip", ownerToken), http.StatusOK, "upload archive")
+
+	w = makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/services/%d/archive-versions", serviceID), nil, ownerToken)
+	requireStatus(t, w, http.StatusOK, "list archive versions")
+	versionID := jsonID(t, parseItems(t, w)[0])
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, fmt.Sprintf("/api/v1/games/%d/services", gameID), map[string]interface{}{
+		"service_id": serviceID,
+	}, ownerToken), http.StatusOK, "add game service")
+
+	pinPath := fmt.Sprintf("/api/v1/games/%d/services/%d/pin", gameID, serviceID)
+	w = makeReq(t, engine, http.MethodPut, pinPath, map[string]interface{}{"archive_version_id": versionID}, ownerToken)
+	requireStatus(t, w, http.StatusOK, "pin game service")
+	if got := parseJSON(t, w)["pinned_service_version_id"]; got != float64(versionID) {
+		t.Fatalf("pinned_service_version_id = %v, want %d", got, versionID)
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodPut, pinPath, map[string]interface{}{}, ownerToken), http.StatusUnprocessableEntity, "pin without a version")
+
+	pins := listServiceGamePins(t, engine, fmt.Sprintf("/api/v1/services/%d/game-pins?version_id=%d", serviceID, versionID), ownerToken)
+	if len(pins) != 1 || pins[0]["follows_current"] != false {
+		t.Fatalf("game pins = %v, want one pinned game", pins)
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, pinPath, nil, ownerToken), http.StatusNoContent, "unpin game service")
+	pins = listServiceGamePins(t, engine, fmt.Sprintf("/api/v1/services/%d/game-pins", serviceID), ownerToken)
+	if len(pins) != 1 || pins[0]["follows_current"] != true {
+		t.Fatalf("game pins = %v, want one game following the current archive", pins)
+	}
+}
+
+func listServiceGamePins(t *testing.T, engine *gin.Engine, path, token string) []map[string]interface{} {
+	t.Helper()
+	w := makeReq(t, engine, http.MethodGet, path, nil, token)
+	requireStatus(t, w, http.StatusOK, "list service game pins")
+	var pins []map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &pins); err != nil {
+		t.Fatalf("parsing game pins: %v, body: %s", err, w.Body.String())
+	}
+	return pins
+}
+
+func TestGamePortConflictsFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_ports", "Admin Ports", "admin12345", "admin")
+	_, ownerToken := seedUser(t, store, "owner_ports", "Owner Ports", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/games", map[string]interface{}{
+		"name":      "Ports Game",
+		"starts_at": time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+		"ends_at":   time.Now().Add(48 * time.Hour).Format(time.RFC3339),
+	}, ownerToken)
+	requireStatus(t, w, http.StatusCreated, "create game")
+	gameID := jsonID(t, parseJSON(t, w))
+
+	serviceIDs := make(map[string]int64)
+	for name, port := range map[string]int{"bank": 8080, "notes": 9090, "shop": 8080} {
+		w = makeReq(t, engine, http.MethodPost, "/api/v1/services", map[string]interface{}{
+			"name": name, "public": true, "ports": []int{port},
+		}, ownerToken)
+		requireStatus(t, w, http.StatusCreated, "create service "+name)
+		serviceIDs[name] = jsonID(t, parseJSON(t, w))
+	}
+
+	addPath := fmt.Sprintf("/api/v1/games/%d/services", gameID)
+	requireStatus(t, makeReq(t, engine, http.MethodPost, addPath, map[string]interface{}{"service_id": serviceIDs["bank"]}, ownerToken), http.StatusOK, "add bank")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, addPath, map[string]interface{}{"service_id": serviceIDs["notes"]}, ownerToken), http.StatusOK, "add notes")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, addPath, map[string]interface{}{"service_id": serviceIDs["shop"]}, ownerToken), http.StatusUnprocessableEntity, "add shop with a taken port")
+
+	conflictsPath := fmt.Sprintf("/api/v1/games/%d/port-conflicts", gameID)
+	if conflicts := listGamePortConflicts(t, engine, conflictsPath, ownerToken); len(conflicts) != 0 {
+		t.Fatalf("port conflicts = %v, want none", conflicts)
+	}
+
+	// A service changing its ports after it was added can still collide.
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, fmt.Sprintf("/api/v1/services/%d", serviceIDs["notes"]), map[string]interface{}{
+		"ports": []int{8080},
+	}, ownerToken), http.StatusOK, "move notes to port 8080")
+	conflicts := listGamePortConflicts(t, engine, conflictsPath, ownerToken)
+	if len(conflicts) != 1 || conflicts[0]["port"] != float64(8080) {
+		t.Fatalf("port conflicts = %v, want one on 8080", conflicts)
+	}
+	if suggestions := conflicts[0]["suggestions"].([]interface{}); len(suggestions) != 1 {
+		t.Errorf("suggestions = %v, want one", suggestions)
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodGet, "/api/v1/games/999999/port-conflicts", nil, adminToken), http.StatusNotFound, "port conflicts of a missing game")
+}
+
+func listGamePortConflicts(t *testing.T, engine *gin.Engine, path, token string) []map[string]interface{} {
+	t.Helper()
+	w := makeReq(t, engine, http.MethodGet, path, nil, token)
+	requireStatus(t, w, http.StatusOK, "list game port conflicts")
+	var conflicts []map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &conflicts); err != nil {
+		t.Fatalf("parsing port conflicts: %v, body: %s", err, w.Body.String())
+	}
+	return conflicts
+}
+
+func TestGameRolesFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_roles", "Admin Roles", "admin12345", "admin")
+	organizerID, organizerToken := seedUser(t, store, "organizer", "Organizer", "password123", "player")
+	juryID, juryToken := seedUser(t, store, "jury", "Jury", "password123", "guest")
+	_, outsiderToken := seedUser(t, store, "outsider_roles", "Outsider", "password123", "player")
+
+	t.Log("Step: admin creates a game with the scoreboard hidden")
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/games", map[string]interface{}{
+		"name":                "Roles Game",
+		"scoreboard_opens_at": time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+	}, adminToken)
+	requireStatus(t, w, http.StatusCreated, "create game")
+	gameID := jsonID(t, parseJSON(t, w))
+	gamePath := fmt.Sprintf("/api/v1/games/%d", gameID)
+	rolesPath := gamePath + "/roles"
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/teams", map[string]interface{}{"name": "Roles Team"}, outsiderToken)
+	requireStatus(t, w, http.StatusCreated, "create team")
+	teamID := jsonID(t, parseJSON(t, w))
+
+	t.Log("Step: only admins appoint organizers")
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, gamePath, map[string]interface{}{"name": "Nope"}, organizerToken), http.StatusForbidden, "player edits a foreign game")
+	requireStatus(t, makeReq(t, engine, http.MethodPut, fmt.Sprintf("%s/%d", rolesPath, organizerID), map[string]interface{}{"role": "organizer"}, organizerToken), http.StatusForbidden, "player appoints themselves")
+	requireStatus(t, makeReq(t, engine, http.MethodPut, fmt.Sprintf("%s/%d", rolesPath, organizerID), map[string]interface{}{"role": "organizer"}, adminToken), http.StatusOK, "admin appoints an organizer")
+	requireStatus(t, makeReq(t, engine, http.MethodPut, fmt.Sprintf("%s/%d", rolesPath, organizerID), map[string]interface{}{"role": "referee"}, adminToken), http.StatusUnprocessableEntity, "unknown role")
+
+	t.Log("Step: the organizer runs the game")
+	w = makeReq(t, engine, http.MethodPatch, gamePath, map[string]interface{}{"name": "Roles Game 2"}, organizerToken)
+	requireStatus(t, w, http.StatusOK, "organizer edits the game")
+	if role := parseJSON(t, w)["viewer_game_role"]; role != "organizer" {
+		t.Errorf("viewer_game_role = %v, want organizer", role)
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/game-teams", map[string]interface{}{"game_id": gameID, "team_id": teamID}, outsiderToken), http.StatusForbidden, "outsider adds a team")
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/game-teams", map[string]interface{}{"game_id": gameID, "team_id": teamID}, organizerToken)
+	requireStatus(t, w, http.StatusCreated, "organizer adds a team")
+	gameTeamID := jsonID(t, parseJSON(t, w))
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, fmt.Sprintf("/api/v1/game-teams/%d", gameTeamID), map[string]interface{}{"ip_address": "10.0.0.5"}, organizerToken), http.StatusOK, "organizer edits a game team")
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, fmt.Sprintf("/api/v1/game-teams/%d", gameTeamID), map[string]interface{}{"ip_address": "10.0.0.6"}, outsiderToken), http.StatusForbidden, "outsider edits a game team")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, gamePath, nil, organizerToken), http.StatusForbidden, "organizer deletes the game")
+
+	t.Log("Step: the organizer appoints the jury, not other organizers")
+	requireStatus(t, makeReq(t, engine, http.MethodPut, fmt.Sprintf("%s/%d", rolesPath, juryID), map[string]interface{}{"role": "organizer"}, organizerToken), http.StatusForbidden, "organizer appoints an organizer")
+	requireStatus(t, makeReq(t, engine, http.MethodPut, fmt.Sprintf("%s/%d", rolesPath, juryID), map[string]interface{}{"role": "jury"}, organizerToken), http.StatusOK, "organizer appoints the jury")
+	w = makeReq(t, engine, http.MethodGet, rolesPath, nil, organizerToken)
+	requireStatus(t, w, http.StatusOK, "list game roles")
+	if items := parseJSON(t, w)["items"].([]interface{}); len(items) != 2 {
+		t.Errorf("game roles = %v, want two", items)
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodGet, rolesPath, nil, juryToken), http.StatusForbidden, "jury lists game roles")
+
+	t.Log("Step: the jury records results and sees the hidden scoreboard")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/results", map[string]interface{}{"game_id": gameID, "team_id": teamID, "score": 1}, outsiderToken), http.StatusForbidden, "outsider records a result")
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/results", map[string]interface{}{"game_id": gameID, "team_id": teamID, "score": 100}, juryToken)
+	requireStatus(t, w, http.StatusCreated, "jury records a result")
+	resultPath := fmt.Sprintf("/api/v1/results/%d", jsonID(t, parseJSON(t, w)))
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, resultPath, map[string]interface{}{"score": 150}, juryToken), http.StatusOK, "jury edits a result")
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, gamePath, map[string]interface{}{"name": "Jury Game"}, juryToken), http.StatusForbidden, "jury edits the game")
+	scoreboardPath := gamePath + "/scoreboard"
+	requireStatus(t, makeReq(t, engine, http.MethodGet, scoreboardPath, nil, juryToken), http.StatusOK, "jury sees the hidden scoreboard")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, scoreboardPath, nil, outsiderToken), http.StatusForbidden, "outsider sees the hidden scoreboard")
+
+	t.Log("Step: removing roles takes the rights away")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("%s/%d", rolesPath, organizerID), nil, organizerToken), http.StatusForbidden, "organizer removes an organizer")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("%s/%d", rolesPath, juryID), nil, organizerToken), http.StatusNoContent, "organizer removes the jury")
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, resultPath, map[string]interface{}{"score": 200}, juryToken), http.StatusForbidden, "former jury edits a result")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("%s/%d", rolesPath, organizerID), nil, adminToken), http.StatusNoContent, "admin removes the organizer")
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, gamePath, map[string]interface{}{"name": "Gone"}, organizerToken), http.StatusForbidden, "former organizer edits the game")
+}
@@ -9,97 +9,159 @@ func TestHTTPRouteCatalogIsCoveredByIntegrationSuite(t *testing.T) {
 	engine, _ := setupTest(t)
 
 	expected := map[string]bool{
-		"GET /healthz":                                  true,
-		"GET /version":                                  true,
-		"POST /api/v1/session":                          true,
-		"DELETE /api/v1/session":                        true,
-		"GET /api/v1/profile":                           true,
-		"PATCH /api/v1/profile":                         true,
-		"PUT /api/v1/profile/password":                  true,
-		"POST /api/v1/profile/avatar":                   true,
-		"GET /api/v1/profile/sessions":                  true,
-		"GET /api/v1/users":                             true,
-		"POST /api/v1/users":                            true,
-		"GET /api/v1/users/:id":                         true,
-		"PATCH /api/v1/users/:id":                       true,
-		"PATCH /api/v1/users/:id/role":                  true,
-		"DELETE /api/v1/users/:id":                      true,
-		"PATCH /api/v1/users/:id/profile":               true,
-		"PUT /api/v1/users/:id/password":                true,
-		"POST /api/v1/users/:id/block":                  true,
-		"GET /api/v1/users/:id/avatar":                  true,
-		"POST /api/v1/users/:id/avatar":                 true,
-		"GET /api/v1/users/:id/sessions":                true,
-		"DELETE /api/v1/users/:id/sessions/:sessionId":  true,
-		"GET /api/v1/universities":                      true,
-		"POST /api/v1/universities":                     true,
-		"GET /api/v1/universities/:id":                  true,
-		"PATCH /api/v1/universities/:id":                true,
-		"DELETE /api/v1/universities/:id":               true,
-		"GET /api/v1/teams":                             true,
-		"POST /api/v1/teams":                            true,
-		"GET /api/v1/teams/:id":                         true,
-		"PATCH /api/v1/teams/:id":                       true,
-		"DELETE /api/v1/teams/:id":                      true,
-		"POST /api/v1/teams/:id/join-request":           true,
-		"POST /api/v1/teams/:id/invite":                 true,
-		"GET /api/v1/teams/:id/members":                 true,
-		"GET /api/v1/teams/:id/events":                  true,
-		"GET /api/v1/team-memberships":                  true,
-		"POST /api/v1/team-memberships":                 true,
-		"GET /api/v1/team-memberships/:id":              true,
-		"PATCH /api/v1/team-memberships/:id":            true,
-		"DELETE /api/v1/team-memberships/:id":           true,
-		"POST /api/v1/team-memberships/:id/approve":     true,
-		"POST /api/v1/team-memberships/:id/reject":      true,
-		"POST /api/v1/team-memberships/:id/accept":      true,
-		"POST /api/v1/team-memberships/:id/decline":     true,
-		"POST /api/v1/team-memberships/:id/set-role":    true,
-		"GET /api/v1/games":                             true,
-		"POST /api/v1/games":                            true,
-		"GET /api/v1/games/:id":                         true,
-		"PATCH /api/v1/games/:id":                       true,
-		"DELETE /api/v1/games/:id":                      true,
-		"POST /api/v1/games/:id/finalize":               true,
-		"POST /api/v1/games/:id/unfinalize":             true,
-		"POST /api/v1/games/:id/publish":                true,
-		"GET /api/v1/games/:id/services":                true,
-		"POST /api/v1/games/:id/services":               true,
-		"DELETE /api/v1/games/:id/services/:service_id": true,
-		"PATCH /api/v1/games/:id/services/:service_id":  true,
-		"GET /api/v1/games/:id/teams":                   true,
-		"POST /api/v1/games/:id/teams/reorder":          true,
-		"GET /api/v1/games/:id/scoreboard":              true,
-		"GET /api/v1/games/:id/export/ctf01d/options":   true,
-		"POST /api/v1/games/:id/export/ctf01d":          true,
-		"POST /api/v1/game-teams":                       true,
-		"PATCH /api/v1/game-teams/:id":                  true,
-		"DELETE /api/v1/game-teams/:id":                 true,
-		"GET /api/v1/results":                           true,
-		"POST /api/v1/results":                          true,
-		"GET /api/v1/results/:id":                       true,
-		"PATCH /api/v1/results/:id":                     true,
-		"DELETE /api/v1/results/:id":                    true,
-		"GET /api/v1/writeups":                          true,
-		"POST /api/v1/writeups":                         true,
-		"GET /api/v1/writeups/:id":                      true,
-		"DELETE /api/v1/writeups/:id":                   true,
-		"GET /api/v1/scoreboard":                        true,
-		"GET /api/v1/services":                          true,
-		"POST /api/v1/services":                         true,
-		"POST /api/v1/services/import/git":              true,
-		"POST /api/v1/services/import/zip":              true,
-		"POST /api/v1/services/import/git/preview":      true,
-		"POST /api/v1/services/import/zip/preview":      true,
-		"DELETE /api/v1/services/:id":                   true,
-		"GET /api/v1/services/:id":                      true,
-		"PATCH /api/v1/services/:id":                    true,
-		"POST /api/v1/services/:id/check-checker":       true,
-		"GET /api/v1/services/:id/download/:kind":       true,
-		"POST /api/v1/services/:id/redownload":          true,
-		"POST /api/v1/services/:id/sync-from-git":       true,
-		"POST /api/v1/services/:id/toggle-public":       true,
-		"POST /api/v1/services/:id/upload-archives":     true,
+		"GET /healthz":                                                    true,
+		"GET /version":                                                    true,
+		"POST /api/v1/session":                                            true,
+		"DELETE /api/v1/session":                                          true,
+		"POST /api/v1/session/two-factor":                                 true,
+		"POST /api/v1/session/two-factor/enrollment":                      true,
+		"GET /api/v1/auth/two-factor/policy":                              true,
+		"PUT /api/v1/auth/two-factor/policy":                              true,
+		"POST /api/v1/session/refresh":                                    true,
+		"GET /api/v1/profile":                                             true,
+		"PATCH /api/v1/profile":                                           true,
+		"PUT /api/v1/profile/password":                                    true,
+		"POST /api/v1/profile/avatar":                                     true,
+		"GET /api/v1/profile/sessions":                                    true,
+		"GET /api/v1/profile/identities":                                  true,
+		"POST /api/v1/profile/identities":                                 true,
+		"DELETE /api/v1/profile/identities/:id":                           true,
+		"GET /api/v1/profile/api-tokens":                                  true,
+		"POST /api/v1/profile/api-tokens":                                 true,
+		"DELETE /api/v1/profile/api-tokens/:id":                           true,
+		"POST /api/v1/profile/email/verification":                         true,
+		"POST /api/v1/auth/password-reset":                                true,
+		"POST /api/v1/auth/password-reset/confirm":                        true,
+		"POST /api/v1/auth/email-verification/confirm":                    true,
+		"GET /api/v1/auth/oidc/providers":                                 true,
+		"POST /api/v1/auth/oidc/:provider/authorize":                      true,
+		"POST /api/v1/auth/oidc/:provider/callback":                       true,
+		"GET /api/v1/profile/two-factor":                                  true,
+		"POST /api/v1/profile/two-factor/enrollment":                      true,
+		"POST /api/v1/profile/two-factor/confirm":                         true,
+		"POST /api/v1/profile/two-factor/recovery-codes":                  true,
+		"POST /api/v1/profile/two-factor/disable":                         true,
+		"GET /api/v1/users":                                               true,
+		"POST /api/v1/users":                                              true,
+		"GET /api/v1/users/:id":                                           true,
+		"PATCH /api/v1/users/:id":                                         true,
+		"PATCH /api/v1/users/:id/role":                                    true,
+		"DELETE /api/v1/users/:id":                                        true,
+		"PATCH /api/v1/users/:id/profile":                                 true,
+		"PUT /api/v1/users/:id/password":                                  true,
+		"POST /api/v1/users/:id/block":                                    true,
+		"GET /api/v1/users/:id/avatar":                                    true,
+		"POST /api/v1/users/:id/avatar":                                   true,
+		"DELETE /api/v1/users/:id/two-factor":                             true,
+		"GET /api/v1/users/:id/sessions":                                  true,
+		"DELETE /api/v1/users/:id/sessions/:sessionId":                    true,
+		"GET /api/v1/audit-events":                                        true,
+		"GET /api/v1/universities":                                        true,
+		"POST /api/v1/universities":                                       true,
+		"GET /api/v1/universities/:id":                                    true,
+		"PATCH /api/v1/universities/:id":                                  true,
+		"DELETE /api/v1/universities/:id":                                 true,
+		"GET /api/v1/teams":                                               true,
+		"POST /api/v1/teams":                                              true,
+		"GET /api/v1/teams/:id":                                           true,
+		"PATCH /api/v1/teams/:id":                                         true,
+		"DELETE /api/v1/teams/:id":                                        true,
+		"POST /api/v1/teams/:id/join-request":                             true,
+		"POST /api/v1/teams/:id/invite":                                   true,
+		"GET /api/v1/teams/:id/members":                                   true,
+		"GET /api/v1/teams/:id/events":                                    true,
+		"GET /api/v1/team-memberships":                                    true,
+		"POST /api/v1/team-memberships":                                   true,
+		"GET /api/v1/team-memberships/:id":                                true,
+		"PATCH /api/v1/team-memberships/:id":                              true,
+		"DELETE /api/v1/team-memberships/:id":                             true,
+		"POST /api/v1/team-memberships/:id/approve":                       true,
+		"POST /api/v1/team-memberships/:id/reject":                        true,
+		"POST /api/v1/team-memberships/:id/accept":                        true,
+		"POST /api/v1/team-memberships/:id/decline":                       true,
+		"POST /api/v1/team-memberships/:id/set-role":                      true,
+		"GET /api/v1/games":                                               true,
+		"POST /api/v1/games":                                              true,
+		"GET /api/v1/games/:id":                                           true,
+		"PATCH /api/v1/games/:id":                                         true,
+		"DELETE /api/v1/games/:id":                                        true,
+		"POST /api/v1/games/:id/finalize":                                 true,
+		"POST /api/v1/games/:id/unfinalize":                               true,
+		"POST /api/v1/games/:id/publish":                                  true,
+		"GET /api/v1/games/:id/services":                                  true,
+		"POST /api/v1/games/:id/services":                                 true,
+		"DELETE /api/v1/games/:id/services/:service_id":                   true,
+		"PATCH /api/v1/games/:id/services/:service_id":                    true,
+		"PUT /api/v1/games/:id/services/:service_id/pin":                  true,
+		"DELETE /api/v1/games/:id/services/:service_id/pin":               true,
+		"GET /api/v1/games/:id/port-conflicts":                            true,
+		"GET /api/v1/games/:id/teams":                                     true,
+		"POST /api/v1/games/:id/teams/reorder":                            true,
+		"GET /api/v1/games/:id/scoreboard":                                true,
+		"GET /api/v1/games/:id/export/ctf01d/options":                     true,
+		"POST /api/v1/games/:id/export/ctf01d":                            true,
+		"GET /api/v1/games/:id/roles":                                     true,
+		"PUT /api/v1/games/:id/roles/:user_id":                            true,
+		"DELETE /api/v1/games/:id/roles/:user_id":                         true,
+		"POST /api/v1/game-teams":                                         true,
+		"PATCH /api/v1/game-teams/:id":                                    true,
+		"DELETE /api/v1/game-teams/:id":                                   true,
+		"GET /api/v1/results":                                             true,
+		"POST /api/v1/results":                                            true,
+		"GET /api/v1/results/:id":                                         true,
+		"PATCH /api/v1/results/:id":                                       true,
+		"DELETE /api/v1/results/:id":                                      true,
+		"GET /api/v1/writeups":                                            true,
+		"POST /api/v1/writeups":                                           true,
+		"GET /api/v1/writeups/:id":                                        true,
+		"DELETE /api/v1/writeups/:id":                                     true,
+		"GET /api/v1/scoreboard":                                          true,
+		"GET /api/v1/services":                                            true,
+		"POST /api/v1/services":                                           true,
+		"POST /api/v1/services/import/git":                                true,
+		"POST /api/v1/services/import/zip":                                true,
+		"POST /api/v1/services/import/git/preview":                        true,
+		"POST /api/v1/services/import/git/discover":                       true,
+		"POST /api/v1/services/import/git/batch":                          true,
+		"POST /api/v1/services/import/zip/preview":                        true,
+		"GET /api/v1/services/search":                                     true,
+		"POST /api/v1/services/manifest/validate":                         true,
+		"DELETE /api/v1/services/:id":                                     true,
+		"GET /api/v1/services/:id":                                        true,
+		"PATCH /api/v1/services/:id":                                      true,
+		"POST /api/v1/services/:id/check-checker":                         true,
+		"GET /api/v1/services/:id/download/:kind":                         true,
+		"GET /api/v1/services/:id/readme":                                 true,
+		"GET /api/v1/services/:id/readme/assets":                          true,
+		"GET /api/v1/services/:id/download/:kind/signed":                  true,
+		"POST /api/v1/services/:id/download-links":                        true,
+		"POST /api/v1/services/:id/redownload":                            true,
+		"POST /api/v1/services/:id/sync-from-git":                         true,
+		"POST /api/v1/services/:id/toggle-public":                         true,
+		"POST /api/v1/services/:id/upload-archives":                       true,
+		"GET /api/v1/services/:id/archive-versions":                       true,
+		"GET /api/v1/services/:id/archive-versions/diff":                  true,
+		"POST /api/v1/services/:id/archive-versions/:version_id/rollback": true,
+		"GET /api/v1/services/:id/game-pins":                              true,
+		"GET /api/v1/services/:id/vulns":                                  true,
+		"PUT /api/v1/services/:id/vulns":                                  true,
+		"GET /api/v1/services/:id/authors":                                true,
+		"POST /api/v1/services/:id/authors":                               true,
+		"DELETE /api/v1/services/:id/authors/:user_id":                    true,
+		"GET /api/v1/service-submissions":                                 true,
+		"POST /api/v1/service-submissions/git":                            true,
+		"POST /api/v1/service-submissions/zip":                            true,
+		"GET /api/v1/service-submissions/:id":                             true,
+		"POST /api/v1/service-submissions/:id/git":                        true,
+		"POST /api/v1/service-submissions/:id/zip":                        true,
+		"POST /api/v1/service-submissions/:id/review":                     true,
+		"GET /api/v1/service-submissions/:id/comments":                    true,
+		"POST /api/v1/service-submissions/:id/comments":                   true,
+		"GET /api/v1/git-credentials":                                     true,
+		"POST /api/v1/git-credentials":                                    true,
+		"GET /api/v1/git-credentials/:id":                                 true,
+		"PATCH /api/v1/git-credentials/:id":                               true,
+		"DELETE /api/v1/git-credentials/:id":                              true,
 	}
 
 	actual := make(map[string]bool)
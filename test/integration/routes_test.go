@@ -1,10 +1,12 @@
 package integration
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -170,6 +172,21 @@ func TestRemainingHTTPRoutesFlow(t *testing.T) {
 	if w.Body.Len() == 0 {
 		t.Fatal("export ctf01d: empty zip body")
 	}
+	if w.Header().Get("Last-Modified") == "" {
+		t.Fatal("export ctf01d: missing Last-Modified")
+	}
+	exportBody := w.Body.Bytes()
+	resumeReq := httptest.NewRequestWithContext(t.Context(), http.MethodPost, fmt.Sprintf("/api/v1/games/%d/export/ctf01d", gameID), strings.NewReader(`{"prefix":"other"}`))
+	resumeReq.Header.Set("Content-Type", "application/json")
+	resumeReq.Header.Set("Authorization", "Bearer "+ownerToken)
+	resumeReq.Header.Set("Range", "bytes=0-9")
+	resumeReq.Header.Set("If-Range", w.Header().Get("ETag"))
+	resumed := httptest.NewRecorder()
+	engine.ServeHTTP(resumed, resumeReq)
+	requireStatus(t, resumed, http.StatusPartialContent, "resume ctf01d export")
+	if !bytes.Equal(resumed.Body.Bytes(), exportBody[:10]) {
+		t.Fatal("resume ctf01d export: range is not taken from the started export")
+	}
 
 	t.Log("Step: writeups routes")
 	w = makeReq(t, engine, http.MethodPost, "/api/v1/writeups", map[string]interface{}{
@@ -11,6 +11,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -368,3 +370,521 @@ func makeMultipartUpload(t *testing.T, engine *gin.Engine, path string, fileData
 	engine.ServeHTTP(w, req)
 	return w
 }
+
+func TestGitCredentialsFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_creds", "Admin Creds", "password123", "admin")
+	_, playerToken := seedUser(t, store, "player_creds", "Player Creds", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/git-credentials", map[string]interface{}{
+		"name": "github-org", "kind": "https_token", "host_pattern": "github.com/ctf01d/*", "secret": "ghp_secret",
+	}, playerToken)
+	requireStatus(t, w, http.StatusForbidden, "player must not create git credential")
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/git-credentials", map[string]interface{}{
+		"name": "github-org", "kind": "https_token", "host_pattern": "github.com/ctf01d/*", "secret": "ghp_secret",
+	}, adminToken)
+	requireStatus(t, w, http.StatusCreated, "create git credential")
+	cred := parseJSON(t, w)
+	credID := jsonID(t, cred)
+	if _, ok := cred["secret"]; ok {
+		t.Fatal("git credential secret must never be returned")
+	}
+
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/git-credentials", nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "list git credentials")
+	if items := parseItems(t, w); len(items) != 1 {
+		t.Fatalf("git credentials = %d, want 1", len(items))
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/git-credentials/%d", credID), nil, adminToken), http.StatusOK, "get git credential")
+
+	w = makeReq(t, engine, http.MethodPatch, fmt.Sprintf("/api/v1/git-credentials/%d", credID), map[string]interface{}{
+		"name": "github-ctf01d",
+	}, adminToken)
+	requireStatus(t, w, http.StatusOK, "update git credential")
+	if name := parseJSON(t, w)["name"]; name != "github-ctf01d" {
+		t.Errorf("name = %v, want github-ctf01d", name)
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("/api/v1/git-credentials/%d", credID), nil, adminToken), http.StatusNoContent, "delete git credential")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/git-credentials/%d", credID), nil, adminToken), http.StatusNotFound, "get deleted git credential")
+}
+
+func TestServiceArchiveVersionsFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_versions", "Admin Versions", "password123", "admin")
+	_, authorToken := seedUser(t, store, "author_versions", "Author Versions", "password123", "player")
+	_, otherToken := seedUser(t, store, "other_versions", "Other Versions", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/services", map[string]interface{}{
+		"name": "versioned-service", "public": true,
+	}, authorToken)
+	requireStatus(t, w, http.StatusCreated, "create service")
+	serviceID := jsonID(t, parseJSON(t, w))
+	uploadPath := fmt.Sprintf("/api/v1/services/%d/upload-archives", serviceID)
+
+	first := createTestZip(t, map[string]string{"service/hello.txt": "hello world"})
+	requireStatus(t, makeMultipartUpload(t, engine, uploadPath, first, "service_archive", "service.zip", authorToken), http.StatusOK, "upload first archive")
+	second := createTestZip(t, map[string]string{"service/hello.txt": "hello again", "service/new.txt": "new"})
+	requireStatus(t, makeMultipartUpload(t, engine, uploadPath, second, "service_archive", "service.zip", authorToken), http.StatusOK, "upload second archive")
+
+	w = makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/services/%d/archive-versions?kind=service", serviceID), nil, otherToken)
+	requireStatus(t, w, http.StatusOK, "list archive versions")
+	versions := parseItems(t, w)
+	if len(versions) != 2 {
+		t.Fatalf("archive versions = %d, want 2", len(versions))
+	}
+	newest, oldest := jsonID(t, versions[0]), jsonID(t, versions[1])
+	if versions[0]["current"] != true {
+		t.Errorf("newest version should be current, got %v", versions[0]["current"])
+	}
+
+	w = makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/services/%d/archive-versions/diff?from=%d&to=%d", serviceID, oldest, newest), nil, otherToken)
+	requireStatus(t, w, http.StatusOK, "diff archive versions")
+	diff := parseJSON(t, w)
+	if added := diff["added"].([]interface{}); len(added) != 1 || added[0] != "service/new.txt" {
+		t.Errorf("added = %v, want [service/new.txt]", added)
+	}
+	if modified := diff["modified"].([]interface{}); len(modified) != 1 || modified[0] != "service/hello.txt" {
+		t.Errorf("modified = %v, want [service/hello.txt]", modified)
+	}
+
+	rollbackPath := fmt.Sprintf("/api/v1/services/%d/archive-versions/%d/rollback", serviceID, oldest)
+	requireStatus(t, makeReq(t, engine, http.MethodPost, rollbackPath, nil, otherToken), http.StatusForbidden, "non-author must not roll back archive")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, rollbackPath, nil, adminToken), http.StatusOK, "roll back archive")
+
+	w = makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/services/%d/archive-versions", serviceID), nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "list archive versions after rollback")
+	for _, version := range parseItems(t, w) {
+		if want := jsonID(t, version) == oldest; version["current"] != want {
+			t.Errorf("version %d current = %v, want %v", jsonID(t, version), version["current"], want)
+		}
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, fmt.Sprintf("/api/v1/services/%d", serviceID), map[string]interface{}{
+		"public": false,
+	}, adminToken), http.StatusOK, "hide service")
+	listPath := fmt.Sprintf("/api/v1/services/%d/archive-versions", serviceID)
+	diffPath := fmt.Sprintf("/api/v1/services/%d/archive-versions/diff?from=%d&to=%d", serviceID, oldest, newest)
+	requireStatus(t, makeReq(t, engine, http.MethodGet, listPath, nil, authorToken), http.StatusOK, "author lists versions of a hidden service")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, diffPath, nil, authorToken), http.StatusOK, "author diffs versions of a hidden service")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, listPath, nil, otherToken), http.StatusNotFound, "hidden service versions for a non-author")
+}
+
+func TestServiceGitMonorepoImportFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_monorepo", "Admin Monorepo", "password123", "admin")
+	_, playerToken := seedUser(t, store, "player_monorepo", "Player Monorepo", "password123", "player")
+
+	files := map[string]string{"README.md": "# Training services\n"}
+	for _, id := range []string{"bank", "notes"} {
+		prefix := "services/" + id + "/"
+		files[prefix+"README.md"] = "# " + id + "\n\nService " + id
+		files[prefix+".ctf01d-service.yml"] = fmt.Sprintf("checker-config-v0.5.2:\n  id: %s\n  service_name: %s\n  script_path: ./checker.py\n", id, id)
+		files[prefix+"vuln-service/docker-compose.yml"] = "services: {}\n"
+		files[prefix+"vuln-service/app.py"] = "print('" + id + "')\n"
+		files[prefix+"checker_"+id+"/checker.py"] = "exit(101)\n"
+	}
+	repoDir := createIntegrationGitRepo(t, files)
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/services/import/git/discover", map[string]interface{}{
+		"repo_url": repoDir,
+	}, playerToken), http.StatusForbidden, "player must not discover services")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/services/import/git/discover", map[string]interface{}{
+		"repo_url": repoDir,
+		"ref":      "main",
+	}, adminToken)
+	requireStatus(t, w, http.StatusOK, "discover services in git")
+	discovery := parseJSON(t, w)
+	found := discovery["services"].([]interface{})
+	if len(found) != 2 {
+		t.Fatalf("discovered services = %d, want 2", len(found))
+	}
+	subdirs := make([]string, len(found))
+	for i, raw := range found {
+		subdirs[i] = raw.(map[string]interface{})["subdir"].(string)
+	}
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/services/import/git/batch", map[string]interface{}{
+		"repo_url": repoDir,
+		"ref":      "main",
+		"subdirs":  []string{},
+	}, adminToken)
+	requireStatus(t, w, http.StatusUnprocessableEntity, "batch import without subdirs")
+
+	w = makeReq(t, engine, http.MethodPost, "/api/v1/services/import/git/batch", map[string]interface{}{
+		"repo_url": repoDir,
+		"ref":      "main",
+		"subdirs":  subdirs,
+	}, adminToken)
+	requireStatus(t, w, http.StatusCreated, "batch import services from git")
+	imported := parseItems(t, w)
+	if len(imported) != 2 {
+		t.Fatalf("imported services = %d, want 2", len(imported))
+	}
+	for _, result := range imported {
+		svc := result["service"].(map[string]interface{})
+		if name := svc["name"]; name != "bank" && name != "notes" {
+			t.Errorf("imported service name = %v, want bank or notes", name)
+		}
+	}
+}
+
+func TestServiceManifestValidateFlow(t *testing.T) {
+	engine, _ := setupTest(t)
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/services/manifest/validate", map[string]interface{}{
+		"content": "checker-config-v1:\n  id: bank\n  service_name: Bank\n  script_path: ./checker.py\n",
+	}, "")
+	requireStatus(t, w, http.StatusOK, "validate manifest as JSON")
+	if result := parseJSON(t, w); result["valid"] != true {
+		t.Fatalf("manifest should be valid, got %v", result["diagnostics"])
+	}
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/v1/services/manifest/validate",
+		bytes.NewBufferString("checker-config-v1:\n  id: bank\n  script_path: ./checker.py\ndifficulty: insane\n"))
+	req.Header.Set("Content-Type", "application/yaml")
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	requireStatus(t, w, http.StatusOK, "validate manifest as YAML")
+	result := parseJSON(t, w)
+	if result["valid"] != false {
+		t.Fatal("manifest with an unknown difficulty should be invalid")
+	}
+	if diagnostics := result["diagnostics"].([]interface{}); len(diagnostics) == 0 {
+		t.Fatal("expected diagnostics for an invalid manifest")
+	}
+}
+
+func TestServiceSearchFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_search", "Admin Search", "password123", "admin")
+	_, playerToken := seedUser(t, store, "player_search", "Player Search", "password123", "player")
+
+	for _, svc := range []map[string]interface{}{
+		{"name": "bank", "public_description": "Online banking with transfers", "tech_stack": []string{"python"}, "public": true},
+		{"name": "notes", "public_description": "Encrypted notes", "tech_stack": []string{"go"}, "public": true},
+		{"name": "vault", "public_description": "Secret banking vault", "tech_stack": []string{"go"}, "public": false},
+	} {
+		requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/services", svc, playerToken), http.StatusCreated, "create service "+svc["name"].(string))
+	}
+
+	searchNames := func(query, token string) []string {
+		t.Helper()
+		w := makeReq(t, engine, http.MethodGet, "/api/v1/services/search?"+query, nil, token)
+		requireStatus(t, w, http.StatusOK, "search services "+query)
+		result := parseJSON(t, w)
+		if _, ok := result["facets"].(map[string]interface{}); !ok {
+			t.Fatalf("search %q: facets missing", query)
+		}
+		var names []string
+		for _, raw := range result["items"].([]interface{}) {
+			hit := raw.(map[string]interface{})
+			names = append(names, hit["service"].(map[string]interface{})["name"].(string))
+		}
+		return names
+	}
+
+	if names := searchNames("q=bank", ""); len(names) != 1 || names[0] != "bank" {
+		t.Errorf("guest search bank = %v, want [bank]", names)
+	}
+	if names := searchNames("q=bank", adminToken); len(names) != 2 {
+		t.Errorf("admin search bank = %v, want bank and vault", names)
+	}
+	if names := searchNames("tech=go", playerToken); len(names) != 1 || names[0] != "notes" {
+		t.Errorf("search tech=go = %v, want [notes]", names)
+	}
+}
+
+func TestServiceReadmeFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, playerToken := seedUser(t, store, "player_readme", "Player Readme", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/services", map[string]interface{}{
+		"name": "readme-service", "public": true,
+	}, playerToken)
+	requireStatus(t, w, http.StatusCreated, "create service")
+	serviceID := jsonID(t, parseJSON(t, w))
+
+	requireStatus(t, makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/services/%d/readme", serviceID), nil, ""), http.StatusNotFound, "readme before upload")
+
+	archive := createTestZip(t, map[string]string{
+		"service/README.md":        "# Bank\n\n![diagram](docs/diagram.png)\n\n<script>alert(1)</script>\n",
+		"service/docs/diagram.png": "\x89PNG\r\n\x1a\n",
+		"service/app.py":           "print('bank')\n",
+	})
+	requireStatus(t, makeMultipartUpload(t, engine, fmt.Sprintf("/api/v1/services/%d/upload-archives", serviceID), archive, "service_archive", "service.zip", playerToken), http.StatusOK, "upload archive")
+
+	w = makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/services/%d/readme", serviceID), nil, "")
+	requireStatus(t, w, http.StatusOK, "get readme")
+	readme := parseJSON(t, w)
+	versionID, ok := readme["version_id"].(float64)
+	if !ok {
+		t.Fatalf("version_id = %v, want the uploaded archive version", readme["version_id"])
+	}
+	rendered, _ := readme["html"].(string)
+	if !strings.Contains(rendered, fmt.Sprintf("/api/v1/services/%d/readme/assets", serviceID)) {
+		t.Errorf("README image should point to the asset endpoint, got %s", rendered)
+	}
+	if strings.Contains(rendered, "<script") {
+		t.Errorf("README HTML must be sanitized, got %s", rendered)
+	}
+
+	assetPath := fmt.Sprintf("/api/v1/services/%d/readme/assets?version=%d&path=", serviceID, int64(versionID))
+	w = makeReq(t, engine, http.MethodGet, assetPath+"service/docs/diagram.png", nil, "")
+	requireStatus(t, w, http.StatusOK, "get readme asset")
+	if ct := w.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("asset content-type = %q, want image/png", ct)
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodGet, assetPath+"service/app.py", nil, ""), http.StatusUnprocessableEntity, "readme asset that is not an image")
+}
+
+func TestServiceVulnsFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, authorToken := seedUser(t, store, "author_vulns", "Author Vulns", "password123", "player")
+	_, otherToken := seedUser(t, store, "other_vulns", "Other Vulns", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/services", map[string]interface{}{
+		"name": "vulnerable-service", "public": true,
+	}, authorToken)
+	requireStatus(t, w, http.StatusCreated, "create service")
+	vulnsPath := fmt.Sprintf("/api/v1/services/%d/vulns", jsonID(t, parseJSON(t, w)))
+
+	catalog := map[string]interface{}{"items": []map[string]interface{}{
+		{"title": "SQL injection in login", "cwe": "89", "difficulty": "easy", "exploit": "sqli.py"},
+		{"title": "Path traversal in downloads", "difficulty": "hard"},
+	}}
+	requireStatus(t, makeReq(t, engine, http.MethodPut, vulnsPath, catalog, otherToken), http.StatusForbidden, "non-author must not replace vulns")
+	requireStatus(t, makeReq(t, engine, http.MethodPut, vulnsPath, map[string]interface{}{"items": []map[string]interface{}{
+		{"title": "Broken", "difficulty": "insane"},
+	}}, authorToken), http.StatusUnprocessableEntity, "replace vulns with an unknown difficulty")
+
+	w = makeReq(t, engine, http.MethodPut, vulnsPath, catalog, authorToken)
+	requireStatus(t, w, http.StatusOK, "replace vulns")
+	items := parseItems(t, w)
+	if len(items) != 2 || items[0]["cwe"] != "CWE-89" {
+		t.Fatalf("vulns = %v, want two with CWE-89 first", items)
+	}
+
+	w = makeReq(t, engine, http.MethodGet, vulnsPath, nil, authorToken)
+	requireStatus(t, w, http.StatusOK, "author lists vulns")
+	if items := parseItems(t, w); len(items) != 2 {
+		t.Fatalf("vulns = %d, want 2", len(items))
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodGet, vulnsPath, nil, otherToken), http.StatusForbidden, "vulns stay hidden before a finalized game")
+}
+
+func TestServiceAuthorsFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_authors", "Admin Authors", "password123", "admin")
+	_, ownerToken := seedUser(t, store, "owner_authors", "Owner Authors", "password123", "player")
+	coauthorID, coauthorToken := seedUser(t, store, "coauthor", "Co Author", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/services", map[string]interface{}{
+		"name": "authored-service", "public": true,
+	}, ownerToken)
+	requireStatus(t, w, http.StatusCreated, "create service")
+	serviceID := jsonID(t, parseJSON(t, w))
+	authorsPath := fmt.Sprintf("/api/v1/services/%d/authors", serviceID)
+
+	w = makeReq(t, engine, http.MethodGet, authorsPath, nil, "")
+	requireStatus(t, w, http.StatusOK, "list authors")
+	if authors := parseItems(t, w); len(authors) != 1 || authors[0]["user_name"] != "owner_authors" {
+		t.Fatalf("authors = %v, want the creator", authors)
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, fmt.Sprintf("/api/v1/services/%d", serviceID), map[string]interface{}{
+		"public_description": "edited by a co-author",
+	}, coauthorToken), http.StatusForbidden, "non-author must not update service")
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, authorsPath, map[string]interface{}{"user_id": coauthorID}, ownerToken), http.StatusForbidden, "player must not add authors")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, authorsPath, map[string]interface{}{"user_id": 999999}, adminToken), http.StatusUnprocessableEntity, "add missing user as author")
+	w = makeReq(t, engine, http.MethodPost, authorsPath, map[string]interface{}{"user_id": coauthorID}, adminToken)
+	requireStatus(t, w, http.StatusOK, "add author")
+	if authors := parseItems(t, w); len(authors) != 2 {
+		t.Fatalf("authors = %d, want 2", len(authors))
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodPatch, fmt.Sprintf("/api/v1/services/%d", serviceID), map[string]interface{}{
+		"public_description": "edited by a co-author",
+	}, coauthorToken), http.StatusOK, "co-author updates service")
+
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("%s/%d", authorsPath, coauthorID), nil, adminToken), http.StatusNoContent, "remove author")
+	requireStatus(t, makeReq(t, engine, http.MethodDelete, fmt.Sprintf("%s/%d", authorsPath, coauthorID), nil, adminToken), http.StatusNotFound, "remove author twice")
+}
+
+func TestServiceSubmissionsFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_submissions", "Admin Submissions", "password123", "admin")
+	_, playerToken := seedUser(t, store, "player_submissions", "Player Submissions", "password123", "player")
+	_, otherToken := seedUser(t, store, "other_submissions", "Other Submissions", "password123", "player")
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, "/api/v1/service-submissions/git", map[string]interface{}{
+		"repo_url": createIntegrationGitRepo(t, map[string]string{"README.md": "# local\n"}),
+	}, playerToken), http.StatusUnprocessableEntity, "submit a local repository")
+
+	bundle := createServiceBundleZip(t, "submitted-service", "Submitted description")
+	w := makeMultipartUpload(t, engine, "/api/v1/service-submissions/zip", bundle, "archive", "bundle.zip", playerToken)
+	requireStatus(t, w, http.StatusCreated, "submit service zip")
+	submission := parseJSON(t, w)
+	submissionID := jsonID(t, submission)
+	if submission["status"] != "submitted" {
+		t.Fatalf("status = %v, want submitted", submission["status"])
+	}
+	submissionPath := fmt.Sprintf("/api/v1/service-submissions/%d", submissionID)
+
+	requireStatus(t, makeReq(t, engine, http.MethodGet, submissionPath, nil, playerToken), http.StatusOK, "submitter gets submission")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, submissionPath, nil, otherToken), http.StatusNotFound, "other player must not see submission")
+
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/service-submissions", nil, otherToken)
+	requireStatus(t, w, http.StatusOK, "other player lists submissions")
+	if items := parseItems(t, w); len(items) != 0 {
+		t.Fatalf("other player submissions = %d, want 0", len(items))
+	}
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/service-submissions?status=submitted", nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "admin lists review queue")
+	if items := parseItems(t, w); len(items) != 1 {
+		t.Fatalf("review queue = %d, want 1", len(items))
+	}
+
+	resubmission := createServiceBundleZip(t, "submitted-service", "Fixed description")
+	requireStatus(t, makeMultipartUpload(t, engine, submissionPath+"/zip", resubmission, "archive", "bundle.zip", playerToken), http.StatusConflict, "resubmit before review")
+
+	reviewPath := submissionPath + "/review"
+	requireStatus(t, makeReq(t, engine, http.MethodPost, reviewPath, map[string]interface{}{"decision": "accepted"}, playerToken), http.StatusForbidden, "player must not review")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, reviewPath, map[string]interface{}{"decision": "changes_requested"}, adminToken), http.StatusUnprocessableEntity, "request changes without a comment")
+	w = makeReq(t, engine, http.MethodPost, reviewPath, map[string]interface{}{
+		"decision": "changes_requested",
+		"comment":  "Please describe the vulnerabilities",
+	}, adminToken)
+	requireStatus(t, w, http.StatusOK, "request changes")
+	if status := parseJSON(t, w)["status"]; status != "changes_requested" {
+		t.Fatalf("status = %v, want changes_requested", status)
+	}
+
+	commentsPath := submissionPath + "/comments"
+	w = makeReq(t, engine, http.MethodGet, commentsPath, nil, playerToken)
+	requireStatus(t, w, http.StatusOK, "list comments")
+	comments := parseItems(t, w)
+	if len(comments) != 1 {
+		t.Fatalf("comments = %d, want the review comment", len(comments))
+	}
+	w = makeReq(t, engine, http.MethodPost, commentsPath, map[string]interface{}{
+		"body":      "Added a vulnerability list",
+		"parent_id": jsonID(t, comments[0]),
+	}, playerToken)
+	requireStatus(t, w, http.StatusCreated, "reply to review comment")
+	requireStatus(t, makeReq(t, engine, http.MethodPost, commentsPath, map[string]interface{}{"body": "hi"}, otherToken), http.StatusNotFound, "other player must not comment")
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, submissionPath+"/git", map[string]interface{}{
+		"repo_url": "file:///etc",
+	}, playerToken), http.StatusUnprocessableEntity, "resubmit a local repository")
+	resubmission = createServiceBundleZip(t, "submitted-service", "Fixed description")
+	w = makeMultipartUpload(t, engine, submissionPath+"/zip", resubmission, "archive", "bundle.zip", playerToken)
+	requireStatus(t, w, http.StatusOK, "resubmit service zip")
+	if status := parseJSON(t, w)["status"]; status != "submitted" {
+		t.Fatalf("status = %v, want submitted", status)
+	}
+
+	w = makeReq(t, engine, http.MethodPost, reviewPath, map[string]interface{}{"decision": "accepted"}, adminToken)
+	requireStatus(t, w, http.StatusOK, "accept submission")
+	accepted := parseJSON(t, w)
+	serviceID, ok := accepted["service_id"].(float64)
+	if !ok {
+		t.Fatalf("accepted submission has no service_id: %v", accepted)
+	}
+	w = makeReq(t, engine, http.MethodGet, fmt.Sprintf("/api/v1/services/%d/authors", int64(serviceID)), nil, "")
+	requireStatus(t, w, http.StatusOK, "list authors of accepted service")
+	if authors := parseItems(t, w); len(authors) != 1 || authors[0]["user_name"] != "player_submissions" {
+		t.Fatalf("authors = %v, want the submitter", authors)
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodPost, reviewPath, map[string]interface{}{"decision": "rejected"}, adminToken), http.StatusConflict, "review a decided submission")
+}
+
+func TestServiceSubmissionConcurrentAccept(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_accept", "Admin Accept", "password123", "admin")
+	_, playerToken := seedUser(t, store, "player_accept", "Player Accept", "password123", "player")
+
+	bundle := createServiceBundleZip(t, "raced-service", "Accepted once")
+	w := makeMultipartUpload(t, engine, "/api/v1/service-submissions/zip", bundle, "archive", "bundle.zip", playerToken)
+	requireStatus(t, w, http.StatusCreated, "submit service zip")
+	reviewPath := fmt.Sprintf("/api/v1/service-submissions/%d/review", jsonID(t, parseJSON(t, w)))
+
+	const reviewers = 4
+	codes := make([]int, reviewers)
+	var wg sync.WaitGroup
+	for i := range reviewers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = makeReq(t, engine, http.MethodPost, reviewPath, map[string]interface{}{"decision": "accepted"}, adminToken).Code
+		}()
+	}
+	wg.Wait()
+
+	accepted := 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusOK:
+			accepted++
+		case http.StatusConflict:
+		default:
+			t.Errorf("concurrent accept: status %d, want 200 or 409", code)
+		}
+	}
+	if accepted != 1 {
+		t.Fatalf("accepted %d times, want once", accepted)
+	}
+	w = makeReq(t, engine, http.MethodGet, "/api/v1/services?q=raced-service", nil, adminToken)
+	requireStatus(t, w, http.StatusOK, "list services")
+	if services := parseItems(t, w); len(services) != 1 {
+		t.Errorf("services created = %d, want 1", len(services))
+	}
+}
+
+func TestServiceDownloadLinksFlow(t *testing.T) {
+	engine, store := setupTest(t)
+	_, adminToken := seedUser(t, store, "admin_links", "Admin Links", "password123", "admin")
+	_, playerToken := seedUser(t, store, "player_links", "Player Links", "password123", "player")
+
+	w := makeReq(t, engine, http.MethodPost, "/api/v1/services", map[string]interface{}{
+		"name": "linked-service", "public": true,
+	}, playerToken)
+	requireStatus(t, w, http.StatusCreated, "create service")
+	serviceID := jsonID(t, parseJSON(t, w))
+	linksPath := fmt.Sprintf("/api/v1/services/%d/download-links", serviceID)
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, linksPath, map[string]interface{}{"kind": "service"}, adminToken), http.StatusUnprocessableEntity, "link to a missing archive")
+
+	archive := createTestZip(t, map[string]string{"service/app.py": "print('linked')\n"})
+	requireStatus(t, makeMultipartUpload(t, engine, fmt.Sprintf("/api/v1/services/%d/upload-archives", serviceID), archive, "service_archive", "service.zip", playerToken), http.StatusOK, "upload archive")
+
+	requireStatus(t, makeReq(t, engine, http.MethodPost, linksPath, map[string]interface{}{"kind": "service"}, playerToken), http.StatusForbidden, "player must not create download links")
+	w = makeReq(t, engine, http.MethodPost, linksPath, map[string]interface{}{
+		"kind":          "service",
+		"max_downloads": 1,
+	}, adminToken)
+	requireStatus(t, w, http.StatusCreated, "create download link")
+	link := parseJSON(t, w)
+	signedURL, _ := link["url"].(string)
+	if !strings.Contains(signedURL, "signature=") {
+		t.Fatalf("url = %q, want a signed download path", signedURL)
+	}
+
+	requireStatus(t, makeReq(t, engine, http.MethodGet, strings.Replace(signedURL, "signature=", "signature=x", 1), nil, ""), http.StatusForbidden, "download with a tampered signature")
+	w = makeReq(t, engine, http.MethodGet, signedURL, nil, "")
+	requireStatus(t, w, http.StatusOK, "download through signed link")
+	if w.Header().Get("Content-Type") != "application/zip" || w.Body.Len() == 0 {
+		t.Fatalf("signed download: content-type %q, %d bytes", w.Header().Get("Content-Type"), w.Body.Len())
+	}
+	requireStatus(t, makeReq(t, engine, http.MethodGet, signedURL, nil, ""), http.StatusForbidden, "download through a used up link")
+
+	w = makeReq(t, engine, http.MethodPost, linksPath, map[string]interface{}{"kind": "service"}, adminToken)
+	requireStatus(t, w, http.StatusCreated, "create a second download link")
+	staleURL, _ := parseJSON(t, w)["url"].(string)
+	replacement := createTestZip(t, map[string]string{"service/app.py": "print('replaced')\n"})
+	requireStatus(t, makeMultipartUpload(t, engine, fmt.Sprintf("/api/v1/services/%d/upload-archives", serviceID), replacement, "service_archive", "service.zip", playerToken), http.StatusOK, "replace archive")
+	requireStatus(t, makeReq(t, engine, http.MethodGet, staleURL, nil, ""), http.StatusNotFound, "download a replaced archive")
+}
@@ -0,0 +1,19 @@
+import client from "./client";
+import type { components } from "./schema";
+
+export type AuditEvent = components["schemas"]["AuditEvent"];
+export type AuditEventList = components["schemas"]["AuditEventList"];
+export type AuditEntityType = AuditEvent["entity_type"];
+
+export async function listAuditEvents(query?: {
+  page?: number;
+  per_page?: number;
+  entity_type?: AuditEntityType;
+  entity_id?: string;
+  actor_id?: number;
+  action?: string;
+  since?: string;
+  until?: string;
+}) {
+  return client.GET("/audit-events", { params: { query } });
+}
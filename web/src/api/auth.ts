@@ -1,22 +1,97 @@
 import type { Middleware } from "openapi-fetch";
+import type { components } from "./schema";
+
+type RefreshResponse = components["schemas"]["RefreshResponse"];
 
 const TOKEN_KEY = "auth_token";
+const TOKEN_EXPIRES_KEY = "auth_token_expires_at";
+const REFRESH_TOKEN_KEY = "refresh_token";
+// Renew the access token a little before it expires, so a request does not
+// race the expiry.
+const REFRESH_MARGIN_MS = 30_000;
 
 export function getToken(): string | null {
   return localStorage.getItem(TOKEN_KEY);
 }
 
-export function setToken(token: string): void {
-  localStorage.setItem(TOKEN_KEY, token);
+export function setSession(session: {
+  token: string;
+  expires_at: string;
+  refresh_token: string;
+}): void {
+  localStorage.setItem(TOKEN_KEY, session.token);
+  localStorage.setItem(
+    TOKEN_EXPIRES_KEY,
+    String(new Date(session.expires_at).getTime()),
+  );
+  localStorage.setItem(REFRESH_TOKEN_KEY, session.refresh_token);
 }
 
 export function clearToken(): void {
   localStorage.removeItem(TOKEN_KEY);
+  localStorage.removeItem(TOKEN_EXPIRES_KEY);
+  localStorage.removeItem(REFRESH_TOKEN_KEY);
+}
+
+function tokenIsFresh(): boolean {
+  const expiresAt = Number(localStorage.getItem(TOKEN_EXPIRES_KEY));
+  return !expiresAt || expiresAt - Date.now() > REFRESH_MARGIN_MS;
+}
+
+async function refreshAccessToken(): Promise<string | null> {
+  // Another tab may have refreshed while this one waited for the lock.
+  if (tokenIsFresh()) return getToken();
+  const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY);
+  if (!refreshToken) return getToken();
+  const response = await fetch("/api/v1/session/refresh", {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({ refresh_token: refreshToken }),
+  });
+  if (!response.ok) {
+    clearToken();
+    return null;
+  }
+  const data = (await response.json()) as RefreshResponse;
+  setSession(data);
+  return data.token;
+}
+
+let refreshing: Promise<string | null> | null = null;
+
+// getFreshToken returns the access token, renewing it first when it is about
+// to expire. A refresh token works only once and reusing it revokes the
+// session, so refreshes are serialized within the tab and, through the Web
+// Locks API, across tabs.
+export async function getFreshToken(): Promise<string | null> {
+  if (!getToken() || tokenIsFresh()) return getToken();
+  refreshing ??= (
+    navigator.locks
+      ? navigator.locks.request("auth-refresh", refreshAccessToken)
+      : refreshAccessToken()
+  ).finally(() => {
+    refreshing = null;
+  });
+  return refreshing;
+}
+
+// authFetch is fetch with the access token, for requests the typed client
+// cannot make (multipart uploads).
+export async function authFetch(
+  input: string,
+  init: RequestInit = {},
+): Promise<Response> {
+  const token = await getFreshToken();
+  const headers = new Headers(init.headers);
+  if (token) {
+    headers.set("Authorization", `Bearer ${token}`);
+  }
+  return fetch(input, { ...init, headers });
 }
 
 const authMiddleware: Middleware = {
   async onRequest({ request }) {
-    const token = getToken();
+    const token = await getFreshToken();
     if (token) {
       request.headers.set("Authorization", `Bearer ${token}`);
     }
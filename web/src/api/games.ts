@@ -79,6 +79,29 @@ export async function setGameServiceStatus(
   });
 }
 
+export async function listGamePortConflicts(id: number) {
+  return client.GET("/games/{id}/port-conflicts", {
+    params: { path: { id } },
+  });
+}
+
+export async function pinGameService(
+  id: number,
+  serviceId: number,
+  pin: { archive_version_id?: number; git_commit?: string },
+) {
+  return client.PUT("/games/{id}/services/{service_id}/pin", {
+    params: { path: { id, service_id: serviceId } },
+    body: pin,
+  });
+}
+
+export async function unpinGameService(id: number, serviceId: number) {
+  return client.DELETE("/games/{id}/services/{service_id}/pin", {
+    params: { path: { id, service_id: serviceId } },
+  });
+}
+
 export async function publishGame(id: number) {
   return client.POST("/games/{id}/publish", { params: { path: { id } } });
 }
@@ -125,3 +148,24 @@ export async function exportCtf01d(
 export async function listGameTeams(id: number) {
   return client.GET("/games/{id}/teams", { params: { path: { id } } });
 }
+
+export async function listGameRoles(id: number) {
+  return client.GET("/games/{id}/roles", { params: { path: { id } } });
+}
+
+export async function setGameRole(
+  id: number,
+  userId: number,
+  role: components["schemas"]["GameRoleSetRequest"]["role"],
+) {
+  return client.PUT("/games/{id}/roles/{user_id}", {
+    params: { path: { id, user_id: userId } },
+    body: { role },
+  });
+}
+
+export async function removeGameRole(id: number, userId: number) {
+  return client.DELETE("/games/{id}/roles/{user_id}", {
+    params: { path: { id, user_id: userId } },
+  });
+}
@@ -15,7 +15,7 @@ export interface paths {
         put?: never;
         /**
          * Login
-         * @description Login
+         * @description Login. Users with two-factor authentication get a challenge instead of a session.
          */
         post: operations["login"];
         /**
@@ -28,31 +28,27 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/profile": {
+    "/session/refresh": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get current user profile
-         * @description Get current user profile
+         * Renew the access token
+         * @description Trade a refresh token for a new access token and a new refresh token. A refresh token works once; presenting a used one revokes the session.
          */
-        get: operations["getProfile"];
-        put?: never;
-        post?: never;
+        post: operations["refreshSession"];
         delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update current user profile
-         * @description Update current user profile
-         */
-        patch: operations["updateProfile"];
+        patch?: never;
         trace?: never;
     };
-    "/profile/password": {
+    "/session/two-factor": {
         parameters: {
             query?: never;
             header?: never;
@@ -60,19 +56,19 @@ export interface paths {
             cookie?: never;
         };
         get?: never;
+        put?: never;
         /**
-         * Change current user's password
-         * @description Change the current user's password without affecting other profile fields
+         * Finish signing in with a second factor
+         * @description Redeem a login challenge with an authenticator code or a recovery code. For a challenge that requires enrollment the code confirms the new authenticator and the response carries recovery codes.
          */
-        put: operations["changeProfilePassword"];
-        post?: never;
+        post: operations["completeTwoFactorLogin"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/profile/avatar": {
+    "/session/two-factor/enrollment": {
         parameters: {
             query?: never;
             header?: never;
@@ -82,89 +78,77 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Upload current user's avatar
-         * @description Upload an avatar image for the current user
+         * Set up a second factor while signing in
+         * @description Start enrollment for a challenge with enrollment_required; finish it with /session/two-factor
          */
-        post: operations["uploadProfileAvatar"];
+        post: operations["startTwoFactorLoginEnrollment"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/profile/sessions": {
+    "/auth/password-reset": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * List current user's active sessions
-         * @description List active sessions for the current user
+         * Ask for a password reset link
+         * @description Mail a single-use reset link, valid for an hour, to every account with this user name or verified email. The response is the same whether or not an account matched.
          */
-        get: operations["listProfileSessions"];
-        put?: never;
-        post?: never;
+        post: operations["requestPasswordReset"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/games": {
+    "/auth/password-reset/confirm": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * List games
-         * @description List games
-         */
-        get: operations["listGames"];
+        get?: never;
         put?: never;
         /**
-         * Create a game
-         * @description Create a game
+         * Set a new password with a reset link
+         * @description Redeem a password reset token. An invalid, used or expired token is reported as a validation error on `token`.
          */
-        post: operations["createGame"];
+        post: operations["confirmPasswordReset"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/games/{id}": {
+    "/auth/email-verification/confirm": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * Get a game by ID
-         * @description Get a game by ID
-         */
-        get: operations["getGame"];
+        get?: never;
         put?: never;
-        post?: never;
         /**
-         * Delete a game
-         * @description Delete a game
+         * Verify an email address
+         * @description Redeem an email verification token. It fails once the user has changed the address the link was sent to.
          */
-        delete: operations["deleteGame"];
+        post: operations["confirmEmailVerification"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update a game
-         * @description Update a game
-         */
-        patch: operations["updateGame"];
+        patch?: never;
         trace?: never;
     };
-    "/games/{id}/services": {
+    "/auth/two-factor/policy": {
         parameters: {
             query?: never;
             header?: never;
@@ -172,47 +156,43 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List services linked to a game
-         * @description List services linked to a game
+         * Get the two-factor policy
+         * @description List the roles that must use two-factor authentication
          */
-        get: operations["listGameServices"];
-        put?: never;
+        get: operations["getTwoFactorPolicy"];
         /**
-         * Link a service to a game
-         * @description Link a service to a game
+         * Update the two-factor policy
+         * @description Replace the roles that must use two-factor authentication. Users of those roles without a second factor set it up at their next sign-in.
          */
-        post: operations["addGameService"];
+        put: operations["updateTwoFactorPolicy"];
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/games/{id}/services/{service_id}": {
+    "/auth/oidc/providers": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
-        post?: never;
         /**
-         * Unlink a service from a game
-         * @description Unlink a service from a game
+         * List external identity providers
+         * @description List the identity providers users can sign in with
          */
-        delete: operations["removeGameService"];
+        get: operations["listOIDCProviders"];
+        put?: never;
+        post?: never;
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Set the planning status of a linked service
-         * @description Set the planning status of a linked service
-         */
-        patch: operations["setGameServiceStatus"];
+        patch?: never;
         trace?: never;
     };
-    "/games/{id}/publish": {
+    "/auth/oidc/{provider}/authorize": {
         parameters: {
             query?: never;
             header?: never;
@@ -222,17 +202,17 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Publish a planning game into the games section
-         * @description Publish a planning game into the games section
+         * Start signing in with an external provider
+         * @description Start signing in with an external provider; the provider redirects back to the frontend page /auth/oidc/{provider}/callback
          */
-        post: operations["publishGame"];
+        post: operations["startOIDCLogin"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/games/{id}/finalize": {
+    "/auth/oidc/{provider}/callback": {
         parameters: {
             query?: never;
             header?: never;
@@ -242,49 +222,53 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Finalize game results
-         * @description Finalize game results
+         * Finish signing in with an external provider
+         * @description Redeem the code the provider returned. Unknown identities get a new guest account when auto-provisioning is on.
          */
-        post: operations["finalizeGame"];
+        post: operations["completeOIDCLogin"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/games/{id}/unfinalize": {
+    "/profile": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Unfinalize game results
-         * @description Unfinalize game results
+         * Get current user profile
+         * @description Get current user profile
          */
-        post: operations["unfinalizeGame"];
+        get: operations["getProfile"];
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
-        patch?: never;
+        /**
+         * Update current user profile
+         * @description Update current user profile
+         */
+        patch: operations["updateProfile"];
         trace?: never;
     };
-    "/games/{id}/export/ctf01d/options": {
+    "/profile/password": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
         /**
-         * Get ctf01d export options and warnings for a game
-         * @description Get ctf01d export options and warnings for a game
+         * Change current user's password
+         * @description Change the current user's password without affecting other profile fields
          */
-        get: operations["getCtf01dExportOptions"];
-        put?: never;
+        put: operations["changeProfilePassword"];
         post?: never;
         delete?: never;
         options?: never;
@@ -292,7 +276,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/games/{id}/export/ctf01d": {
+    "/profile/email/verification": {
         parameters: {
             query?: never;
             header?: never;
@@ -302,81 +286,81 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Export game as ctf01d zip archive
-         * @description Export game as ctf01d zip archive
+         * Send an email verification link
+         * @description Mail a verification link, valid for a day, to the current user's email address. Fails when the profile has no email or it is already verified.
          */
-        post: operations["exportCtf01d"];
+        post: operations["sendProfileEmailVerification"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/games/{id}/teams": {
+    "/profile/avatar": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * List teams in a game
-         * @description List teams in a game
+         * Upload current user's avatar
+         * @description Upload an avatar image for the current user
          */
-        get: operations["listGameTeams"];
-        put?: never;
-        post?: never;
+        post: operations["uploadProfileAvatar"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/game-teams": {
+    "/profile/sessions": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Add a team to a game
-         * @description Add a team to a game
+         * List current user's active sessions
+         * @description List active sessions for the current user
          */
-        post: operations["createGameTeam"];
+        get: operations["listProfileSessions"];
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/game-teams/{id}": {
+    "/profile/identities": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * List current user's external identities
+         * @description List the external identities linked to the current user
+         */
+        get: operations["listProfileIdentities"];
         put?: never;
-        post?: never;
         /**
-         * Remove a team from a game
-         * @description Remove a team from a game
+         * Start linking an external identity
+         * @description Start linking an identity of an external provider to the current user
          */
-        delete: operations["deleteGameTeam"];
+        post: operations["linkProfileIdentity"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update a game team entry
-         * @description Update a game team entry
-         */
-        patch: operations["updateGameTeam"];
+        patch?: never;
         trace?: never;
     };
-    "/games/{id}/teams/reorder": {
+    "/profile/identities/{id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -385,18 +369,18 @@ export interface paths {
         };
         get?: never;
         put?: never;
+        post?: never;
         /**
-         * Reorder teams in a game
-         * @description Reorder teams in a game
+         * Unlink an external identity
+         * @description Unlink an identity; the last one of a user without a password cannot be unlinked
          */
-        post: operations["reorderGameTeams"];
-        delete?: never;
+        delete: operations["unlinkProfileIdentity"];
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/results": {
+    "/profile/api-tokens": {
         parameters: {
             query?: never;
             header?: never;
@@ -404,51 +388,43 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List results
-         * @description List results
+         * List current user's API tokens
+         * @description List the personal API tokens of the current user
          */
-        get: operations["listResults"];
+        get: operations["listProfileAPITokens"];
         put?: never;
         /**
-         * Create a result
-         * @description Create a result
+         * Create an API token
+         * @description Create a personal API token that acts as the current user within its scopes
          */
-        post: operations["createResult"];
+        post: operations["createProfileAPIToken"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/results/{id}": {
+    "/profile/api-tokens/{id}": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * Get a result by ID
-         * @description Get a result by ID
-         */
-        get: operations["getResult"];
+        get?: never;
         put?: never;
         post?: never;
         /**
-         * Delete a result
-         * @description Delete a result
+         * Revoke an API token
+         * @description Revoke one of the current user's API tokens
          */
-        delete: operations["deleteResult"];
+        delete: operations["revokeProfileAPIToken"];
         options?: never;
         head?: never;
-        /**
-         * Update a result
-         * @description Update a result
-         */
-        patch: operations["updateResult"];
+        patch?: never;
         trace?: never;
     };
-    "/games/{id}/scoreboard": {
+    "/profile/two-factor": {
         parameters: {
             query?: never;
             header?: never;
@@ -456,10 +432,10 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get scoreboard for a game
-         * @description Get scoreboard for a game
+         * Get current user's two-factor status
+         * @description Get the two-factor authentication status of the current user
          */
-        get: operations["getGameScoreboard"];
+        get: operations["getProfileTwoFactor"];
         put?: never;
         post?: never;
         delete?: never;
@@ -468,79 +444,67 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/scoreboard": {
+    "/profile/two-factor/enrollment": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get global scoreboard
-         * @description Get global scoreboard
+         * Start setting up a second factor
+         * @description Generate an authenticator secret; it takes effect once confirmed with a code
          */
-        get: operations["getGlobalScoreboard"];
-        put?: never;
-        post?: never;
+        post: operations["startProfileTwoFactorEnrollment"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services": {
+    "/profile/two-factor/confirm": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * List services
-         * @description List services
-         */
-        get: operations["listServices"];
+        get?: never;
         put?: never;
         /**
-         * Create a service
-         * @description Create a service
+         * Confirm the second factor
+         * @description Enable two-factor authentication with a code from the new authenticator and get recovery codes
          */
-        post: operations["createService"];
+        post: operations["confirmProfileTwoFactor"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services/{id}": {
+    "/profile/two-factor/recovery-codes": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * Get a service by ID
-         * @description Get a service by ID
-         */
-        get: operations["getService"];
+        get?: never;
         put?: never;
-        post?: never;
         /**
-         * Delete a service
-         * @description Delete a service
+         * Replace recovery codes
+         * @description Replace the recovery codes, confirmed with an authenticator code
          */
-        delete: operations["deleteService"];
+        post: operations["regenerateProfileRecoveryCodes"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update a service
-         * @description Update a service
-         */
-        patch: operations["updateService"];
+        patch?: never;
         trace?: never;
     };
-    "/services/{id}/toggle-public": {
+    "/profile/two-factor/disable": {
         parameters: {
             query?: never;
             header?: never;
@@ -550,117 +514,137 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Toggle service public flag
-         * @description Toggle service public flag
+         * Turn off the second factor
+         * @description Turn off two-factor authentication, confirmed with an authenticator or recovery code; not allowed when the role requires it
          */
-        post: operations["toggleServicePublic"];
+        post: operations["disableProfileTwoFactor"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services/{id}/check-checker": {
+    "/audit-events": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Run checker inspection
-         * @description Run checker inspection
+         * List audit events
+         * @description Browse the log of administrative and sensitive actions, filtered by entity, actor, action and time
          */
-        post: operations["checkServiceChecker"];
+        get: operations["listAuditEvents"];
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services/{id}/redownload": {
+    "/games": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * List games
+         * @description List games
+         */
+        get: operations["listGames"];
         put?: never;
         /**
-         * Re-download service and checker archives from URLs
-         * @description Re-download service and checker archives from URLs
+         * Create a game
+         * @description Create a game
          */
-        post: operations["redownloadServiceArchives"];
+        post: operations["createGame"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services/{id}/sync-from-git": {
+    "/games/{id}": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * Get a game by ID
+         * @description Get a game by ID
+         */
+        get: operations["getGame"];
         put?: never;
+        post?: never;
         /**
-         * Synchronize service metadata and archives from configured git source
-         * @description Synchronize service metadata and archives from configured git source
+         * Delete a game
+         * @description Delete a game
          */
-        post: operations["syncServiceFromGit"];
-        delete?: never;
+        delete: operations["deleteGame"];
         options?: never;
         head?: never;
-        patch?: never;
+        /**
+         * Update a game
+         * @description Update a game
+         */
+        patch: operations["updateGame"];
         trace?: never;
     };
-    "/services/{id}/upload-archives": {
+    "/games/{id}/services": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * List services linked to a game
+         * @description List services linked to a game
+         */
+        get: operations["listGameServices"];
         put?: never;
         /**
-         * Upload service and/or checker archives
-         * @description Upload service and/or checker archives
+         * Link a service to a game
+         * @description Link a service to a game. Fails with a validation error when the service's ports are already used by another service of the game.
          */
-        post: operations["uploadServiceArchives"];
+        post: operations["addGameService"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services/{id}/download/{kind}": {
+    "/games/{id}/services/{service_id}": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * Download service or checker archive
-         * @description Download service or checker archive
-         */
-        get: operations["downloadServiceArchive"];
+        get?: never;
         put?: never;
         post?: never;
-        delete?: never;
+        /**
+         * Unlink a service from a game
+         * @description Unlink a service from a game
+         */
+        delete: operations["removeGameService"];
         options?: never;
         head?: never;
-        patch?: never;
+        /**
+         * Set the planning status of a linked service
+         * @description Set the planning status of a linked service
+         */
+        patch: operations["setGameServiceStatus"];
         trace?: never;
     };
-    "/services/import/git": {
+    "/games/{id}/services/{service_id}/pin": {
         parameters: {
             query?: never;
             header?: never;
@@ -668,19 +652,23 @@ export interface paths {
             cookie?: never;
         };
         get?: never;
-        put?: never;
         /**
-         * Import a service from a git repository
-         * @description Import a service from a git repository
+         * Pin a linked service to an archive version
+         * @description Pin the service and checker archives by archive version or git commit. Finalized games cannot be changed.
          */
-        post: operations["importServiceFromGit"];
-        delete?: never;
+        put: operations["pinGameService"];
+        post?: never;
+        /**
+         * Make a linked service follow its current archive again
+         * @description Make a linked service follow its current archive again
+         */
+        delete: operations["unpinGameService"];
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services/import/git/preview": {
+    "/games/{id}/publish": {
         parameters: {
             query?: never;
             header?: never;
@@ -690,37 +678,37 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Preview and validate a git service import
-         * @description Preview and validate a git service import
+         * Publish a planning game into the games section
+         * @description Publish a planning game into the games section. When GAMES_BLOCK_PUBLISH_ON_PORT_CONFLICTS is set, games whose services share a port are rejected.
          */
-        post: operations["previewServiceGitImport"];
+        post: operations["publishGame"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services/import/zip": {
+    "/games/{id}/port-conflicts": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Import a service from a ZIP file
-         * @description Import a service from a ZIP file
+         * List vulnbox ports shared by services of a game
+         * @description Lists every port used by more than one service of the game. The first service keeps the port; each other service gets the closest free higher port as a suggestion.
          */
-        post: operations["importServiceFromZip"];
+        get: operations["listGamePortConflicts"];
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/services/import/zip/preview": {
+    "/games/{id}/finalize": {
         parameters: {
             query?: never;
             header?: never;
@@ -730,41 +718,37 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Preview and validate a ZIP service import
-         * @description Preview and validate a ZIP service import
+         * Finalize game results
+         * @description Finalize game results
          */
-        post: operations["previewServiceZipImport"];
+        post: operations["finalizeGame"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/team-memberships": {
+    "/games/{id}/unfinalize": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * List team memberships
-         * @description List team memberships
-         */
-        get: operations["listTeamMemberships"];
+        get?: never;
         put?: never;
         /**
-         * Create a team membership
-         * @description Create a team membership
+         * Unfinalize game results
+         * @description Unfinalize game results
          */
-        post: operations["createTeamMembership"];
+        post: operations["unfinalizeGame"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/team-memberships/{id}": {
+    "/games/{id}/export/ctf01d/options": {
         parameters: {
             query?: never;
             header?: never;
@@ -772,27 +756,19 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get a team membership by ID
-         * @description Get a team membership by ID
+         * Get ctf01d export options and warnings for a game
+         * @description Get ctf01d export options and warnings for a game
          */
-        get: operations["getTeamMembership"];
+        get: operations["getCtf01dExportOptions"];
         put?: never;
         post?: never;
-        /**
-         * Delete a team membership
-         * @description Delete a team membership
-         */
-        delete: operations["deleteTeamMembership"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update a team membership
-         * @description Update a team membership
-         */
-        patch: operations["updateTeamMembership"];
+        patch?: never;
         trace?: never;
     };
-    "/team-memberships/{id}/approve": {
+    "/games/{id}/export/ctf01d": {
         parameters: {
             query?: never;
             header?: never;
@@ -802,37 +778,37 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Approve a pending team membership
-         * @description Approve a pending team membership
+         * Export game as ctf01d zip archive
+         * @description Export game as ctf01d zip archive
          */
-        post: operations["approveTeamMembership"];
+        post: operations["exportCtf01d"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/team-memberships/{id}/reject": {
+    "/games/{id}/roles": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Reject a pending team membership
-         * @description Reject a pending team membership
+         * List the roles users hold in a game
+         * @description List the organizers, jury and observers of a game
          */
-        post: operations["rejectTeamMembership"];
+        get: operations["listGameRoles"];
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/team-memberships/{id}/accept": {
+    "/games/{id}/roles/{user_id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -840,59 +816,75 @@ export interface paths {
             cookie?: never;
         };
         get?: never;
-        put?: never;
         /**
-         * Accept a team membership invitation
-         * @description Accept a team membership invitation
+         * Give a user a role in a game
+         * @description Replaces the user's role in the game. Admins appoint organizers; organizers appoint the jury and observers.
          */
-        post: operations["acceptTeamMembership"];
-        delete?: never;
+        put: operations["setGameRole"];
+        post?: never;
+        /**
+         * Take a user's role in a game away
+         * @description Organizers can only remove the jury and observers.
+         */
+        delete: operations["removeGameRole"];
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/team-memberships/{id}/decline": {
+    "/git-credentials": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * List git credentials
+         * @description List credentials used to clone private service repositories
+         */
+        get: operations["listGitCredentials"];
         put?: never;
         /**
-         * Decline a team membership invitation
-         * @description Decline a team membership invitation
+         * Create a git credential
+         * @description Register an HTTPS token or SSH deploy key for private repositories
          */
-        post: operations["declineTeamMembership"];
+        post: operations["createGitCredential"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/team-memberships/{id}/set-role": {
+    "/git-credentials/{id}": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * Get a git credential by ID
+         * @description Get a git credential by ID
+         */
+        get: operations["getGitCredential"];
         put?: never;
+        post?: never;
         /**
-         * Set the role of a team member
-         * @description Set the role of a team member
+         * Delete a git credential
+         * @description Delete a git credential
          */
-        post: operations["setTeamMembershipRole"];
-        delete?: never;
+        delete: operations["deleteGitCredential"];
         options?: never;
         head?: never;
-        patch?: never;
+        /**
+         * Update a git credential
+         * @description Rename, rotate the secret or change the host pattern of a git credential
+         */
+        patch: operations["updateGitCredential"];
         trace?: never;
     };
-    "/teams": {
+    "/games/{id}/teams": {
         parameters: {
             query?: never;
             header?: never;
@@ -900,51 +892,39 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List teams
-         * @description List teams
+         * List teams in a game
+         * @description List teams in a game
          */
-        get: operations["listTeams"];
+        get: operations["listGameTeams"];
         put?: never;
-        /**
-         * Create a team
-         * @description Create a team
-         */
-        post: operations["createTeam"];
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/teams/{id}": {
+    "/game-teams": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        /**
-         * Get a team by ID
-         * @description Get a team by ID
-         */
-        get: operations["getTeam"];
+        get?: never;
         put?: never;
-        post?: never;
         /**
-         * Delete a team
-         * @description Delete a team
+         * Add a team to a game
+         * @description Admins and organizers of the game add teams to it.
          */
-        delete: operations["deleteTeam"];
+        post: operations["createGameTeam"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update a team
-         * @description Update a team
-         */
-        patch: operations["updateTeam"];
+        patch?: never;
         trace?: never;
     };
-    "/teams/{id}/join-request": {
+    "/game-teams/{id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -953,18 +933,22 @@ export interface paths {
         };
         get?: never;
         put?: never;
+        post?: never;
         /**
-         * Request to join a team
-         * @description Request to join a team
+         * Remove a team from a game
+         * @description Remove a team from a game
          */
-        post: operations["requestJoinTeam"];
-        delete?: never;
+        delete: operations["deleteGameTeam"];
         options?: never;
         head?: never;
-        patch?: never;
+        /**
+         * Update a game team entry
+         * @description Update a game team entry
+         */
+        patch: operations["updateGameTeam"];
         trace?: never;
     };
-    "/teams/{id}/invite": {
+    "/games/{id}/teams/reorder": {
         parameters: {
             query?: never;
             header?: never;
@@ -974,17 +958,17 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Invite a user to a team
-         * @description Invite a user to a team
+         * Reorder teams in a game
+         * @description Reorder teams in a game
          */
-        post: operations["inviteToTeam"];
+        post: operations["reorderGameTeams"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/teams/{id}/members": {
+    "/results": {
         parameters: {
             query?: never;
             header?: never;
@@ -992,19 +976,23 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List team members
-         * @description List team members
+         * List results
+         * @description List results
          */
-        get: operations["listTeamMembers"];
+        get: operations["listResults"];
         put?: never;
-        post?: never;
+        /**
+         * Create a result
+         * @description Admins, organizers and the jury of the game record its results.
+         */
+        post: operations["createResult"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/teams/{id}/events": {
+    "/results/{id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -1012,19 +1000,27 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List team membership events
-         * @description List team membership events
+         * Get a result by ID
+         * @description Get a result by ID
          */
-        get: operations["listTeamEvents"];
+        get: operations["getResult"];
         put?: never;
         post?: never;
-        delete?: never;
+        /**
+         * Delete a result
+         * @description Delete a result
+         */
+        delete: operations["deleteResult"];
         options?: never;
         head?: never;
-        patch?: never;
+        /**
+         * Update a result
+         * @description Update a result
+         */
+        patch: operations["updateResult"];
         trace?: never;
     };
-    "/universities": {
+    "/games/{id}/scoreboard": {
         parameters: {
             query?: never;
             header?: never;
@@ -1032,23 +1028,19 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List universities
-         * @description List universities
+         * Get scoreboard for a game
+         * @description Get scoreboard for a game
          */
-        get: operations["listUniversities"];
+        get: operations["getGameScoreboard"];
         put?: never;
-        /**
-         * Create a university
-         * @description Create a university
-         */
-        post: operations["createUniversity"];
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/universities/{id}": {
+    "/scoreboard": {
         parameters: {
             query?: never;
             header?: never;
@@ -1056,51 +1048,79 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get a university by ID
-         * @description Get a university by ID
+         * Get global scoreboard
+         * @description Get global scoreboard
          */
-        get: operations["getUniversity"];
+        get: operations["getGlobalScoreboard"];
         put?: never;
         post?: never;
-        /**
-         * Delete a university
-         * @description Delete a university
-         */
-        delete: operations["deleteUniversity"];
+        delete?: never;
         options?: never;
         head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/service-submissions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
         /**
-         * Update a university
-         * @description Update a university
+         * List service submissions
+         * @description Admins see the whole review queue, players their own submissions.
          */
-        patch: operations["updateUniversity"];
+        get: operations["listServiceSubmissions"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
         trace?: never;
     };
-    "/users": {
+    "/service-submissions/git": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * List users
-         * @description List users
+         * Submit a service from a git repository
+         * @description The repository is fetched anonymously and must pass the import preview.
          */
-        get: operations["listUsers"];
+        post: operations["submitServiceFromGit"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/service-submissions/zip": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
         put?: never;
         /**
-         * Create a user
-         * @description Create a user
+         * Submit a service archive
+         * @description The archive must pass the import preview; it is kept until the submission is decided.
          */
-        post: operations["createUser"];
+        post: operations["submitServiceFromZip"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/users/{id}": {
+    "/service-submissions/{id}": {
         parameters: {
             query?: never;
             header?: never;
@@ -1108,27 +1128,19 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get a user by ID
-         * @description Get a user by ID
+         * Get a service submission
+         * @description Visible to the submitter and admins
          */
-        get: operations["getUser"];
+        get: operations["getServiceSubmission"];
         put?: never;
         post?: never;
-        /**
-         * Delete a user
-         * @description Delete a user and all references to them, confirmed with the admin's password.
-         */
-        delete: operations["deleteUser"];
+        delete?: never;
         options?: never;
         head?: never;
-        /**
-         * Update a user
-         * @description Update a user
-         */
-        patch: operations["updateUser"];
+        patch?: never;
         trace?: never;
     };
-    "/users/{id}/profile": {
+    "/service-submissions/{id}/git": {
         parameters: {
             query?: never;
             header?: never;
@@ -1137,18 +1149,38 @@ export interface paths {
         };
         get?: never;
         put?: never;
-        post?: never;
+        /**
+         * Resubmit a service from a git repository
+         * @description Only submissions sent back for changes can be resubmitted.
+         */
+        post: operations["resubmitServiceFromGit"];
         delete?: never;
         options?: never;
         head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/service-submissions/{id}/zip": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
         /**
-         * Update a user's profile (admin)
-         * @description Update a user's profile fields and password
+         * Resubmit a service archive
+         * @description Only submissions sent back for changes can be resubmitted.
          */
-        patch: operations["updateUserProfileAdmin"];
+        post: operations["resubmitServiceFromZip"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
         trace?: never;
     };
-    "/users/{id}/password": {
+    "/service-submissions/{id}/review": {
         parameters: {
             query?: never;
             header?: never;
@@ -1156,39 +1188,43 @@ export interface paths {
             cookie?: never;
         };
         get?: never;
+        put?: never;
         /**
-         * Change a user's password (admin)
-         * @description Change a user's password without affecting other profile fields
+         * Decide on a service submission
+         * @description Accepting imports the service and makes the submitter its author. Only submissions waiting in the queue can be reviewed.
          */
-        put: operations["changeUserPassword"];
-        post?: never;
+        post: operations["reviewServiceSubmission"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/users/{id}/block": {
+    "/service-submissions/{id}/comments": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * List review comments of a submission
+         * @description Replies carry the id of their parent comment.
+         */
+        get: operations["listServiceSubmissionComments"];
         put?: never;
         /**
-         * Block or unblock a user
-         * @description Block (revoking all sessions) or unblock a user
+         * Comment on a submission
+         * @description The submitter and admins discuss the submission here.
          */
-        post: operations["setUserBlocked"];
+        post: operations["addServiceSubmissionComment"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/users/{id}/avatar": {
+    "/services": {
         parameters: {
             query?: never;
             header?: never;
@@ -1196,23 +1232,23 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get a user's avatar image
-         * @description Returns the stored avatar image for a user
+         * List services
+         * @description List services
          */
-        get: operations["getUserAvatar"];
+        get: operations["listServices"];
         put?: never;
         /**
-         * Upload a user's avatar
-         * @description Upload an avatar image which is scaled and stored on the server
+         * Create a service
+         * @description Create a service
          */
-        post: operations["uploadUserAvatar"];
+        post: operations["createService"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/users/{id}/sessions": {
+    "/services/search": {
         parameters: {
             query?: never;
             header?: never;
@@ -1220,10 +1256,10 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * List a user's active sessions
-         * @description List the active sessions for a user
+         * Full-text search over the service catalog
+         * @description Ranks services by relevance and returns highlighted snippets. Values of one filter are alternatives, different filters must all match. Private descriptions and non-public services are only searched for admins.
          */
-        get: operations["listUserSessions"];
+        get: operations["searchServices"];
         put?: never;
         post?: never;
         delete?: never;
@@ -1232,27 +1268,55 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/users/{id}/sessions/{sessionId}": {
+    "/services/{id}": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
+        /**
+         * Get a service by ID
+         * @description Get a service by ID
+         */
+        get: operations["getService"];
         put?: never;
         post?: never;
         /**
-         * Revoke a user's session
-         * @description Revoke a single active session for a user
+         * Delete a service
+         * @description Delete a service
          */
-        delete: operations["revokeUserSession"];
+        delete: operations["deleteService"];
+        options?: never;
+        head?: never;
+        /**
+         * Update a service
+         * @description Update a service
+         */
+        patch: operations["updateService"];
+        trace?: never;
+    };
+    "/services/{id}/toggle-public": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Toggle service public flag
+         * @description Toggle service public flag
+         */
+        post: operations["toggleServicePublic"];
+        delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/users/{id}/role": {
+    "/services/{id}/check-checker": {
         parameters: {
             query?: never;
             header?: never;
@@ -1261,42 +1325,78 @@ export interface paths {
         };
         get?: never;
         put?: never;
-        post?: never;
+        /**
+         * Run checker inspection
+         * @description Run checker inspection
+         */
+        post: operations["checkServiceChecker"];
         delete?: never;
         options?: never;
         head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/redownload": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
         /**
-         * Update a user's role
-         * @description Update a user's role
+         * Re-download service and checker archives from URLs
+         * @description Re-download service and checker archives from URLs
          */
-        patch: operations["updateUserRole"];
+        post: operations["redownloadServiceArchives"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
         trace?: never;
     };
-    "/writeups": {
+    "/services/{id}/sync-from-git": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * List writeups
-         * @description List writeups
+         * Synchronize service metadata and archives from configured git source
+         * @description Synchronize service metadata and archives from configured git source
          */
-        get: operations["listWriteups"];
+        post: operations["syncServiceFromGit"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/upload-archives": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
         put?: never;
         /**
-         * Create a writeup
-         * @description Create a writeup
+         * Upload service and/or checker archives
+         * @description Upload service and/or checker archives
          */
-        post: operations["createWriteup"];
+        post: operations["uploadServiceArchives"];
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/writeups/{id}": {
+    "/services/{id}/download/{kind}": {
         parameters: {
             query?: never;
             header?: never;
@@ -1304,689 +1404,3710 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get a writeup by ID
-         * @description Get a writeup by ID
+         * Download service or checker archive
+         * @description Download service or checker archive
          */
-        get: operations["getWriteup"];
+        get: operations["downloadServiceArchive"];
         put?: never;
         post?: never;
-        /**
-         * Delete a writeup
-         * @description Delete a writeup
-         */
-        delete: operations["deleteWriteup"];
+        delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-}
-export type webhooks = Record<string, never>;
-export interface components {
-    schemas: {
-        Error: {
-            code: string;
-            message: string;
-            details?: {
-                [key: string]: unknown;
+    "/services/{id}/download-links": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Create a signed, expiring download link for an archive
+         * @description Mints an HMAC-signed URL for one archive of the service, so jury hosts
+         * and provisioning scripts can download it without a token.
+         *
+         */
+        post: operations["createServiceDownloadLink"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/download/{kind}/signed": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Download an archive through a signed link
+         * @description Streams the archive when the signature is valid, the link has not
+         * expired and its download limit is not used up. A link covers the
+         * archive it was issued for; once that archive is replaced, it answers
+         * 404.
+         *
+         */
+        get: operations["downloadServiceArchiveSigned"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/readme": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get the rendered README of a service
+         * @description Get the rendered README of a service
+         */
+        get: operations["getServiceReadme"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/readme/assets": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get an image referenced by the service README
+         * @description Get an image referenced by the service README
+         */
+        get: operations["getServiceReadmeAsset"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/authors": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List the authors of a service
+         * @description List the platform users credited as authors of a service
+         */
+        get: operations["listServiceAuthors"];
+        put?: never;
+        /**
+         * Add an author to a service
+         * @description Authors may update the service, upload archives, sync it from git and see its private fields.
+         */
+        post: operations["addServiceAuthor"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/authors/{user_id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Remove an author from a service
+         * @description Remove an author from a service
+         */
+        delete: operations["removeServiceAuthor"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/vulns": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List the intended vulnerabilities of a service
+         * @description Admins and service authors always see the catalog; players once a game with the service has been finalized.
+         */
+        get: operations["listServiceVulns"];
+        /**
+         * Replace the vulnerability catalog of a service
+         * @description Replace the vulnerability catalog of a service
+         */
+        put: operations["replaceServiceVulns"];
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/archive-versions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List stored archive versions of a service
+         * @description List stored archive versions of a service
+         */
+        get: operations["listServiceArchiveVersions"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/archive-versions/diff": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Compare the file lists of two archive versions
+         * @description Compare the file lists of two archive versions
+         */
+        get: operations["diffServiceArchiveVersions"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/archive-versions/{version_id}/rollback": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Make a stored archive version active again
+         * @description Make a stored archive version active again
+         */
+        post: operations["rollbackServiceArchiveVersion"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/{id}/game-pins": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List games using a service and the archive versions they are pinned to
+         * @description Shows which games follow the current archive and which are pinned, i.e. which games an archive update or pin change affects
+         */
+        get: operations["listServiceGamePins"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/import/git": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Import a service from a git repository
+         * @description Import a service from a git repository
+         */
+        post: operations["importServiceFromGit"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/import/git/preview": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Preview and validate a git service import
+         * @description Preview and validate a git service import
+         */
+        post: operations["previewServiceGitImport"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/import/git/discover": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Find and preview every service in a repository
+         * @description Scans the repository (below subdir, if set) for directories with a service manifest or with service and checker directories
+         */
+        post: operations["discoverServicesInGit"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/import/git/batch": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Import several services of a repository at once
+         * @description Imports the selected discovered directories in one transaction; nothing is imported when one of them fails
+         */
+        post: operations["importServicesFromGit"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/import/zip": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Import a service from a ZIP file
+         * @description Import a service from a ZIP, tar or tar.gz archive. Tar archives are converted to the canonical ZIP bundle.
+         */
+        post: operations["importServiceFromZip"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/import/zip/preview": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Preview and validate a ZIP service import
+         * @description Preview and validate a ZIP, tar or tar.gz service import
+         */
+        post: operations["previewServiceZipImport"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/services/manifest/validate": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Validate a .ctf01d-service.yml manifest
+         * @description Checks a manifest against its versioned JSON schema without importing anything. Accepts the raw YAML document or a JSON object with the document in content, so CI jobs can lint manifests before pushing.
+         */
+        post: operations["validateServiceManifest"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/team-memberships": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List team memberships
+         * @description List team memberships
+         */
+        get: operations["listTeamMemberships"];
+        put?: never;
+        /**
+         * Create a team membership
+         * @description Create a team membership
+         */
+        post: operations["createTeamMembership"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/team-memberships/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get a team membership by ID
+         * @description Get a team membership by ID
+         */
+        get: operations["getTeamMembership"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete a team membership
+         * @description Delete a team membership
+         */
+        delete: operations["deleteTeamMembership"];
+        options?: never;
+        head?: never;
+        /**
+         * Update a team membership
+         * @description Update a team membership
+         */
+        patch: operations["updateTeamMembership"];
+        trace?: never;
+    };
+    "/team-memberships/{id}/approve": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Approve a pending team membership
+         * @description Approve a pending team membership
+         */
+        post: operations["approveTeamMembership"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/team-memberships/{id}/reject": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Reject a pending team membership
+         * @description Reject a pending team membership
+         */
+        post: operations["rejectTeamMembership"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/team-memberships/{id}/accept": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Accept a team membership invitation
+         * @description Accept a team membership invitation
+         */
+        post: operations["acceptTeamMembership"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/team-memberships/{id}/decline": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Decline a team membership invitation
+         * @description Decline a team membership invitation
+         */
+        post: operations["declineTeamMembership"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/team-memberships/{id}/set-role": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Set the role of a team member
+         * @description Set the role of a team member
+         */
+        post: operations["setTeamMembershipRole"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/teams": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List teams
+         * @description List teams
+         */
+        get: operations["listTeams"];
+        put?: never;
+        /**
+         * Create a team
+         * @description Create a team
+         */
+        post: operations["createTeam"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/teams/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get a team by ID
+         * @description Get a team by ID
+         */
+        get: operations["getTeam"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete a team
+         * @description Delete a team
+         */
+        delete: operations["deleteTeam"];
+        options?: never;
+        head?: never;
+        /**
+         * Update a team
+         * @description Update a team
+         */
+        patch: operations["updateTeam"];
+        trace?: never;
+    };
+    "/teams/{id}/join-request": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Request to join a team
+         * @description Request to join a team
+         */
+        post: operations["requestJoinTeam"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/teams/{id}/invite": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Invite a user to a team
+         * @description Invite a user to a team
+         */
+        post: operations["inviteToTeam"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/teams/{id}/members": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List team members
+         * @description List team members
+         */
+        get: operations["listTeamMembers"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/teams/{id}/events": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List team membership events
+         * @description List team membership events
+         */
+        get: operations["listTeamEvents"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/universities": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List universities
+         * @description List universities
+         */
+        get: operations["listUniversities"];
+        put?: never;
+        /**
+         * Create a university
+         * @description Create a university
+         */
+        post: operations["createUniversity"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/universities/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get a university by ID
+         * @description Get a university by ID
+         */
+        get: operations["getUniversity"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete a university
+         * @description Delete a university
+         */
+        delete: operations["deleteUniversity"];
+        options?: never;
+        head?: never;
+        /**
+         * Update a university
+         * @description Update a university
+         */
+        patch: operations["updateUniversity"];
+        trace?: never;
+    };
+    "/users": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List users
+         * @description List users
+         */
+        get: operations["listUsers"];
+        put?: never;
+        /**
+         * Create a user
+         * @description Create a user
+         */
+        post: operations["createUser"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/users/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get a user by ID
+         * @description Get a user by ID
+         */
+        get: operations["getUser"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete a user
+         * @description Delete a user and all references to them, confirmed with the admin's password.
+         */
+        delete: operations["deleteUser"];
+        options?: never;
+        head?: never;
+        /**
+         * Update a user
+         * @description Update a user
+         */
+        patch: operations["updateUser"];
+        trace?: never;
+    };
+    "/users/{id}/profile": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        /**
+         * Update a user's profile (admin)
+         * @description Update a user's profile fields and password
+         */
+        patch: operations["updateUserProfileAdmin"];
+        trace?: never;
+    };
+    "/users/{id}/password": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        /**
+         * Change a user's password (admin)
+         * @description Change a user's password without affecting other profile fields
+         */
+        put: operations["changeUserPassword"];
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/users/{id}/block": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Block or unblock a user
+         * @description Block (revoking all sessions) or unblock a user
+         */
+        post: operations["setUserBlocked"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/users/{id}/avatar": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get a user's avatar image
+         * @description Returns the stored avatar image for a user
+         */
+        get: operations["getUserAvatar"];
+        put?: never;
+        /**
+         * Upload a user's avatar
+         * @description Upload an avatar image which is scaled and stored on the server
+         */
+        post: operations["uploadUserAvatar"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/users/{id}/sessions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List a user's active sessions
+         * @description List the active sessions for a user
+         */
+        get: operations["listUserSessions"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/users/{id}/sessions/{sessionId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Revoke a user's session
+         * @description Revoke a single active session for a user
+         */
+        delete: operations["revokeUserSession"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/users/{id}/role": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        /**
+         * Update a user's role
+         * @description Update a user's role
+         */
+        patch: operations["updateUserRole"];
+        trace?: never;
+    };
+    "/users/{id}/two-factor": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Reset a user's second factor
+         * @description Remove a user's authenticator and recovery codes, e.g. after a lost phone. The user enrolls again at the next sign-in if the role requires it.
+         */
+        delete: operations["resetUserTwoFactor"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/writeups": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List writeups
+         * @description List writeups
+         */
+        get: operations["listWriteups"];
+        put?: never;
+        /**
+         * Create a writeup
+         * @description Create a writeup
+         */
+        post: operations["createWriteup"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/writeups/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get a writeup by ID
+         * @description Get a writeup by ID
+         */
+        get: operations["getWriteup"];
+        put?: never;
+        post?: never;
+        /**
+         * Delete a writeup
+         * @description Delete a writeup
+         */
+        delete: operations["deleteWriteup"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+}
+export type webhooks = Record<string, never>;
+export interface components {
+    schemas: {
+        Error: {
+            code: string;
+            message: string;
+            details?: {
+                [key: string]: unknown;
+            } | null;
+        };
+        Pagination: {
+            page: number;
+            per_page: number;
+            total: number;
+        };
+        Timestamped: {
+            /** Format: date-time */
+            created_at?: string;
+            /** Format: date-time */
+            updated_at?: string;
+        };
+        LoginRequest: {
+            user_name: string;
+            password: string;
+        };
+        LoginResponse: {
+            /** @description Short-lived access token */
+            token: string;
+            /**
+             * Format: date-time
+             * @description When the access token expires
+             */
+            expires_at: string;
+            /** @description Single-use token for /session/refresh, valid while the session lasts */
+            refresh_token: string;
+            user: components["schemas"]["User"];
+            /** @description New recovery codes; only present when two-factor enrollment was completed during sign-in */
+            recovery_codes?: string[];
+        };
+        RefreshRequest: {
+            refresh_token: string;
+        };
+        RefreshResponse: {
+            token: string;
+            /** Format: date-time */
+            expires_at: string;
+            /** @description Replaces the refresh token that was sent, which stops working */
+            refresh_token: string;
+            /**
+             * Format: date-time
+             * @description Refreshing is possible until then; sign in again afterwards
+             */
+            session_expires_at: string;
+        };
+        TwoFactorChallenge: {
+            /** @description Pass it to /session/two-factor with a code to finish signing in */
+            challenge_token: string;
+            /** Format: date-time */
+            expires_at: string;
+            /** @description The user's role requires two-factor authentication and the user has not set it up yet */
+            enrollment_required: boolean;
+        };
+        TwoFactorLoginRequest: {
+            challenge_token: string;
+            /** @description Code from the authenticator app */
+            code?: string;
+            /** @description One-time recovery code, instead of code */
+            recovery_code?: string;
+        };
+        TwoFactorChallengeToken: {
+            challenge_token: string;
+        };
+        TwoFactorEnrollment: {
+            /** @description Base32 secret for manual entry */
+            secret: string;
+            otpauth_uri: string;
+            /**
+             * Format: byte
+             * @description Base64-encoded PNG with the otpauth URI
+             */
+            qr_code_png: string;
+        };
+        TwoFactorCode: {
+            code: string;
+        };
+        TwoFactorDisable: {
+            code?: string;
+            recovery_code?: string;
+        };
+        TwoFactorRecoveryCodes: {
+            /** @description Shown once; each code signs in one time */
+            recovery_codes: string[];
+        };
+        TwoFactorStatus: {
+            enabled: boolean;
+            /** Format: date-time */
+            enabled_at?: string | null;
+            /** @description The user's role requires two-factor authentication */
+            required: boolean;
+            recovery_codes_remaining: number;
+        };
+        TwoFactorPolicy: {
+            required_roles: ("guest" | "player" | "admin")[];
+        };
+        OIDCProvider: {
+            name: string;
+            display_name: string;
+        };
+        OIDCProviderList: {
+            items: components["schemas"]["OIDCProvider"][];
+        };
+        OIDCAuthorization: {
+            /** @description Provider URL to send the browser to */
+            authorization_url: string;
+            /** @description Keep it and check it against the state of the callback */
+            state: string;
+        };
+        OIDCCallbackRequest: {
+            code: string;
+            state: string;
+        };
+        OIDCCallbackResponse: {
+            /** @description Session token; absent when the callback linked an identity */
+            token?: string;
+            /**
+             * Format: date-time
+             * @description When the access token expires; absent when the callback linked an identity
+             */
+            expires_at?: string;
+            /** @description Absent when the callback linked an identity */
+            refresh_token?: string;
+            user: components["schemas"]["User"];
+            linked: boolean;
+        };
+        UserIdentityLink: {
+            provider: string;
+        };
+        UserIdentity: {
+            /** Format: int64 */
+            id: number;
+            provider: string;
+            email?: string | null;
+            /** Format: date-time */
+            created_at: string;
+            /** Format: date-time */
+            last_login_at?: string | null;
+        };
+        UserIdentityList: {
+            items: components["schemas"]["UserIdentity"][];
+        };
+        /** @enum {string} */
+        APITokenScope: "results:read" | "results:write" | "services:sync" | "games:export";
+        APITokenCreate: {
+            name: string;
+            scopes: components["schemas"]["APITokenScope"][];
+            /**
+             * Format: date-time
+             * @description The token lasts until revoked when omitted
+             */
+            expires_at?: string | null;
+        };
+        APIToken: {
+            /** Format: int64 */
+            id: number;
+            name: string;
+            /** @description Leading characters of the token, to recognize it */
+            hint: string;
+            scopes: components["schemas"]["APITokenScope"][];
+            /** Format: date-time */
+            expires_at?: string | null;
+            /** Format: date-time */
+            last_used_at?: string | null;
+            last_used_ip?: string | null;
+            /** Format: date-time */
+            created_at: string;
+        };
+        APITokenList: {
+            items: components["schemas"]["APIToken"][];
+        };
+        APITokenCreated: {
+            /** @description The token itself; it is shown only once */
+            token: string;
+            api_token: components["schemas"]["APIToken"];
+        };
+        PasswordResetRequest: {
+            /** @description User name or email address */
+            login: string;
+        };
+        PasswordResetConfirm: {
+            token: string;
+            password: string;
+        };
+        EmailVerificationConfirm: {
+            token: string;
+        };
+        AuditEvent: {
+            /** Format: int64 */
+            id: number;
+            /**
+             * Format: int64
+             * @description Empty for actions taken outside a request or by a deleted user
+             */
+            actor_id?: number | null;
+            actor_name?: string | null;
+            ip_address?: string | null;
+            /** @description X-Request-ID of the request that took the action */
+            request_id?: string | null;
+            /** @enum {string} */
+            entity_type: "game" | "result" | "user" | "service" | "git_credential";
+            entity_id: string;
+            /** @description For example finalize, unfinalize, export, create, update, delete, change_role, block, unblock, revoke_session, revoke_sessions, reset_two_factor, set_game_role, remove_game_role, upload_archives, redownload, git_sync, git_sync_failed, create_download_link */
+            action: string;
+            /** @description Fields the action changed, as they were; empty when the entity did not exist */
+            before?: {
+                [key: string]: unknown;
             } | null;
+            /** @description Fields the action changed, as they became; empty when the entity is gone */
+            after?: {
+                [key: string]: unknown;
+            } | null;
+            /** Format: date-time */
+            created_at: string;
+        };
+        AuditEventList: {
+            items: components["schemas"]["AuditEvent"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        Game: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            name?: string | null;
+            organizer?: string | null;
+            /** Format: date-time */
+            starts_at?: string | null;
+            /** Format: date-time */
+            ends_at?: string | null;
+            avatar_url?: string | null;
+            site_url?: string | null;
+            ctftime_url?: string | null;
+            finalized: boolean;
+            /** Format: date-time */
+            finalized_at?: string | null;
+            /** Format: date-time */
+            registration_opens_at?: string | null;
+            /** Format: date-time */
+            registration_closes_at?: string | null;
+            /** Format: date-time */
+            scoreboard_opens_at?: string | null;
+            /** Format: date-time */
+            scoreboard_closes_at?: string | null;
+            vpn_url?: string | null;
+            vpn_config_url?: string | null;
+            access_instructions?: string | null;
+            access_secret?: string | null;
+            published?: boolean;
+            theme?: string | null;
+            requirements?: string | null;
+            /** @enum {string} */
+            readonly status?: "upcoming" | "ongoing" | "past" | "unknown";
+            /** @enum {string} */
+            readonly registration_status?: "unscheduled" | "upcoming" | "open" | "closed";
+            /** @enum {string} */
+            readonly scoreboard_status?: "always" | "upcoming" | "open" | "closed";
+            /**
+             * @description Role of the signed-in viewer in this game; returned by the single-game endpoints
+             * @enum {string|null}
+             */
+            readonly viewer_game_role?: "organizer" | "jury" | "observer" | null;
+        };
+        GameRole: {
+            /** Format: int64 */
+            user_id: number;
+            user_name: string;
+            display_name: string;
+            avatar_url?: string | null;
+            /** @enum {string} */
+            role: "organizer" | "jury" | "observer";
+            /** Format: date-time */
+            created_at: string;
+        };
+        GameRoleList: {
+            items: components["schemas"]["GameRole"][];
+        };
+        GameRoleSetRequest: {
+            /** @enum {string} */
+            role: "organizer" | "jury" | "observer";
+        };
+        GameCreate: {
+            name?: string;
+            organizer?: string;
+            /** Format: date-time */
+            starts_at?: string;
+            /** Format: date-time */
+            ends_at?: string;
+            avatar_url?: string;
+            site_url?: string;
+            ctftime_url?: string;
+            /** Format: date-time */
+            registration_opens_at?: string;
+            /** Format: date-time */
+            registration_closes_at?: string;
+            /** Format: date-time */
+            scoreboard_opens_at?: string;
+            /** Format: date-time */
+            scoreboard_closes_at?: string;
+            vpn_url?: string;
+            vpn_config_url?: string;
+            access_instructions?: string;
+            access_secret?: string;
+            published?: boolean;
+            theme?: string;
+            requirements?: string;
+        };
+        GameUpdate: {
+            name?: string;
+            organizer?: string;
+            /** Format: date-time */
+            starts_at?: string;
+            /** Format: date-time */
+            ends_at?: string;
+            avatar_url?: string;
+            site_url?: string;
+            ctftime_url?: string;
+            /** Format: date-time */
+            registration_opens_at?: string;
+            /** Format: date-time */
+            registration_closes_at?: string;
+            /** Format: date-time */
+            scoreboard_opens_at?: string;
+            /** Format: date-time */
+            scoreboard_closes_at?: string;
+            vpn_url?: string;
+            vpn_config_url?: string;
+            access_instructions?: string;
+            access_secret?: string;
+            theme?: string;
+            requirements?: string;
+        };
+        GameList: {
+            items: components["schemas"]["Game"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        GameServiceLink: {
+            /** Format: int64 */
+            service_id: number;
+            status: string;
+            /**
+             * Format: int64
+             * @description Service archive version the game is pinned to; null follows the current archive
+             */
+            pinned_service_version_id?: number | null;
+            /** Format: int64 */
+            pinned_checker_version_id?: number | null;
+            /** @description Git commit the pinned archive was built from */
+            pinned_git_commit?: string | null;
+        };
+        GamePortService: {
+            /** Format: int64 */
+            service_id: number;
+            name: string;
+            ports: number[];
+        };
+        GamePortSuggestion: {
+            /** Format: int64 */
+            service_id: number;
+            /** Format: int32 */
+            port: number;
+        };
+        GamePortConflict: {
+            /** Format: int32 */
+            port: number;
+            services: components["schemas"]["GamePortService"][];
+            suggestions: components["schemas"]["GamePortSuggestion"][];
+        };
+        Ctf01dExportOptions: {
+            /** @default 8080 */
+            port: number;
+            /** @default true */
+            include_html: boolean;
+            /** @default true */
+            include_compose: boolean;
+            html_source_path?: string;
+            /** @default 1 */
+            flag_ttl_min: number;
+            /** @default 1 */
+            basic_attack_cost: number;
+            /** @default 1 */
+            defence_cost: number;
+            /** Format: date-time */
+            coffee_break_start?: string | null;
+            /** Format: date-time */
+            coffee_break_end?: string | null;
+            warnings?: string[];
+        };
+        Ctf01dExportRequest: {
+            prefix?: string;
+            /** @default true */
+            include_html: boolean;
+            html_source_path?: string;
+            /** @default true */
+            include_compose: boolean;
+            compose_project?: string;
+            /** @default 8080 */
+            port: number;
+            /** @default ./html */
+            htmlfolder: string;
+            /** @default false */
+            random: boolean;
+            /** @default 1 */
+            flag_ttl_min: number;
+            /** @default 1 */
+            basic_attack_cost: number;
+            /** @default 1 */
+            defence_cost: number;
+            /** Format: date-time */
+            coffee_break_start?: string | null;
+            /** Format: date-time */
+            coffee_break_end?: string | null;
+        };
+        Ctf01dExportError: {
+            code: string;
+            message?: string;
+            errors: string[];
+        };
+        GitCredential: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            name: string;
+            /** @enum {string} */
+            kind: "https_token" | "ssh_key";
+            /** @description Glob matched against the repository host, or host/owner/repo when it contains a slash */
+            host_pattern?: string | null;
+            username?: string | null;
+        };
+        GitCredentialCreate: {
+            name: string;
+            /** @enum {string} */
+            kind: "https_token" | "ssh_key";
+            host_pattern?: string;
+            username?: string;
+            /** @description HTTPS access token or SSH private key; stored encrypted and never returned */
+            secret: string;
+        };
+        GitCredentialUpdate: {
+            name?: string;
+            /** @description Empty string detaches the credential from host matching */
+            host_pattern?: string;
+            username?: string;
+            secret?: string;
+        };
+        GitCredentialList: {
+            items: components["schemas"]["GitCredential"][];
+        };
+        GameTeam: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            game_id: number;
+            /** Format: int64 */
+            team_id: number;
+            ip_address?: string | null;
+            ctf01d_id?: string | null;
+            ctf01d_overrides?: Record<string, never> | null;
+            team_type?: string | null;
+            order: number;
+        };
+        GameTeamCreate: {
+            /** Format: int64 */
+            game_id: number;
+            /** Format: int64 */
+            team_id: number;
+            ip_address?: string;
+            ctf01d_id?: string;
+            ctf01d_overrides?: Record<string, never>;
+            team_type?: string;
+            order?: number;
+        };
+        GameTeamUpdate: {
+            ip_address?: string;
+            ctf01d_id?: string;
+            ctf01d_overrides?: Record<string, never>;
+            team_type?: string;
+            order?: number;
+        };
+        GameTeamList: {
+            items: components["schemas"]["GameTeam"][];
+        };
+        ReorderRequest: {
+            items: {
+                /** Format: int64 */
+                id: number;
+                order: number;
+            }[];
+        };
+        Result: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            game_id: number;
+            /** Format: int64 */
+            team_id: number;
+            score?: number | null;
+        };
+        ResultCreate: {
+            /** Format: int64 */
+            game_id: number;
+            /** Format: int64 */
+            team_id: number;
+            score?: number;
+        };
+        ResultUpdate: {
+            score?: number;
+        };
+        ResultList: {
+            items: components["schemas"]["Result"][];
+        };
+        ScoreboardEntry: {
+            /** Format: int64 */
+            team_id: number;
+            team_name: string;
+            score: number;
+            position: number;
+        };
+        Scoreboard: {
+            /** Format: int64 */
+            game_id: number;
+            /** @enum {string} */
+            status: "always" | "upcoming" | "open" | "closed";
+            entries: components["schemas"]["ScoreboardEntry"][];
+        };
+        GlobalScoreboard: {
+            entries: {
+                /** Format: int64 */
+                team_id: number;
+                team_name: string;
+                total_score: number;
+            }[];
+        };
+        ServiceSubmission: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            submitter_id: number;
+            /** @enum {string} */
+            status: "submitted" | "changes_requested" | "accepted" | "rejected";
+            /** @enum {string} */
+            source_kind: "git" | "zip";
+            repo_url?: string | null;
+            ref?: string | null;
+            subdir?: string | null;
+            /** @description Commit a git submission was previewed at; accepting imports this commit */
+            commit?: string | null;
+            service_name: string;
+            preview?: components["schemas"]["ServiceImportPreview"];
+            /**
+             * Format: int64
+             * @description Service created when the submission was accepted
+             */
+            service_id?: number | null;
+            /** Format: int64 */
+            reviewer_id?: number | null;
+            /** Format: date-time */
+            reviewed_at?: string | null;
+        };
+        ServiceSubmissionList: {
+            items: components["schemas"]["ServiceSubmission"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        ServiceSubmissionGitRequest: {
+            /** @description Public https repository URL */
+            repo_url: string;
+            ref?: string;
+            subdir?: string;
+        };
+        ServiceSubmissionReview: {
+            /** @enum {string} */
+            decision: "changes_requested" | "accepted" | "rejected";
+            /** @description Posted to the review discussion; required when requesting changes */
+            comment?: string;
+        };
+        ServiceSubmissionComment: {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            submission_id: number;
+            /** Format: int64 */
+            author_id?: number | null;
+            author_user_name?: string | null;
+            /** Format: int64 */
+            parent_id?: number | null;
+            body: string;
+            /** Format: date-time */
+            created_at: string;
+        };
+        ServiceSubmissionCommentList: {
+            items: components["schemas"]["ServiceSubmissionComment"][];
+        };
+        ServiceSubmissionCommentCreate: {
+            body: string;
+            /**
+             * Format: int64
+             * @description Comment of the same submission this one replies to
+             */
+            parent_id?: number;
+        };
+        ServiceArchiveMeta: {
+            /** Format: int64 */
+            size?: number | null;
+            sha256?: string | null;
+            /** Format: date-time */
+            downloaded_at?: string | null;
+        };
+        ServiceSource: {
+            /** @enum {string} */
+            kind: "manual" | "zip" | "git";
+            repo_url?: string | null;
+            ref?: string | null;
+            subdir?: string | null;
+            /** Format: int64 */
+            credential_id?: number | null;
+            last_commit?: string | null;
+            /** Format: date-time */
+            synced_at?: string | null;
+            /** @enum {string} */
+            sync_status: "unknown" | "ok" | "failed";
+            sync_error?: string | null;
+        };
+        GitSourceInput: {
+            repo_url?: string;
+            ref?: string;
+            subdir?: string;
+            /**
+             * Format: int64
+             * @description Git credential used for private repositories; host pattern matching applies when none is set. On update an omitted value keeps the current credential and 0 clears it
+             */
+            credential_id?: number;
+        };
+        Service: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            name: string;
+            public_description?: string | null;
+            private_description?: string | null;
+            author?: string | null;
+            copyright?: string | null;
+            avatar_url?: string | null;
+            public: boolean;
+            service_archive_url?: string | null;
+            checker_archive_url?: string | null;
+            writeup_url?: string | null;
+            exploits_url?: string | null;
+            /** @enum {string} */
+            check_status: "unknown" | "ok" | "failed";
+            /** Format: date-time */
+            checked_at?: string | null;
+            service_archive?: components["schemas"]["ServiceArchiveMeta"];
+            checker_archive?: components["schemas"]["ServiceArchiveMeta"];
+            ctf01d_training: Record<string, never> | null;
+            ports: number[];
+            tech_stack: string[];
+            /** @description SPDX identifier detected from the LICENSE file at import */
+            license?: string | null;
+            source?: components["schemas"]["ServiceSource"];
+        };
+        ServiceCreate: {
+            name: string;
+            public_description?: string;
+            private_description?: string;
+            author?: string;
+            copyright?: string;
+            avatar_url?: string;
+            public?: boolean;
+            service_archive_url?: string;
+            checker_archive_url?: string;
+            writeup_url?: string;
+            exploits_url?: string;
+            ctf01d_training?: Record<string, never>;
+            ports?: number[];
+            tech_stack?: string[];
+            git_source?: components["schemas"]["GitSourceInput"];
+        };
+        ServiceUpdate: {
+            name?: string;
+            public_description?: string;
+            private_description?: string;
+            author?: string;
+            copyright?: string;
+            avatar_url?: string;
+            public?: boolean;
+            service_archive_url?: string;
+            checker_archive_url?: string;
+            writeup_url?: string;
+            exploits_url?: string;
+            ctf01d_training?: Record<string, never>;
+            ports?: number[];
+            tech_stack?: string[];
+            git_source?: components["schemas"]["GitSourceInput"];
+        };
+        ServiceList: {
+            items: components["schemas"]["Service"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        ServiceSnippetPart: {
+            text: string;
+            /** @description The text is a term of the query */
+            match: boolean;
+        };
+        ServiceSearchHit: {
+            service: components["schemas"]["Service"];
+            /** Format: float */
+            rank: number;
+            games_count: number;
+            /** @description Highlighted fragments of the descriptions and README; empty without a text query */
+            snippet: components["schemas"]["ServiceSnippetPart"][];
+        };
+        ServiceFacetValue: {
+            value: string;
+            count: number;
+        };
+        /** @description Each facet counts the services matching the query and the other facets' filters */
+        ServiceSearchFacets: {
+            tech_stack: components["schemas"]["ServiceFacetValue"][];
+            /** @description Detected license; "none" when it was missing or not recognized */
+            license: components["schemas"]["ServiceFacetValue"][];
+            check_status: components["schemas"]["ServiceFacetValue"][];
+            /** @description How many games use the service, bucketed as 0, 1, 2-4 and 5+ */
+            games_usage: components["schemas"]["ServiceFacetValue"][];
+        };
+        ServiceSearchResult: {
+            items: components["schemas"]["ServiceSearchHit"][];
+            facets: components["schemas"]["ServiceSearchFacets"];
+            pagination: components["schemas"]["Pagination"];
+        };
+        GitImportRequest: {
+            repo_url: string;
+            ref?: string;
+            subdir?: string;
+            /**
+             * Format: int64
+             * @description Git credential used for private repositories; host pattern matching applies when omitted
+             */
+            credential_id?: number;
+        };
+        ImportResult: {
+            service: components["schemas"]["Service"];
+            warnings: string[];
+        };
+        GitImportDiscovery: {
+            commit: string;
+            services: components["schemas"]["ServiceImportPreview"][];
+        };
+        GitBatchImportRequest: {
+            repo_url: string;
+            ref?: string;
+            /** Format: int64 */
+            credential_id?: number;
+            /** @description Service directories returned by discovery */
+            subdirs: string[];
+        };
+        GitBatchImportResult: {
+            items: components["schemas"]["ImportResult"][];
+        };
+        ServiceImportValidationItem: {
+            id: string;
+            title: string;
+            /** @enum {string} */
+            status: "ok" | "warning" | "error";
+            message: string;
+        };
+        ServiceManifestValidateRequest: {
+            /** @description Contents of .ctf01d-service.yml */
+            content: string;
+        };
+        ServiceManifestDiagnostic: {
+            /** @description JSON pointer to the offending value; empty for document-level problems */
+            path: string;
+            /** @description 1-based YAML line, 0 when unknown */
+            line: number;
+            /** @description 1-based YAML column, 0 when unknown */
+            column: number;
+            message: string;
+        };
+        ServiceManifestValidation: {
+            schema_version: number;
+            valid: boolean;
+            diagnostics: components["schemas"]["ServiceManifestDiagnostic"][];
+        };
+        ServiceImportPreview: {
+            /** @enum {string} */
+            source: "git" | "zip";
+            valid: boolean;
+            service_name: string;
+            repository_owner?: string;
+            repository_name?: string;
+            expected_repository_name: string;
+            root_directory?: string;
+            service_directory?: string;
+            checker_directory?: string;
+            has_dev_directory: boolean;
+            /** Format: int64 */
+            existing_service_id?: number | null;
+            /** @description Repository directory the service was imported from */
+            subdir?: string;
+            requirements: components["schemas"]["ServiceImportValidationItem"][];
+            warnings: string[];
+        };
+        ServiceArchiveVersion: {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            service_id: number;
+            /** @enum {string} */
+            kind: "service" | "checker";
+            sha256: string;
+            /** Format: int64 */
+            size: number;
+            /** @enum {string} */
+            source_kind: "url" | "upload" | "git";
+            /** @description Download URL or git commit the archive was built from */
+            source_ref?: string | null;
+            /** Format: date-time */
+            created_at: string;
+            /** @description Whether this version is the active archive of the service */
+            current: boolean;
+            /** @description Whether a game is pinned to this version; pinned versions are never pruned */
+            pinned: boolean;
+        };
+        ServiceArchiveVersionList: {
+            items: components["schemas"]["ServiceArchiveVersion"][];
+        };
+        ServiceReadme: {
+            /** Format: int64 */
+            service_id: number;
+            /**
+             * Format: int64
+             * @description Archive version the README was read from; null for a README recorded only at import
+             */
+            version_id?: number | null;
+            markdown: string;
+            /** @description Sanitized HTML rendering; relative images point to the readme asset endpoint */
+            html: string;
+        };
+        ServiceAuthor: {
+            /** Format: int64 */
+            user_id: number;
+            user_name: string;
+            display_name: string;
+            avatar_url?: string | null;
+            /** Format: date-time */
+            created_at: string;
+        };
+        ServiceAuthorList: {
+            items: components["schemas"]["ServiceAuthor"][];
+        };
+        ServiceAuthorAddRequest: {
+            /** Format: int64 */
+            user_id: number;
+        };
+        ServiceVuln: {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            service_id: number;
+            title: string;
+            /** @example CWE-89 */
+            cwe?: string | null;
+            /** @enum {string|null} */
+            difficulty?: "easy" | "medium" | "hard" | null;
+            /** @description Path of the demonstrating exploit inside exploits/ */
+            exploit?: string | null;
+            fix?: string | null;
+        };
+        ServiceVulnInput: {
+            title: string;
+            /** @description CWE id; a bare number is accepted */
+            cwe?: string | null;
+            /** @enum {string|null} */
+            difficulty?: "easy" | "medium" | "hard" | null;
+            exploit?: string | null;
+            fix?: string | null;
+        };
+        ServiceVulnList: {
+            items: components["schemas"]["ServiceVuln"][];
+        };
+        ServiceVulnsReplaceRequest: {
+            items: components["schemas"]["ServiceVulnInput"][];
+        };
+        ServiceArchiveVersionDiff: {
+            from: components["schemas"]["ServiceArchiveVersion"];
+            to: components["schemas"]["ServiceArchiveVersion"];
+            added: string[];
+            removed: string[];
+            modified: string[];
+            unchanged: number;
+        };
+        ServiceGamePin: {
+            /** Format: int64 */
+            game_id: number;
+            game_name?: string | null;
+            game_finalized: boolean;
+            status: string;
+            /** Format: int64 */
+            pinned_service_version_id?: number | null;
+            /** Format: int64 */
+            pinned_checker_version_id?: number | null;
+            /** @description The game is not pinned and uses the current archive of the service */
+            follows_current: boolean;
+        };
+        ServiceDownloadLinkCreate: {
+            /** @enum {string} */
+            kind: "service" | "checker";
+            /** @description Link lifetime, 24 hours by default and at most 7 days */
+            expires_in_seconds?: number;
+            /** @description How many times the link can be used; unlimited when omitted */
+            max_downloads?: number | null;
+        };
+        ServiceDownloadLink: {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            service_id: number;
+            kind: string;
+            /** @description Path of the signed download, relative to the API host */
+            url: string;
+            /** Format: date-time */
+            expires_at: string;
+            max_downloads?: number | null;
+            download_count: number;
+            /** Format: date-time */
+            created_at: string;
+        };
+        TeamMembership: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            team_id: number;
+            /** Format: int64 */
+            user_id: number;
+            /** @enum {string} */
+            role: "owner" | "captain" | "vice_captain" | "player" | "guest";
+            /** @enum {string} */
+            status: "pending" | "approved" | "rejected";
+        };
+        TeamMembershipCreate: {
+            /** Format: int64 */
+            team_id: number;
+            /** Format: int64 */
+            user_id: number;
+            /**
+             * @default guest
+             * @enum {string}
+             */
+            role: "owner" | "captain" | "vice_captain" | "player" | "guest";
+            /**
+             * @default pending
+             * @enum {string}
+             */
+            status: "pending" | "approved" | "rejected";
+        };
+        TeamMembershipUpdate: {
+            /** @enum {string} */
+            role?: "owner" | "captain" | "vice_captain" | "player" | "guest";
+            /** @enum {string} */
+            status?: "pending" | "approved" | "rejected";
+        };
+        TeamMembershipList: {
+            items: components["schemas"]["TeamMembership"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        SetRoleRequest: {
+            /** @enum {string} */
+            role: "owner" | "captain" | "vice_captain" | "player" | "guest";
+        };
+        TeamMembershipEvent: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            team_id: number;
+            /** Format: int64 */
+            user_id: number;
+            /** Format: int64 */
+            actor_id?: number | null;
+            action: string;
+            from_role?: string | null;
+            to_role?: string | null;
+            from_status?: string | null;
+            to_status?: string | null;
+        };
+        TeamMembershipEventList: {
+            items: components["schemas"]["TeamMembershipEvent"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        Team: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            name: string;
+            description?: string | null;
+            website?: string | null;
+            avatar_url?: string | null;
+            /** Format: int64 */
+            captain_id?: number | null;
+            /** Format: int64 */
+            university_id?: number | null;
+        };
+        TeamCreate: {
+            name: string;
+            description?: string;
+            website?: string;
+            avatar_url?: string;
+            /** Format: int64 */
+            university_id?: number | null;
+        };
+        TeamUpdate: {
+            name?: string;
+            description?: string;
+            website?: string;
+            avatar_url?: string;
+            /** Format: int64 */
+            university_id?: number | null;
+        };
+        TeamList: {
+            items: components["schemas"]["Team"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        InviteRequest: {
+            /** Format: int64 */
+            user_id: number;
+        };
+        University: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            name?: string | null;
+            site_url?: string | null;
+            avatar_url?: string | null;
+        };
+        UniversityCreate: {
+            name?: string;
+            site_url?: string;
+            avatar_url?: string;
+        };
+        UniversityUpdate: {
+            name?: string;
+            site_url?: string;
+            avatar_url?: string;
+        };
+        UniversityList: {
+            items: components["schemas"]["University"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        User: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            user_name: string;
+            display_name: string;
+            /** @enum {string} */
+            language: "en" | "ru";
+            /** @enum {string} */
+            theme: "classic" | "indigo" | "dark" | "midnight";
+            /** @enum {string} */
+            role: "guest" | "player" | "admin";
+            rating: number;
+            avatar_url?: string | null;
+            bio?: string | null;
+            telegram?: string | null;
+            github?: string | null;
+            email?: string | null;
+            /**
+             * Format: date-time
+             * @description When a link sent to the current email was followed; only shown to the user and admins
+             */
+            email_verified_at?: string | null;
+            last_login_ip?: string | null;
+            /** Format: date-time */
+            last_login_at?: string | null;
+            is_blocked: boolean;
+        };
+        UserCreate: {
+            user_name: string;
+            display_name: string;
+            password: string;
+            /**
+             * @default guest
+             * @enum {string}
+             */
+            role: "guest" | "player" | "admin";
+            avatar_url?: string | null;
+        };
+        UserUpdate: {
+            display_name?: string;
+            avatar_url?: string | null;
+            password?: string;
+        };
+        UserRoleUpdate: {
+            /** @enum {string} */
+            role: "guest" | "player" | "admin";
+        };
+        UserProfileUpdate: {
+            display_name?: string;
+            password?: string;
+            bio?: string | null;
+            telegram?: string | null;
+            github?: string | null;
+            email?: string | null;
+            /** @enum {string} */
+            language?: "en" | "ru";
+            /** @enum {string} */
+            theme?: "classic" | "indigo" | "dark" | "midnight";
+        };
+        PasswordUpdate: {
+            password: string;
+        };
+        UserBlockUpdate: {
+            blocked: boolean;
+        };
+        UserDeleteRequest: {
+            /** @description The acting admin's password, required to confirm deletion. */
+            password: string;
+        };
+        UserSession: {
+            /** Format: int64 */
+            id: number;
+            ip_address?: string | null;
+            user_agent?: string | null;
+            /** Format: date-time */
+            created_at: string;
+            /** Format: date-time */
+            last_seen_at: string;
+            /** Format: date-time */
+            expires_at: string;
+            current: boolean;
+        };
+        UserSessionList: {
+            items: components["schemas"]["UserSession"][];
+        };
+        UserList: {
+            items: components["schemas"]["User"][];
+            pagination: components["schemas"]["Pagination"];
+        };
+        Writeup: components["schemas"]["Timestamped"] & {
+            /** Format: int64 */
+            id: number;
+            /** Format: int64 */
+            game_id: number;
+            /** Format: int64 */
+            team_id: number;
+            title: string;
+            /** Format: uri */
+            url: string;
+        };
+        WriteupCreate: {
+            /** Format: int64 */
+            game_id: number;
+            /** Format: int64 */
+            team_id: number;
+            title: string;
+            /** Format: uri */
+            url: string;
+        };
+        WriteupList: {
+            items: components["schemas"]["Writeup"][];
+        };
+    };
+    responses: {
+        /** @description Resource not found */
+        NotFound: {
+            headers: {
+                [name: string]: unknown;
+            };
+            content: {
+                "application/json": components["schemas"]["Error"];
+            };
+        };
+        /** @description Unauthorized */
+        Unauthorized: {
+            headers: {
+                [name: string]: unknown;
+            };
+            content: {
+                "application/json": components["schemas"]["Error"];
+            };
+        };
+        /** @description Forbidden */
+        Forbidden: {
+            headers: {
+                [name: string]: unknown;
+            };
+            content: {
+                "application/json": components["schemas"]["Error"];
+            };
+        };
+        /** @description Validation error */
+        ValidationError: {
+            headers: {
+                [name: string]: unknown;
+            };
+            content: {
+                "application/json": components["schemas"]["Error"];
+            };
+        };
+        /** @description Conflict */
+        Conflict: {
+            headers: {
+                [name: string]: unknown;
+            };
+            content: {
+                "application/json": components["schemas"]["Error"];
+            };
+        };
+        /** @description Rate limit exceeded */
+        TooManyRequests: {
+            headers: {
+                [name: string]: unknown;
+            };
+            content: {
+                "application/json": components["schemas"]["Error"];
+            };
+        };
+    };
+    parameters: {
+        PageParam: number;
+        PerPageParam: number;
+    };
+    requestBodies: never;
+    headers: never;
+    pathItems: never;
+}
+export type $defs = Record<string, never>;
+export interface operations {
+    login: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["LoginRequest"];
+            };
+        };
+        responses: {
+            /** @description Login successful */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["LoginResponse"];
+                };
+            };
+            /** @description Password accepted, a second factor is required */
+            202: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorChallenge"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    logout: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Logged out */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    refreshSession: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["RefreshRequest"];
+            };
+        };
+        responses: {
+            /** @description New access and refresh tokens */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["RefreshResponse"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    completeTwoFactorLogin: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorLoginRequest"];
+            };
+        };
+        responses: {
+            /** @description Login successful */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["LoginResponse"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            422: components["responses"]["ValidationError"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    startTwoFactorLoginEnrollment: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorChallengeToken"];
+            };
+        };
+        responses: {
+            /** @description New authenticator secret */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorEnrollment"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            409: components["responses"]["Conflict"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    requestPasswordReset: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["PasswordResetRequest"];
+            };
+        };
+        responses: {
+            /** @description Accepted; a link is mailed if an account matches */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            422: components["responses"]["ValidationError"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    confirmPasswordReset: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["PasswordResetConfirm"];
+            };
+        };
+        responses: {
+            /** @description Password changed; every session of the user is revoked */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            422: components["responses"]["ValidationError"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    confirmEmailVerification: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["EmailVerificationConfirm"];
+            };
+        };
+        responses: {
+            /** @description Email address verified */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            422: components["responses"]["ValidationError"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    getTwoFactorPolicy: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Roles that must use two-factor authentication */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorPolicy"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+        };
+    };
+    updateTwoFactorPolicy: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorPolicy"];
+            };
+        };
+        responses: {
+            /** @description Policy updated */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorPolicy"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
+        };
+    };
+    listOIDCProviders: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Configured providers */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["OIDCProviderList"];
+                };
+            };
+        };
+    };
+    startOIDCLogin: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                provider: string;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Authorization URL (authorization code flow with PKCE) */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["OIDCAuthorization"];
+                };
+            };
+            404: components["responses"]["NotFound"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    completeOIDCLogin: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                provider: string;
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["OIDCCallbackRequest"];
+            };
+        };
+        responses: {
+            /** @description Signed in, or identity linked */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["OIDCCallbackResponse"];
+                };
+            };
+            /** @description Identity accepted, a second factor is required */
+            202: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorChallenge"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    getProfile: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Current user profile */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["User"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    updateProfile: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UserProfileUpdate"];
+            };
+        };
+        responses: {
+            /** @description Profile updated */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["User"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            422: components["responses"]["ValidationError"];
+        };
+    };
+    changeProfilePassword: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["PasswordUpdate"];
+            };
+        };
+        responses: {
+            /** @description Password changed */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            422: components["responses"]["ValidationError"];
+        };
+    };
+    sendProfileEmailVerification: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Link sent to the profile's email address */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            409: components["responses"]["Conflict"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
+    uploadProfileAvatar: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "multipart/form-data": {
+                    /** Format: binary */
+                    avatar: string;
+                };
+            };
+        };
+        responses: {
+            /** @description Avatar uploaded; the image is scaled and stored server-side */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["User"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            422: components["responses"]["ValidationError"];
+        };
+    };
+    listProfileSessions: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Active sessions */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["UserSessionList"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    listProfileIdentities: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Linked identities */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["UserIdentityList"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    linkProfileIdentity: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UserIdentityLink"];
+            };
+        };
+        responses: {
+            /** @description Authorization URL; the callback links the identity instead of signing in */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["OIDCAuthorization"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    unlinkProfileIdentity: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Identity unlinked */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
+        };
+    };
+    listProfileAPITokens: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description API tokens */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["APITokenList"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    createProfileAPIToken: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["APITokenCreate"];
+            };
+        };
+        responses: {
+            /** @description API token created; the token is not shown again */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["APITokenCreated"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
         };
-        Pagination: {
-            page: number;
-            per_page: number;
-            total: number;
+    };
+    revokeProfileAPIToken: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        Timestamped: {
-            /** Format: date-time */
-            created_at?: string;
-            /** Format: date-time */
-            updated_at?: string;
+        requestBody?: never;
+        responses: {
+            /** @description API token revoked */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
-        LoginRequest: {
-            user_name: string;
-            password: string;
+    };
+    getProfileTwoFactor: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        LoginResponse: {
-            token: string;
-            user: components["schemas"]["User"];
+        requestBody?: never;
+        responses: {
+            /** @description Two-factor status */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorStatus"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
         };
-        Game: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            name?: string | null;
-            organizer?: string | null;
-            /** Format: date-time */
-            starts_at?: string | null;
-            /** Format: date-time */
-            ends_at?: string | null;
-            avatar_url?: string | null;
-            site_url?: string | null;
-            ctftime_url?: string | null;
-            finalized: boolean;
-            /** Format: date-time */
-            finalized_at?: string | null;
-            /** Format: date-time */
-            registration_opens_at?: string | null;
-            /** Format: date-time */
-            registration_closes_at?: string | null;
-            /** Format: date-time */
-            scoreboard_opens_at?: string | null;
-            /** Format: date-time */
-            scoreboard_closes_at?: string | null;
-            vpn_url?: string | null;
-            vpn_config_url?: string | null;
-            access_instructions?: string | null;
-            access_secret?: string | null;
-            published?: boolean;
-            theme?: string | null;
-            requirements?: string | null;
-            /** @enum {string} */
-            readonly status?: "upcoming" | "ongoing" | "past" | "unknown";
-            /** @enum {string} */
-            readonly registration_status?: "unscheduled" | "upcoming" | "open" | "closed";
-            /** @enum {string} */
-            readonly scoreboard_status?: "always" | "upcoming" | "open" | "closed";
+    };
+    startProfileTwoFactorEnrollment: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        GameCreate: {
-            name?: string;
-            organizer?: string;
-            /** Format: date-time */
-            starts_at?: string;
-            /** Format: date-time */
-            ends_at?: string;
-            avatar_url?: string;
-            site_url?: string;
-            ctftime_url?: string;
-            /** Format: date-time */
-            registration_opens_at?: string;
-            /** Format: date-time */
-            registration_closes_at?: string;
-            /** Format: date-time */
-            scoreboard_opens_at?: string;
-            /** Format: date-time */
-            scoreboard_closes_at?: string;
-            vpn_url?: string;
-            vpn_config_url?: string;
-            access_instructions?: string;
-            access_secret?: string;
-            published?: boolean;
-            theme?: string;
-            requirements?: string;
+        requestBody?: never;
+        responses: {
+            /** @description New authenticator secret */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorEnrollment"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            409: components["responses"]["Conflict"];
         };
-        GameUpdate: {
-            name?: string;
-            organizer?: string;
-            /** Format: date-time */
-            starts_at?: string;
-            /** Format: date-time */
-            ends_at?: string;
-            avatar_url?: string;
-            site_url?: string;
-            ctftime_url?: string;
-            /** Format: date-time */
-            registration_opens_at?: string;
-            /** Format: date-time */
-            registration_closes_at?: string;
-            /** Format: date-time */
-            scoreboard_opens_at?: string;
-            /** Format: date-time */
-            scoreboard_closes_at?: string;
-            vpn_url?: string;
-            vpn_config_url?: string;
-            access_instructions?: string;
-            access_secret?: string;
-            theme?: string;
-            requirements?: string;
+    };
+    confirmProfileTwoFactor: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        GameList: {
-            items: components["schemas"]["Game"][];
-            pagination: components["schemas"]["Pagination"];
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorCode"];
+            };
         };
-        GameServiceLink: {
-            /** Format: int64 */
-            service_id: number;
-            status: string;
+        responses: {
+            /** @description Two-factor authentication enabled */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorRecoveryCodes"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
         };
-        Ctf01dExportOptions: {
-            /** @default 8080 */
-            port: number;
-            /** @default true */
-            include_html: boolean;
-            /** @default true */
-            include_compose: boolean;
-            html_source_path?: string;
-            /** @default 1 */
-            flag_ttl_min: number;
-            /** @default 1 */
-            basic_attack_cost: number;
-            /** @default 1 */
-            defence_cost: number;
-            /** Format: date-time */
-            coffee_break_start?: string | null;
-            /** Format: date-time */
-            coffee_break_end?: string | null;
-            warnings?: string[];
+    };
+    regenerateProfileRecoveryCodes: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        Ctf01dExportRequest: {
-            prefix?: string;
-            /** @default true */
-            include_html: boolean;
-            html_source_path?: string;
-            /** @default true */
-            include_compose: boolean;
-            compose_project?: string;
-            /** @default 8080 */
-            port: number;
-            /** @default ./html */
-            htmlfolder: string;
-            /** @default false */
-            random: boolean;
-            /** @default 1 */
-            flag_ttl_min: number;
-            /** @default 1 */
-            basic_attack_cost: number;
-            /** @default 1 */
-            defence_cost: number;
-            /** Format: date-time */
-            coffee_break_start?: string | null;
-            /** Format: date-time */
-            coffee_break_end?: string | null;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorCode"];
+            };
+        };
+        responses: {
+            /** @description New recovery codes; the old ones stop working */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["TwoFactorRecoveryCodes"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            409: components["responses"]["Conflict"];
+        };
+    };
+    disableProfileTwoFactor: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["TwoFactorDisable"];
+            };
         };
-        Ctf01dExportError: {
-            code: string;
-            message?: string;
-            errors: string[];
+        responses: {
+            /** @description Two-factor authentication disabled */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            409: components["responses"]["Conflict"];
         };
-        GameTeam: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            /** Format: int64 */
-            game_id: number;
-            /** Format: int64 */
-            team_id: number;
-            ip_address?: string | null;
-            ctf01d_id?: string | null;
-            ctf01d_overrides?: Record<string, never> | null;
-            team_type?: string | null;
-            order: number;
+    };
+    listAuditEvents: {
+        parameters: {
+            query?: {
+                page?: components["parameters"]["PageParam"];
+                per_page?: components["parameters"]["PerPageParam"];
+                entity_type?: "game" | "result" | "user" | "service" | "git_credential";
+                entity_id?: string;
+                actor_id?: number;
+                action?: string;
+                /** @description Only events at or after this time */
+                since?: string;
+                /** @description Only events before this time */
+                until?: string;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        GameTeamCreate: {
-            /** Format: int64 */
-            game_id: number;
-            /** Format: int64 */
-            team_id: number;
-            ip_address?: string;
-            ctf01d_id?: string;
-            ctf01d_overrides?: Record<string, never>;
-            team_type?: string;
-            order?: number;
+        requestBody?: never;
+        responses: {
+            /** @description Audit events, newest first */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["AuditEventList"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            422: components["responses"]["ValidationError"];
         };
-        GameTeamUpdate: {
-            ip_address?: string;
-            ctf01d_id?: string;
-            ctf01d_overrides?: Record<string, never>;
-            team_type?: string;
-            order?: number;
+    };
+    listGames: {
+        parameters: {
+            query?: {
+                page?: components["parameters"]["PageParam"];
+                per_page?: components["parameters"]["PerPageParam"];
+                q?: string;
+                published?: boolean;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        GameTeamList: {
-            items: components["schemas"]["GameTeam"][];
+        requestBody?: never;
+        responses: {
+            /** @description List of games */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GameList"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
         };
-        ReorderRequest: {
-            items: {
-                /** Format: int64 */
-                id: number;
-                order: number;
-            }[];
+    };
+    createGame: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        Result: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            /** Format: int64 */
-            game_id: number;
-            /** Format: int64 */
-            team_id: number;
-            score?: number | null;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["GameCreate"];
+            };
         };
-        ResultCreate: {
-            /** Format: int64 */
-            game_id: number;
-            /** Format: int64 */
-            team_id: number;
-            score?: number;
+        responses: {
+            /** @description Game created */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Game"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            422: components["responses"]["ValidationError"];
         };
-        ResultUpdate: {
-            score?: number;
+    };
+    getGame: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        ResultList: {
-            items: components["schemas"]["Result"][];
+        requestBody?: never;
+        responses: {
+            /** @description Game details */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Game"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
-        ScoreboardEntry: {
-            /** Format: int64 */
-            team_id: number;
-            team_name: string;
-            score: number;
-            position: number;
+    };
+    deleteGame: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        Scoreboard: {
-            /** Format: int64 */
-            game_id: number;
-            /** @enum {string} */
-            status: "always" | "upcoming" | "open" | "closed";
-            entries: components["schemas"]["ScoreboardEntry"][];
+        requestBody?: never;
+        responses: {
+            /** @description Game deleted */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
-        GlobalScoreboard: {
-            entries: {
-                /** Format: int64 */
-                team_id: number;
-                team_name: string;
-                total_score: number;
-            }[];
+    };
+    updateGame: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        ServiceArchiveMeta: {
-            /** Format: int64 */
-            size?: number | null;
-            sha256?: string | null;
-            /** Format: date-time */
-            downloaded_at?: string | null;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["GameUpdate"];
+            };
         };
-        ServiceSource: {
-            /** @enum {string} */
-            kind: "manual" | "zip" | "git";
-            repo_url?: string | null;
-            ref?: string | null;
-            subdir?: string | null;
-            last_commit?: string | null;
-            /** Format: date-time */
-            synced_at?: string | null;
-            /** @enum {string} */
-            sync_status: "unknown" | "ok" | "failed";
-            sync_error?: string | null;
+        responses: {
+            /** @description Game updated */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Game"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
-        GitSourceInput: {
-            repo_url?: string;
-            ref?: string;
-            subdir?: string;
+    };
+    listGameServices: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        Service: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            name: string;
-            public_description?: string | null;
-            private_description?: string | null;
-            author?: string | null;
-            copyright?: string | null;
-            avatar_url?: string | null;
-            public: boolean;
-            service_archive_url?: string | null;
-            checker_archive_url?: string | null;
-            writeup_url?: string | null;
-            exploits_url?: string | null;
-            /** @enum {string} */
-            check_status: "unknown" | "ok" | "failed";
-            /** Format: date-time */
-            checked_at?: string | null;
-            service_archive?: components["schemas"]["ServiceArchiveMeta"];
-            checker_archive?: components["schemas"]["ServiceArchiveMeta"];
-            ctf01d_training: Record<string, never> | null;
-            ports: number[];
-            tech_stack: string[];
-            source?: components["schemas"]["ServiceSource"];
+        requestBody?: never;
+        responses: {
+            /** @description List of linked services with planning status */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GameServiceLink"][];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
-        ServiceCreate: {
-            name: string;
-            public_description?: string;
-            private_description?: string;
-            author?: string;
-            copyright?: string;
-            avatar_url?: string;
-            public?: boolean;
-            service_archive_url?: string;
-            checker_archive_url?: string;
-            writeup_url?: string;
-            exploits_url?: string;
-            ctf01d_training?: Record<string, never>;
-            ports?: number[];
-            tech_stack?: string[];
-            git_source?: components["schemas"]["GitSourceInput"];
+    };
+    addGameService: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        ServiceUpdate: {
-            name?: string;
-            public_description?: string;
-            private_description?: string;
-            author?: string;
-            copyright?: string;
-            avatar_url?: string;
-            public?: boolean;
-            service_archive_url?: string;
-            checker_archive_url?: string;
-            writeup_url?: string;
-            exploits_url?: string;
-            ctf01d_training?: Record<string, never>;
-            ports?: number[];
-            tech_stack?: string[];
-            git_source?: components["schemas"]["GitSourceInput"];
+        requestBody: {
+            content: {
+                "application/json": {
+                    /** Format: int64 */
+                    service_id: number;
+                    status?: string;
+                };
+            };
         };
-        ServiceList: {
-            items: components["schemas"]["Service"][];
-            pagination: components["schemas"]["Pagination"];
+        responses: {
+            /** @description Service linked */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
         };
-        GitImportRequest: {
-            repo_url: string;
-            ref?: string;
-            subdir?: string;
+    };
+    removeGameService: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+                service_id: number;
+            };
+            cookie?: never;
         };
-        ImportResult: {
-            service: components["schemas"]["Service"];
-            warnings: string[];
+        requestBody?: never;
+        responses: {
+            /** @description Service unlinked */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
-        ServiceImportValidationItem: {
-            id: string;
-            title: string;
-            /** @enum {string} */
-            status: "ok" | "warning" | "error";
-            message: string;
+    };
+    setGameServiceStatus: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+                service_id: number;
+            };
+            cookie?: never;
         };
-        ServiceImportPreview: {
-            /** @enum {string} */
-            source: "git" | "zip";
-            valid: boolean;
-            service_name: string;
-            repository_owner?: string;
-            repository_name?: string;
-            expected_repository_name: string;
-            root_directory?: string;
-            service_directory?: string;
-            checker_directory?: string;
-            has_dev_directory: boolean;
-            /** Format: int64 */
-            existing_service_id?: number | null;
-            requirements: components["schemas"]["ServiceImportValidationItem"][];
-            warnings: string[];
+        requestBody: {
+            content: {
+                "application/json": {
+                    status: string;
+                };
+            };
         };
-        TeamMembership: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            /** Format: int64 */
-            team_id: number;
-            /** Format: int64 */
-            user_id: number;
-            /** @enum {string} */
-            role: "owner" | "captain" | "vice_captain" | "player" | "guest";
-            /** @enum {string} */
-            status: "pending" | "approved" | "rejected";
+        responses: {
+            /** @description Status updated */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
-        TeamMembershipCreate: {
-            /** Format: int64 */
-            team_id: number;
-            /** Format: int64 */
-            user_id: number;
-            /**
-             * @default guest
-             * @enum {string}
-             */
-            role: "owner" | "captain" | "vice_captain" | "player" | "guest";
-            /**
-             * @default pending
-             * @enum {string}
-             */
-            status: "pending" | "approved" | "rejected";
+    };
+    pinGameService: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+                service_id: number;
+            };
+            cookie?: never;
         };
-        TeamMembershipUpdate: {
-            /** @enum {string} */
-            role?: "owner" | "captain" | "vice_captain" | "player" | "guest";
-            /** @enum {string} */
-            status?: "pending" | "approved" | "rejected";
+        requestBody: {
+            content: {
+                "application/json": {
+                    /** Format: int64 */
+                    archive_version_id?: number;
+                    git_commit?: string;
+                };
+            };
         };
-        TeamMembershipList: {
-            items: components["schemas"]["TeamMembership"][];
-            pagination: components["schemas"]["Pagination"];
+        responses: {
+            /** @description Service pinned */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GameServiceLink"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
         };
-        SetRoleRequest: {
-            /** @enum {string} */
-            role: "owner" | "captain" | "vice_captain" | "player" | "guest";
+    };
+    unpinGameService: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+                service_id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Service unpinned */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
         };
-        TeamMembershipEvent: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            /** Format: int64 */
-            team_id: number;
-            /** Format: int64 */
-            user_id: number;
-            /** Format: int64 */
-            actor_id?: number | null;
-            action: string;
-            from_role?: string | null;
-            to_role?: string | null;
-            from_status?: string | null;
-            to_status?: string | null;
+    };
+    publishGame: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        TeamMembershipEventList: {
-            items: components["schemas"]["TeamMembershipEvent"][];
-            pagination: components["schemas"]["Pagination"];
+        requestBody?: never;
+        responses: {
+            /** @description Game published */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Game"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
-        Team: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            name: string;
-            description?: string | null;
-            website?: string | null;
-            avatar_url?: string | null;
-            /** Format: int64 */
-            captain_id?: number | null;
-            /** Format: int64 */
-            university_id?: number | null;
+    };
+    listGamePortConflicts: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        TeamCreate: {
-            name: string;
-            description?: string;
-            website?: string;
-            avatar_url?: string;
-            /** Format: int64 */
-            university_id?: number | null;
+        requestBody?: never;
+        responses: {
+            /** @description Port conflicts with suggested free ports */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GamePortConflict"][];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
-        TeamUpdate: {
-            name?: string;
-            description?: string;
-            website?: string;
-            avatar_url?: string;
-            /** Format: int64 */
-            university_id?: number | null;
+    };
+    finalizeGame: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        TeamList: {
-            items: components["schemas"]["Team"][];
-            pagination: components["schemas"]["Pagination"];
+        requestBody?: never;
+        responses: {
+            /** @description Game finalized */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Game"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
-        InviteRequest: {
-            /** Format: int64 */
-            user_id: number;
+    };
+    unfinalizeGame: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        University: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            name?: string | null;
-            site_url?: string | null;
-            avatar_url?: string | null;
+        requestBody?: never;
+        responses: {
+            /** @description Game unfinalized */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Game"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
-        UniversityCreate: {
-            name?: string;
-            site_url?: string;
-            avatar_url?: string;
+    };
+    getCtf01dExportOptions: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        UniversityUpdate: {
-            name?: string;
-            site_url?: string;
-            avatar_url?: string;
+        requestBody?: never;
+        responses: {
+            /** @description Export options with warnings */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Ctf01dExportOptions"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
-        UniversityList: {
-            items: components["schemas"]["University"][];
-            pagination: components["schemas"]["Pagination"];
+    };
+    exportCtf01d: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        User: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            user_name: string;
-            display_name: string;
-            /** @enum {string} */
-            language: "en" | "ru";
-            /** @enum {string} */
-            theme: "classic" | "indigo" | "dark" | "midnight";
-            /** @enum {string} */
-            role: "guest" | "player" | "admin";
-            rating: number;
-            avatar_url?: string | null;
-            bio?: string | null;
-            telegram?: string | null;
-            github?: string | null;
-            email?: string | null;
-            last_login_ip?: string | null;
-            /** Format: date-time */
-            last_login_at?: string | null;
-            is_blocked: boolean;
+        requestBody?: {
+            content: {
+                "application/json": components["schemas"]["Ctf01dExportRequest"];
+            };
         };
-        UserCreate: {
-            user_name: string;
-            display_name: string;
-            password: string;
-            /**
-             * @default guest
-             * @enum {string}
-             */
-            role: "guest" | "player" | "admin";
-            avatar_url?: string | null;
+        responses: {
+            /** @description ctf01d zip archive */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/zip": string;
+                };
+            };
+            /** @description Requested byte range (Range, If-Range) */
+            206: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/zip": string;
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            /** @description Requested range not satisfiable */
+            416: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Export validation errors */
+            422: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Ctf01dExportError"];
+                };
+            };
         };
-        UserUpdate: {
-            display_name?: string;
-            avatar_url?: string | null;
-            password?: string;
+    };
+    listGameRoles: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
         };
-        UserRoleUpdate: {
-            /** @enum {string} */
-            role: "guest" | "player" | "admin";
+        requestBody?: never;
+        responses: {
+            /** @description Organizers, jury and observers of the game */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GameRoleList"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
-        UserProfileUpdate: {
-            display_name?: string;
-            password?: string;
-            bio?: string | null;
-            telegram?: string | null;
-            github?: string | null;
-            email?: string | null;
-            /** @enum {string} */
-            language?: "en" | "ru";
-            /** @enum {string} */
-            theme?: "classic" | "indigo" | "dark" | "midnight";
+    };
+    setGameRole: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+                user_id: number;
+            };
+            cookie?: never;
         };
-        PasswordUpdate: {
-            password: string;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["GameRoleSetRequest"];
+            };
         };
-        UserBlockUpdate: {
-            blocked: boolean;
+        responses: {
+            /** @description Roles after the change */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GameRoleList"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
-        UserDeleteRequest: {
-            /** @description The acting admin's password, required to confirm deletion. */
-            password: string;
+    };
+    removeGameRole: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+                user_id: number;
+            };
+            cookie?: never;
         };
-        UserSession: {
-            /** Format: int64 */
-            id: number;
-            ip_address?: string | null;
-            user_agent?: string | null;
-            /** Format: date-time */
-            created_at: string;
-            /** Format: date-time */
-            last_seen_at: string;
-            /** Format: date-time */
-            expires_at: string;
-            current: boolean;
+        requestBody?: never;
+        responses: {
+            /** @description Role removed */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
-        UserSessionList: {
-            items: components["schemas"]["UserSession"][];
+    };
+    listGitCredentials: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        UserList: {
-            items: components["schemas"]["User"][];
-            pagination: components["schemas"]["Pagination"];
+        requestBody?: never;
+        responses: {
+            /** @description Git credentials without secrets */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GitCredentialList"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
         };
-        Writeup: components["schemas"]["Timestamped"] & {
-            /** Format: int64 */
-            id: number;
-            /** Format: int64 */
-            game_id: number;
-            /** Format: int64 */
-            team_id: number;
-            title: string;
-            /** Format: uri */
-            url: string;
+    };
+    createGitCredential: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        WriteupCreate: {
-            /** Format: int64 */
-            game_id: number;
-            /** Format: int64 */
-            team_id: number;
-            title: string;
-            /** Format: uri */
-            url: string;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["GitCredentialCreate"];
+            };
         };
-        WriteupList: {
-            items: components["schemas"]["Writeup"][];
+        responses: {
+            /** @description Git credential created */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GitCredential"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    responses: {
-        /** @description Resource not found */
-        NotFound: {
-            headers: {
-                [name: string]: unknown;
+    getGitCredential: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
             };
-            content: {
-                "application/json": components["schemas"]["Error"];
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Git credential details without the secret */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GitCredential"];
+                };
             };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
-        /** @description Unauthorized */
-        Unauthorized: {
-            headers: {
-                [name: string]: unknown;
+    };
+    deleteGitCredential: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
             };
-            content: {
-                "application/json": components["schemas"]["Error"];
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Git credential deleted; attached services fall back to host matching */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
             };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
-        /** @description Forbidden */
-        Forbidden: {
-            headers: {
-                [name: string]: unknown;
+    };
+    updateGitCredential: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
             };
+            cookie?: never;
+        };
+        requestBody: {
             content: {
-                "application/json": components["schemas"]["Error"];
+                "application/json": components["schemas"]["GitCredentialUpdate"];
             };
         };
-        /** @description Validation error */
-        ValidationError: {
-            headers: {
-                [name: string]: unknown;
+        responses: {
+            /** @description Git credential updated */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GitCredential"];
+                };
             };
-            content: {
-                "application/json": components["schemas"]["Error"];
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
+        };
+    };
+    listGameTeams: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
             };
+            cookie?: never;
         };
-        /** @description Conflict */
-        Conflict: {
-            headers: {
-                [name: string]: unknown;
-            };
-            content: {
-                "application/json": components["schemas"]["Error"];
+        requestBody?: never;
+        responses: {
+            /** @description List of game teams */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GameTeamList"];
+                };
             };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
     };
-    parameters: {
-        PageParam: number;
-        PerPageParam: number;
-    };
-    requestBodies: never;
-    headers: never;
-    pathItems: never;
-}
-export type $defs = Record<string, never>;
-export interface operations {
-    login: {
+    createGameTeam: {
         parameters: {
             query?: never;
             header?: never;
@@ -1995,32 +5116,37 @@ export interface operations {
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["LoginRequest"];
+                "application/json": components["schemas"]["GameTeamCreate"];
             };
         };
         responses: {
-            /** @description Login successful */
-            200: {
+            /** @description Game team created */
+            201: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["LoginResponse"];
+                    "application/json": components["schemas"]["GameTeam"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
         };
     };
-    logout: {
+    deleteGameTeam: {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Logged out */
+            /** @description Game team deleted */
             204: {
                 headers: {
                     [name: string]: unknown;
@@ -2028,80 +5154,91 @@ export interface operations {
                 content?: never;
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
     };
-    getProfile: {
+    updateGameTeam: {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["GameTeamUpdate"];
+            };
+        };
         responses: {
-            /** @description Current user profile */
+            /** @description Game team updated */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["User"];
+                    "application/json": components["schemas"]["GameTeam"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
     };
-    updateProfile: {
+    reorderGameTeams: {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["UserProfileUpdate"];
+                "application/json": components["schemas"]["ReorderRequest"];
             };
         };
         responses: {
-            /** @description Profile updated */
+            /** @description Teams reordered */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content: {
-                    "application/json": components["schemas"]["User"];
-                };
+                content?: never;
             };
             401: components["responses"]["Unauthorized"];
-            422: components["responses"]["ValidationError"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
     };
-    changeProfilePassword: {
+    listResults: {
         parameters: {
-            query?: never;
+            query?: {
+                game_id?: number;
+                team_id?: number;
+            };
             header?: never;
             path?: never;
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["PasswordUpdate"];
-            };
-        };
+        requestBody?: never;
         responses: {
-            /** @description Password changed */
-            204: {
+            /** @description List of results */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["ResultList"];
+                };
             };
             401: components["responses"]["Unauthorized"];
-            422: components["responses"]["ValidationError"];
         };
     };
-    uploadProfileAvatar: {
+    createResult: {
         parameters: {
             query?: never;
             header?: never;
@@ -2110,100 +5247,103 @@ export interface operations {
         };
         requestBody: {
             content: {
-                "multipart/form-data": {
-                    /** Format: binary */
-                    avatar: string;
-                };
+                "application/json": components["schemas"]["ResultCreate"];
             };
         };
         responses: {
-            /** @description Avatar uploaded; the image is scaled and stored server-side */
-            200: {
+            /** @description Result created */
+            201: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["User"];
+                    "application/json": components["schemas"]["Result"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            409: components["responses"]["Conflict"];
             422: components["responses"]["ValidationError"];
         };
     };
-    listProfileSessions: {
+    getResult: {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Active sessions */
+            /** @description Result details */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["UserSessionList"];
+                    "application/json": components["schemas"]["Result"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
     };
-    listGames: {
+    deleteResult: {
         parameters: {
-            query?: {
-                page?: components["parameters"]["PageParam"];
-                per_page?: components["parameters"]["PerPageParam"];
-                q?: string;
-                published?: boolean;
-            };
+            query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description List of games */
-            200: {
+            /** @description Result deleted */
+            204: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content: {
-                    "application/json": components["schemas"]["GameList"];
-                };
+                content?: never;
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
     };
-    createGame: {
+    updateResult: {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["GameCreate"];
+                "application/json": components["schemas"]["ResultUpdate"];
             };
         };
         responses: {
-            /** @description Game created */
-            201: {
+            /** @description Result updated */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Game"];
+                    "application/json": components["schemas"]["Result"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
             422: components["responses"]["ValidationError"];
         };
     };
-    getGame: {
+    getGameScoreboard: {
         parameters: {
             query?: never;
             header?: never;
@@ -2214,71 +5354,122 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Game details */
+            /** @description Game scoreboard */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Game"];
+                    "application/json": components["schemas"]["Scoreboard"];
                 };
             };
-            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
         };
     };
-    deleteGame: {
+    getGlobalScoreboard: {
         parameters: {
             query?: never;
             header?: never;
-            path: {
-                id: number;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Global scoreboard */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["GlobalScoreboard"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    listServiceSubmissions: {
+        parameters: {
+            query?: {
+                page?: number;
+                per_page?: number;
+                status?: "submitted" | "changes_requested" | "accepted" | "rejected";
             };
+            header?: never;
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Game deleted */
-            204: {
+            /** @description Submissions, newest first */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["ServiceSubmissionList"];
+                };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    updateGame: {
+    submitServiceFromGit: {
         parameters: {
             query?: never;
             header?: never;
-            path: {
-                id: number;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ServiceSubmissionGitRequest"];
+            };
+        };
+        responses: {
+            /** @description Submission queued for review */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["ServiceSubmission"];
+                };
             };
+            401: components["responses"]["Unauthorized"];
+            422: components["responses"]["ValidationError"];
+        };
+    };
+    submitServiceFromZip: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["GameUpdate"];
+                "multipart/form-data": {
+                    /** Format: binary */
+                    archive: string;
+                };
             };
         };
         responses: {
-            /** @description Game updated */
-            200: {
+            /** @description Submission queued for review */
+            201: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Game"];
+                    "application/json": components["schemas"]["ServiceSubmission"];
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
             422: components["responses"]["ValidationError"];
         };
     };
-    listGameServices: {
+    getServiceSubmission: {
         parameters: {
             query?: never;
             header?: never;
@@ -2289,20 +5480,20 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description List of linked services with planning status */
+            /** @description Submission with its import preview */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["GameServiceLink"][];
+                    "application/json": components["schemas"]["ServiceSubmission"];
                 };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
         };
     };
-    addGameService: {
+    resubmitServiceFromGit: {
         parameters: {
             query?: never;
             header?: never;
@@ -2313,80 +5504,90 @@ export interface operations {
         };
         requestBody: {
             content: {
-                "application/json": {
-                    /** Format: int64 */
-                    service_id: number;
-                    status?: string;
-                };
+                "application/json": components["schemas"]["ServiceSubmissionGitRequest"];
             };
         };
         responses: {
-            /** @description Service linked */
+            /** @description Submission back in the review queue */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["ServiceSubmission"];
+                };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
             409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    removeGameService: {
+    resubmitServiceFromZip: {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 id: number;
-                service_id: number;
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "multipart/form-data": {
+                    /** Format: binary */
+                    archive: string;
+                };
+            };
+        };
         responses: {
-            /** @description Service unlinked */
-            204: {
+            /** @description Submission back in the review queue */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["ServiceSubmission"];
+                };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    setGameServiceStatus: {
+    reviewServiceSubmission: {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 id: number;
-                service_id: number;
             };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": {
-                    status: string;
-                };
+                "application/json": components["schemas"]["ServiceSubmissionReview"];
             };
         };
         responses: {
-            /** @description Status updated */
-            204: {
+            /** @description Reviewed submission */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["ServiceSubmission"];
+                };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
+            409: components["responses"]["Conflict"];
             422: components["responses"]["ValidationError"];
         };
     };
-    publishGame: {
+    listServiceSubmissionComments: {
         parameters: {
             query?: never;
             header?: never;
@@ -2397,20 +5598,20 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Game published */
+            /** @description Comments in posting order */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Game"];
+                    "application/json": components["schemas"]["ServiceSubmissionCommentList"];
                 };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
         };
     };
-    finalizeGame: {
+    addServiceSubmissionComment: {
         parameters: {
             query?: never;
             header?: never;
@@ -2419,15 +5620,19 @@ export interface operations {
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ServiceSubmissionCommentCreate"];
+            };
+        };
         responses: {
-            /** @description Game finalized */
-            200: {
+            /** @description Comment posted */
+            201: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Game"];
+                    "application/json": components["schemas"]["ServiceSubmissionComment"];
                 };
             };
             401: components["responses"]["Unauthorized"];
@@ -2435,55 +5640,90 @@ export interface operations {
             422: components["responses"]["ValidationError"];
         };
     };
-    unfinalizeGame: {
+    listServices: {
         parameters: {
-            query?: never;
-            header?: never;
-            path: {
-                id: number;
+            query?: {
+                page?: components["parameters"]["PageParam"];
+                per_page?: components["parameters"]["PerPageParam"];
+                public?: boolean;
+                q?: string;
             };
+            header?: never;
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Game unfinalized */
+            /** @description List of services */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Game"];
+                    "application/json": components["schemas"]["ServiceList"];
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
         };
     };
-    getCtf01dExportOptions: {
+    createService: {
         parameters: {
             query?: never;
             header?: never;
-            path: {
-                id: number;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ServiceCreate"];
+            };
+        };
+        responses: {
+            /** @description Service created */
+            201: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["Service"];
+                };
             };
+            401: components["responses"]["Unauthorized"];
+            409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
+        };
+    };
+    searchServices: {
+        parameters: {
+            query?: {
+                page?: components["parameters"]["PageParam"];
+                per_page?: components["parameters"]["PerPageParam"];
+                /** @description Words matched as prefixes against name, tech stack, author, descriptions and README */
+                q?: string;
+                tech?: string[];
+                license?: string[];
+                check_status?: string[];
+                games_usage?: ("0" | "1" | "2-4" | "5+")[];
+            };
+            header?: never;
+            path?: never;
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Export options with warnings */
+            /** @description Ranked search results with facets */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Ctf01dExportOptions"];
+                    "application/json": components["schemas"]["ServiceSearchResult"];
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
         };
     };
-    exportCtf01d: {
+    getService: {
         parameters: {
             query?: never;
             header?: never;
@@ -2492,35 +5732,22 @@ export interface operations {
             };
             cookie?: never;
         };
-        requestBody?: {
-            content: {
-                "application/json": components["schemas"]["Ctf01dExportRequest"];
-            };
-        };
+        requestBody?: never;
         responses: {
-            /** @description ctf01d zip archive */
+            /** @description Service details */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/zip": string;
+                    "application/json": components["schemas"]["Service"];
                 };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
-            /** @description Export validation errors */
-            422: {
-                headers: {
-                    [name: string]: unknown;
-                };
-                content: {
-                    "application/json": components["schemas"]["Ctf01dExportError"];
-                };
-            };
         };
     };
-    listGameTeams: {
+    deleteService: {
         parameters: {
             query?: never;
             header?: never;
@@ -2531,47 +5758,48 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description List of game teams */
-            200: {
+            /** @description Service deleted */
+            204: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content: {
-                    "application/json": components["schemas"]["GameTeamList"];
-                };
+                content?: never;
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
         };
     };
-    createGameTeam: {
+    updateService: {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["GameTeamCreate"];
+                "application/json": components["schemas"]["ServiceUpdate"];
             };
         };
         responses: {
-            /** @description Game team created */
-            201: {
+            /** @description Service updated */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["GameTeam"];
+                    "application/json": components["schemas"]["Service"];
                 };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
             409: components["responses"]["Conflict"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    deleteGameTeam: {
+    toggleServicePublic: {
         parameters: {
             query?: never;
             header?: never;
@@ -2582,18 +5810,20 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Game team deleted */
-            204: {
+            /** @description Service toggled */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["Service"];
+                };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
         };
     };
-    updateGameTeam: {
+    checkServiceChecker: {
         parameters: {
             query?: never;
             header?: never;
@@ -2602,26 +5832,23 @@ export interface operations {
             };
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["GameTeamUpdate"];
-            };
-        };
+        requestBody?: never;
         responses: {
-            /** @description Game team updated */
+            /** @description Checker inspected */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["GameTeam"];
+                    "application/json": components["schemas"]["Service"];
                 };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    reorderGameTeams: {
+    redownloadServiceArchives: {
         parameters: {
             query?: never;
             header?: never;
@@ -2630,99 +5857,136 @@ export interface operations {
             };
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["ReorderRequest"];
-            };
-        };
+        requestBody?: never;
         responses: {
-            /** @description Teams reordered */
+            /** @description Archives re-downloaded */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["Service"];
+                };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    listResults: {
+    syncServiceFromGit: {
         parameters: {
-            query?: {
-                game_id?: number;
-                team_id?: number;
-            };
+            query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description List of results */
+            /** @description Service synchronized */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["ResultList"];
+                    "application/json": components["schemas"]["Service"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    createResult: {
+    uploadServiceArchives: {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["ResultCreate"];
+                "multipart/form-data": {
+                    /**
+                     * Format: binary
+                     * @description ZIP, tar or tar.gz archive
+                     */
+                    service_archive?: string;
+                    /**
+                     * Format: binary
+                     * @description ZIP, tar or tar.gz archive
+                     */
+                    checker_archive?: string;
+                };
             };
         };
         responses: {
-            /** @description Result created */
-            201: {
+            /** @description Archives uploaded */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Result"];
+                    "application/json": components["schemas"]["Service"];
                 };
             };
             401: components["responses"]["Unauthorized"];
-            409: components["responses"]["Conflict"];
+            404: components["responses"]["NotFound"];
             422: components["responses"]["ValidationError"];
         };
     };
-    getResult: {
+    downloadServiceArchive: {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 id: number;
+                kind: "service" | "checker";
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Result details */
+            /** @description Archive file */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Result"];
+                    "application/zip": string;
+                };
+            };
+            /** @description Requested byte range (Range, If-Range) */
+            206: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/zip": string;
+                };
+            };
+            /** @description Not modified (If-None-Match, If-Modified-Since) */
+            304: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+            /** @description Requested range not satisfiable */
+            416: {
+                headers: {
+                    [name: string]: unknown;
                 };
+                content?: never;
             };
-            401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
         };
     };
-    deleteResult: {
+    createServiceDownloadLink: {
         parameters: {
             query?: never;
             header?: never;
@@ -2731,51 +5995,84 @@ export interface operations {
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ServiceDownloadLinkCreate"];
+            };
+        };
         responses: {
-            /** @description Result deleted */
-            204: {
+            /** @description Link created */
+            201: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["ServiceDownloadLink"];
+                };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    updateResult: {
+    downloadServiceArchiveSigned: {
         parameters: {
-            query?: never;
+            query: {
+                link: number;
+                expires: number;
+                signature: string;
+            };
             header?: never;
             path: {
                 id: number;
+                kind: "service" | "checker";
             };
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["ResultUpdate"];
-            };
-        };
+        requestBody?: never;
         responses: {
-            /** @description Result updated */
+            /** @description Archive file */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Result"];
+                    "application/zip": string;
                 };
             };
-            401: components["responses"]["Unauthorized"];
+            /** @description Requested byte range (Range, If-Range) */
+            206: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/zip": string;
+                };
+            };
+            /** @description Not modified (If-None-Match, If-Modified-Since) */
+            304: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
-            422: components["responses"]["ValidationError"];
+            /** @description Requested range not satisfiable */
+            416: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
         };
     };
-    getGameScoreboard: {
+    getServiceReadme: {
         parameters: {
-            query?: never;
+            query?: {
+                /** @description Service archive version; defaults to the active one */
+                version?: number;
+            };
             header?: never;
             path: {
                 id: number;
@@ -2784,118 +6081,126 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Game scoreboard */
+            /** @description README markdown and its sanitized HTML rendering */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Scoreboard"];
+                    "application/json": components["schemas"]["ServiceReadme"];
                 };
             };
-            403: components["responses"]["Forbidden"];
+            401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
         };
     };
-    getGlobalScoreboard: {
+    getServiceReadmeAsset: {
         parameters: {
-            query?: never;
+            query: {
+                version: number;
+                /** @description Path of the image inside the archive, next to or below the README */
+                path: string;
+            };
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Global scoreboard */
+            /** @description Image file from the service archive */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["GlobalScoreboard"];
+                    "image/*": string;
                 };
             };
             401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    listServices: {
+    listServiceAuthors: {
         parameters: {
-            query?: {
-                page?: components["parameters"]["PageParam"];
-                per_page?: components["parameters"]["PerPageParam"];
-                public?: boolean;
-                q?: string;
-            };
+            query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description List of services */
+            /** @description Users credited as authors */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["ServiceList"];
+                    "application/json": components["schemas"]["ServiceAuthorList"];
                 };
             };
-            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
     };
-    createService: {
+    addServiceAuthor: {
         parameters: {
             query?: never;
             header?: never;
-            path?: never;
+            path: {
+                id: number;
+            };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["ServiceCreate"];
+                "application/json": components["schemas"]["ServiceAuthorAddRequest"];
             };
         };
         responses: {
-            /** @description Service created */
-            201: {
+            /** @description Authors after the change */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Service"];
+                    "application/json": components["schemas"]["ServiceAuthorList"];
                 };
             };
             401: components["responses"]["Unauthorized"];
-            409: components["responses"]["Conflict"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
             422: components["responses"]["ValidationError"];
         };
     };
-    getService: {
+    removeServiceAuthor: {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 id: number;
+                user_id: number;
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Service details */
-            200: {
+            /** @description Author removed */
+            204: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content: {
-                    "application/json": components["schemas"]["Service"];
-                };
+                content?: never;
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
         };
     };
-    deleteService: {
+    listServiceVulns: {
         parameters: {
             query?: never;
             header?: never;
@@ -2906,18 +6211,21 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Service deleted */
-            204: {
+            /** @description Vulnerabilities in catalog order */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
-                content?: never;
+                content: {
+                    "application/json": components["schemas"]["ServiceVulnList"];
+                };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
         };
     };
-    updateService: {
+    replaceServiceVulns: {
         parameters: {
             query?: never;
             header?: never;
@@ -2928,28 +6236,30 @@ export interface operations {
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["ServiceUpdate"];
+                "application/json": components["schemas"]["ServiceVulnsReplaceRequest"];
             };
         };
         responses: {
-            /** @description Service updated */
+            /** @description The stored catalog */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Service"];
+                    "application/json": components["schemas"]["ServiceVulnList"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
-            409: components["responses"]["Conflict"];
             422: components["responses"]["ValidationError"];
         };
     };
-    toggleServicePublic: {
+    listServiceArchiveVersions: {
         parameters: {
-            query?: never;
+            query?: {
+                kind?: "service" | "checker";
+            };
             header?: never;
             path: {
                 id: number;
@@ -2958,22 +6268,26 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Service toggled */
+            /** @description Archive versions, newest first */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Service"];
+                    "application/json": components["schemas"]["ServiceArchiveVersionList"];
                 };
             };
             401: components["responses"]["Unauthorized"];
             404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    checkServiceChecker: {
+    diffServiceArchiveVersions: {
         parameters: {
-            query?: never;
+            query: {
+                from: number;
+                to: number;
+            };
             header?: never;
             path: {
                 id: number;
@@ -2982,13 +6296,13 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Checker inspected */
+            /** @description File list differences */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Service"];
+                    "application/json": components["schemas"]["ServiceArchiveVersionDiff"];
                 };
             };
             401: components["responses"]["Unauthorized"];
@@ -2996,18 +6310,19 @@ export interface operations {
             422: components["responses"]["ValidationError"];
         };
     };
-    redownloadServiceArchives: {
+    rollbackServiceArchiveVersion: {
         parameters: {
             query?: never;
             header?: never;
             path: {
                 id: number;
+                version_id: number;
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Archives re-downloaded */
+            /** @description Archive rolled back */
             200: {
                 headers: {
                     [name: string]: unknown;
@@ -3017,13 +6332,17 @@ export interface operations {
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
             422: components["responses"]["ValidationError"];
         };
     };
-    syncServiceFromGit: {
+    listServiceGamePins: {
         parameters: {
-            query?: never;
+            query?: {
+                /** @description Only games pinned to this archive version */
+                version_id?: number;
+            };
             header?: never;
             path: {
                 id: number;
@@ -3032,80 +6351,71 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Service synchronized */
+            /** @description Games using the service */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Service"];
+                    "application/json": components["schemas"]["ServiceGamePin"][];
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
-            422: components["responses"]["ValidationError"];
         };
     };
-    uploadServiceArchives: {
+    importServiceFromGit: {
         parameters: {
             query?: never;
             header?: never;
-            path: {
-                id: number;
-            };
+            path?: never;
             cookie?: never;
         };
         requestBody: {
             content: {
-                "multipart/form-data": {
-                    /** Format: binary */
-                    service_archive?: string;
-                    /** Format: binary */
-                    checker_archive?: string;
-                };
+                "application/json": components["schemas"]["GitImportRequest"];
             };
         };
         responses: {
-            /** @description Archives uploaded */
-            200: {
+            /** @description Service imported */
+            201: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["Service"];
+                    "application/json": components["schemas"]["ImportResult"];
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
             422: components["responses"]["ValidationError"];
         };
     };
-    downloadServiceArchive: {
+    previewServiceGitImport: {
         parameters: {
             query?: never;
             header?: never;
-            path: {
-                id: number;
-                kind: "service" | "checker";
-            };
+            path?: never;
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["GitImportRequest"];
+            };
+        };
         responses: {
-            /** @description Archive file */
+            /** @description Import preview */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/zip": string;
+                    "application/json": components["schemas"]["ServiceImportPreview"];
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
+            422: components["responses"]["ValidationError"];
         };
     };
-    importServiceFromGit: {
+    discoverServicesInGit: {
         parameters: {
             query?: never;
             header?: never;
@@ -3118,20 +6428,21 @@ export interface operations {
             };
         };
         responses: {
-            /** @description Service imported */
-            201: {
+            /** @description Discovered services */
+            200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["ImportResult"];
+                    "application/json": components["schemas"]["GitImportDiscovery"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             422: components["responses"]["ValidationError"];
         };
     };
-    previewServiceGitImport: {
+    importServicesFromGit: {
         parameters: {
             query?: never;
             header?: never;
@@ -3140,20 +6451,21 @@ export interface operations {
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["GitImportRequest"];
+                "application/json": components["schemas"]["GitBatchImportRequest"];
             };
         };
         responses: {
-            /** @description Import preview */
-            200: {
+            /** @description Services imported */
+            201: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["ServiceImportPreview"];
+                    "application/json": components["schemas"]["GitBatchImportResult"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             422: components["responses"]["ValidationError"];
         };
     };
@@ -3215,6 +6527,33 @@ export interface operations {
             422: components["responses"]["ValidationError"];
         };
     };
+    validateServiceManifest: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/yaml": string;
+                "application/json": components["schemas"]["ServiceManifestValidateRequest"];
+            };
+        };
+        responses: {
+            /** @description Validation result; valid is false when diagnostics were found */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["ServiceManifestValidation"];
+                };
+            };
+            422: components["responses"]["ValidationError"];
+            429: components["responses"]["TooManyRequests"];
+        };
+    };
     listTeamMemberships: {
         parameters: {
             query?: {
@@ -4069,7 +7408,30 @@ export interface operations {
                     "image/png": string;
                 };
             };
+            /** @description Requested byte range (Range, If-Range) */
+            206: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "image/png": string;
+                };
+            };
+            /** @description Not modified (If-None-Match, If-Modified-Since) */
+            304: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
             404: components["responses"]["NotFound"];
+            /** @description Requested range not satisfiable */
+            416: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
         };
     };
     uploadUserAvatar: {
@@ -4182,6 +7544,29 @@ export interface operations {
             422: components["responses"]["ValidationError"];
         };
     };
+    resetUserTwoFactor: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                id: number;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Two-factor authentication removed */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+        };
+    };
     listWriteups: {
         parameters: {
             query?: {
@@ -0,0 +1,80 @@
+import client from "./client";
+import { authFetch } from "./auth";
+import type { components } from "./schema";
+
+export type ServiceSubmission = components["schemas"]["ServiceSubmission"];
+export type ServiceSubmissionComment =
+  components["schemas"]["ServiceSubmissionComment"];
+export type ServiceSubmissionGitRequest =
+  components["schemas"]["ServiceSubmissionGitRequest"];
+export type ServiceSubmissionReview =
+  components["schemas"]["ServiceSubmissionReview"];
+
+export async function listServiceSubmissions(query?: {
+  page?: number;
+  per_page?: number;
+  status?: ServiceSubmission["status"];
+}) {
+  return client.GET("/service-submissions", { params: { query } });
+}
+
+export async function getServiceSubmission(id: number) {
+  return client.GET("/service-submissions/{id}", { params: { path: { id } } });
+}
+
+export async function submitServiceFromGit(body: ServiceSubmissionGitRequest) {
+  return client.POST("/service-submissions/git", { body });
+}
+
+export async function submitServiceFromZip(formData: FormData) {
+  const response = await authFetch("/api/v1/service-submissions/zip", {
+    method: "POST",
+    body: formData,
+  });
+  return response;
+}
+
+export async function resubmitServiceFromGit(
+  id: number,
+  body: ServiceSubmissionGitRequest,
+) {
+  return client.POST("/service-submissions/{id}/git", {
+    params: { path: { id } },
+    body,
+  });
+}
+
+export async function resubmitServiceFromZip(id: number, formData: FormData) {
+  const response = await authFetch(`/api/v1/service-submissions/${id}/zip`, {
+    method: "POST",
+    body: formData,
+  });
+  return response;
+}
+
+export async function reviewServiceSubmission(
+  id: number,
+  body: ServiceSubmissionReview,
+) {
+  return client.POST("/service-submissions/{id}/review", {
+    params: { path: { id } },
+    body,
+  });
+}
+
+export async function listServiceSubmissionComments(id: number) {
+  return client.GET("/service-submissions/{id}/comments", {
+    params: { path: { id } },
+  });
+}
+
+export async function addServiceSubmissionComment(
+  id: number,
+  body: string,
+  parentId?: number,
+) {
+  return client.POST("/service-submissions/{id}/comments", {
+    params: { path: { id } },
+    body: { body, parent_id: parentId },
+  });
+}
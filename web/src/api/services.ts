@@ -1,4 +1,5 @@
 import client from "./client";
+import { authFetch } from "./auth";
 import type { components } from "./schema";
 
 export type Service = components["schemas"]["Service"];
@@ -36,6 +37,21 @@ export async function listServices(query?: {
   return client.GET("/services", { params: { query } });
 }
 
+export type ServiceSearchResult =
+  components["schemas"]["ServiceSearchResult"];
+
+export async function searchServices(query?: {
+  page?: number;
+  per_page?: number;
+  q?: string;
+  tech?: string[];
+  license?: string[];
+  check_status?: string[];
+  games_usage?: ("0" | "1" | "2-4" | "5+")[];
+}) {
+  return client.GET("/services/search", { params: { query } });
+}
+
 /**
  * Fetch every service across all pages. The list endpoint caps per_page at
  * 100, so callers needing the full set must paginate.
@@ -92,6 +108,95 @@ export async function redownloadServiceArchives(id: number) {
   return client.POST("/services/{id}/redownload", { params: { path: { id } } });
 }
 
+export type ServiceDownloadLinkCreate =
+  components["schemas"]["ServiceDownloadLinkCreate"];
+
+export async function createServiceDownloadLink(
+  id: number,
+  body: ServiceDownloadLinkCreate,
+) {
+  return client.POST("/services/{id}/download-links", {
+    params: { path: { id } },
+    body,
+  });
+}
+
+export type ServiceArchiveVersion =
+  components["schemas"]["ServiceArchiveVersion"];
+export type ServiceArchiveVersionDiff =
+  components["schemas"]["ServiceArchiveVersionDiff"];
+
+export async function getServiceReadme(id: number, version?: number) {
+  return client.GET("/services/{id}/readme", {
+    params: { path: { id }, query: { version } },
+  });
+}
+
+export async function listServiceAuthors(id: number) {
+  return client.GET("/services/{id}/authors", { params: { path: { id } } });
+}
+
+export async function addServiceAuthor(id: number, userId: number) {
+  return client.POST("/services/{id}/authors", {
+    params: { path: { id } },
+    body: { user_id: userId },
+  });
+}
+
+export async function removeServiceAuthor(id: number, userId: number) {
+  return client.DELETE("/services/{id}/authors/{user_id}", {
+    params: { path: { id, user_id: userId } },
+  });
+}
+
+export async function listServiceVulns(id: number) {
+  return client.GET("/services/{id}/vulns", { params: { path: { id } } });
+}
+
+export async function replaceServiceVulns(
+  id: number,
+  items: components["schemas"]["ServiceVulnInput"][],
+) {
+  return client.PUT("/services/{id}/vulns", {
+    params: { path: { id } },
+    body: { items },
+  });
+}
+
+export async function listServiceArchiveVersions(
+  id: number,
+  kind?: "service" | "checker",
+) {
+  return client.GET("/services/{id}/archive-versions", {
+    params: { path: { id }, query: { kind } },
+  });
+}
+
+export async function diffServiceArchiveVersions(
+  id: number,
+  from: number,
+  to: number,
+) {
+  return client.GET("/services/{id}/archive-versions/diff", {
+    params: { path: { id }, query: { from, to } },
+  });
+}
+
+export async function rollbackServiceArchiveVersion(
+  id: number,
+  versionId: number,
+) {
+  return client.POST("/services/{id}/archive-versions/{version_id}/rollback", {
+    params: { path: { id, version_id: versionId } },
+  });
+}
+
+export async function listServiceGamePins(id: number, versionId?: number) {
+  return client.GET("/services/{id}/game-pins", {
+    params: { path: { id }, query: { version_id: versionId } },
+  });
+}
+
 export async function syncServiceFromGit(id: number) {
   return client.POST("/services/{id}/sync-from-git", {
     params: { path: { id } },
@@ -120,8 +225,24 @@ export async function previewServiceGitImport(
   return client.POST("/services/import/git/preview", { body });
 }
 
+export async function discoverServicesInGit(
+  body: components["schemas"]["GitImportRequest"],
+) {
+  return client.POST("/services/import/git/discover", { body });
+}
+
+export async function importServicesFromGit(
+  body: components["schemas"]["GitBatchImportRequest"],
+) {
+  return client.POST("/services/import/git/batch", { body });
+}
+
+export async function validateServiceManifest(content: string) {
+  return client.POST("/services/manifest/validate", { body: { content } });
+}
+
 export async function importServiceFromZip(formData: FormData) {
-  const response = await fetch("/api/v1/services/import/zip", {
+  const response = await authFetch("/api/v1/services/import/zip", {
     method: "POST",
     body: formData,
   });
@@ -129,7 +250,7 @@ export async function importServiceFromZip(formData: FormData) {
 }
 
 export async function previewServiceZipImport(formData: FormData) {
-  const response = await fetch("/api/v1/services/import/zip/preview", {
+  const response = await authFetch("/api/v1/services/import/zip/preview", {
     method: "POST",
     body: formData,
   });
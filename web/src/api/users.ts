@@ -1,5 +1,5 @@
 import client from "./client";
-import { clearToken, getToken } from "./auth";
+import { authFetch, clearToken } from "./auth";
 import type { components } from "./schema";
 
 export type User = components["schemas"]["User"];
@@ -9,6 +9,14 @@ export type UserCreate = components["schemas"]["UserCreate"];
 export type UserUpdate = components["schemas"]["UserUpdate"];
 export type UserProfileUpdate = components["schemas"]["UserProfileUpdate"];
 export type UserSession = components["schemas"]["UserSession"];
+export type UserIdentity = components["schemas"]["UserIdentity"];
+export type OIDCProvider = components["schemas"]["OIDCProvider"];
+export type TwoFactorChallenge = components["schemas"]["TwoFactorChallenge"];
+export type TwoFactorEnrollment = components["schemas"]["TwoFactorEnrollment"];
+export type TwoFactorStatus = components["schemas"]["TwoFactorStatus"];
+export type APIToken = components["schemas"]["APIToken"];
+export type APITokenScope = components["schemas"]["APITokenScope"];
+export type APITokenCreate = components["schemas"]["APITokenCreate"];
 export type UserRole = User["role"];
 
 export async function login(body: LoginRequest) {
@@ -18,6 +26,44 @@ export async function login(body: LoginRequest) {
   return { data, error };
 }
 
+export function isTwoFactorChallenge(
+  data: LoginResponse | TwoFactorChallenge,
+): data is TwoFactorChallenge {
+  return "challenge_token" in data;
+}
+
+export async function completeTwoFactorLogin(
+  challengeToken: string,
+  code: string,
+  recoveryCode?: string,
+) {
+  return client.POST("/session/two-factor", {
+    body: recoveryCode
+      ? { challenge_token: challengeToken, recovery_code: recoveryCode }
+      : { challenge_token: challengeToken, code },
+  });
+}
+
+export async function startTwoFactorLoginEnrollment(challengeToken: string) {
+  return client.POST("/session/two-factor/enrollment", {
+    body: { challenge_token: challengeToken },
+  });
+}
+
+export async function requestPasswordReset(login: string) {
+  return client.POST("/auth/password-reset", { body: { login } });
+}
+
+export async function confirmPasswordReset(token: string, password: string) {
+  return client.POST("/auth/password-reset/confirm", {
+    body: { token, password },
+  });
+}
+
+export async function confirmEmailVerification(token: string) {
+  return client.POST("/auth/email-verification/confirm", { body: { token } });
+}
+
 export async function logout() {
   await client.DELETE("/session");
   clearToken();
@@ -35,10 +81,121 @@ export async function changeProfilePassword(password: string) {
   return client.PUT("/profile/password", { body: { password } });
 }
 
+export async function sendProfileEmailVerification() {
+  return client.POST("/profile/email/verification");
+}
+
 export async function listProfileSessions() {
   return client.GET("/profile/sessions");
 }
 
+export async function getProfileTwoFactor() {
+  return client.GET("/profile/two-factor");
+}
+
+export async function startProfileTwoFactorEnrollment() {
+  return client.POST("/profile/two-factor/enrollment");
+}
+
+export async function confirmProfileTwoFactor(code: string) {
+  return client.POST("/profile/two-factor/confirm", { body: { code } });
+}
+
+export async function regenerateProfileRecoveryCodes(code: string) {
+  return client.POST("/profile/two-factor/recovery-codes", { body: { code } });
+}
+
+export async function disableProfileTwoFactor(
+  code: string,
+  recoveryCode?: string,
+) {
+  return client.POST("/profile/two-factor/disable", {
+    body: recoveryCode ? { recovery_code: recoveryCode } : { code },
+  });
+}
+
+export async function getTwoFactorPolicy() {
+  return client.GET("/auth/two-factor/policy");
+}
+
+export async function updateTwoFactorPolicy(requiredRoles: UserRole[]) {
+  return client.PUT("/auth/two-factor/policy", {
+    body: { required_roles: requiredRoles },
+  });
+}
+
+// The state of the pending external sign-in; the callback page must come
+// back with the same one, or someone else's sign-in is being completed.
+const OIDC_STATE_KEY = "oidc_state";
+
+export async function listOIDCProviders() {
+  return client.GET("/auth/oidc/providers");
+}
+
+export async function startOIDCLogin(provider: string) {
+  const { data, error } = await client.POST("/auth/oidc/{provider}/authorize", {
+    params: { path: { provider } },
+  });
+  if (data) {
+    sessionStorage.setItem(OIDC_STATE_KEY, data.state);
+  }
+  return { data, error };
+}
+
+export async function completeOIDCLogin(
+  provider: string,
+  code: string,
+  state: string,
+) {
+  const expected = sessionStorage.getItem(OIDC_STATE_KEY);
+  sessionStorage.removeItem(OIDC_STATE_KEY);
+  if (!expected || expected !== state) {
+    return {
+      data: undefined,
+      error: { code: "unauthorized", message: "sign-in state mismatch" },
+    };
+  }
+  const { data, error } = await client.POST("/auth/oidc/{provider}/callback", {
+    params: { path: { provider } },
+    body: { code, state },
+  });
+  return { data, error };
+}
+
+export async function listProfileIdentities() {
+  return client.GET("/profile/identities");
+}
+
+export async function linkProfileIdentity(provider: string) {
+  const { data, error } = await client.POST("/profile/identities", {
+    body: { provider },
+  });
+  if (data) {
+    sessionStorage.setItem(OIDC_STATE_KEY, data.state);
+  }
+  return { data, error };
+}
+
+export async function unlinkProfileIdentity(id: number) {
+  return client.DELETE("/profile/identities/{id}", {
+    params: { path: { id } },
+  });
+}
+
+export async function listProfileAPITokens() {
+  return client.GET("/profile/api-tokens");
+}
+
+export async function createProfileAPIToken(body: APITokenCreate) {
+  return client.POST("/profile/api-tokens", { body });
+}
+
+export async function revokeProfileAPIToken(id: number) {
+  return client.DELETE("/profile/api-tokens/{id}", {
+    params: { path: { id } },
+  });
+}
+
 export async function listUsers(query?: {
   page?: number;
   per_page?: number;
@@ -108,14 +265,12 @@ export async function revokeUserSession(id: number, sessionId: number) {
 }
 
 // Avatar upload uses multipart/form-data which openapi-fetch does not model, so
-// post the file directly with the auth header.
+// post the file directly.
 export async function uploadUserAvatar(id: number, file: File) {
   const formData = new FormData();
   formData.append("avatar", file);
-  const token = getToken();
-  const response = await fetch(`/api/v1/users/${id}/avatar`, {
+  const response = await authFetch(`/api/v1/users/${id}/avatar`, {
     method: "POST",
-    headers: token ? { Authorization: `Bearer ${token}` } : {},
     body: formData,
   });
   return response;
@@ -124,11 +279,15 @@ export async function uploadUserAvatar(id: number, file: File) {
 export async function uploadProfileAvatar(file: File) {
   const formData = new FormData();
   formData.append("avatar", file);
-  const token = getToken();
-  const response = await fetch("/api/v1/profile/avatar", {
+  const response = await authFetch("/api/v1/profile/avatar", {
     method: "POST",
-    headers: token ? { Authorization: `Bearer ${token}` } : {},
     body: formData,
   });
   return response;
 }
+
+export async function resetUserTwoFactor(id: number) {
+  return client.DELETE("/users/{id}/two-factor", {
+    params: { path: { id } },
+  });
+}
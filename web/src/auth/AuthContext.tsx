@@ -6,14 +6,25 @@ import {
   useEffect,
   type ReactNode,
 } from "react";
-import { getToken, setToken, clearToken } from "../api/auth";
+import { getToken, setSession, clearToken } from "../api/auth";
 import * as usersApi from "../api/users";
-import type { User } from "../api/users";
+import type { LoginResponse, TwoFactorChallenge, User } from "../api/users";
 
 interface AuthContextValue {
   user: User | null;
   loading: boolean;
-  login: (userName: string, password: string) => Promise<void>;
+  // login resolves with a challenge when the account needs a second factor;
+  // completeTwoFactor finishes it and returns recovery codes after a
+  // required enrollment.
+  login: (
+    userName: string,
+    password: string,
+  ) => Promise<TwoFactorChallenge | null>;
+  completeTwoFactor: (
+    challengeToken: string,
+    code: string,
+    recoveryCode?: string,
+  ) => Promise<string[]>;
   logout: () => Promise<void>;
   refreshUser: () => Promise<void>;
   isAdmin: boolean;
@@ -22,6 +33,15 @@ interface AuthContextValue {
 
 const AuthContext = createContext<AuthContextValue | null>(null);
 
+// A throttled sign-in names the wait in its message; show a stable text that
+// can be translated instead.
+function loginError(error?: { code: string; message: string }): Error {
+  if (error?.code === "too_many_requests") {
+    return new Error("Too many attempts. Try again later.");
+  }
+  return new Error(error?.message ?? "Login failed");
+}
+
 export function AuthProvider({ children }: { children: ReactNode }) {
   const [user, setUser] = useState<User | null>(null);
   const [loading, setLoading] = useState(true);
@@ -53,18 +73,45 @@ export function AuthProvider({ children }: { children: ReactNode }) {
     void refreshUser();
   }, [refreshUser]);
 
-  const login = useCallback(async (userName: string, password: string) => {
-    const { data, error } = await usersApi.login({
-      user_name: userName,
-      password,
-    });
-    if (error || !data) {
-      throw new Error(error?.message ?? "Login failed");
-    }
-    setToken(data.token);
+  const startSession = useCallback((data: LoginResponse) => {
+    setSession(data);
     setUser(data.user);
   }, []);
 
+  const login = useCallback(
+    async (userName: string, password: string) => {
+      const { data, error } = await usersApi.login({
+        user_name: userName,
+        password,
+      });
+      if (error || !data) {
+        throw loginError(error);
+      }
+      if (usersApi.isTwoFactorChallenge(data)) {
+        return data;
+      }
+      startSession(data);
+      return null;
+    },
+    [startSession],
+  );
+
+  const completeTwoFactor = useCallback(
+    async (challengeToken: string, code: string, recoveryCode?: string) => {
+      const { data, error } = await usersApi.completeTwoFactorLogin(
+        challengeToken,
+        code,
+        recoveryCode,
+      );
+      if (error || !data) {
+        throw loginError(error);
+      }
+      startSession(data);
+      return data.recovery_codes ?? [];
+    },
+    [startSession],
+  );
+
   const logout = useCallback(async () => {
     await usersApi.logout();
     clearToken();
@@ -76,7 +123,16 @@ export function AuthProvider({ children }: { children: ReactNode }) {
 
   return (
     <AuthContext.Provider
-      value={{ user, loading, login, logout, refreshUser, isAdmin, isPlayer }}
+      value={{
+        user,
+        loading,
+        login,
+        completeTwoFactor,
+        logout,
+        refreshUser,
+        isAdmin,
+        isPlayer,
+      }}
     >
       {children}
     </AuthContext.Provider>
@@ -0,0 +1,185 @@
+import { useCallback, useEffect, useState } from "react";
+import * as usersApi from "../api/users";
+import type { APIToken, APITokenScope } from "../api/users";
+import { useI18n } from "../i18n/I18nContext";
+import { ActionButton, ErrorDisplay } from "./ErrorDisplay";
+import { SectionCount, formatDateTime, formatRelativeTime } from "./DetailInfo";
+
+const SCOPES: APITokenScope[] = [
+  "results:read",
+  "results:write",
+  "services:sync",
+  "games:export",
+];
+
+// APITokensSection lets the user issue personal API tokens for automation.
+// A new token is shown once; afterwards only its hint is listed.
+export default function APITokensSection() {
+  const { t } = useI18n();
+  const [tokens, setTokens] = useState<APIToken[]>([]);
+  const [name, setName] = useState("");
+  const [scopes, setScopes] = useState<APITokenScope[]>([]);
+  const [expiresOn, setExpiresOn] = useState("");
+  const [creating, setCreating] = useState(false);
+  const [created, setCreated] = useState<string | null>(null);
+  const [error, setError] = useState<{ message?: string } | null>(null);
+
+  const fetchTokens = useCallback(async () => {
+    const { data } = await usersApi.listProfileAPITokens();
+    if (data) setTokens(data.items);
+  }, []);
+
+  useEffect(() => {
+    void fetchTokens();
+  }, [fetchTokens]);
+
+  const toggleScope = (scope: APITokenScope) => {
+    setScopes((current) =>
+      current.includes(scope)
+        ? current.filter((s) => s !== scope)
+        : [...current, scope],
+    );
+  };
+
+  const handleCreate = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setCreating(true);
+    setError(null);
+    setCreated(null);
+    const { data, error: err } = await usersApi.createProfileAPIToken({
+      name,
+      scopes,
+      // The token stays valid through the whole chosen day.
+      expires_at: expiresOn
+        ? new Date(`${expiresOn}T23:59:59`).toISOString()
+        : undefined,
+    });
+    setCreating(false);
+    if (err) {
+      setError(err);
+      return;
+    }
+    if (data) {
+      setCreated(data.token);
+      setName("");
+      setScopes([]);
+      setExpiresOn("");
+      await fetchTokens();
+    }
+  };
+
+  const handleRevoke = async (id: number) => {
+    const { error: err } = await usersApi.revokeProfileAPIToken(id);
+    if (err) {
+      setError(err);
+      return;
+    }
+    await fetchTokens();
+  };
+
+  return (
+    <div className="detail-section">
+      <div className="section-head">
+        <h3>
+          {t("API tokens")} <SectionCount n={tokens.length} />
+        </h3>
+      </div>
+      <ErrorDisplay error={error} />
+      {created && (
+        <div className="success-message">
+          <p>{t("Copy the token now. It will not be shown again.")}</p>
+          <pre>{created}</pre>
+        </div>
+      )}
+      {tokens.length === 0 ? (
+        <p className="section-empty">{t("No API tokens.")}</p>
+      ) : (
+        <table className="data-table">
+          <thead>
+            <tr>
+              <th>{t("Name")}</th>
+              <th>{t("Token")}</th>
+              <th>{t("Scopes")}</th>
+              <th>{t("Last used")}</th>
+              <th>{t("Expires")}</th>
+              <th></th>
+            </tr>
+          </thead>
+          <tbody>
+            {tokens.map((token) => (
+              <tr key={token.id}>
+                <td>{token.name}</td>
+                <td>
+                  <code>{token.hint}…</code>
+                </td>
+                <td>{token.scopes.join(", ")}</td>
+                <td title={token.last_used_ip ?? ""}>
+                  {token.last_used_at
+                    ? formatRelativeTime(token.last_used_at)
+                    : t("Never")}
+                </td>
+                <td>
+                  {token.expires_at
+                    ? formatDateTime(token.expires_at)
+                    : t("Never")}
+                </td>
+                <td>
+                  <ActionButton
+                    onClick={() => handleRevoke(token.id)}
+                    variant="danger"
+                    confirm={t("Revoke this API token?")}
+                  >
+                    {t("Revoke")}
+                  </ActionButton>
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      )}
+      <form onSubmit={handleCreate}>
+        <div className="form-group">
+          <label>{t("Name")}</label>
+          <input
+            type="text"
+            value={name}
+            onChange={(e) => setName(e.target.value)}
+            maxLength={100}
+            required
+            disabled={creating}
+          />
+        </div>
+        <div className="form-group">
+          <label>{t("Scopes")}</label>
+          {SCOPES.map((scope) => (
+            <label key={scope}>
+              <input
+                type="checkbox"
+                checked={scopes.includes(scope)}
+                onChange={() => toggleScope(scope)}
+                disabled={creating}
+              />{" "}
+              <code>{scope}</code>
+            </label>
+          ))}
+        </div>
+        <div className="form-group">
+          <label>{t("Expires")}</label>
+          <input
+            type="date"
+            value={expiresOn}
+            onChange={(e) => setExpiresOn(e.target.value)}
+            disabled={creating}
+          />
+        </div>
+        <button
+          type="submit"
+          className="btn btn-primary"
+          disabled={creating || scopes.length === 0}
+        >
+          {creating ? t("Creating...") : t("Create token")}
+        </button>
+      </form>
+    </div>
+  );
+}
@@ -36,6 +36,23 @@ const ruCatalog: Record<string, TranslationValue> = {
   "Sign In": "Войти",
   "Signing in...": "Вход...",
   "Login failed": "Не удалось войти",
+  "Too many attempts. Try again later.":
+    "Слишком много попыток. Попробуйте позже.",
+  "rate limit exceeded, retry later":
+    "Слишком много запросов, попробуйте позже",
+  "Two-factor authentication": "Двухфакторная аутентификация",
+  "Authentication code": "Код аутентификации",
+  "Recovery code": "Код восстановления",
+  "Recovery codes": "Коды восстановления",
+  "Use a recovery code": "Ввести код восстановления",
+  "Use authenticator app": "Ввести код из приложения",
+  Verify: "Подтвердить",
+  Continue: "Продолжить",
+  "Secret key": "Секретный ключ",
+  "Your role requires two-factor authentication. Scan the QR code with an authenticator app, then enter the code it shows.":
+    "Для вашей роли требуется двухфакторная аутентификация. Отсканируйте QR-код приложением-аутентификатором и введите показанный код.",
+  "Save these codes somewhere safe. Each one signs you in once if you lose your authenticator.":
+    "Сохраните эти коды в надёжном месте. Каждый из них позволяет войти один раз, если вы потеряете аутентификатор.",
   Username: "Логин",
   Password: "Пароль",
   "New Password": "Новый пароль",
@@ -83,6 +100,39 @@ const ruCatalog: Record<string, TranslationValue> = {
   Expires: "Истекает",
   Revoke: "Отозвать",
   "Revoke this session?": "Отозвать эту сессию?",
+  "API tokens": "API-токены",
+  "No API tokens.": "API-токенов нет.",
+  Token: "Токен",
+  Scopes: "Права",
+  "Last used": "Последнее использование",
+  Never: "Никогда",
+  "Create token": "Создать токен",
+  "Revoke this API token?": "Отозвать этот API-токен?",
+  "Copy the token now. It will not be shown again.":
+    "Скопируйте токен сейчас. Больше он показан не будет.",
+  "Forgot password?": "Забыли пароль?",
+  "Reset password": "Сброс пароля",
+  "Username or email": "Имя пользователя или email",
+  "Enter your username or email. We will send a reset link to the account's verified email.":
+    "Введите имя пользователя или email. Мы отправим ссылку для сброса на подтверждённый адрес учётной записи.",
+  "Send reset link": "Отправить ссылку",
+  "Sending...": "Отправка...",
+  "If an account with a verified email matches, a reset link is on its way.":
+    "Если нашлась учётная запись с подтверждённым адресом, ссылка для сброса уже отправлена.",
+  "Set password": "Задать пароль",
+  "Your password has been changed. Sign in with the new one.":
+    "Пароль изменён. Войдите с новым паролем.",
+  "Email verification": "Подтверждение email",
+  "Your email address is verified.": "Адрес электронной почты подтверждён.",
+  "The link is invalid or has expired. Request a new one from your profile.":
+    "Ссылка недействительна или устарела. Запросите новую в профиле.",
+  "Email status": "Статус email",
+  Verified: "Подтверждён",
+  "Not verified": "Не подтверждён",
+  "Send verification link": "Отправить ссылку для подтверждения",
+  "Verification link sent. Check your inbox.":
+    "Ссылка для подтверждения отправлена. Проверьте почту.",
+  "is invalid or expired": "недействителен или устарел",
   "Delete this result?": "Удалить этот результат?",
   Retry: "Повторить",
   Prev: "Назад",
@@ -59,7 +59,7 @@ export default function GameDetailPage() {
   const { id } = useParams<{ id: string }>();
   const gameId = Number(id);
   const navigate = useNavigate();
-  const { user, isPlayer, isAdmin } = useAuth();
+  const { user, isAdmin } = useAuth();
 
   const [game, setGame] = useState<Game | null>(null);
   usePageTitle(game?.name);
@@ -455,7 +455,7 @@ export default function GameDetailPage() {
   if (loading) return <div className="loading">{t("Loading...")}</div>;
   if (!game) return <ErrorDisplay error={error} onRetry={fetchGame} />;
 
-  const canEdit = isPlayer;
+  const canEdit = isAdmin || game.viewer_game_role === "organizer";
   const canManageWriteups = isAdmin || manageableTeamIds.length > 0;
   const title = game.name ?? `${t("Game")} #${game.id}`;
 
@@ -1,17 +1,26 @@
 import { useState } from "react";
-import { useNavigate, useLocation } from "react-router-dom";
+import { Link, useNavigate, useLocation } from "react-router-dom";
 import { usePageTitle } from "../components/usePageTitle";
 import { useAuth } from "../auth/AuthContext";
 import { useI18n } from "../i18n/I18nContext";
+import * as usersApi from "../api/users";
+import type { TwoFactorChallenge, TwoFactorEnrollment } from "../api/users";
 
 export default function LoginPage() {
   const { t } = useI18n();
   usePageTitle(t("Login"));
   const [userName, setUserName] = useState("");
   const [password, setPassword] = useState("");
+  const [challenge, setChallenge] = useState<TwoFactorChallenge | null>(null);
+  const [enrollment, setEnrollment] = useState<TwoFactorEnrollment | null>(
+    null,
+  );
+  const [code, setCode] = useState("");
+  const [useRecoveryCode, setUseRecoveryCode] = useState(false);
+  const [recoveryCodes, setRecoveryCodes] = useState<string[]>([]);
   const [error, setError] = useState("");
   const [loading, setLoading] = useState(false);
-  const { login } = useAuth();
+  const { login, completeTwoFactor } = useAuth();
   const navigate = useNavigate();
   const location = useLocation();
   const from =
@@ -23,7 +32,42 @@ export default function LoginPage() {
     setError("");
     setLoading(true);
     try {
-      await login(userName, password);
+      const pending = await login(userName, password);
+      if (!pending) {
+        navigate(from, { replace: true });
+        return;
+      }
+      setChallenge(pending);
+      if (pending.enrollment_required) {
+        const { data, error: enrollError } =
+          await usersApi.startTwoFactorLoginEnrollment(pending.challenge_token);
+        if (enrollError || !data) {
+          throw new Error(enrollError?.message ?? "Login failed");
+        }
+        setEnrollment(data);
+      }
+    } catch (err) {
+      setError(err instanceof Error ? err.message : t("Login failed"));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const handleCodeSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!challenge) return;
+    setError("");
+    setLoading(true);
+    try {
+      const codes = await completeTwoFactor(
+        challenge.challenge_token,
+        useRecoveryCode ? "" : code,
+        useRecoveryCode ? code : undefined,
+      );
+      if (codes.length > 0) {
+        setRecoveryCodes(codes);
+        return;
+      }
       navigate(from, { replace: true });
     } catch (err) {
       setError(err instanceof Error ? err.message : t("Login failed"));
@@ -32,6 +76,110 @@ export default function LoginPage() {
     }
   };
 
+  const startOver = () => {
+    setChallenge(null);
+    setEnrollment(null);
+    setCode("");
+    setUseRecoveryCode(false);
+    setPassword("");
+    setError("");
+  };
+
+  if (recoveryCodes.length > 0) {
+    return (
+      <div className="login-page">
+        <div className="login-form">
+          <h1>CTF01D Training Platform</h1>
+          <h2>{t("Recovery codes")}</h2>
+          <p>
+            {t(
+              "Save these codes somewhere safe. Each one signs you in once if you lose your authenticator.",
+            )}
+          </p>
+          <pre>{recoveryCodes.join("\n")}</pre>
+          <button
+            type="button"
+            className="btn btn-primary"
+            onClick={() => navigate(from, { replace: true })}
+          >
+            {t("Continue")}
+          </button>
+        </div>
+      </div>
+    );
+  }
+
+  if (challenge) {
+    return (
+      <div className="login-page">
+        <form onSubmit={handleCodeSubmit} className="login-form">
+          <h1>CTF01D Training Platform</h1>
+          <h2>{t("Two-factor authentication")}</h2>
+          {error && <div className="error-display">{t(error)}</div>}
+          {enrollment && (
+            <>
+              <p>
+                {t(
+                  "Your role requires two-factor authentication. Scan the QR code with an authenticator app, then enter the code it shows.",
+                )}
+              </p>
+              <img
+                src={`data:image/png;base64,${enrollment.qr_code_png}`}
+                alt={enrollment.otpauth_uri}
+                width={200}
+                height={200}
+              />
+              <p>
+                {t("Secret key")}: <code>{enrollment.secret}</code>
+              </p>
+            </>
+          )}
+          <div className="form-group">
+            <label>
+              {useRecoveryCode ? t("Recovery code") : t("Authentication code")}
+            </label>
+            <input
+              type="text"
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              inputMode={useRecoveryCode ? "text" : "numeric"}
+              autoComplete="one-time-code"
+              required
+              autoFocus
+              disabled={loading}
+            />
+          </div>
+          <button type="submit" className="btn btn-primary" disabled={loading}>
+            {loading ? t("Signing in...") : t("Verify")}
+          </button>
+          {!enrollment && (
+            <button
+              type="button"
+              className="btn btn-secondary"
+              onClick={() => {
+                setUseRecoveryCode(!useRecoveryCode);
+                setCode("");
+              }}
+              disabled={loading}
+            >
+              {useRecoveryCode
+                ? t("Use authenticator app")
+                : t("Use a recovery code")}
+            </button>
+          )}
+          <button
+            type="button"
+            className="btn btn-secondary"
+            onClick={startOver}
+            disabled={loading}
+          >
+            {t("Back")}
+          </button>
+        </form>
+      </div>
+    );
+  }
+
   return (
     <div className="login-page">
       <form onSubmit={handleSubmit} className="login-form">
@@ -62,6 +210,9 @@ export default function LoginPage() {
         <button type="submit" className="btn btn-primary" disabled={loading}>
           {loading ? t("Signing in...") : t("Sign In")}
         </button>
+        <p className="section-hint">
+          <Link to="/reset-password">{t("Forgot password?")}</Link>
+        </p>
       </form>
     </div>
   );
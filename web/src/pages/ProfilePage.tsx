@@ -1,7 +1,11 @@
 import { useState, useCallback, useEffect, useRef } from "react";
 import * as usersApi from "../api/users";
 import type { User, UserSession } from "../api/users";
-import { ErrorDisplay, handleApiError } from "../components/ErrorDisplay";
+import {
+  ActionButton,
+  ErrorDisplay,
+  handleApiError,
+} from "../components/ErrorDisplay";
 import { usePageTitle } from "../components/usePageTitle";
 import { useAuth } from "../auth/AuthContext";
 import { useI18n } from "../i18n/I18nContext";
@@ -19,6 +23,7 @@ import {
   UserSessionsTable,
 } from "../components/UserProfileBlocks";
 import ThemeSection from "../components/ThemeSection";
+import APITokensSection from "../components/APITokensSection";
 import {
   emptyUserProfileForm,
   profileUpdateFromForm,
@@ -148,6 +153,17 @@ export default function ProfilePage() {
     setSuccess("Password updated successfully.");
   };
 
+  const handleSendVerification = async () => {
+    setError(null);
+    setSuccess(null);
+    const { error: err } = await usersApi.sendProfileEmailVerification();
+    if (err) {
+      setError(err);
+      return;
+    }
+    setSuccess("Verification link sent. Check your inbox.");
+  };
+
   const handleAvatarChange = async (e: React.ChangeEvent<HTMLInputElement>) => {
     const file = e.target.files?.[0];
     if (!file) return;
@@ -227,6 +243,20 @@ export default function ProfilePage() {
             <InfoRow label={t("Telegram")}>{user.telegram || "—"}</InfoRow>
             <InfoRow label={t("GitHub")}>{user.github || "—"}</InfoRow>
             <InfoRow label={t("Email")}>{user.email || "—"}</InfoRow>
+            {user.email && (
+              <InfoRow label={t("Email status")}>
+                {user.email_verified_at ? (
+                  t("Verified")
+                ) : (
+                  <>
+                    {t("Not verified")}{" "}
+                    <ActionButton onClick={handleSendVerification}>
+                      {t("Send verification link")}
+                    </ActionButton>
+                  </>
+                )}
+              </InfoRow>
+            )}
             <InfoRow label={t("Language")}>
               {languageLabel(user.language)}
             </InfoRow>
@@ -285,6 +315,8 @@ export default function ProfilePage() {
           <UserSessionsTable sessions={sessions} showExpires />
         )}
       </div>
+
+      <APITokensSection />
     </div>
   );
 }
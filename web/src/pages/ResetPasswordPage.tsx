@@ -0,0 +1,144 @@
+import { useState } from "react";
+import { Link, useSearchParams } from "react-router-dom";
+import { usePageTitle } from "../components/usePageTitle";
+import { useI18n } from "../i18n/I18nContext";
+import { ErrorDisplay } from "../components/ErrorDisplay";
+import * as usersApi from "../api/users";
+
+// ResetPasswordPage asks for a reset link and, opened from that link (with
+// ?token=), sets the new password.
+export default function ResetPasswordPage() {
+  const { t } = useI18n();
+  usePageTitle(t("Reset password"));
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get("token") ?? "";
+  const [login, setLogin] = useState("");
+  const [password, setPassword] = useState("");
+  const [confirm, setConfirm] = useState("");
+  const [error, setError] = useState<{ message?: string } | null>(null);
+  const [done, setDone] = useState(false);
+  const [loading, setLoading] = useState(false);
+
+  const handleRequest = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError(null);
+    setLoading(true);
+    const { error: err } = await usersApi.requestPasswordReset(login);
+    setLoading(false);
+    if (err) {
+      setError(err);
+      return;
+    }
+    setDone(true);
+  };
+
+  const handleReset = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (password !== confirm) {
+      setError({ message: "Passwords do not match." });
+      return;
+    }
+    setError(null);
+    setLoading(true);
+    const { error: err } = await usersApi.confirmPasswordReset(token, password);
+    setLoading(false);
+    if (err) {
+      setError(err);
+      return;
+    }
+    setDone(true);
+  };
+
+  if (done) {
+    return (
+      <div className="login-page">
+        <div className="login-form">
+          <h1>CTF01D Training Platform</h1>
+          <h2>{t("Reset password")}</h2>
+          <div className="success-message">
+            {token
+              ? t("Your password has been changed. Sign in with the new one.")
+              : t(
+                  "If an account with a verified email matches, a reset link is on its way.",
+                )}
+          </div>
+          <Link to="/login" className="btn btn-primary">
+            {t("Sign In")}
+          </Link>
+        </div>
+      </div>
+    );
+  }
+
+  if (token) {
+    return (
+      <div className="login-page">
+        <form onSubmit={handleReset} className="login-form">
+          <h1>CTF01D Training Platform</h1>
+          <h2>{t("Reset password")}</h2>
+          <ErrorDisplay error={error} />
+          <div className="form-group">
+            <label>{t("New Password")}</label>
+            <input
+              type="password"
+              value={password}
+              onChange={(e) => setPassword(e.target.value)}
+              minLength={6}
+              autoComplete="new-password"
+              required
+              autoFocus
+              disabled={loading}
+            />
+          </div>
+          <div className="form-group">
+            <label>{t("Confirm Password")}</label>
+            <input
+              type="password"
+              value={confirm}
+              onChange={(e) => setConfirm(e.target.value)}
+              minLength={6}
+              autoComplete="new-password"
+              required
+              disabled={loading}
+            />
+          </div>
+          <button type="submit" className="btn btn-primary" disabled={loading}>
+            {loading ? t("Saving...") : t("Set password")}
+          </button>
+        </form>
+      </div>
+    );
+  }
+
+  return (
+    <div className="login-page">
+      <form onSubmit={handleRequest} className="login-form">
+        <h1>CTF01D Training Platform</h1>
+        <h2>{t("Reset password")}</h2>
+        <p className="section-hint">
+          {t(
+            "Enter your username or email. We will send a reset link to the account's verified email.",
+          )}
+        </p>
+        <ErrorDisplay error={error} />
+        <div className="form-group">
+          <label>{t("Username or email")}</label>
+          <input
+            type="text"
+            value={login}
+            onChange={(e) => setLogin(e.target.value)}
+            required
+            autoFocus
+            disabled={loading}
+          />
+        </div>
+        <button type="submit" className="btn btn-primary" disabled={loading}>
+          {loading ? t("Sending...") : t("Send reset link")}
+        </button>
+        <Link to="/login" className="btn btn-secondary">
+          {t("Back")}
+        </Link>
+      </form>
+    </div>
+  );
+}
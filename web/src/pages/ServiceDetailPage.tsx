@@ -18,6 +18,7 @@ import {
   safeHref,
 } from "../components/DetailInfo";
 import { usePageTitle } from "../components/usePageTitle";
+import { authFetch } from "../api/auth";
 import { useAuth } from "../auth/AuthContext";
 import { useI18n } from "../i18n/I18nContext";
 
@@ -221,14 +222,9 @@ export default function ServiceDetailPage() {
         formData.append("service_archive", serviceArchiveFile);
       if (checkerArchiveFile)
         formData.append("checker_archive", checkerArchiveFile);
-      const token = localStorage.getItem("auth_token");
-      const response = await fetch(
+      const response = await authFetch(
         `/api/v1/services/${serviceId}/upload-archives`,
-        {
-          method: "POST",
-          headers: token ? { Authorization: `Bearer ${token}` } : {},
-          body: formData,
-        },
+        { method: "POST", body: formData },
       );
       if (!response.ok) {
         const body = await response.json();
@@ -718,7 +714,7 @@ export default function ServiceDetailPage() {
               <label>{t("Service Archive")}</label>
               <input
                 type="file"
-                accept=".zip"
+                accept=".zip,.tar,.tar.gz,.tgz"
                 onChange={(e) =>
                   setServiceArchiveFile(e.target.files?.[0] ?? null)
                 }
@@ -728,7 +724,7 @@ export default function ServiceDetailPage() {
               <label>{t("Checker Archive")}</label>
               <input
                 type="file"
-                accept=".zip"
+                accept=".zip,.tar,.tar.gz,.tgz"
                 onChange={(e) =>
                   setCheckerArchiveFile(e.target.files?.[0] ?? null)
                 }
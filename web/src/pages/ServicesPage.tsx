@@ -486,7 +486,7 @@ export default function ServicesPage() {
               <label>{t("ZIP Archive *")}</label>
               <input
                 type="file"
-                accept=".zip"
+                accept=".zip,.tar,.tar.gz,.tgz"
                 onChange={(e) => {
                   setZipFile(e.target.files?.[0] ?? null);
                   resetImportPreview();
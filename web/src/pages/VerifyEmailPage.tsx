@@ -0,0 +1,59 @@
+import { useEffect, useRef, useState } from "react";
+import { Link, useSearchParams } from "react-router-dom";
+import { usePageTitle } from "../components/usePageTitle";
+import { useI18n } from "../i18n/I18nContext";
+import * as usersApi from "../api/users";
+
+// VerifyEmailPage is opened from the verification email and redeems its
+// token once.
+export default function VerifyEmailPage() {
+  const { t } = useI18n();
+  usePageTitle(t("Email verification"));
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get("token") ?? "";
+  const [status, setStatus] = useState<"pending" | "verified" | "failed">(
+    "pending",
+  );
+  // The token is single-use: a second request (StrictMode re-runs effects)
+  // would report it as invalid.
+  const submitted = useRef(false);
+
+  useEffect(() => {
+    if (submitted.current) return;
+    submitted.current = true;
+    void usersApi.confirmEmailVerification(token).then(({ error: err }) => {
+      if (err) {
+        setStatus("failed");
+        return;
+      }
+      setStatus("verified");
+    });
+  }, [token]);
+
+  return (
+    <div className="login-page">
+      <div className="login-form">
+        <h1>CTF01D Training Platform</h1>
+        <h2>{t("Email verification")}</h2>
+        {status === "pending" && (
+          <div className="loading">{t("Loading...")}</div>
+        )}
+        {status === "verified" && (
+          <div className="success-message">
+            {t("Your email address is verified.")}
+          </div>
+        )}
+        {status === "failed" && (
+          <div className="error-display">
+            {t(
+              "The link is invalid or has expired. Request a new one from your profile.",
+            )}
+          </div>
+        )}
+        <Link to="/profile" className="btn btn-primary">
+          {t("Profile")}
+        </Link>
+      </div>
+    </div>
+  );
+}